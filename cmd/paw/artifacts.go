@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var artifactsCmd = &cobra.Command{
+	Use:   "artifacts [task]",
+	Short: "List the artifacts of a task",
+	Long: `List the reports and build outputs a task wrote to its artifacts/ directory.
+
+Artifacts of a running task are read from its agent directory; finished tasks'
+artifacts are saved to history (history/artifacts/) and listed newest first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		taskName := args[0]
+
+		found := false
+		activeDir := task.New(taskName, appCtx.GetAgentDir(taskName)).GetArtifactsDir()
+		files, err := service.ListTaskArtifacts(activeDir)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			found = true
+			fmt.Print(formatArtifactSet("Running task", activeDir, files))
+		}
+
+		sets, err := service.NewHistoryService(appCtx.GetHistoryDir()).ListArtifacts(taskName)
+		if err != nil {
+			return err
+		}
+		for _, set := range sets {
+			found = true
+			fmt.Print(formatArtifactSet("Saved "+strings.TrimSuffix(filepath.Base(set.Dir), "_"+taskName), set.Dir, set.Files))
+		}
+
+		if !found {
+			fmt.Fprintf(os.Stderr, "No artifacts found for task %s\n", taskName)
+		}
+		return nil
+	},
+}
+
+// formatArtifactSet renders a heading with the directory followed by its files.
+func formatArtifactSet(title, dir string, files []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%s):\n", title, dir)
+	for _, file := range files {
+		fmt.Fprintf(&sb, "  %s\n", file)
+	}
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/embed"
+	"github.com/dongho-jung/paw/internal/git"
+)
+
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Inspect PAW's embedded assets and their local replacements",
+}
+
+var assetsOverridesCmd = &cobra.Command{
+	Use:   "overrides",
+	Short: "List the files in .paw/overrides that replace embedded assets",
+	Long: `List the files in .paw/overrides that replace PAW's embedded prompt and help
+assets. A file replaces the asset with the same path, e.g. overrides/PROMPT.md
+(the system prompt in git projects), overrides/prompts/task-name.md, or
+overrides/templates/todo-triage.md. Files that match no asset are ignored and
+listed so typos are easy to spot.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, err := getAppFromProject(); err != nil {
+			return err
+		}
+		active, unknown, err := embed.ListOverrides()
+		if err != nil {
+			return err
+		}
+		if len(active) == 0 && len(unknown) == 0 {
+			fmt.Fprintln(os.Stderr, "No overrides (add files to .paw/overrides/ to replace embedded assets)")
+			return nil
+		}
+		for _, name := range active {
+			fmt.Printf("✓ %s\n", name)
+		}
+		for _, name := range unknown {
+			fmt.Printf("? %s: matches no embedded asset, ignored\n", name)
+		}
+		return nil
+	},
+}
+
+var assetsDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show where .paw/.claude differs from PAW's embedded files",
+	Long: `Compare the Claude files in .paw/.claude with the ones embedded in this PAW
+binary, with their .local overlays applied.
+
+PAW rewrites a file only when its content changed, and keeps files edited since
+PAW wrote them. To keep local changes and still get PAW's updates, move them to
+an overlay next to the file: CLAUDE.md.local is appended to CLAUDE.md, and the
+JSON object in settings.local.json.local is merged into settings.local.json.
+Remove an edited file to have it written again on the next start.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		claudeDir := filepath.Join(appCtx.PawDir, constants.ClaudeLink)
+		files, err := embed.DiffClaudeFiles(claudeDir)
+		if files == nil {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		diverged := 0
+		for _, f := range files {
+			switch f.State {
+			case embed.ClaudeFileCurrent:
+				fmt.Printf("✓ %s\n", f.Path)
+				continue
+			case embed.ClaudeFileMissing:
+				fmt.Printf("✗ %s: missing (written on the next start)\n", f.Path)
+			case embed.ClaudeFileOutdated:
+				fmt.Printf("~ %s: outdated (rewritten on the next start)\n", f.Path)
+			case embed.ClaudeFileEdited:
+				fmt.Printf("! %s: edited (kept; move your changes to %s%s)\n", f.Path, f.Path, embed.LocalOverlaySuffix)
+			}
+			diverged++
+			if f.Have == nil {
+				continue
+			}
+			diff, diffErr := diffContents(f.Path, f.Want, f.Have)
+			if diffErr != nil {
+				return diffErr
+			}
+			fmt.Print(diff)
+		}
+		if diverged == 0 {
+			fmt.Println("\nAll Claude files match this PAW version")
+		}
+		return nil
+	},
+}
+
+func init() {
+	assetsCmd.AddCommand(assetsDiffCmd)
+	assetsCmd.AddCommand(assetsOverridesCmd)
+}
+
+// diffContents returns a unified diff from PAW's content to the one on disk.
+func diffContents(name string, want, have []byte) (string, error) {
+	dir, err := os.MkdirTemp("", "paw-assets-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	wantPath := filepath.Join("paw", name)
+	havePath := filepath.Join(constants.ClaudeLink, name)
+	for path, data := range map[string][]byte{wantPath: want, havePath: have} {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+			return "", err
+		}
+		if err := os.WriteFile(full, data, 0600); err != nil {
+			return "", err
+		}
+	}
+
+	output, err := git.CombinedOutput(dir, "diff", "--no-index", "--no-prefix", "--no-color", wantPath, havePath)
+	var exitErr *exec.ExitError
+	if err != nil && (!errors.As(err, &exitErr) || exitErr.ExitCode() != 1) {
+		return "", fmt.Errorf("git diff failed: %w: %s", err, output)
+	}
+	return string(output), nil
+}
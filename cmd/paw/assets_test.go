@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffContents(t *testing.T) {
+	diff, err := diffContents("CLAUDE.md", []byte("rules\n"), []byte("rules\nmine\n"))
+	if err != nil {
+		t.Fatalf("diffContents() error = %v", err)
+	}
+	for _, want := range []string{"--- paw/CLAUDE.md\n", "+++ .claude/CLAUDE.md\n", "+mine\n"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diffContents() missing %q:\n%s", want, diff)
+		}
+	}
+
+	if diff, err := diffContents("CLAUDE.md", []byte("same\n"), []byte("same\n")); err != nil || diff != "" {
+		t.Errorf("diffContents() of equal contents = %q, %v", diff, err)
+	}
+}
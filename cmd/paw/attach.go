@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -33,7 +34,7 @@ Examples:
 // pawSession represents a running PAW session
 type pawSession struct {
 	Name       string
-	SocketPath string
+	SocketPath string // Empty for cooperative sessions on the default server
 }
 
 func runAttach(_ *cobra.Command, args []string) error {
@@ -80,7 +81,7 @@ func runAttach(_ *cobra.Command, args []string) error {
 				for _, s := range sessions {
 					fmt.Printf("  - %s\n", s.Name)
 				}
-				return errors.New("session not found")
+				return exitcode.Errorf(exitcode.SessionNotFound, "session not found")
 			}
 		}
 	case len(sessions) == 1:
@@ -95,6 +96,9 @@ func runAttach(_ *cobra.Command, args []string) error {
 			fmt.Printf("  %d. %s\n", i+1, s.Name)
 		}
 		fmt.Println()
+		if isNonInteractive() {
+			return errNeedsAnswer("Choosing the session to attach to", "pass the session name: paw attach <session>")
+		}
 		fmt.Print("Select session [1]: ")
 
 		var input string
@@ -151,7 +155,7 @@ func findPawSessions() []pawSession {
 			socketPath := filepath.Join(dir, name)
 
 			// Verify the session is actually running by checking if tmux responds
-			tm := tmux.New(sessionName)
+			tm := newTmuxClient(sessionName)
 			if tm.HasSession(sessionName) {
 				sessions = append(sessions, pawSession{
 					Name:       sessionName,
@@ -162,6 +166,17 @@ func findPawSessions() []pawSession {
 		}
 	}
 
+	// Cooperative sessions run on the default server
+	for _, sessionName := range tmux.CooperativeSessions() {
+		if seen[sessionName] {
+			continue
+		}
+		if newTmuxClient(sessionName).HasSession(sessionName) {
+			sessions = append(sessions, pawSession{Name: sessionName})
+			seen[sessionName] = true
+		}
+	}
+
 	// Sort by name for consistent ordering
 	sort.Slice(sessions, func(i, j int) bool {
 		return sessions[i].Name < sessions[j].Name
@@ -176,7 +191,8 @@ func attachToPawSession(session pawSession) error {
 	setAttachTerminalTitle("[paw] " + session.Name)
 
 	// Use tmux attach-session with the PAW socket
-	tm := tmux.New(session.Name)
+	tm := newTmuxClient(session.Name)
+	defer endCooperativeSession(tm, session.Name)
 	return tm.AttachSession(session.Name)
 }
 
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var (
+	auditSince  string
+	auditTask   string
+	auditFailed bool
+	auditLimit  int
+	auditJSON   bool
+
+	auditAutoAnswers bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show git operations performed by PAW",
+	Long: `Show the audit log of every git command PAW ran for the current project
+(arguments, directory, exit code, duration), oldest first.
+
+Useful for tracking down merge mishaps: filter by task, time, or failures.
+
+With --auto-answers, show the prompts answered by auto_answers rules instead.`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "Show entries since time (duration like 2h or timestamp)")
+	auditCmd.Flags().StringVar(&auditTask, "task", "", "Filter entries by task name")
+	auditCmd.Flags().BoolVar(&auditFailed, "failed", false, "Show only failed commands")
+	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 0, "Show only the last N entries (0 = all)")
+	auditCmd.Flags().BoolVar(&auditJSON, "json", false, "Output entries as JSON lines")
+	auditCmd.Flags().BoolVar(&auditAutoAnswers, "auto-answers", false, "Show auto-answers given to tasks instead of git operations")
+}
+
+func runAudit(_ *cobra.Command, _ []string) error {
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+
+	sinceTime, err := parseSince(auditSince)
+	if err != nil {
+		return err
+	}
+
+	if auditAutoAnswers {
+		return runAuditAutoAnswers(appCtx, sinceTime)
+	}
+
+	entries, err := git.ReadAuditLog(appCtx.GetAuditLogPath())
+	if err != nil {
+		return err
+	}
+
+	entries = filterAuditEntries(entries, sinceTime, auditTask, auditFailed)
+	if auditLimit > 0 && len(entries) > auditLimit {
+		entries = entries[len(entries)-auditLimit:]
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No git operations recorded")
+		return nil
+	}
+
+	for _, e := range entries {
+		if auditJSON {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Println(formatAuditEntry(e))
+	}
+	return nil
+}
+
+// filterAuditEntries keeps entries matching the time, task, and failure filters.
+func filterAuditEntries(entries []git.AuditEntry, since time.Time, taskName string, failedOnly bool) []git.AuditEntry {
+	var filtered []git.AuditEntry
+	for _, e := range entries {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if taskName != "" && e.Task != taskName {
+			continue
+		}
+		if failedOnly && e.ExitCode == 0 {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// formatAuditEntry renders an entry as a single human-readable line.
+func formatAuditEntry(e git.AuditEntry) string {
+	status := "✓"
+	if e.ExitCode != 0 {
+		status = fmt.Sprintf("✗ %d", e.ExitCode)
+	}
+
+	source := e.Script
+	if e.Task != "" {
+		source += "/" + e.Task
+	}
+
+	line := fmt.Sprintf("%s  %-5s %6dms  [%s]  git %s",
+		e.Time.Local().Format("2006-01-02 15:04:05"), status, e.DurationMS, source, strings.Join(e.Args, " "))
+	if e.Dir != "" {
+		line += "  (in " + e.Dir + ")"
+	}
+	if e.Error != "" {
+		line += "\n    " + strings.ReplaceAll(strings.TrimSpace(e.Error), "\n", "\n    ")
+	}
+	return line
+}
+
+// runAuditAutoAnswers prints the auto-answers recorded for the project's tasks.
+func runAuditAutoAnswers(appCtx *app.App, since time.Time) error {
+	entries, err := os.ReadDir(appCtx.AgentsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var records []service.AutoAnswerRecord
+	for _, entry := range entries {
+		if !entry.IsDir() || (auditTask != "" && entry.Name() != auditTask) {
+			continue
+		}
+		t := task.New(entry.Name(), filepath.Join(appCtx.AgentsDir, entry.Name()))
+		taskRecords, err := service.LoadAutoAnswers(t.GetAutoAnswersPath())
+		if err != nil {
+			return err
+		}
+		for _, rec := range taskRecords {
+			if since.IsZero() || !rec.Time.Before(since) {
+				records = append(records, rec)
+			}
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	if auditLimit > 0 && len(records) > auditLimit {
+		records = records[len(records)-auditLimit:]
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(os.Stderr, "No auto-answers recorded")
+		return nil
+	}
+
+	for _, rec := range records {
+		if auditJSON {
+			data, err := json.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Printf("%s  [%s]  %q → %q  (rule: %s)\n",
+			rec.Time.Local().Format("2006-01-02 15:04:05"), rec.Task, rec.Matched, rec.Response, rec.Rule)
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// autopilotOptionKey holds the pid of the session's autopilot runner.
+const autopilotOptionKey = "@paw_autopilot"
+
+var (
+	autopilotManifest string
+	autopilotParallel int
+	autopilotDryRun   bool
+	autopilotStop     bool
+)
+
+var autopilotCmd = &cobra.Command{
+	Use:   "autopilot",
+	Short: "Run a manifest of tasks unattended and report what merged",
+	Long: `Queue the tasks of a work manifest and run them unattended (e.g. overnight)
+in the running PAW session, a few at a time. Each task finishes with the
+manifest's on_complete action once its agent is done, and the project's verify
+commands (build, lint, test) must pass before it merges, whatever
+verify_before_push says. diff_checks, security_scanners, self_eval, and
+reviewer_model apply as configured.
+
+A task that stops without merging (failed verification, a held merge, a
+question, or task_timeout) is reported blocked and kept for you; tasks that
+depend on it are skipped. Without --manifest, prints the latest run's report,
+which is also saved to .paw/autopilot-report.md when the run ends.
+
+Manifest (YAML):
+  parallel: 2               # Tasks at a time (default 1, at most 8)
+  on_complete: merge        # merge (default), merge-push, or pr
+  model: sonnet             # Default model
+  task_timeout: 3h          # Running longer is reported blocked (0 = none)
+  tasks:
+    - name: fix-login-timeout
+      prompt: Fix the login timeout in auth/session.go
+    - name: document-timeout
+      depends_on: fix-login-timeout   # Starts once it merged
+      prompt: |
+        Document the new timeout setting in docs/config.md.
+
+Examples:
+  paw autopilot --manifest plan.yaml
+  paw autopilot --manifest plan.yaml --dry-run
+  paw autopilot              # The latest run's report
+  paw autopilot --stop       # Start no more tasks`,
+	Args: cobra.NoArgs,
+	RunE: runAutopilot,
+}
+
+func runAutopilot(_ *cobra.Command, _ []string) error {
+	if autopilotManifest == "" {
+		return showAutopilotRun()
+	}
+
+	data, err := os.ReadFile(autopilotManifest) //nolint:gosec // G304: manifest path is from user args
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m, err := service.ParseAutopilotManifest(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", autopilotManifest, err)
+	}
+	if autopilotParallel != 0 {
+		if autopilotParallel < 1 || autopilotParallel > constants.AutopilotMaxParallel {
+			return fmt.Errorf("--parallel must be 1-%d", constants.AutopilotMaxParallel)
+		}
+		m.Parallel = autopilotParallel
+	}
+
+	if autopilotDryRun {
+		fmt.Printf("%d tasks, %d at a time, on_complete %s\n", len(m.Tasks), m.Parallel, m.OnComplete)
+		for _, t := range m.Tasks {
+			line := "  " + t.Name
+			if t.DependsOn != "" {
+				line += " (after " + t.DependsOn + ")"
+			}
+			summary, _, _ := strings.Cut(strings.TrimSpace(t.Prompt), "\n")
+			fmt.Printf("%s: %s\n", line, summary)
+		}
+		return nil
+	}
+
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+	if !appCtx.IsGitRepo {
+		return errors.New("autopilot merges tasks, so it needs a git repository")
+	}
+	if appCtx.Config == nil || len(appCtx.Config.Commands.VerifyEntries()) == 0 {
+		return errors.New("autopilot verifies every task before it merges: set the build, lint, or test command in .paw/config (or run 'paw setup')")
+	}
+	tm := newTmuxClient(appCtx.SessionName)
+	if !tm.HasSession(appCtx.SessionName) {
+		return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+	}
+	if run, err := service.LoadAutopilotRun(appCtx.PawDir); err != nil {
+		return err
+	} else if run != nil && run.FinishedAt == "" {
+		return fmt.Errorf("autopilot run %s has not finished (see 'paw autopilot'; --stop starts no more of its tasks)", run.ID)
+	}
+
+	manifestPath, _ := filepath.Abs(autopilotManifest)
+	run := service.NewAutopilotRun(m, manifestPath, time.Now())
+	if err := service.SaveAutopilotRun(appCtx.PawDir, run); err != nil {
+		return err
+	}
+	_ = os.Remove(filepath.Join(appCtx.PawDir, constants.AutopilotReportFileName))
+	startAutopilotRunner(appCtx, tm, getPawBin())
+
+	fmt.Printf("🌙 Autopilot run %s: %d tasks, %d at a time, on_complete %s\n", run.ID, len(run.Items), run.Parallel, run.OnComplete)
+	fmt.Println("   'paw autopilot' shows its progress; the report is saved when it ends")
+	return nil
+}
+
+// showAutopilotRun prints the latest run's report, or stops it with --stop.
+func showAutopilotRun() error {
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+	run, err := service.LoadAutopilotRun(appCtx.PawDir)
+	if err != nil {
+		return err
+	}
+	if run == nil {
+		fmt.Println("No autopilot runs (start one with 'paw autopilot --manifest plan.yaml')")
+		return nil
+	}
+
+	if autopilotStop {
+		if run.FinishedAt != "" {
+			fmt.Printf("Autopilot run %s already finished\n", run.ID)
+			return nil
+		}
+		stopped := 0
+		now := time.Now()
+		for i := range run.Items {
+			if run.Items[i].State == service.AutopilotQueued {
+				run.Finish(i, service.AutopilotSkipped, "autopilot stopped", "", now)
+				stopped++
+			}
+		}
+		if err := service.SaveAutopilotRun(appCtx.PawDir, run); err != nil {
+			return err
+		}
+		fmt.Printf("⏹️  Autopilot run %s: %d queued tasks will not start; running tasks finish as usual\n", run.ID, stopped)
+		return nil
+	}
+
+	fmt.Print(service.FormatAutopilotReport(run))
+	return nil
+}
+
+// startAutopilotRunner starts the session's autopilot runner when a run has
+// not finished, unless it is already running.
+func startAutopilotRunner(appCtx *app.App, tm tmux.Client, pawBin string) {
+	run, err := service.LoadAutopilotRun(appCtx.PawDir)
+	if err != nil || run == nil || run.FinishedAt != "" {
+		return
+	}
+	if sessionProcessRunning(tm, appCtx.SessionName, autopilotOptionKey) {
+		return
+	}
+
+	runnerCmd := exec.Command(pawBin, "internal", "autopilot-run", appCtx.SessionName) //nolint:gosec // G204: pawBin is from getPawBin()
+	runnerCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := runnerCmd.Start(); err != nil {
+		logging.Warn("Failed to start autopilot: %v", err)
+	}
+}
+
+var autopilotRunCmd = &cobra.Command{
+	Use:    "autopilot-run [session]",
+	Short:  "Run the autopilot run's tasks while the session runs",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "autopilot", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		pid := strconv.Itoa(os.Getpid())
+		setOrUnsetOption(tm, autopilotOptionKey, pid)
+
+		for {
+			run, err := service.LoadAutopilotRun(appCtx.PawDir)
+			if err != nil {
+				logging.Warn("autopilot: %v", err)
+				return err
+			}
+			if run == nil || run.FinishedAt != "" {
+				return nil
+			}
+
+			advanceAutopilotRun(appCtx, run)
+			if run.Done() {
+				finishAutopilotRun(appCtx, tm, run)
+				return nil
+			}
+			if err := service.SaveAutopilotRun(appCtx.PawDir, run); err != nil {
+				logging.Warn("autopilot: %v", err)
+			}
+
+			time.Sleep(constants.AutopilotPollInterval)
+			// Stop with the session (the next session resumes the run), or when another runner took over
+			if !tm.HasSession(sessionName) || sessionOption(tm, sessionName, autopilotOptionKey) != pid {
+				logging.Debug("autopilot: session gone or runner replaced, exiting")
+				return nil
+			}
+		}
+	},
+}
+
+// advanceAutopilotRun records the outcome of the run's finished tasks and
+// starts the queued tasks that may start.
+func advanceAutopilotRun(appCtx *app.App, run *service.AutopilotRun) {
+	gitClient := git.New()
+	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+	now := time.Now()
+
+	for i := range run.Items {
+		it := &run.Items[i]
+		if it.State != service.AutopilotRunning {
+			continue
+		}
+		if state, reason, commit := checkAutopilotTask(appCtx, gitClient, mainBranch, run, it, now); state != service.AutopilotRunning {
+			run.Finish(i, state, reason, commit, now)
+			logging.Log("autopilot: %s %s %s", it.Task, state, reason)
+		}
+	}
+
+	for _, i := range run.Startable(now) {
+		it := &run.Items[i]
+		taskName, err := startAutopilotTask(appCtx, run, it)
+		if err != nil {
+			logging.Warn("autopilot: failed to start %s: %v", it.Name, err)
+			run.Finish(i, service.AutopilotBlocked, "failed to start: "+err.Error(), "", now)
+			continue
+		}
+		run.Start(i, taskName, now)
+		logging.Log("autopilot: started %s as %s", it.Name, taskName)
+	}
+}
+
+// startAutopilotTask creates and starts a manifest task, finishing with the
+// run's on_complete action.
+func startAutopilotTask(appCtx *app.App, run *service.AutopilotRun, it *service.AutopilotItem) (string, error) {
+	opts := &config.TaskOptions{
+		Model:      config.Model(it.Model),
+		BranchName: it.Name,
+		OnComplete: run.OnComplete,
+		Autopilot:  run.ID,
+		Labels:     []string{"autopilot"},
+	}
+	newTask, err := spawnTask(appCtx, it.Prompt, opts)
+	if err != nil {
+		return "", err
+	}
+	return newTask.Name, nil
+}
+
+// checkAutopilotTask returns where a running task stands: still running, or
+// its outcome with a reason and, once merged, the merge commit.
+func checkAutopilotTask(appCtx *app.App, gitClient git.Client, mainBranch string, run *service.AutopilotRun, it *service.AutopilotItem, now time.Time) (service.AutopilotState, string, string) {
+	agentDir := appCtx.GetAgentDir(it.Task)
+	if _, err := os.Stat(agentDir); err != nil {
+		return autopilotTaskOutcome(appCtx, gitClient, mainBranch, it.Task)
+	}
+
+	t := task.New(it.Task, agentDir)
+	if reason := t.ReviewReason(); reason != "" {
+		return service.AutopilotBlocked, reason, ""
+	}
+	if t.HasPR() {
+		prNumber, _ := t.LoadPRNumber()
+		return service.AutopilotFinished, fmt.Sprintf("PR #%d opened", prNumber), ""
+	}
+	if started, err := time.Parse(time.RFC3339, it.StartedAt); err == nil && run.TaskTimeout() > 0 && now.Sub(started) > run.TaskTimeout() {
+		return service.AutopilotBlocked, fmt.Sprintf("still running after %s", run.TaskTimeout()), ""
+	}
+
+	status, _ := t.LoadStatus()
+	switch status { //nolint:exhaustive // Pending and working tasks are still running
+	case task.StatusCorrupted:
+		return service.AutopilotBlocked, "task is corrupted", ""
+	case task.StatusDone, task.StatusWaiting:
+		// An idle task normally finishes within seconds; one that stays idle
+		// was held (failed verification, merge conflict) or asked a question
+		if it.StalledSince == "" {
+			it.StalledSince = now.Format(time.RFC3339)
+			return service.AutopilotRunning, "", ""
+		}
+		since, err := time.Parse(time.RFC3339, it.StalledSince)
+		if err != nil || now.Sub(since) < constants.AutopilotStallTimeout {
+			return service.AutopilotRunning, "", ""
+		}
+		if rec := service.LoadFailureRecord(t.GetFailurePath()); rec != nil {
+			return service.AutopilotBlocked, strings.TrimSpace(fmt.Sprintf("%s %s", strings.ReplaceAll(string(rec.Kind), "_", " "), rec.Detail)), ""
+		}
+		if status == task.StatusWaiting {
+			return service.AutopilotBlocked, "waiting for input", ""
+		}
+		return service.AutopilotBlocked, "done but not merged (see the task window)", ""
+	default:
+		it.StalledSince = ""
+	}
+	return service.AutopilotRunning, "", ""
+}
+
+// autopilotTaskOutcome returns how a task that is no longer active ended.
+func autopilotTaskOutcome(appCtx *app.App, gitClient git.Client, mainBranch, taskName string) (service.AutopilotState, string, string) {
+	if commit, err := gitClient.FindTaskMergeCommit(appCtx.ProjectDir, taskName, mainBranch); err == nil && commit != "" {
+		return service.AutopilotMerged, "", commit
+	}
+
+	historyFiles, err := service.NewHistoryService(appCtx.GetHistoryDir()).ListHistoryFiles()
+	if err == nil {
+		for _, file := range historyFiles {
+			if service.ExtractTaskName(file) != taskName {
+				continue
+			}
+			if service.IsCancelled(file) {
+				return service.AutopilotDropped, "cancelled", ""
+			}
+			return service.AutopilotFinished, "finished without merging", ""
+		}
+	}
+	return service.AutopilotDropped, "task was removed", ""
+}
+
+// finishAutopilotRun saves the run's report and tells the user it ended.
+func finishAutopilotRun(appCtx *app.App, tm tmux.Client, run *service.AutopilotRun) {
+	run.FinishedAt = time.Now().Format(time.RFC3339)
+	if err := service.SaveAutopilotRun(appCtx.PawDir, run); err != nil {
+		logging.Warn("autopilot: %v", err)
+	}
+	reportPath := filepath.Join(appCtx.PawDir, constants.AutopilotReportFileName)
+	if err := os.WriteFile(reportPath, []byte(service.FormatAutopilotReport(run)), 0644); err != nil { //nolint:gosec // G306: report is for the user to read
+		logging.Warn("autopilot: failed to write report: %v", err)
+	}
+
+	summary := fmt.Sprintf("%d merged, %d blocked", run.Count(service.AutopilotMerged), run.Count(service.AutopilotBlocked))
+	if n := run.Count(service.AutopilotFinished); n > 0 {
+		summary += fmt.Sprintf(", %d finished without merging", n)
+	}
+	if n := run.Count(service.AutopilotSkipped) + run.Count(service.AutopilotDropped); n > 0 {
+		summary += fmt.Sprintf(", %d not done", n)
+	}
+	logging.Log("autopilot: run %s finished: %s", run.ID, summary)
+	_ = notify.Send("Autopilot finished", fmt.Sprintf("🌅 %s: %s (paw autopilot)", appCtx.GetDisplayName(), summary))
+	_ = tm.DisplayMessage("🌅 Autopilot finished: "+summary, constants.DisplayMsgImportant)
+}
+
+func init() {
+	autopilotCmd.Flags().StringVarP(&autopilotManifest, "manifest", "m", "", "Work manifest (YAML) to run")
+	autopilotCmd.Flags().IntVar(&autopilotParallel, "parallel", 0, "Tasks at a time (overrides the manifest)")
+	autopilotCmd.Flags().BoolVar(&autopilotDryRun, "dry-run", false, "Check the manifest and print its tasks without starting them")
+	autopilotCmd.Flags().BoolVar(&autopilotStop, "stop", false, "Start no more of the current run's tasks")
+}
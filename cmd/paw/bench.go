@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/bench"
+)
+
+var (
+	benchTasks      []int
+	benchIterations int
+)
+
+var benchCmd = &cobra.Command{
+	Use:    "bench",
+	Short:  "Measure discovery, Kanban render, and recovery time with fake tasks",
+	Hidden: true,
+	Long: `Simulate projects with many tasks and windows (no tmux or Claude needed)
+and print how long task discovery, Kanban rendering, and the recovery scans take.
+
+Use it to compare builds when changing the TUI or the recovery code:
+  paw bench --tasks 10,100,500 --iterations 20`,
+	RunE: runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntSliceVar(&benchTasks, "tasks", []int{10, 100, 500}, "Numbers of simulated tasks")
+	benchCmd.Flags().IntVar(&benchIterations, "iterations", 20, "Iterations per measurement")
+}
+
+func runBench(_ *cobra.Command, _ []string) error {
+	if benchIterations <= 0 {
+		return errors.New("--iterations must be positive")
+	}
+
+	fmt.Printf("%-8s %-15s %12s\n", "TASKS", "OPERATION", "PER OP")
+	for _, tasks := range benchTasks {
+		if tasks <= 0 {
+			return fmt.Errorf("invalid task count: %d", tasks)
+		}
+		results, err := bench.Run(tasks, benchIterations)
+		if err != nil {
+			return fmt.Errorf("benchmark with %d tasks failed: %w", tasks, err)
+		}
+		for _, r := range results {
+			fmt.Printf("%-8d %-15s %12v\n", tasks, r.Name, r.PerOp())
+		}
+	}
+	return nil
+}
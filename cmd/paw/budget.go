@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+var (
+	budgetOverride      bool
+	budgetClearOverride bool
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Show today's token usage against the daily budget",
+	Long: `Show the tokens the project's agents used today, per task, against
+daily_token_budget. Once the budget is used up, new tasks are queued until
+the next day; --override lets them start for the rest of today.
+
+Examples:
+  paw budget                   # Show today's usage
+  paw budget --override        # Let queued tasks start today
+  paw budget --clear-override  # Enforce the budget again`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppForSetup()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		switch {
+		case budgetOverride && budgetClearOverride:
+			return fmt.Errorf("--override and --clear-override cannot be used together")
+		case budgetOverride:
+			if err := service.SetBudgetOverride(budgetOverridePath(appCtx), now); err != nil {
+				return fmt.Errorf("failed to override budget: %w", err)
+			}
+			fmt.Println("✅ Daily token budget overridden for the rest of today")
+		case budgetClearOverride:
+			if err := service.ClearBudgetOverride(budgetOverridePath(appCtx)); err != nil {
+				return fmt.Errorf("failed to clear override: %w", err)
+			}
+			fmt.Println("✅ Daily token budget enforced again")
+		}
+
+		report, err := loadProjectUsage(appCtx, now)
+		if err != nil {
+			return err
+		}
+		printUsageReport(appCtx, report, now)
+		return nil
+	},
+}
+
+func init() {
+	budgetCmd.Flags().BoolVar(&budgetOverride, "override", false, "Let new tasks start for the rest of today despite the budget")
+	budgetCmd.Flags().BoolVar(&budgetClearOverride, "clear-override", false, "Enforce the budget again after --override")
+}
+
+func budgetOverridePath(appCtx *app.App) string {
+	return filepath.Join(appCtx.PawDir, constants.BudgetOverrideFile)
+}
+
+// loadProjectUsage reads the project's token usage for now's day from the
+// Claude Code transcripts.
+func loadProjectUsage(appCtx *app.App, now time.Time) (*service.UsageReport, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find home directory: %w", err)
+	}
+	report, err := service.LoadDailyUsage(filepath.Join(home, constants.ClaudeProjectsDir), appCtx.ProjectDir, appCtx.AgentsDir, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token usage: %w", err)
+	}
+	return report, nil
+}
+
+func printUsageReport(appCtx *app.App, report *service.UsageReport, now time.Time) {
+	used := report.Total.Budgeted()
+	budget := int64(appCtx.Config.DailyTokenBudget)
+
+	fmt.Printf("Token usage on %s: %s", report.Day.Format("2006-01-02"), service.FormatTokens(used))
+	if budget > 0 {
+		fmt.Printf(" / %s (%d%%)", service.FormatTokens(budget), used*100/budget)
+	}
+	fmt.Printf("  (+%s cache reads)\n", service.FormatTokens(report.Total.CacheRead))
+
+	names := make([]string, 0, len(report.ByTask))
+	for name := range report.ByTask {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return report.ByTask[names[i]].Budgeted() > report.ByTask[names[j]].Budgeted()
+	})
+	for _, name := range names {
+		label := name
+		if label == "" {
+			label = "(project)"
+		}
+		fmt.Printf("  %-32s %8s\n", label, service.FormatTokens(report.ByTask[name].Budgeted()))
+	}
+
+	switch {
+	case budget == 0:
+		fmt.Println("No daily_token_budget set")
+	case service.BudgetOverridden(budgetOverridePath(appCtx), now):
+		fmt.Println("⚠️  Budget overridden for today")
+	case used >= budget:
+		fmt.Println("💸 Budget used up: new tasks are queued until tomorrow (paw budget --override)")
+	}
+}
+
+// tokenBudgetExhausted reports whether the daily token budget is used up and
+// not overridden, with the tokens used so far.
+func tokenBudgetExhausted(appCtx *app.App, now time.Time) (bool, int64) {
+	budget := int64(appCtx.Config.DailyTokenBudget)
+	if budget <= 0 || service.BudgetOverridden(budgetOverridePath(appCtx), now) {
+		return false, 0
+	}
+	report, err := loadProjectUsage(appCtx, now)
+	if err != nil {
+		logging.Warn("tokenBudgetExhausted: %v", err)
+		return false, 0
+	}
+	used := report.Total.Budgeted()
+	return used >= budget, used
+}
+
+// waitForTokenBudget queues a new task while the daily token budget is used
+// up, until the next day or an override.
+func waitForTokenBudget(appCtx *app.App, tm tmux.Client, windowID string, t *task.Task) {
+	exhausted, used := tokenBudgetExhausted(appCtx, time.Now())
+	if !exhausted {
+		return
+	}
+
+	budget := service.FormatTokens(int64(appCtx.Config.DailyTokenBudget))
+	logging.Info("Task %s queued: daily token budget used (%d/%d)", t.Name, used, appCtx.Config.DailyTokenBudget)
+	waitName := windowNameForStatus(t.Name, task.StatusWaiting)
+	_ = renameWindowWithStatus(tm, windowID, waitName, appCtx.PawDir, t.Name, "token-budget", task.StatusWaiting)
+	msg := fmt.Sprintf("💸 %s queued: daily token budget used (%s/%s); run 'paw budget --override' to start it today",
+		t.Name, service.FormatTokens(used), budget)
+	_ = tm.DisplayMessage(msg, 3000)
+	_ = notify.Send("Task queued", msg)
+
+	for {
+		time.Sleep(constants.TokenBudgetPollInterval)
+		if exhausted, _ := tokenBudgetExhausted(appCtx, time.Now()); !exhausted {
+			break
+		}
+	}
+
+	logging.Info("Task %s leaving the token budget queue", t.Name)
+	workingName := windowNameForStatus(t.Name, task.StatusWorking)
+	_ = renameWindowWithStatus(tm, windowID, workingName, appCtx.PawDir, t.Name, "token-budget", task.StatusWorking)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// recordChangelog adds a task's changelog entry to the merge commit just made
+// on the main branch in the project directory, so the entry lands (and is
+// undone) with the task's changes.
+func recordChangelog(appCtx *app.App, taskName string, gitClient git.Client) {
+	cfg := appCtx.Config
+	if cfg == nil || cfg.Changelog == "" {
+		return
+	}
+	projectDir := appCtx.ProjectDir
+	message, err := gitClient.GetCommitMessage(projectDir, "HEAD")
+	if err != nil || git.ParseTaskTrailer(message) != taskName {
+		// The branch had nothing new to merge
+		logging.Debug("recordChangelog: HEAD is not the merge of %s; skipping", taskName)
+		return
+	}
+
+	template := cfg.ChangelogTemplate
+	if template == "" {
+		template = constants.DefaultChangelogTemplate
+	}
+	entry := service.ParseChangelogEntry(message, taskName, time.Now())
+	content := entry.Render(template)
+
+	var path string
+	switch cfg.Changelog {
+	case constants.ChangelogFragment:
+		path, err = service.WriteChangelogFragment(filepath.Join(projectDir, constants.ChangelogFragmentDir), entry, content)
+	case constants.ChangelogAppend:
+		path = filepath.Join(projectDir, constants.ChangelogFileName)
+		err = service.AppendChangelog(path, content)
+	}
+	if err != nil {
+		logging.Warn("recordChangelog: %v", err)
+		fmt.Printf("  ⚠️  Failed to write changelog entry: %v\n", err)
+		return
+	}
+
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		rel = path
+	}
+	if err := gitClient.Add(projectDir, rel); err != nil {
+		logging.Warn("recordChangelog: failed to stage %s: %v", rel, err)
+		fmt.Printf("  ⚠️  Changelog entry written to %s but not committed: %v\n", rel, err)
+		return
+	}
+	if err := gitClient.CommitAmend(projectDir); err != nil {
+		logging.Warn("recordChangelog: failed to amend merge commit: %v", err)
+		fmt.Printf("  ⚠️  Changelog entry staged in %s but not committed: %v\n", rel, err)
+		return
+	}
+	logging.Log("recordChangelog: task=%s entry added to %s", taskName, rel)
+	fmt.Printf("  ✓ Changelog entry added to %s\n", rel)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/notify"
 )
 
@@ -123,6 +124,7 @@ func collectCheckResults() []checkResult {
 		checkClaude(),
 		checkGit(),
 		checkGh(),
+		checkProxy(),
 	}
 
 	// macOS-specific checks
@@ -200,7 +202,7 @@ func checkClaude() checkResult {
 func checkGit() checkResult {
 	result := checkResult{name: "git", required: false}
 
-	output, err := exec.Command("git", "--version").Output()
+	output, err := git.CombinedOutput("", "--version")
 	if err != nil {
 		result.ok = false
 		result.message = "not installed - needed for worktree mode"
@@ -13,7 +13,6 @@ import (
 	"github.com/dongho-jung/paw/internal/embed"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/task"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 func collectProjectResults() []checkResult {
@@ -168,12 +167,39 @@ func projectChecks(appCtx *app.App) []checkResult {
 		}
 	}
 
+	results = append(results, policyChecks()...)
 	results = append(results, worktreeChecks(appCtx)...)
 	results = append(results, sessionChecks(appCtx)...)
 
 	return results
 }
 
+// policyChecks reports the organization policy, if there is one.
+func policyChecks() []checkResult {
+	policy, err := config.LoadPolicy()
+	if err != nil {
+		return []checkResult{{
+			name:     "org policy",
+			ok:       false,
+			required: true,
+			message:  err.Error(),
+		}}
+	}
+	if policy == nil {
+		return nil
+	}
+	rules := "no rules"
+	if r := policy.Rules(); len(r) > 0 {
+		rules = strings.Join(r, ", ")
+	}
+	return []checkResult{{
+		name:     "org policy",
+		ok:       true,
+		required: false,
+		message:  fmt.Sprintf("%s (%s)", policy.Path, rules),
+	}}
+}
+
 func worktreeChecks(appCtx *app.App) []checkResult {
 	if !appCtx.IsGitRepo || appCtx.Config == nil {
 		return nil
@@ -218,7 +244,7 @@ func sessionChecks(appCtx *app.App) []checkResult {
 		return nil
 	}
 
-	tm := tmux.New(appCtx.SessionName)
+	tm := newTmuxClient(appCtx.SessionName)
 	if !tm.HasSession(appCtx.SessionName) {
 		return []checkResult{
 			{
@@ -272,7 +298,7 @@ func sessionChecks(appCtx *app.App) []checkResult {
 	msg := fmt.Sprintf("orphaned=%d stopped=%d incomplete=%d", len(orphaned), len(stopped), len(incomplete))
 	ok := len(orphaned) == 0 && len(stopped) == 0 && len(incomplete) == 0
 
-	return []checkResult{
+	results := []checkResult{
 		{
 			name:     "tmux session",
 			ok:       ok,
@@ -280,6 +306,17 @@ func sessionChecks(appCtx *app.App) []checkResult {
 			message:  msg,
 		},
 	}
+
+	sessionEnv := proxyEnv(envEntries(readSessionEnv(tm, appCtx.SessionName)))
+	if drift := sessionProxyDrift(proxyEnv(os.Environ()), sessionEnv); len(drift) > 0 {
+		results = append(results, checkResult{
+			name:     "session proxy env",
+			ok:       false,
+			required: false,
+			message:  fmt.Sprintf("session differs from this shell: %s (run paw to sync, then restart agents)", strings.Join(drift, ", ")),
+		})
+	}
+	return results
 }
 
 func pathExists(path string) bool {
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	cleanDryRun     bool
+	cleanYes        bool
+	cleanWorktrees  bool
+	cleanBranches   bool
+	cleanHistory    bool
+	cleanLogs       bool
+	cleanKeepConfig bool
+)
+
+// cleanKeepConfigFiles are the .paw entries kept by --keep-config.
+var cleanKeepConfigFiles = map[string]bool{
+	constants.ConfigFileName:       true,
+	constants.PromptFileName:       true,
+	constants.PromptsDirName:       true,
+	constants.OverridesDirName:     true,
+	constants.ProjectPathFileName:  true,
+	constants.ReadinessFileName:    true,
+	constants.TemplateScheduleFile: true,
+	constants.BudgetOverrideFile:   true,
+	service.TemplateFile:           true,
+	service.InputHistoryFile:       true,
+}
+
+func init() {
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without removing anything")
+	cleanCmd.Flags().BoolVarP(&cleanYes, "yes", "y", false, "Skip the interactive preview")
+	cleanCmd.Flags().BoolVar(&cleanWorktrees, "worktrees", false, "Remove only task worktrees (with their task directories)")
+	cleanCmd.Flags().BoolVar(&cleanBranches, "branches", false, "Remove only task branches not checked out in a worktree")
+	cleanCmd.Flags().BoolVar(&cleanHistory, "history", false, "Remove only the task history")
+	cleanCmd.Flags().BoolVar(&cleanLogs, "logs", false, "Remove only the logs and git audit log")
+	cleanCmd.Flags().BoolVar(&cleanKeepConfig, "keep-config", false, "Keep config, PROMPT.md, prompts, overrides, readiness checks, input templates and schedules, the budget override, and input history when removing the .paw directory")
+}
+
+// cleanKinds returns the resource kinds selected by the flags.
+// Without --worktrees, --branches, --history, or --logs every kind is selected.
+func cleanKinds() map[service.CleanKind]bool {
+	if !cleanWorktrees && !cleanBranches && !cleanHistory && !cleanLogs {
+		return map[service.CleanKind]bool{
+			service.CleanWindow:   true,
+			service.CleanWorktree: true,
+			service.CleanBranch:   true,
+			service.CleanHistory:  true,
+			service.CleanLogs:     true,
+			service.CleanPawDir:   true,
+		}
+	}
+	return map[service.CleanKind]bool{
+		service.CleanWorktree: cleanWorktrees,
+		service.CleanBranch:   cleanBranches,
+		service.CleanHistory:  cleanHistory,
+		service.CleanLogs:     cleanLogs,
+	}
+}
+
+// runClean removes PAW resources of the current project after an interactive preview
+func runClean(_ *cobra.Command, _ []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// If in git repo, use repo root as project directory
+	gitClient := git.New()
+	isGitRepo := gitClient.IsGitRepo(cwd)
+	projectDir := cwd
+	if isGitRepo {
+		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
+			projectDir = repoRoot
+		}
+	}
+
+	// Use NewWithGitInfo to correctly resolve global workspace path
+	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
+	if err != nil {
+		return err
+	}
+
+	// Set subdirectory context for correct session name (e.g., finops-treemap)
+	if isGitRepo {
+		application.SetSubdirectoryContext(cwd, projectDir)
+	}
+
+	if err := application.LoadConfig(); err != nil {
+		// Config might not exist, continue anyway
+		application.Config = config.DefaultConfig()
+	}
+
+	tm := newTmuxClient(application.SessionName)
+
+	var mgr *task.Manager
+	if application.IsGitRepo {
+		mgr = task.NewManager(application.AgentsDir, application.ProjectDir, application.PawDir, application.IsGitRepo, application.Config)
+
+		// Prune stale worktree entries first to prevent git errors
+		mgr.PruneWorktrees()
+	}
+
+	plan, tasks := buildCleanPlan(application, tm, gitClient, mgr, cleanKinds())
+	if len(plan.Items) == 0 {
+		fmt.Println("Nothing to clean.")
+		return nil
+	}
+
+	if cleanDryRun {
+		fmt.Println("Would remove:")
+		printCleanPlan(plan)
+		return nil
+	}
+
+	selected := plan
+	if !cleanYes {
+		if !canPrompt() {
+			fmt.Println("Would remove:")
+			printCleanPlan(plan)
+			return errors.New("no terminal for the preview (or running non-interactively); run with --yes to clean without it")
+		}
+		selected, err = tui.RunCleanPreview(plan)
+		if err != nil {
+			return err
+		}
+		if selected == nil {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Println("Cleaning up PAW resources...")
+	killSession := len(selected.OfKind(service.CleanWindow)) == len(plan.OfKind(service.CleanWindow))
+	executeCleanPlan(application, tm, mgr, tasks, selected, killSession)
+
+	fmt.Println("Done!")
+	return nil
+}
+
+// buildCleanPlan lists the project's PAW resources of the given kinds that exist,
+// grouped by kind. Also returns the project's tasks by name.
+func buildCleanPlan(application *app.App, tm tmux.Client, gitClient git.Client, mgr *task.Manager, kinds map[service.CleanKind]bool) (*service.CleanPlan, map[string]*task.Task) {
+	plan := &service.CleanPlan{}
+	tasks := make(map[string]*task.Task)
+
+	if kinds[service.CleanWindow] && tm.HasSession(application.SessionName) {
+		windows, _ := tm.ListWindows()
+		for _, w := range windows {
+			plan.Add(service.CleanWindow, w.Name, w.ID)
+		}
+	}
+
+	var taskList []*task.Task
+	if mgr != nil {
+		taskList, _ = mgr.ListTasks()
+	}
+	hasWorktree := make(map[string]bool)
+	for _, t := range taskList {
+		tasks[t.Name] = t
+		if _, err := os.Stat(t.GetWorktreeDir()); err == nil {
+			hasWorktree[t.Name] = true
+			if kinds[service.CleanWorktree] {
+				plan.Add(service.CleanWorktree, t.Name, t.GetWorktreeDir())
+			}
+		}
+	}
+	if kinds[service.CleanBranch] {
+		for _, t := range taskList {
+			// A branch checked out in a kept worktree can't be deleted
+			if hasWorktree[t.Name] && !kinds[service.CleanWorktree] {
+				continue
+			}
+			if gitClient.BranchExists(application.ProjectDir, t.Name) {
+				plan.Add(service.CleanBranch, t.Name, t.Name)
+			}
+		}
+	}
+
+	if kinds[service.CleanHistory] {
+		if _, err := os.Stat(application.GetHistoryDir()); err == nil {
+			plan.Add(service.CleanHistory, constants.HistoryDirName, application.GetHistoryDir())
+		}
+	}
+	if kinds[service.CleanLogs] {
+		for _, path := range []string{application.GetLogPath(), application.GetAuditLogPath()} {
+			if matches := cleanLogFiles(path); len(matches) > 0 {
+				plan.Add(service.CleanLogs, filepath.Base(path), path)
+			}
+		}
+	}
+
+	if kinds[service.CleanPawDir] {
+		if _, err := os.Stat(application.PawDir); err == nil {
+			name := application.PawDir
+			if cleanKeepConfig {
+				name = "everything except config"
+			}
+			plan.Add(service.CleanPawDir, name, application.PawDir)
+		}
+	}
+
+	return plan, tasks
+}
+
+// printCleanPlan prints the plan's items grouped by kind.
+func printCleanPlan(plan *service.CleanPlan) {
+	for _, kind := range []service.CleanKind{service.CleanWindow, service.CleanWorktree, service.CleanBranch, service.CleanHistory, service.CleanLogs, service.CleanPawDir} {
+		items := plan.OfKind(kind)
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", kind.Title())
+		for _, item := range items {
+			if item.Target != item.Name {
+				fmt.Printf("  - %s (%s)\n", item.Name, item.Target)
+			} else {
+				fmt.Printf("  - %s\n", item.Name)
+			}
+		}
+	}
+}
+
+// executeCleanPlan removes the resources in the plan. The whole session is
+// killed only if every window was selected.
+func executeCleanPlan(application *app.App, tm tmux.Client, mgr *task.Manager, tasks map[string]*task.Task, plan *service.CleanPlan, killSession bool) {
+	if windows := plan.OfKind(service.CleanWindow); len(windows) > 0 {
+		if killSession {
+			fmt.Println("Killing tmux session...")
+			_ = tm.KillSession(application.SessionName)
+			endCooperativeSession(tm, application.SessionName)
+		} else {
+			for _, w := range windows {
+				fmt.Printf("Killing window: %s\n", w.Name)
+				_ = tm.KillWindow(w.Target)
+			}
+		}
+	}
+
+	if mgr != nil {
+		for _, item := range plan.OfKind(service.CleanWorktree) {
+			t := tasks[item.Name]
+			fmt.Printf("Removing worktree: %s\n", t.Name)
+			mgr.RemoveWorktree(t)
+			_ = t.Remove()
+		}
+		for _, item := range plan.OfKind(service.CleanBranch) {
+			fmt.Printf("Deleting branch: %s\n", item.Name)
+			mgr.DeleteBranch(tasks[item.Name])
+		}
+		mgr.InvalidateTruncatedNameCache()
+	}
+
+	if len(plan.OfKind(service.CleanHistory)) > 0 {
+		fmt.Println("Removing history...")
+		_ = os.RemoveAll(application.GetHistoryDir())
+	}
+	for _, item := range plan.OfKind(service.CleanLogs) {
+		fmt.Printf("Removing log: %s\n", item.Name)
+		for _, path := range cleanLogFiles(item.Target) {
+			_ = os.Remove(path)
+		}
+	}
+
+	if len(plan.OfKind(service.CleanPawDir)) > 0 {
+		if cleanKeepConfig {
+			fmt.Println("Removing .paw directory (keeping config)...")
+			removePawDirExceptConfig(application.PawDir)
+		} else {
+			fmt.Println("Removing .paw directory...")
+			_ = os.RemoveAll(application.PawDir)
+		}
+	}
+}
+
+// cleanLogFiles returns a log file and its rotated backups that exist.
+func cleanLogFiles(path string) []string {
+	var files []string
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+	backups, _ := filepath.Glob(path + ".[0-9]*")
+	return append(files, backups...)
+}
+
+// removePawDirExceptConfig removes everything in the .paw directory except the config files.
+func removePawDirExceptConfig(pawDir string) {
+	entries, err := os.ReadDir(pawDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if cleanKeepConfigFiles[entry.Name()] || strings.HasPrefix(entry.Name(), service.InputHistoryFile+"-") {
+			continue
+		}
+		_ = os.RemoveAll(filepath.Join(pawDir, entry.Name()))
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestRemovePawDirExceptConfig(t *testing.T) {
+	pawDir := t.TempDir()
+	kept := []string{
+		constants.ConfigFileName,
+		constants.PromptFileName,
+		constants.PromptsDirName,
+		constants.OverridesDirName,
+		constants.ProjectPathFileName,
+		constants.ReadinessFileName,
+		constants.TemplateScheduleFile,
+		constants.BudgetOverrideFile,
+		service.TemplateFile,
+		service.InputHistoryFile,
+		service.InputHistoryFile + "-alice",
+	}
+	removed := []string{
+		constants.AgentsDirName,
+		constants.HistoryDirName,
+		constants.LogFileName,
+		"window-map.json",
+	}
+	for _, name := range append(append([]string{}, kept...), removed...) {
+		if err := os.WriteFile(filepath.Join(pawDir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removePawDirExceptConfig(pawDir)
+
+	entries, err := os.ReadDir(pawDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	sort.Strings(got)
+	sort.Strings(kept)
+	if !reflect.DeepEqual(got, kept) {
+		t.Errorf("entries after removePawDirExceptConfig() = %v, want %v", got, kept)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the project configuration",
+}
+
+var configPresetCmd = &cobra.Command{
+	Use:   "preset [name]",
+	Short: "Apply a configuration preset (solo-yolo, team-safe, ci-strict)",
+	Long: `Apply a named preset that sets on_complete, verify_before_push,
+skip_permissions, approval_commands, and failure_retries together.
+Commands, notification channels, hooks, and other settings are kept.
+
+Without a name, lists the presets.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			for _, p := range config.Presets() {
+				fmt.Printf("  %-10s %s\n", p.Name, p.Description)
+			}
+			return nil
+		}
+
+		preset, ok := config.FindPreset(args[0])
+		if !ok {
+			return fmt.Errorf("unknown preset %q (run 'paw config preset' to list presets)", args[0])
+		}
+
+		appCtx, err := getAppForSetup()
+		if err != nil {
+			return err
+		}
+		cfg := appCtx.Config.Clone()
+		preset.Apply(cfg)
+		if err := cfg.Save(appCtx.PawDir); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Applied preset %s to %s\n", preset.Name, filepath.Join(appCtx.PawDir, constants.ConfigFileName))
+		fmt.Printf("  on_complete: %s, verify_before_push: %t, skip_permissions: %t\n",
+			cfg.OnComplete, cfg.VerifyBeforePush, cfg.SkipPermissions)
+		if cfg.VerifyBeforePush && len(cfg.Commands.VerifyEntries()) == 0 {
+			fmt.Println("  Note: no build/test/lint commands are configured yet; run 'paw setup' to add them.")
+		}
+		for _, note := range appCtx.Policy.Apply(cfg.Clone()) {
+			fmt.Printf("  Policy: %s\n", note)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPresetCmd)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// reportCoverage measures the task's coverage against the commit it forked
+// from with the coverage command, prints the delta, and saves it to the
+// task's artifacts for the PR description. The base is measured in a
+// temporary worktree and cached by commit.
+func reportCoverage(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client) {
+	if appCtx.Config == nil || appCtx.Config.Commands.Coverage == "" {
+		return
+	}
+	command := appCtx.Config.Commands.Coverage
+	base, err := gitClient.MergeBase(workDir, gitClient.GetMainBranch(workDir), "HEAD")
+	if err != nil {
+		logging.Warn("reportCoverage: failed to find the merge base: %v", err)
+		return
+	}
+	head, err := gitClient.GetHeadCommit(workDir)
+	if err != nil {
+		logging.Warn("reportCoverage: failed to read HEAD: %v", err)
+		return
+	}
+
+	spinner := tui.NewSimpleSpinner("Measuring coverage")
+	spinner.Start()
+
+	env := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
+	cache := service.LoadCoverageCache(filepath.Join(appCtx.PawDir, constants.CoverageCacheFileName))
+	// measure runs the command in dir, or in a temporary worktree at commit
+	// if dir is empty
+	measure := func(commit, dir string) (float64, error) {
+		if percent, ok := cache.Get(commit, command); ok {
+			return percent, nil
+		}
+		if dir == "" {
+			tmpDir, err := os.MkdirTemp("", "paw-coverage-")
+			if err != nil {
+				return 0, err
+			}
+			defer func() { _ = os.RemoveAll(tmpDir) }()
+			if err := gitClient.WorktreeAdd(workDir, tmpDir, commit, false); err != nil {
+				return 0, fmt.Errorf("failed to check out %s: %w", commit, err)
+			}
+			defer func() {
+				if err := gitClient.WorktreeRemove(workDir, tmpDir, true); err != nil {
+					logging.Debug("reportCoverage: failed to remove worktree: %v", err)
+				}
+			}()
+			dir = tmpDir
+		}
+		percent, err := service.MeasureCoverage(command, dir, env, constants.DefaultVerifyTimeout)
+		if err != nil {
+			return 0, err
+		}
+		if err := cache.Put(commit, command, percent); err != nil {
+			logging.Warn("reportCoverage: failed to cache coverage: %v", err)
+		}
+		return percent, nil
+	}
+
+	var delta service.CoverageDelta
+	delta.Task, err = measure(head, workDir)
+	if err == nil {
+		delta.Base, err = measure(base, "")
+	}
+	if err != nil {
+		spinner.Stop(false, "failed")
+		logging.Warn("reportCoverage: %v", err)
+		fmt.Printf("  ⚠️  Coverage not measured: %v\n", err)
+		return
+	}
+	spinner.Stop(true, delta.String())
+	logging.Log("reportCoverage: task=%s coverage %s", targetTask.Name, delta)
+
+	artifactsDir := targetTask.GetArtifactsDir()
+	if err := os.MkdirAll(artifactsDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		logging.Warn("reportCoverage: failed to create artifacts dir: %v", err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(artifactsDir, constants.CoverageReportName), []byte(delta.String()+"\n"), 0644); err != nil { //nolint:gosec // G306: artifacts are not secret
+		logging.Warn("reportCoverage: failed to save coverage: %v", err)
+	}
+}
+
+// loadCoverageReport returns the coverage delta saved by reportCoverage, or "".
+func loadCoverageReport(t *task.Task) string {
+	data, err := os.ReadFile(filepath.Join(t.GetArtifactsDir(), constants.CoverageReportName)) //nolint:gosec // G304: path is in the task's artifacts
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
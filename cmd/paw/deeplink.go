@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+)
+
+const (
+	// urlHandlerAppName is the AppleScript applet registered for paw:// links.
+	urlHandlerAppName = "PAW Links.app"
+	// urlHandlerBundleID identifies the applet to Launch Services.
+	urlHandlerBundleID = "io.github.dongho-jung.paw.links"
+
+	plistBuddyPath = "/usr/libexec/PlistBuddy"
+	lsregisterPath = "/System/Library/Frameworks/CoreServices.framework/Frameworks/LaunchServices.framework/Support/lsregister"
+)
+
+var urlHandlerCmd = &cobra.Command{
+	Use:   "url-handler",
+	Short: "Manage the paw:// link handler (macOS)",
+	Long: `Register a handler for paw://project/task links so clicking a task
+notification (with terminal-notifier or ntfy) or a dashboard link focuses the
+task's tmux window.
+
+Examples:
+  paw url-handler install     # Register paw:// links
+  paw url-handler uninstall   # Remove the handler`,
+}
+
+var urlHandlerInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Register the paw:// link handler",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if runtime.GOOS != "darwin" {
+			return errors.New("the paw:// handler can only be registered on macOS")
+		}
+
+		appPath, err := urlHandlerAppPath()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(appPath), 0755); err != nil { //nolint:gosec // G301: ~/Applications uses standard permissions
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(appPath), err)
+		}
+		_ = os.RemoveAll(appPath)
+
+		// Launch Services runs applets with a minimal PATH; keep the current one
+		// so focus-task can find tmux.
+		script := urlHandlerScript(getPawBin(), os.Getenv("PATH"))
+		if out, err := exec.Command("osacompile", "-o", appPath, "-e", script).CombinedOutput(); err != nil { //nolint:gosec // G204: arguments are constructed by PAW
+			return fmt.Errorf("osacompile failed: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+
+		plist := filepath.Join(appPath, "Contents", "Info.plist")
+		for _, entry := range urlHandlerPlistEntries() {
+			// Set fails for missing keys and Add fails for existing ones; try both.
+			if exec.Command(plistBuddyPath, "-c", "Set "+entry, plist).Run() == nil { //nolint:gosec // G204: entries are constants
+				continue
+			}
+			if out, err := exec.Command(plistBuddyPath, "-c", "Add "+entry, plist).CombinedOutput(); err != nil { //nolint:gosec // G204: entries are constants
+				return fmt.Errorf("failed to update Info.plist (%s): %w: %s", entry, err, strings.TrimSpace(string(out)))
+			}
+		}
+
+		if out, err := exec.Command(lsregisterPath, "-f", appPath).CombinedOutput(); err != nil { //nolint:gosec // G204: appPath is under the user's home
+			return fmt.Errorf("failed to register %s: %w: %s", appPath, err, strings.TrimSpace(string(out)))
+		}
+
+		fmt.Printf("✅ Registered paw:// links (%s)\n", appPath)
+		fmt.Println("   Try: open " + notify.TaskLink("myproject", "my-task"))
+		if _, err := exec.LookPath("terminal-notifier"); err != nil {
+			fmt.Println("   Install terminal-notifier (brew install terminal-notifier) to open tasks from notification clicks.")
+		}
+		return nil
+	},
+}
+
+var urlHandlerUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the paw:// link handler",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appPath, err := urlHandlerAppPath()
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(appPath); os.IsNotExist(err) {
+			fmt.Println("paw:// handler is not installed")
+			return nil
+		}
+		if runtime.GOOS == "darwin" {
+			_ = exec.Command(lsregisterPath, "-u", appPath).Run() //nolint:gosec // G204: appPath is under the user's home
+		}
+		if err := os.RemoveAll(appPath); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", appPath, err)
+		}
+		fmt.Println("✅ Removed paw:// handler")
+		return nil
+	},
+}
+
+var focusTaskCmd = &cobra.Command{
+	Use:   "focus-task [paw://project/task | session task]",
+	Short: "Focus a task window from a paw:// link",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName, taskName, err := focusTaskTarget(args)
+		if err != nil {
+			return err
+		}
+
+		if appCtx, err := getAppFromSession(sessionName); err == nil {
+			_, cleanup := setupLoggerFromApp(appCtx, "focus-task", taskName)
+			defer cleanup()
+		}
+		logging.Debug("-> focusTaskCmd(session=%s, task=%s)", sessionName, taskName)
+		defer logging.Debug("<- focusTaskCmd")
+
+		running := false
+		for _, s := range findPawSessions() {
+			if s.Name == sessionName {
+				running = true
+				break
+			}
+		}
+		if !running {
+			return exitcode.Errorf(exitcode.SessionNotFound, "project %q has no running PAW session (start it with 'paw' in the project)", sessionName)
+		}
+
+		tm := newTmuxClient(sessionName)
+		if taskName != "" {
+			windows, err := tm.ListWindows()
+			if err != nil {
+				return err
+			}
+			windowID := ""
+			for _, w := range windows {
+				if token, ok := constants.ExtractTaskName(w.Name); ok && constants.MatchesWindowToken(token, taskName) {
+					windowID = w.ID
+					break
+				}
+			}
+			if windowID == "" {
+				_ = tm.DisplayMessage("Task not found: "+taskName, constants.DisplayMsgStandard)
+				return fmt.Errorf("task %q has no window in %s", taskName, sessionName)
+			}
+			if err := tm.SelectWindow(windowID); err != nil {
+				return err
+			}
+		}
+
+		if clients, err := tm.RunWithOutput("list-clients", "-t", sessionName, "-F", "#{client_tty}"); err != nil || strings.TrimSpace(clients) == "" {
+			fmt.Printf("No terminal is attached to %s; run 'paw attach %s'\n", sessionName, sessionName)
+		}
+		return nil
+	},
+}
+
+func init() {
+	urlHandlerCmd.AddCommand(urlHandlerInstallCmd)
+	urlHandlerCmd.AddCommand(urlHandlerUninstallCmd)
+}
+
+// focusTaskTarget resolves focus-task arguments: either a single paw:// link
+// or an explicit session and task name.
+func focusTaskTarget(args []string) (string, string, error) {
+	if len(args) == 2 {
+		return args[0], args[1], nil
+	}
+	return notify.ParseTaskLink(args[0])
+}
+
+// urlHandlerAppPath returns where the link handler applet is installed.
+func urlHandlerAppPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Applications", urlHandlerAppName), nil
+}
+
+// urlHandlerScript builds the AppleScript that forwards opened links to focus-task.
+func urlHandlerScript(pawBin, path string) string {
+	command := "PATH=" + shellQuote(path) + " " + shellQuote(pawBin) + " internal focus-task "
+	return "on open location theURL\n" +
+		"\tdo shell script " + appleScriptString(command) + " & quoted form of theURL\n" +
+		"end open location"
+}
+
+// urlHandlerPlistEntries are the PlistBuddy entries that claim the paw:// scheme.
+func urlHandlerPlistEntries() []string {
+	return []string{
+		":CFBundleIdentifier string " + urlHandlerBundleID,
+		":LSUIElement bool true",
+		":CFBundleURLTypes array",
+		":CFBundleURLTypes:0 dict",
+		":CFBundleURLTypes:0:CFBundleURLName string PAW task link",
+		":CFBundleURLTypes:0:CFBundleURLSchemes array",
+		":CFBundleURLTypes:0:CFBundleURLSchemes:0 string " + notify.DeepLinkScheme,
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
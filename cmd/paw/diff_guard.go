@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// diffOverLimit returns why a worktree's diff is too large to merge
+// automatically, or "" if it is within auto_merge_max_files/lines.
+func diffOverLimit(gitClient git.Client, cfg *config.Config, worktreeDir string) string {
+	if cfg.AutoMergeMaxFiles == 0 && cfg.AutoMergeMaxLines == 0 {
+		return ""
+	}
+	files, lines, err := gitClient.DiffSize(worktreeDir, gitClient.GetMainBranch(worktreeDir))
+	if err != nil {
+		logging.Debug("diffOverLimit: failed to measure diff: %v", err)
+		return ""
+	}
+	if cfg.AutoMergeMaxFiles > 0 && files > cfg.AutoMergeMaxFiles {
+		return fmt.Sprintf("%d files changed (auto_merge_max_files %d)", files, cfg.AutoMergeMaxFiles)
+	}
+	if cfg.AutoMergeMaxLines > 0 && lines > cfg.AutoMergeMaxLines {
+		return fmt.Sprintf("%d lines changed (auto_merge_max_lines %d)", lines, cfg.AutoMergeMaxLines)
+	}
+	return ""
+}
+
+// holdLargeDiff checks a task about to be merged automatically with action.
+// A diff over the limits flags the task for review and returns true: the
+// caller must leave it in confirm mode. Tasks back within the limits lose
+// their flag.
+func holdLargeDiff(cfg *config.Config, pawDir, taskName, action string) bool {
+	if !config.IsMergeAction(action) {
+		return false
+	}
+	t := task.New(taskName, filepath.Join(pawDir, constants.AgentsDirName, taskName))
+	worktreeDir := t.GetWorktreeDir()
+	if !pathExists(worktreeDir) {
+		return false
+	}
+
+	reason := diffOverLimit(git.New(), cfg, worktreeDir)
+	if reason == "" {
+		if err := t.ClearReviewReason(); err != nil {
+			logging.Warn("holdLargeDiff: failed to clear review flag: %v", err)
+		}
+		return false
+	}
+
+	logging.Info("holdLargeDiff: task=%s held for review instead of %s: %s", taskName, action, reason)
+	if err := t.SetReviewReason(reason); err != nil {
+		logging.Warn("holdLargeDiff: failed to flag task for review: %v", err)
+	}
+	_ = notify.Send("Review required", fmt.Sprintf("🔍 %s was not merged automatically: %s", taskName, reason))
+	return true
+}
+
+// maxShownViolations limits the diff check violations printed in the pane.
+const maxShownViolations = 20
+
+// checkDiffPolicies runs the diff_checks rules on the task's changes since it
+// forked from the main branch. Returns false (keeping the task open) if any
+// check fails; the violations are listed in the end-task pane.
+func checkDiffPolicies(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
+	if appCtx.Config == nil || len(appCtx.Config.DiffChecks) == 0 {
+		return true
+	}
+	checks, errs := service.ParseDiffChecks(appCtx.Config.DiffChecks)
+	for _, err := range errs {
+		logging.Warn("checkDiffPolicies: %v", err)
+		fmt.Printf("  ⚠️  Ignoring %v\n", err)
+	}
+	if len(checks) == 0 {
+		return true
+	}
+	base, err := gitClient.MergeBase(workDir, gitClient.GetMainBranch(workDir), "HEAD")
+	if err != nil {
+		logging.Warn("checkDiffPolicies: failed to find the merge base: %v", err)
+		return true
+	}
+
+	spinner := tui.NewSimpleSpinner("Checking diff policies")
+	spinner.Start()
+
+	var violations []service.DiffViolation
+	if patch, err := gitClient.DiffPatch(workDir, base); err != nil {
+		logging.Warn("checkDiffPolicies: failed to read diff: %v", err)
+	} else {
+		violations = service.CheckAddedLines(checks, service.ParseAddedLines(patch))
+	}
+	files, err := gitClient.ChangedFiles(workDir, base)
+	if err != nil {
+		logging.Warn("checkDiffPolicies: failed to list changed files: %v", err)
+	}
+	env := append(appCtx.GetEnvVars(targetTask.Name, workDir, windowID),
+		"PAW_DIFF_BASE="+base,
+		"PAW_CHANGED_FILES="+strings.Join(files, "\n"),
+	)
+	for _, check := range checks {
+		if check.Command != "" {
+			violations = append(violations, service.RunDiffCheckCommand(check, workDir, env, constants.DefaultVerifyTimeout)...)
+		}
+	}
+
+	if len(violations) == 0 {
+		spinner.Stop(true, fmt.Sprintf("%d checks", len(checks)))
+		return true
+	}
+	spinner.Stop(false, fmt.Sprintf("%d violations", len(violations)))
+	logging.Warn("checkDiffPolicies: task=%s violations=%d", targetTask.Name, len(violations))
+
+	fmt.Println()
+	fmt.Println("  ✗ Diff checks failed; not merging")
+	for i, v := range violations {
+		if i == maxShownViolations {
+			fmt.Printf("    … and %d more\n", len(violations)-maxShownViolations)
+			break
+		}
+		fmt.Printf("    %s\n", v)
+	}
+	keepTaskWaiting(appCtx, targetTask, windowID, tm, "Diff checks failed")
+	return false
+}
+
+// keepTaskWaiting marks a task that failed a pre-merge guard as waiting and
+// alerts the user.
+func keepTaskWaiting(appCtx *app.App, targetTask *task.Task, windowID string, tm tmux.Client, reason string) {
+	if err := renameWindowWithStatus(tm, windowID, windowNameForStatus(targetTask.Name, task.StatusWaiting), appCtx.PawDir, targetTask.Name, "end-task", task.StatusWaiting); err != nil {
+		logging.Warn("Failed to rename window: %v", err)
+	}
+	notify.PlaySound(notify.SoundError)
+	if err := tm.DisplayMessage(fmt.Sprintf("⚠️ %s: %s", reason, targetTask.Name), constants.DisplayMsgImportant); err != nil {
+		logging.Trace("Failed to display message: %v", err)
+	}
+}
+
+// runSecurityScans runs the security_scanners on the task's commits since it
+// forked from the main branch, saving their reports to the task's artifacts.
+// Returns false (keeping the task open) on critical findings. Scanners that
+// aren't installed are skipped with a warning.
+func runSecurityScans(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
+	if appCtx.Config == nil || len(appCtx.Config.SecurityScanners) == 0 {
+		return true
+	}
+	base, err := gitClient.MergeBase(workDir, gitClient.GetMainBranch(workDir), "HEAD")
+	if err != nil {
+		logging.Warn("runSecurityScans: failed to find the merge base: %v", err)
+		return true
+	}
+
+	var critical []service.ScanFinding
+	var reports []string
+	for _, name := range appCtx.Config.SecurityScanners {
+		spinner := tui.NewSimpleSpinner("Running " + name)
+		spinner.Start()
+		findings, reportPath, err := service.RunSecurityScan(name, workDir, base, targetTask.GetArtifactsDir(), constants.SecurityScanTimeout)
+		if errors.Is(err, service.ErrScannerNotInstalled) {
+			spinner.Stop(true, "skipped")
+			logging.Warn("runSecurityScans: %v", err)
+			fmt.Printf("  ⚠️  %s is not installed; skipping it\n", name)
+			continue
+		}
+		if err != nil {
+			// A broken scanner doesn't block the merge, but is reported
+			spinner.Stop(false, "failed")
+			logging.Warn("runSecurityScans: %v", err)
+			fmt.Printf("  ⚠️  %v\n", err)
+			continue
+		}
+
+		blocking := 0
+		for _, f := range findings {
+			if f.Critical {
+				critical = append(critical, f)
+				blocking++
+			}
+		}
+		logging.Log("runSecurityScans: task=%s scanner=%s findings=%d critical=%d", targetTask.Name, name, len(findings), blocking)
+		if blocking > 0 {
+			spinner.Stop(false, fmt.Sprintf("%d critical of %d findings", blocking, len(findings)))
+			reports = append(reports, reportPath)
+		} else {
+			spinner.Stop(true, fmt.Sprintf("%d findings", len(findings)))
+		}
+	}
+	if len(critical) == 0 {
+		return true
+	}
+
+	fmt.Println()
+	fmt.Println("  ✗ Security scan found critical issues; not merging")
+	for i, f := range critical {
+		if i == maxShownViolations {
+			fmt.Printf("    … and %d more\n", len(critical)-maxShownViolations)
+			break
+		}
+		fmt.Printf("    %s\n", f)
+	}
+	for _, report := range reports {
+		fmt.Printf("  Report: %s\n", report)
+	}
+	keepTaskWaiting(appCtx, targetTask, windowID, tm, "Security scan failed")
+	return false
+}
+
+// errSecurityFindings is end-task's exit error for a task kept after a
+// security scan reported critical findings.
+func errSecurityFindings(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.SecurityFindings, "security scan found critical issues in %s; task kept", t.Name), t.Name)
+}
+
+// errPolicyViolation is end-task's exit error for a task kept after failing
+// its diff checks.
+func errPolicyViolation(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.PolicyViolation, "diff checks failed for %s; task kept", t.Name), t.Name)
+}
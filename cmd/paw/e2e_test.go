@@ -0,0 +1,574 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/claude/claudetest"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tmux/tmuxtest"
+)
+
+// e2eEnv is a git project wired to tmux and claude fakes, so CLI commands can
+// run their lifecycle flows without tmux or Claude installed.
+type e2eEnv struct {
+	app     *app.App
+	session string
+	tmux    *tmuxtest.Fake
+	agent   *claudetest.Fake
+}
+
+func newE2EEnv(t *testing.T, script ...claudetest.Step) *e2eEnv {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".local", "state"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	// Code paths that shell out to the Claude CLI (e.g. merge auto-resolution) must fail
+	// instead of reaching a real agent
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil { //nolint:gosec // G306: stub needs to be executable
+		t.Fatalf("failed to write claude stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	projectDir := filepath.Join(t.TempDir(), "project")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	runGit(t, projectDir, "init", "-b", "main")
+	runGit(t, projectDir, "config", "user.email", "test@example.com")
+	runGit(t, projectDir, "config", "user.name", "Test")
+	for name, content := range map[string]string{
+		"README.md":  "# project\n",
+		".gitignore": ".paw/\n",
+	} {
+		if err := os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	runGit(t, projectDir, "add", ".")
+	runGit(t, projectDir, "commit", "-m", "initial commit")
+
+	appCtx, err := app.NewWithGitInfoWithWorkspace(projectDir, true, config.PawInProjectLocal)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	if err := appCtx.Initialize(); err != nil {
+		t.Fatalf("failed to initialize app: %v", err)
+	}
+	if err := config.DefaultConfig().Save(appCtx.PawDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	env := &e2eEnv{
+		app:     appCtx,
+		session: appCtx.SessionName,
+		tmux:    tmuxtest.New(appCtx.SessionName),
+		agent:   claudetest.New(script...),
+	}
+	_ = env.tmux.SetEnv("PROJECT_DIR", appCtx.ProjectDir)
+	_ = env.tmux.SetEnv("PAW_DIR", appCtx.PawDir)
+
+	prevTmux, prevClaude := newTmuxClient, newClaudeClient
+	newTmuxClient = func(string) tmux.Client { return env.tmux }
+	newClaudeClient = func() claude.Client { return env.agent }
+
+	// Detached helpers (watch-wait, summaries) run "true" instead of the test binary
+	truePath, err := exec.LookPath("true")
+	if err != nil {
+		t.Skip("true not installed")
+	}
+	getPawBin()
+	prevPawBin := cachedPawBin
+	cachedPawBin = truePath
+
+	prevUserInitiated, prevAction := endTaskUserInitiated, endTaskAction
+	t.Cleanup(func() {
+		newTmuxClient, newClaudeClient = prevTmux, prevClaude
+		cachedPawBin = prevPawBin
+		endTaskUserInitiated, endTaskAction = prevUserInitiated, prevAction
+		endTaskKeepBranch = false
+		endTaskCmd.Flags().Lookup("keep-branch").Changed = false
+		rootCmd.SetArgs(nil)
+	})
+	return env
+}
+
+// run executes a paw command line like the CLI would.
+func (e *e2eEnv) run(t *testing.T, args ...string) {
+	t.Helper()
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("paw %s: %v", strings.Join(args, " "), err)
+	}
+}
+
+// addTask creates a task as the new-task popup would and returns its agent directory.
+func (e *e2eEnv) addTask(t *testing.T, name, content string) string {
+	t.Helper()
+	agentDir := e.app.GetAgentDir(name)
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatalf("failed to create agent dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDir, constants.TaskFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write task: %v", err)
+	}
+	return agentDir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func TestE2E_HandleTaskThenMerge(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Add a greeting file", Output: "⏺ Adding the greeting"})
+
+	agentDir := env.addTask(t, "add-greeting", "Add a greeting file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+	if _, ok := env.tmux.Window(windowID); !ok {
+		t.Fatalf("task window %s was not created", windowID)
+	}
+	if got := env.tmux.PaneCount(windowID); got != 2 {
+		t.Errorf("task window has %d panes, want 2 (agent and user)", got)
+	}
+	if env.agent.Remaining() != 0 {
+		t.Errorf("agent did not receive the task instruction; inputs = %q", env.agent.Inputs())
+	}
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, config.DefaultConfig())
+	tk, err := mgr.GetTask("add-greeting")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	worktreeDir := mgr.GetWorkingDirectory(tk)
+	if _, err := os.Stat(worktreeDir); err != nil {
+		t.Fatalf("worktree was not created: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeDir, "greeting.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tk.GetArtifactsDir(), "report.md"), []byte("# Report\n"), 0644); err != nil {
+		t.Fatalf("failed to write artifact: %v", err)
+	}
+
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+
+	if got := runGit(t, env.app.ProjectDir, "show", "main:greeting.txt"); got != "hello\n" {
+		t.Errorf("main:greeting.txt = %q, want %q", got, "hello\n")
+	}
+	sets, err := service.NewHistoryService(env.app.GetHistoryDir()).ListArtifacts("add-greeting")
+	if err != nil || len(sets) != 1 || len(sets[0].Files) != 1 || sets[0].Files[0] != "report.md" {
+		t.Errorf("saved artifacts = %+v, %v; want report.md", sets, err)
+	}
+	if _, err := os.Stat(agentDir); !os.IsNotExist(err) {
+		t.Errorf("agent dir still exists after end-task: %v", err)
+	}
+	if _, ok := env.tmux.Window(windowID); ok {
+		t.Errorf("task window %s was not killed", windowID)
+	}
+	if !strings.Contains(strings.Join(env.tmux.Messages(), "\n"), "Task completed: add-greeting") {
+		t.Errorf("messages = %q, want a completion message", env.tmux.Messages())
+	}
+}
+
+func TestE2E_MergeTaskKeepsTask(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Add a farewell file", Output: "⏺ Adding the farewell"})
+
+	agentDir := env.addTask(t, "add-farewell", "Add a farewell file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, config.DefaultConfig())
+	tk, err := mgr.GetTask("add-farewell")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(mgr.GetWorkingDirectory(tk), "farewell.txt"), []byte("bye\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+
+	env.run(t, "internal", "merge-task", env.session, windowID)
+
+	if got := runGit(t, env.app.ProjectDir, "show", "main:farewell.txt"); got != "bye\n" {
+		t.Errorf("main:farewell.txt = %q, want %q", got, "bye\n")
+	}
+	if _, err := os.Stat(agentDir); err != nil {
+		t.Errorf("agent dir was removed by merge-task: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(env.app.PawDir, constants.MergeLockFileName)); !os.IsNotExist(err) {
+		t.Errorf("merge lock was not released: %v", err)
+	}
+	if !strings.Contains(strings.Join(env.tmux.Messages(), "\n"), "Merged: add-farewell") {
+		t.Errorf("messages = %q, want a merge message", env.tmux.Messages())
+	}
+}
+
+func TestE2E_EndTaskTwice(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Add a notes file", Output: "⏺ Adding notes"})
+
+	agentDir := env.addTask(t, "add-notes", "Add a notes file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	// A task whose completion marker survived an interrupted cleanup only
+	// gets the rest of its cleanup
+	tk := task.New("add-notes", agentDir)
+	if err := tk.MarkCompleted(task.Completion{Drop: true}); err != nil {
+		t.Fatalf("failed to mark task completed: %v", err)
+	}
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+	if _, err := os.Stat(agentDir); !os.IsNotExist(err) {
+		t.Fatalf("agent dir still exists after resuming cleanup: %v", err)
+	}
+	if _, ok := env.tmux.Window(windowID); ok {
+		t.Fatal("resumed cleanup did not kill the window")
+	}
+
+	// The second Ctrl+F finds nothing left to finish
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+}
+
+func TestE2E_EndTaskKeepCommitsOnly(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Draft a todo file", Output: "⏺ Drafting"})
+
+	agentDir := env.addTask(t, "draft-todo", "Draft a todo file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, config.DefaultConfig())
+	tk, err := mgr.GetTask("draft-todo")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	worktreeDir := mgr.GetWorkingDirectory(tk)
+	if err := os.WriteFile(filepath.Join(worktreeDir, "todo.txt"), []byte("- ship\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", constants.ActionKeep, env.session, windowID)
+
+	if got := runGit(t, env.app.ProjectDir, "show", "draft-todo:todo.txt"); got != "- ship\n" {
+		t.Errorf("draft-todo:todo.txt = %q, want the committed file", got)
+	}
+	if _, err := os.Stat(worktreeDir); err != nil {
+		t.Errorf("worktree was removed: %v", err)
+	}
+	if _, ok := env.tmux.Window(windowID); !ok {
+		t.Error("task window was killed")
+	}
+}
+
+func TestE2E_KeepBranchOnMerge(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Add a license file", Output: "⏺ Adding the license"})
+	cfg := config.DefaultConfig()
+	cfg.KeepBranch = true
+	if err := cfg.Save(env.app.PawDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	agentDir := env.addTask(t, "add-license", "Add a license file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, cfg)
+	tk, err := mgr.GetTask("add-license")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	worktreeDir := mgr.GetWorkingDirectory(tk)
+	if err := os.WriteFile(filepath.Join(worktreeDir, "LICENSE"), []byte("MIT\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+
+	if got := runGit(t, env.app.ProjectDir, "show", "main:LICENSE"); got != "MIT\n" {
+		t.Errorf("main:LICENSE = %q, want %q", got, "MIT\n")
+	}
+	if _, err := os.Stat(worktreeDir); !os.IsNotExist(err) {
+		t.Errorf("worktree still exists after end-task: %v", err)
+	}
+	runGit(t, env.app.ProjectDir, "rev-parse", "--verify", "refs/heads/add-license")
+}
+
+func TestE2E_DelayedCleanupParksWorktree(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Add a notice file", Output: "⏺ Adding the notice"})
+	cfg := config.DefaultConfig()
+	cfg.CleanupPolicy = constants.CleanupDelayed
+	cfg.CleanupRetentionDays = 2
+	if err := cfg.Save(env.app.PawDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	agentDir := env.addTask(t, "add-notice", "Add a notice file")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, cfg)
+	tk, err := mgr.GetTask("add-notice")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	worktreeDir := mgr.GetWorkingDirectory(tk)
+	if err := os.WriteFile(filepath.Join(worktreeDir, "NOTICE"), []byte("notice\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+
+	if _, err := os.Stat(agentDir); !os.IsNotExist(err) {
+		t.Errorf("agent directory still exists after end-task: %v", err)
+	}
+	if out, err := exec.Command("git", "-C", env.app.ProjectDir, "rev-parse", "--verify", "refs/heads/add-notice").CombinedOutput(); err == nil {
+		t.Errorf("branch add-notice still exists: %s", out)
+	}
+
+	parked, err := mgr.ListParkedTasks()
+	if err != nil || len(parked) != 1 || parked[0].Name != "add-notice" {
+		t.Fatalf("ListParkedTasks() = %v, %v; want add-notice", parked, err)
+	}
+	parkedWorktree := filepath.Join(parked[0].Dir, filepath.Base(worktreeDir))
+	if data, err := os.ReadFile(filepath.Join(parkedWorktree, "NOTICE")); err != nil || string(data) != "notice\n" {
+		t.Errorf("parked worktree NOTICE = %q, %v", data, err)
+	}
+	if got := runGit(t, parkedWorktree, "log", "-1", "--format=%s"); got == "" {
+		t.Error("parked worktree is not a working git checkout")
+	}
+
+	// Parked tasks stay until cleanup_retention_days have passed
+	if pruned, err := mgr.PruneParkedTasks(time.Now().Add(24 * time.Hour)); err != nil || len(pruned) != 0 {
+		t.Errorf("PruneParkedTasks() after 1 day = %v, %v; want none", pruned, err)
+	}
+	if pruned, err := mgr.PruneParkedTasks(time.Now().Add(3 * 24 * time.Hour)); err != nil || len(pruned) != 1 {
+		t.Errorf("PruneParkedTasks() after 3 days = %v, %v; want add-notice", pruned, err)
+	}
+	if _, err := os.Stat(parked[0].Dir); !os.IsNotExist(err) {
+		t.Errorf("parked task still exists after prune: %v", err)
+	}
+	if got := runGit(t, env.app.ProjectDir, "worktree", "list"); strings.Contains(got, parked[0].Dir) {
+		t.Errorf("git still lists the parked worktree:\n%s", got)
+	}
+}
+
+// startConflictingTask starts a task whose README change conflicts with a
+// later commit on main, and returns its window ID.
+func startConflictingTask(t *testing.T, env *e2eEnv) string {
+	t.Helper()
+	agentDir := env.addTask(t, "edit-readme", "Edit the README")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+
+	mgr := task.NewManager(env.app.AgentsDir, env.app.ProjectDir, env.app.PawDir, true, config.DefaultConfig())
+	tk, err := mgr.GetTask("edit-readme")
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(mgr.GetWorkingDirectory(tk), "README.md"), []byte("# task\n"), 0644); err != nil {
+		t.Fatalf("failed to write in worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(env.app.ProjectDir, "README.md"), []byte("# main\n"), 0644); err != nil {
+		t.Fatalf("failed to write in project: %v", err)
+	}
+	runGit(t, env.app.ProjectDir, "commit", "-am", "Edit README on main")
+	return strings.TrimSpace(string(windowIDData))
+}
+
+func TestE2E_ConflictResolvedInTemporaryWorktree(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Edit the README", Output: "⏺ Editing"})
+	windowID := startConflictingTask(t, env)
+
+	// The conflict resolver records where it ran and resolves README.md there
+	cwdFile := filepath.Join(t.TempDir(), "claude-cwd")
+	binDir := t.TempDir()
+	stub := "#!/bin/sh\npwd > " + cwdFile + "\nprintf '# main\\n# task\\n' > README.md\ngit add -A\necho 'Kept both headings'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(stub), 0755); err != nil { //nolint:gosec // G306: stub needs to be executable
+		t.Fatalf("failed to write claude stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	env.run(t, "internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID)
+
+	if got := runGit(t, env.app.ProjectDir, "show", "main:README.md"); got != "# main\n# task\n" {
+		t.Errorf("main:README.md = %q, want the resolution", got)
+	}
+	cwd, err := os.ReadFile(cwdFile)
+	if err != nil {
+		t.Fatalf("conflict resolver did not run: %v", err)
+	}
+	if dir := strings.TrimSpace(string(cwd)); !strings.HasPrefix(dir, filepath.Join(env.app.PawDir, constants.ConflictWorktreeDirName)) {
+		t.Errorf("conflict resolver ran in %s, want a temporary worktree", dir)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(env.app.PawDir, constants.ConflictWorktreeDirName)); len(entries) != 0 {
+		t.Errorf("temporary worktree left behind: %v", entries)
+	}
+	if got := runGit(t, env.app.ProjectDir, "status", "--porcelain"); got != "" {
+		t.Errorf("project checkout is not clean:\n%s", got)
+	}
+
+	// The transcript is saved to history with the task's artifacts
+	sets, err := service.NewHistoryService(env.app.GetHistoryDir()).ListArtifacts("edit-readme")
+	if err != nil || len(sets) != 1 || len(sets[0].Files) != 1 {
+		t.Fatalf("ListArtifacts() = %+v, %v; want the conflict transcript", sets, err)
+	}
+	transcript, err := os.ReadFile(filepath.Join(sets[0].Dir, sets[0].Files[0]))
+	if err != nil {
+		t.Fatalf("failed to read transcript: %v", err)
+	}
+	for _, want := range []string{"- Result: resolved", "<<<<<<< HEAD", "Kept both headings", "+# task"} {
+		if !strings.Contains(string(transcript), want) {
+			t.Errorf("transcript missing %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestE2E_FailedConflictResolutionLeavesCheckout(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Edit the README", Output: "⏺ Editing"})
+	windowID := startConflictingTask(t, env)
+	mainHead := runGit(t, env.app.ProjectDir, "rev-parse", "main")
+
+	// The Claude stub of newE2EEnv fails, so the resolution is abandoned
+	rootCmd.SetArgs([]string{"internal", "end-task", "--user-initiated", "--action", "merge", env.session, windowID})
+	if err := rootCmd.Execute(); exitCode(err) != exitcode.MergeConflict {
+		t.Errorf("end-task error = %v (exit code %d), want exit code %d", err, exitCode(err), exitcode.MergeConflict)
+	}
+
+	if got := runGit(t, env.app.ProjectDir, "rev-parse", "main"); got != mainHead {
+		t.Errorf("main moved to %s, want %s", got, mainHead)
+	}
+	if got := runGit(t, env.app.ProjectDir, "status", "--porcelain"); got != "" {
+		t.Errorf("project checkout is not clean:\n%s", got)
+	}
+	if data, _ := os.ReadFile(filepath.Join(env.app.ProjectDir, "README.md")); string(data) != "# main\n" {
+		t.Errorf("README.md = %q, want main's version", data)
+	}
+	if got := runGit(t, env.app.ProjectDir, "worktree", "list"); strings.Contains(got, constants.ConflictWorktreeDirName) {
+		t.Errorf("temporary worktree left behind:\n%s", got)
+	}
+
+	files, _ := service.ListTaskArtifacts(filepath.Join(env.app.GetAgentDir("edit-readme"), constants.ArtifactsDirName))
+	if len(files) != 1 || !strings.HasPrefix(files[0], "conflict-resolution-") {
+		t.Fatalf("task artifacts = %v, want the conflict transcript", files)
+	}
+	transcript, _ := os.ReadFile(filepath.Join(env.app.GetAgentDir("edit-readme"), constants.ArtifactsDirName, files[0]))
+	if !strings.Contains(string(transcript), "- Result: abandoned: claude conflict resolution failed") {
+		t.Errorf("transcript does not record the failure:\n%s", transcript)
+	}
+}
+
+func TestE2E_VerifyFailureKeepsTask(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Break the tests", Output: "⏺ Done"})
+	cfg := config.DefaultConfig()
+	cfg.Commands = config.Commands{Build: "true", Test: "exit 1"}
+	if err := cfg.Save(env.app.PawDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	agentDir := env.addTask(t, "break-tests", "Break the tests")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+	windowIDData, err := os.ReadFile(filepath.Join(agentDir, constants.TabLockDirName, constants.WindowIDFileName))
+	if err != nil {
+		t.Fatalf("handle-task did not save the window ID: %v", err)
+	}
+	windowID := strings.TrimSpace(string(windowIDData))
+
+	taskContext, err := os.ReadFile(filepath.Join(agentDir, constants.TaskContextFileName))
+	if err != nil || !strings.Contains(string(taskContext), "**test**: `exit 1`") {
+		t.Errorf("task context does not list the project commands (%v):\n%s", err, taskContext)
+	}
+
+	rootCmd.SetArgs([]string{"internal", "end-task", "--user-initiated", "--action", "merge-push", env.session, windowID})
+	if err := rootCmd.Execute(); exitCode(err) != exitcode.VerificationFailed {
+		t.Errorf("end-task error = %v (exit code %d), want exit code %d", err, exitCode(err), exitcode.VerificationFailed)
+	}
+
+	if _, err := os.Stat(agentDir); err != nil {
+		t.Errorf("agent dir removed after failed verification: %v", err)
+	}
+	if _, ok := env.tmux.Window(windowID); !ok {
+		t.Errorf("task window %s was killed after failed verification", windowID)
+	}
+	rec := service.LoadFailureRecord(filepath.Join(agentDir, constants.FailureFileName))
+	if rec == nil || rec.Kind != service.FailureTest {
+		t.Errorf("failure record = %+v, want %s", rec, service.FailureTest)
+	}
+	if got := runGit(t, env.app.ProjectDir, "rev-list", "--count", "main"); strings.TrimSpace(got) != "1" {
+		t.Errorf("main has %s commits, want 1 (no merge)", strings.TrimSpace(got))
+	}
+}
+
+func TestE2E_BranchOnCompleteRule(t *testing.T) {
+	env := newE2EEnv(t, claudetest.Step{Expect: "Fix the release", Output: "⏺ Fixing"})
+	cfg := config.DefaultConfig()
+	cfg.OnComplete = constants.ActionMergePush
+	cfg.OnCompleteBranches = []config.BranchRule{{Pattern: "release/*", OnComplete: constants.OnCompleteConfirm}}
+	if err := cfg.Save(env.app.PawDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	runGit(t, env.app.ProjectDir, "checkout", "-b", "release/1.0")
+
+	agentDir := env.addTask(t, "fix-release", "Fix the release")
+	env.run(t, "internal", "handle-task", env.session, agentDir)
+
+	opts, err := config.LoadTaskOptions(agentDir)
+	if err != nil {
+		t.Fatalf("failed to load task options: %v", err)
+	}
+	if opts.OnComplete != constants.OnCompleteConfirm {
+		t.Errorf("task on_complete = %q, want %q from the release/* rule", opts.OnComplete, constants.OnCompleteConfirm)
+	}
+}
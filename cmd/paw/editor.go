@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// editorServerOptionKey holds the pid of the session's editor endpoint.
+const editorServerOptionKey = "@paw_editor_server"
+
+var editorCmd = &cobra.Command{
+	Use:   "editor",
+	Short: "Print the editor endpoint's socket (for editor plugins)",
+	Long: `Print the Unix socket of the editor endpoint, which lets editor plugins
+(VS Code, Neovim) create tasks from a code selection. It runs while the
+session runs when editor_endpoint is set in .paw/config.
+
+The endpoint speaks HTTP/1.1 over the socket (protocol 1):
+
+  GET  /v1/status   {"protocol": 1, "project": "...", "session": "..."}
+  POST /v1/tasks    {"prompt": "...", "file": "src/db.go", "start_line": 10,
+                     "end_line": 20, "selection": "...", "language": "go",
+                     "model": "sonnet", "name": "optional-task-name"}
+                    → 201 {"task": "task-name"}
+
+Only prompt is required. The file (absolute or relative to the project), its
+lines, and the selected code are added to the task's prompt. Errors return
+a 4xx/5xx status with {"error": "..."}.
+
+Example:
+  curl --unix-socket "$(paw editor)" -d '{"prompt": "Add tests"}' http://paw/v1/tasks`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		socketPath := service.EditorSocketPath(appCtx.PawDir, appCtx.SessionName)
+		fmt.Println(socketPath)
+
+		conn, err := net.DialTimeout("unix", socketPath, time.Second)
+		if err != nil {
+			if appCtx.Config == nil || !appCtx.Config.EditorEndpoint {
+				return exitcode.Errorf(exitcode.SessionNotFound, "editor endpoint is off (set editor_endpoint: true in .paw/config)")
+			}
+			return exitcode.Errorf(exitcode.SessionNotFound, "editor endpoint is not running (run 'paw' first)")
+		}
+		_ = conn.Close()
+		return nil
+	},
+}
+
+var editorServerCmd = &cobra.Command{
+	Use:    "editor-server [session]",
+	Short:  "Serve the editor endpoint while the session runs",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "editor-server", "")
+		defer cleanup()
+
+		socketPath := service.EditorSocketPath(appCtx.PawDir, sessionName)
+		listener, err := listenEditorSocket(socketPath)
+		if err != nil {
+			logging.Warn("editor-server: %v", err)
+			return err
+		}
+		defer func() { _ = os.Remove(socketPath) }()
+
+		tm := newTmuxClient(sessionName)
+		pid := strconv.Itoa(os.Getpid())
+		setOrUnsetOption(tm, editorServerOptionKey, pid)
+
+		server := &http.Server{
+			Handler:           editorHandler(appCtx, tm),
+			ReadHeaderTimeout: constants.EditorRequestTimeout,
+			WriteTimeout:      constants.EditorRequestTimeout,
+		}
+		go func() {
+			if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logging.Warn("editor-server: %v", err)
+			}
+		}()
+		logging.Log("editor-server: listening on %s", socketPath)
+
+		for {
+			time.Sleep(constants.EditorSessionPollInterval)
+			// Stop with the session, or when another endpoint took over
+			if !tm.HasSession(sessionName) || sessionOption(tm, sessionName, editorServerOptionKey) != pid {
+				logging.Debug("editor-server: session gone or endpoint replaced, exiting")
+				ctx, cancel := context.WithTimeout(context.Background(), constants.EditorRequestTimeout)
+				_ = server.Shutdown(ctx)
+				cancel()
+				return nil
+			}
+		}
+	},
+}
+
+// listenEditorSocket listens on the editor socket, replacing a stale socket
+// left by an endpoint that exited without removing it.
+func listenEditorSocket(socketPath string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", socketPath, time.Second); err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("another editor endpoint is listening on %s", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	// Only the user may create tasks
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// editorHandler serves the editor endpoint's protocol.
+func editorHandler(appCtx *app.App, tm tmux.Client) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeEditorJSON(w, http.StatusMethodNotAllowed, service.EditorError{Error: "use GET"})
+			return
+		}
+		writeEditorJSON(w, http.StatusOK, service.EditorStatus{
+			Protocol: constants.EditorProtocolVersion,
+			Project:  appCtx.ProjectDir,
+			Session:  appCtx.SessionName,
+		})
+	})
+	mux.HandleFunc("/v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeEditorJSON(w, http.StatusMethodNotAllowed, service.EditorError{Error: "use POST"})
+			return
+		}
+		var req service.EditorTaskRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, constants.EditorRequestMaxBytes)).Decode(&req); err != nil {
+			writeEditorJSON(w, http.StatusBadRequest, service.EditorError{Error: "invalid request: " + err.Error()})
+			return
+		}
+		if err := req.Normalize(appCtx.ProjectDir); err != nil {
+			writeEditorJSON(w, http.StatusBadRequest, service.EditorError{Error: err.Error()})
+			return
+		}
+
+		newTask, err := createEditorTask(appCtx, &req)
+		if err != nil {
+			logging.Warn("editor-server: %v", err)
+			writeEditorJSON(w, http.StatusInternalServerError, service.EditorError{Error: err.Error()})
+			return
+		}
+		_ = tm.DisplayMessage("🧩 Task from editor: "+newTask.Name, constants.DisplayMsgStandard)
+		writeEditorJSON(w, http.StatusCreated, service.EditorTaskResponse{Task: newTask.Name})
+	})
+	return mux
+}
+
+// createEditorTask creates and starts a task from an editor request.
+func createEditorTask(appCtx *app.App, req *service.EditorTaskRequest) (*task.Task, error) {
+	opts := &config.TaskOptions{Model: config.Model(req.Model), BranchName: req.Name}
+	newTask, err := spawnTask(appCtx, req.Content(), opts)
+	if err != nil {
+		return nil, err
+	}
+	logging.Log("Editor task created: %s (file=%s lines=%d-%d)", newTask.Name, req.File, req.StartLine, req.EndLine)
+	return newTask, nil
+}
+
+func writeEditorJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// startEditorServer starts the session's editor endpoint when editor_endpoint
+// is set, unless it is already running.
+func startEditorServer(appCtx *app.App, tm tmux.Client, pawBin string) {
+	if appCtx.Config == nil || !appCtx.Config.EditorEndpoint {
+		return
+	}
+	if sessionProcessRunning(tm, appCtx.SessionName, editorServerOptionKey) {
+		return
+	}
+
+	serverCmd := exec.Command(pawBin, "internal", "editor-server", appCtx.SessionName) //nolint:gosec // G204: pawBin is from getPawBin()
+	serverCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := serverCmd.Start(); err != nil {
+		logging.Warn("Failed to start editor endpoint: %v", err)
+	}
+}
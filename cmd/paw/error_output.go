@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// errorFormat is set by the global --error-format flag.
+var errorFormat string
+
+// exportGlobalFlags checks the global flags and passes them on to the paw
+// processes this one starts (internal commands run from tmux inherit them).
+func exportGlobalFlags(_ *cobra.Command, _ []string) error {
+	if nonInteractive {
+		_ = os.Setenv(constants.NonInteractiveEnv, "1")
+	}
+	if errorFormat != "" {
+		if errorFormat != constants.ErrorFormatText && errorFormat != constants.ErrorFormatJSON {
+			return fmt.Errorf("invalid --error-format %q (use %s or %s)", errorFormat, constants.ErrorFormatText, constants.ErrorFormatJSON)
+		}
+		_ = os.Setenv(constants.ErrorFormatEnv, errorFormat)
+	}
+	return nil
+}
+
+// classifyError gives well-known errors without an exit code theirs.
+func classifyError(err error) error {
+	if exitcode.Code(err) == exitcode.Failure && errors.Is(err, task.ErrTaskNotFound) {
+		return exitcode.New(exitcode.TaskNotFound, err)
+	}
+	return err
+}
+
+// exitCode returns the documented exit code for a command error.
+func exitCode(err error) int {
+	return exitcode.Code(classifyError(err))
+}
+
+// printError writes err to stderr as text, or as a JSON object (code,
+// message, task, hint) with --error-format json.
+func printError(err error) {
+	format := errorFormat
+	if format == "" {
+		format = os.Getenv(constants.ErrorFormatEnv)
+	}
+	if format == constants.ErrorFormatJSON {
+		fmt.Fprintln(os.Stderr, exitcode.NewReport(err).JSON())
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(fmt.Errorf("end-task: %w", task.ErrTaskNotFound)); got != exitcode.TaskNotFound {
+		t.Errorf("exitCode(task not found) = %d, want %d", got, exitcode.TaskNotFound)
+	}
+	if got := exitCode(exitcode.Errorf(exitcode.SessionNotFound, "no session")); got != exitcode.SessionNotFound {
+		t.Errorf("exitCode(session not found) = %d, want %d", got, exitcode.SessionNotFound)
+	}
+}
+
+func TestExportGlobalFlags_InvalidErrorFormat(t *testing.T) {
+	errorFormat = "yaml"
+	t.Cleanup(func() { errorFormat = "" })
+	if err := exportGlobalFlags(nil, nil); err == nil {
+		t.Error("exportGlobalFlags() error = nil for --error-format yaml")
+	}
+}
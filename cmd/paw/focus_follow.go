@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// focusFollowOptionKey is the session option holding the status bar
+// focus-follow indicator; focus-follow is on while it is set.
+const focusFollowOptionKey = "@paw_focus_follow"
+
+// focusFollowIndicator is shown in the status bar while focus-follow is on.
+const focusFollowIndicator = "🎯 follow"
+
+var toggleFocusFollowCmd = &cobra.Command{
+	Use:    "toggle-focus-follow [session]",
+	Short:  "Toggle switching to tasks as soon as they need input",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		tm := newTmuxClient(sessionName)
+
+		enabled := !focusFollowEnabled(tm, sessionName)
+		setFocusFollow(tm, enabled)
+		if enabled {
+			_ = tm.DisplayMessage("🎯 Focus-follow on: jump to tasks that need input", constants.DisplayMsgQuick)
+		} else {
+			_ = tm.DisplayMessage("Focus-follow off", constants.DisplayMsgQuick)
+		}
+		return nil
+	},
+}
+
+// setFocusFollow turns focus-follow on or off for the session.
+func setFocusFollow(tm tmux.Client, enabled bool) {
+	var err error
+	if enabled {
+		err = tm.SetOption(focusFollowOptionKey, focusFollowIndicator, false)
+	} else {
+		err = tm.Run("set-option", "-u", focusFollowOptionKey)
+	}
+	if err != nil {
+		logging.Debug("setFocusFollow: %v", err)
+	}
+}
+
+// focusFollowEnabled reports whether focus-follow is on for the session.
+func focusFollowEnabled(tm tmux.Client, sessionName string) bool {
+	value, err := tm.RunWithOutput("show-option", "-qv", "-t", sessionName, focusFollowOptionKey)
+	return err == nil && strings.TrimSpace(value) != ""
+}
+
+// userTyping reports whether any client attached to the session pressed a
+// key within FocusFollowTypingGrace.
+func userTyping(tm tmux.Client, sessionName string, now time.Time) bool {
+	out, err := tm.RunWithOutput("list-clients", "-t", sessionName, "-F", "#{client_activity}")
+	if err != nil {
+		return false
+	}
+	for _, field := range strings.Fields(out) {
+		secs, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		if now.Sub(time.Unix(secs, 0)) < constants.FocusFollowTypingGrace {
+			return true
+		}
+	}
+	return false
+}
+
+// followWaitingTask switches to a task window that just started waiting when
+// focus-follow is on. It returns false while the switch is held back because
+// the user is typing, so the caller can try again on its next poll.
+func followWaitingTask(tm tmux.Client, sessionName, windowID, taskName string) bool {
+	if !focusFollowEnabled(tm, sessionName) {
+		return true
+	}
+	if active, err := tm.RunWithOutput("display-message", "-p", "-t", sessionName, "#{window_id}"); err == nil && strings.TrimSpace(active) == windowID {
+		return true
+	}
+	if userTyping(tm, sessionName, time.Now()) {
+		logging.Trace("followWaitingTask: user is typing, holding switch to task=%s", taskName)
+		return false
+	}
+
+	logging.Info("followWaitingTask: switching to task=%s window=%s", taskName, windowID)
+	if err := tm.SelectWindow(windowID); err != nil {
+		logging.Debug("followWaitingTask: failed to select window: %v", err)
+	}
+	return true
+}
@@ -12,7 +12,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
 )
 
 type historyEntry struct {
@@ -100,27 +105,235 @@ var historyShowCmd = &cobra.Command{
 			return err
 		}
 
-		data, err := os.ReadFile(entry.Path)
+		saved, err := service.NewHistoryService(application.GetHistoryDir()).ReadEntry(entry.Path)
 		if err != nil {
 			return fmt.Errorf("failed to read history entry: %w", err)
 		}
 
-		fmt.Print(string(data))
-		if len(data) == 0 || data[len(data)-1] != '\n' {
-			fmt.Println()
+		fmt.Print(saved.Render())
+		return nil
+	},
+}
+
+var historyCloneNow bool
+
+var historyCloneCmd = &cobra.Command{
+	Use:   "clone [entry]",
+	Short: "Re-run a history entry's task as a new task",
+	Long: `Clone a finished task (content and options) from history into the new-task
+window, where you can edit it before pressing Alt+Enter. The branch name and
+dependency of the original task are not copied.
+
+Use --now to start the cloned task right away instead.
+
+Active tasks can be cloned with 'c' in a Kanban column.`,
+	Example: `  paw history clone 1
+  paw history clone fix-login --now`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		saved, err := readHistoryEntryArg(appCtx, args[0])
+		if err != nil {
+			return err
+		}
+		clone, err := service.HistoryTaskClone(saved)
+		if err != nil {
+			return err
+		}
+
+		tm := newTmuxClient(appCtx.SessionName)
+		if !tm.HasSession(appCtx.SessionName) {
+			return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+		}
+
+		if historyCloneNow {
+			_, cleanup := setupLoggerFromApp(appCtx, "history-clone", "")
+			defer cleanup()
+
+			newTask, err := startClonedTask(appCtx, clone)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("🚀 Started %s (cloned from %s)\n", newTask.Name, clone.Source)
+			return nil
+		}
+
+		if err := service.WriteCloneSelection(appCtx.PawDir, clone); err != nil {
+			return err
+		}
+		_ = tm.SelectWindow(appCtx.SessionName + ":" + constants.NewWindowName)
+		fmt.Printf("📋 Cloned %s into the new-task window: edit it and press Alt+Enter\n", clone.Source)
+		return nil
+	},
+}
+
+var historyFollowUpCmd = &cobra.Command{
+	Use:   "follow-up [entry]",
+	Short: "Start a follow-up task to a history entry",
+	Long: `Open the new-task window pre-filled with a reference to a finished task's
+request, summary, and branch. The new task is linked to it ("follow_up" in its
+options and history), and the Kanban shows "↪ follows <task>" on its card.
+
+Active and done tasks get a follow-up with 'n' in a Kanban column.`,
+	Example: `  paw history follow-up 1
+  paw history follow-up fix-login`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		saved, err := readHistoryEntryArg(appCtx, args[0])
+		if err != nil {
+			return err
+		}
+		followUp, err := service.HistoryFollowUp(saved)
+		if err != nil {
+			return err
+		}
+
+		tm := newTmuxClient(appCtx.SessionName)
+		if !tm.HasSession(appCtx.SessionName) {
+			return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+		}
+		if err := service.WriteCloneSelection(appCtx.PawDir, followUp); err != nil {
+			return err
+		}
+		_ = tm.SelectWindow(appCtx.SessionName + ":" + constants.NewWindowName)
+		fmt.Printf("↪ Follow-up to %s opened in the new-task window: describe it and press Alt+Enter\n", followUp.Source)
+		return nil
+	},
+}
+
+// readHistoryEntryArg reads the history entry an argument refers to (index,
+// task name, or path), honoring the history filter flags.
+func readHistoryEntryArg(appCtx *app.App, ref string) (*service.HistoryEntry, error) {
+	entries, err := loadHistoryEntries(appCtx.GetHistoryDir(), historyOptions{
+		task:  historyTask,
+		since: historySince,
+		query: historyQuery,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entry, err := resolveHistoryEntry(entries, appCtx.GetHistoryDir(), ref)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := service.NewHistoryService(appCtx.GetHistoryDir()).ReadEntry(entry.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history entry: %w", err)
+	}
+	if saved.Meta == nil {
+		saved.Meta = &service.HistoryMetadata{}
+	}
+	if saved.Meta.TaskName == "" {
+		saved.Meta.TaskName = entry.Task
+	}
+	return saved, nil
+}
+
+// startClonedTask creates and starts a task from a clone without editing it.
+func startClonedTask(appCtx *app.App, clone *service.TaskClone) (*task.Task, error) {
+	opts := clone.Options
+	if opts.Parent != "" && !pathExists(filepath.Join(appCtx.AgentsDir, opts.Parent)) {
+		opts.Parent = ""
+	}
+	opts.BranchName = ""
+	newTask, err := spawnTask(appCtx, clone.Content, opts)
+	if err != nil {
+		return nil, err
+	}
+	logging.Log("Cloned task created: %s (source=%s)", newTask.Name, clone.Source)
+	return newTask, nil
+}
+
+var historyInitKeyCmd = &cobra.Command{
+	Use:   "init-key",
+	Short: "Create a history encryption key in the OS keychain",
+	Long: `Create a random key for history_encryption and store it in the OS keychain
+(macOS Keychain, or Secret Service via secret-tool on Linux).
+
+If the keychain is unavailable, the key is printed so you can export it as
+PAW_HISTORY_KEY instead. Keep a copy: encrypted history can't be read without it.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, err := service.ResolveHistoryKey(); err == nil {
+			fmt.Println("A history key is already configured")
+			return nil
 		}
 
+		secret, err := service.GenerateHistorySecret()
+		if err != nil {
+			return err
+		}
+		if err := service.StoreHistorySecret(secret); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			fmt.Printf("Export this key instead (e.g. in your shell profile):\n\n  export %s=%s\n", constants.HistoryKeyEnv, secret)
+			return nil
+		}
+		fmt.Println("History key stored in the OS keychain")
+		fmt.Println("Set 'history_encryption: true' in the project config to encrypt new history entries")
 		return nil
 	},
 }
 
+var historyEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt existing plaintext history entries",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		application, err := getAppFromCwd()
+		if err != nil {
+			return err
+		}
+
+		count, err := service.NewHistoryService(application.GetHistoryDir()).EncryptExisting()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Encrypted %d history entries\n", count)
+		return nil
+	},
+}
+
+var historyMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Convert single-file history entries to entry directories",
+	Long: `Convert history entries saved as single files by older PAW versions into
+entry directories (meta.json, task.md, summary.md, capture.txt, hooks.md).
+
+Old entries stay readable without migrating; encrypted entries need the
+history key and stay encrypted.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		application, err := getAppFromCwd()
+		if err != nil {
+			return err
+		}
+
+		count, err := service.NewHistoryService(application.GetHistoryDir()).MigrateLegacy()
+		fmt.Printf("Migrated %d history entries\n", count)
+		return err
+	},
+}
+
 func init() {
 	historyCmd.PersistentFlags().StringVar(&historyTask, "task", "", "Filter history by task name (substring)")
 	historyCmd.PersistentFlags().StringVar(&historySince, "since", "", "Filter history since time (duration or timestamp)")
 	historyCmd.PersistentFlags().StringVar(&historyQuery, "query", "", "Filter history by text in the entry")
 	historyCmd.PersistentFlags().IntVar(&historyLimit, "limit", 20, "Limit number of entries shown")
 	historyCmd.Flags().BoolVar(&historyNoSummary, "no-summary", false, "Hide summary preview in list")
+	historyCloneCmd.Flags().BoolVar(&historyCloneNow, "now", false, "Start the cloned task right away instead of opening it for editing")
 	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyCloneCmd)
+	historyCmd.AddCommand(historyFollowUpCmd)
+	historyCmd.AddCommand(historyInitKeyCmd)
+	historyCmd.AddCommand(historyEncryptCmd)
+	historyCmd.AddCommand(historyMigrateCmd)
 }
 
 func loadHistoryEntries(historyDir string, opts historyOptions) ([]historyEntry, error) {
@@ -172,18 +385,23 @@ func loadHistoryEntries(historyDir string, opts historyOptions) ([]historyEntry,
 			Cancelled: service.IsCancelled(path),
 		}
 
-		var content string
 		if readContent {
-			data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled history directory
+			saved, err := svc.ReadEntry(path)
 			if err != nil {
+				// Keep entries that can't be decrypted visible unless searching
+				if queryLower != "" {
+					continue
+				}
+				entry.Summary = summaryLine("🔒 "+err.Error(), 120)
+				entries = append(entries, entry)
 				continue
 			}
-			content = string(data)
-			if queryLower != "" && !strings.Contains(strings.ToLower(content), queryLower) {
+			text := strings.Join([]string{saved.Task, saved.Summary, saved.Capture, saved.Hooks}, "\n")
+			if queryLower != "" && !strings.Contains(strings.ToLower(text), queryLower) {
 				continue
 			}
 			if opts.withSummary {
-				entry.Summary = summaryLine(extractSummary(content), 120)
+				entry.Summary = summaryLine(saved.Summary, 120)
 			}
 		}
 
@@ -215,23 +433,6 @@ func parseHistoryTimestamp(path string) time.Time {
 	return parsed
 }
 
-func extractSummary(content string) string {
-	const summaryMarker = "\n---summary---\n"
-	idx := strings.Index(content, summaryMarker)
-	if idx == -1 {
-		return ""
-	}
-	start := idx + len(summaryMarker)
-	end := len(content)
-	for _, marker := range []string{"\n---capture---\n", "\n---hooks---\n"} {
-		if next := strings.Index(content[start:], marker); next != -1 {
-			end = start + next
-			break
-		}
-	}
-	return strings.TrimSpace(content[start:end])
-}
-
 func summaryLine(summary string, maxLen int) string {
 	if summary == "" {
 		return ""
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// defaultJUnitLimit is the most tasks 'paw import junit' creates without
+// --combined or a higher --limit.
+const defaultJUnitLimit = 10
+
+var (
+	junitCombined bool
+	junitLimit    int
+	junitDryRun   bool
+)
+
+var importJUnitCmd = &cobra.Command{
+	Use:   "junit <report.xml|url>",
+	Short: "Create tasks from the failing tests in a JUnit XML report",
+	Long: `Create a task per failing test in a JUnit XML report (from a file or an
+http(s) URL, e.g. a CI artifact), with the failure message and output in the
+task, in the running PAW session. --combined creates one task for all of them.
+
+Reports with more failing tests than --limit are refused, so a broken build
+doesn't open dozens of windows; use --combined or raise --limit.
+
+Examples:
+  paw import junit report.xml
+  paw import junit https://ci.example.com/job/42/junit.xml --combined
+  paw import junit report.xml --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		source := args[0]
+		data, err := service.ReadJUnitReport(source)
+		if err != nil {
+			return err
+		}
+		failures, err := service.ParseJUnitReport(data)
+		if err != nil {
+			return err
+		}
+		if len(failures) == 0 {
+			fmt.Printf("✅ No failing tests in %s\n", source)
+			return nil
+		}
+		if !junitCombined && len(failures) > junitLimit {
+			return fmt.Errorf("%s has %d failing tests, more than --limit %d; use --combined for one task, or raise --limit", source, len(failures), junitLimit)
+		}
+		tasks := service.JUnitTasks(failures, source, junitCombined)
+
+		if junitDryRun {
+			for _, t := range tasks {
+				fmt.Printf("── %s ──\n%s\n", t.Name, t.Content)
+			}
+			return nil
+		}
+
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		tm := newTmuxClient(appCtx.SessionName)
+		if !tm.HasSession(appCtx.SessionName) {
+			return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "import-junit", "")
+		defer cleanup()
+
+		fmt.Printf("%d failing tests in %s\n", len(failures), source)
+		return createSplitTasks(appCtx, tasks)
+	},
+}
+
+func init() {
+	importJUnitCmd.Flags().BoolVar(&junitCombined, "combined", false, "Create one task for all failing tests")
+	importJUnitCmd.Flags().IntVar(&junitLimit, "limit", defaultJUnitLimit, "Most tasks to create without --combined")
+	importJUnitCmd.Flags().BoolVar(&junitDryRun, "dry-run", false, "Print the tasks without creating them")
+	importCmd.AddCommand(importJUnitCmd)
+}
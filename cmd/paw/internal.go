@@ -18,6 +18,7 @@ func init() {
 	internalCmd.AddCommand(spawnTaskCmd)
 	internalCmd.AddCommand(handleTaskCmd)
 	internalCmd.AddCommand(filePickerCmd)
+	internalCmd.AddCommand(pasteTaskCmd)
 
 	// Task lifecycle commands
 	internalCmd.AddCommand(endTaskCmd)
@@ -29,6 +30,8 @@ func init() {
 	internalCmd.AddCommand(doneTaskCmd)
 	internalCmd.AddCommand(recoverTaskCmd)
 	internalCmd.AddCommand(resumeAgentCmd)
+	internalCmd.AddCommand(retryTaskCmd)
+	internalCmd.AddCommand(summarizeHistoryCmd)
 
 	// Sync commands
 	internalCmd.AddCommand(syncWithMainCmd)
@@ -59,6 +62,13 @@ func init() {
 	internalCmd.AddCommand(showCurrentTaskCmd)
 	internalCmd.AddCommand(finishPickerTUICmd)
 	internalCmd.AddCommand(prPopupTUICmd)
+	internalCmd.AddCommand(approvalPopupCmd)
+	internalCmd.AddCommand(approvalPopupTUICmd)
+	internalCmd.AddCommand(patchReviewTUICmd)
+	internalCmd.AddCommand(quickReplyCmd)
+	internalCmd.AddCommand(quickReplyTUICmd)
+	internalCmd.AddCommand(interruptTaskCmd)
+	internalCmd.AddCommand(steerTaskTUICmd)
 	internalCmd.AddCommand(togglePromptPickerCmd)
 	internalCmd.AddCommand(promptPickerTUICmd)
 	internalCmd.AddCommand(taskNameInputTUICmd)
@@ -67,23 +77,45 @@ func init() {
 	// Navigation commands
 	internalCmd.AddCommand(selectPrevWindowCmd)
 	internalCmd.AddCommand(selectNextWindowCmd)
+	internalCmd.AddCommand(zoomPaneCmd)
 	internalCmd.AddCommand(newShellWindowCmd)
+	internalCmd.AddCommand(focusTaskCmd)
 
 	// Utility commands
 	internalCmd.AddCommand(renameWindowCmd)
 	internalCmd.AddCommand(stopHookCmd)
+	internalCmd.AddCommand(renderStreamCmd)
 	internalCmd.AddCommand(userPromptSubmitHookCmd)
+	internalCmd.AddCommand(approveExecCmd)
+	internalCmd.AddCommand(proposePatchCmd)
+	internalCmd.AddCommand(snoozeTaskCmd)
+	internalCmd.AddCommand(toggleFocusFollowCmd)
+	internalCmd.AddCommand(refreshMuteCmd)
+	internalCmd.AddCommand(refreshPresenceCmd)
+	internalCmd.AddCommand(requestControlCmd)
 	internalCmd.AddCommand(askUserQuestionPreHookCmd)
 	internalCmd.AddCommand(askUserQuestionHookCmd)
 	internalCmd.AddCommand(watchWaitCmd)
 	internalCmd.AddCommand(watchPRCmd)
 	internalCmd.AddCommand(logPaneLayoutCmd)
+	internalCmd.AddCommand(saveLayoutCmd)
+	internalCmd.AddCommand(restoreLayoutCmd)
+	internalCmd.AddCommand(runSchedulesCmd)
+	internalCmd.AddCommand(editorServerCmd)
+	internalCmd.AddCommand(autopilotRunCmd)
 
 	// Add flags to end-task command
 	endTaskCmd.Flags().StringVar(&paneCaptureFile, "pane-capture-file", "", "Path to pre-captured pane content file")
 	endTaskCmd.Flags().BoolVar(&endTaskUserInitiated, "user-initiated", false, "Require explicit user action to finish")
-	endTaskCmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, drop")
+	endTaskCmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, push, merge, merge-push, pr, done, drop")
+	endTaskCmd.Flags().BoolVar(&endTaskKeepBranch, "keep-branch", false, "Keep the task branch on cleanup (default: keep_branch)")
+	endTaskCmd.Flags().BoolVar(&endTaskAuto, "auto", false, "Finish started by on_complete or a confirm timeout (a reviewer rejection keeps the task)")
 
 	// Add flags to end-task-ui command (receives action from finish-picker-tui)
-	endTaskUICmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, drop")
+	endTaskUICmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, push, merge, merge-push, pr, done, drop")
+	endTaskUICmd.Flags().BoolVar(&endTaskKeepBranch, "keep-branch", false, "Keep the task branch on cleanup (default: keep_branch)")
+	endTaskUICmd.Flags().BoolVar(&endTaskAuto, "auto", false, "Finish started by on_complete or a confirm timeout (a reviewer rejection keeps the task)")
+
+	// Add flags to propose-patch command (pair tasks)
+	proposePatchCmd.Flags().StringVarP(&proposePatchSummary, "summary", "s", "", "One-line summary of the change, shown to the user")
 }
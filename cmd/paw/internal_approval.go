@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var approveExecCmd = &cobra.Command{
+	Use:                "approve-exec [command] [args...]",
+	Short:              "Run a command after user approval (called from PATH shims)",
+	Args:               cobra.MinimumNArgs(1),
+	Hidden:             true,
+	DisableFlagParsing: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runApproveExec(args[0], args[1:])
+	},
+}
+
+var approvalPopupCmd = &cobra.Command{
+	Use:    "approval-popup [session]",
+	Short:  "Show the oldest pending command approval or patch review",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "approval-popup", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		agentDir := findPendingApproval(appCtx)
+		if agentDir == "" {
+			if agentDir = findPendingPatchProposal(appCtx); agentDir != "" {
+				showPatchReview(tm, sessionName, agentDir)
+				return nil
+			}
+			_ = tm.DisplayMessage("No pending approvals", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		showApprovalPopup(tm, sessionName, agentDir)
+		return nil
+	},
+}
+
+var approvalPopupTUICmd = &cobra.Command{
+	Use:    "approval-popup-tui [session] [agent-dir]",
+	Short:  "Run command approval TUI (called from popup)",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		agentDir := args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "approval-popup-tui", filepath.Base(agentDir))
+		defer cleanup()
+
+		t := task.New(filepath.Base(agentDir), agentDir)
+		req := service.LoadApprovalRequest(t.GetApprovalPath())
+		if req == nil || req.Decision != service.ApprovalPending {
+			return nil
+		}
+
+		approved, decided, err := tui.RunApprovalPopup(req.TaskName, req.Command, req.Dir)
+		if err != nil {
+			logging.Warn("RunApprovalPopup failed: %v", err)
+			return err
+		}
+		if !decided {
+			return nil
+		}
+
+		if err := service.DecideApproval(t.GetApprovalPath(), req.ID, approved); err != nil {
+			_ = newTmuxClient(sessionName).DisplayMessage(err.Error(), constants.DisplayMsgStandard)
+			return nil
+		}
+		logging.Log("Approval for %s: %s (approved=%v)", req.TaskName, req.Command, approved)
+		return nil
+	},
+}
+
+// setupApprovalShims creates a PATH shim for every command named in the
+// approval rules. Each shim routes the call through approve-exec.
+// Returns the shim directory, or "" if no rules are configured.
+func setupApprovalShims(appCtx *app.App, t *task.Task, pawBin string) string {
+	shimDir := t.GetApprovalShimDir()
+	_ = os.RemoveAll(shimDir)
+
+	if appCtx.Config == nil || len(appCtx.Config.ApprovalCommands) == 0 {
+		return ""
+	}
+
+	if err := os.MkdirAll(shimDir, 0755); err != nil { //nolint:gosec // G301: shim dir must be on the agent's PATH
+		logging.Warn("Failed to create approval shim dir: %v", err)
+		return ""
+	}
+
+	for _, name := range service.ApprovalCommandNames(appCtx.Config.ApprovalCommands) {
+		script := fmt.Sprintf("#!/bin/sh\n# Auto-generated by paw: %s needs approval for some arguments\nexec %s \"$@\"\n",
+			name, shellJoin(pawBin, "internal", "approve-exec", name))
+		if err := os.WriteFile(filepath.Join(shimDir, name), []byte(script), 0755); err != nil { //nolint:gosec // G306: shim needs to be executable
+			logging.Warn("Failed to create approval shim for %s: %v", name, err)
+		}
+	}
+
+	logging.Debug("Approval shims created: %s", shimDir)
+	return shimDir
+}
+
+// runApproveExec runs a shimmed command, asking the user first if it matches an approval rule.
+func runApproveExec(name string, args []string) error {
+	taskName := os.Getenv("TASK_NAME")
+	sessionName := os.Getenv("SESSION_NAME")
+
+	appCtx, err := getAppFromSession(sessionName)
+	if err != nil {
+		return err
+	}
+	t := task.New(taskName, filepath.Join(appCtx.AgentsDir, taskName))
+
+	realPath := lookPathExcluding(name, t.GetApprovalShimDir())
+	if realPath == "" {
+		fmt.Fprintf(os.Stderr, "%s: command not found\n", name)
+		os.Exit(127)
+	}
+	argv := append([]string{name}, args...)
+
+	rule := ""
+	if appCtx.Config != nil {
+		rule = service.MatchApprovalRule(appCtx.Config.ApprovalCommands, name, args)
+	}
+	if rule == "" {
+		return syscall.Exec(realPath, argv, os.Environ()) //nolint:gosec // G204: runs the command the agent asked for
+	}
+
+	// The process is replaced by the command (or exits), so close the logger explicitly
+	_, cleanup := setupLoggerFromApp(appCtx, "approve-exec", taskName)
+
+	// Only one request per task at a time (the agent may run commands in parallel)
+	approvalPath := t.GetApprovalPath()
+	waitForApprovalSlot(approvalPath)
+
+	cwd, _ := os.Getwd()
+	req := service.NewApprovalRequest(taskName, rule, name, args, cwd)
+	if err := service.SaveApprovalRequest(approvalPath, req); err != nil {
+		cleanup()
+		return err
+	}
+	logging.Log("Approval requested: %s (rule: %s)", req.Command, rule)
+
+	fmt.Fprintf(os.Stderr, "⏸️  paw: waiting for user approval to run: %s\n", req.Command)
+
+	tm := newTmuxClient(sessionName)
+	_ = notify.SendWithUrgency("Approval needed", fmt.Sprintf("🛑 %s: %s", taskName, req.Command), notify.UrgencyCritical)
+	notify.PlaySound(notify.SoundNeedInput)
+	_ = tm.DisplayMessage(fmt.Sprintf("🛑 %s wants to run: %s (⌥A to review)", taskName, req.Command), constants.DisplayMsgImportant)
+	go showApprovalPopup(tm, sessionName, t.AgentDir)
+
+	decision := service.WaitForApproval(approvalPath, req.ID, constants.ApprovalTimeout, constants.ApprovalPollInterval)
+	logging.Log("Approval %s: %s", decision, req.Command)
+
+	if decision == service.ApprovalApproved {
+		cleanup()
+		return syscall.Exec(realPath, argv, os.Environ()) //nolint:gosec // G204: approved by the user
+	}
+
+	reason := "denied by the user"
+	if decision == service.ApprovalTimedOut {
+		reason = "not approved in time"
+	}
+	fmt.Fprintf(os.Stderr, "⛔ paw: %s was %s. Do not retry it; ask the user how to proceed.\n", req.Command, reason)
+	cleanup()
+	os.Exit(1)
+	return nil
+}
+
+// waitForApprovalSlot waits until no other request of the task is pending.
+func waitForApprovalSlot(path string) {
+	for i := 0; i < int(constants.ApprovalTimeout/constants.ApprovalPollInterval); i++ {
+		req := service.LoadApprovalRequest(path)
+		if req == nil || req.Decision != service.ApprovalPending {
+			return
+		}
+		time.Sleep(constants.ApprovalPollInterval)
+	}
+}
+
+// lookPathExcluding finds an executable on PATH, skipping the given directory.
+func lookPathExcluding(name, excludeDir string) string {
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || filepath.Clean(dir) == filepath.Clean(excludeDir) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return path
+		}
+	}
+	return ""
+}
+
+// findPendingApproval returns the agent directory of the oldest pending approval request.
+func findPendingApproval(appCtx *app.App) string {
+	entries, err := os.ReadDir(appCtx.AgentsDir)
+	if err != nil {
+		return ""
+	}
+
+	type pending struct {
+		agentDir    string
+		requestedAt string
+	}
+	var found []pending
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		agentDir := filepath.Join(appCtx.AgentsDir, entry.Name())
+		req := service.LoadApprovalRequest(task.New(entry.Name(), agentDir).GetApprovalPath())
+		if req != nil && req.Decision == service.ApprovalPending {
+			found = append(found, pending{agentDir: agentDir, requestedAt: req.RequestedAt})
+		}
+	}
+	if len(found) == 0 {
+		return ""
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].requestedAt < found[j].requestedAt })
+	return found[0].agentDir
+}
+
+// showApprovalPopup opens the approval popup for a task's pending request.
+func showApprovalPopup(tm tmux.Client, sessionName, agentDir string) {
+	popupCmd := shellJoin(getPawBin(), "internal", "approval-popup-tui", sessionName, agentDir)
+	_ = tm.DisplayPopup(tmux.PopupOpts{
+		Width:  constants.PopupWidthApproval,
+		Height: constants.PopupHeightApproval,
+		Title:  " Approval Needed ",
+		Close:  true,
+		Style:  "fg=terminal,bg=terminal",
+	}, popupCmd)
+}
@@ -7,7 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -45,57 +45,62 @@ var toggleNewCmd = &cobra.Command{
 		logging.Debug("-> toggleNewCmd(session=%s)", sessionName)
 		defer logging.Debug("<- toggleNewCmd")
 
-		tm := tmux.New(sessionName)
+		return openNewTaskWindow(newTmuxClient(sessionName), appCtx, sessionName)
+	},
+}
 
-		// Check if _ window exists
-		windows, err := tm.ListWindows()
-		if err != nil {
-			return err
-		}
+// openNewTaskWindow selects the new task window, creating it (with the
+// new-task loop running) if it doesn't exist.
+func openNewTaskWindow(tm tmux.Client, appCtx *app.App, sessionName string) error {
+	// Check if _ window exists
+	windows, err := tm.ListWindows()
+	if err != nil {
+		return err
+	}
 
-		for _, w := range windows {
-			if strings.HasPrefix(w.Name, constants.EmojiNew) {
-				// Window exists, just select it (don't send command again to avoid pasting into vim/editor)
-				logging.Trace("toggleNewCmd: new task window already exists, selecting windowID=%s", w.ID)
-				return tm.SelectWindow(w.ID)
-			}
+	for _, w := range windows {
+		if strings.HasPrefix(w.Name, constants.EmojiNew) {
+			// Window exists, just select it (don't send command again to avoid pasting into vim/editor)
+			logging.Trace("openNewTaskWindow: new task window already exists, selecting windowID=%s", w.ID)
+			restartNewTaskLoop(tm, appCtx, w.ID)
+			return tm.SelectWindow(w.ID)
 		}
+	}
 
-		// Create new window without command (keeps shell open)
-		logging.Trace("toggleNewCmd: creating new task window name=%s", constants.NewWindowName)
-		windowID, err := tm.NewWindow(tmux.WindowOpts{
-			Name:     constants.NewWindowName,
-			StartDir: appCtx.ProjectDir,
-		})
-		if err != nil {
-			return err
-		}
-		logging.Trace("toggleNewCmd: new task window created windowID=%s", windowID)
+	// Create new window without command (keeps shell open)
+	logging.Trace("openNewTaskWindow: creating new task window name=%s", constants.NewWindowName)
+	windowID, err := tm.NewWindow(tmux.WindowOpts{
+		Name:     constants.NewWindowName,
+		StartDir: appCtx.ProjectDir,
+	})
+	if err != nil {
+		return err
+	}
+	logging.Trace("openNewTaskWindow: new task window created windowID=%s", windowID)
 
-		// Wait for shell to be ready before sending keys
-		paneID := windowID + ".0"
-		if err := tm.WaitForPane(paneID, constants.PaneWaitTimeout, 1); err != nil {
-			logging.Warn("toggleNewCmd: WaitForPane timed out, continuing anyway: %v", err)
-		}
+	// Wait for shell to be ready before sending keys
+	paneID := windowID + ".0"
+	if err := tm.WaitForPane(paneID, constants.PaneWaitTimeout, 1); err != nil {
+		logging.Warn("openNewTaskWindow: WaitForPane timed out, continuing anyway: %v", err)
+	}
 
-		// Create file picker pane on the left side of the main window before launching the TUI.
-		if err := createFilePickerPane(tm, appCtx, windowID); err != nil {
-			logging.Warn("Failed to create file picker pane: %v", err)
-			// Non-fatal: continue without file picker pane
-		}
+	// Create file picker pane on the left side of the main window before launching the TUI.
+	if err := createFilePickerPane(tm, appCtx, windowID); err != nil {
+		logging.Warn("Failed to create file picker pane: %v", err)
+		// Non-fatal: continue without file picker pane
+	}
 
-		// Send new-task command to the new window
-		// Include PAW_DIR, PROJECT_DIR, and DISPLAY_NAME so getAppFromSession can find the project
-		newTaskCmdStr := buildNewTaskCommand(appCtx, getPawBin(), sessionName)
-		if err := tm.SendKeysLiteral(windowID, newTaskCmdStr); err != nil {
-			return fmt.Errorf("failed to send keys: %w", err)
-		}
-		if err := tm.SendKeys(windowID, "Enter"); err != nil {
-			return fmt.Errorf("failed to send Enter: %w", err)
-		}
+	// Send new-task command to the new window
+	// Include PAW_DIR, PROJECT_DIR, and DISPLAY_NAME so getAppFromSession can find the project
+	newTaskCmdStr := buildNewTaskCommand(appCtx, getPawBin(), sessionName)
+	if err := tm.SendKeysLiteral(windowID, newTaskCmdStr); err != nil {
+		return fmt.Errorf("failed to send keys: %w", err)
+	}
+	if err := tm.SendKeys(windowID, "Enter"); err != nil {
+		return fmt.Errorf("failed to send Enter: %w", err)
+	}
 
-		return nil
-	},
+	return nil
 }
 
 // createFilePickerPane creates a file picker pane on the left side of the window.
@@ -198,9 +203,6 @@ var newTaskCmd = &cobra.Command{
 		tui.SetProjectName(displayName)
 		tui.SetSessionName(sessionName)
 
-		// Initialize input history service (used for saving history after task creation)
-		inputHistorySvc := service.NewInputHistoryService(appCtx.PawDir)
-
 		// Get active task names for dependency selection
 		activeTasks := getActiveTaskNames(appCtx.AgentsDir)
 
@@ -219,6 +221,12 @@ var newTaskCmd = &cobra.Command{
 				continue
 			}
 
+			if result.Quit {
+				logging.Log("New-task loop stopped by user")
+				fmt.Println("New-task loop stopped. Press ⌃N in this window to restart it.")
+				return execShell()
+			}
+
 			// Handle cross-project jump request
 			if result.JumpTarget != nil {
 				target := result.JumpTarget
@@ -231,7 +239,7 @@ var newTaskCmd = &cobra.Command{
 				}
 
 				// Select the task window in target session
-				targetTm := tmux.New(target.Session)
+				targetTm := newTmuxClient(target.Session)
 				if err := targetTm.SelectWindow(target.WindowID); err != nil {
 					logging.Warn("Failed to select target window: %v", err)
 				}
@@ -239,11 +247,10 @@ var newTaskCmd = &cobra.Command{
 				// Use detach-client -E to replace the current client with a new attachment
 				// to the target session. This works across different tmux sockets and
 				// prevents nesting (unlike syscall.Exec which would create nested tmux).
-				targetSocket := constants.TmuxSocketPrefix + target.Session
-				switchCmd := fmt.Sprintf("tmux -L %s attach-session -t %s", shellQuote(targetSocket), shellQuote(target.Session))
+				switchCmd := shellJoin(tmux.AttachArgs(target.Session)...)
 				logging.Debug("Switching to session via detach-client -E: %s", switchCmd)
 
-				tm := tmux.New(sessionName)
+				tm := newTmuxClient(sessionName)
 				if err := tm.Run("detach-client", "-E", switchCmd); err != nil {
 					logging.Error("Failed to switch session: %v", err)
 					fmt.Printf("Failed to switch to session %s: %v\n", target.Session, err)
@@ -277,6 +284,8 @@ var newTaskCmd = &cobra.Command{
 			}
 
 			content := result.Content
+			// Whoever submitted gets the entry in their history
+			inputHistorySvc := inputHistoryService(appCtx, newTmuxClient(sessionName))
 
 			// Save content to temp file for spawn-task to read
 			tmpFile, err := os.CreateTemp("", "paw-task-content-*.txt")
@@ -336,7 +345,8 @@ var newTaskCmd = &cobra.Command{
 				continue
 			}
 
-			// Save content to input history (after successful spawn)
+			// Save content to input history (after successful spawn), per user
+			// in shared sessions
 			if err := inputHistorySvc.SaveInput(content); err != nil {
 				logging.Warn("Failed to save input history: %v", err)
 			}
@@ -416,7 +426,7 @@ var spawnTaskCmd = &cobra.Command{
 		_, cleanup := setupLoggerFromApp(appCtx, "spawn-task", "")
 		defer cleanup()
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		pawBin := getPawBin()
 
 		// Create a temporary "⏳" window for progress display
@@ -448,65 +458,81 @@ var spawnTaskCmd = &cobra.Command{
 		}
 
 		// Create task (loading screen shows while this runs)
-		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
-
-		// Pass custom branch name if provided in options
-		var customBranchName string
-		if taskOpts != nil && taskOpts.BranchName != "" {
-			customBranchName = taskOpts.BranchName
-			logging.Debug("Using custom branch name from options: %s", customBranchName)
+		// Steps separated by ---then--- become a chain of tasks, each
+		// starting once the previous one succeeds
+		steps := service.SplitThenSteps(content)
+		if len(steps) == 0 {
+			steps = []string{content}
 		}
+		prevName := ""
+		for i, step := range steps {
+			stepOpts := taskOpts
+			if i > 0 {
+				if taskOpts != nil {
+					stepOpts = taskOpts.Clone()
+				} else {
+					stepOpts = &config.TaskOptions{}
+				}
+				stepOpts.BranchName = ""
+				stepOpts.DependsOn = &config.TaskDependency{TaskName: prevName, Condition: config.DependsOnSuccess}
+			}
 
-		newTask, err := mgr.CreateTask(content, customBranchName)
-		if err != nil {
-			logging.Error("Failed to create task: %v", err)
-			return fmt.Errorf("failed to create task: %w", err)
+			newTask, err := spawnTask(appCtx, step, stepOpts)
+			if err != nil {
+				return err
+			}
+			if len(steps) > 1 {
+				logging.Log("Chained task %d/%d created: %s", i+1, len(steps), newTask.Name)
+			}
+			prevName = newTask.Name
 		}
 
-		logging.Log("Task created: %s", newTask.Name)
+		return nil
+	},
+}
 
-		// Save task options if provided
-		if taskOpts != nil {
-			if err := taskOpts.Save(newTask.AgentDir); err != nil {
-				logging.Warn("Failed to save task options: %v", err)
-			} else {
-				logging.Debug("Task options saved: model=%s", taskOpts.Model)
-			}
-		}
+// spawnTask creates a task, saves its options, and starts its handler,
+// waiting until the task window exists. The options' branch name is used as
+// the task name when set, and is then replaced by the name the task got,
+// since a name may get a numeric suffix when it already exists.
+func spawnTask(appCtx *app.App, content string, taskOpts *config.TaskOptions) (*task.Task, error) {
+	if taskOpts == nil {
+		taskOpts = &config.TaskOptions{}
+	}
+	if taskOpts.BranchName != "" {
+		logging.Debug("Using custom branch name from options: %s", taskOpts.BranchName)
+	}
 
-		// Handle task (creates actual window, starts Claude)
-		handleCmd := exec.Command(pawBin, "internal", "handle-task", sessionName, newTask.AgentDir) //nolint:gosec // G204: pawBin is from getPawBin()
-		// Pass PAW_DIR and PROJECT_DIR so getAppFromSession can find the project
-		// (required for global workspaces where there's no local .paw directory)
-		handleCmd.Env = append(os.Environ(),
-			"PAW_DIR="+appCtx.PawDir,
-			"PROJECT_DIR="+appCtx.ProjectDir,
-		)
-		if err := handleCmd.Start(); err != nil {
-			logging.Warn("Failed to start handle-task: %v", err)
-			return fmt.Errorf("failed to start task handler: %w", err)
-		}
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	newTask, err := mgr.CreateTask(content, taskOpts.BranchName)
+	if err != nil {
+		logging.Error("Failed to create task: %v", err)
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
 
-		// Wait for handle-task to create the window
-		windowIDFile := filepath.Join(newTask.AgentDir, constants.TabLockDirName, constants.WindowIDFileName)
-		for i := 0; i < constants.WindowIDWaitMaxAttempts; i++ {
-			if _, err := os.Stat(windowIDFile); err == nil {
-				break
-			}
-			time.Sleep(constants.WindowIDWaitInterval)
-		}
+	logging.Log("Task created: %s", newTask.Name)
 
-		logging.Debug("Task window created for: %s", newTask.Name)
+	taskOpts.BranchName = newTask.Name
+	if err := taskOpts.Save(newTask.AgentDir); err != nil {
+		return nil, fmt.Errorf("failed to save options of task %s: %w", newTask.Name, err)
+	}
+	logging.Debug("Task options saved: model=%s", taskOpts.Model)
 
-		return nil
-	},
+	// Handle task (creates actual window, starts Claude)
+	if err := startTaskHandler(appCtx, newTask); err != nil {
+		logging.Warn("Failed to start handle-task: %v", err)
+		return nil, err
+	}
+
+	logging.Debug("Task window created for: %s", newTask.Name)
+	return newTask, nil
 }
 
 // ensureMainWindowInSession ensures the main window (⭐️main) exists in the given session.
 // If it doesn't exist, it creates one. This is used when jumping to another project
 // to ensure the target session has a properly functioning main window.
 func ensureMainWindowInSession(sessionName string) error {
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 
 	// Check if main window exists
 	windows, err := tm.ListWindows()
@@ -570,7 +596,7 @@ func ensureMainWindowInSession(sessionName string) error {
 // showTaskNamePopup shows a popup for entering a task name directly.
 // Returns the entered task name, or empty string if cancelled/failed.
 func showTaskNamePopup(sessionName string, appCtx *app.App) string {
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 
 	// Build the task name input TUI command
 	tuiCmd := shellJoin(getPawBin(), "internal", "task-name-input-tui", sessionName)
@@ -654,7 +680,7 @@ func createTaskWithName(sessionName string, appCtx *app.App, taskName string) er
 	_ = optsTmpFile.Close()
 
 	// Initialize input history service
-	inputHistorySvc := service.NewInputHistoryService(appCtx.PawDir)
+	inputHistorySvc := inputHistoryService(appCtx, newTmuxClient(sessionName))
 
 	// Spawn task creation in a separate window (non-blocking)
 	spawnArgs := []string{"internal", "spawn-task", sessionName, tmpFile.Name(), optsTmpFile.Name()}
@@ -673,3 +699,40 @@ func createTaskWithName(sessionName string, appCtx *app.App, taskName string) er
 	logging.Debug("createTaskWithName: task spawned, content file: %s, opts file: %s", tmpFile.Name(), optsTmpFile.Name())
 	return nil
 }
+
+// restartNewTaskLoop restarts the new-task loop in the window after it was
+// quit. The command is only sent when the pane sits at a shell prompt, so it
+// never gets typed into an editor or another program.
+func restartNewTaskLoop(tm tmux.Client, appCtx *app.App, windowID string) {
+	paneID := windowID + ".0"
+	if tm.HasPane(windowID + ".1") {
+		paneID = windowID + ".1"
+	}
+	command, err := tm.GetPaneCommand(paneID)
+	if err != nil || !isShellCommand(command) {
+		return
+	}
+	logging.Log("Restarting new-task loop in %s", paneID)
+	_ = tm.SendKeysLiteral(paneID, buildNewTaskCommand(appCtx, getPawBin(), appCtx.SessionName))
+	_ = tm.SendKeys(paneID, "Enter")
+	_ = tm.SelectPane(paneID)
+}
+
+// isShellCommand reports whether a pane's current command is a shell.
+func isShellCommand(command string) bool {
+	switch strings.TrimPrefix(filepath.Base(strings.TrimSpace(command)), "-") {
+	case "sh", "bash", "zsh", "fish", "dash", "ksh", "tcsh", "csh", "nu":
+		return true
+	}
+	return false
+}
+
+// execShell replaces the process with the user's shell so the pane stays
+// usable after the new-task loop is quit, however the loop was started.
+func execShell() error {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	return syscall.Exec(shell, []string{shell}, os.Environ()) //nolint:gosec // G204: the user's own shell
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -16,6 +17,8 @@ import (
 	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/embed"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/lock"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
 	"github.com/dongho-jung/paw/internal/service"
@@ -62,7 +65,20 @@ var handleTaskCmd = &cobra.Command{
 			logging.Warn("Failed to load task options: %v", err)
 			taskOpts = config.DefaultTaskOptions()
 		}
-		logging.Debug("Task options: model=%s", taskOpts.Model)
+		logging.Debug("Task options: model=%s, research=%v, pair=%v", taskOpts.Model, taskOpts.Research, taskOpts.Pair)
+
+		// Serialize handle-task runs for this task
+		handleLock, err := lock.TryAcquire(filepath.Join(agentDir, constants.HandleLockFileName), "handle-task", lock.Options{TTL: constants.HandleLockTTL})
+		if err != nil {
+			if errors.Is(err, lock.ErrLocked) {
+				logging.Debug("Task already being handled by another process: %v", err)
+				return nil
+			}
+			return fmt.Errorf("failed to acquire handle lock: %w", err)
+		}
+		defer func() { _ = handleLock.Release() }()
+
+		tm := newTmuxClient(sessionName)
 
 		// Create tab-lock atomically
 		created, err := t.CreateTabLock()
@@ -71,21 +87,39 @@ var handleTaskCmd = &cobra.Command{
 			return err
 		}
 		if !created {
-			logging.Debug("Task already being handled by another process")
-			return nil
+			// With the handle lock held, a tab-lock without a live window was
+			// left behind by a handle-task that crashed during setup
+			if windowID, err := t.LoadWindowID(); err == nil && windowID != "" && tm.HasPane(windowID) {
+				logging.Debug("Task already has window %s", windowID)
+				return nil
+			}
+			logging.Warn("Removing stale tab-lock for task %s", taskName)
+			if err := t.RemoveTabLock(); err != nil {
+				return fmt.Errorf("failed to remove stale tab-lock: %w", err)
+			}
+			if _, err := t.CreateTabLock(); err != nil {
+				logging.Error("Failed to create tab-lock: %v", err)
+				return err
+			}
 		}
 		logging.Debug("Tab-lock created successfully")
 
+		// Apply on_complete_branches from the branch the task starts from
+		if !t.HasSessionMarker() {
+			resolveTaskOnComplete(appCtx, taskOpts, agentDir)
+		}
+
 		// Track if this is a reopen case (for session resume)
 		isReopen := false
 
 		// Setup worktree if git mode (skip if worktree already exists - reopen case)
-		if appCtx.IsWorktreeMode() {
+		// Research tasks are read-only and run in the project directory
+		if appCtx.IsWorktreeMode() && !taskOpts.Research {
 			worktreeDir := t.GetWorktreeDir()
 			if _, err := os.Stat(worktreeDir); os.IsNotExist(err) {
 				// Worktree doesn't exist, create it
 				timer := logging.StartTimer("worktree setup")
-				if err := mgr.SetupWorktree(t); err != nil {
+				if err := setupTaskWorktree(appCtx, mgr, t, taskOpts, agentDir); err != nil {
 					timer.StopWithResult(false, err.Error())
 					_ = t.RemoveTabLock()
 					return fmt.Errorf("failed to setup worktree: %w", err)
@@ -102,7 +136,7 @@ var handleTaskCmd = &cobra.Command{
 				}
 			}
 		} else {
-			// Non-worktree mode: check session marker for reopen
+			// Non-worktree mode / research task: check session marker for reopen
 			if t.HasSessionMarker() {
 				isReopen = true
 				logging.Log("Session resume: detected previous session for task %s", taskName)
@@ -116,30 +150,21 @@ var handleTaskCmd = &cobra.Command{
 			}
 		}
 
-		// Setup symlinks (error is non-fatal)
-		if err := t.SetupSymlinks(appCtx.ProjectDir); err != nil {
-			logging.Warn("Failed to setup symlinks: %v", err)
-		}
+		// Setup origin and .claude links (errors are non-fatal)
+		setupTaskLinks(appCtx, t)
 
-		// Setup .claude symlink for stop-hook support
-		// For worktree mode: SetupWorktree already creates symlink in agent dir (outside git)
-		// For non-worktree mode: symlink in project directory (Claude runs there)
-		if appCtx.IsWorktreeMode() {
-			// Worktree mode: ensure .claude exists in agent dir (handles reopen case)
-			// SetupWorktree creates this for new tasks, but we need it for reopen too
-			if err := t.SetupClaudeSymlink(appCtx.PawDir); err != nil {
-				logging.Warn("Failed to setup claude symlink in agent dir: %v", err)
-			}
-		} else {
-			// Non-worktree mode: symlink in project directory
-			if err := t.SetupClaudeSymlinkInDir(appCtx.PawDir, appCtx.ProjectDir); err != nil {
-				logging.Warn("Failed to setup claude symlink in project dir: %v", err)
+		if !taskOpts.Research {
+			if err := os.MkdirAll(t.GetArtifactsDir(), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+				logging.Warn("Failed to create artifacts directory: %v", err)
 			}
 		}
 
 		// Create tmux window
-		tm := tmux.New(sessionName)
 		workDir := mgr.GetWorkingDirectory(t)
+		// Pair tasks may only change files through approved patches
+		if taskOpts.Pair && !taskOpts.Research && !isReopen && appCtx.IsGitRepo {
+			recordPairTree(git.New(), t, workDir)
+		}
 		windowName := t.GetWindowName()
 		logging.Trace("handleTaskCmd: creating task window name=%s workDir=%s", windowName, workDir)
 		logging.Debug("Creating tmux window: session=%s, workDir=%s", sessionName, workDir)
@@ -197,18 +222,29 @@ var handleTaskCmd = &cobra.Command{
 			}
 		}
 
-		// Split window for user pane (error is non-fatal)
-		userPaneCmd := shellCommand("exec " + shellQuote(getShell()))
-		if err := tm.SplitWindow(windowID, true, workDir, userPaneCmd); err != nil {
-			logging.Warn("Failed to split window: %v", err)
-		} else {
-			logging.Trace("Window split for user pane: startDir=%s", workDir)
-		}
+		// Split window for the user pane and extra pane (errors are non-fatal)
+		splitTaskPanes(tm, windowID, workDir, taskLayout(appCtx.Config), shellCommand("exec "+shellQuote(getShell())))
 
 		// Build system prompt
 		globalPrompt, _ := embed.GetPrompt(appCtx.IsGitRepo)
+		if taskOpts.Research {
+			globalPrompt, _ = embed.GetResearchPrompt()
+		} else if variantPrompt := assignPromptVariant(appCtx, t, taskOpts, agentDir, isReopen); variantPrompt != "" {
+			globalPrompt = variantPrompt
+		}
+		if taskOpts.Pair && !taskOpts.Research {
+			pairPrompt, _ := embed.GetPairPrompt()
+			globalPrompt += "\n\n" + pairPrompt
+		}
 		projectPrompt, _ := os.ReadFile(appCtx.GetPromptPath())
-		systemPrompt := claude.BuildSystemPrompt(globalPrompt, string(projectPrompt))
+		var contextPaths []string
+		contextBudget := constants.DefaultContextMaxKB * 1024
+		if appCtx.Config != nil {
+			contextPaths = appCtx.Config.ContextFiles
+			contextBudget = appCtx.Config.ContextMaxKB * 1024
+		}
+		contextFiles := loadContextFiles(appCtx.ProjectDir, append(append([]string(nil), contextPaths...), taskOpts.ContextFiles...))
+		systemPrompt := claude.BuildSystemPrompt(globalPrompt, string(projectPrompt), contextFiles, contextBudget)
 
 		// Get paw binary path for end-task script
 		pawBin := getPawBin()
@@ -216,7 +252,19 @@ var handleTaskCmd = &cobra.Command{
 		pawBinSymlink := filepath.Join(appCtx.PawDir, constants.BinSymlinkName)
 
 		// Build task context and user prompt (context stored separately, referenced via @path)
-		taskContext := buildTaskContextPrompt(appCtx, taskName, workDir)
+		repoMap, err := service.EnsureRepoMap(appCtx.PawDir, appCtx.ProjectDir)
+		if err != nil {
+			logging.Warn("Failed to update repo map: %v", err)
+		}
+		fileHistory := ""
+		if appCtx.IsGitRepo && appCtx.Config != nil && appCtx.Config.FileHistory {
+			historyDir := appCtx.ProjectDir
+			if appCtx.IsWorktreeMode() && !taskOpts.Research {
+				historyDir = workDir
+			}
+			fileHistory = service.FormatFileHistory(service.CollectFileHistory(git.New(), historyDir, t.Content))
+		}
+		taskContext := buildTaskContextPrompt(appCtx, taskName, workDir, repoMap, fileHistory, taskOpts.Research)
 		contextPath := t.GetTaskContextPath()
 		contextRef := ""
 		if err := os.WriteFile(contextPath, []byte(taskContext), 0644); err != nil { //nolint:gosec // G306: context file needs to be readable by Claude
@@ -249,6 +297,9 @@ exec %s
 			logging.Debug("End-task script created: %s", endTaskScriptPath)
 		}
 
+		// Route commands that need approval through PATH shims
+		setupApprovalShims(appCtx, t, pawBin)
+
 		// Create start-agent script to avoid shell escaping issues with tmux
 		startAgentScriptPath := filepath.Join(t.AgentDir, constants.StartAgentScriptName)
 		startAgentContent := buildStartAgentScript(appCtx, t, taskOpts, windowID, workDir, systemPrompt, pawBin, pawBinSymlink, sessionName, isReopen)
@@ -267,47 +318,28 @@ exec %s
 			}
 		}
 
-		// Start Claude using the start-agent script
-		if err := tm.RespawnPane(agentPane, workDir, shellQuote(startAgentScriptPath)); err != nil {
-			return fmt.Errorf("failed to start Claude: %w", err)
-		}
+		// Outside the working hours, queue the task until they start
+		waitForWorkingHours(appCtx, tm, windowID, t)
 
-		// Wait for Claude to be ready
-		logging.Debug("Waiting for Claude to be ready...")
-		claudeClient := claude.New()
-		claudeTimer := logging.StartTimer("Claude startup")
-		if err := claudeClient.WaitForReady(tm, agentPane); err != nil {
-			claudeTimer.StopWithResult(false, err.Error())
-			logging.Warn("WaitForReady timed out, continuing anyway...")
-		} else {
-			claudeTimer.StopWithResult(true, "")
-		}
-
-		// Verify Claude is actually running (has content)
-		verifyTimer := logging.StartTimer("Verify Claude alive")
-		if err := claudeClient.VerifyPaneAlive(tm, agentPane, 10*time.Second); err != nil {
-			verifyTimer.StopWithResult(false, err.Error())
-			logging.Warn("Claude pane may not be alive: %v", err)
-		} else {
-			verifyTimer.StopWithResult(true, "")
+		// Queue new tasks while the daily token budget is used up
+		if !isReopen {
+			waitForTokenBudget(appCtx, tm, windowID, t)
 		}
 
-		// Send trust response if needed (error is non-fatal)
-		if err := claudeClient.SendTrustResponse(tm, agentPane); err != nil {
-			logging.Trace("Failed to send trust response: %v", err)
-		} else {
-			logging.Debug("Trust response sent")
+		// Start Claude using the start-agent script
+		if err := tm.RespawnPane(agentPane, workDir, shellQuote(startAgentScriptPath)); err != nil {
+			return fmt.Errorf("failed to start Claude: %w", err)
 		}
 
-		// Wait a bit more for Claude to be fully ready
-		time.Sleep(1 * time.Second)
-
-		if isReopen {
-			// Resume mode: don't clear history or send task instruction
-			logging.Log("Session resumed: task=%s, windowID=%s", taskName, windowID)
+		if useStreamJSON(appCtx, isReopen) {
+			// The task prompt is already on the command line; render-stream
+			// reports the status once the headless run ends.
+			if err := t.CreateSessionMarker(); err != nil {
+				logging.Warn("Failed to create session marker: %v", err)
+			}
+			logging.Log("Task started (stream-json): name=%s, windowID=%s", taskName, windowID)
 		} else {
-			// New task: clear screen and send task instruction
-			startNewTaskSession(tm, claudeClient, agentPane, t, taskName, windowID)
+			startInteractiveAgent(appCtx, tm, agentPane, t, taskName, windowID, isReopen)
 		}
 
 		// Start wait watcher to handle window status + notifications when user input is needed
@@ -345,34 +377,118 @@ exec %s
 	},
 }
 
+// loadContextFiles reads the files to attach to the system prompt. Relative paths
+// are resolved against the project; duplicates and unreadable files are skipped.
+func loadContextFiles(projectDir string, paths []string) []claude.ContextFile {
+	seen := make(map[string]bool, len(paths))
+	files := make([]claude.ContextFile, 0, len(paths))
+	for _, path := range paths {
+		fullPath := path
+		if !filepath.IsAbs(path) {
+			fullPath = filepath.Join(projectDir, path)
+		}
+		fullPath = filepath.Clean(fullPath)
+		if seen[fullPath] {
+			continue
+		}
+		seen[fullPath] = true
+
+		content, err := os.ReadFile(fullPath) //nolint:gosec // G304: context files are configured by the user
+		if err != nil {
+			logging.Warn("Skipping context file %s: %v", path, err)
+			continue
+		}
+		files = append(files, claude.ContextFile{Path: path, Content: string(content)})
+	}
+	return files
+}
+
 // buildTaskContextPrompt constructs the task preamble stored separately.
-func buildTaskContextPrompt(appCtx *app.App, taskName, workDir string) string {
+func buildTaskContextPrompt(appCtx *app.App, taskName, workDir, repoMap, fileHistory string, research bool) string {
 	var userPrompt strings.Builder
 	userPrompt.WriteString(fmt.Sprintf("# Task: %s\n\n", taskName))
+	if research {
+		userPrompt.WriteString("**Mode**: Read-only research (no worktree, no commits)\n")
+		userPrompt.WriteString(fmt.Sprintf("**Project**: %s\n", appCtx.ProjectDir))
+		userPrompt.WriteString(fmt.Sprintf("**Answer file**: %s\n\n", filepath.Join(appCtx.AgentsDir, taskName, constants.ResearchAnswerFile)))
+		userPrompt.WriteString("**Finish**: User triggers completion with Ctrl+F. Do not call end-task automatically.\n\n")
+		writeRepoMapSection(&userPrompt, repoMap)
+		writeFileHistorySection(&userPrompt, fileHistory)
+		userPrompt.WriteString("---\n\n")
+		return userPrompt.String()
+	}
 	if appCtx.IsWorktreeMode() {
 		userPrompt.WriteString(fmt.Sprintf("**Worktree**: %s\n", workDir))
-		userPrompt.WriteString(fmt.Sprintf("**Project**: %s\n\n", appCtx.ProjectDir))
+		userPrompt.WriteString(fmt.Sprintf("**Project**: %s\n", appCtx.ProjectDir))
 	} else {
 		// Non-git mode: Claude runs in agent dir, accesses project via origin/
 		userPrompt.WriteString(fmt.Sprintf("**Working Dir**: %s\n", workDir))
-		userPrompt.WriteString(fmt.Sprintf("**Project**: %s (access via `origin/`)\n\n", appCtx.ProjectDir))
+		userPrompt.WriteString(fmt.Sprintf("**Project**: %s (access via `origin/`)\n", appCtx.ProjectDir))
 	}
+	userPrompt.WriteString(fmt.Sprintf("**Artifacts**: %s (reports and build outputs; saved to history)\n\n", filepath.Join(appCtx.AgentsDir, taskName, constants.ArtifactsDirName)))
 
 	userPrompt.WriteString("**Finish**: User triggers completion with Ctrl+F. Do not call end-task automatically.\n\n")
 
+	var commands []config.CommandEntry
+	verify := false
+	if appCtx.Config != nil {
+		commands = appCtx.Config.Commands.Entries()
+		verify = appCtx.Config.VerifyBeforePush && len(appCtx.Config.Commands.VerifyEntries()) > 0
+	}
+	if len(commands) > 0 {
+		userPrompt.WriteString("## 🛠️ Project commands\n\n")
+		for _, c := range commands {
+			userPrompt.WriteString(fmt.Sprintf("- **%s**: `%s`\n", c.Name, c.Command))
+		}
+		if verify {
+			userPrompt.WriteString("\nBuild, lint, and test run automatically before the task is pushed; a failure keeps the task open.\n")
+		}
+		userPrompt.WriteString("\n")
+	}
+
 	// Add Plan Mode instructions (always shown since we start in plan mode)
 	userPrompt.WriteString("## 📋 PLAN MODE (Required)\n\n")
 	userPrompt.WriteString("You are starting in **Plan Mode**. Before writing any code:\n\n")
-	userPrompt.WriteString("1. **Project analysis**: Identify build/test commands.\n")
+	if len(commands) > 0 {
+		userPrompt.WriteString("1. **Project analysis**: Use the project commands above to build and test.\n")
+	} else {
+		userPrompt.WriteString("1. **Project analysis**: Identify build/test commands.\n")
+	}
 	userPrompt.WriteString("2. **Write the Plan** including:\n")
 	userPrompt.WriteString("   - Implementation steps\n")
 	userPrompt.WriteString("   - **✅ How to validate success** (state whether automated verification is possible)\n")
 	userPrompt.WriteString("3. Start implementation after the plan is ready.\n\n")
 
+	writeRepoMapSection(&userPrompt, repoMap)
+	writeFileHistorySection(&userPrompt, fileHistory)
 	userPrompt.WriteString("---\n\n")
 	return userPrompt.String()
 }
 
+// writeRepoMapSection adds the cached repository map so the agent can skip
+// exploring the project layout.
+func writeRepoMapSection(sb *strings.Builder, repoMap string) {
+	if repoMap == "" {
+		return
+	}
+	sb.WriteString("## 🗺️ Repository map\n\n")
+	// Nest the map's sections under this heading
+	sb.WriteString(strings.TrimPrefix(strings.ReplaceAll("\n"+repoMap, "\n## ", "\n### "), "\n"))
+	sb.WriteString("\n")
+}
+
+// writeFileHistorySection adds the recent history of the files the task
+// mentions, so the agent knows how they got to their current state.
+func writeFileHistorySection(sb *strings.Builder, fileHistory string) {
+	if fileHistory == "" {
+		return
+	}
+	sb.WriteString("## 🕰️ File history\n\n")
+	sb.WriteString("Recent commits to files this task mentions (newest first):\n\n")
+	sb.WriteString(fileHistory)
+	sb.WriteString("\n")
+}
+
 func buildUserPrompt(taskContent, contextPath string) string {
 	var userPrompt strings.Builder
 	if contextPath != "" {
@@ -382,6 +498,39 @@ func buildUserPrompt(taskContent, contextPath string) string {
 	return userPrompt.String()
 }
 
+// resolveTaskOnComplete stores the on_complete action for a new task when an
+// on_complete_branches rule matches the branch it is based on.
+func resolveTaskOnComplete(appCtx *app.App, taskOpts *config.TaskOptions, agentDir string) {
+	if appCtx.Config == nil || len(appCtx.Config.OnCompleteBranches) == 0 || taskOpts.OnComplete != "" || !appCtx.IsGitRepo {
+		return
+	}
+	baseBranch, err := git.New().GetCurrentBranch(appCtx.ProjectDir)
+	if err != nil || baseBranch == "" {
+		logging.Debug("resolveTaskOnComplete: no base branch: %v", err)
+		return
+	}
+	action := appCtx.Config.OnCompleteFor(baseBranch)
+	if action == appCtx.Config.OnComplete {
+		return
+	}
+
+	taskOpts.OnComplete = action
+	if err := taskOpts.Save(agentDir); err != nil {
+		logging.Warn("Failed to save task on_complete: %v", err)
+		return
+	}
+	logging.Info("on_complete for task based on %s: %s", baseBranch, action)
+}
+
+// permissionFlag returns the Claude flag that skips permission prompts, unless
+// the project turned skip_permissions off.
+func permissionFlag(cfg *config.Config) string {
+	if cfg != nil && !cfg.SkipPermissions {
+		return ""
+	}
+	return " --dangerously-skip-permissions"
+}
+
 // buildStartAgentScript creates the start-agent script content.
 func buildStartAgentScript(appCtx *app.App, t *task.Task, taskOpts *config.TaskOptions, windowID, workDir, systemPrompt, pawBin, pawBinSymlink, sessionName string, isReopen bool) string {
 	taskName := t.Name
@@ -392,15 +541,26 @@ func buildStartAgentScript(appCtx *app.App, t *task.Task, taskOpts *config.TaskO
 
 	// Build model flag for Claude command
 	// Always pass the model flag since Claude CLI's default (sonnet) differs from PAW's default (opus)
-	modelFlag := ""
+	agentFlags := ""
 	if taskOpts.Model != "" {
-		modelFlag = " --model " + shellQuote(string(taskOpts.Model))
+		agentFlags = " --model " + shellQuote(string(taskOpts.Model))
+	}
+	// Pair tasks propose patches instead of editing files
+	if taskOpts.Pair && !taskOpts.Research {
+		agentFlags += " --disallowedTools " + shellQuote(constants.PairDisallowedTools)
 	}
 
 	// Settings file path - use agent directory's .claude symlink
 	// This keeps settings outside git worktree while still being accessible
 	settingsPath := filepath.Join(t.AgentDir, constants.ClaudeLink, "settings.local.json")
 
+	// Put approval shims first on PATH so configured commands ask the user first
+	pathExport := ""
+	if info, err := os.Stat(t.GetApprovalShimDir()); err == nil && info.IsDir() {
+		pathExport = fmt.Sprintf("export PATH=%s:\"$PATH\"\n", shellQuote(t.GetApprovalShimDir()))
+	}
+	pathExport += proxyEnvExports()
+
 	if isReopen {
 		// Resume mode: use --continue to automatically continue previous session
 		return fmt.Sprintf(`#!/bin/bash
@@ -412,17 +572,48 @@ export PROJECT_DIR=%s
 export PAW_HOME=%s
 export PAW_BIN=%s
 export SESSION_NAME=%s
-export IS_DEMO='1'
+%sexport IS_DEMO='1'
 
 # Continue the previous Claude session (--continue auto-selects last session)
 # --settings points to agent dir's .claude (outside git worktree)
-exec claude --continue --dangerously-skip-permissions --settings %s%s
+exec claude --continue%s --settings %s%s
 `, shellQuote(taskName), shellQuote(appCtx.PawDir), shellQuote(appCtx.ProjectDir), worktreeDirExport, shellQuote(windowID),
-			shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), shellQuote(settingsPath), modelFlag)
+			shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), pathExport,
+			permissionFlag(appCtx.Config), shellQuote(settingsPath), agentFlags)
 	}
 
 	// New session: start fresh with system prompt
 	encodedPrompt := base64.StdEncoding.EncodeToString([]byte(systemPrompt))
+	if useStreamJSON(appCtx, isReopen) {
+		// Headless run first: render-stream turns the stream-json events into a
+		// transcript and reports the status; then continue interactively.
+		return fmt.Sprintf(`#!/bin/bash
+# Auto-generated start-agent script for this task (STREAM-JSON MODE)
+export TASK_NAME=%s
+export PAW_DIR=%s
+export PROJECT_DIR=%s
+%sexport WINDOW_ID=%s
+export PAW_HOME=%s
+export PAW_BIN=%s
+export SESSION_NAME=%s
+%sexport IS_DEMO='1'
+
+# System prompt is base64 encoded to avoid shell escaping issues
+# The task prompt is read from the agent dir's user prompt file
+# %s tells the stop hook that render-stream reports the status
+%s=1 claude -p --output-format stream-json --verbose%s --settings %s%s --system-prompt "$(base64 -d <<'__PROMPT_END__'
+%s
+__PROMPT_END__
+)" "$(cat %s)" | "$PAW_BIN" internal render-stream
+
+# Continue the same session interactively for follow-ups
+exec claude --continue%s --settings %s%s
+`, shellQuote(taskName), shellQuote(appCtx.PawDir), shellQuote(appCtx.ProjectDir), worktreeDirExport, shellQuote(windowID),
+			shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), pathExport,
+			streamEnvVar, streamEnvVar, permissionFlag(appCtx.Config), shellQuote(settingsPath), agentFlags, encodedPrompt, shellQuote(t.GetUserPromptPath()),
+			permissionFlag(appCtx.Config), shellQuote(settingsPath), agentFlags)
+	}
+
 	return fmt.Sprintf(`#!/bin/bash
 # Auto-generated start-agent script for this task
 export TASK_NAME=%s
@@ -432,18 +623,70 @@ export PROJECT_DIR=%s
 export PAW_HOME=%s
 export PAW_BIN=%s
 export SESSION_NAME=%s
-export IS_DEMO='1'
+%sexport IS_DEMO='1'
 
 # System prompt is base64 encoded to avoid shell escaping issues
 # Using heredoc with single-quoted delimiter prevents any shell interpretation
 # --settings points to agent dir's .claude (outside git worktree)
-exec claude --dangerously-skip-permissions --settings %s%s --system-prompt "$(base64 -d <<'__PROMPT_END__'
+exec claude%s --settings %s%s --system-prompt "$(base64 -d <<'__PROMPT_END__'
 %s
 __PROMPT_END__
 )"
 `, shellQuote(taskName), shellQuote(appCtx.PawDir), shellQuote(appCtx.ProjectDir), worktreeDirExport, shellQuote(windowID),
-		shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName),
-		shellQuote(settingsPath), modelFlag, encodedPrompt)
+		shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), pathExport,
+		permissionFlag(appCtx.Config), shellQuote(settingsPath), agentFlags, encodedPrompt)
+}
+
+// startInteractiveAgent waits for the interactive Claude session to come up,
+// answers the trust prompt, and sends the task instruction to a new task.
+func startInteractiveAgent(appCtx *app.App, tm tmux.Client, agentPane string, t *task.Task, taskName, windowID string, isReopen bool) {
+	// Wait for Claude to be ready
+	logging.Debug("Waiting for Claude to be ready...")
+	useProjectReadinessRules(appCtx)
+	claudeClient := newClaudeClient()
+	claudeTimer := logging.StartTimer("Claude startup")
+	if err := claudeClient.WaitForReady(tm, agentPane); err != nil {
+		claudeTimer.StopWithResult(false, err.Error())
+		logging.Warn("WaitForReady timed out, continuing anyway...")
+	} else {
+		claudeTimer.StopWithResult(true, "")
+	}
+
+	// Verify Claude is actually running (has content)
+	verifyTimer := logging.StartTimer("Verify Claude alive")
+	if err := claudeClient.VerifyPaneAlive(tm, agentPane, 10*time.Second); err != nil {
+		verifyTimer.StopWithResult(false, err.Error())
+		logging.Warn("Claude pane may not be alive: %v", err)
+	} else {
+		verifyTimer.StopWithResult(true, "")
+	}
+
+	// Send trust response if needed (error is non-fatal)
+	if err := claudeClient.SendTrustResponse(tm, agentPane); err != nil {
+		logging.Trace("Failed to send trust response: %v", err)
+	} else {
+		logging.Debug("Trust response sent")
+	}
+
+	// Wait a bit more for Claude to be fully ready
+	time.Sleep(1 * time.Second)
+
+	if isReopen {
+		// Resume mode: don't clear history or send task instruction
+		logging.Log("Session resumed: task=%s, windowID=%s", taskName, windowID)
+		if t.TakeResumeMarker() {
+			resumeStoppedAgent(appCtx, tm, claudeClient, agentPane, t, windowID)
+		}
+	} else {
+		// New task: clear screen and send task instruction
+		startNewTaskSession(tm, claudeClient, agentPane, t, taskName, windowID)
+	}
+}
+
+// useStreamJSON reports whether a new task runs headless under stream-json
+// supervision (agent_mode: stream-json). Resumed tasks are always interactive.
+func useStreamJSON(appCtx *app.App, isReopen bool) bool {
+	return !isReopen && appCtx.Config != nil && appCtx.Config.AgentMode == constants.AgentModeStreamJSON
 }
 
 // startNewTaskSession handles the setup for a new (non-resumed) task session.
@@ -493,3 +736,50 @@ func startNewTaskSession(tm tmux.Client, claudeClient claude.Client, agentPane s
 		}
 	}()
 }
+
+// claudeLinkDir returns where a task's .claude link lives: the agent directory
+// (outside git) in worktree mode, the project directory (where Claude runs) otherwise.
+func claudeLinkDir(appCtx *app.App) string {
+	if appCtx.IsWorktreeMode() {
+		return "" // Agent directory
+	}
+	return appCtx.ProjectDir
+}
+
+// setupTaskLinks links a task's agent directory to the project root and its
+// .claude folder to the PAW directory's (for stop-hook support). With
+// link_mode: copy, the origin is a plain file and .claude is a copy that is
+// synced back first, so changes from an unfinished previous run aren't lost.
+func setupTaskLinks(appCtx *app.App, t *task.Task) {
+	if appCtx.Config != nil && appCtx.Config.LinkMode == constants.LinkModeCopy {
+		if err := t.SetupOriginFile(appCtx.ProjectDir); err != nil {
+			logging.Warn("Failed to setup origin file: %v", err)
+		}
+		if err := t.SyncClaudeDirBack(appCtx.PawDir, claudeLinkDir(appCtx)); err != nil {
+			logging.Warn("Failed to sync .claude copy: %v", err)
+		}
+		if err := t.CopyClaudeDirInDir(appCtx.PawDir, claudeLinkDir(appCtx)); err != nil {
+			logging.Warn("Failed to copy .claude: %v", err)
+		}
+		return
+	}
+
+	if err := t.SetupSymlinks(appCtx.ProjectDir); err != nil {
+		logging.Warn("Failed to setup symlinks (set link_mode: copy if the filesystem denies symlinks): %v", err)
+	}
+	// SetupWorktree creates the agent dir symlink for new tasks, but reopened tasks need it too
+	if err := t.SetupClaudeSymlinkInDir(appCtx.PawDir, claudeLinkDir(appCtx)); err != nil {
+		logging.Warn("Failed to setup claude symlink: %v", err)
+	}
+}
+
+// syncTaskLinks copies changes in a task's .claude copy back to the PAW
+// directory before the task is cleaned up (link_mode: copy only).
+func syncTaskLinks(appCtx *app.App, t *task.Task) {
+	if appCtx.Config == nil || appCtx.Config.LinkMode != constants.LinkModeCopy {
+		return
+	}
+	if err := t.SyncClaudeDirBack(appCtx.PawDir, claudeLinkDir(appCtx)); err != nil {
+		logging.Warn("Failed to sync .claude copy: %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -38,7 +37,7 @@ var cancelTaskCmd = &cobra.Command{
 		logging.Debug("-> cancelTaskCmd(session=%s, windowID=%s)", sessionName, windowID)
 		defer logging.Debug("<- cancelTaskCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		fmt.Println()
 		fmt.Println("  ╭─────────────────────────────────────╮")
@@ -108,9 +107,7 @@ var cancelTaskCmd = &cobra.Command{
 							fmt.Println()
 
 							// Abort revert if in progress
-							abortCmd := exec.Command("git", "revert", "--abort")
-							abortCmd.Dir = appCtx.ProjectDir
-							_ = abortCmd.Run()
+							_ = gitClient.RevertAbort(appCtx.ProjectDir)
 
 							// Rename window to corrupted state and notify user
 							corruptedName := windowNameForStatus(targetTask.Name, task.StatusCorrupted)
@@ -161,7 +158,11 @@ var cancelTaskCmd = &cobra.Command{
 			}
 		}
 
+		warnStackedChildren(appCtx, targetTask.Name, tm)
+		recordPromptVariantFinish(appCtx, targetTask, service.VariantOutcomeCancelled)
+
 		// Cleanup task
+		syncTaskLinks(appCtx, targetTask)
 		cleanupSpinner := tui.NewSimpleSpinner("Cleaning up")
 		cleanupSpinner.Start()
 
@@ -211,7 +212,7 @@ var cancelTaskUICmd = &cobra.Command{
 		logging.Debug("-> cancelTaskUICmd(session=%s, windowID=%s)", sessionName, windowID)
 		defer logging.Debug("<- cancelTaskUICmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get the paw binary path
 		pawBin := getPawBin()
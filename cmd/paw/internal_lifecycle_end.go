@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,9 +13,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/github"
+	"github.com/dongho-jung/paw/internal/lifecycle"
+	"github.com/dongho-jung/paw/internal/lock"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
 	"github.com/dongho-jung/paw/internal/service"
@@ -25,13 +30,15 @@ import (
 
 var paneCaptureFile string
 var endTaskUserInitiated bool
-var endTaskAction string // merge, pr, keep (default), drop
+var endTaskAction string   // keep (default), push, merge, merge-push, pr, done, drop
+var endTaskKeepBranch bool // Overrides keep_branch when set
+var endTaskAuto bool       // Started by on_complete or a confirm timeout rather than the user
 
 var endTaskCmd = &cobra.Command{
 	Use:   "end-task [session] [window-id]",
 	Short: "Finish a task (commit, merge, cleanup)",
 	Args:  cobra.ExactArgs(2),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		logging.Debug("-> endTaskCmd(session=%s, windowID=%s)", args[0], args[1])
 		defer logging.Debug("<- endTaskCmd")
 
@@ -47,6 +54,12 @@ var endTaskCmd = &cobra.Command{
 		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 		targetTask, err := mgr.FindTaskByWindowID(windowID)
 		if err != nil {
+			if errors.Is(err, task.ErrTaskNotFound) {
+				// A previous run already cleaned the task up
+				fmt.Printf("\n  ○ Task already completed (no task for window %s)\n\n", windowID)
+				removePaneCapture()
+				return nil
+			}
 			return err
 		}
 
@@ -54,18 +67,44 @@ var endTaskCmd = &cobra.Command{
 		_, cleanup := setupLoggerFromApp(appCtx, "end-task", targetTask.Name)
 		defer cleanup()
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		if !endTaskUserInitiated {
 			message := "Finish is user-initiated. Press Ctrl+F to finish this task."
 			logging.Warn("endTaskCmd: blocked (not user initiated)")
 			fmt.Printf("\n  ⚠️  %s\n\n", message)
-			if paneCaptureFile != "" {
-				_ = os.Remove(paneCaptureFile)
-			}
+			removePaneCapture()
 			_ = tm.DisplayMessage(message, 3000)
 			return nil
 		}
 
+		// Ctrl+F pressed twice or a re-run UI pane must not operate on a
+		// task that is being, or has been, finished
+		endLock, err := lock.TryAcquire(filepath.Join(targetTask.AgentDir, constants.EndTaskLockFileName), "end-task", lock.Options{TTL: constants.EndTaskLockTTL})
+		if err != nil {
+			if errors.Is(err, lock.ErrLocked) {
+				logging.Log("endTaskCmd: task %s is already being finished: %v", targetTask.Name, err)
+				fmt.Printf("\n  ○ Task is already being finished: %s\n\n", targetTask.Name)
+				removePaneCapture()
+				return nil
+			}
+			return fmt.Errorf("failed to acquire end-task lock: %w", err)
+		}
+		defer func() { _ = endLock.Release() }()
+
+		// A finished task whose cleanup was interrupted only needs the rest
+		// of its cleanup
+		if targetTask.IsCompleted() {
+			logging.Log("endTaskCmd: task %s already completed, resuming cleanup", targetTask.Name)
+			fmt.Printf("\n  ○ Task already completed, finishing cleanup: %s\n\n", targetTask.Name)
+			removePaneCapture()
+			completion, err := targetTask.LoadCompletion()
+			if err != nil {
+				return fmt.Errorf("failed to read completion marker: %w", err)
+			}
+			cleanupCompletedTask(appCtx, mgr, targetTask, windowID, completion, tm)
+			return nil
+		}
+
 		logging.Log("=== Finish task: %s ===", targetTask.Name)
 
 		// Print task header for user feedback
@@ -77,19 +116,33 @@ var endTaskCmd = &cobra.Command{
 		logging.Debug("Configuration: Action=%s", endTaskAction)
 
 		// Handle drop and done actions - skip git operations
-		skipGitOps := (endTaskAction == constants.ActionDrop || endTaskAction == constants.ActionDone)
+		// Research tasks never touch git (they run read-only in the project directory)
+		research := targetTask.IsResearch()
+		skipGitOps := (endTaskAction == constants.ActionDrop || endTaskAction == constants.ActionDone || research)
 		switch endTaskAction {
 		case constants.ActionDrop:
 			fmt.Println("  Dropping task (discarding changes)...")
 			logging.Log("drop action: discarding changes for task %s", targetTask.Name)
+			warnStackedChildren(appCtx, targetTask.Name, tm)
 		case constants.ActionDone:
 			fmt.Println("  Finishing task...")
 			logging.Log("done action: cleaning up task %s", targetTask.Name)
 		}
 
+		// Pair tasks may only be finished with the changes the user applied
+		if appCtx.IsGitRepo && !skipGitOps && targetTask.IsPair() && !checkPairChanges(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+			removePaneCapture()
+			return errUnapprovedChanges(targetTask)
+		}
+
 		// Commit changes if git mode (skip for drop action)
+		var heldBack []string
 		if appCtx.IsGitRepo && !skipGitOps {
-			commitChangesIfNeeded(gitClient, workDir)
+			heldBack = commitChangesIfNeeded(gitClient, workDir, appCtx.Config)
+			if !runSelfEval(appCtx, targetTask, windowID, workDir, endTaskAction, gitClient, tm) {
+				removePaneCapture()
+				return errLowSelfEval(targetTask) // Keep worktree and branch for review
+			}
 
 			// Handle action-based behavior
 			switch endTaskAction {
@@ -98,88 +151,28 @@ var endTaskCmd = &cobra.Command{
 				if !appCtx.IsWorktreeMode() {
 					logging.Warn("PR creation requested in non-worktree mode; skipping")
 					fmt.Println("  ⚠️  PR creation is only available in worktree mode")
-					if paneCaptureFile != "" {
-						_ = os.Remove(paneCaptureFile)
-					}
+					removePaneCapture()
 					return nil
 				}
 
-				fallbackBranch := targetTask.Name
-				branchName, ok := resolvePushBranch(gitClient, workDir, fallbackBranch)
-				if !ok {
-					fmt.Println("  ⚠️  Failed to determine branch for PR creation")
-					if paneCaptureFile != "" {
-						_ = os.Remove(paneCaptureFile)
-					}
-					return nil
+				if !checkDiffPolicies(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+					removePaneCapture()
+					return errPolicyViolation(targetTask)
 				}
-
-				pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", branchName))
-				pushSpinner.Start()
-
-				pushTimer := logging.StartTimer("git push")
-				if err := gitClient.Push(workDir, "origin", branchName, true); err != nil {
-					pushTimer.StopWithResult(false, err.Error())
-					pushSpinner.Stop(false, err.Error())
-					fmt.Printf("  ⚠️  Failed to push branch: %v\n", err)
-					if paneCaptureFile != "" {
-						_ = os.Remove(paneCaptureFile)
-					}
-					return nil
+				if !runSecurityScans(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+					removePaneCapture()
+					return errSecurityFindings(targetTask)
 				}
 
-				pushTimer.StopWithResult(true, "branch="+branchName)
-				pushSpinner.Stop(true, branchName)
-
-				ghClient := github.New()
-				if !ghClient.IsInstalled() {
-					fmt.Println("  ⚠️  gh CLI not found; cannot create PR")
-					if paneCaptureFile != "" {
-						_ = os.Remove(paneCaptureFile)
-					}
-					return nil
+				verified := verifyBeforePush(appCtx, targetTask, windowID, workDir, tm)
+				if verified {
+					reportCoverage(appCtx, targetTask, windowID, workDir, gitClient)
+					createTaskPR(appCtx, targetTask, sessionName, windowID, workDir, gitClient, tm)
 				}
 
-				mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
-				prTitle := buildPRTitle(targetTask.Name)
-				commits, err := gitClient.GetBranchCommits(workDir, branchName, mainBranch, 20)
-				if err != nil {
-					logging.Warn("Failed to read branch commits: %v", err)
-					commits = nil
-				}
-				prBody := buildPRBody(targetTask.Name, commits)
-
-				prSpinner := tui.NewSimpleSpinner("Creating pull request")
-				prSpinner.Start()
-				prTimer := logging.StartTimer("gh pr create")
-				prNumber, prURL, err := ghClient.CreatePR(workDir, prTitle, prBody, mainBranch)
-				if err != nil {
-					prTimer.StopWithResult(false, err.Error())
-					prSpinner.Stop(false, err.Error())
-					fmt.Printf("  ⚠️  Failed to create PR: %v\n", err)
-					if paneCaptureFile != "" {
-						_ = os.Remove(paneCaptureFile)
-					}
-					return nil
-				}
-				prTimer.StopWithResult(true, fmt.Sprintf("pr=%d", prNumber))
-				prSpinner.Stop(true, fmt.Sprintf("#%d", prNumber))
-				fmt.Printf("  ✓ PR created: %s\n", prURL)
-
-				if err := targetTask.SavePRNumber(prNumber); err != nil {
-					logging.Warn("Failed to save PR number: %v", err)
-				}
-
-				reviewName := constants.EmojiReview + constants.TruncateForWindowName(targetTask.Name)
-				if err := renameWindowWithStatus(tm, windowID, reviewName, appCtx.PawDir, targetTask.Name, "end-task", task.StatusWaiting); err != nil {
-					logging.Warn("Failed to rename window for PR review: %v", err)
-				}
-
-				startPRWatch(appCtx, sessionName, windowID, targetTask.Name, prNumber)
-				showPRPopup(tm, sessionName, prNumber, prURL)
-
-				if paneCaptureFile != "" {
-					_ = os.Remove(paneCaptureFile)
+				removePaneCapture()
+				if !verified {
+					return errVerificationFailed(targetTask)
 				}
 				return nil
 
@@ -200,9 +193,7 @@ var endTaskCmd = &cobra.Command{
 						createSpinner.Stop(false, err.Error())
 						logging.Warn("Failed to create main branch: %v", err)
 						fmt.Printf("  ⚠️  Failed to create %s branch: %v\n", mainBranch, err)
-						if paneCaptureFile != "" {
-							_ = os.Remove(paneCaptureFile)
-						}
+						removePaneCapture()
 						return nil
 					}
 					createSpinner.Stop(true, mainBranch)
@@ -211,8 +202,9 @@ var endTaskCmd = &cobra.Command{
 					// Now proceed with merge
 					mergeSuccess := runAutoMerge(appCtx, targetTask, windowID, workDir, gitClient, tm)
 					if !mergeSuccess {
-						return nil // Exit without cleanup - keep worktree and branch
+						return errMergeFailed(targetTask) // Exit without cleanup - keep worktree and branch
 					}
+					restackChildren(appCtx, targetTask.Name, gitClient, tm)
 				}
 
 			case constants.ActionMergePush, constants.ActionMerge:
@@ -222,20 +214,48 @@ var endTaskCmd = &cobra.Command{
 					fmt.Println()
 					fmt.Println("  ⚠️  Merge is only available in worktree mode")
 				} else {
+					// Remember main's head so the merge can be undone if main turns out to be protected
+					mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+					preMergeHead, _ := gitClient.GetBranchHead(appCtx.ProjectDir, mainBranch)
+
+					if !checkDiffPolicies(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+						removePaneCapture()
+						return errPolicyViolation(targetTask) // Keep worktree and branch so the agent can fix it
+					}
+					if !runSecurityScans(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+						removePaneCapture()
+						return errSecurityFindings(targetTask) // Keep worktree and branch so the agent can fix it
+					}
+					if !runReviewerPass(appCtx, targetTask, windowID, workDir, gitClient, tm) {
+						removePaneCapture()
+						return errReviewRejected(targetTask) // Keep worktree and branch for review
+					}
+
+					// Autopilot tasks are verified before any merge, pushed or not
+					if (endTaskAction == constants.ActionMergePush || targetTask.IsAutopilot()) && !verifyBeforePush(appCtx, targetTask, windowID, workDir, tm) {
+						removePaneCapture()
+						return errVerificationFailed(targetTask) // Keep worktree and branch so the agent can fix it
+					}
+					reportCoverage(appCtx, targetTask, windowID, workDir, gitClient)
+
 					mergeSuccess := runAutoMerge(appCtx, targetTask, windowID, workDir, gitClient, tm)
 					if !mergeSuccess {
-						return nil // Exit without cleanup - keep worktree and branch
+						return errMergeFailed(targetTask) // Exit without cleanup - keep worktree and branch
 					}
 
 					// Push main to remote if "merge-push" action
 					if endTaskAction == constants.ActionMergePush {
-						mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
 						pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", mainBranch))
 						pushSpinner.Start()
 
 						if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
 							pushSpinner.Stop(false, err.Error())
 							logging.Warn("Failed to push main branch: %v", err)
+							if git.IsProtectedBranchRejection(err) && preMergeHead != "" {
+								fallbackToPR(appCtx, targetTask, sessionName, windowID, workDir, mainBranch, preMergeHead, gitClient, tm)
+								removePaneCapture()
+								return nil // Keep worktree and branch for the PR
+							}
 							fmt.Printf("  ⚠️  Failed to push %s: %v\n", mainBranch, err)
 							fmt.Println("  Note: Merge was successful, but push failed. You can push manually.")
 						} else {
@@ -243,11 +263,25 @@ var endTaskCmd = &cobra.Command{
 							fmt.Printf("  ✓ Pushed %s to remote\n", mainBranch)
 						}
 					}
+					restackChildren(appCtx, targetTask.Name, gitClient, tm)
+				}
+
+			case constants.ActionPush:
+				// Commit & push: publish the branch and keep the task open
+				if !appCtx.IsWorktreeMode() {
+					logging.Warn("push requested in non-worktree mode; skipping")
+					fmt.Println("  ⚠️  Push is only available in worktree mode")
+				} else if pushTaskBranch(gitClient, workDir, targetTask.Name) {
+					fmt.Println("  ✓ Changes committed and pushed (task kept open)")
 				}
+				removePaneCapture()
+				return nil
 
 			default:
-				// "keep" or empty - just commit (already done above)
-				fmt.Println("  ○ Changes committed")
+				// "keep" or empty - just commit (already done above) and keep the task open
+				fmt.Println("  ○ Changes committed (task kept open)")
+				removePaneCapture()
+				return nil
 			}
 		}
 		fmt.Println()
@@ -275,9 +309,37 @@ var endTaskCmd = &cobra.Command{
 			}
 		}
 
+		// Research tasks complete by saving the answer to history
+		if research && endTaskAction != constants.ActionDrop {
+			saveResearchHistory(appCtx, targetTask, sessionName)
+		}
+
+		// Keep the task's reports and build outputs, with the files left out
+		// of its commits
+		if endTaskAction != constants.ActionDrop {
+			if appCtx.IsWorktreeMode() {
+				keepHeldBackFiles(targetTask, workDir, heldBack)
+			}
+			saveTaskArtifacts(appCtx, targetTask, sessionName)
+		}
+
 		// Clean up temp pane capture file if it exists
-		if paneCaptureFile != "" {
-			_ = os.Remove(paneCaptureFile)
+		removePaneCapture()
+
+		recordPromptVariantFinish(appCtx, targetTask, endTaskAction)
+
+		// Keep the branch if the finish picker or keep_branch asks for it
+		keepBranch := appCtx.Config != nil && appCtx.Config.KeepBranch
+		if cmd.Flags().Changed("keep-branch") {
+			keepBranch = endTaskKeepBranch
+		}
+		keepBranch = keepBranch && endTaskAction != constants.ActionDrop && appCtx.IsWorktreeMode() && !research
+
+		// Mark the task completed before cleanup, so a later run or session
+		// start only resumes the cleanup if it is interrupted halfway
+		completion := task.Completion{Drop: endTaskAction == constants.ActionDrop, KeepBranch: keepBranch}
+		if err := targetTask.MarkCompleted(completion); err != nil {
+			logging.Warn("Failed to write completion marker: %v", err)
 		}
 
 		// Notify user that task completed successfully
@@ -291,35 +353,50 @@ var endTaskCmd = &cobra.Command{
 		}
 
 		// Cleanup task (only reached if merge succeeded or not in auto-merge mode)
-		cleanupSpinner := tui.NewSimpleSpinner("Cleaning up")
-		cleanupSpinner.Start()
-
-		cleanupTimer := logging.StartTimer("task cleanup")
-		if err := mgr.CleanupTask(targetTask); err != nil {
-			cleanupTimer.StopWithResult(false, err.Error())
-			cleanupSpinner.Stop(false, err.Error())
-		} else {
-			cleanupTimer.StopWithResult(true, "")
-			cleanupSpinner.Stop(true, "")
-		}
+		cleanupCompletedTask(appCtx, mgr, targetTask, windowID, completion, tm)
+		return nil
+	},
+}
 
-		// Kill window
-		if err := tm.KillWindow(windowID); err != nil {
-			logging.Warn("Failed to kill window: %v", err)
-		}
+// cleanupCompletedTask removes (or parks) a task end-task has finished, the
+// way its completion records, and closes its window.
+func cleanupCompletedTask(appCtx *app.App, mgr *task.Manager, targetTask *task.Task, windowID string, completion task.Completion, tm tmux.Client) {
+	syncTaskLinks(appCtx, targetTask)
+	cleanupSpinner := tui.NewSimpleSpinner("Cleaning up")
+	cleanupSpinner.Start()
+
+	// Dropped tasks are removed right away; others follow cleanup_policy
+	cleanupTimer := logging.StartTimer("task cleanup")
+	if err := mgr.FinishCompletion(targetTask, completion); err != nil {
+		cleanupTimer.StopWithResult(false, err.Error())
+		cleanupSpinner.Stop(false, err.Error())
+	} else {
+		cleanupTimer.StopWithResult(true, "")
+		cleanupSpinner.Stop(true, "")
+	}
+	if pruned, err := mgr.PruneParkedTasks(time.Now()); err != nil {
+		logging.Warn("Failed to prune parked tasks: %v", err)
+	} else if len(pruned) > 0 {
+		logging.Info("Pruned %d parked task(s) past cleanup_retention_days", len(pruned))
+	}
 
-		fmt.Println()
-		fmt.Println("  ✓ Done!")
+	// Kill window
+	if err := tm.KillWindow(windowID); err != nil {
+		logging.Warn("Failed to kill window: %v", err)
+	}
 
-		return nil
-	},
+	fmt.Println()
+	if completion.KeepBranch {
+		fmt.Printf("  ○ Kept branch %s\n", targetTask.Name)
+	}
+	fmt.Println("  ✓ Done!")
 }
 
 var endTaskUICmd = &cobra.Command{
 	Use:   "end-task-ui [session] [window-id]",
 	Short: "Finish task with UI feedback (creates visible pane)",
 	Args:  cobra.ExactArgs(2),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		sessionName := args[0]
 		windowID := args[1]
 
@@ -335,12 +412,17 @@ var endTaskUICmd = &cobra.Command{
 		logging.Debug("-> endTaskUICmd(session=%s, windowID=%s)", sessionName, windowID)
 		defer logging.Debug("<- endTaskUICmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// IMPORTANT: Capture the agent pane content BEFORE creating the split pane
 		// This is necessary because splitting shifts pane indices, causing windowID+".0"
 		// to no longer be the agent pane
-		paneContent, err := tm.CapturePane(windowID+".0", constants.PaneCaptureLines)
+		agentDir := ""
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		if t, err := mgr.FindTaskByWindowID(windowID); err == nil {
+			agentDir = t.AgentDir
+		}
+		paneContent, err := tm.CapturePaneOpts(windowID+".0", paneCaptureOpts(appCtx.Config, agentDir))
 		if err != nil {
 			logging.Warn("Failed to pre-capture agent pane: %v", err)
 			paneContent = "" // Continue anyway, end-task will try to capture directly
@@ -382,6 +464,12 @@ var endTaskUICmd = &cobra.Command{
 		if capturePath != "" {
 			cmdArgs = append(cmdArgs, "--pane-capture-file", capturePath)
 		}
+		if cmd.Flags().Changed("keep-branch") {
+			cmdArgs = append(cmdArgs, "--keep-branch="+strconv.FormatBool(endTaskKeepBranch))
+		}
+		if endTaskAuto {
+			cmdArgs = append(cmdArgs, "--auto")
+		}
 		cmdArgs = append(cmdArgs, sessionName, windowID)
 		endTaskCmdStr := strings.Join([]string{
 			shellEnv("PAW_DIR", appCtx.PawDir),
@@ -410,334 +498,332 @@ var endTaskUICmd = &cobra.Command{
 	},
 }
 
-// runAutoMerge performs the auto-merge process for a task.
-// Returns true if merge succeeded, false if failed.
-func runAutoMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
-	logging.Log("auto-merge: starting merge process")
-	fmt.Println()
-	fmt.Println("  Auto-merge mode:")
+// removePaneCapture removes the pane capture that end-task-ui handed to end-task.
+func removePaneCapture() {
+	if paneCaptureFile != "" {
+		_ = os.Remove(paneCaptureFile)
+	}
+}
 
-	// Get main branch name
-	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
-	logging.Debug("Main branch: %s", mainBranch)
+// saveResearchHistory saves a research task's answer and pane capture to history.
+func saveResearchHistory(appCtx *app.App, targetTask *task.Task, sessionName string) {
+	answer, err := os.ReadFile(targetTask.GetResearchAnswerPath())
+	if err != nil {
+		logging.Warn("Research answer not found: %v", err)
+	}
 
-	if appCtx.Config != nil && appCtx.Config.PreMergeHook != "" {
-		hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
-		hookSpinner := tui.NewSimpleSpinner("Running pre-merge hook")
-		hookSpinner.Start()
-		if _, err := service.RunHook(
-			"pre-merge",
-			appCtx.Config.PreMergeHook,
-			appCtx.ProjectDir,
-			hookEnv,
-			targetTask.GetHookOutputPath("pre-merge"),
-			targetTask.GetHookMetaPath("pre-merge"),
-			constants.DefaultHookTimeout,
-		); err != nil {
-			logging.Warn("Pre-merge hook failed: %v", err)
-			hookSpinner.Stop(false, err.Error())
-		} else {
-			hookSpinner.Stop(true, "")
+	var paneContent []byte
+	if paneCaptureFile != "" {
+		if paneContent, err = os.ReadFile(paneCaptureFile); err != nil {
+			logging.Warn("Failed to read pane capture: %v", err)
 		}
 	}
 
-	mergeTimer := logging.StartTimer("auto-merge")
-
-	// Acquire merge lock to prevent concurrent merges
-	lockSpinner := tui.NewSimpleSpinner("Acquiring merge lock")
-	lockSpinner.Start()
-
-	lockFile := filepath.Join(appCtx.PawDir, "merge.lock")
-	lockAcquired := acquireMergeLock(lockFile, targetTask.Name)
-
-	if !lockAcquired {
-		logging.Warn("Failed to acquire merge lock after %d seconds", constants.MergeLockMaxRetries)
-		mergeTimer.StopWithResult(false, "lock timeout")
-		lockSpinner.Stop(false, fmt.Sprintf("timeout after %ds", constants.MergeLockMaxRetries))
-		return handleMergeFailure(appCtx, targetTask, windowID, tm)
+	taskOpts, _ := config.LoadTaskOptions(targetTask.AgentDir)
+	meta := &service.HistoryMetadata{
+		SessionName: sessionName,
+		ProjectDir:  appCtx.ProjectDir,
+		TaskOptions: taskOpts,
+		FinishedAt:  time.Now().Format(time.RFC3339),
 	}
-	lockSpinner.Stop(true, "")
-	defer func() { _ = os.Remove(lockFile) }()
 
-	// Check for ongoing merge or conflicts in project dir
-	hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-	hasOngoingMerge := gitClient.HasOngoingMerge(appCtx.ProjectDir)
-
-	if hasConflicts || hasOngoingMerge {
-		logging.Warn("Project directory has ongoing merge or conflicts")
-		fmt.Println()
-		fmt.Println("  ⚠️  Project directory has unresolved conflicts or ongoing merge")
-		if hasConflicts && len(conflictFiles) > 0 {
-			fmt.Println("  Conflicting files:")
-			for _, f := range conflictFiles {
-				fmt.Printf("    - %s\n", f)
-			}
-		}
-		fmt.Println()
-		fmt.Println("  Please resolve conflicts in the project directory first:")
-		fmt.Printf("    cd %s\n", appCtx.ProjectDir)
-		fmt.Println("    git status  # View current state")
-		fmt.Println("    # Resolve conflicts, then: git add . && git commit")
-		fmt.Println("    # Or abort merge: git merge --abort")
-		fmt.Println()
-		mergeTimer.StopWithResult(false, "project has conflicts")
-		return handleMergeFailure(appCtx, targetTask, windowID, tm)
+	historyService := newHistoryService(appCtx, sessionName)
+	if err := historyService.SaveResearch(targetTask.Name, targetTask.Content, string(answer), string(paneContent), meta); err != nil {
+		logging.Warn("Failed to save research history: %v", err)
+		fmt.Printf("  ⚠️  Failed to save answer to history: %v\n", err)
+		return
 	}
+	logging.Log("Research answer saved to history: task=%s", targetTask.Name)
+	fmt.Println("  ✓ Answer saved to history (paw history)")
+}
 
-	// Stash any uncommitted changes in project dir
-	hasLocalChanges := gitClient.HasChanges(appCtx.ProjectDir)
-	if hasLocalChanges {
-		logging.Debug("Stashing local changes...")
-		if err := gitClient.StashPush(appCtx.ProjectDir, constants.MergeStashMessage); err != nil {
-			logging.Warn("Failed to stash changes: %v", err)
-		}
+// saveTaskArtifacts copies the task's artifacts directory to history before cleanup.
+func saveTaskArtifacts(appCtx *app.App, targetTask *task.Task, sessionName string) {
+	saved, err := newHistoryService(appCtx, sessionName).SaveArtifacts(targetTask.Name, targetTask.GetArtifactsDir())
+	if err != nil {
+		logging.Warn("Failed to save artifacts: %v", err)
+		fmt.Printf("  ⚠️  Failed to save artifacts to history: %v\n", err)
+		return
+	}
+	if saved == "" {
+		return
 	}
+	logging.Log("Task artifacts saved to history: task=%s, dir=%s", targetTask.Name, saved)
+	fmt.Printf("  ✓ Artifacts saved to history (paw artifacts %s)\n", targetTask.Name)
+}
 
-	// Remember current branch to restore later
-	currentBranch, _ := gitClient.GetCurrentBranch(appCtx.ProjectDir)
+// errVerificationFailed and errMergeFailed are end-task's exit errors for a
+// task kept after a failed verification or merge.
+func errVerificationFailed(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.VerificationFailed, "verification failed for %s; task kept", t.Name), t.Name)
+}
 
-	// Perform the actual merge
-	mergeSuccess := performMerge(appCtx, targetTask, windowID, workDir, mainBranch, currentBranch, gitClient, mergeTimer)
+func errMergeFailed(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.MergeConflict, "merge failed for %s; task kept", t.Name), t.Name)
+}
 
-	// Restore stashed changes by message (not blind pop)
-	if hasLocalChanges {
-		restoreSpinner := tui.NewSimpleSpinner("Restoring stashed changes")
-		restoreSpinner.Start()
-		if err := gitClient.StashPopByMessage(appCtx.ProjectDir, constants.MergeStashMessage); err != nil {
-			logging.Warn("Failed to restore stashed changes: %v", err)
-			restoreSpinner.Stop(false, err.Error())
-		} else {
-			restoreSpinner.Stop(true, "")
-		}
+// verifyBeforePush runs the project's build, lint, and test commands in the
+// task worktree (with verify_before_push, or always for autopilot tasks).
+// Returns false (keeping the task open) if any of them fails.
+func verifyBeforePush(appCtx *app.App, targetTask *task.Task, windowID, workDir string, tm tmux.Client) bool {
+	if appCtx.Config == nil || (!appCtx.Config.VerifyBeforePush && !targetTask.IsAutopilot()) {
+		return true
 	}
-
-	if !mergeSuccess {
-		return handleMergeFailure(appCtx, targetTask, windowID, tm)
+	entries := appCtx.Config.Commands.VerifyEntries()
+	if len(entries) == 0 {
+		return true
 	}
 
-	return true
-}
+	hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+		name := "verify-" + e.Name
+		spinner := tui.NewSimpleSpinner(fmt.Sprintf("Verifying: %s", e.Command))
+		spinner.Start()
+		if _, err := service.RunHook(
+			name,
+			e.Command,
+			workDir,
+			hookEnv,
+			targetTask.GetHookOutputPath(name),
+			targetTask.GetHookMetaPath(name),
+			constants.DefaultVerifyTimeout,
+		); err != nil {
+			spinner.Stop(false, err.Error())
+			logging.Warn("Verification failed: %s: %v", e.Name, err)
 
-// acquireMergeLock attempts to acquire the merge lock file.
-func acquireMergeLock(lockFile, taskName string) bool {
-	for retries := 0; retries < constants.MergeLockMaxRetries; retries++ {
-		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: lock file just needs to exist
-		if err == nil {
-			_, writeErr := fmt.Fprintf(f, "%s\n%d", taskName, os.Getpid())
-			closeErr := f.Close()
-			if writeErr != nil || closeErr != nil {
-				_ = os.Remove(lockFile)
-				logging.Warn("Failed to write lock file: write=%v, close=%v", writeErr, closeErr)
-				time.Sleep(constants.MergeLockRetryInterval)
-				continue
+			kind := service.FailureBuild
+			if e.Name == "test" {
+				kind = service.FailureTest
+			}
+			if _, recErr := service.RecordFailure(targetTask.GetFailurePath(), kind, e.Command); recErr != nil {
+				logging.Warn("Failed to record verification failure: %v", recErr)
 			}
-			return true
-		}
 
-		if isStaleLock(lockFile) {
-			logging.Debug("Detected stale merge lock, removing...")
-			if rmErr := os.Remove(lockFile); rmErr != nil {
-				logging.Warn("Failed to remove stale lock: %v", rmErr)
+			fmt.Println()
+			fmt.Printf("  ✗ %s failed; not pushing\n", e.Name)
+			fmt.Printf("    Output: %s\n", targetTask.GetHookOutputPath(name))
+			if err := renameWindowWithStatus(tm, windowID, windowNameForStatus(targetTask.Name, task.StatusWaiting), appCtx.PawDir, targetTask.Name, "end-task", task.StatusWaiting); err != nil {
+				logging.Warn("Failed to rename window: %v", err)
+			}
+			notify.PlaySound(notify.SoundError)
+			if err := tm.DisplayMessage(fmt.Sprintf("⚠️ Verification failed (%s): %s", e.Name, targetTask.Name), constants.DisplayMsgImportant); err != nil {
+				logging.Trace("Failed to display message: %v", err)
 			}
-			continue
+			return false
 		}
-
-		logging.Trace("Waiting for merge lock (attempt %d/%d)...", retries+1, constants.MergeLockMaxRetries)
-		time.Sleep(constants.MergeLockRetryInterval)
+		spinner.Stop(true, "")
 	}
-	return false
+	fmt.Printf("  ✓ Verified (%s)\n", strings.Join(names, ", "))
+	return true
 }
 
-// performMerge executes the git merge operation.
-func performMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir, mainBranch, currentBranch string, gitClient git.Client, mergeTimer *logging.Timer) bool {
-	// Check if remote origin exists
-	hasRemote := gitClient.HasRemote(appCtx.ProjectDir, "origin")
-
-	// Fetch latest from origin (only if remote exists)
-	if hasRemote {
-		fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
-		fetchSpinner.Start()
-		logging.Debug("Fetching from origin...")
-		if err := gitClient.Fetch(appCtx.ProjectDir, "origin"); err != nil {
-			logging.Warn("Failed to fetch: %v", err)
-			fetchSpinner.Stop(false, err.Error())
-		} else {
-			fetchSpinner.Stop(true, "")
-		}
-	} else {
-		logging.Debug("No remote 'origin' found, skipping fetch")
-		fmt.Println("  ○ No remote origin (local repo)")
-	}
-
-	// Check if main branch exists before checkout
-	if !gitClient.BranchExists(appCtx.ProjectDir, mainBranch) {
-		logging.Warn("Main branch %s does not exist", mainBranch)
-		mergeTimer.StopWithResult(false, "main branch not found")
-		fmt.Printf("\n  ✗ Branch '%s' does not exist\n", mainBranch)
-		fmt.Println("    This appears to be a new repository without a main branch.")
-		fmt.Println("    Consider renaming your task branch to main instead of merging.")
-		return false
-	}
-
-	// Checkout main
-	checkoutSpinner := tui.NewSimpleSpinner("Checking out " + mainBranch)
-	checkoutSpinner.Start()
-	logging.Debug("Checking out %s...", mainBranch)
-	if err := gitClient.Checkout(appCtx.ProjectDir, mainBranch); err != nil {
-		logging.Warn("Failed to checkout %s: %v", mainBranch, err)
-		mergeTimer.StopWithResult(false, "checkout failed")
-		checkoutSpinner.Stop(false, err.Error())
-		return false
-	}
-	checkoutSpinner.Stop(true, "")
-
-	// Pull latest (only if remote exists)
-	if hasRemote {
-		pullSpinner := tui.NewSimpleSpinner("Pulling latest changes")
-		pullSpinner.Start()
-		logging.Debug("Pulling latest changes...")
-		if err := gitClient.Pull(appCtx.ProjectDir); err != nil {
-			logging.Warn("Failed to pull: %v", err)
-			pullSpinner.Stop(false, err.Error())
-		} else {
-			pullSpinner.Stop(true, "")
-		}
+// createTaskPR pushes the task branch and creates a pull request for it.
+// On success the task window is switched to review and the PR is watched.
+func createTaskPR(appCtx *app.App, targetTask *task.Task, sessionName, windowID, workDir string, gitClient git.Client, tm tmux.Client) {
+	branchName, ok := resolvePushBranch(gitClient, workDir, targetTask.Name)
+	if !ok {
+		fmt.Println("  ⚠️  Failed to determine branch for PR creation")
+		return
 	}
 
-	// Merge task branch (squash)
-	mergeSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Merging %s into %s", targetTask.Name, mainBranch))
-	mergeSpinner.Start()
-	logging.Debug("Squash merging branch %s into %s...", targetTask.Name, mainBranch)
+	lease, err := resolvePushLease(gitClient, workDir, branchName)
+	if err != nil {
+		fmt.Printf("  ⚠️  Not pushing %s: %v\n", branchName, err)
+		return
+	}
 
-	branchCommits, _ := gitClient.GetBranchCommits(appCtx.ProjectDir, targetTask.Name, mainBranch, 20)
-	mergeMsg := git.GenerateMergeCommitMessage(targetTask.Name, branchCommits)
-	mergeConflictOccurred := false
-	mergeSuccess := true
+	// Prepare the PR while the branch is being pushed
+	ghClient := github.New()
+	var ghInstalled bool
+	var baseBranch, parent, prBody string
+	errs := tui.RunParallel(
+		tui.ParallelStep{
+			Message: fmt.Sprintf("Pushing %s to remote", branchName),
+			Run: func() (string, error) {
+				pushTimer := logging.StartTimer("git push")
+				if err := gitClient.PushWithLease(workDir, "origin", branchName, lease); err != nil {
+					pushTimer.StopWithResult(false, err.Error())
+					return "", err
+				}
+				pushTimer.StopWithResult(true, "branch="+branchName)
+				return branchName, nil
+			},
+		},
+		tui.ParallelStep{
+			Message: "Preparing pull request",
+			Run: func() (string, error) {
+				ghInstalled = ghClient.IsInstalled()
+				mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+				baseBranch, parent = prBaseBranch(appCtx, targetTask, gitClient, mainBranch)
+				commits, err := gitClient.GetBranchCommits(workDir, branchName, baseBranch, 20)
+				if err != nil {
+					logging.Warn("Failed to read branch commits: %v", err)
+					commits = nil
+				}
+				prBody = buildPRBody(targetTask.Name, commits, loadCoverageReport(targetTask))
+				return "", nil
+			},
+		},
+	)
+	if errs[0] != nil {
+		fmt.Printf("  ⚠️  Failed to push branch: %v\n", errs[0])
+		return
+	}
 
-	if err := gitClient.MergeSquash(appCtx.ProjectDir, targetTask.Name, mergeMsg); err != nil {
-		logging.Warn("Merge failed: %v - checking for conflicts", err)
-		mergeSpinner.Stop(false, "conflict")
-		mergeConflictOccurred = true
+	if !ghInstalled {
+		fmt.Println("  ⚠️  gh CLI not found; cannot create PR")
+		return
+	}
 
-		mergeSuccess = handleMergeConflicts(appCtx, targetTask, mainBranch, mergeMsg, gitClient, mergeTimer)
+	if parent != "" && baseBranch != parent {
+		fmt.Printf("  ⚠️  %s is not on the remote; the PR targets %s and includes its commits\n", parent, baseBranch)
 	}
 
-	if mergeSuccess {
-		if !mergeConflictOccurred {
-			mergeSpinner.Stop(true, "")
-		}
-		mergeTimer.StopWithResult(true, fmt.Sprintf("squash merged %s into %s (local only)", targetTask.Name, mainBranch))
+	prTitle := buildPRTitle(targetTask.Name)
+
+	prSpinner := tui.NewSimpleSpinner("Creating pull request")
+	prSpinner.Start()
+	prTimer := logging.StartTimer("gh pr create")
+	prNumber, prURL, err := ghClient.CreatePR(workDir, prTitle, prBody, baseBranch)
+	if err != nil {
+		prTimer.StopWithResult(false, err.Error())
+		prSpinner.Stop(false, err.Error())
+		fmt.Printf("  ⚠️  Failed to create PR: %v\n", err)
+		return
 	}
+	prTimer.StopWithResult(true, fmt.Sprintf("pr=%d", prNumber))
+	prSpinner.Stop(true, fmt.Sprintf("#%d", prNumber))
+	fmt.Printf("  ✓ PR created: %s\n", prURL)
 
-	if mergeSuccess && appCtx.Config != nil && appCtx.Config.PostMergeHook != "" {
-		hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
-		hookSpinner := tui.NewSimpleSpinner("Running post-merge hook")
-		hookSpinner.Start()
-		if _, err := service.RunHook(
-			"post-merge",
-			appCtx.Config.PostMergeHook,
-			appCtx.ProjectDir,
-			hookEnv,
-			targetTask.GetHookOutputPath("post-merge"),
-			targetTask.GetHookMetaPath("post-merge"),
-			constants.DefaultHookTimeout,
-		); err != nil {
-			logging.Warn("Post-merge hook failed: %v", err)
-			hookSpinner.Stop(false, err.Error())
-		} else {
-			hookSpinner.Stop(true, "")
-		}
+	if err := targetTask.SavePRNumber(prNumber); err != nil {
+		logging.Warn("Failed to save PR number: %v", err)
 	}
 
-	// Restore original branch if different from main
-	if currentBranch != "" && currentBranch != mainBranch {
-		restoreSpinner := tui.NewSimpleSpinner("Restoring " + currentBranch)
-		restoreSpinner.Start()
-		if err := gitClient.Checkout(appCtx.ProjectDir, currentBranch); err != nil {
-			logging.Warn("Failed to restore branch: %v", err)
-			restoreSpinner.Stop(false, err.Error())
-		} else {
-			restoreSpinner.Stop(true, "")
-		}
+	reviewName := constants.EmojiReview + constants.TruncateForWindowName(targetTask.Name)
+	if err := renameWindowWithStatus(tm, windowID, reviewName, appCtx.PawDir, targetTask.Name, "end-task", task.StatusWaiting); err != nil {
+		logging.Warn("Failed to rename window for PR review: %v", err)
 	}
 
-	return mergeSuccess
+	startPRWatch(appCtx, sessionName, windowID, targetTask.Name, prNumber)
+	showPRPopup(tm, sessionName, prNumber, prURL)
 }
 
-// handleMergeConflicts attempts to resolve merge conflicts.
-func handleMergeConflicts(appCtx *app.App, targetTask *task.Task, mainBranch, mergeMsg string, gitClient git.Client, mergeTimer *logging.Timer) bool {
-	hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-	if hasConflicts && len(conflictFiles) > 0 {
-		fmt.Println()
-		fmt.Printf("  ⚠️  Merge conflicts detected in %d file(s):\n", len(conflictFiles))
-		for _, f := range conflictFiles {
-			fmt.Printf("      - %s\n", f)
-		}
-		fmt.Println()
+// fallbackToPR handles a push rejected because the main branch is protected:
+// it undoes the local merge into main and opens a pull request for the task instead.
+func fallbackToPR(appCtx *app.App, targetTask *task.Task, sessionName, windowID, workDir, mainBranch, preMergeHead string, gitClient git.Client, tm tmux.Client) {
+	logging.Log("Push to %s rejected by branch protection; falling back to PR for task %s", mainBranch, targetTask.Name)
+	fmt.Println()
+	fmt.Printf("  ⚠️  %s is protected on the remote and rejected the direct push.\n", mainBranch)
+	fmt.Println("  Creating a pull request instead.")
 
-		resolveSpinner := tui.NewSimpleSpinner("Resolving conflicts with Claude")
-		resolveSpinner.Start()
+	if err := gitClient.ResetBranch(appCtx.ProjectDir, mainBranch, preMergeHead); err != nil {
+		logging.Warn("Failed to undo local merge into %s: %v", mainBranch, err)
+		fmt.Printf("  ⚠️  Could not undo the local merge into %s: %v\n", mainBranch, err)
+		fmt.Printf("     Run: git -C %s reset --keep %s\n", appCtx.ProjectDir, preMergeHead)
+	} else {
+		fmt.Printf("  ✓ Undid the local merge into %s\n", mainBranch)
+	}
+	fmt.Println()
 
-		taskContent, _ := targetTask.LoadContent()
+	createTaskPR(appCtx, targetTask, sessionName, windowID, workDir, gitClient, tm)
+}
 
-		if resolveErr := resolveConflictsWithClaude(appCtx.ProjectDir, targetTask.Name, taskContent, conflictFiles); resolveErr != nil {
-			logging.Warn("Claude conflict resolution failed: %v", resolveErr)
-			resolveSpinner.Stop(false, "failed")
-			if abortErr := gitClient.MergeAbort(appCtx.ProjectDir); abortErr != nil {
-				logging.Warn("Failed to abort merge: %v", abortErr)
-			}
-			mergeTimer.StopWithResult(false, "conflict resolution failed")
-			return false
-		}
+// runAutoMerge performs the auto-merge process for a task.
+// Returns true if merge succeeded, false if failed.
+func runAutoMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
+	logging.Log("auto-merge: starting merge process")
+	fmt.Println()
+	fmt.Println("  Auto-merge mode:")
 
-		stillHasConflicts, remainingFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-		if stillHasConflicts && len(remainingFiles) > 0 {
-			logging.Warn("Conflicts still exist after Claude resolution: %v", remainingFiles)
-			resolveSpinner.Stop(false, "unresolved")
-			if abortErr := gitClient.MergeAbort(appCtx.ProjectDir); abortErr != nil {
-				logging.Warn("Failed to abort merge: %v", abortErr)
-			}
-			mergeTimer.StopWithResult(false, "conflicts remain")
-			return false
-		}
+	// Get main branch name
+	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+	logging.Debug("Main branch: %s", mainBranch)
 
-		resolveSpinner.Stop(true, "resolved")
-		logging.Log("Conflicts resolved by Claude, completing merge")
+	lifecycle.RunMergeHook(appCtx, targetTask, "pre-merge", workDir, windowID)
 
-		addAllWithClaudeGuard(gitClient, appCtx.ProjectDir, "end-task conflict resolution")
+	mergeTimer := logging.StartTimer("auto-merge")
 
-		if commitErr := gitClient.Commit(appCtx.ProjectDir, mergeMsg); commitErr != nil {
-			logging.Warn("Failed to commit merge: %v", commitErr)
-			mergeTimer.StopWithResult(false, "commit failed")
-			return false
-		}
-		logging.Log("Merge completed after conflict resolution")
-		return true
+	// Acquire merge lock to prevent concurrent merges
+	lockSpinner := tui.NewSimpleSpinner("Acquiring merge lock")
+	lockSpinner.Start()
+
+	mergeLock, err := acquireMergeLock(appCtx.PawDir, targetTask.Name)
+	if err != nil {
+		logging.Warn("Failed to acquire merge lock: %v", err)
+		mergeTimer.StopWithResult(false, "lock timeout")
+		lockSpinner.Stop(false, fmt.Sprintf("timeout after %s", constants.MergeLockTimeout))
+		return handleMergeFailure(appCtx, targetTask, windowID, tm)
 	}
+	lockSpinner.Stop(true, "")
+	defer func() { _ = mergeLock.Release() }()
 
-	// No conflicts detected, but merge still failed - try auto-resolution
-	logging.Warn("Merge failed without conflicts - attempting auto-resolution with Claude")
-	fmt.Println()
-	fmt.Println("  ⚠️  Merge failed without obvious conflicts")
-	fmt.Println()
+	if !mergeIntoMain(appCtx, targetTask, windowID, workDir, mainBranch, gitClient, mergeTimer, nil) {
+		return handleMergeFailure(appCtx, targetTask, windowID, tm)
+	}
+	return true
+}
 
-	autoResolveSpinner := tui.NewSimpleSpinner("Attempting auto-resolution with Claude")
-	autoResolveSpinner.Start()
+// mergeIntoMain squash-merges the task branch into mainBranch in the project
+// directory (see lifecycle.Merge), resolving conflicts with Claude and
+// recording the task's changelog entry. afterMerge, if set, runs once the
+// merge is committed; returning false marks the merge as failed.
+// The caller must hold the merge lock.
+func mergeIntoMain(appCtx *app.App, targetTask *task.Task, windowID, workDir, mainBranch string, gitClient git.Client, mergeTimer *logging.Timer, afterMerge func() bool) bool {
+	merge := &lifecycle.Merge{
+		App:        appCtx,
+		Task:       targetTask,
+		WindowID:   windowID,
+		WorkDir:    workDir,
+		MainBranch: mainBranch,
+		Git:        gitClient,
+		Timer:      mergeTimer,
+		ResolveConflicts: func(mergeMsg string) bool {
+			return resolveMergeConflicts(appCtx, targetTask, mainBranch, mergeMsg, gitClient, mergeTimer)
+		},
+		AutoResolve: func() error {
+			taskContent, _ := targetTask.LoadContent()
+			return autoResolveMergeFailure(appCtx.ProjectDir, targetTask.Name, taskContent, targetTask.Name, mainBranch, gitClient)
+		},
+		AfterMerge: func() bool {
+			recordChangelog(appCtx, targetTask.Name, gitClient)
+			return afterMerge == nil || afterMerge()
+		},
+	}
+	return merge.Run()
+}
+
+// resolveMergeConflicts has Claude resolve the conflicts of squash-merging the
+// task in a temporary worktree, then fast-forwards the main branch to the
+// result. The project checkout is only touched by the fast-forward.
+func resolveMergeConflicts(appCtx *app.App, targetTask *task.Task, mainBranch, mergeMsg string, gitClient git.Client, mergeTimer *logging.Timer) bool {
+	resolveSpinner := tui.NewSimpleSpinner("Resolving conflicts with Claude in a temporary worktree")
+	resolveSpinner.Start()
 
 	taskContent, _ := targetTask.LoadContent()
-	if autoResolveErr := autoResolveMergeFailure(appCtx.ProjectDir, targetTask.Name, taskContent, targetTask.Name, mainBranch, gitClient); autoResolveErr != nil {
-		logging.Warn("Auto-resolution failed: %v", autoResolveErr)
-		autoResolveSpinner.Stop(false, "failed")
-		if abortErr := gitClient.MergeAbort(appCtx.ProjectDir); abortErr != nil {
-			logging.Warn("Failed to abort merge: %v", abortErr)
-		}
-		mergeTimer.StopWithResult(false, "merge failed")
-		return false
+	transcript := &service.ConflictTranscript{Task: targetTask.Name, Into: mainBranch, Time: time.Now(), Result: "resolved"}
+	err := resolveConflictsInWorktree(appCtx, targetTask.Name, taskContent, mainBranch, mergeMsg, gitClient, transcript)
+	if err != nil {
+		transcript.Result = "abandoned: " + err.Error()
+		logging.Warn("Claude conflict resolution failed: %v", err)
+		resolveSpinner.Stop(false, "failed")
+		mergeTimer.StopWithResult(false, "conflict resolution failed")
+	} else {
+		resolveSpinner.Stop(true, "resolved")
+		logging.Log("Merge completed after conflict resolution")
 	}
 
-	autoResolveSpinner.Stop(true, "resolved")
-	logging.Log("Merge issue resolved by Claude auto-resolution")
-	return true
+	// Keep Claude's session with the task's artifacts, which are saved to history
+	if transcript.Prompt != "" {
+		if path, saveErr := service.SaveConflictTranscript(targetTask.GetArtifactsDir(), transcript); saveErr != nil {
+			logging.Warn("Failed to save conflict transcript: %v", saveErr)
+		} else {
+			logging.Log("Conflict resolution transcript saved: %s", path)
+			fmt.Printf("  ✓ Conflict resolution saved to artifacts (paw artifacts %s)\n", targetTask.Name)
+		}
+	}
+	return err == nil
 }
 
 // handleMergeFailure handles the case when merge fails.
@@ -745,6 +831,9 @@ func handleMergeFailure(appCtx *app.App, targetTask *task.Task, windowID string,
 	logging.Warn("Merge failed - keeping task for manual resolution")
 	fmt.Println()
 	fmt.Println("  ✗ Merge failed - manual resolution needed")
+	if _, err := service.RecordFailure(targetTask.GetFailurePath(), service.FailureMergeConflict, "merge into main"); err != nil {
+		logging.Warn("Failed to record merge failure: %v", err)
+	}
 	corruptedWindowName := windowNameForStatus(targetTask.Name, task.StatusCorrupted)
 	logging.Trace("endTaskCmd: renaming window to corrupted state name=%s", corruptedWindowName)
 	if err := renameWindowWithStatus(tm, windowID, corruptedWindowName, appCtx.PawDir, targetTask.Name, "end-task", task.StatusCorrupted); err != nil {
@@ -769,7 +858,7 @@ func buildPRTitle(taskName string) string {
 	return fmt.Sprintf("%s: %s", commitType, subject)
 }
 
-func buildPRBody(taskName string, commits []git.CommitInfo) string {
+func buildPRBody(taskName string, commits []git.CommitInfo, coverage string) string {
 	summary := constants.FormatTaskNameForCommit(taskName)
 	if summary == "" {
 		summary = taskName
@@ -793,6 +882,11 @@ func buildPRBody(taskName string, commits []git.CommitInfo) string {
 		}
 	}
 
+	if coverage != "" {
+		sb.WriteString("\n## Coverage\n")
+		sb.WriteString("- " + coverage + "\n")
+	}
+
 	return strings.TrimSpace(sb.String())
 }
 
@@ -812,6 +906,60 @@ func startPRWatch(appCtx *app.App, sessionName, windowID, taskName string, prNum
 	}
 }
 
+// newHistoryService creates a history service for the session's project.
+// Summaries are generated in the background so saving history never blocks end-task.
+func newHistoryService(appCtx *app.App, sessionName string) *service.HistoryService {
+	historyService := service.NewHistoryService(appCtx.GetHistoryDir())
+	historyService.SetEncryption(appCtx.Config != nil && appCtx.Config.HistoryEncryption)
+	historyService.SetAsyncSummary(func(historyFile string) {
+		startHistorySummary(appCtx, sessionName, historyFile)
+	})
+	return historyService
+}
+
+// startHistorySummary starts a detached process that writes the summary into a history file.
+func startHistorySummary(appCtx *app.App, sessionName, historyFile string) {
+	summaryCmd := exec.Command(getPawBin(), "internal", "summarize-history", sessionName, historyFile) //nolint:gosec // G204: pawBin is from getPawBin()
+	summaryCmd.Dir = appCtx.ProjectDir
+	summaryCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := summaryCmd.Start(); err != nil {
+		logging.Warn("Failed to start history summary: %v", err)
+	} else {
+		logging.Debug("History summary started for %s", historyFile)
+	}
+}
+
+var summarizeHistoryCmd = &cobra.Command{
+	Use:   "summarize-history [session] [history-file]",
+	Short: "Generate the summary of a saved history entry",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		historyFile := args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "summarize-history", service.ExtractTaskName(historyFile))
+		defer cleanup()
+
+		historyService := service.NewHistoryService(appCtx.GetHistoryDir())
+		timer := logging.StartTimer("history summary")
+		if err := historyService.FillSummary(historyFile); err != nil {
+			timer.StopWithResult(false, err.Error())
+			logging.Warn("Failed to generate summary: %v", err)
+			return err
+		}
+		timer.StopWithResult(true, "")
+		return nil
+	},
+}
+
 func showPRPopup(tm tmux.Client, sessionName string, prNumber int, prURL string) {
 	popupCmd := shellJoin(getPawBin(), "internal", "pr-popup-tui", sessionName, strconv.Itoa(prNumber), prURL)
 	_ = tm.DisplayPopup(tmux.PopupOpts{
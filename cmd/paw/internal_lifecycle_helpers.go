@@ -1,24 +1,32 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
-	"syscall"
 
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/lock"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/tui"
 )
 
 // resolveConflictsWithClaude attempts to resolve merge conflicts using Claude.
-// It runs Claude with opus model for better conflict resolution.
+// It runs Claude with opus model for better conflict resolution, and records
+// the prompt and Claude's output in transcript.
 // Returns nil if conflicts were resolved, error otherwise.
-func resolveConflictsWithClaude(projectDir, taskName, taskContent string, conflictFiles []string) error {
+func resolveConflictsWithClaude(projectDir, taskName, taskContent string, conflictFiles []string, transcript *service.ConflictTranscript) error {
 	if len(conflictFiles) == 0 {
 		return nil
 	}
@@ -52,6 +60,7 @@ Start resolving the conflicts now.`, filesStr, taskName, taskContent)
 
 	logging.Debug("resolveConflictsWithClaude: starting conflict resolution for %d files with opus", len(conflictFiles))
 	logging.Trace("resolveConflictsWithClaude: prompt=%s", prompt)
+	transcript.Prompt = prompt
 
 	// Set a timeout for conflict resolution
 	ctx, cancel := context.WithTimeout(context.Background(), constants.ConflictResolutionTimeout)
@@ -61,10 +70,13 @@ Start resolving the conflicts now.`, filesStr, taskName, taskContent)
 	cmd := exec.CommandContext(ctx, "claude", "-p", "--model", "opus", "--dangerously-skip-permissions")
 	cmd.Dir = projectDir
 	cmd.Stdin = strings.NewReader(prompt)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(os.Stdout, &output)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &output)
 
-	if err := cmd.Run(); err != nil {
+	err := cmd.Run()
+	transcript.Output = output.String()
+	if err != nil {
 		logging.Warn("resolveConflictsWithClaude: claude command failed: %v", err)
 		return fmt.Errorf("claude conflict resolution failed: %w", err)
 	}
@@ -73,13 +85,106 @@ Start resolving the conflicts now.`, filesStr, taskName, taskContent)
 	return nil
 }
 
+// resolveConflictsInWorktree squash-merges the task branch into the main
+// branch in a temporary worktree, has Claude resolve the conflicts there, and
+// commits the result. The main branch is then fast-forwarded to that commit,
+// so a failed or misbehaving resolution never reaches the project checkout.
+// The project directory must have the main branch checked out. The
+// conflicts, Claude's session, and the resolution are recorded in transcript.
+func resolveConflictsInWorktree(appCtx *app.App, taskName, taskContent, mainBranch, mergeMsg string, gitClient git.Client, transcript *service.ConflictTranscript) error {
+	base, err := gitClient.GetBranchHead(appCtx.ProjectDir, mainBranch)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", mainBranch, err)
+	}
+	parent := filepath.Join(appCtx.PawDir, constants.ConflictWorktreeDirName)
+	if err := os.MkdirAll(parent, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	dir, err := os.MkdirTemp(parent, taskName+"-")
+	if err != nil {
+		return err
+	}
+	if err := gitClient.WorktreeAddDetached(appCtx.ProjectDir, dir, base); err != nil {
+		_ = os.RemoveAll(dir)
+		return fmt.Errorf("failed to create conflict worktree: %w", err)
+	}
+	defer func() {
+		if err := gitClient.WorktreeRemove(appCtx.ProjectDir, dir, true); err != nil {
+			logging.Warn("Failed to remove conflict worktree: %v", err)
+			_ = os.RemoveAll(dir)
+			_ = gitClient.WorktreePrune(appCtx.ProjectDir)
+		}
+	}()
+	logging.Debug("resolveConflictsInWorktree: merging %s into %s in %s", taskName, mainBranch, dir)
+
+	if err := gitClient.MergeSquash(dir, taskName, mergeMsg); err != nil {
+		_, conflictFiles, _ := gitClient.HasConflicts(dir)
+		if len(conflictFiles) == 0 {
+			return fmt.Errorf("merge failed without conflicts: %w", err)
+		}
+		transcript.Files = conflictFiles
+		transcript.Conflicts = make(map[string]string, len(conflictFiles))
+		for _, f := range conflictFiles {
+			if data, err := os.ReadFile(filepath.Join(dir, f)); err == nil { //nolint:gosec // G304: conflicting files reported by git
+				transcript.Conflicts[f] = string(data)
+			}
+		}
+		if err := resolveConflictsWithClaude(dir, taskName, taskContent, conflictFiles, transcript); err != nil {
+			return err
+		}
+		transcript.Diff, _ = gitClient.DiffFiles(dir, base, conflictFiles)
+		if _, remaining, _ := gitClient.HasConflicts(dir); len(remaining) > 0 {
+			return fmt.Errorf("conflicts remain in %s", strings.Join(remaining, ", "))
+		}
+		if marked := filesWithConflictMarkers(dir, conflictFiles); len(marked) > 0 {
+			return fmt.Errorf("conflict markers remain in %s", strings.Join(marked, ", "))
+		}
+		excludeNested := appCtx.Config != nil && appCtx.Config.ExcludeNestedRepos
+		addAllWithClaudeGuard(gitClient, dir, "conflict resolution", excludeNested)
+		if err := gitClient.Commit(dir, mergeMsg); err != nil {
+			return fmt.Errorf("failed to commit the resolution: %w", err)
+		}
+	}
+
+	resolved, err := gitClient.GetHeadCommit(dir)
+	if err != nil {
+		return err
+	}
+	if resolved == base {
+		return nil // Nothing to merge
+	}
+	if err := gitClient.MergeFastForward(appCtx.ProjectDir, resolved); err != nil {
+		return fmt.Errorf("failed to apply the resolution to %s: %w", mainBranch, err)
+	}
+	return nil
+}
+
+// filesWithConflictMarkers returns the files under dir that still contain
+// conflict markers.
+func filesWithConflictMarkers(dir string, files []string) []string {
+	var marked []string
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f)) //nolint:gosec // G304: conflicting files reported by git
+		if err != nil {
+			continue // Deleted as part of the resolution
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+				marked = append(marked, f)
+				break
+			}
+		}
+	}
+	return marked
+}
+
 // autoResolveMergeFailure attempts to resolve a general merge failure using Claude.
 // This is called when merge fails but no explicit conflicts are detected, or when
 // conflict resolution has failed. It uses opus model for comprehensive analysis.
 // Returns nil if the issue was resolved, error otherwise.
 func autoResolveMergeFailure(projectDir, taskName, taskContent, branchToMerge, mainBranch string, gitClient git.Client) error {
 	// Get current git status for context
-	statusOutput, _ := exec.Command("git", "-C", projectDir, "status").Output()
+	statusOutput, _ := gitClient.Status(projectDir)
 
 	// Build a comprehensive prompt for Claude
 	prompt := fmt.Sprintf(`You are an expert at resolving git merge issues. A merge operation has failed and needs your help.
@@ -114,7 +219,7 @@ IMPORTANT:
 - If you absolutely cannot resolve the issue, explain why clearly
 - Prefer completing the merge over aborting if possible
 
-Start analyzing and resolving the merge issue now.`, projectDir, branchToMerge, mainBranch, taskName, taskContent, string(statusOutput))
+Start analyzing and resolving the merge issue now.`, projectDir, branchToMerge, mainBranch, taskName, taskContent, statusOutput)
 
 	logging.Debug("autoResolveMergeFailure: starting auto-resolution for task %s with opus", taskName)
 	logging.Trace("autoResolveMergeFailure: prompt length=%d", len(prompt))
@@ -152,33 +257,54 @@ Start analyzing and resolving the merge issue now.`, projectDir, branchToMerge,
 	return nil
 }
 
-func addAllWithClaudeGuard(gitClient git.Client, workDir, context string) {
+// addAllWithClaudeGuard stages all changes in workDir. With excludeNested,
+// untracked nested git repositories are left unstaged (see exclude_nested_repos).
+func addAllWithClaudeGuard(gitClient git.Client, workDir, context string, excludeNested bool) {
 	if workDir == "" || gitClient == nil {
 		return
 	}
 
+	var nested []string
+	if excludeNested {
+		repos, err := gitClient.NestedRepos(workDir)
+		if err != nil {
+			logging.Warn("%s: failed to list nested repositories: %v", context, err)
+		}
+		if len(repos) > 0 {
+			logging.Log("%s: leaving nested repositories out of the commit: %s", context, strings.Join(repos, ", "))
+		}
+		nested = repos
+	}
+
 	// .claude is now in agent directory (outside worktree), no longer needs protection
-	if err := gitClient.AddAll(workDir); err != nil {
+	if err := gitClient.AddAllExcept(workDir, nested); err != nil {
 		logging.Warn("%s: failed to add changes: %v", context, err)
 	}
 }
 
 // commitChangesIfNeeded commits any pending changes in the working directory.
-// Returns true if changes were committed, false otherwise.
-func commitChangesIfNeeded(gitClient git.Client, workDir string) bool {
+// Returns the files held back from the commit (see guardLargeFiles), which
+// stay uncommitted in the working directory.
+func commitChangesIfNeeded(gitClient git.Client, workDir string, cfg *config.Config) []string {
 	hasChanges := gitClient.HasChanges(workDir)
 	logging.Trace("Git status: hasChanges=%v", hasChanges)
 
 	if !hasChanges {
 		fmt.Println("  ○ No changes to commit")
-		return false
+		return nil
 	}
 
 	spinner := tui.NewSimpleSpinner("Committing changes")
 	spinner.Start()
 
 	commitTimer := logging.StartTimer("git commit")
-	addAllWithClaudeGuard(gitClient, workDir, "commitChangesIfNeeded")
+	notes, heldBack := stageTaskChanges(gitClient, workDir, "commitChangesIfNeeded", cfg)
+	defer printNotes(notes)
+	if !gitClient.HasStagedChanges(workDir) {
+		commitTimer.StopWithResult(true, "nothing staged")
+		spinner.Stop(true, "nothing left to commit")
+		return heldBack
+	}
 
 	diffStat, _ := gitClient.GetDiffStat(workDir)
 	logging.Trace("Changes: %s", strings.ReplaceAll(diffStat, "\n", ", "))
@@ -191,7 +317,37 @@ func commitChangesIfNeeded(gitClient git.Client, workDir string) bool {
 		spinner.Stop(true, "")
 	}
 
-	return true
+	return heldBack
+}
+
+// errRemoteDiverged is returned when the remote task branch has commits that aren't
+// in the local branch and the user chose not to overwrite them.
+var errRemoteDiverged = errors.New("remote branch has commits not present locally")
+
+// resolvePushLease checks the remote task branch before a push and returns the remote
+// head to pass to PushWithLease. If someone else pushed commits to the branch, the
+// user is asked before they are overwritten.
+func resolvePushLease(gitClient git.Client, workDir, branch string) (string, error) {
+	head, missing, err := gitClient.RemoteBranchStatus(workDir, "origin", branch)
+	if err != nil {
+		return "", err
+	}
+	if missing == 0 {
+		return head, nil
+	}
+
+	logging.Warn("Remote branch %s has %d commit(s) not present locally", branch, missing)
+	fmt.Printf("  ⚠️  origin/%s has %d commit(s) not present locally (someone else pushed).\n", branch, missing)
+	if !canPrompt() {
+		return "", errRemoteDiverged
+	}
+	fmt.Print("  Overwrite them? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+		return "", errRemoteDiverged
+	}
+	logging.Log("User chose to overwrite remote branch %s", branch)
+	return head, nil
 }
 
 func resolvePushBranch(gitClient git.Client, workDir, fallback string) (string, bool) {
@@ -217,44 +373,39 @@ func resolvePushBranch(gitClient git.Client, workDir, fallback string) (string,
 	return fallback, true
 }
 
-// isStaleLock checks if the merge lock file is stale (the process that created it is no longer running).
-// Returns true if the lock is stale and should be removed.
-func isStaleLock(lockFile string) bool {
-	content, err := os.ReadFile(lockFile) //nolint:gosec // G304: lockFile is from pawDir/.lock
-	if err != nil {
-		// Can't read lock file - assume it's not stale
+// pushTaskBranch pushes the task branch to origin with a lease. It reports
+// whether the branch was pushed.
+func pushTaskBranch(gitClient git.Client, workDir, taskName string) bool {
+	branchName, ok := resolvePushBranch(gitClient, workDir, taskName)
+	if !ok {
+		fmt.Println("  ⚠️  Skipping push: unable to determine branch")
 		return false
 	}
-
-	lines := strings.Split(string(content), "\n")
-	if len(lines) < 2 {
-		// Invalid lock file format - consider it stale
-		logging.Trace("Invalid lock file format (missing PID), treating as stale")
-		return true
-	}
-
-	var pid int
-	if _, err := fmt.Sscanf(lines[1], "%d", &pid); err != nil {
-		// Can't parse PID - consider it stale
-		logging.Trace("Invalid PID in lock file, treating as stale")
-		return true
-	}
-
-	// Check if the process is still running by sending signal 0
-	process, err := os.FindProcess(pid)
+	lease, err := resolvePushLease(gitClient, workDir, branchName)
 	if err != nil {
-		// Process doesn't exist - stale lock
-		return true
+		logging.Warn("Not pushing task branch: %v", err)
+		fmt.Printf("  ⚠️  Skipping push of %s: %v\n", branchName, err)
+		return false
 	}
 
-	// Send signal 0 to check if process exists (doesn't actually send a signal)
-	if err := process.Signal(syscall.Signal(0)); err != nil {
-		// Process doesn't exist or we don't have permission (either way, treat as stale)
-		logging.Trace("Lock holder process %d not running (err=%v), treating as stale", pid, err)
-		return true
+	pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", branchName))
+	pushSpinner.Start()
+	if err := gitClient.PushWithLease(workDir, "origin", branchName, lease); err != nil {
+		pushSpinner.Stop(false, err.Error())
+		logging.Warn("Failed to push task branch: %v", err)
+		return false
 	}
+	pushSpinner.Stop(true, branchName)
+	return true
+}
 
-	// Process is still running - lock is valid
-	logging.Trace("Lock holder process %d is still running", pid)
-	return false
+// acquireMergeLock takes the workspace's merge lock for the task, waiting up
+// to MergeLockTimeout for another merge to finish.
+func acquireMergeLock(pawDir, taskName string) (*lock.Lock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.MergeLockTimeout)
+	defer cancel()
+	return lock.Acquire(ctx, filepath.Join(pawDir, constants.MergeLockFileName), taskName, lock.Options{
+		TTL:           constants.MergeLockTTL,
+		RetryInterval: constants.MergeLockRetryInterval,
+	})
 }
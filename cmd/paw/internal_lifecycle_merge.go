@@ -3,17 +3,14 @@ package main
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/lifecycle"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
-	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
@@ -39,7 +36,7 @@ var mergeTaskCmd = &cobra.Command{
 		logging.Debug("-> mergeTaskCmd(session=%s, windowID=%s)", sessionName, windowID)
 		defer logging.Debug("<- mergeTaskCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		fmt.Println()
 		fmt.Println("  ╭─────────────────────────────────────╮")
@@ -84,20 +81,7 @@ var mergeTaskCmd = &cobra.Command{
 			return nil
 		}
 
-		if appCtx.Config != nil && appCtx.Config.PreMergeHook != "" {
-			hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
-			if _, err := service.RunHook(
-				"pre-merge",
-				appCtx.Config.PreMergeHook,
-				appCtx.ProjectDir,
-				hookEnv,
-				targetTask.GetHookOutputPath("pre-merge"),
-				targetTask.GetHookMetaPath("pre-merge"),
-				constants.DefaultHookTimeout,
-			); err != nil {
-				logging.Warn("Pre-merge hook failed: %v", err)
-			}
-		}
+		lifecycle.RunMergeHook(appCtx, targetTask, "pre-merge", workDir, windowID)
 
 		// Commit any uncommitted changes first
 		hasChanges := gitClient.HasChanges(workDir)
@@ -105,260 +89,57 @@ var mergeTaskCmd = &cobra.Command{
 			commitSpinner := tui.NewSimpleSpinner("Committing changes")
 			commitSpinner.Start()
 
-			addAllWithClaudeGuard(gitClient, workDir, "merge-task commit")
-
-			diffStat, _ := gitClient.GetDiffStat(workDir)
-			message := fmt.Sprintf(constants.CommitMessageAutoCommitMerge, diffStat)
-			if err := gitClient.Commit(workDir, message); err != nil {
-				commitSpinner.Stop(false, err.Error())
+			notes, _ := stageTaskChanges(gitClient, workDir, "merge-task commit", appCtx.Config)
+			if !gitClient.HasStagedChanges(workDir) {
+				commitSpinner.Stop(true, "nothing left to commit")
 			} else {
-				commitSpinner.Stop(true, "")
+				diffStat, _ := gitClient.GetDiffStat(workDir)
+				message := fmt.Sprintf(constants.CommitMessageAutoCommitMerge, diffStat)
+				if err := gitClient.Commit(workDir, message); err != nil {
+					commitSpinner.Stop(false, err.Error())
+				} else {
+					commitSpinner.Stop(true, "")
+				}
 			}
+			printNotes(notes)
 		}
 
 		// Push task branch
-		branchName, ok := resolvePushBranch(gitClient, workDir, targetTask.Name)
-		if !ok {
-			fmt.Println("  ⚠️  Skipping push: unable to determine branch")
-		} else {
-			pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", branchName))
-			pushSpinner.Start()
+		pushTaskBranch(gitClient, workDir, targetTask.Name)
 
-			if err := gitClient.Push(workDir, "origin", branchName, true); err != nil {
-				pushSpinner.Stop(false, err.Error())
-				logging.Warn("Failed to push task branch: %v", err)
-			} else {
-				pushSpinner.Stop(true, branchName)
-			}
-		}
+		mergeTimer := logging.StartTimer("merge-task")
 
 		// Acquire merge lock
 		lockSpinner := tui.NewSimpleSpinner("Acquiring merge lock")
 		lockSpinner.Start()
 
-		lockFile := filepath.Join(appCtx.PawDir, "merge.lock")
-		lockAcquired := false
-		for retries := 0; retries < constants.MergeLockMaxRetries; retries++ {
-			f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: lock file just needs to exist
-			if err == nil {
-				_, writeErr := fmt.Fprintf(f, "%s\n%d", targetTask.Name, os.Getpid())
-				closeErr := f.Close()
-				if writeErr != nil || closeErr != nil {
-					_ = os.Remove(lockFile)
-					time.Sleep(constants.MergeLockRetryInterval)
-					continue
-				}
-				lockAcquired = true
-				break
-			}
-
-			if isStaleLock(lockFile) {
-				_ = os.Remove(lockFile)
-				continue
-			}
-
-			time.Sleep(constants.MergeLockRetryInterval)
-		}
-
-		if !lockAcquired {
+		mergeLock, err := acquireMergeLock(appCtx.PawDir, targetTask.Name)
+		if err != nil {
+			logging.Warn("Failed to acquire merge lock: %v", err)
+			mergeTimer.StopWithResult(false, "lock timeout")
 			lockSpinner.Stop(false, "timeout")
 			fmt.Println("  ✗ Failed to acquire merge lock")
 			return nil
 		}
 		lockSpinner.Stop(true, "")
-		defer func() { _ = os.Remove(lockFile) }()
-
-		// Check for ongoing merge or conflicts
-		hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-		hasOngoingMerge := gitClient.HasOngoingMerge(appCtx.ProjectDir)
-
-		if hasConflicts || hasOngoingMerge {
-			fmt.Println("  ⚠️  Project has unresolved conflicts or ongoing merge")
-			if hasConflicts && len(conflictFiles) > 0 {
-				for _, f := range conflictFiles {
-					fmt.Printf("    - %s\n", f)
-				}
+		defer func() { _ = mergeLock.Release() }()
+
+		// Unlike end-task, merge-task keeps the task, so restack its children
+		// and push main right away
+		mergeSuccess := mergeIntoMain(appCtx, targetTask, windowID, workDir, mainBranch, gitClient, mergeTimer, func() bool {
+			restackChildren(appCtx, targetTask.Name, gitClient, tm)
+			if !gitClient.HasRemote(appCtx.ProjectDir, "origin") {
+				return true
 			}
-			fmt.Printf("\n  Please resolve in: %s\n", appCtx.ProjectDir)
-			return nil
-		}
-
-		// Stash local changes in project dir
-		hasLocalChanges := gitClient.HasChanges(appCtx.ProjectDir)
-		if hasLocalChanges {
-			if err := gitClient.StashPush(appCtx.ProjectDir, constants.MergeStashMessage); err != nil {
-				logging.Warn("Failed to stash changes: %v", err)
-			}
-		}
-
-		// Remember current branch
-		currentBranch, _ := gitClient.GetCurrentBranch(appCtx.ProjectDir)
-
-		mergeSuccess := true
-
-		// Check if remote origin exists
-		hasRemote := gitClient.HasRemote(appCtx.ProjectDir, "origin")
-
-		// Fetch from origin (only if remote exists)
-		if hasRemote {
-			fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
-			fetchSpinner.Start()
-			if err := gitClient.Fetch(appCtx.ProjectDir, "origin"); err != nil {
-				fetchSpinner.Stop(false, err.Error())
-			} else {
-				fetchSpinner.Stop(true, "")
+			pushMainSpinner := tui.NewSimpleSpinner("Pushing " + mainBranch)
+			pushMainSpinner.Start()
+			if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
+				pushMainSpinner.Stop(false, err.Error())
+				return false
 			}
-		} else {
-			fmt.Println("  ○ No remote origin (local repo)")
-		}
-
-		// Check if main branch exists before checkout
-		if !gitClient.BranchExists(appCtx.ProjectDir, mainBranch) {
-			fmt.Printf("\n  ✗ Branch '%s' does not exist\n", mainBranch)
-			fmt.Println("    This appears to be a new repository without a main branch.")
-			return nil
-		}
-
-		// Checkout main
-		checkoutSpinner := tui.NewSimpleSpinner("Checking out " + mainBranch)
-		checkoutSpinner.Start()
-		if err := gitClient.Checkout(appCtx.ProjectDir, mainBranch); err != nil {
-			checkoutSpinner.Stop(false, err.Error())
-			mergeSuccess = false
-		} else {
-			checkoutSpinner.Stop(true, "")
-
-			// Pull latest (only if remote exists)
-			if hasRemote {
-				pullSpinner := tui.NewSimpleSpinner("Pulling latest")
-				pullSpinner.Start()
-				if err := gitClient.Pull(appCtx.ProjectDir); err != nil {
-					pullSpinner.Stop(false, err.Error())
-				} else {
-					pullSpinner.Stop(true, "")
-				}
-			}
-
-			// Squash merge
-			mergeSpinner := tui.NewSimpleSpinner("Merging " + targetTask.Name)
-			mergeSpinner.Start()
-			branchCommits, _ := gitClient.GetBranchCommits(appCtx.ProjectDir, targetTask.Name, mainBranch, 20)
-			mergeMsg := git.GenerateMergeCommitMessage(targetTask.Name, branchCommits)
-			mergeConflictOccurred := false
-			if err := gitClient.MergeSquash(appCtx.ProjectDir, targetTask.Name, mergeMsg); err != nil {
-				mergeSpinner.Stop(false, "conflict")
-				mergeConflictOccurred = true
-
-				// Check if this is a conflict situation
-				hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-				if hasConflicts && len(conflictFiles) > 0 {
-					// Try to resolve conflicts with Claude
-					fmt.Println()
-					fmt.Printf("  ⚠️  Merge conflicts detected in %d file(s):\n", len(conflictFiles))
-					for _, f := range conflictFiles {
-						fmt.Printf("      - %s\n", f)
-					}
-					fmt.Println()
-
-					resolveSpinner := tui.NewSimpleSpinner("Resolving conflicts with Claude")
-					resolveSpinner.Start()
-
-					taskContent, _ := targetTask.LoadContent()
-
-					if resolveErr := resolveConflictsWithClaude(appCtx.ProjectDir, targetTask.Name, taskContent, conflictFiles); resolveErr != nil {
-						logging.Warn("Claude conflict resolution failed: %v", resolveErr)
-						resolveSpinner.Stop(false, "failed")
-						_ = gitClient.MergeAbort(appCtx.ProjectDir)
-						mergeSuccess = false
-					} else {
-						stillHasConflicts, remainingFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
-						if stillHasConflicts && len(remainingFiles) > 0 {
-							logging.Warn("Conflicts still exist after Claude resolution: %v", remainingFiles)
-							resolveSpinner.Stop(false, "unresolved")
-							_ = gitClient.MergeAbort(appCtx.ProjectDir)
-							mergeSuccess = false
-						} else {
-							resolveSpinner.Stop(true, "resolved")
-							logging.Log("Conflicts resolved by Claude, completing merge")
-
-							addAllWithClaudeGuard(gitClient, appCtx.ProjectDir, "merge-task conflict resolution")
-
-							if commitErr := gitClient.Commit(appCtx.ProjectDir, mergeMsg); commitErr != nil {
-								logging.Warn("Failed to commit merge: %v", commitErr)
-								mergeSuccess = false
-							} else {
-								logging.Log("Merge completed after conflict resolution")
-							}
-						}
-					}
-				} else {
-					// No conflicts detected, but merge still failed
-					logging.Warn("Merge failed without conflicts - attempting auto-resolution with Claude")
-					fmt.Println()
-					fmt.Println("  ⚠️  Merge failed without obvious conflicts")
-					fmt.Println()
-
-					autoResolveSpinner := tui.NewSimpleSpinner("Attempting auto-resolution with Claude")
-					autoResolveSpinner.Start()
-
-					taskContent, _ := targetTask.LoadContent()
-					if autoResolveErr := autoResolveMergeFailure(appCtx.ProjectDir, targetTask.Name, taskContent, targetTask.Name, mainBranch, gitClient); autoResolveErr != nil {
-						logging.Warn("Auto-resolution failed: %v", autoResolveErr)
-						autoResolveSpinner.Stop(false, "failed")
-						_ = gitClient.MergeAbort(appCtx.ProjectDir)
-						mergeSuccess = false
-					} else {
-						autoResolveSpinner.Stop(true, "resolved")
-						logging.Log("Merge issue resolved by Claude auto-resolution")
-					}
-				}
-			}
-
-			// If merge succeeded, push (only if remote exists)
-			if mergeSuccess {
-				if !mergeConflictOccurred {
-					mergeSpinner.Stop(true, "")
-				}
-
-				if hasRemote {
-					pushMainSpinner := tui.NewSimpleSpinner("Pushing " + mainBranch)
-					pushMainSpinner.Start()
-					if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
-						pushMainSpinner.Stop(false, err.Error())
-						mergeSuccess = false
-					} else {
-						pushMainSpinner.Stop(true, "")
-					}
-				}
-			}
-
-			// Restore original branch
-			if currentBranch != "" && currentBranch != mainBranch {
-				_ = gitClient.Checkout(appCtx.ProjectDir, currentBranch)
-			}
-		}
-
-		// Restore stashed changes by message (not blind pop)
-		if hasLocalChanges {
-			if err := gitClient.StashPopByMessage(appCtx.ProjectDir, constants.MergeStashMessage); err != nil {
-				logging.Warn("Failed to restore stashed changes: %v", err)
-			}
-		}
-
-		if mergeSuccess && appCtx.Config != nil && appCtx.Config.PostMergeHook != "" {
-			hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
-			if _, err := service.RunHook(
-				"post-merge",
-				appCtx.Config.PostMergeHook,
-				appCtx.ProjectDir,
-				hookEnv,
-				targetTask.GetHookOutputPath("post-merge"),
-				targetTask.GetHookMetaPath("post-merge"),
-				constants.DefaultHookTimeout,
-			); err != nil {
-				logging.Warn("Post-merge hook failed: %v", err)
-			}
-		}
+			pushMainSpinner.Stop(true, "")
+			return true
+		})
 
 		fmt.Println()
 		if mergeSuccess {
@@ -397,7 +178,7 @@ var mergeTaskUICmd = &cobra.Command{
 		logging.Debug("-> mergeTaskUICmd(session=%s)", sessionName)
 		defer logging.Debug("<- mergeTaskUICmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get current window info
 		windowID, windowName, err := getCurrentWindowInfo(tm)
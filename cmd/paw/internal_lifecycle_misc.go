@@ -37,7 +37,7 @@ var doneTaskCmd = &cobra.Command{
 		logging.Debug("-> doneTaskCmd(session=%s)", sessionName)
 		defer logging.Debug("<- doneTaskCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Batch query: Get window ID and name in a single tmux call
 		values, err := tm.DisplayMultiple("#{window_id}", "#{window_name}")
@@ -141,7 +141,7 @@ var resumeAgentCmd = &cobra.Command{
 
 		logging.Log("=== Resuming agent: %s ===", taskName)
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get task
 		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
@@ -176,12 +176,13 @@ export PROJECT_DIR=%s
 export PAW_HOME=%s
 export PAW_BIN=%s
 export SESSION_NAME=%s
-
+%s
 # Continue the previous Claude session (--continue auto-selects last session)
 # --settings points to agent dir's .claude (outside git worktree)
-exec claude --continue --dangerously-skip-permissions --settings %s
+exec claude --continue%s --settings %s
 `, shellQuote(taskName), shellQuote(appCtx.PawDir), shellQuote(appCtx.ProjectDir), worktreeDirExport, shellQuote(windowID),
-			shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), shellQuote(settingsPath))
+			shellQuote(filepath.Dir(filepath.Dir(pawBin))), shellQuote(pawBinSymlink), shellQuote(sessionName), proxyEnvExports(),
+			permissionFlag(appCtx.Config), shellQuote(settingsPath))
 
 		startAgentScriptPath := filepath.Join(t.AgentDir, constants.StartAgentScriptName)
 		if err := os.WriteFile(startAgentScriptPath, []byte(startAgentContent), 0755); err != nil { //nolint:gosec // G306: script needs to be executable
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var retryTaskCmd = &cobra.Command{
+	Use:    "retry-task [session] [window-id]",
+	Short:  "Re-prompt an agent to fix its last classified failure",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		windowID := args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		targetTask, err := mgr.FindTaskByWindowID(windowID)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "retry-task", targetTask.Name)
+		defer cleanup()
+
+		logging.Debug("-> retryTaskCmd(session=%s, windowID=%s)", sessionName, windowID)
+		defer logging.Debug("<- retryTaskCmd")
+
+		rec := service.LoadFailureRecord(targetTask.GetFailurePath())
+		if rec == nil {
+			logging.Debug("retryTaskCmd: no failure record for task=%s", targetTask.Name)
+			return nil
+		}
+
+		tm := newTmuxClient(sessionName)
+		agentPane := windowID + ".0"
+		useProjectReadinessRules(appCtx)
+		claudeClient := newClaudeClient()
+
+		// The stop hook starts us while Claude is still finishing its turn
+		if err := claudeClient.WaitForReady(tm, agentPane); err != nil {
+			logging.Warn("retryTaskCmd: agent not ready: %v", err)
+			return nil
+		}
+
+		prompt := service.BuildRetryPrompt(rec)
+		if err := claudeClient.SendInputWithRetry(tm, agentPane, prompt, 3); err != nil {
+			logging.Warn("retryTaskCmd: failed to send retry prompt: %v", err)
+			return nil
+		}
+
+		newName := windowNameForStatus(targetTask.Name, task.StatusWorking)
+		if err := renameWindowWithStatus(tm, windowID, newName, appCtx.PawDir, targetTask.Name, "failure-retry", task.StatusWorking); err != nil {
+			logging.Warn("retryTaskCmd: failed to rename window: %v", err)
+		}
+
+		logging.Info("retryTaskCmd: task=%s retry=%d failure=%s", targetTask.Name, rec.Attempts, rec.Kind)
+		_ = tm.DisplayMessage("🔁 Auto-retry "+targetTask.Name+": "+string(rec.Kind), constants.DisplayMsgStandard)
+		return nil
+	},
+}
+
+// checkAgentFailure classifies how the agent ended and stores the result in
+// the agent directory; a clean turn clears it, resetting the retry count.
+// failed reports that the agent stopped in a failed state (not done, and not
+// asking the user anything); only then is an auto-retry scheduled, when
+// failure_retries allows it.
+func checkAgentFailure(sessionName, windowID, pawDir, taskName string, timeline *service.Timeline, failed bool) {
+	failurePath := filepath.Join(pawDir, constants.AgentsDirName, taskName, constants.FailureFileName)
+	kind, detail := service.ClassifyFailure(timeline)
+	if kind == service.FailureNone {
+		if err := service.ClearFailureRecord(failurePath); err != nil {
+			logging.Warn("checkAgentFailure: %v", err)
+		}
+		return
+	}
+
+	rec, err := service.RecordFailure(failurePath, kind, detail)
+	if err != nil {
+		logging.Warn("checkAgentFailure: %v", err)
+		return
+	}
+	logging.Info("checkAgentFailure: task=%s failure=%s attempts=%d detail=%q", taskName, kind, rec.Attempts, detail)
+
+	if !failed {
+		return
+	}
+	cfg, err := config.Load(pawDir)
+	if err != nil || cfg.FailureRetries <= 0 || !kind.IsRetryable() {
+		return
+	}
+	if rec.Attempts > cfg.FailureRetries {
+		logging.Info("checkAgentFailure: retry limit reached for task=%s (%d)", taskName, cfg.FailureRetries)
+		return
+	}
+
+	retryCmd := exec.Command(getPawBin(), "internal", "retry-task", sessionName, windowID) //nolint:gosec // G204: pawBin is from getPawBin()
+	retryCmd.Env = append(os.Environ(), "PAW_DIR="+pawDir)
+	if err := retryCmd.Start(); err != nil {
+		logging.Warn("checkAgentFailure: failed to start retry: %v", err)
+	}
+}
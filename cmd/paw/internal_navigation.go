@@ -1,10 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -31,10 +34,59 @@ var selectNextWindowCmd = &cobra.Command{
 	},
 }
 
+var zoomPaneCmd = &cobra.Command{
+	Use:    "zoom-pane [session] [agent|user]",
+	Short:  "Toggle zoom on the agent or user pane of the current task window",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName, which := args[0], args[1]
+		paneIndex := 0
+		switch which {
+		case "agent":
+		case "user":
+			paneIndex = 1
+		default:
+			return fmt.Errorf("unknown pane %q (use agent or user)", which)
+		}
+
+		tm := newTmuxClient(sessionName)
+		info, err := tm.DisplayMultiple("#{window_id}", "#{window_name}", "#{pane_index}", "#{window_zoomed_flag}", "#{window_panes}")
+		if err != nil || len(info) < 5 {
+			return nil
+		}
+		windowID, windowName, zoomed := info[0], info[1], info[3] == "1"
+		activePane, _ := strconv.Atoi(info[2])
+		panes, _ := strconv.Atoi(info[4])
+		if _, ok := constants.ExtractTaskName(windowName); !ok {
+			_ = tm.DisplayMessage("Not a task window", constants.DisplayMsgQuick)
+			return nil
+		}
+		if paneIndex >= panes {
+			_ = tm.DisplayMessage("No "+which+" pane in this window", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		target := windowID + "." + strconv.Itoa(paneIndex)
+		// Same pane again: unzoom
+		if zoomed && activePane == paneIndex {
+			return tm.Run("resize-pane", "-Z", "-t", target)
+		}
+		if err := tm.SelectPane(target); err != nil {
+			return nil
+		}
+		// Selecting another pane unzooms the window; zoom the selected one
+		if flag, _ := tm.Display("#{window_zoomed_flag}"); strings.TrimSpace(flag) != "1" {
+			return tm.Run("resize-pane", "-Z", "-t", target)
+		}
+		return nil
+	},
+}
+
 // selectAdjacentWindow selects the previous (direction=-1) or next (direction=1) window,
 // skipping hidden windows whose names start with hiddenWindowPrefix.
 func selectAdjacentWindow(sessionName string, direction int) error {
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 
 	windows, err := tm.ListWindows()
 	if err != nil {
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var proposePatchSummary string
+
+var proposePatchCmd = &cobra.Command{
+	Use:   "propose-patch",
+	Short: "Propose a patch (on stdin) for the user to review and apply (pair tasks)",
+	Long: `Propose a unified diff, read from stdin, for the user to review. The command
+waits for the decision: it exits 0 once the patch is applied, and 1 with the
+user's feedback if it is rejected.`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		applied, err := runProposePatch(os.Stdin)
+		if err == nil && !applied {
+			os.Exit(1)
+		}
+		return err
+	},
+}
+
+var patchReviewTUICmd = &cobra.Command{
+	Use:    "patch-review-tui [session] [agent-dir]",
+	Short:  "Run the patch review TUI (called from popup)",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		agentDir := args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "patch-review-tui", filepath.Base(agentDir))
+		defer cleanup()
+
+		t := task.New(filepath.Base(agentDir), agentDir)
+		p := service.LoadPatchProposal(t.GetPatchProposalPath())
+		if p == nil || p.Decision != service.ApprovalPending {
+			return nil
+		}
+
+		approved, feedback, decided, err := tui.RunPatchReview(p.TaskName, p.Summary, p.Patch)
+		if err != nil {
+			logging.Warn("RunPatchReview failed: %v", err)
+			return err
+		}
+		if !decided {
+			return nil
+		}
+
+		if err := service.DecidePatchProposal(t.GetPatchProposalPath(), p.ID, approved, feedback); err != nil {
+			_ = newTmuxClient(sessionName).DisplayMessage(err.Error(), constants.DisplayMsgStandard)
+			return nil
+		}
+		logging.Log("Patch review for %s: %d files (approved=%v)", p.TaskName, len(p.Files), approved)
+		return nil
+	},
+}
+
+// runProposePatch checks that the patch applies, asks the user to review it,
+// and applies it once approved. Returns false, after printing the reason for
+// the agent, if the patch does not apply or the user rejects it.
+func runProposePatch(stdin io.Reader) (bool, error) {
+	taskName := os.Getenv("TASK_NAME")
+	sessionName := os.Getenv("SESSION_NAME")
+
+	appCtx, err := getAppFromSession(sessionName)
+	if err != nil {
+		return false, err
+	}
+	t := task.New(taskName, filepath.Join(appCtx.AgentsDir, taskName))
+	_, cleanup := setupLoggerFromApp(appCtx, "propose-patch", taskName)
+	defer cleanup()
+
+	data, err := io.ReadAll(io.LimitReader(stdin, constants.PatchProposalMaxBytes+1))
+	if err != nil {
+		return false, fmt.Errorf("failed to read the patch: %w", err)
+	}
+	if len(data) > constants.PatchProposalMaxBytes {
+		return false, fmt.Errorf("patch is larger than %d bytes; split it into smaller patches", constants.PatchProposalMaxBytes)
+	}
+	patch := string(data)
+	if strings.TrimSpace(patch) == "" {
+		return false, errors.New("no patch on stdin")
+	}
+	if !strings.HasSuffix(patch, "\n") {
+		patch += "\n"
+	}
+
+	dir := os.Getenv("WORKTREE_DIR")
+	if dir == "" {
+		dir = appCtx.ProjectDir
+	}
+	if appCtx.IsGitRepo {
+		if files, err := unapprovedPairChanges(git.New(), t, dir); err != nil {
+			logging.Warn("Failed to check for unapproved changes: %v", err)
+		} else if len(files) > 0 {
+			fmt.Fprintf(os.Stderr, "⛔ paw: these files were changed outside an approved patch: %s\nPair tasks must not edit files (not even with shell commands). Ask the user to revert these changes, then propose them as a patch.\n", strings.Join(files, ", "))
+			return false, nil
+		}
+	}
+	if err := applyPatch(dir, patch, true); err != nil {
+		fmt.Fprintf(os.Stderr, "⛔ paw: the patch does not apply to %s:\n%v\nRegenerate it against the current files and propose it again.\n", dir, err)
+		return false, nil
+	}
+
+	p := service.NewPatchProposal(taskName, proposePatchSummary, patch, dir)
+	if err := service.SavePatchProposal(t.GetPatchProposalPath(), p); err != nil {
+		return false, err
+	}
+	logging.Log("Patch proposed: %d files (%s)", len(p.Files), p.Summary)
+	fmt.Fprintf(os.Stderr, "⏸️  paw: waiting for the user to review the patch (%s)\n", strings.Join(p.Files, ", "))
+
+	tm := newTmuxClient(sessionName)
+	_ = notify.SendWithUrgency("Patch proposed", fmt.Sprintf("🩹 %s: %s", taskName, patchProposalTitle(p)), notify.UrgencyCritical)
+	notify.PlaySound(notify.SoundNeedInput)
+	_ = tm.DisplayMessage(fmt.Sprintf("🩹 %s proposes a patch: %s (⌥A to review)", taskName, patchProposalTitle(p)), constants.DisplayMsgImportant)
+	go showPatchReview(tm, sessionName, t.AgentDir)
+
+	decided := service.WaitForPatchDecision(t.GetPatchProposalPath(), p.ID, constants.PatchReviewTimeout, constants.ApprovalPollInterval)
+	logging.Log("Patch %s: %s", decided.Decision, patchProposalTitle(p))
+
+	switch decided.Decision {
+	case service.ApprovalApproved:
+		if err := applyPatch(dir, patch, false); err != nil {
+			fmt.Fprintf(os.Stderr, "⛔ paw: the user approved the patch but it no longer applies:\n%v\nRegenerate it against the current files and propose it again.\n", err)
+			return false, nil
+		}
+		if appCtx.IsGitRepo {
+			recordPairTree(git.New(), t, dir)
+		}
+		if path, err := service.SaveAppliedPatch(t.GetArtifactsDir(), patch); err != nil {
+			logging.Warn("Failed to keep the applied patch: %v", err)
+		} else {
+			logging.Debug("Applied patch kept as %s", path)
+		}
+		fmt.Printf("✅ paw: the user applied the patch to %s\n", strings.Join(p.Files, ", "))
+		return true, nil
+	case service.ApprovalTimedOut:
+		fmt.Fprintln(os.Stderr, "⛔ paw: the patch was not reviewed in time and was not applied. Ask the user how to proceed.")
+	default:
+		msg := "⛔ paw: the user rejected the patch; nothing was applied."
+		if decided.Feedback != "" {
+			msg += " Feedback: " + decided.Feedback
+		} else {
+			msg += " Do not propose it again unchanged; ask the user what to change."
+		}
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	return false, nil
+}
+
+// recordPairTree records a pair task's files as approved: as they were when
+// the task started, or after the user applied a patch.
+func recordPairTree(gitClient git.Client, t *task.Task, dir string) {
+	tree, err := gitClient.WorktreeTree(dir)
+	if err != nil {
+		logging.Warn("Failed to record the files of pair task %s: %v", t.Name, err)
+		return
+	}
+	if err := fileutil.WriteFileAtomic(t.GetPairTreePath(), []byte(tree), 0644); err != nil {
+		logging.Warn("Failed to record the files of pair task %s: %v", t.Name, err)
+	}
+}
+
+// unapprovedPairChanges returns the files of a pair task that changed since
+// they were last approved, i.e. not through an approved patch. Tasks without
+// a record are compared with their HEAD.
+func unapprovedPairChanges(gitClient git.Client, t *task.Task, dir string) ([]string, error) {
+	approved := "HEAD"
+	if data, err := os.ReadFile(t.GetPairTreePath()); err == nil {
+		approved = strings.TrimSpace(string(data))
+	}
+	current, err := gitClient.WorktreeTree(dir)
+	if err != nil {
+		return nil, err
+	}
+	if current == approved {
+		return nil, nil
+	}
+	return gitClient.TreeChangedFiles(dir, approved, current)
+}
+
+// checkPairChanges keeps a pair task whose files were changed outside an
+// approved patch from being finished. Returns false, listing the files in the
+// end-task pane, if it has such changes.
+func checkPairChanges(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
+	files, err := unapprovedPairChanges(gitClient, targetTask, workDir)
+	if err != nil {
+		logging.Warn("checkPairChanges: %v", err)
+		return true
+	}
+	if len(files) == 0 {
+		return true
+	}
+	logging.Warn("checkPairChanges: task=%s unapproved changes in %d files", targetTask.Name, len(files))
+
+	fmt.Println()
+	fmt.Println("  ✗ Files were changed outside an approved patch; not finishing")
+	for i, f := range files {
+		if i == maxShownViolations {
+			fmt.Printf("    … and %d more\n", len(files)-maxShownViolations)
+			break
+		}
+		fmt.Printf("    %s\n", f)
+	}
+	fmt.Printf("  Revert them (git -C %s status) and have the agent propose them as a patch\n", workDir)
+	keepTaskWaiting(appCtx, targetTask, windowID, tm, "Unapproved changes")
+	return false
+}
+
+// errUnapprovedChanges is end-task's exit error for a pair task kept because
+// its files were changed outside an approved patch.
+func errUnapprovedChanges(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.PolicyViolation, "files of pair task %s were changed outside an approved patch; task kept", t.Name), t.Name)
+}
+
+// applyPatch applies a patch in dir with git apply, or only checks that it
+// applies.
+func applyPatch(dir, patch string, checkOnly bool) error {
+	f, err := os.CreateTemp("", "paw-patch-*.diff")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(patch); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	args := []string{"apply", "--whitespace=nowarn"}
+	if checkOnly {
+		args = append(args, "--check")
+	}
+	if output, err := git.CombinedOutput(dir, append(args, f.Name())...); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// patchProposalTitle describes a proposal by its summary, or its files.
+func patchProposalTitle(p *service.PatchProposal) string {
+	if p.Summary != "" {
+		return p.Summary
+	}
+	return strings.Join(p.Files, ", ")
+}
+
+// findPendingPatchProposal returns the agent directory of the oldest pending
+// patch proposal.
+func findPendingPatchProposal(appCtx *app.App) string {
+	entries, err := os.ReadDir(appCtx.AgentsDir)
+	if err != nil {
+		return ""
+	}
+
+	type pending struct {
+		agentDir    string
+		requestedAt string
+	}
+	var found []pending
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		agentDir := filepath.Join(appCtx.AgentsDir, entry.Name())
+		p := service.LoadPatchProposal(task.New(entry.Name(), agentDir).GetPatchProposalPath())
+		if p != nil && p.Decision == service.ApprovalPending {
+			found = append(found, pending{agentDir: agentDir, requestedAt: p.RequestedAt})
+		}
+	}
+	if len(found) == 0 {
+		return ""
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].requestedAt < found[j].requestedAt })
+	return found[0].agentDir
+}
+
+// showPatchReview opens the patch review for a task's pending proposal.
+func showPatchReview(tm tmux.Client, sessionName, agentDir string) {
+	popupCmd := shellJoin(getPawBin(), "internal", "patch-review-tui", sessionName, agentDir)
+	_ = tm.DisplayPopup(tmux.PopupOpts{
+		Width:  constants.PopupWidthPatchReview,
+		Height: constants.PopupHeightPatchReview,
+		Title:  " Patch Review ",
+		Close:  true,
+		Style:  "fg=terminal,bg=terminal",
+	}, popupCmd)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+var pasteTaskCmd = &cobra.Command{
+	Use:    "paste-task [session]",
+	Short:  "Open the task input pre-filled with the tmux paste buffer",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "paste-task", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		buffer, err := tm.RunWithOutput("show-buffer")
+		if err != nil || strings.TrimSpace(buffer) == "" {
+			_ = tm.DisplayMessage("The tmux paste buffer is empty (copy something first)", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		template := ""
+		if appCtx.Config != nil {
+			template = appCtx.Config.PasteTemplate
+		}
+		content := formatPasteTask(template, buffer)
+
+		selectionPath := filepath.Join(appCtx.PawDir, constants.PasteSelectionFile)
+		if err := os.WriteFile(selectionPath, []byte(content), 0644); err != nil { //nolint:gosec // G306: selection file needs to be readable
+			return err
+		}
+		logging.Log("Pasted %d bytes from the tmux buffer into the task input", len(buffer))
+
+		return openNewTaskWindow(tm, appCtx, sessionName)
+	},
+}
+
+// formatPasteTask wraps the paste buffer in the paste template. A template
+// without {buffer} gets the buffer appended below it.
+func formatPasteTask(template, buffer string) string {
+	if strings.TrimSpace(template) == "" {
+		template = constants.DefaultPasteTemplate
+	}
+	buffer = strings.TrimRight(buffer, "\n")
+	if !strings.Contains(template, "{buffer}") {
+		return strings.TrimRight(template, "\n") + "\n\n" + buffer
+	}
+	return strings.ReplaceAll(template, "{buffer}", buffer)
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestFormatPasteTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		buffer   string
+		want     string
+	}{
+		{"default", "", "panic: nil map\n", "Investigate and fix:\n\n```\npanic: nil map\n```"},
+		{"placeholder", "Why does this fail? {buffer}", "exit 1", "Why does this fail? exit 1"},
+		{"no placeholder", "Fix this:\n", "TypeError", "Fix this:\n\nTypeError"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPasteTask(tt.template, tt.buffer); got != tt.want {
+				t.Errorf("formatPasteTask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 
+	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
@@ -50,7 +55,7 @@ var toggleCmdPaletteCmd = &cobra.Command{
 		defer logging.Debug("<- toggleCmdPaletteCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Run command palette in top pane
 		paletteCmd := shellJoin(getPawBin(), "internal", "cmd-palette-tui", sessionName)
@@ -99,6 +104,21 @@ var cmdPaletteTUICmd = &cobra.Command{
 				Description: "Restore missing panes in current task window",
 				ID:          "restore-panes",
 			},
+			{
+				Name:        "Snooze Task (30m)",
+				Description: "Silence current task notifications for 30 minutes",
+				ID:          "snooze-30m",
+			},
+			{
+				Name:        "Snooze Task (2h)",
+				Description: "Silence current task notifications for 2 hours",
+				ID:          "snooze-2h",
+			},
+			{
+				Name:        "Unsnooze Task",
+				Description: "Resume current task notifications",
+				ID:          "snooze-off",
+			},
 		}
 
 		logging.Debug("cmdPaletteTUICmd: running command palette")
@@ -125,6 +145,10 @@ var cmdPaletteTUICmd = &cobra.Command{
 			logging.Debug("cmdPaletteTUICmd: executing restore-panes")
 			restoreCmd := exec.Command(pawBin, "internal", "restore-panes", sessionName) //nolint:gosec // G204: pawBin is from getPawBin()
 			return restoreCmd.Run()
+		case "snooze-30m", "snooze-2h", "snooze-off":
+			logging.Debug("cmdPaletteTUICmd: executing %s", selected.ID)
+			snoozeCmd := exec.Command(pawBin, "internal", "snooze-task", sessionName, strings.TrimPrefix(selected.ID, "snooze-")) //nolint:gosec // G204: pawBin is from getPawBin()
+			return snoozeCmd.Run()
 		}
 
 		return nil
@@ -157,8 +181,10 @@ var finishPickerTUICmd = &cobra.Command{
 		hasChanges := false
 		hasRemote := false
 		hasMainBranch := true // Assume main branch exists by default
+		isGitTask := appCtx.IsGitRepo
+		var summary *tui.FinishSummary
 		if appCtx.IsGitRepo {
-			tm := tmux.New(sessionName)
+			tm := newTmuxClient(sessionName)
 			mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 			mgr.SetTmuxClient(tm)
 
@@ -181,7 +207,11 @@ var finishPickerTUICmd = &cobra.Command{
 				}
 			}
 
-			if targetTask != nil {
+			if targetTask != nil && targetTask.IsResearch() {
+				// Research tasks have no branch: only offer done/drop
+				isGitTask = false
+				logging.Debug("finishPickerTUICmd: research task %s", targetTask.Name)
+			} else if targetTask != nil {
 				mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
 				workDir := mgr.GetWorkingDirectory(targetTask)
 				hasChanges = gitClient.HasChanges(workDir)
@@ -207,6 +237,7 @@ var finishPickerTUICmd = &cobra.Command{
 					}
 				}
 				logging.Debug("finishPickerTUICmd: hasCommits=%v hasChanges=%v hasRemote=%v hasMainBranch=%v (branch=%s, main=%s)", hasCommits, hasChanges, hasRemote, hasMainBranch, targetTask.Name, mainBranch)
+				summary = buildFinishSummary(appCtx, targetTask, workDir, mainBranch, hasMainBranch, hasRemote, gitClient)
 			} else {
 				logging.Warn("finishPickerTUICmd: could not find task for windowID=%s", windowID)
 			}
@@ -214,7 +245,7 @@ var finishPickerTUICmd = &cobra.Command{
 
 		// Run the finish picker
 		hasWork := hasCommits || hasChanges
-		action, err := tui.RunFinishPicker(appCtx.IsGitRepo, hasWork, hasRemote, hasMainBranch)
+		action, err := tui.RunFinishPicker(isGitTask, hasWork, hasRemote, hasMainBranch, summary)
 		if err != nil {
 			logging.Debug("finishPickerTUICmd: RunFinishPicker failed: %v", err)
 			return err
@@ -241,6 +272,8 @@ var finishPickerTUICmd = &cobra.Command{
 			endAction = constants.ActionPR
 		case tui.FinishActionKeep:
 			endAction = constants.ActionKeep
+		case tui.FinishActionPush:
+			endAction = constants.ActionPush
 		case tui.FinishActionDone:
 			endAction = constants.ActionDone
 		case tui.FinishActionDrop:
@@ -254,11 +287,75 @@ var finishPickerTUICmd = &cobra.Command{
 
 		// Call end-task-ui with the action flag
 		logging.Debug("finishPickerTUICmd: calling end-task-ui with action=%s", endAction)
-		endCmd := exec.Command(pawBin, "internal", "end-task-ui", sessionName, windowID, "--action", endAction) //nolint:gosec // G204: pawBin is from getPawBin()
+		endArgs := []string{"internal", "end-task-ui", sessionName, windowID, "--action", endAction}
+		if summary != nil {
+			endArgs = append(endArgs, "--keep-branch="+strconv.FormatBool(summary.KeepBranch))
+		}
+		endCmd := exec.Command(pawBin, endArgs...) //nolint:gosec // G204: pawBin is from getPawBin()
 		return endCmd.Run()
 	},
 }
 
+// buildFinishSummary collects what the finish picker shows about a git task:
+// its commits and uncommitted files, push target, and the checks and
+// verification that finishing it will run.
+func buildFinishSummary(appCtx *app.App, t *task.Task, workDir, mainBranch string, hasMainBranch, hasRemote bool, gitClient git.Client) *tui.FinishSummary {
+	summary := &tui.FinishSummary{Task: t.Name, Branch: t.Name, MainBranch: mainBranch}
+	if appCtx.Config != nil {
+		summary.KeepBranch = appCtx.Config.KeepBranch
+	}
+	if status, err := gitClient.Status(workDir); err == nil && strings.TrimSpace(status) != "" {
+		summary.Uncommitted = len(strings.Split(strings.TrimSpace(status), "\n"))
+	}
+	if hasMainBranch {
+		summary.Commits, _ = gitClient.CountCommits(workDir, mainBranch, "HEAD")
+		// Uncommitted changes are not part of the check; they are committed on finish
+		if conflicts, err := gitClient.MergeTree(workDir, mainBranch, t.Name); err == nil {
+			summary.Conflicts = conflicts
+		}
+	}
+	if hasRemote {
+		summary.Remote = "origin"
+	}
+
+	cfg := appCtx.Config
+	if cfg == nil {
+		return summary
+	}
+	if action := taskOnComplete(cfg, appCtx.PawDir, t.Name); action != constants.OnCompleteConfirm {
+		summary.Default = tui.FinishAction(action)
+	}
+	if len(cfg.DiffChecks) > 0 {
+		summary.Checks = append(summary.Checks, fmt.Sprintf("%d diff check(s)", len(cfg.DiffChecks)))
+	}
+	summary.Checks = append(summary.Checks, cfg.SecurityScanners...)
+	if cfg.VerifyBeforePush {
+		entries := cfg.Commands.VerifyEntries()
+		var failed []string
+		ran := 0
+		for _, e := range entries {
+			summary.Verify = append(summary.Verify, e.Name)
+			meta, err := service.LoadHookMetadata(t.GetHookMetaPath("verify-" + e.Name))
+			if err != nil {
+				continue
+			}
+			ran++
+			if meta.Status != "success" {
+				failed = append(failed, e.Name)
+			}
+		}
+		switch {
+		case len(failed) > 0:
+			summary.LastVerify = "failed (" + strings.Join(failed, ", ") + ")"
+		case ran == len(entries) && ran > 0:
+			summary.LastVerify = "passed"
+		case ran > 0:
+			summary.LastVerify = "partly run"
+		}
+	}
+	return summary
+}
+
 var taskNameInputTUICmd = &cobra.Command{
 	Use:    "task-name-input-tui [session]",
 	Short:  "Run task name input TUI (called from popup)",
@@ -325,7 +422,7 @@ var newShellWindowCmd = &cobra.Command{
 		logging.Debug("-> newShellWindowCmd(session=%s)", sessionName)
 		defer logging.Debug("<- newShellWindowCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Create a new window with shell in project directory
 		// Window name will be auto-set by tmux's automatic-rename to the running process
@@ -11,7 +11,6 @@ import (
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/embed"
 	"github.com/dongho-jung/paw/internal/logging"
-	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
 )
 
@@ -24,7 +23,7 @@ var togglePromptPickerCmd = &cobra.Command{
 		defer logging.Debug("<- togglePromptPickerCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Run prompt picker in top pane
 		pickerCmd := shellJoin(getPawBin(), "internal", "prompt-picker-tui", sessionName)
@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/dongho-jung/paw/internal/claude"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/task"
@@ -29,7 +29,7 @@ var popupShellCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		paneID, _ := tm.GetOption(shellPaneIDKey)
 		hasShellPane := paneID != "" && tm.HasPane(paneID)
@@ -205,7 +205,7 @@ var showCurrentTaskCmd = &cobra.Command{
 		logging.Debug("-> showCurrentTaskCmd(session=%s)", sessionName)
 		defer logging.Debug("<- showCurrentTaskCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get current window info
 		windowID, windowName, err := getCurrentWindowInfo(tm)
@@ -243,7 +243,11 @@ var showCurrentTaskCmd = &cobra.Command{
 		}
 
 		// Run task viewer in top pane (closes with q/Esc)
-		taskViewerCmd := shellJoin(getPawBin(), "internal", "task-viewer", taskFilePath)
+		viewerArgs := []string{getPawBin(), "internal", "task-viewer", taskFilePath}
+		if _, err := os.Stat(t.GetTimelinePath()); err == nil {
+			viewerArgs = append(viewerArgs, t.GetTimelinePath())
+		}
+		taskViewerCmd := shellJoin(viewerArgs...)
 
 		result, err := displayTopPane(tm, "task", taskViewerCmd, "")
 		if err != nil {
@@ -279,7 +283,7 @@ var restorePanesCmd = &cobra.Command{
 		logging.Debug("-> restorePanesCmd(session=%s)", sessionName)
 		defer logging.Debug("<- restorePanesCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get current window info
 		windowID, windowName, err := getCurrentWindowInfo(tm)
@@ -318,13 +322,17 @@ var restorePanesCmd = &cobra.Command{
 
 		logging.Debug("Current pane count: %s", paneCount)
 
-		// Task window should have 2 panes: agent (0) and user (1)
+		// Task window has the agent pane (0) plus the configured user and extra panes
 		// Get working directory (t and mgr already set above)
 		workDir := mgr.GetWorkingDirectory(t)
+		layout := taskLayout(appCtx.Config)
+		expectedCount := strconv.Itoa(layout.PaneCount())
+		taskFilePath := t.GetTaskFilePath()
+		userPaneCmd := shellCommand(fmt.Sprintf("cat %s; echo; exec %s", shellQuote(taskFilePath), shellQuote(getShell())))
 
 		// Check which pane is missing and restore
 		switch paneCount {
-		case "2":
+		case expectedCount:
 			_ = tm.DisplayMessage("All panes are present", constants.DisplayMsgStandard)
 			return nil
 		case "0":
@@ -342,14 +350,10 @@ var restorePanesCmd = &cobra.Command{
 				return fmt.Errorf("failed to respawn agent pane: %w", err)
 			}
 
-			// Create user pane
-			taskFilePath := t.GetTaskFilePath()
-			userPaneCmd := shellCommand(fmt.Sprintf("cat %s; echo; exec %s", shellQuote(taskFilePath), shellQuote(getShell())))
-			if err := tm.SplitWindow(windowID, true, workDir, userPaneCmd); err != nil {
-				logging.Warn("Failed to create user pane: %v", err)
-			}
+			// Create user and extra panes
+			splitTaskPanes(tm, windowID, workDir, layout, userPaneCmd)
 
-			_ = tm.DisplayMessage("Restored both panes", constants.DisplayMsgStandard)
+			_ = tm.DisplayMessage("Restored all panes", constants.DisplayMsgStandard)
 		case "1":
 			// One pane exists - need to determine which one is missing
 			// Check if the existing pane is running claude (agent) or shell (user)
@@ -362,13 +366,9 @@ var restorePanesCmd = &cobra.Command{
 			logging.Debug("Existing pane command: %s", paneCmd)
 
 			if paneCmd == "claude" || strings.Contains(paneCmd, constants.StartAgentScriptName) {
-				// Agent pane exists, user pane is missing
+				// Agent pane exists, user (and extra) panes are missing
 				logging.Log("User pane missing, creating it")
-				taskFilePath := t.GetTaskFilePath()
-				userPaneCmd := shellCommand(fmt.Sprintf("cat %s; echo; exec %s", shellQuote(taskFilePath), shellQuote(getShell())))
-				if err := tm.SplitWindow(windowID, true, workDir, userPaneCmd); err != nil {
-					return fmt.Errorf("failed to create user pane: %w", err)
-				}
+				splitTaskPanes(tm, windowID, workDir, layout, userPaneCmd)
 				_ = tm.DisplayMessage("Restored user pane", constants.DisplayMsgStandard)
 			} else {
 				// User pane exists (or unknown), agent pane is missing
@@ -384,7 +384,7 @@ var restorePanesCmd = &cobra.Command{
 				// Split before the current pane to create agent pane at position 0
 				_, err := tm.SplitWindowPane(tmux.SplitOpts{
 					Target:     windowID + ".0",
-					Horizontal: true,
+					Horizontal: layout.Horizontal(),
 					Before:     true,
 					StartDir:   workDir,
 					Command:    shellQuote(startAgentScript),
@@ -431,7 +431,7 @@ func checkAndRecoverStdinInjection(tm tmux.Client, t *task.Task, windowID string
 	}
 
 	// Check if Claude is running in the agent pane
-	claudeClient := claude.New()
+	claudeClient := newClaudeClient()
 	if !claudeClient.IsClaudeRunning(tm, agentPane) {
 		logging.Debug("checkAndRecoverStdinInjection: Claude not running, skipping")
 		return nil
@@ -222,7 +222,7 @@ var toggleLogCmd = &cobra.Command{
 		defer logging.Debug("<- toggleLogCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		appCtx, err := getAppFromSession(sessionName)
 		if err != nil {
@@ -267,7 +267,7 @@ var toggleHelpCmd = &cobra.Command{
 		defer logging.Debug("<- toggleHelpCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Run help viewer in top pane (closes with q/Esc/Ctrl+/)
 		helpCmd := shellJoin(getPawBin(), "internal", "help-viewer")
@@ -301,9 +301,9 @@ var helpViewerCmd = &cobra.Command{
 }
 
 var taskViewerCmd = &cobra.Command{
-	Use:    "task-viewer [filepath]",
+	Use:    "task-viewer [filepath] [timeline-path]",
 	Short:  "Run the task content viewer",
-	Args:   cobra.ExactArgs(1),
+	Args:   cobra.RangeArgs(1, 2),
 	Hidden: true,
 	RunE: func(_ *cobra.Command, args []string) error {
 		taskFilePath := args[0]
@@ -314,7 +314,15 @@ var taskViewerCmd = &cobra.Command{
 			return fmt.Errorf("failed to read task file: %w", err)
 		}
 
-		return tui.RunTaskViewer(string(content))
+		// Timeline is optional (only exists after the first stop hook)
+		var timeline *service.Timeline
+		if len(args) > 1 {
+			if tl, err := service.LoadTimeline(args[1]); err == nil {
+				timeline = tl
+			}
+		}
+
+		return tui.RunTaskViewer(string(content), timeline)
 	},
 }
 
@@ -334,7 +342,7 @@ var gitViewerCmd = &cobra.Command{
 // It handles common setup: session validation, git repo check, pane path resolution,
 // and main branch detection.
 func runGitViewerTopPane(sessionName, viewerName, internalCmd string) error {
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 
 	appCtx, err := getAppFromSession(sessionName)
 	if err != nil {
@@ -415,7 +423,7 @@ var toggleHistoryCmd = &cobra.Command{
 		defer logging.Debug("<- toggleHistoryCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		appCtx, err := getAppFromSession(sessionName)
 		if err != nil {
@@ -462,7 +470,7 @@ var historyPickerCmd = &cobra.Command{
 		defer logging.Debug("<- historyPickerCmd")
 
 		// Initialize input history service
-		inputHistorySvc := service.NewInputHistoryService(appCtx.PawDir)
+		inputHistorySvc := inputHistoryService(appCtx, newTmuxClient(sessionName))
 
 		// Load history
 		history, err := inputHistorySvc.GetAllContents()
@@ -510,7 +518,7 @@ var toggleTemplateCmd = &cobra.Command{
 		defer logging.Debug("<- toggleTemplateCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		appCtx, err := getAppFromSession(sessionName)
 		if err != nil {
@@ -605,7 +613,7 @@ var toggleProjectPickerCmd = &cobra.Command{
 		defer logging.Debug("<- toggleProjectPickerCmd")
 
 		sessionName := args[0]
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		appCtx, err := getAppFromSession(sessionName)
 		if err != nil {
@@ -744,12 +752,11 @@ var projectPickerWrapperCmd = &cobra.Command{
 		if action == tui.ProjectPickerSelect && selected != nil {
 			logging.Debug("projectPickerWrapperCmd: switching to session %s", selected.Name)
 
-			tm := tmux.New(currentSession)
+			tm := newTmuxClient(currentSession)
 
 			// Use detach-client -E to replace the current client with a new attachment
 			// to the target session. This works across different tmux sockets.
-			targetSocket := constants.TmuxSocketPrefix + selected.Name
-			switchCmd := fmt.Sprintf("tmux -L %s attach-session -t %s", shellQuote(targetSocket), shellQuote(selected.Name))
+			switchCmd := shellJoin(tmux.AttachArgs(selected.Name)...)
 			return tm.Run("detach-client", "-E", switchCmd)
 		}
 
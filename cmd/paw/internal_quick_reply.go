@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var quickReplyCmd = &cobra.Command{
+	Use:    "quick-reply [session]",
+	Short:  "Reply to a waiting task without switching windows",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "quick-reply", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		if len(waitingTasks(tm, sessionName, appCtx.PawDir)) == 0 {
+			_ = tm.DisplayMessage("No tasks are waiting for input", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		popupCmd := shellJoin(getPawBin(), "internal", "quick-reply-tui", sessionName)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:  constants.PopupWidthQuickReply,
+			Height: constants.PopupHeightQuickReply,
+			Title:  " Quick Reply ",
+			Close:  true,
+			Style:  "fg=terminal,bg=terminal",
+		}, popupCmd)
+	},
+}
+
+var quickReplyTUICmd = &cobra.Command{
+	Use:    "quick-reply-tui [session]",
+	Short:  "Run quick reply TUI (called from popup)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "quick-reply-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> quickReplyTUICmd(session=%s)", sessionName)
+		defer logging.Debug("<- quickReplyTUICmd")
+
+		tm := newTmuxClient(sessionName)
+		waiting := waitingTasks(tm, sessionName, appCtx.PawDir)
+		if len(waiting) == 0 {
+			return nil
+		}
+
+		items := make([]tui.QuickReplyTask, len(waiting))
+		for i, t := range waiting {
+			items[i] = tui.QuickReplyTask{Name: t.Name, Question: t.Question}
+		}
+
+		index, reply, err := tui.RunQuickReply(items)
+		if err != nil {
+			logging.Warn("RunQuickReply failed: %v", err)
+			return err
+		}
+		if index < 0 || reply == "" {
+			return nil
+		}
+
+		target := waiting[index]
+		if err := newClaudeClient().SendInputWithRetry(tm, target.WindowID+".0", reply, 3); err != nil {
+			logging.Warn("quickReplyTUICmd: failed to send reply to %s: %v", target.Name, err)
+			_ = tm.DisplayMessage(fmt.Sprintf("Failed to reply to %s", target.Name), constants.DisplayMsgStandard)
+			return nil
+		}
+
+		logging.Log("Quick reply to %s: %s", target.Name, reply)
+		_ = tm.DisplayMessage(fmt.Sprintf("💬 Replied to %s", target.Name), constants.DisplayMsgQuick)
+		return nil
+	},
+}
+
+// waitingTasks returns the session's tasks in the Waiting column.
+func waitingTasks(tm tmux.Client, sessionName, pawDir string) []*service.DiscoveredTask {
+	var waiting []*service.DiscoveredTask
+	for _, t := range service.NewTaskDiscoveryService().DiscoverSession(tm, sessionName, pawDir) {
+		if t.Status == service.DiscoveredWaiting {
+			waiting = append(waiting, t)
+		}
+	}
+	return waiting
+}
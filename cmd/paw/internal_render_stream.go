@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// streamEnvVar is set for the headless stream-json run so the stop hook
+// leaves status detection to render-stream.
+const streamEnvVar = "PAW_STREAM_JSON"
+
+// streamMaxLineSize bounds a single stream-json event (large tool results).
+const streamMaxLineSize = 16 * 1024 * 1024
+
+var renderStreamCmd = &cobra.Command{
+	Use:    "render-stream",
+	Short:  "Render claude stream-json events and track the task's status and cost",
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		sessionName := os.Getenv("SESSION_NAME")
+		windowID := os.Getenv("WINDOW_ID")
+		taskName := os.Getenv("TASK_NAME")
+		pawDir := os.Getenv("PAW_DIR")
+
+		if pawDir != "" {
+			_, cleanup := setupLogger(filepath.Join(pawDir, constants.LogFileName), os.Getenv("PAW_DEBUG") == "1", "render-stream", taskName)
+			defer cleanup()
+		}
+		logging.Debug("-> renderStreamCmd(session=%s, windowID=%s, task=%s)", sessionName, windowID, taskName)
+		defer logging.Debug("<- renderStreamCmd")
+
+		statePath := ""
+		if pawDir != "" && taskName != "" {
+			statePath = filepath.Join(pawDir, constants.AgentsDirName, taskName, constants.StreamStateFileName)
+		}
+
+		state, transcript := renderStream(os.Stdin, os.Stdout, statePath)
+		if !state.Finished() {
+			// Claude exited without a result event (crash, Ctrl+C)
+			logging.Warn("renderStreamCmd: task=%s stream ended without a result", taskName)
+			state.Status = service.StreamStatusError
+			saveStreamState(statePath, state)
+		}
+
+		if err := validateRequiredParams(map[string]string{
+			"SESSION_NAME": sessionName,
+			"WINDOW_ID":    windowID,
+			"TASK_NAME":    taskName,
+			"PAW_DIR":      pawDir,
+		}); err != nil {
+			logging.Debug("renderStreamCmd: skipping status update: %v", err)
+			return nil
+		}
+		finishStreamRun(sessionName, windowID, pawDir, taskName, state, transcript)
+		return nil
+	},
+}
+
+// renderStream prints each event read from r in Claude Code's transcript style
+// and keeps the stream state file up to date. It returns the final state and
+// the rendered transcript.
+func renderStream(r io.Reader, w io.Writer, statePath string) (*service.StreamState, string) {
+	state := &service.StreamState{}
+	var transcript strings.Builder
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), streamMaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if strings.TrimSpace(string(line)) == "" {
+			continue
+		}
+		ev, err := service.ParseStreamEvent(line)
+		if err != nil {
+			// Not an event (e.g. a startup error): show it as is
+			_, _ = fmt.Fprintln(w, string(line))
+			continue
+		}
+		for _, out := range state.Apply(ev, time.Now()) {
+			_, _ = fmt.Fprintln(w, out)
+			transcript.WriteString(out)
+			transcript.WriteString("\n")
+		}
+		saveStreamState(statePath, state)
+	}
+	if err := scanner.Err(); err != nil {
+		logging.Warn("renderStream: %v", err)
+	}
+	return state, transcript.String()
+}
+
+func saveStreamState(path string, state *service.StreamState) {
+	if path == "" {
+		return
+	}
+	if err := service.SaveStreamState(path, state); err != nil {
+		logging.Debug("saveStreamState: %v", err)
+	}
+}
+
+// finishStreamRun records the run's outcome: the timeline, the window status
+// (done, or waiting when the run failed), and then the same failure handling
+// and on_complete action the stop hook applies to interactive runs.
+func finishStreamRun(sessionName, windowID, pawDir, taskName string, state *service.StreamState, transcript string) {
+	timeline := service.ParseTimeline(transcript)
+	timelinePath := filepath.Join(pawDir, constants.AgentsDirName, taskName, constants.TimelineFileName)
+	if err := service.SaveTimeline(timelinePath, timeline); err != nil {
+		logging.Debug("finishStreamRun: failed to save timeline: %v", err)
+	}
+
+	status := task.StatusDone
+	if state.Status == service.StreamStatusError {
+		status = task.StatusWaiting
+	}
+
+	tm := newTmuxClient(sessionName)
+	if err := renameWindowWithStatus(tm, windowID, windowNameForStatus(taskName, status), pawDir, taskName, "stream-json", status); err != nil {
+		logging.Warn("finishStreamRun: failed to rename window: %v", err)
+		return
+	}
+	logging.Info("finishStreamRun: task=%s status=%s cost=%s turns=%d tool_calls=%d",
+		taskName, status, service.FormatCost(state.CostUSD), state.Turns, state.ToolCalls)
+
+	checkAgentFailure(sessionName, windowID, pawDir, taskName, timeline, state.Status == service.StreamStatusError)
+	if status == task.StatusDone {
+		autoCompleteTask(sessionName, windowID, pawDir, taskName, timeline)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestRenderStream(t *testing.T) {
+	input := strings.Join([]string{
+		`{"type":"system","subtype":"init","session_id":"s-1","model":"claude-opus-4"}`,
+		`Error: something printed outside the stream`,
+		`{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test ./..."}}]}}`,
+		`{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]}}`,
+		`{"type":"result","subtype":"success","total_cost_usd":0.12,"num_turns":2}`,
+	}, "\n")
+	statePath := filepath.Join(t.TempDir(), ".stream.json")
+
+	var out bytes.Buffer
+	state, transcript := renderStream(strings.NewReader(input), &out, statePath)
+
+	if state.Status != service.StreamStatusDone {
+		t.Errorf("Status = %q, want %q", state.Status, service.StreamStatusDone)
+	}
+	for _, want := range []string{"⏺ Bash(go test ./...)", "⎿  ok", "Error: something printed outside the stream", "$0.12"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(transcript, "outside the stream") {
+		t.Errorf("transcript should only hold rendered events:\n%s", transcript)
+	}
+
+	saved, err := service.LoadStreamState(statePath)
+	if err != nil {
+		t.Fatalf("LoadStreamState() error = %v", err)
+	}
+	if saved.CostUSD != 0.12 || saved.ToolCalls != 1 {
+		t.Errorf("saved state = %+v, want cost 0.12 and 1 tool call", saved)
+	}
+}
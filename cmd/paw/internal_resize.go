@@ -7,7 +7,6 @@ import (
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 var resizeFilePickerReason string
@@ -32,7 +31,7 @@ var resizeFilePickerCmd = &cobra.Command{
 			reason = "manual"
 		}
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		windows, err := tm.ListWindows()
 		if err != nil {
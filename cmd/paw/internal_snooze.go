@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var snoozeTaskCmd = &cobra.Command{
+	Use:   "snooze-task [session] [duration|off]",
+	Short: "Snooze notifications for the current task",
+	Long: `Suppress the current task's notifications and move it to the bottom of
+its Kanban column for the given duration (e.g. 30m, 2h). When the snooze
+ends, a still-waiting task alerts again.
+
+Without a duration, toggles a snooze of the default length. "off" ends a snooze.`,
+	Args:   cobra.RangeArgs(1, 2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			// Log and return nil to avoid run-shell output error in pane
+			logging.Warn("snoozeTaskCmd: getAppFromSession failed: %v", err)
+			return nil
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "snooze-task", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+
+		_, windowName, err := getCurrentWindowInfo(tm)
+		if err != nil {
+			logging.Warn("snoozeTaskCmd: failed to get window info: %v", err)
+			return nil
+		}
+		taskName, isTaskWindow := constants.ExtractTaskName(windowName)
+		if !isTaskWindow {
+			_ = tm.DisplayMessage("Not a task window", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		t, err := mgr.FindTaskByTruncatedName(taskName)
+		if err != nil {
+			_ = tm.DisplayMessage("Task not found: "+taskName, constants.DisplayMsgStandard)
+			return nil
+		}
+
+		arg := ""
+		if len(args) > 1 {
+			arg = args[1]
+		}
+		now := time.Now()
+		duration, err := parseSnoozeArg(arg, t.IsSnoozed(now))
+		if err != nil {
+			_ = tm.DisplayMessage(err.Error(), constants.DisplayMsgStandard)
+			return nil
+		}
+
+		if duration == 0 {
+			if err := t.ClearSnooze(); err != nil {
+				logging.Warn("snoozeTaskCmd: failed to clear snooze: %v", err)
+				return nil
+			}
+			logging.Info("snoozeTaskCmd: task=%s unsnoozed", t.Name)
+			_ = tm.DisplayMessage("🔔 Snooze ended: "+t.Name, constants.DisplayMsgQuick)
+			return nil
+		}
+
+		until := now.Add(duration)
+		if err := t.Snooze(until); err != nil {
+			logging.Warn("snoozeTaskCmd: failed to save snooze: %v", err)
+			return nil
+		}
+		logging.Info("snoozeTaskCmd: task=%s snoozed until %s", t.Name, until.Format(time.RFC3339))
+		_ = tm.DisplayMessage(fmt.Sprintf("%s Snoozed %s until %s", constants.EmojiSnoozed, t.Name, until.Format("15:04")), constants.DisplayMsgStandard)
+		return nil
+	},
+}
+
+// parseSnoozeArg returns how long to snooze for; 0 means end the snooze.
+// An empty argument toggles: it ends an active snooze or starts a default one.
+func parseSnoozeArg(arg string, snoozed bool) (time.Duration, error) {
+	switch arg {
+	case "":
+		if snoozed {
+			return 0, nil
+		}
+		return constants.DefaultSnoozeDuration, nil
+	case "off":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid snooze duration %q (use e.g. 30m, 2h, or off)", arg)
+	}
+	return d, nil
+}
+
+// snoozeCheck reports whether a task's notifications are snoozed now. When a
+// snooze has ended, it removes the snooze and reports expired so the caller
+// can alert again.
+func snoozeCheck(t *task.Task, now time.Time) (snoozed, expired bool) {
+	until := t.SnoozedUntil()
+	if until.IsZero() {
+		return false, false
+	}
+	if now.Before(until) {
+		return true, false
+	}
+	if err := t.ClearSnooze(); err != nil {
+		logging.Warn("snoozeCheck: failed to clear snooze for task=%s: %v", t.Name, err)
+	}
+	return false, true
+}
+
+// notifySnoozeEnded re-alerts for a task that is done and still awaiting review.
+func notifySnoozeEnded(taskName string) {
+	notify.PlaySound(notify.SoundTaskCompleted)
+	_ = notify.Send("Snooze ended", fmt.Sprintf("🔔 %s is still waiting for review", taskName))
+}
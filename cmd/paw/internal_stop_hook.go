@@ -12,10 +12,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 const doneMarker = "PAW_DONE"
@@ -66,6 +67,12 @@ var stopHookCmd = &cobra.Command{
 			return nil
 		}
 
+		// Headless stream-json runs report their status through render-stream
+		if os.Getenv(streamEnvVar) != "" {
+			stopHookTrace("Skipping: %s is set (render-stream reports the status)", streamEnvVar)
+			return nil
+		}
+
 		sessionName := os.Getenv("SESSION_NAME")
 		windowID := os.Getenv("WINDOW_ID")
 		taskName := os.Getenv("TASK_NAME")
@@ -91,7 +98,7 @@ var stopHookCmd = &cobra.Command{
 		logging.Debug("-> stopHookCmd(session=%s, windowID=%s, task=%s)", sessionName, windowID, taskName)
 		defer logging.Debug("<- stopHookCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		paneID := windowID + ".0"
 		if !tm.HasPane(paneID) {
 			logging.Debug("stopHookCmd: pane %s not found, skipping", paneID)
@@ -102,7 +109,15 @@ var stopHookCmd = &cobra.Command{
 		windowName, err := getWindowName(tm, windowID)
 		isFinal := err == nil && isFinalWindow(windowName)
 
-		paneContent, err := tm.CapturePane(paneID, constants.PaneCaptureLines)
+		// Status markers are matched on plain text, so only the depth applies
+		captureLines := constants.PaneCaptureLines
+		if pawDir != "" {
+			if cfg, err := config.Load(pawDir); err == nil {
+				cfg.Normalize()
+				captureLines = paneCaptureOpts(cfg, filepath.Join(pawDir, constants.AgentsDirName, taskName)).Lines
+			}
+		}
+		paneContent, err := tm.CapturePane(paneID, captureLines)
 		if err != nil {
 			logging.Warn("stopHookCmd: failed to capture pane: %v", err)
 			stopHookTrace("Error: failed to capture pane: %v", err)
@@ -116,6 +131,15 @@ var stopHookCmd = &cobra.Command{
 			return nil
 		}
 
+		// Refresh the structured timeline from the full capture (before tailing)
+		timeline := service.ParseTimeline(paneContent)
+		if pawDir != "" {
+			timelinePath := filepath.Join(pawDir, constants.AgentsDirName, taskName, constants.TimelineFileName)
+			if err := service.SaveTimeline(timelinePath, timeline); err != nil {
+				logging.Debug("stopHookCmd: failed to save timeline: %v", err)
+			}
+		}
+
 		// If window is already final and done marker is still valid in last segment, skip
 		// UNLESS there's a waiting marker (which indicates new work started)
 		// This allows re-classification when new work is requested after PAW_DONE
@@ -146,6 +170,13 @@ var stopHookCmd = &cobra.Command{
 
 				logging.Info("stopHookCmd: task=%s status_changed_to=%s window=%s (via signal file)", taskName, signalStatus, newName)
 				stopHookTrace("STATUS UPDATE SUCCESS (signal) task=%s status=%s", taskName, signalStatus)
+				if signalStatus != task.StatusWorking {
+					// The agent reported its status itself, so it did not stop on a failure
+					checkAgentFailure(sessionName, windowID, pawDir, taskName, timeline, false)
+				}
+				if signalStatus == task.StatusDone && !isFinal {
+					autoCompleteTask(sessionName, windowID, pawDir, taskName, timeline)
+				}
 				return nil
 			}
 		}
@@ -158,6 +189,7 @@ var stopHookCmd = &cobra.Command{
 		// - Agent outputs PAW_WAITING
 		// - Old PAW_DONE is still in terminal, but PAW_WAITING should win
 		var status task.Status
+		unmarked := false // No marker or question: the agent just stopped
 		if hasWaitingMarker(paneContent) {
 			// Detect PAW_WAITING marker directly in stop hook
 			// This is more reliable than watch-wait's distance-limited detection
@@ -179,6 +211,7 @@ var stopHookCmd = &cobra.Command{
 			logging.Info("stopHookCmd: task=%s no_marker_found, starting_classification", taskName)
 			stopHookTrace("Calling Claude for classification task=%s content_len=%d (will try haiku→sonnet→opus→opus+thinking)", taskName, len(paneContent))
 
+			unmarked = true
 			var err error
 			status, err = classifyStopStatus(taskName, paneContent)
 			if err != nil {
@@ -202,10 +235,72 @@ var stopHookCmd = &cobra.Command{
 		// L2 logging for all status changes
 		logging.Info("stopHookCmd: task=%s status_changed_to=%s window=%s", taskName, status, newName)
 		stopHookTrace("STATUS UPDATE SUCCESS task=%s status=%s newName=%s", taskName, status, newName)
+
+		// Agent stopped: check whether it ended on a failure (and maybe auto-retry).
+		// Only an agent that stopped without finishing or asking anything is retried.
+		if pawDir != "" && status != task.StatusWorking {
+			checkAgentFailure(sessionName, windowID, pawDir, taskName, timeline, unmarked && status == task.StatusWaiting)
+		}
+		if status == task.StatusDone && !isFinal {
+			autoCompleteTask(sessionName, windowID, pawDir, taskName, timeline)
+		}
 		return nil
 	},
 }
 
+// autoCompleteTask finishes a task that just became done with its on_complete
+// action (per-task override, else the project's). Tasks that ended on a
+// failure, or whose diff is too large to merge unreviewed, are left for the user.
+func autoCompleteTask(sessionName, windowID, pawDir, taskName string, timeline *service.Timeline) {
+	if pawDir == "" {
+		return
+	}
+	cfg, err := config.Load(pawDir)
+	if err != nil {
+		return
+	}
+	cfg.Normalize()
+	policy, err := config.LoadPolicy()
+	if err != nil {
+		logging.Warn("autoCompleteTask: %v; waiting for the user", err)
+		return
+	}
+	policy.Apply(cfg)
+	action := policy.OnComplete(cfg, taskOnComplete(cfg, pawDir, taskName))
+	if action == constants.OnCompleteConfirm {
+		return
+	}
+	if kind, _ := service.ClassifyFailure(timeline); kind != service.FailureNone {
+		logging.Info("autoCompleteTask: task=%s ended on %s, waiting for the user", taskName, kind)
+		return
+	}
+	if holdLargeDiff(cfg, pawDir, taskName, action) {
+		return
+	}
+
+	logging.Info("autoCompleteTask: task=%s action=%s", taskName, action)
+	if err := startEndTaskUI(sessionName, windowID, pawDir, action); err != nil {
+		logging.Warn("autoCompleteTask: failed to start end-task: %v", err)
+	}
+}
+
+// taskOnComplete returns a task's on_complete action: its own override
+// (from on_complete_branches), else the project's.
+func taskOnComplete(cfg *config.Config, pawDir, taskName string) string {
+	if opts, err := config.LoadTaskOptions(filepath.Join(pawDir, constants.AgentsDirName, taskName)); err == nil && opts.OnComplete != "" {
+		return opts.OnComplete
+	}
+	return cfg.OnComplete
+}
+
+// startEndTaskUI finishes a task in the background with the given action,
+// on behalf of on_complete or a confirm timeout.
+func startEndTaskUI(sessionName, windowID, pawDir, action string) error {
+	endCmd := exec.Command(getPawBin(), "internal", "end-task-ui", "--auto", "--action", action, sessionName, windowID) //nolint:gosec // G204: pawBin is from getPawBin()
+	endCmd.Env = append(os.Environ(), "PAW_DIR="+pawDir)
+	return endCmd.Start()
+}
+
 func classifyStopStatus(taskName, paneContent string) (task.Status, error) {
 	// NOTE: WAITING is intentionally excluded from stop-hook classification.
 	// The watch-wait watcher handles WAITING detection using specific markers
@@ -2,8 +2,7 @@ package main
 
 import (
 	"fmt"
-	"os/exec"
-	"strings"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -107,7 +106,7 @@ var syncWithMainCmd = &cobra.Command{
 
 		// Check if there are new commits on main
 		remoteMain := "origin/" + mainBranch
-		behindCount, err := getBehindCount(workDir, currentBranch, remoteMain)
+		behindCount, err := getBehindCount(gitClient, workDir, currentBranch, remoteMain)
 		if err != nil {
 			logging.Warn("Failed to check commit count: %v", err)
 			behindCount = "unknown"
@@ -148,6 +147,11 @@ var syncWithMainCmd = &cobra.Command{
 		rebaseTimer.StopWithResult(true, "rebased onto origin/"+mainBranch)
 		rebaseSpinner.Stop(true, "")
 
+		// The rebased files are the pair task's new approved baseline
+		if targetTask.IsPair() {
+			recordPairTree(gitClient, targetTask, workDir)
+		}
+
 		logging.Log("Successfully synced %s with %s", targetTask.Name, mainBranch)
 		fmt.Println()
 		fmt.Printf("  ✓ Successfully synced with %s!\n", mainBranch)
@@ -176,7 +180,7 @@ var syncWithMainUICmd = &cobra.Command{
 		logging.Debug("-> syncWithMainUICmd(session=%s, windowID=%s)", sessionName, windowID)
 		defer logging.Debug("<- syncWithMainUICmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 
 		// Get the paw binary path
 		pawBin := getPawBin()
@@ -209,7 +213,7 @@ var syncWithMainUICmd = &cobra.Command{
 }
 
 // getBehindCount returns how many commits the current branch is behind origin/main
-func getBehindCount(workDir, currentBranch, remoteMain string) (string, error) {
+func getBehindCount(gitClient git.Client, workDir, currentBranch, remoteMain string) (string, error) {
 	// Security: Validate ref names to prevent command injection
 	if !git.IsValidGitRef(currentBranch) {
 		return "0", fmt.Errorf("invalid current branch name: %q", currentBranch)
@@ -218,12 +222,9 @@ func getBehindCount(workDir, currentBranch, remoteMain string) (string, error) {
 		return "0", fmt.Errorf("invalid remote main name: %q", remoteMain)
 	}
 
-	rangeExpr := currentBranch + ".." + remoteMain
-	cmd := exec.Command("git", "rev-list", "--count", rangeExpr) //nolint:gosec // G204: branch names validated above
-	cmd.Dir = workDir
-	output, err := cmd.Output()
+	count, err := gitClient.CountCommits(workDir, currentBranch, remoteMain)
 	if err != nil {
 		return "0", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return strconv.Itoa(count), nil
 }
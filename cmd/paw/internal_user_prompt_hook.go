@@ -8,7 +8,6 @@ import (
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 // updateWindowStatus is a helper that handles common hook setup and window status updates.
@@ -36,7 +35,7 @@ func updateWindowStatus(hookName string, emoji string) error {
 	logging.Debug("-> %s(session=%s, windowID=%s, task=%s)", hookName, sessionName, windowID, taskName)
 	defer logging.Debug("<- %s", hookName)
 
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 	paneID := windowID + ".0"
 	if !tm.HasPane(paneID) {
 		logging.Debug("%s: pane %s not found, skipping", hookName, paneID)
@@ -4,16 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/clipboard"
 	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
@@ -29,6 +34,22 @@ var (
 	cachedPawBinOnce sync.Once
 )
 
+// Client constructors; end-to-end tests replace them with tmuxtest and claudetest fakes.
+var (
+	newTmuxClient   = tmux.New
+	newClaudeClient = claude.New
+)
+
+// useProjectReadinessRules makes Claude clients detect readiness with the
+// project's .paw/readiness.json, falling back to the embedded rules.
+func useProjectReadinessRules(appCtx *app.App) {
+	rules, err := claude.LoadReadinessRules(appCtx.PawDir)
+	if err != nil {
+		logging.Warn("Invalid readiness rules, using the defaults: %v", err)
+	}
+	claude.SetReadinessRules(rules)
+}
+
 // getPawBin returns the path to the paw binary, caching the result.
 // Falls back to "paw" if os.Executable() fails.
 func getPawBin() string {
@@ -79,7 +100,7 @@ var renameWindowCmd = &cobra.Command{
 			sessionName = "paw" // fallback
 		}
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		pawDir := os.Getenv("PAW_DIR")
 		taskName := os.Getenv("TASK_NAME")
 
@@ -113,6 +134,7 @@ type sessionContext struct {
 	projectDir  string
 	pawDir      string
 	displayName string
+	proxyEnv    map[string]string // Proxy and CA variables synced from the shell that started PAW
 }
 
 func readSessionEnv(tm tmux.Client, sessionName string) map[string]string {
@@ -143,7 +165,7 @@ func loadSessionContext(sessionName string) sessionContext {
 		return sessionContext{}
 	}
 
-	tm := tmux.New(sessionName)
+	tm := newTmuxClient(sessionName)
 	if !tm.HasSession(sessionName) {
 		return sessionContext{}
 	}
@@ -154,6 +176,7 @@ func loadSessionContext(sessionName string) sessionContext {
 		ctx.projectDir = env["PROJECT_DIR"]
 		ctx.pawDir = env["PAW_DIR"]
 		ctx.displayName = env["DISPLAY_NAME"]
+		ctx.proxyEnv = proxyEnv(envEntries(env))
 	}
 
 	if ctx.projectDir == "" {
@@ -183,6 +206,10 @@ func syncSessionEnv(tm tmux.Client, appCtx *app.App) {
 		"DISPLAY_NAME": appCtx.GetDisplayName(),
 		"SESSION_NAME": appCtx.SessionName,
 	}
+	// Pass the shell's proxy settings to agents started in the session
+	for key, value := range proxyEnv(os.Environ()) {
+		values[key] = value
+	}
 
 	for key, value := range values {
 		if value == "" {
@@ -233,6 +260,7 @@ func setupLogger(logPath string, debug bool, scriptName string, taskName string)
 		logger.SetTask(taskName)
 	}
 	logging.SetGlobal(logger)
+	git.SetAuditLog(filepath.Join(filepath.Dir(logPath), constants.AuditLogFileName), scriptName, taskName)
 
 	return logger, func() { _ = logger.Close() }
 }
@@ -279,7 +307,9 @@ func renameWindowWithStatus(tm tmux.Client, windowID, name, pawDir, taskName, so
 	//
 	// NOTE: WAITING state is handled by watch-wait watcher (wait.go) which provides
 	// action buttons and prompt context. Corrupted states also display as WAITING.
-	if prevStatus != status && status == task.StatusDone {
+	if prevStatus != status && status == task.StatusDone && t.IsSnoozed(time.Now()) {
+		logging.Info("renameWindowWithStatus: task=%s is snoozed, holding done notification", taskName)
+	} else if prevStatus != status && status == task.StatusDone {
 		logging.Info("renameWindowWithStatus: sending done notification for task=%s", taskName)
 		notify.PlaySound(notify.SoundTaskCompleted)
 		_ = notify.Send("Task ready", fmt.Sprintf("✅ %s is ready for review", taskName))
@@ -299,6 +329,7 @@ func getAppFromSession(sessionName string) (*app.App, error) {
 	gitClient := git.New()
 
 	sessionCtx := loadSessionContext(sessionName)
+	importProxyEnv(sessionCtx.proxyEnv)
 
 	// Get environment variables - both may be set
 	projectDirEnv := sessionCtx.projectDir
@@ -456,7 +487,7 @@ func getAppFromSession(sessionName string) (*app.App, error) {
 	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
 	if err != nil {
 		logging.Debug("getAppFromSession: app.NewWithGitInfo failed: %v", err)
-		return nil, fmt.Errorf("could not find project directory for session %s", sessionName)
+		return nil, exitcode.Errorf(exitcode.ConfigMissing, "could not find project directory for session %s", sessionName)
 	}
 
 	if displayNameEnv != "" {
@@ -469,12 +500,79 @@ func getAppFromSession(sessionName string) (*app.App, error) {
 	// Verify that the workspace exists (was initialized)
 	if !application.IsInitialized() {
 		logging.Debug("getAppFromSession: workspace not initialized at %s", application.PawDir)
-		return nil, fmt.Errorf("could not find project directory for session %s", sessionName)
+		return nil, exitcode.Errorf(exitcode.ConfigMissing, "could not find project directory for session %s", sessionName)
 	}
 
 	return loadAppConfig(application)
 }
 
+// getAppFromProject resolves the initialized workspace for the current directory
+// the same way `paw` does (git repo root, global or local workspace).
+// Unlike getAppFromCwd, it also finds global workspaces.
+func getAppFromProject() (*app.App, error) {
+	application, err := newProjectApp()
+	if err != nil {
+		return nil, err
+	}
+	if !application.IsInitialized() {
+		return nil, exitcode.Errorf(exitcode.ConfigMissing, "workspace not initialized at %s (run 'paw' first)", application.PawDir)
+	}
+
+	return loadAppConfig(application)
+}
+
+// newProjectApp returns the app for the project containing the current
+// directory, whether or not its workspace is initialized.
+func newProjectApp() (*app.App, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	gitClient := git.New()
+	isGitRepo := gitClient.IsGitRepo(cwd)
+	projectDir := cwd
+	if isGitRepo {
+		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
+			projectDir = repoRoot
+		}
+	}
+
+	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app: %w", err)
+	}
+	if isGitRepo {
+		application.SetSubdirectoryContext(cwd, projectDir)
+	}
+	return application, nil
+}
+
+// startTaskHandler runs handle-task for a created task in the running session
+// and waits for its window, so later tasks can depend on it.
+func startTaskHandler(appCtx *app.App, t *task.Task) error {
+	handleCmd := exec.Command(getPawBin(), "internal", "handle-task", appCtx.SessionName, t.AgentDir) //nolint:gosec // G204: pawBin is from getPawBin()
+	// Pass PAW_DIR and PROJECT_DIR so getAppFromSession can find the project
+	// (required for global workspaces where there's no local .paw directory)
+	handleCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := handleCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start task handler for %s: %w", t.Name, err)
+	}
+
+	// Wait for the window so dependent tasks can find it
+	windowIDFile := filepath.Join(t.AgentDir, constants.TabLockDirName, constants.WindowIDFileName)
+	for i := 0; i < constants.WindowIDWaitMaxAttempts; i++ {
+		if _, err := os.Stat(windowIDFile); err == nil {
+			break
+		}
+		time.Sleep(constants.WindowIDWaitInterval)
+	}
+	return nil
+}
+
 func loadAppConfig(application *app.App) (*app.App, error) {
 	pawHome, _ := getPawHome()
 	application.SetPawHome(pawHome)
@@ -489,11 +587,28 @@ func loadAppConfig(application *app.App) (*app.App, error) {
 		_ = os.Setenv("PAW_LOG_FORMAT", application.Config.LogFormat)
 		_ = os.Setenv("PAW_LOG_MAX_SIZE_MB", strconv.Itoa(application.Config.LogMaxSizeMB))
 		_ = os.Setenv("PAW_LOG_MAX_BACKUPS", strconv.Itoa(application.Config.LogMaxBackups))
+		clipboard.SetMode(application.Config.Clipboard)
 	}
 
 	return application, nil
 }
 
+// paneCaptureOpts returns the capture settings for the task in agentDir: its
+// own pane_capture_lines/pane_capture_format overrides, else the project's.
+func paneCaptureOpts(cfg *config.Config, agentDir string) tmux.CaptureOpts {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	var taskOpts *config.TaskOptions
+	if agentDir != "" {
+		if opts, err := config.LoadTaskOptions(agentDir); err == nil {
+			taskOpts = opts
+		}
+	}
+	lines, ansi := cfg.PaneCapture(taskOpts)
+	return tmux.CaptureOpts{Lines: lines, Escapes: ansi}
+}
+
 // getShell returns the user's preferred shell
 func getShell() string {
 	shell := os.Getenv("SHELL")
@@ -102,3 +102,18 @@ func TestBuildNewTaskCommand(t *testing.T) {
 		t.Fatalf("buildNewTaskCommand() missing fallback display name: %q", got)
 	}
 }
+
+func TestIsShellCommand(t *testing.T) {
+	for command, want := range map[string]bool{
+		"zsh":           true,
+		"-bash":         true,
+		"/usr/bin/fish": true,
+		"paw":           false,
+		"nvim":          false,
+		"":              false,
+	} {
+		if got := isShellCommand(command); got != want {
+			t.Errorf("isShellCommand(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+const (
+	// interruptSettleTimeout is how long to wait for the agent to stop after
+	// Escape before pressing it once more.
+	interruptSettleTimeout = 3 * time.Second
+	// interruptPollInterval is how often the pane is checked while stopping.
+	interruptPollInterval = 200 * time.Millisecond
+)
+
+var interruptCmd = &cobra.Command{
+	Use:   "interrupt <task> [direction]",
+	Short: "Stop an agent mid-task and give it new direction",
+	Long: `Stop the task's agent the way Claude Code expects (Escape, which ends the
+current step and keeps the session), then send new direction.
+
+Without a direction argument, you are asked for one; leave it empty to keep
+the agent stopped. The interruption is recorded in the task timeline.
+
+Examples:
+  paw interrupt fix-login "use the existing session helper instead"
+  paw interrupt fix-login        # stop, then ask for direction`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runInterrupt,
+}
+
+func runInterrupt(_ *cobra.Command, args []string) error {
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+
+	tm := newTmuxClient(appCtx.SessionName)
+	if !tm.HasSession(appCtx.SessionName) {
+		return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+	}
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	t, err := mgr.GetTask(args[0])
+	if err != nil {
+		return exitcode.WithTask(exitcode.New(exitcode.TaskNotFound, fmt.Errorf("task %q not found: %w", args[0], err)), args[0])
+	}
+
+	_, cleanup := setupLoggerFromApp(appCtx, "interrupt", t.Name)
+	defer cleanup()
+
+	windowID, err := taskWindowID(tm, t.Name)
+	if err != nil {
+		return err
+	}
+
+	if !interruptAgent(tm, windowID+".0") {
+		fmt.Println("⚠️  The agent still looks busy; it may take a moment to stop")
+	}
+	fmt.Printf("⏸  Interrupted %s\n", t.Name)
+
+	direction := ""
+	if len(args) > 1 {
+		direction = strings.TrimSpace(args[1])
+	} else if canPrompt() {
+		fmt.Print("New direction (empty to leave it stopped): ")
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		direction = strings.TrimSpace(line)
+	}
+
+	if err := steerAgent(appCtx, tm, windowID, t, direction); err != nil {
+		return err
+	}
+	if direction != "" {
+		fmt.Printf("➡️  Sent new direction to %s\n", t.Name)
+	}
+	return nil
+}
+
+var interruptTaskCmd = &cobra.Command{
+	Use:    "interrupt-task [session]",
+	Short:  "Interrupt the current task's agent and ask for new direction",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			// Log and return nil to avoid run-shell output error in pane
+			logging.Warn("interruptTaskCmd: getAppFromSession failed: %v", err)
+			return nil
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "interrupt-task", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		windowID, windowName, err := getCurrentWindowInfo(tm)
+		if err != nil {
+			logging.Warn("interruptTaskCmd: failed to get window info: %v", err)
+			return nil
+		}
+		taskName, isTaskWindow := constants.ExtractTaskName(windowName)
+		if !isTaskWindow {
+			_ = tm.DisplayMessage("Not a task window", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		if !interruptAgent(tm, windowID+".0") {
+			logging.Debug("interruptTaskCmd: agent still busy after Escape, task=%s", taskName)
+		}
+
+		popupCmd := shellJoin(getPawBin(), "internal", "steer-task-tui", sessionName, windowID)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:  constants.PopupWidthSteer,
+			Height: constants.PopupHeightSteer,
+			Title:  " Interrupt ",
+			Close:  true,
+			Style:  "fg=terminal,bg=terminal",
+		}, popupCmd)
+	},
+}
+
+var steerTaskTUICmd = &cobra.Command{
+	Use:    "steer-task-tui [session] [window-id]",
+	Short:  "Ask for new direction for an interrupted task (called from popup)",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName, windowID := args[0], args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		t, err := mgr.FindTaskByWindowID(windowID)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "steer-task-tui", t.Name)
+		defer cleanup()
+
+		logging.Debug("-> steerTaskTUICmd(session=%s, windowID=%s)", sessionName, windowID)
+		defer logging.Debug("<- steerTaskTUICmd")
+
+		direction, err := tui.RunSteerInput(t.Name)
+		if err != nil {
+			logging.Warn("RunSteerInput failed: %v", err)
+			direction = ""
+		}
+
+		tm := newTmuxClient(sessionName)
+		if err := steerAgent(appCtx, tm, windowID, t, direction); err != nil {
+			logging.Warn("steerTaskTUICmd: %v", err)
+			_ = tm.DisplayMessage("Failed to send direction to "+t.Name, constants.DisplayMsgStandard)
+			return nil
+		}
+		if direction != "" {
+			_ = tm.DisplayMessage("➡️ New direction sent to "+t.Name, constants.DisplayMsgQuick)
+		} else {
+			_ = tm.DisplayMessage("⏸ "+t.Name+" stopped", constants.DisplayMsgQuick)
+		}
+		return nil
+	},
+}
+
+// interruptAgent stops the agent's current step with Escape. Claude Code
+// cancels the step and keeps the session; Ctrl+C is avoided because a second
+// one quits Claude. Escape is pressed once more if the agent still shows its
+// spinner after interruptSettleTimeout. It returns whether the agent stopped.
+func interruptAgent(tm tmux.Client, paneID string) bool {
+	for attempt := 0; attempt < 2; attempt++ {
+		if err := tm.SendKeys(paneID, "Escape"); err != nil {
+			logging.Warn("interruptAgent: failed to send Escape: %v", err)
+			return false
+		}
+		deadline := time.Now().Add(interruptSettleTimeout)
+		for time.Now().Before(deadline) {
+			time.Sleep(interruptPollInterval)
+			content, err := tm.CapturePane(paneID, 10)
+			if err == nil && !agentBusy(content) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// agentBusy reports whether the pane shows Claude's working spinner.
+func agentBusy(content string) bool {
+	return strings.Contains(strings.ToLower(content), "esc to interrupt")
+}
+
+// steerAgent records the interruption in the task timeline and sends the new
+// direction, if any, to the agent.
+func steerAgent(appCtx *app.App, tm tmux.Client, windowID string, t *task.Task, direction string) error {
+	ev := service.TimelineEvent{Kind: service.TimelineInterrupt, Detail: direction}
+	if err := service.AppendTimelineEvent(t.GetTimelinePath(), ev); err != nil {
+		logging.Warn("steerAgent: failed to record interruption: %v", err)
+	}
+	logging.Info("steerAgent: task=%s interrupted direction=%q", t.Name, direction)
+
+	if direction == "" {
+		return nil
+	}
+	if err := newClaudeClient().SendInputWithRetry(tm, windowID+".0", direction, 3); err != nil {
+		return fmt.Errorf("failed to send direction: %w", err)
+	}
+
+	newName := windowNameForStatus(t.Name, task.StatusWorking)
+	if err := renameWindowWithStatus(tm, windowID, newName, appCtx.PawDir, t.Name, "interrupt", task.StatusWorking); err != nil {
+		logging.Warn("steerAgent: failed to rename window: %v", err)
+	}
+	return nil
+}
+
+// taskWindowID returns the window ID of a running task.
+func taskWindowID(tm tmux.Client, taskName string) (string, error) {
+	windows, err := tm.ListWindows()
+	if err != nil {
+		return "", err
+	}
+	for _, w := range windows {
+		if token, ok := constants.ExtractTaskName(w.Name); ok && constants.MatchesWindowToken(token, taskName) {
+			return w.ID, nil
+		}
+	}
+	return "", fmt.Errorf("task %q has no window (is it running?)", taskName)
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestAgentBusy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"spinner", "✻ Running tests… (1m 36s · ↓ 5.9k tokens · esc to interrupt)", true},
+		{"stopped", "  ⎿  Interrupted by user\n\n> ", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agentBusy(tt.content); got != tt.want {
+				t.Errorf("agentBusy(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
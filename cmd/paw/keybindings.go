@@ -42,6 +42,13 @@ func shellPassthrough(key, pawCmd string) string {
 //   - Ctrl+J: Toggle project picker (switch between PAW sessions)
 //   - Ctrl+Y: Edit prompts (open prompt picker)
 //   - Ctrl+K: New shell window
+//   - Alt+A: Review pending command approval or pair-mode patch
+//   - Alt+Z: Snooze/unsnooze current task notifications
+//   - Alt+F: Toggle focus-follow (jump to tasks that need input)
+//   - Alt+R: Quick reply to a waiting task
+//   - Alt+I: Interrupt the current task's agent and give it new direction
+//   - Alt+C: Take or request control of the new-task window (shared sessions)
+//   - Alt+V: New task from the tmux paste buffer
 //   - Alt+Left/Right: Select previous/next window
 //   - Alt+Up/Down: Swap window left/right
 //   - Alt+Tab: Cycle pane forward (in task windows) / Cycle options (in new task window)
@@ -87,6 +94,15 @@ func buildKeybindings(ctx KeybindingsContext) []tmux.BindOpts {
 	cmdToggleProjectPicker := buildPawRunShell("toggle-project-picker", ctx.SessionName)
 	cmdTogglePromptPicker := buildPawRunShell("toggle-prompt-picker", ctx.SessionName)
 	cmdNewShellWindow := buildPawRunShell("new-shell-window", ctx.SessionName)
+	cmdApprovalPopup := buildPawRunShell("approval-popup", ctx.SessionName)
+	cmdSnoozeTask := buildPawRunShell("snooze-task", ctx.SessionName)
+	cmdToggleFocusFollow := buildPawRunShell("toggle-focus-follow", ctx.SessionName)
+	cmdQuickReply := buildPawRunShell("quick-reply", ctx.SessionName)
+	cmdInterruptTask := buildPawRunShell("interrupt-task", ctx.SessionName)
+	cmdRequestControl := buildPawRunShell("request-control", ctx.SessionName, "#{client_user}")
+	cmdPasteTask := buildPawRunShell("paste-task", ctx.SessionName)
+	cmdZoomAgent := buildPawRunShell("zoom-pane", ctx.SessionName, "agent")
+	cmdZoomUser := buildPawRunShell("zoom-pane", ctx.SessionName, "user")
 
 	// Alt+Tab: context-aware - pass through to TUI in new task window, cycle panes otherwise
 	// #{m:pattern,string} checks if string matches pattern (⭐️* = starts with ⭐️)
@@ -118,6 +134,15 @@ func buildKeybindings(ctx KeybindingsContext) []tmux.BindOpts {
 		{Key: "M-Right", Command: cmdNextWindow, NoPrefix: true},
 		{Key: "M-Up", Command: cmdSwapWindowLeft, NoPrefix: true},
 		{Key: "M-Down", Command: cmdSwapWindowRight, NoPrefix: true},
+		{Key: "M-1", Command: cmdZoomAgent, NoPrefix: true},
+		{Key: "M-2", Command: cmdZoomUser, NoPrefix: true},
+		{Key: "M-a", Command: cmdApprovalPopup, NoPrefix: true},
+		{Key: "M-z", Command: cmdSnoozeTask, NoPrefix: true},
+		{Key: "M-f", Command: cmdToggleFocusFollow, NoPrefix: true},
+		{Key: "M-r", Command: cmdQuickReply, NoPrefix: true},
+		{Key: "M-i", Command: cmdInterruptTask, NoPrefix: true},
+		{Key: "M-c", Command: cmdRequestControl, NoPrefix: true},
+		{Key: "M-v", Command: cmdPasteTask, NoPrefix: true},
 
 		// Task commands (Ctrl-based)
 		// These pass through to shell in shell pane, except Ctrl+F and Ctrl+Q
@@ -9,8 +9,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/exitcode"
 	"github.com/dongho-jung/paw/internal/git"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 var killCmd = &cobra.Command{
@@ -37,12 +37,18 @@ var killAllCmd = &cobra.Command{
 	Short: "Kill all running PAW sessions",
 	Long: `Kill all running PAW tmux sessions without removing .paw directories.
 
-Prompts for confirmation before killing all sessions.
+Prompts for confirmation before killing all sessions (skip it with --yes).
 
 Unlike 'paw clean', this preserves .paw directories, worktrees, and branches.`,
 	RunE: runKillAll,
 }
 
+var killAllYes bool
+
+func init() {
+	killAllCmd.Flags().BoolVarP(&killAllYes, "yes", "y", false, "Kill without asking for confirmation")
+}
+
 // Note: killAllCmd is registered in main.go as a root command
 
 func runKill(_ *cobra.Command, args []string) error {
@@ -89,7 +95,7 @@ func runKill(_ *cobra.Command, args []string) error {
 				for _, s := range sessions {
 					fmt.Printf("  - %s\n", s.Name)
 				}
-				return errors.New("session not found")
+				return exitcode.Errorf(exitcode.SessionNotFound, "session not found")
 			}
 		}
 	case len(sessions) == 1:
@@ -104,6 +110,9 @@ func runKill(_ *cobra.Command, args []string) error {
 			fmt.Printf("  %d. %s\n", i+1, s.Name)
 		}
 		fmt.Println()
+		if isNonInteractive() {
+			return errNeedsAnswer("Choosing the session to kill", "pass the session name: paw kill <session>")
+		}
 		fmt.Print("Select session to kill [1]: ")
 
 		var input string
@@ -190,9 +199,14 @@ func runKillAll(_ *cobra.Command, _ []string) error {
 	fmt.Println()
 
 	// Confirm before killing
-	if !confirmPrompt(fmt.Sprintf("Kill all %d session(s)? [y/N]: ", len(sessions))) {
-		fmt.Println("Cancelled.")
-		return nil
+	if !killAllYes {
+		if isNonInteractive() {
+			return errNeedsAnswer("Killing all sessions", "run with --yes to confirm")
+		}
+		if !confirmPrompt(fmt.Sprintf("Kill all %d session(s)? [y/N]: ", len(sessions))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
 	}
 
 	var failed []string
@@ -217,7 +231,7 @@ func runKillAll(_ *cobra.Command, _ []string) error {
 // forceKillSession kills a PAW session immediately without graceful shutdown.
 // If verbose is true, prints progress messages.
 func forceKillSession(session pawSession, verbose bool) error {
-	tm := tmux.New(session.Name)
+	tm := newTmuxClient(session.Name)
 
 	// Check if session still exists
 	if !tm.HasSession(session.Name) {
@@ -237,6 +251,7 @@ func forceKillSession(session pawSession, verbose bool) error {
 		}
 		return fmt.Errorf("failed to kill session: %w", err)
 	}
+	endCooperativeSession(tm, session.Name)
 
 	if verbose {
 		fmt.Println("  Session terminated.")
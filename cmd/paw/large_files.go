@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// stageTaskChanges stages a task's changes for an automatic commit, keeping
+// out nested repositories and large files as configured. It returns notes
+// about large files to show once the commit spinner has stopped, and the
+// files it held back from the commit.
+func stageTaskChanges(gitClient git.Client, workDir, context string, cfg *config.Config) ([]string, []string) {
+	addAllWithClaudeGuard(gitClient, workDir, context, cfg.ExcludeNestedRepos)
+	return guardLargeFiles(gitClient, workDir, context, cfg)
+}
+
+// guardLargeFiles applies large_file_action to staged files over
+// large_file_mb. Files matching LFS patterns are unstaged while git-lfs is
+// missing, since git would commit them as regular files. It also returns
+// the files it unstaged.
+func guardLargeFiles(gitClient git.Client, workDir, context string, cfg *config.Config) ([]string, []string) {
+	staged, err := gitClient.StagedFiles(workDir)
+	if err != nil || len(staged) == 0 {
+		return nil, nil
+	}
+	lfsPaths, err := gitClient.LFSPaths(workDir, staged)
+	if err != nil {
+		logging.Debug("%s: failed to check LFS attributes: %v", context, err)
+	}
+
+	var notes, heldBack []string
+	lfsChecked, lfsAvailable := false, false
+	hasLFS := func() bool {
+		if !lfsChecked {
+			lfsChecked, lfsAvailable = true, gitClient.LFSAvailable(workDir)
+		}
+		return lfsAvailable
+	}
+	skip := func(path, reason string) {
+		if err := gitClient.ResetPath(workDir, path); err != nil {
+			logging.Warn("%s: failed to unstage %s: %v", context, path, err)
+			return
+		}
+		logging.Warn("%s: left %s out of the commit: %s", context, path, reason)
+		heldBack = append(heldBack, path)
+		notes = append(notes, fmt.Sprintf("⚠️  Left %s out of the commit (%s); it stays in the worktree uncommitted", path, reason))
+	}
+
+	limit := int64(cfg.LargeFileMB) * 1024 * 1024
+	for _, path := range staged {
+		if lfsPaths[path] {
+			if !hasLFS() {
+				skip(path, "matches an LFS pattern but git-lfs is not installed")
+			}
+			continue
+		}
+		if limit == 0 {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(workDir, path))
+		if err != nil || !info.Mode().IsRegular() || info.Size() <= limit {
+			continue
+		}
+
+		size := fmt.Sprintf("%d MB > large_file_mb %d", info.Size()/(1024*1024), cfg.LargeFileMB)
+		switch cfg.LargeFileAction {
+		case constants.LargeFileAllow:
+			logging.Warn("%s: committing large file %s (%s)", context, path, size)
+			notes = append(notes, fmt.Sprintf("⚠️  Committed large file %s (%s)", path, size))
+			continue
+		case constants.LargeFileLFS:
+			if !hasLFS() {
+				skip(path, size+"; git-lfs is not installed")
+				continue
+			}
+			if err := gitClient.LFSTrack(workDir, path); err != nil {
+				logging.Warn("%s: failed to track %s with git-lfs: %v", context, path, err)
+				skip(path, size+"; git lfs track failed")
+				continue
+			}
+			logging.Log("%s: tracked large file %s with git-lfs", context, path)
+			notes = append(notes, fmt.Sprintf("📦 Tracked %s with git-lfs (%s)", path, size))
+			continue
+		}
+		skip(path, size)
+	}
+	return notes, heldBack
+}
+
+// keepHeldBackFiles moves the files held back from a task's commits into its
+// artifacts (under held-back/) before the worktree is removed, so they are
+// saved to history instead of lost.
+func keepHeldBackFiles(t *task.Task, workDir string, heldBack []string) {
+	if len(heldBack) == 0 {
+		return
+	}
+	dir := filepath.Join(t.GetArtifactsDir(), constants.HeldBackDirName)
+	moved := 0
+	for _, path := range heldBack {
+		dst := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			logging.Warn("keepHeldBackFiles: %v", err)
+			continue
+		}
+		if err := os.Rename(filepath.Join(workDir, filepath.FromSlash(path)), dst); err != nil {
+			logging.Warn("keepHeldBackFiles: failed to keep %s: %v", path, err)
+			fmt.Printf("  ⚠️  %s was left out of the commit and is removed with the worktree: %v\n", path, err)
+			continue
+		}
+		moved++
+	}
+	if moved > 0 {
+		logging.Log("keepHeldBackFiles: moved %d file(s) of %s to its artifacts", moved, t.Name)
+		fmt.Printf("  📦 Moved %d file(s) left out of the commit to the task's artifacts (%s/)\n", moved, constants.HeldBackDirName)
+	}
+}
+
+// printNotes prints notes indented under the current step.
+func printNotes(notes []string) {
+	for _, note := range notes {
+		fmt.Println("  " + note)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func TestKeepHeldBackFiles(t *testing.T) {
+	agentDir := t.TempDir()
+	workDir := filepath.Join(agentDir, constants.WorktreeDirName)
+	if err := os.MkdirAll(filepath.Join(workDir, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "data", "dump.bin"), []byte("large"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tk := task.New("add-dump", agentDir)
+	keepHeldBackFiles(tk, workDir, []string{"data/dump.bin", "gone.bin"})
+
+	data, err := os.ReadFile(filepath.Join(tk.GetArtifactsDir(), constants.HeldBackDirName, "data", "dump.bin"))
+	if err != nil || string(data) != "large" {
+		t.Fatalf("held-back file in artifacts = %q, %v; want the file's contents", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "data", "dump.bin")); !os.IsNotExist(err) {
+		t.Errorf("held-back file still in the worktree: %v", err)
+	}
+}
@@ -3,13 +3,18 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
 )
 
+var locationSet string
+
 var locationCmd = &cobra.Command{
 	Use:   "location",
 	Short: "Show workspace location for the current project",
@@ -18,10 +23,24 @@ var locationCmd = &cobra.Command{
 By default, PAW stores git project workspaces in ~/.local/share/paw/workspaces/{project-id}/
 to avoid modifying project .gitignore files, and uses .paw/ for non-git projects.
 
+Use --set to choose where workspaces of all projects are stored
+(saved as workspace_location in ~/.config/paw/config):
+  auto    Git projects in ~/.local/share/paw/workspaces, others in .paw/ (default)
+  global  Always ~/.local/share/paw/workspaces/{project-id}/
+  local   Always .paw/ in the project
+  xdg     Always $XDG_STATE_HOME/paw/{project-id}/ (~/.local/state/paw by default)
+
+The current project's workspace is moved right away if its session isn't
+running; other projects' workspaces move the next time 'paw' starts them.
+
 Use 'paw --local' to force a local .paw workspace for git projects.`,
 	RunE: runLocation,
 }
 
+func init() {
+	locationCmd.Flags().StringVar(&locationSet, "set", "", "Set the workspace location for all projects (auto, global, local, xdg)")
+}
+
 func runLocation(_ *cobra.Command, _ []string) error {
 	// Get current directory
 	cwd, err := os.Getwd()
@@ -39,12 +58,26 @@ func runLocation(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	if locationSet != "" {
+		if err := setWorkspaceLocation(config.PawInProject(locationSet)); err != nil {
+			return err
+		}
+	}
+
 	// Create app context to get workspace path
 	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
 	if err != nil {
 		return fmt.Errorf("failed to create app: %w", err)
 	}
 
+	if locationSet != "" {
+		if newTmuxClient(application.SessionName).HasSession(application.SessionName) {
+			fmt.Fprintf(os.Stderr, "Session %s is running; the workspace will move the next time 'paw' starts it.\n", application.SessionName)
+		} else if _, err := migrateWorkspace(application); err != nil {
+			return err
+		}
+	}
+
 	// Print workspace location
 	fmt.Println(application.PawDir)
 
@@ -54,7 +87,12 @@ func runLocation(_ *cobra.Command, _ []string) error {
 	}
 
 	if application.IsGlobalWorkspace() {
-		fmt.Fprintf(os.Stderr, "(global workspace; auto mode for git projects)\n")
+		location := config.GlobalWorkspaceLocation()
+		if location == config.PawInProjectAuto {
+			fmt.Fprintf(os.Stderr, "(global workspace; auto mode for git projects)\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "(%s workspace; workspace_location in ~/.config/paw/config)\n", location)
+		}
 		if application.IsGitRepo {
 			fmt.Fprintf(os.Stderr, "Tip: run `paw --local` to force a local .paw workspace.\n")
 		}
@@ -64,3 +102,54 @@ func runLocation(_ *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// setWorkspaceLocation saves workspace_location in the global config.
+func setWorkspaceLocation(location config.PawInProject) error {
+	if !location.IsValid() {
+		return fmt.Errorf("invalid workspace location %q (use auto, global, local, or xdg)", location)
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return err
+	}
+	cfg.WorkspaceLocation = string(location)
+	if location == config.PawInProjectAuto {
+		cfg.WorkspaceLocation = ""
+	}
+	if err := cfg.Save(config.GlobalPawDir()); err != nil {
+		return fmt.Errorf("failed to save global config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Workspace location set to %s\n", location)
+	return nil
+}
+
+// migrateWorkspace moves an existing workspace of the project to the current
+// workspace location when nothing exists there yet, and repairs the task
+// worktrees inside it. Must not run while the project's session is running.
+// Returns the old workspace path, or "" if nothing was moved.
+func migrateWorkspace(application *app.App) (string, error) {
+	if application.IsInitialized() {
+		return "", nil
+	}
+	from := config.FindExistingWorkspace(application.ProjectDir, application.IsGitRepo, application.PawDir)
+	if from == "" {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(application.PawDir), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return "", fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+	if err := os.Rename(from, application.PawDir); err != nil {
+		return "", fmt.Errorf("failed to move workspace from %s to %s (move it manually): %w", from, application.PawDir, err)
+	}
+	fmt.Fprintf(os.Stderr, "Moved workspace: %s -> %s\n", from, application.PawDir)
+	logging.Log("Moved workspace: %s -> %s", from, application.PawDir)
+
+	if _, err := relocateWorkspace(application, git.New()); err != nil {
+		logging.Warn("Failed to repair workspace after moving it: %v", err)
+		fmt.Fprintln(os.Stderr, "Warning: run 'paw repair --relocate' to fix task worktrees")
+	}
+	return from, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
 )
 
 var (
@@ -102,7 +103,7 @@ func getAppFromCwd() (*app.App, error) {
 		dir = parent
 	}
 
-	return nil, fmt.Errorf("could not find .paw directory from %s", cwd)
+	return nil, exitcode.Errorf(exitcode.ConfigMissing, "could not find .paw directory from %s", cwd)
 }
 
 func parseLogLine(line string) (time.Time, string, bool) {
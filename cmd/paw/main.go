@@ -4,16 +4,18 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"runtime/debug"
 	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/clipboard"
 	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/exitcode"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
-	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
 )
 
@@ -26,8 +28,9 @@ var (
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		err = classifyError(err)
+		printError(err)
+		os.Exit(exitcode.Code(err))
 	}
 }
 
@@ -36,12 +39,15 @@ var rootCmd = &cobra.Command{
 	Short: "PAW - Parallel AI Workers",
 	Long: `PAW is a Claude Code-based autonomous task execution system.
 It manages tasks in tmux sessions with optional git worktree isolation.`,
-	RunE:         runMain,
-	SilenceUsage: true,
+	RunE:              runMain,
+	SilenceUsage:      true,
+	SilenceErrors:     true, // Printed by main in the --error-format
+	PersistentPreRunE: exportGlobalFlags,
 }
 
 var showVersion bool
 var forceLocal bool
+var traceStartup bool
 
 func init() {
 	hydrateBuildInfo()
@@ -54,11 +60,35 @@ func init() {
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(cleanAllCmd)
 	rootCmd.AddCommand(killCmd)
+	rootCmd.AddCommand(stopCmd)
 	rootCmd.AddCommand(killAllCmd)
 	rootCmd.AddCommand(locationCmd)
+	rootCmd.AddCommand(repairCmd)
+	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(muteCmd)
+	rootCmd.AddCommand(unmuteCmd)
+	rootCmd.AddCommand(budgetCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(splitCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(interruptCmd)
+	rootCmd.AddCommand(undoMergeCmd)
 	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(artifactsCmd)
+	rootCmd.AddCommand(parkedCmd)
+	rootCmd.AddCommand(assetsCmd)
+	rootCmd.AddCommand(variantsCmd)
+	rootCmd.AddCommand(editorCmd)
+	rootCmd.AddCommand(autopilotCmd)
+	rootCmd.AddCommand(benchCmd)
 	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(windowMapCmd)
+	rootCmd.AddCommand(urlHandlerCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	// Internal commands (hidden, called by tmux keybindings)
@@ -67,6 +97,10 @@ func init() {
 	// Add -v/--version flag to root command
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", false, "Print version information")
 	rootCmd.Flags().BoolVar(&forceLocal, "local", false, "Force local .paw workspace for git repositories")
+	rootCmd.Flags().BoolVar(&traceStartup, "trace-startup", false, "Print a timing breakdown of startup before attaching")
+	cleanAllCmd.Flags().BoolVarP(&cleanAllYes, "yes", "y", false, "Clean without asking for confirmation")
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", "", "Error output on stderr: text or json (also PAW_ERROR_FORMAT)")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt: take defaults or fail with an error (also PAW_NONINTERACTIVE=1)")
 }
 
 func hydrateBuildInfo() {
@@ -156,8 +190,18 @@ func printVersion() {
 var cleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean up all PAW resources",
-	Long:  "Remove all worktrees, branches, tmux session, and .paw directory",
-	RunE:  runClean,
+	Long: `Remove the tmux session windows, worktrees, branches, history, logs, and
+.paw directory of the current project.
+
+Use --worktrees, --branches, --history, or --logs to remove only those
+resources, and --keep-config to keep config, PROMPT.md, prompts, overrides,
+readiness checks, input templates and their schedules, the budget override,
+and input history when removing the .paw directory.
+
+Shows an interactive preview first where you can opt out of individual items
+(keeping a worktree also keeps its branch and the .paw directory).
+Use --dry-run to only list the resources, or --yes to skip the preview.`,
+	RunE: runClean,
 }
 
 var cleanAllCmd = &cobra.Command{
@@ -165,21 +209,29 @@ var cleanAllCmd = &cobra.Command{
 	Short: "Clean up all PAW resources across all projects",
 	Long: `Clean up all PAW sessions and workspaces across all projects.
 
-Prompts for confirmation before cleaning.
+Prompts for confirmation before cleaning (skip it with --yes).
 
 This command:
 1. Kills all running PAW tmux sessions
-2. Removes all PAW workspaces from ~/.local/share/paw/workspaces/
+2. Removes all PAW workspaces from ~/.local/share/paw/workspaces/ and $XDG_STATE_HOME/paw/
 3. Cleans up git worktrees and branches for each workspace`,
 	RunE: runCleanAll,
 }
 
+var cleanAllYes bool
+
 // runMain is the main entry point - starts or attaches to a tmux session
 func runMain(_ *cobra.Command, _ []string) error {
 	// Check for -v flag first
 	if checkVersionFlag() {
 		return nil
 	}
+	if traceStartup {
+		startStartupTrace()
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return exitcode.Errorf(exitcode.TmuxUnavailable, "tmux is not installed (see 'paw check')")
+	}
 
 	// Get current directory
 	cwd, err := os.Getwd()
@@ -187,10 +239,16 @@ func runMain(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	// A task worktree is a checkout of the project, not a new project
+	if wt, ok := findTaskWorktree(cwd); ok {
+		return refuseTaskWorktree(wt)
+	}
+
 	// Detect git repo first - if in a git repo, use repo root as project dir
 	// This prevents issues with:
 	// 1. Session names containing colons (e.g., "project:src") conflicting with tmux target syntax
 	// 2. .paw directory being created in subdirectory instead of repo root
+	detectTimer := logging.StartTimer("project detection")
 	gitClient := git.New()
 	isGitRepo := gitClient.IsGitRepo(cwd)
 	projectDir := cwd
@@ -228,8 +286,19 @@ func runMain(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get PAW home: %w", err)
 	}
 	application.SetPawHome(pawHome)
+	detectTimer.Stop()
+
+	// Move an existing workspace to the location set with 'paw location --set'
+	// (never under a running session)
+	if !forceLocal && config.GlobalWorkspaceLocation() != config.PawInProjectAuto &&
+		!newTmuxClient(application.SessionName).HasSession(application.SessionName) {
+		if _, err := migrateWorkspace(application); err != nil {
+			return err
+		}
+	}
 
 	// Initialize .paw directory
+	configTimer := logging.StartTimer("config load")
 	if err := application.Initialize(); err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
@@ -246,13 +315,15 @@ func runMain(_ *cobra.Command, _ []string) error {
 
 	// Load configuration
 	if err := application.LoadConfig(); err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return exitcode.Errorf(exitcode.ConfigInvalid, "failed to load config: %w", err)
 	}
 	if application.Config != nil {
 		_ = os.Setenv("PAW_LOG_FORMAT", application.Config.LogFormat)
 		_ = os.Setenv("PAW_LOG_MAX_SIZE_MB", strconv.Itoa(application.Config.LogMaxSizeMB))
 		_ = os.Setenv("PAW_LOG_MAX_BACKUPS", strconv.Itoa(application.Config.LogMaxBackups))
+		clipboard.SetMode(application.Config.Clipboard)
 	}
+	configTimer.Stop()
 
 	// Setup logging (file) with configured options
 	logger, err := logging.New(application.GetLogPath(), application.Debug)
@@ -262,9 +333,15 @@ func runMain(_ *cobra.Command, _ []string) error {
 	defer func() { _ = logger.Close() }()
 	logger.SetScript("paw")
 	logging.SetGlobal(logger)
+	git.SetAuditLog(application.GetAuditLogPath(), "paw", "")
+
+	// Choose the tmux server (dedicated or cooperative) before creating the client
+	if err := applyTmuxMode(application); err != nil {
+		return err
+	}
 
 	// Create tmux client
-	tm := tmux.New(application.SessionName)
+	tm := newTmuxClient(application.SessionName)
 
 	// Check if session already exists
 	if tm.HasSession(application.SessionName) {
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// muteOptionKey is the session option holding the status bar mute indicator.
+const muteOptionKey = "@paw_mute"
+
+var muteFor time.Duration
+
+var muteCmd = &cobra.Command{
+	Use:   "mute",
+	Short: "Silence notifications and sounds for this project",
+	Long: `Silence desktop notifications, sounds, and Slack/ntfy messages for the
+current project's session without editing the config. The status bar shows
+🔇 while muted.
+
+Examples:
+  paw mute            # Mute until 'paw unmute'
+  paw mute --for 2h   # Mute for two hours`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if muteFor < 0 {
+			return fmt.Errorf("invalid --for duration %s", muteFor)
+		}
+
+		appCtx, err := getAppForSetup()
+		if err != nil {
+			return err
+		}
+
+		var until time.Time
+		if muteFor > 0 {
+			until = time.Now().Add(muteFor)
+		}
+		if err := notify.Mute(muteFilePath(appCtx), until); err != nil {
+			return fmt.Errorf("failed to mute: %w", err)
+		}
+
+		tm := newTmuxClient(appCtx.SessionName)
+		if tm.HasSession(appCtx.SessionName) {
+			refreshMuteIndicator(tm, appCtx)
+		}
+
+		if until.IsZero() {
+			fmt.Println("🔇 Notifications muted until 'paw unmute'")
+		} else {
+			fmt.Printf("🔇 Notifications muted until %s\n", until.Format("15:04"))
+		}
+		return nil
+	},
+}
+
+var unmuteCmd = &cobra.Command{
+	Use:   "unmute",
+	Short: "Resume notifications muted with 'paw mute'",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppForSetup()
+		if err != nil {
+			return err
+		}
+		if err := notify.Unmute(muteFilePath(appCtx)); err != nil {
+			return fmt.Errorf("failed to unmute: %w", err)
+		}
+		if tm := newTmuxClient(appCtx.SessionName); tm.HasSession(appCtx.SessionName) {
+			refreshMuteIndicator(tm, appCtx)
+		}
+		fmt.Println("🔔 Notifications resumed")
+		return nil
+	},
+}
+
+var refreshMuteCmd = &cobra.Command{
+	Use:    "refresh-mute [session]",
+	Short:  "Update the status bar mute indicator",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		refreshMuteIndicator(newTmuxClient(sessionName), appCtx)
+		return nil
+	},
+}
+
+func init() {
+	muteCmd.Flags().DurationVar(&muteFor, "for", 0, "Mute for a duration (e.g. 30m, 2h) instead of until 'paw unmute'")
+}
+
+func muteFilePath(appCtx *app.App) string {
+	return filepath.Join(appCtx.PawDir, constants.MuteFileName)
+}
+
+// muteIndicator returns the status bar text for a mute state ("" if not muted).
+func muteIndicator(muted bool, until time.Time) string {
+	switch {
+	case !muted:
+		return ""
+	case until.IsZero():
+		return "🔇 muted"
+	default:
+		return "🔇 until " + until.Format("15:04")
+	}
+}
+
+// refreshMuteIndicator sets the session's status bar indicator from the mute
+// marker. For a timed mute, it schedules another refresh for when it runs out.
+func refreshMuteIndicator(tm tmux.Client, appCtx *app.App) {
+	now := time.Now()
+	muted, until := notify.MuteState(muteFilePath(appCtx), now)
+	indicator := muteIndicator(muted, until)
+	var err error
+	if indicator == "" {
+		err = tm.Run("set-option", "-u", muteOptionKey)
+	} else {
+		err = tm.SetOption(muteOptionKey, indicator, false)
+	}
+	if err != nil {
+		logging.Debug("refreshMuteIndicator: %v", err)
+	}
+	if muted && !until.IsZero() {
+		scheduleMuteRefresh(tm, appCtx.SessionName, until.Sub(now))
+	}
+}
+
+// scheduleMuteRefresh refreshes the indicator after a delay. The refresh
+// re-reads the marker, so a later mute or unmute is respected.
+func scheduleMuteRefresh(tm tmux.Client, sessionName string, after time.Duration) {
+	secs := strconv.Itoa(int(after.Seconds()) + 1)
+	refresh := "sleep " + secs + " && " + shellJoin(getPawBin(), "internal", "refresh-mute", sessionName)
+	if err := tm.Run("run-shell", "-b", refresh); err != nil {
+		logging.Debug("scheduleMuteRefresh: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// nonInteractive is set by the global --non-interactive flag.
+var nonInteractive bool
+
+// isNonInteractive reports whether prompts must not wait for input, because
+// of --non-interactive or PAW_NONINTERACTIVE.
+func isNonInteractive() bool {
+	if nonInteractive {
+		return true
+	}
+	enabled, err := strconv.ParseBool(os.Getenv(constants.NonInteractiveEnv))
+	return err == nil && enabled
+}
+
+// canPrompt reports whether PAW may ask a question on stdin.
+func canPrompt() bool {
+	return !isNonInteractive() && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// errNeedsAnswer is returned by prompts without a safe default when
+// non-interactive; hint says how to answer up front.
+func errNeedsAnswer(question, hint string) error {
+	return fmt.Errorf("%s needs an answer, but running non-interactively; %s", question, hint)
+}
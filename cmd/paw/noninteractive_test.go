@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestIsNonInteractive(t *testing.T) {
+	nonInteractive = false
+	t.Cleanup(func() { nonInteractive = false })
+
+	t.Setenv(constants.NonInteractiveEnv, "")
+	if isNonInteractive() {
+		t.Error("isNonInteractive() = true without flag or env")
+	}
+	t.Setenv(constants.NonInteractiveEnv, "1")
+	if !isNonInteractive() {
+		t.Error("isNonInteractive() = false with PAW_NONINTERACTIVE=1")
+	}
+	t.Setenv(constants.NonInteractiveEnv, "0")
+	nonInteractive = true
+	if !isNonInteractive() {
+		t.Error("isNonInteractive() = false with --non-interactive")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
@@ -94,3 +95,56 @@ func forceFilePickerWidth(tm tmux.Client, windowID, reason string) {
 
 	logPaneSnapshot(tm, windowID, reason+"-after")
 }
+
+// taskLayout returns the configured task window layout (defaults without config).
+func taskLayout(cfg *config.Config) config.TaskLayout {
+	if cfg == nil {
+		return config.TaskLayout{}
+	}
+	return cfg.TaskLayout
+}
+
+// splitTaskPanes adds the configured panes beside a task window's agent pane:
+// the user pane running userPaneCmd and the optional extra pane. The user pane
+// is focused afterwards when there is one.
+func splitTaskPanes(tm tmux.Client, windowID, workDir string, layout config.TaskLayout, userPaneCmd string) {
+	agentPane := windowID + ".0"
+	if !layout.NoUserPane {
+		size := ""
+		if layout.UserPaneSize > 0 {
+			size = strconv.Itoa(layout.UserPaneSize) + "%"
+		}
+		if _, err := tm.SplitWindowPane(tmux.SplitOpts{
+			Target:     agentPane,
+			Horizontal: layout.Horizontal(),
+			Size:       size,
+			StartDir:   workDir,
+			Command:    userPaneCmd,
+		}); err != nil {
+			logging.Warn("Failed to split window for user pane: %v", err)
+		} else {
+			logging.Trace("Window split for user pane: startDir=%s", workDir)
+		}
+	}
+
+	if layout.ExtraPane != "" {
+		// Share the user pane's side of the window, so the agent pane keeps its size
+		opts := tmux.SplitOpts{
+			Target:     agentPane,
+			Horizontal: layout.Horizontal(),
+			StartDir:   workDir,
+			Command:    shellCommand(layout.ExtraPane + "; exec " + shellQuote(getShell())),
+		}
+		if !layout.NoUserPane {
+			opts.Target = windowID + ".1"
+			opts.Horizontal = !layout.Horizontal()
+		}
+		if _, err := tm.SplitWindowPane(opts); err != nil {
+			logging.Warn("Failed to split window for extra pane: %v", err)
+		}
+	}
+
+	if !layout.NoUserPane && layout.ExtraPane != "" {
+		_ = tm.SelectPane(windowID + ".1")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var parkedCmd = &cobra.Command{
+	Use:   "parked",
+	Short: "List finished tasks kept by cleanup_policy",
+	Long: `List the finished tasks whose agent directory and worktree were parked in
+.paw/parked/ instead of removed (cleanup_policy: delayed or manual).
+
+A parked worktree is checked out at the task's last commit, so you can inspect
+what the agent actually ran after its branch was merged and deleted. With the
+delayed policy, parked tasks are removed after cleanup_retention_days.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		parked, err := mgr.ListParkedTasks()
+		if err != nil {
+			return err
+		}
+		if len(parked) == 0 {
+			fmt.Fprintln(os.Stderr, "No parked tasks")
+			return nil
+		}
+
+		for _, p := range parked {
+			fmt.Printf("%-30s %s  %s\n", p.Name, p.ParkedAt.Format("2006-01-02 15:04"), p.Dir)
+		}
+		if appCtx.Config != nil && appCtx.Config.CleanupPolicy == constants.CleanupDelayed {
+			fmt.Printf("\nRemoved after %d days (cleanup_retention_days)\n", appCtx.Config.CleanupRetentionDays)
+		}
+		return nil
+	},
+}
+
+var parkedPruneCmd = &cobra.Command{
+	Use:   "prune [task...]",
+	Short: "Remove parked tasks",
+	Long: `Remove the given parked tasks, or all of them, with their worktrees.
+A task name removes every parked copy of that task.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		parked, err := mgr.ListParkedTasks()
+		if err != nil {
+			return err
+		}
+
+		names := make(map[string]bool, len(args))
+		for _, name := range args {
+			names[name] = true
+		}
+		removed := 0
+		for _, p := range parked {
+			if len(names) > 0 && !names[p.Name] {
+				continue
+			}
+			if err := mgr.RemoveParkedTask(p); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove %s: %v\n", p.Dir, err)
+				continue
+			}
+			fmt.Printf("✓ Removed %s\n", p.Dir)
+			removed++
+		}
+		if removed == 0 {
+			fmt.Fprintln(os.Stderr, "No parked tasks removed")
+		}
+		return nil
+	},
+}
+
+func init() {
+	parkedCmd.AddCommand(parkedPruneCmd)
+}
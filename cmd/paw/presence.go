@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"os/user"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+const (
+	// presenceOptionKey is the session option holding the status bar list of
+	// users attached to a shared session.
+	presenceOptionKey = "@paw_presence"
+	// controlOptionKey holds the user who has control of the new-task window.
+	controlOptionKey = "@paw_control"
+	// controlRequestOptionKey holds the user waiting for control.
+	controlRequestOptionKey = "@paw_control_request"
+)
+
+// presenceStatus is the status bar part for shared sessions: who is attached
+// and who has control of the new-task window ("you" for the holder's own
+// clients, since status formats are expanded per client).
+const presenceStatus = "#{?" + presenceOptionKey + ",#{" + presenceOptionKey + "} │,}" +
+	"#{?" + controlOptionKey + ",#{?#{==:#{" + controlOptionKey + "},#{client_user}},✍️ you,✍️ #{" + controlOptionKey + "}} │,}"
+
+// sessionClient is a client attached to the session.
+type sessionClient struct {
+	User     string
+	TTY      string
+	Activity time.Time // Last key press
+}
+
+// listSessionClients returns the clients attached to the session. The user
+// is empty on tmux versions before 3.3.
+func listSessionClients(tm tmux.Client, sessionName string) []sessionClient {
+	out, err := tm.RunWithOutput("list-clients", "-t", sessionName, "-F", "#{client_user}\t#{client_tty}\t#{client_activity}")
+	if err != nil {
+		logging.Debug("listSessionClients: %v", err)
+		return nil
+	}
+	return parseSessionClients(out)
+}
+
+func parseSessionClients(out string) []sessionClient {
+	var clients []sessionClient
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		c := sessionClient{User: fields[0], TTY: fields[1]}
+		if secs, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			c.Activity = time.Unix(secs, 0)
+		}
+		clients = append(clients, c)
+	}
+	return clients
+}
+
+// distinctUsers returns the sorted names of the users behind the clients.
+func distinctUsers(clients []sessionClient) []string {
+	seen := make(map[string]bool)
+	var users []string
+	for _, c := range clients {
+		if c.User != "" && !seen[c.User] {
+			seen[c.User] = true
+			users = append(users, c.User)
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// presenceIndicator returns the status bar text listing the attached users,
+// or "" unless the session is shared by two or more users.
+func presenceIndicator(users []string) string {
+	if len(users) < 2 {
+		return ""
+	}
+	return "👥 " + strings.Join(users, ", ")
+}
+
+// refreshPresence updates the presence indicator. Control of the new-task
+// window is dropped once the session is no longer shared or its holder left.
+func refreshPresence(tm tmux.Client, sessionName string) {
+	users := distinctUsers(listSessionClients(tm, sessionName))
+	setOrUnsetOption(tm, presenceOptionKey, presenceIndicator(users))
+
+	holder := sessionOption(tm, sessionName, controlOptionKey)
+	if len(users) < 2 || (holder != "" && !containsString(users, holder)) {
+		setOrUnsetOption(tm, controlOptionKey, "")
+		setOrUnsetOption(tm, controlRequestOptionKey, "")
+	}
+}
+
+// controlAction is what ⌥C does for the user who pressed it.
+type controlAction int
+
+const (
+	controlKeep     controlAction = iota // The user already has control
+	controlGrant                         // Give control to the user
+	controlHandOver                      // The holder passes control to the waiting user
+	controlRequest                       // Ask the holder for control
+)
+
+// decideControl returns what ⌥C does for requester. Control is free when
+// nobody holds it, the holder left, or the holder has been idle for
+// ControlIdleTimeout.
+func decideControl(holder, requester, pending string, clients []sessionClient, now time.Time) controlAction {
+	if holder == requester {
+		if pending != "" && pending != requester {
+			return controlHandOver
+		}
+		return controlKeep
+	}
+	if holder == "" {
+		return controlGrant
+	}
+	var lastActivity time.Time
+	for _, c := range clients {
+		if c.User == holder && c.Activity.After(lastActivity) {
+			lastActivity = c.Activity
+		}
+	}
+	if lastActivity.IsZero() || now.Sub(lastActivity) >= constants.ControlIdleTimeout {
+		return controlGrant
+	}
+	return controlRequest
+}
+
+var refreshPresenceCmd = &cobra.Command{
+	Use:    "refresh-presence [session]",
+	Short:  "Update the status bar list of attached users",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		refreshPresence(newTmuxClient(args[0]), args[0])
+		return nil
+	},
+}
+
+var requestControlCmd = &cobra.Command{
+	Use:    "request-control [session] [user]",
+	Short:  "Take or request control of the new-task window in a shared session",
+	Args:   cobra.ExactArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName, requester := args[0], args[1]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Warn("requestControlCmd: getAppFromSession failed: %v", err)
+			return nil
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "request-control", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		if requester == "" {
+			_ = tm.DisplayMessage("Control needs tmux 3.3 or later (client_user)", constants.DisplayMsgStandard)
+			return nil
+		}
+		clients := listSessionClients(tm, sessionName)
+		if len(distinctUsers(clients)) < 2 {
+			_ = tm.DisplayMessage("Nobody else is attached", constants.DisplayMsgQuick)
+			return nil
+		}
+
+		holder := sessionOption(tm, sessionName, controlOptionKey)
+		pending := sessionOption(tm, sessionName, controlRequestOptionKey)
+		switch decideControl(holder, requester, pending, clients, time.Now()) {
+		case controlKeep:
+			_ = tm.DisplayMessage("✍️ You have control of the new-task window", constants.DisplayMsgQuick)
+		case controlGrant:
+			logging.Info("requestControlCmd: control to %s (was %q)", requester, holder)
+			setOrUnsetOption(tm, controlOptionKey, requester)
+			setOrUnsetOption(tm, controlRequestOptionKey, "")
+			messageUsers(tm, clients, []string{requester}, "✍️ You have control of the new-task window")
+			if holder != "" {
+				messageUsers(tm, clients, []string{holder}, fmt.Sprintf("✍️ %s took control of the new-task window", requester))
+			}
+		case controlHandOver:
+			logging.Info("requestControlCmd: %s handed control to %s", holder, pending)
+			setOrUnsetOption(tm, controlOptionKey, pending)
+			setOrUnsetOption(tm, controlRequestOptionKey, "")
+			messageUsers(tm, clients, []string{pending}, fmt.Sprintf("✍️ %s handed you control of the new-task window", holder))
+		case controlRequest:
+			logging.Info("requestControlCmd: %s requested control from %s", requester, holder)
+			setOrUnsetOption(tm, controlRequestOptionKey, requester)
+			messageUsers(tm, clients, []string{holder}, fmt.Sprintf("👋 %s requests control of the new-task window (⌥C to hand it over)", requester))
+			messageUsers(tm, clients, []string{requester}, fmt.Sprintf("👋 Asked %s for control of the new-task window", holder))
+		}
+		return nil
+	},
+}
+
+// messageUsers shows a message on every client of the given users.
+func messageUsers(tm tmux.Client, clients []sessionClient, users []string, message string) {
+	for _, c := range clients {
+		if containsString(users, c.User) {
+			if err := tm.Run("display-message", "-c", c.TTY, "-d", strconv.Itoa(constants.DisplayMsgStandard), message); err != nil {
+				logging.Debug("messageUsers: %v", err)
+			}
+		}
+	}
+}
+
+// inputHistoryService returns the input history of the user at the new-task
+// window, i.e. behind the client that pressed a key last (the one that just
+// submitted or opened the history): a separate history for other users of a
+// shared session, the project's history otherwise.
+func inputHistoryService(appCtx *app.App, tm tmux.Client) *service.InputHistoryService {
+	submitter := activeClientUser(listSessionClients(tm, appCtx.SessionName))
+	if submitter == "" || submitter == currentUserName() {
+		return service.NewInputHistoryService(appCtx.PawDir)
+	}
+	return service.NewUserInputHistoryService(appCtx.PawDir, submitter)
+}
+
+// activeClientUser returns the user of the client with the latest key press.
+func activeClientUser(clients []sessionClient) string {
+	var active sessionClient
+	for _, c := range clients {
+		if c.Activity.After(active.Activity) {
+			active = c
+		}
+	}
+	return active.User
+}
+
+func currentUserName() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+func sessionOption(tm tmux.Client, sessionName, key string) string {
+	value, err := tm.RunWithOutput("show-option", "-qv", "-t", sessionName, key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+func setOrUnsetOption(tm tmux.Client, key, value string) {
+	var err error
+	if value == "" {
+		err = tm.Run("set-option", "-u", key)
+	} else {
+		err = tm.SetOption(key, value, false)
+	}
+	if err != nil {
+		logging.Debug("setOrUnsetOption(%s): %v", key, err)
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSessionClients(t *testing.T) {
+	clients := parseSessionClients("alice\t/dev/ttys001\t1700000000\nbob\t/dev/ttys002\t1700000100\nalice\t/dev/ttys003\t1700000050\nbad line\n")
+
+	if len(clients) != 3 {
+		t.Fatalf("len(clients) = %d, want 3", len(clients))
+	}
+	if clients[1].User != "bob" || clients[1].TTY != "/dev/ttys002" || clients[1].Activity.Unix() != 1700000100 {
+		t.Errorf("clients[1] = %+v", clients[1])
+	}
+	if got := presenceIndicator(distinctUsers(clients)); got != "👥 alice, bob" {
+		t.Errorf("presenceIndicator() = %q, want %q", got, "👥 alice, bob")
+	}
+	if got := presenceIndicator(distinctUsers(clients[:1])); got != "" {
+		t.Errorf("presenceIndicator() for one user = %q, want empty", got)
+	}
+	if got := activeClientUser(clients); got != "bob" {
+		t.Errorf("activeClientUser() = %q, want bob (latest key press)", got)
+	}
+	if got := activeClientUser(nil); got != "" {
+		t.Errorf("activeClientUser(nil) = %q, want empty", got)
+	}
+}
+
+func TestDecideControl(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	active := []sessionClient{{User: "alice", Activity: now.Add(-10 * time.Second)}, {User: "bob", Activity: now}}
+	idle := []sessionClient{{User: "alice", Activity: now.Add(-10 * time.Minute)}, {User: "bob", Activity: now}}
+
+	tests := []struct {
+		name                       string
+		holder, requester, pending string
+		clients                    []sessionClient
+		want                       controlAction
+	}{
+		{"free", "", "bob", "", active, controlGrant},
+		{"already holder", "alice", "alice", "", active, controlKeep},
+		{"holder hands over", "alice", "alice", "bob", active, controlHandOver},
+		{"holder active", "alice", "bob", "", active, controlRequest},
+		{"holder idle", "alice", "bob", "", idle, controlGrant},
+		{"holder left", "carol", "bob", "", active, controlGrant},
+	}
+	for _, tt := range tests {
+		if got := decideControl(tt.holder, tt.requester, tt.pending, tt.clients, now); got != tt.want {
+			t.Errorf("%s: decideControl() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// sessionProcessRunning reports whether the process whose pid is stored in
+// the session option key (a session's background runner) is still alive.
+func sessionProcessRunning(tm tmux.Client, sessionName, key string) bool {
+	pid, err := strconv.Atoi(sessionOption(tm, sessionName, key))
+	return err == nil && processAlive(pid)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestProcessAlive(t *testing.T) {
+	if !processAlive(os.Getpid()) {
+		t.Error("processAlive(self) = false, want true")
+	}
+	if processAlive(0) || processAlive(-1) {
+		t.Error("processAlive(non-positive pid) = true, want false")
+	}
+
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot run true: %v", err)
+	}
+	if processAlive(cmd.Process.Pid) {
+		t.Errorf("processAlive(exited pid %d) = true, want false", cmd.Process.Pid)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// proxyEnvVars returns the proxy and CA variables passed through to agents and git.
+func proxyEnvVars() []string {
+	return append(slices.Clone(constants.ProxyEnvVars), constants.CAEnvVars...)
+}
+
+// proxyEnv returns the proxy and CA variables set in env (KEY=VALUE entries).
+func proxyEnv(env []string) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range env {
+		key, value, ok := strings.Cut(entry, "=")
+		if ok && value != "" && slices.Contains(proxyEnvVars(), key) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// proxyEnvExports returns export lines for the proxy and CA variables of this
+// process, so start-agent scripts keep them however tmux runs the script.
+// Proxy URLs with credentials are not written to the script; the agent gets
+// them from the tmux session environment (see syncSessionEnv).
+func proxyEnvExports() string {
+	values := proxyEnv(os.Environ())
+	var b strings.Builder
+	for _, key := range proxyEnvVars() {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if proxyHasCredentials(value) {
+			fmt.Fprintf(&b, "# %s has credentials and is inherited from the tmux session\n", key)
+			continue
+		}
+		fmt.Fprintf(&b, "export %s\n", shellEnv(key, value))
+	}
+	return b.String()
+}
+
+// proxyHasCredentials reports whether a proxy URL holds a user or password.
+func proxyHasCredentials(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.User != nil
+}
+
+// importProxyEnv sets the proxy and CA variables of the tmux session that this
+// process does not have. Commands run from tmux key bindings get the
+// environment the tmux server started with, not the shell that started PAW,
+// so without this git and Claude would bypass the proxy.
+func importProxyEnv(values map[string]string) {
+	for key, value := range values {
+		if os.Getenv(key) != "" {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			logging.Debug("importProxyEnv: failed to set %s: %v", key, err)
+		}
+	}
+}
+
+func envEntries(env map[string]string) []string {
+	entries := make([]string, 0, len(env))
+	for key, value := range env {
+		entries = append(entries, key+"="+value)
+	}
+	return entries
+}
+
+// checkProxy validates the proxy and custom CA settings of this shell.
+func checkProxy() checkResult {
+	return checkProxyEnv(proxyEnv(os.Environ()))
+}
+
+// checkProxyEnv validates proxy URLs and CA bundles, and warns when git and
+// Claude would trust different CAs.
+func checkProxyEnv(values map[string]string) checkResult {
+	result := checkResult{name: "proxy", required: false}
+	if len(values) == 0 {
+		result.ok = true
+		result.message = "no proxy or custom CA configured"
+		return result
+	}
+
+	var problems, settings []string
+	for _, key := range constants.ProxyEnvVars {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(key, "NO_PROXY") {
+			settings = append(settings, key+"="+value)
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("%s is not a URL (want scheme://host:port)", key))
+			continue
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			problems = append(problems, fmt.Sprintf("%s has unsupported scheme %q", key, u.Scheme))
+			continue
+		}
+		settings = append(settings, key+"="+u.Redacted())
+	}
+	for _, pair := range [][2]string{{"HTTPS_PROXY", "https_proxy"}, {"HTTP_PROXY", "http_proxy"}, {"NO_PROXY", "no_proxy"}} {
+		upper, lower := values[pair[0]], values[pair[1]]
+		if upper != "" && lower != "" && upper != lower {
+			problems = append(problems, fmt.Sprintf("%s and %s differ", pair[0], pair[1]))
+		}
+	}
+
+	for _, key := range constants.CAEnvVars {
+		path, ok := values[key]
+		if !ok {
+			continue
+		}
+		if err := checkCAPath(path); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		settings = append(settings, key+"="+path)
+	}
+	if values["NODE_EXTRA_CA_CERTS"] == "" {
+		for _, key := range []string{"GIT_SSL_CAINFO", "SSL_CERT_FILE"} {
+			if values[key] != "" {
+				problems = append(problems, fmt.Sprintf("%s is set but NODE_EXTRA_CA_CERTS is not, so Claude will not trust the custom CA", key))
+				break
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		result.ok = false
+		result.message = strings.Join(problems, "; ")
+		return result
+	}
+	result.ok = true
+	result.message = strings.Join(settings, ", ")
+	return result
+}
+
+// checkCAPath verifies a CA bundle holds at least one PEM certificate. A
+// directory (GIT_SSL_CAPATH) only has to exist.
+func checkCAPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s does not exist", path)
+	}
+	if info.IsDir() {
+		return nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the user's CA bundle setting
+	if err != nil {
+		return fmt.Errorf("cannot read %s", path)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("%s has no PEM certificate", path)
+		}
+		if block.Type == "CERTIFICATE" {
+			return nil
+		}
+	}
+}
+
+// sessionProxyDrift returns the proxy and CA variables of this shell that the
+// tmux session has with a different value or not at all.
+func sessionProxyDrift(shell, session map[string]string) []string {
+	var drift []string
+	for _, key := range proxyEnvVars() {
+		if value, ok := shell[key]; ok && session[key] != value {
+			drift = append(drift, key)
+		}
+	}
+	return drift
+}
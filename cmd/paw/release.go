@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var (
+	releaseBump   string
+	releaseDryRun bool
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release [version]",
+	Short: "Start a task that prepares a release PR",
+	Long: `Start a task that prepares a release PR.
+
+The task gathers the tasks merged into the main branch since the last tag,
+with their history summaries, drafts release notes into the changelog, bumps
+the version (in release_version_files, if set), and finishes by opening a PR.
+
+Without a version, the last tag is bumped: major for breaking changes, minor
+if a feature was merged, patch otherwise (override with --bump).
+
+Examples:
+  paw release                  # Next version inferred from merged tasks
+  paw release --bump minor
+  paw release v2.0.0
+  paw release --dry-run        # Print the release task without starting it`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		if !appCtx.IsWorktreeMode() {
+			return errors.New("paw release needs a git project in worktree mode (it finishes with a PR)")
+		}
+
+		plan, err := buildReleasePlan(appCtx, git.New(), args)
+		if err != nil {
+			return err
+		}
+		if releaseDryRun {
+			fmt.Print(plan.Prompt())
+			return nil
+		}
+
+		tm := newTmuxClient(appCtx.SessionName)
+		if !tm.HasSession(appCtx.SessionName) {
+			return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "release", "")
+		defer cleanup()
+
+		newTask, err := startReleaseTask(appCtx, plan)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🚀 Started %s: release %s with %d merged tasks\n", newTask.Name, plan.Version, len(plan.Items))
+		return nil
+	},
+}
+
+func init() {
+	releaseCmd.Flags().StringVar(&releaseBump, "bump", "", "Version bump: major, minor, or patch (default: inferred)")
+	releaseCmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "Print the release task without starting it")
+}
+
+// buildReleasePlan collects the tasks merged since the last tag and picks
+// the release version.
+func buildReleasePlan(appCtx *app.App, gitClient git.Client, args []string) (*service.ReleasePlan, error) {
+	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+	lastTag, err := gitClient.LatestTag(appCtx.ProjectDir, mainBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the last tag: %w", err)
+	}
+	merges, err := gitClient.TaskMerges(appCtx.ProjectDir, lastTag, mainBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged tasks: %w", err)
+	}
+	if len(merges) == 0 {
+		if lastTag == "" {
+			return nil, fmt.Errorf("no PAW tasks merged into %s yet", mainBranch)
+		}
+		return nil, fmt.Errorf("no PAW tasks merged into %s since %s", mainBranch, lastTag)
+	}
+
+	summaries := releaseSummaries(appCtx, merges)
+	items := make([]service.ReleaseItem, 0, len(merges))
+	for _, m := range merges {
+		items = append(items, service.ReleaseItem{
+			Entry:   service.ParseChangelogEntry(m.Message, m.Task, time.Now()),
+			Hash:    m.Hash,
+			Summary: summaries[m.Task],
+		})
+	}
+
+	plan := &service.ReleasePlan{LastTag: lastTag, Items: items}
+	if appCtx.Config != nil {
+		plan.VersionFiles = appCtx.Config.ReleaseVersionFiles
+		plan.Changelog = appCtx.Config.Changelog
+	}
+	if len(args) > 0 {
+		plan.Version = args[0]
+		return plan, nil
+	}
+	bump := releaseBump
+	if bump == "" {
+		bump = service.InferBump(items)
+	}
+	if plan.Version, err = service.NextVersion(lastTag, bump); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// releaseSummaries returns the history summaries of the merged tasks, the
+// latest entry winning for tasks finished more than once.
+func releaseSummaries(appCtx *app.App, merges []git.TaskMerge) map[string]string {
+	wanted := make(map[string]bool, len(merges))
+	for _, m := range merges {
+		wanted[m.Task] = true
+	}
+	summaries := make(map[string]string)
+
+	historyService := service.NewHistoryService(appCtx.GetHistoryDir())
+	files, err := historyService.ListHistoryFiles()
+	if err != nil {
+		logging.Warn("releaseSummaries: %v", err)
+		return summaries
+	}
+	for _, file := range files {
+		name := service.ExtractTaskName(file)
+		if !wanted[name] || service.IsCancelled(file) {
+			continue
+		}
+		entry, err := historyService.ReadEntry(file)
+		if err != nil {
+			logging.Debug("releaseSummaries: %v", err)
+			continue
+		}
+		if entry.Summary != "" {
+			summaries[name] = entry.Summary
+		}
+	}
+	return summaries
+}
+
+// startReleaseTask creates the release task and starts it. It finishes by
+// opening a PR whatever the project's on_complete.
+func startReleaseTask(appCtx *app.App, plan *service.ReleasePlan) (*task.Task, error) {
+	opts := &config.TaskOptions{BranchName: "release-" + plan.Version, OnComplete: constants.ActionPR, Labels: []string{"release"}}
+	newTask, err := spawnTask(appCtx, plan.Prompt(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start release task: %w", err)
+	}
+	logging.Log("Release task created: %s (version=%s, tasks=%d)", newTask.Name, plan.Version, len(plan.Items))
+	return newTask, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+var (
+	repairRelocate  bool
+	repairFrom      string
+	repairWindowMap bool
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair the workspace of the current project",
+	Long: `Repair the workspace of the current project.
+
+Use --relocate after moving or renaming the project (or its .paw directory).
+It finds the workspace of the project's old location, moves it to where the
+project's workspace now belongs, and fixes what points at the old location:
+  - git worktree links between the repository and task worktrees
+  - the origin and .claude symlinks in each task's agent directory
+  - the recorded project path of global workspaces
+
+Global workspaces are found by the project's old path. If the project was
+renamed, or several moved projects are found, pass the old path with --from.
+
+Use --window-map when task windows show the wrong task (e.g. after a crash).
+It rebuilds the map from window names to task names from the session's live
+windows and the tasks' tab-locks, dropping tasks that no longer exist.`,
+	RunE: runRepair,
+}
+
+func init() {
+	repairCmd.Flags().BoolVar(&repairRelocate, "relocate", false, "Fix the workspace after the project or .paw directory was moved")
+	repairCmd.Flags().StringVar(&repairFrom, "from", "", "Old project path (with --relocate)")
+	repairCmd.Flags().BoolVar(&repairWindowMap, "window-map", false, "Rebuild the window map from live tmux windows and tab-locks")
+}
+
+func runRepair(_ *cobra.Command, _ []string) error {
+	if !repairRelocate && !repairWindowMap {
+		return errors.New("nothing to repair; use --relocate or --window-map")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	gitClient := git.New()
+	isGitRepo := gitClient.IsGitRepo(cwd)
+	projectDir := cwd
+	if isGitRepo {
+		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
+			projectDir = repoRoot
+		}
+	}
+
+	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create app: %w", err)
+	}
+	if !repairRelocate {
+		if !application.IsInitialized() {
+			return errors.New("no PAW workspace for this project")
+		}
+		return rebuildWindowMap(application)
+	}
+	if newTmuxClient(application.SessionName).HasSession(application.SessionName) {
+		return fmt.Errorf("session %s is running; stop it first with 'paw kill'", application.SessionName)
+	}
+
+	if !application.IsInitialized() {
+		from, err := findRelocatedWorkspace(application.ProjectDir, repairFrom)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(application.PawDir), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+			return fmt.Errorf("failed to create workspace directory: %w", err)
+		}
+		if err := os.Rename(from, application.PawDir); err != nil {
+			return fmt.Errorf("failed to move workspace from %s to %s: %w", from, application.PawDir, err)
+		}
+		fmt.Printf("Moved workspace: %s -> %s\n", from, application.PawDir)
+		logging.Log("repair: moved workspace %s -> %s", from, application.PawDir)
+	}
+
+	repaired, err := relocateWorkspace(application, gitClient)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Repaired %d task(s) in %s\n", repaired, application.PawDir)
+	if repairWindowMap {
+		return rebuildWindowMap(application)
+	}
+	return nil
+}
+
+// rebuildWindowMap rebuilds the window map from the session's live windows
+// (if it is running) and the tasks' tab-locks.
+func rebuildWindowMap(application *app.App) error {
+	var windows []tmux.Window
+	tm := newTmuxClient(application.SessionName)
+	if tm.HasSession(application.SessionName) {
+		var err error
+		if windows, err = tm.ListWindows(); err != nil {
+			return fmt.Errorf("failed to list windows: %w", err)
+		}
+	}
+
+	mapping, err := service.RebuildWindowMap(application.PawDir, windows)
+	if err != nil {
+		return err
+	}
+	logging.Log("repair: rebuilt window map with %d entries", len(mapping))
+	fmt.Printf("Rebuilt window map: %d task(s)\n", len(mapping))
+	return nil
+}
+
+// findRelocatedWorkspace returns the global workspace of a project that was moved to projectDir.
+// Without from, it looks for workspaces whose project no longer exists and had the same name.
+func findRelocatedWorkspace(projectDir, from string) (string, error) {
+	if from != "" {
+		if abs, err := filepath.Abs(from); err == nil {
+			from = abs
+		}
+	}
+
+	var matches, orphans []string
+	for _, root := range []string{config.GlobalWorkspacesDir(), config.XDGStateWorkspacesDir()} {
+		if root == "" {
+			continue
+		}
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			wsPath := filepath.Join(root, entry.Name())
+			data, err := os.ReadFile(filepath.Join(wsPath, constants.ProjectPathFileName)) //nolint:gosec // G304: path is inside the PAW workspaces dir
+			if err != nil {
+				continue
+			}
+			oldProject := strings.TrimSpace(string(data))
+			if from != "" {
+				if oldProject == from {
+					matches = append(matches, wsPath)
+				}
+				continue
+			}
+			if _, err := os.Stat(oldProject); !os.IsNotExist(err) {
+				continue
+			}
+			orphans = append(orphans, oldProject)
+			if filepath.Base(oldProject) == filepath.Base(projectDir) {
+				matches = append(matches, wsPath)
+			}
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case from != "":
+		return "", fmt.Errorf("no workspace found for %s", from)
+	case len(matches) > 1:
+		return "", errors.New("several moved projects match; pass the old project path with --from")
+	case len(orphans) > 0:
+		return "", fmt.Errorf("no workspace found for this project; pass the old project path with --from (moved projects: %s)", strings.Join(orphans, ", "))
+	}
+	return "", errors.New("no workspace found for this project")
+}
+
+// relocateWorkspace fixes the paths stored in a workspace after the project or workspace moved.
+// Returns the number of tasks repaired.
+func relocateWorkspace(application *app.App, gitClient git.Client) (int, error) {
+	if application.IsGlobalWorkspace() {
+		projectPathFile := filepath.Join(application.PawDir, constants.ProjectPathFileName)
+		if err := fileutil.WriteFileAtomic(projectPathFile, []byte(application.ProjectDir), 0644); err != nil {
+			return 0, fmt.Errorf("failed to write project path file: %w", err)
+		}
+	}
+
+	cfg, err := config.Load(application.PawDir)
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	mgr := task.NewManager(application.AgentsDir, application.ProjectDir, application.PawDir, application.IsGitRepo, cfg)
+	tasks, err := mgr.ListTasks()
+	if err != nil {
+		return 0, err
+	}
+
+	if application.Config == nil {
+		application.Config = cfg
+	}
+	var worktrees []string
+	for _, t := range tasks {
+		setupTaskLinks(application, t)
+		if worktree := relocateTaskWorktree(t); worktree != "" {
+			worktrees = append(worktrees, worktree)
+		}
+	}
+
+	if application.IsGitRepo && len(worktrees) > 0 {
+		if err := gitClient.WorktreeRepair(application.ProjectDir, worktrees...); err != nil {
+			return 0, fmt.Errorf("failed to repair worktrees (run 'git worktree repair' in %s): %w", application.ProjectDir, err)
+		}
+		mgr.PruneWorktrees()
+	}
+
+	logging.Log("repair: relocated %d tasks in %s", len(tasks), application.PawDir)
+	return len(tasks), nil
+}
+
+// relocateTaskWorktree returns the task's worktree directory. Worktree directories
+// are named after the project, so after a rename the old one is moved into place.
+// Returns "" if the task has no worktree.
+func relocateTaskWorktree(t *task.Task) string {
+	worktree := t.GetWorktreeDir()
+	if _, err := os.Stat(worktree); err == nil {
+		return worktree
+	}
+
+	entries, err := os.ReadDir(t.AgentDir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		old := filepath.Join(t.AgentDir, entry.Name())
+		if !entry.IsDir() || old == worktree {
+			continue
+		}
+		if fi, err := os.Stat(filepath.Join(old, ".git")); err != nil || fi.IsDir() {
+			continue // Worktrees have a .git file pointing at the repository
+		}
+		if err := os.Rename(old, worktree); err != nil {
+			logging.Warn("repair: failed to rename worktree %s: %v", old, err)
+			return old
+		}
+		return worktree
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// runReviewerPass has reviewer_model review the task's diff before it is
+// merged, saving the review to the task's artifacts. A review made at the
+// same commit by the same model is reused. Returns false (keeping the task
+// open) when the reviewer requests changes on an automatic merge; merges the
+// user chose go ahead with the review printed. A failed review never blocks
+// the merge.
+func runReviewerPass(appCtx *app.App, targetTask *task.Task, windowID, workDir string, gitClient git.Client, tm tmux.Client) bool {
+	cfg := appCtx.Config
+	if cfg == nil || cfg.ReviewerModel == "" || targetTask.IsResearch() {
+		return true
+	}
+
+	head, _ := gitClient.GetHeadCommit(workDir)
+	artifactsDir := targetTask.GetArtifactsDir()
+	review, err := service.LoadCodeReview(artifactsDir)
+	if err != nil || review == nil || head == "" || review.Head != head || review.Model != cfg.ReviewerModel {
+		if review = requestReview(targetTask, workDir, cfg.ReviewerModel, head, gitClient); review == nil {
+			return true
+		}
+	} else {
+		fmt.Printf("  ○ Review by %s at this commit: %s\n", review.Model, review.Verdict)
+	}
+	printReview(review)
+	if review.Approved() {
+		return true
+	}
+
+	if !endTaskAuto {
+		fmt.Println("  ⚠️  Reviewer requested changes; merging as you asked")
+		return true
+	}
+
+	logging.Info("runReviewerPass: task=%s held for review: %s", targetTask.Name, review.Summary)
+	if err := targetTask.SetReviewReason(review.ReviewReason()); err != nil {
+		logging.Warn("runReviewerPass: failed to flag task for review: %v", err)
+	}
+	fmt.Println()
+	fmt.Println("  ✗ Reviewer requested changes; not merging automatically (⌃F to merge anyway)")
+	if err := renameWindowWithStatus(tm, windowID, windowNameForStatus(targetTask.Name, task.StatusDone), appCtx.PawDir, targetTask.Name, "end-task", task.StatusDone); err != nil {
+		logging.Warn("Failed to rename window: %v", err)
+	}
+	_ = notify.Send("Review required", fmt.Sprintf("🔍 %s was not merged automatically: %s", targetTask.Name, review.ReviewReason()))
+	notify.PlaySound(notify.SoundError)
+	if err := tm.DisplayMessage(fmt.Sprintf("🔍 Reviewer requested changes: %s", targetTask.Name), constants.DisplayMsgImportant); err != nil {
+		logging.Trace("Failed to display message: %v", err)
+	}
+	return false
+}
+
+// requestReview asks the reviewer model for a review of the task's diff and
+// saves it, or returns nil if the review failed.
+func requestReview(targetTask *task.Task, workDir, model, head string, gitClient git.Client) *service.CodeReview {
+	spinner := tui.NewSimpleSpinner("Reviewing with " + model)
+	spinner.Start()
+
+	base, err := gitClient.MergeBase(workDir, gitClient.GetMainBranch(workDir), "HEAD")
+	var diff string
+	if err == nil {
+		diff, err = gitClient.DiffFiles(workDir, base, nil)
+	}
+	var raw string
+	if err == nil {
+		raw, err = newClaudeClient().GenerateReview(model, targetTask.Content, diff)
+	}
+	var review *service.CodeReview
+	if err == nil {
+		review, err = service.ParseCodeReview(raw)
+	}
+	if err != nil {
+		spinner.Stop(false, "failed")
+		logging.Warn("requestReview: %v", err)
+		fmt.Printf("  ⚠️  Review failed: %v\n", err)
+		return nil
+	}
+
+	review.Model = model
+	review.Head = head
+	review.Time = time.Now()
+	if err := service.SaveCodeReview(targetTask.GetArtifactsDir(), targetTask.Name, review); err != nil {
+		logging.Warn("requestReview: failed to save the review: %v", err)
+	}
+	spinner.Stop(review.Approved(), review.Verdict)
+	logging.Log("requestReview: task=%s model=%s verdict=%s comments=%d", targetTask.Name, model, review.Verdict, len(review.Comments))
+	return review
+}
+
+// printReview prints a review in the end-task pane.
+func printReview(review *service.CodeReview) {
+	if review.Summary != "" {
+		fmt.Printf("    %s\n", review.Summary)
+	}
+	for i, c := range review.Comments {
+		if i == maxShownViolations {
+			fmt.Printf("    … and %d more (see artifacts/%s)\n", len(review.Comments)-maxShownViolations, service.CodeReviewReportFile)
+			break
+		}
+		fmt.Printf("    %s\n", c)
+	}
+}
+
+// errReviewRejected is end-task's exit error for an automatic merge held
+// after the reviewer requested changes.
+func errReviewRejected(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.ReviewRejected, "reviewer requested changes to %s; task kept for review", t.Name), t.Name)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// runSelfEval has Claude score the task's work against its description when
+// self_eval is set, saving the rubric to the task's artifacts. A score below
+// self_eval_min_score flags the task for review; for merge actions it also
+// returns false (keeping the task open) the first time the task is evaluated
+// at its current commit, so finishing again merges it. A failed evaluation
+// never blocks the finish.
+func runSelfEval(appCtx *app.App, targetTask *task.Task, windowID, workDir, action string, gitClient git.Client, tm tmux.Client) bool {
+	cfg := appCtx.Config
+	if cfg == nil || !cfg.SelfEval || targetTask.IsResearch() {
+		return true
+	}
+	switch action {
+	case constants.ActionMerge, constants.ActionMergePush, constants.ActionPR, constants.ActionCreateMain:
+	default:
+		return true
+	}
+
+	head, _ := gitClient.GetHeadCommit(workDir)
+	artifactsDir := targetTask.GetArtifactsDir()
+	if prev, err := service.LoadSelfEval(artifactsDir); err == nil && prev != nil && head != "" && prev.Head == head {
+		// Already evaluated, and surfaced, at this commit
+		fmt.Printf("  ○ Self-evaluation: %d/5 at this commit (see artifacts/%s)\n", prev.Score, service.SelfEvalReportFile)
+		return true
+	}
+
+	spinner := tui.NewSimpleSpinner("Self-evaluating")
+	spinner.Start()
+
+	var diff string
+	if base, err := gitClient.MergeBase(workDir, gitClient.GetMainBranch(workDir), "HEAD"); err != nil {
+		logging.Warn("runSelfEval: failed to find the merge base: %v", err)
+	} else if diff, err = gitClient.DiffFiles(workDir, base, nil); err != nil {
+		logging.Warn("runSelfEval: failed to read diff: %v", err)
+	}
+	var capture string
+	if paneCaptureFile != "" {
+		// The capture keeps colors when pane_capture_format is ansi
+		data, _ := os.ReadFile(paneCaptureFile)
+		capture = ansi.Strip(string(data))
+	}
+
+	raw, err := newClaudeClient().GenerateSelfEval(targetTask.Content, diff, capture)
+	var eval *service.SelfEval
+	if err == nil {
+		eval, err = service.ParseSelfEval(raw)
+	}
+	if err != nil {
+		spinner.Stop(false, "failed")
+		logging.Warn("runSelfEval: %v", err)
+		fmt.Printf("  ⚠️  Self-evaluation failed: %v\n", err)
+		return true
+	}
+	eval.Head = head
+	eval.Time = time.Now()
+	if err := service.SaveSelfEval(artifactsDir, targetTask.Name, eval); err != nil {
+		logging.Warn("runSelfEval: failed to save the rubric: %v", err)
+	}
+
+	low := eval.LowConfidence(cfg.SelfEvalMinScore)
+	spinner.Stop(!low, fmt.Sprintf("%d/5, requirements %s", eval.Score, eval.Requirements))
+	logging.Log("runSelfEval: task=%s score=%d requirements=%s", targetTask.Name, eval.Score, eval.Requirements)
+	printSelfEval(eval)
+	if !low {
+		return true
+	}
+
+	if err := targetTask.SetReviewReason(eval.ReviewReason()); err != nil {
+		logging.Warn("runSelfEval: failed to flag task for review: %v", err)
+	}
+	_ = notify.Send("Low-confidence completion", fmt.Sprintf("🤔 %s: %s", targetTask.Name, eval.ReviewReason()))
+	if !config.IsMergeAction(action) {
+		return true
+	}
+
+	fmt.Println()
+	fmt.Println("  ✗ Low self-evaluation score; not merging (finish again to merge anyway)")
+	if err := renameWindowWithStatus(tm, windowID, windowNameForStatus(targetTask.Name, task.StatusDone), appCtx.PawDir, targetTask.Name, "end-task", task.StatusDone); err != nil {
+		logging.Warn("Failed to rename window: %v", err)
+	}
+	notify.PlaySound(notify.SoundError)
+	if err := tm.DisplayMessage(fmt.Sprintf("🤔 Low self-evaluation score (%d/5): %s", eval.Score, targetTask.Name), constants.DisplayMsgImportant); err != nil {
+		logging.Trace("Failed to display message: %v", err)
+	}
+	return false
+}
+
+// printSelfEval prints a rubric in the end-task pane.
+func printSelfEval(eval *service.SelfEval) {
+	if eval.Summary != "" {
+		fmt.Printf("    %s\n", eval.Summary)
+	}
+	for _, risk := range eval.Risks {
+		fmt.Printf("    risk: %s\n", risk)
+	}
+	for _, item := range eval.Untested {
+		fmt.Printf("    untested: %s\n", item)
+	}
+}
+
+// errLowSelfEval is end-task's exit error for a merge held after a
+// low-confidence self-evaluation.
+func errLowSelfEval(t *task.Task) error {
+	return exitcode.WithTask(exitcode.Errorf(exitcode.LowSelfEval, "self-evaluation scored %s low; task kept for review", t.Name), t.Name)
+}
@@ -8,9 +8,13 @@ import (
 	"strings"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/embed"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/lock"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 	"golang.org/x/term"
@@ -21,7 +25,8 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 	logging.Debug("Starting new tmux session...")
 
 	// Create/update bin symlink for hook execution
-	if err := updateBinSymlink(appCtx.PawDir); err != nil {
+	binTimer := logging.StartTimer("bin symlink")
+	if err := updateBinSymlink(appCtx.PawDir, appCtx.Config); err != nil {
 		logging.Warn("Failed to create bin symlink: %v", err)
 	}
 
@@ -29,8 +34,10 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 	if err := saveVersion(appCtx.PawDir); err != nil {
 		logging.Warn("Failed to save version: %v", err)
 	}
+	binTimer.Stop()
 
 	// Clean up merged tasks before starting new session
+	mergedTimer := logging.StartTimer("merged task scan")
 	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 
 	// Prune stale worktree entries first to prevent git errors
@@ -45,11 +52,13 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 			fmt.Printf("✅ Cleaned up merged task: %s\n", t.Name)
 		}
 	}
+	mergedTimer.Stop()
 
 	// Get paw binary path for initial command
 	pawBin := getPawBin()
 
 	// Create session with a shell (not the new-task command directly)
+	tmuxTimer := logging.StartTimer("tmux setup")
 	width, height, ok := getTerminalSize()
 	if err := tm.NewSession(tmux.SessionOpts{
 		Name:       appCtx.SessionName,
@@ -68,6 +77,7 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 
 	// Setup tmux configuration
 	setupTmuxConfig(appCtx, tm)
+	tmuxTimer.Stop()
 
 	// Setup git repo marker if applicable
 	if appCtx.IsGitRepo {
@@ -76,6 +86,7 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 	}
 
 	// Write embedded claude files to .paw/.claude/
+	embedTimer := logging.StartTimer("embed writes")
 	claudeDir := filepath.Join(appCtx.PawDir, constants.ClaudeLink)
 	if err := embed.WriteClaudeFiles(claudeDir); err != nil {
 		logging.Warn("Failed to write claude files: %v", err)
@@ -88,14 +99,28 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 
 	// Update .gitignore (only if using local workspace)
 	if appCtx.IsGitRepo && !appCtx.IsGlobalWorkspace() {
-		updateGitignore(appCtx.ProjectDir)
+		updateGitignore(appCtx.ProjectDir, appCtx.Config.GitignoreManagement)
 	}
+	embedTimer.Stop()
+
+	// Refresh the cached repo map injected into task prompts
+	repoMapTimer := logging.StartTimer("repo map")
+	if _, err := service.EnsureRepoMap(appCtx.PawDir, appCtx.ProjectDir); err != nil {
+		logging.Warn("Failed to update repo map: %v", err)
+	}
+	repoMapTimer.Stop()
 
 	// Reopen incomplete tasks (tasks with worktree but no window)
+	incompleteTimer := logging.StartTimer("incomplete task scan")
 	mgr.SetTmuxClient(tm)
+	finishInterruptedCleanups(appCtx, mgr)
 	incomplete, err := mgr.FindIncompleteTasks(appCtx.SessionName)
 	if err == nil && len(incomplete) > 0 {
 		logging.Log("Found %d incomplete tasks to reopen", len(incomplete))
+		// Arrange the reopened windows like the saved layout once they exist
+		if startLayoutRestore(appCtx, tm, pawBin) {
+			logging.Log("Restoring saved session layout")
+		}
 		for _, t := range incomplete {
 			logging.Log("Reopening incomplete task: %s", t.Name)
 			_ = t.RemoveTabLock()
@@ -113,8 +138,16 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 			}
 		}
 	}
+	incompleteTimer.Stop()
+
+	// Start scheduled templates, the editor endpoint, and an unfinished
+	// autopilot run while the session runs
+	startTemplateScheduler(appCtx, tm, pawBin)
+	startEditorServer(appCtx, tm, pawBin)
+	startAutopilotRunner(appCtx, tm, pawBin)
 
 	// Wait for shell to be ready before sending keys
+	paneTimer := logging.StartTimer("main window setup")
 	paneTarget := appCtx.SessionName + ":" + constants.NewWindowName + ".0"
 	if err := tm.WaitForPane(paneTarget, constants.PaneWaitTimeout, 1); err != nil {
 		logging.Warn("WaitForPane timed out, continuing anyway: %v", err)
@@ -134,8 +167,11 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 	newTaskCmd := buildNewTaskCommand(appCtx, pawBin, appCtx.SessionName)
 	_ = tm.SendKeysLiteral(appCtx.SessionName+":"+constants.NewWindowName, newTaskCmd)
 	_ = tm.SendKeys(appCtx.SessionName+":"+constants.NewWindowName, "Enter")
+	paneTimer.Stop()
 
 	// Attach to session
+	printStartupTrace()
+	defer endCooperativeSession(tm, appCtx.SessionName)
 	if err := tm.AttachSession(appCtx.SessionName); err != nil {
 		return fmt.Errorf("failed to attach to newly created session %q: %w (workspace: %s)", appCtx.SessionName, err, appCtx.PawDir)
 	}
@@ -160,10 +196,11 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 	syncSessionEnv(tm, appCtx)
 
 	// Check if PAW version has changed and update symlink
+	versionTimer := logging.StartTimer("version check")
 	versionChanged := checkVersionChanged(appCtx.PawDir)
 	if versionChanged {
 		logging.Log("PAW version changed, updating bin symlink...")
-		if err := updateBinSymlink(appCtx.PawDir); err != nil {
+		if err := updateBinSymlink(appCtx.PawDir, appCtx.Config); err != nil {
 			logging.Warn("Failed to update bin symlink: %v", err)
 		} else {
 			logging.Log("Bin symlink updated to current version")
@@ -182,6 +219,7 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 			logging.Warn("Failed to respawn main window: %v", err)
 		}
 	}
+	versionTimer.Stop()
 
 	// Ensure .claude directory exists with settings.local.json (for stop-hook support)
 	// This is critical for task status updates - without it, tasks stay "working" forever.
@@ -190,6 +228,7 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 	// - WriteClaudeFiles failed silently during initial setup
 	// - Workspace is stored in the global workspace location
 	// Also refresh .claude files on version change to pick up updated CLAUDE.md templates.
+	embedTimer := logging.StartTimer("embed writes")
 	claudeDir := filepath.Join(appCtx.PawDir, constants.ClaudeLink)
 	claudeDirMissing := false
 	if _, err := os.Stat(claudeDir); os.IsNotExist(err) {
@@ -217,8 +256,10 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 			logging.Log("PAW help file refreshed successfully")
 		}
 	}
+	embedTimer.Stop()
 
 	// Run cleanup and recovery before attaching
+	mergedTimer := logging.StartTimer("merged task scan")
 	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 	mgr.SetTmuxClient(tm)
 
@@ -261,8 +302,11 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 			fmt.Printf("✅ Cleaned up merged task: %s\n", t.Name)
 		}
 	}
+	finishInterruptedCleanups(appCtx, mgr)
+	mergedTimer.Stop()
 
 	// Clean up orphaned windows (windows without agent directory)
+	recoveryTimer := logging.StartTimer("recovery scan")
 	orphanedWindows, err := mgr.FindOrphanedWindows()
 	if err == nil && len(orphanedWindows) > 0 {
 		logging.Log("Found %d orphaned windows to close", len(orphanedWindows))
@@ -311,10 +355,12 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 			}
 		}
 	}
+	recoveryTimer.Stop()
 
 	logging.Debug("Attaching to session: %s", appCtx.SessionName)
 
 	// Re-apply tmux config to ensure terminal title is set
+	tmuxTimer := logging.StartTimer("tmux setup")
 	reapplyTmuxConfig(appCtx, tm)
 
 	// Detect terminal theme and apply theme-aware tmux colors.
@@ -338,8 +384,9 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 
 	// Also set terminal title option on re-attach
 	// Use DisplayName for user-friendly display (e.g., "repo/subdir" format)
-	_ = tm.SetOption("set-titles", "on", true)
-	_ = tm.SetOption("set-titles-string", "[paw] "+appCtx.GetDisplayName(), true)
+	_ = tm.SetSessionOption("set-titles", "on")
+	_ = tm.SetSessionOption("set-titles-string", "[paw] "+appCtx.GetDisplayName())
+	tmuxTimer.Stop()
 
 	// Verify session still exists before attaching
 	// (cleanup operations might have killed all windows, destroying the session)
@@ -348,7 +395,15 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 		return startNewSession(appCtx, tm)
 	}
 
+	// Start scheduled templates, the editor endpoint, and an unfinished
+	// autopilot run while the session runs
+	startTemplateScheduler(appCtx, tm, getPawBin())
+	startEditorServer(appCtx, tm, getPawBin())
+	startAutopilotRunner(appCtx, tm, getPawBin())
+
 	// Attach to session
+	printStartupTrace()
+	defer endCooperativeSession(tm, appCtx.SessionName)
 	if err := tm.AttachSession(appCtx.SessionName); err != nil {
 		return fmt.Errorf("failed to attach to session %q: %w (workspace: %s)", appCtx.SessionName, err, appCtx.PawDir)
 	}
@@ -450,9 +505,45 @@ func respawnMainWindow(appCtx *app.App, tm tmux.Client) error {
 	return nil
 }
 
+// finishInterruptedCleanups resumes the cleanup of tasks end-task finished
+// but did not clean up, e.g. because PAW was killed halfway. Their windows
+// are closed by the orphaned window scan.
+func finishInterruptedCleanups(appCtx *app.App, mgr *task.Manager) {
+	tasks, err := mgr.ListTasks()
+	if err != nil {
+		logging.Warn("Failed to list tasks: %v", err)
+		return
+	}
+	for _, t := range tasks {
+		if !t.IsCompleted() {
+			continue
+		}
+		endLock, err := lock.TryAcquire(filepath.Join(t.AgentDir, constants.EndTaskLockFileName), "end-task", lock.Options{TTL: constants.EndTaskLockTTL})
+		if err != nil {
+			logging.Debug("Skipping cleanup of completed task %s: %v", t.Name, err)
+			continue
+		}
+		completion, err := t.LoadCompletion()
+		if err != nil {
+			logging.Warn("Failed to read completion marker of %s: %v", t.Name, err)
+			_ = endLock.Release()
+			continue
+		}
+		logging.Log("Finishing interrupted cleanup of completed task: %s", t.Name)
+		syncTaskLinks(appCtx, t)
+		if err := mgr.FinishCompletion(t, completion); err != nil {
+			logging.Warn("Failed to clean up completed task %s: %v", t.Name, err)
+		} else {
+			fmt.Printf("✅ Cleaned up completed task: %s\n", t.Name)
+		}
+		_ = endLock.Release()
+	}
+}
+
 // updateBinSymlink creates or updates the .paw/bin symlink to point to the current paw binary.
 // Uses atomic rename to prevent race conditions (TOCTOU vulnerability).
-func updateBinSymlink(pawDir string) error {
+// With link_mode: copy, .paw/bin is a copy of the binary instead.
+func updateBinSymlink(pawDir string, cfg *config.Config) error {
 	// Get current executable path
 	exe, err := os.Executable()
 	if err != nil {
@@ -467,6 +558,18 @@ func updateBinSymlink(pawDir string) error {
 
 	symlink := filepath.Join(pawDir, constants.BinSymlinkName)
 
+	if cfg != nil && cfg.LinkMode == constants.LinkModeCopy {
+		exeInfo, err := os.Stat(exe)
+		if err != nil {
+			return fmt.Errorf("failed to stat executable: %w", err)
+		}
+		if info, err := os.Lstat(symlink); err == nil && info.Mode().IsRegular() &&
+			info.Size() == exeInfo.Size() && info.ModTime().Equal(exeInfo.ModTime()) {
+			return nil
+		}
+		return fileutil.CopyFile(exe, symlink)
+	}
+
 	// Check if symlink exists and points to the same binary
 	if target, err := os.Readlink(symlink); err == nil {
 		if target == exe {
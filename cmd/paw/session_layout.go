@@ -0,0 +1,282 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// layoutRestoringKey is set while reopened task windows are being arranged,
+// so the partial layout isn't saved over the one being restored.
+const layoutRestoringKey = "@paw_restoring_layout"
+
+// layoutSaveHooks are the tmux events that change the session layout.
+var layoutSaveHooks = []string{
+	"session-window-changed",
+	"window-linked",
+	"window-unlinked",
+	"after-split-window",
+	"after-kill-pane",
+	"pane-exited",
+	"after-swap-window",
+	"after-select-pane",
+	"after-resize-pane",
+}
+
+var saveLayoutCmd = &cobra.Command{
+	Use:    "save-layout [session]",
+	Short:  "Save window order, active window, and shell panes",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		saveSessionLayout(newTmuxClient(sessionName), appCtx)
+		return nil
+	},
+}
+
+var restoreLayoutCmd = &cobra.Command{
+	Use:    "restore-layout [session]",
+	Short:  "Arrange reopened task windows like the saved layout",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "restore-layout", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		defer func() { _ = tm.Run("set-option", "-gu", layoutRestoringKey) }()
+
+		layout, err := service.LoadSessionLayout(appCtx.PawDir)
+		if err != nil {
+			logging.Warn("restore-layout: failed to load layout: %v", err)
+			return nil
+		}
+		restoreSessionLayout(tm, appCtx, layout)
+		return nil
+	},
+}
+
+// applyLayoutHooks saves the session layout whenever windows or panes change.
+func applyLayoutHooks(tm tmux.Client, sessionName string) {
+	saveCmd := "run-shell -b " + shellQuote(shellQuote(getPawBin())+" internal save-layout '#{session_name}'")
+	scope := hookScope(sessionName)
+	for _, hook := range layoutSaveHooks {
+		if err := tm.Run(append(append([]string{"set-hook"}, scope...), hook, saveCmd)...); err != nil {
+			logging.Debug("applyLayoutHooks: %s: %v", hook, err)
+		}
+	}
+}
+
+// saveSessionLayout records the session's task windows unless a restore is
+// still arranging them.
+func saveSessionLayout(tm tmux.Client, appCtx *app.App) {
+	if restoring, _ := tm.GetOption(layoutRestoringKey); restoring != "" {
+		return
+	}
+	output, err := tm.RunWithOutput("list-panes", "-s", "-t", appCtx.SessionName, "-F", service.SessionLayoutPaneFormat)
+	if err != nil {
+		// Session is going away; keep the last saved layout
+		logging.Debug("saveSessionLayout: list-panes failed: %v", err)
+		return
+	}
+	layout := service.ParseSessionLayout(output, service.BuildTokenMap(appCtx.PawDir))
+	if err := service.SaveSessionLayout(appCtx.PawDir, layout); err != nil {
+		logging.Debug("saveSessionLayout: %v", err)
+	}
+}
+
+// startLayoutRestore arranges the reopened task windows in the background once
+// they exist. It returns false if there is no saved layout.
+func startLayoutRestore(appCtx *app.App, tm tmux.Client, pawBin string) bool {
+	layout, err := service.LoadSessionLayout(appCtx.PawDir)
+	if err != nil || len(layout.Windows) == 0 {
+		return false
+	}
+	_ = tm.SetOption(layoutRestoringKey, "1", true)
+
+	restoreCmd := exec.Command(pawBin, "internal", "restore-layout", appCtx.SessionName) //nolint:gosec // G204: pawBin is from getPawBin()
+	restoreCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := restoreCmd.Start(); err != nil {
+		logging.Warn("Failed to start layout restore: %v", err)
+		_ = tm.Run("set-option", "-gu", layoutRestoringKey)
+		return false
+	}
+	return true
+}
+
+// restoreSessionLayout waits for the saved tasks' windows, then restores their
+// order, shell panes, and the active window.
+func restoreSessionLayout(tm tmux.Client, appCtx *app.App, layout service.SessionLayout) {
+	var expected []string
+	for _, w := range layout.Windows {
+		if _, err := os.Stat(appCtx.GetAgentDir(w.Task)); err == nil {
+			expected = append(expected, w.Task)
+		}
+	}
+
+	tokenMap := service.BuildTokenMap(appCtx.PawDir)
+	var windowIDs map[string]string // task name -> window ID
+	deadline := time.Now().Add(constants.LayoutRestoreTimeout)
+	for {
+		windowIDs = taskWindowIDs(tm, tokenMap)
+		if layoutWindowsReady(tm, appCtx.SessionName, taskLayout(appCtx.Config).PaneCount(), expected, windowIDs) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	order := make([]string, 0, len(expected))
+	for _, name := range expected {
+		if id, ok := windowIDs[name]; ok {
+			order = append(order, id)
+		}
+	}
+	reorderTaskWindows(tm, appCtx.SessionName, order)
+
+	for _, name := range expected {
+		if id, ok := windowIDs[name]; ok {
+			restoreShellPanes(tm, id, layout.Task(name))
+		}
+	}
+
+	if id, ok := windowIDs[layout.ActiveTask]; ok {
+		_ = tm.SelectWindow(id)
+	}
+	logging.Log("restore-layout: restored %d of %d saved windows", len(order), len(layout.Windows))
+}
+
+// taskWindowIDs maps task names to the IDs of their windows.
+func taskWindowIDs(tm tmux.Client, tokenMap map[string]string) map[string]string {
+	ids := map[string]string{}
+	windows, err := tm.ListWindows()
+	if err != nil {
+		return ids
+	}
+	for _, w := range windows {
+		if token, ok := constants.ExtractTaskName(w.Name); ok {
+			name := token
+			if full, ok := tokenMap[token]; ok {
+				name = full
+			}
+			ids[name] = w.ID
+		}
+	}
+	return ids
+}
+
+// layoutWindowsReady reports whether every expected task has a window with
+// its configured panes split off.
+func layoutWindowsReady(tm tmux.Client, sessionName string, paneCount int, expected []string, windowIDs map[string]string) bool {
+	output, err := tm.RunWithOutput("list-panes", "-s", "-t", sessionName, "-F", "#{window_id}")
+	if err != nil {
+		return false
+	}
+	panes := map[string]int{}
+	for _, id := range strings.Fields(output) {
+		panes[id]++
+	}
+	for _, name := range expected {
+		id, ok := windowIDs[name]
+		if !ok || panes[id] < paneCount {
+			return false
+		}
+	}
+	return true
+}
+
+// reorderTaskWindows moves the given windows into the slots held by task
+// windows, in order. Other windows (like the main window) keep their slots.
+func reorderTaskWindows(tm tmux.Client, sessionName string, order []string) {
+	for pos, id := range order {
+		windows, err := tm.ListWindows()
+		if err != nil {
+			return
+		}
+		var slots []int
+		current := -1
+		for _, w := range windows {
+			if _, ok := constants.ExtractTaskName(w.Name); ok {
+				slots = append(slots, w.Index)
+			}
+			if w.ID == id {
+				current = w.Index
+			}
+		}
+		sort.Ints(slots)
+		if pos >= len(slots) || current < 0 || current == slots[pos] {
+			continue
+		}
+		if err := tm.Run("swap-window", "-d", "-s", id, "-t", sessionName+":"+strconv.Itoa(slots[pos])); err != nil {
+			logging.Debug("reorderTaskWindows: swap %s failed: %v", id, err)
+		}
+	}
+}
+
+// restoreShellPanes recreates the saved shell panes of a task window, then
+// applies its saved pane layout, focus, and zoom.
+func restoreShellPanes(tm tmux.Client, windowID string, saved *service.LayoutWindow) {
+	if saved == nil {
+		return
+	}
+	output, err := tm.RunWithOutput("list-panes", "-t", windowID, "-F", "#{pane_index}")
+	if err != nil {
+		return
+	}
+	shells := len(strings.Fields(output)) - 1
+
+	switch {
+	case len(saved.ShellDirs) < shells:
+		// Panes were closed before the restart
+		for i := shells; i > len(saved.ShellDirs); i-- {
+			_ = tm.KillPane(windowID + "." + strconv.Itoa(i))
+		}
+	case len(saved.ShellDirs) > shells:
+		userPaneCmd := shellCommand("exec " + shellQuote(getShell()))
+		for _, dir := range saved.ShellDirs[max(shells, 0):] {
+			if _, err := os.Stat(dir); err != nil {
+				dir = ""
+			}
+			if err := tm.SplitWindow(windowID, true, dir, userPaneCmd); err != nil {
+				logging.Debug("restoreShellPanes: split %s failed: %v", windowID, err)
+			}
+		}
+	}
+
+	if saved.Layout != "" {
+		if err := tm.Run("select-layout", "-t", windowID, saved.Layout); err != nil {
+			logging.Debug("restoreShellPanes: select-layout %s failed: %v", windowID, err)
+		}
+	}
+	activePane := windowID + "." + strconv.Itoa(saved.ActivePane)
+	_ = tm.SelectPane(activePane)
+	if saved.Zoomed {
+		_ = tm.Run("resize-pane", "-Z", "-t", activePane)
+	}
+}
@@ -8,12 +8,10 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/task"
-	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 // runCleanAll removes all PAW resources across all projects
@@ -27,11 +25,17 @@ func runCleanAll(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	workspacesDir := filepath.Join(homeDir, constants.GlobalDataDir, constants.GlobalWorkspacesDir)
+	workspacesDirs := []string{filepath.Join(homeDir, constants.GlobalDataDir, constants.GlobalWorkspacesDir)}
+	if stateDir := config.XDGStateWorkspacesDir(); stateDir != "" {
+		workspacesDirs = append(workspacesDirs, stateDir)
+	}
 	var workspaces []string
 
-	entries, err := os.ReadDir(workspacesDir)
-	if err == nil {
+	for _, workspacesDir := range workspacesDirs {
+		entries, err := os.ReadDir(workspacesDir)
+		if err != nil {
+			continue
+		}
 		for _, entry := range entries {
 			if entry.IsDir() {
 				workspaces = append(workspaces, filepath.Join(workspacesDir, entry.Name()))
@@ -60,9 +64,14 @@ func runCleanAll(_ *cobra.Command, _ []string) error {
 	fmt.Println()
 
 	// Confirm before cleaning
-	if !confirmPrompt("Clean all PAW resources? [y/N]: ") {
-		fmt.Println("Cancelled.")
-		return nil
+	if !cleanAllYes {
+		if isNonInteractive() {
+			return errNeedsAnswer("Cleaning all PAW resources", "run with --yes to confirm")
+		}
+		if !confirmPrompt("Clean all PAW resources? [y/N]: ") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
 	}
 
 	fmt.Println()
@@ -124,71 +133,6 @@ func cleanWorkspaceGitResources(wsPath, projectDir string) {
 	}
 }
 
-// runClean removes all PAW resources
-func runClean(_ *cobra.Command, _ []string) error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
-	// If in git repo, use repo root as project directory
-	gitClient := git.New()
-	isGitRepo := gitClient.IsGitRepo(cwd)
-	projectDir := cwd
-	if isGitRepo {
-		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
-			projectDir = repoRoot
-		}
-	}
-
-	// Use NewWithGitInfo to correctly resolve global workspace path
-	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
-	if err != nil {
-		return err
-	}
-
-	// Set subdirectory context for correct session name (e.g., finops-treemap)
-	if isGitRepo {
-		application.SetSubdirectoryContext(cwd, projectDir)
-	}
-
-	if err := application.LoadConfig(); err != nil {
-		// Config might not exist, continue anyway
-		application.Config = config.DefaultConfig()
-	}
-
-	tm := tmux.New(application.SessionName)
-
-	fmt.Println("Cleaning up PAW resources...")
-
-	// Kill tmux session if exists
-	if tm.HasSession(application.SessionName) {
-		fmt.Println("Killing tmux session...")
-		_ = tm.KillSession(application.SessionName)
-	}
-
-	// Clean up tasks
-	if application.IsGitRepo {
-		mgr := task.NewManager(application.AgentsDir, application.ProjectDir, application.PawDir, application.IsGitRepo, application.Config)
-
-		// Prune stale worktree entries first to prevent git errors
-		mgr.PruneWorktrees()
-
-		tasks, _ := mgr.ListTasks()
-		for _, t := range tasks {
-			fmt.Printf("Cleaning up task: %s\n", t.Name)
-			_ = mgr.CleanupTask(t)
-		}
-	}
-
-	// Remove .paw directory
-	fmt.Println("Removing .paw directory...")
-	_ = os.RemoveAll(application.PawDir)
-
-	fmt.Println("Done!")
-	return nil
-}
-
 // getPawHome returns the PAW installation directory
 func getPawHome() (string, error) {
 	// Check PAW_HOME env var
@@ -212,9 +156,13 @@ func getPawHome() (string, error) {
 	return filepath.Dir(exe), nil
 }
 
-// updateGitignore adds .paw gitignore rules if not already present
+// updateGitignore adds .paw gitignore rules if not already present, as set by
+// gitignore_management (mode).
 // Rules: .paw/ (ignore all), !.paw/config (keep config)
-func updateGitignore(projectDir string) {
+func updateGitignore(projectDir, mode string) {
+	if mode == constants.GitignoreNever {
+		return
+	}
 	gitignorePath := filepath.Join(projectDir, ".gitignore")
 
 	// Read existing content
@@ -242,15 +190,18 @@ func updateGitignore(projectDir string) {
 		return
 	}
 
-	// Prompt user to add rules (default Y)
-	fmt.Print("Add .paw/ gitignore rules (keeps config tracked)? [Y/n]: ")
-	var answer string
-	_, _ = fmt.Scanln(&answer)
-	answer = strings.TrimSpace(strings.ToLower(answer))
+	// Prompt user to add rules (default Y); without a terminal to answer
+	// the prompt or with --non-interactive, add them as in auto mode
+	if mode == constants.GitignorePrompt && canPrompt() {
+		fmt.Print("Add .paw/ gitignore rules (keeps config tracked)? [Y/n]: ")
+		var answer string
+		_, _ = fmt.Scanln(&answer)
+		answer = strings.TrimSpace(strings.ToLower(answer))
 
-	// Default is Y
-	if answer != "" && answer != "y" && answer != "yes" {
-		return
+		// Default is Y
+		if answer != "" && answer != "y" && answer != "yes" {
+			return
+		}
 	}
 
 	// Append missing rules
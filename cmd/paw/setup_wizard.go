@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Configure the project interactively",
+	Long: `Walk through the project configuration: a profile preset, build/test/lint
+commands, verify-before-push, what happens when a task is done, permission
+prompts, notification channels (Slack, ntfy), link mode, and history encryption. Slack tokens and ntfy topics can be tested with ⌃T
+before they are saved.
+
+Empty build/test/lint commands are pre-filled from the project's CI
+workflows, Makefile, justfile, package.json, or toolchain (go.mod, Cargo.toml).
+
+The wizard edits the existing .paw/config; settings it doesn't cover are kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppForSetup()
+		if err != nil {
+			return err
+		}
+
+		detected := service.DetectProjectCommands(appCtx.ProjectDir)
+		if isNonInteractive() {
+			// Take the wizard's defaults: the config as is, with empty
+			// commands filled in from the detected ones
+			return saveSetupConfig(appCtx, setupDefaults(appCtx.Config, detected))
+		}
+		cfg, saved, err := tui.RunSetupWizard(appCtx.Config, detected, tui.SetupTesters{
+			Slack: notify.TestSlack,
+			Ntfy:  notify.TestNtfy,
+		})
+		if err != nil {
+			return err
+		}
+		if !saved {
+			fmt.Println("Setup cancelled; config unchanged.")
+			return nil
+		}
+
+		return saveSetupConfig(appCtx, cfg)
+	},
+}
+
+// setupDefaults returns cfg with its empty build/test/lint commands filled
+// in from detected, as the wizard pre-fills them.
+func setupDefaults(cfg *config.Config, detected service.DetectedCommands) *config.Config {
+	cfg = cfg.Clone()
+	detected.FillEmpty(&cfg.Commands)
+	return cfg
+}
+
+func saveSetupConfig(appCtx *app.App, cfg *config.Config) error {
+	if err := cfg.Save(appCtx.PawDir); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Printf("✓ Config saved to %s\n", filepath.Join(appCtx.PawDir, constants.ConfigFileName))
+	return nil
+}
+
+// getAppForSetup returns the app for the current project, creating the
+// workspace if paw hasn't run there yet.
+func getAppForSetup() (*app.App, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	gitClient := git.New()
+	isGitRepo := gitClient.IsGitRepo(cwd)
+	projectDir := cwd
+	if isGitRepo {
+		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
+			projectDir = repoRoot
+		}
+	}
+
+	application, err := app.NewWithGitInfo(projectDir, isGitRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app: %w", err)
+	}
+	if !application.IsInitialized() {
+		if err := application.Initialize(); err != nil {
+			return nil, fmt.Errorf("failed to initialize workspace: %w", err)
+		}
+	}
+	return loadAppConfig(application)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	snapshotOutput string
+	snapshotWidth  int
+	snapshotLight  bool
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the Kanban board as an HTML, PNG, or text file",
+	Long: `Render the Kanban board of all running PAW sessions to a file for sharing
+(e.g. in standups). The format follows the output file's extension:
+
+  .html  Standalone page with the board's colors (default)
+  .png   Image rendered with 'freeze' (https://github.com/charmbracelet/freeze)
+  .txt   Plain text
+  .ans   Raw ANSI, for 'cat' in a terminal
+
+Use '-o -' to print the board to stdout.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if snapshotWidth < 60 {
+			return fmt.Errorf("--width must be at least 60, got %d", snapshotWidth)
+		}
+
+		now := time.Now()
+		isDark := !snapshotLight
+		kanban := tui.NewKanbanView(isDark)
+		kanban.SetTasks(service.NewTaskDiscoveryService().DiscoverAll())
+		if !kanban.HasTasks() {
+			fmt.Fprintln(os.Stderr, "No running PAW tasks found; the board is empty")
+		}
+		board := kanban.RenderSnapshot(snapshotWidth)
+
+		output := snapshotOutput
+		if output == "" {
+			output = "paw-board-" + now.Format("20060102-1504") + ".html"
+		}
+		if output == "-" {
+			fmt.Println(board)
+			return nil
+		}
+
+		switch ext := strings.ToLower(filepath.Ext(output)); ext {
+		case ".html", ".htm":
+			err := os.WriteFile(output, []byte(tui.SnapshotHTML(board, isDark, now)), 0644) //nolint:gosec // G306: shared report
+			if err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		case ".png":
+			if err := writeSnapshotPNG(board, output); err != nil {
+				return err
+			}
+		case ".txt":
+			if err := os.WriteFile(output, []byte(ansi.Strip(board)+"\n"), 0644); err != nil { //nolint:gosec // G306: shared report
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		case ".ans":
+			if err := os.WriteFile(output, []byte(board+"\n"), 0644); err != nil { //nolint:gosec // G306: shared report
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		default:
+			return fmt.Errorf("unsupported snapshot format %q (use .html, .png, .txt, or .ans)", ext)
+		}
+
+		fmt.Printf("📸 Saved board snapshot to %s\n", output)
+		return nil
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Output file (default: paw-board-<time>.html)")
+	snapshotCmd.Flags().IntVar(&snapshotWidth, "width", 160, "Board width in columns")
+	snapshotCmd.Flags().BoolVar(&snapshotLight, "light", false, "Use light theme colors")
+}
+
+// writeSnapshotPNG renders the ANSI board to a PNG with freeze.
+func writeSnapshotPNG(board, output string) error {
+	freeze, err := exec.LookPath("freeze")
+	if err != nil {
+		return errors.New("PNG snapshots need 'freeze' (brew install charmbracelet/tap/freeze); use .html instead")
+	}
+
+	tmp, err := os.CreateTemp("", "paw-board-*.ans")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.WriteString(board + "\n"); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	cmd := exec.Command(freeze, "--execute", shellJoin("cat", tmp.Name()), "--output", output) //nolint:gosec // G204: freeze is resolved via LookPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("freeze failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
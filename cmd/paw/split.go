@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var splitCmd = &cobra.Command{
+	Use:   "split [file]",
+	Short: "Split a large task into smaller dependent tasks",
+	Long: `Ask Claude to decompose a large task description into smaller tasks,
+preview the plan, and create the selected tasks in the running PAW session.
+
+Tasks that depend on an earlier task wait for it to finish successfully
+(the same as the "Depends on" task option).
+
+The description is read from the file argument, or from stdin if omitted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSplit,
+}
+
+func runSplit(_ *cobra.Command, args []string) error {
+	content, err := readSplitInput(args)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+
+	tm := newTmuxClient(appCtx.SessionName)
+	if !tm.HasSession(appCtx.SessionName) {
+		return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+	}
+
+	_, cleanup := setupLoggerFromApp(appCtx, "split", "")
+	defer cleanup()
+
+	raw, err := tui.RunSpinner("Splitting task...", func() (string, error) {
+		return newClaudeClient().GenerateTaskSplit(content)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to split task: %w", err)
+	}
+
+	plan, err := service.ParseSplitPlan(raw)
+	if err != nil {
+		logging.Debug("runSplit: raw response=%q", raw)
+		return err
+	}
+
+	// The preview starts with every task selected; take that without it
+	selected := plan.Tasks
+	if !isNonInteractive() {
+		selected, err = tui.RunSplitPreview(plan)
+		if err != nil {
+			return err
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	return createSplitTasks(appCtx, selected)
+}
+
+// readSplitInput reads the task description from a file argument or stdin.
+func readSplitInput(args []string) (string, error) {
+	var data []byte
+	var err error
+	if len(args) > 0 {
+		data, err = os.ReadFile(args[0]) //nolint:gosec // G304: file path is from user args
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read task description: %w", err)
+	}
+
+	content := strings.TrimSpace(string(data))
+	if content == "" {
+		return "", errors.New("task description is empty")
+	}
+	return content, nil
+}
+
+// createSplitTasks creates the tasks in plan order and starts each one.
+// Dependencies are remapped to the actual task names, since a name may get
+// a numeric suffix when it already exists.
+func createSplitTasks(appCtx *app.App, tasks []service.SplitTask) error {
+	created := make(map[string]string, len(tasks))
+
+	for _, st := range tasks {
+		opts := &config.TaskOptions{BranchName: st.Name}
+		if dep := created[st.DependsOn]; dep != "" {
+			opts.DependsOn = &config.TaskDependency{
+				TaskName:  dep,
+				Condition: config.DependsOnSuccess,
+			}
+		}
+		newTask, err := spawnTask(appCtx, st.Content, opts)
+		if err != nil {
+			return fmt.Errorf("failed to start task %s: %w", st.Name, err)
+		}
+		created[st.Name] = newTask.Name
+		logging.Log("Split task created: %s", newTask.Name)
+
+		if opts.DependsOn != nil {
+			fmt.Printf("  ✅ %s (after %s)\n", newTask.Name, opts.DependsOn.TaskName)
+		} else {
+			fmt.Printf("  ✅ %s\n", newTask.Name)
+		}
+	}
+
+	fmt.Printf("Created %d tasks in %s\n", len(tasks), appCtx.SessionName)
+	return nil
+}
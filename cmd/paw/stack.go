@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// setupTaskWorktree creates a new task's worktree, on its parent task's
+// branch when the task is stacked. A parent whose branch is already gone
+// (merged or cancelled before the child started) unstacks the task.
+func setupTaskWorktree(appCtx *app.App, mgr *task.Manager, t *task.Task, taskOpts *config.TaskOptions, agentDir string) error {
+	parent := taskOpts.Parent
+	if parent == "" {
+		return mgr.SetupWorktree(t)
+	}
+	if git.New().BranchExists(appCtx.ProjectDir, parent) {
+		return mgr.SetupStackedWorktree(t, parent)
+	}
+
+	logging.Warn("setupTaskWorktree: parent %s of %s has no branch; starting from the main branch", parent, t.Name)
+	taskOpts.Parent = ""
+	if err := taskOpts.Save(agentDir); err != nil {
+		logging.Warn("setupTaskWorktree: failed to unstack task: %v", err)
+	}
+	return mgr.SetupWorktree(t)
+}
+
+// stackedChildren returns the tasks stacked on parent, sorted by name.
+func stackedChildren(agentsDir, parent string) []*task.Task {
+	entries, err := os.ReadDir(agentsDir)
+	if err != nil {
+		return nil
+	}
+	var children []*task.Task
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == parent {
+			continue
+		}
+		agentDir := filepath.Join(agentsDir, entry.Name())
+		if opts, err := config.LoadTaskOptions(agentDir); err == nil && opts.Parent == parent {
+			children = append(children, task.New(entry.Name(), agentDir))
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
+// unstackTask clears a child task's parent once it no longer builds on it.
+func unstackTask(child *task.Task) {
+	opts, err := config.LoadTaskOptions(child.AgentDir)
+	if err != nil {
+		logging.Warn("unstackTask: failed to load options of %s: %v", child.Name, err)
+		return
+	}
+	opts.Parent = ""
+	if err := opts.Save(child.AgentDir); err != nil {
+		logging.Warn("unstackTask: failed to save options of %s: %v", child.Name, err)
+	}
+}
+
+// restackChildren rebases the tasks stacked on a parent that was just merged
+// onto the main branch, dropping the parent's commits (already squashed into
+// main) from their branches. Must run before the parent's branch is deleted.
+// Children that can't be rebased cleanly are left as they were, with the
+// command to finish the rebase by hand.
+func restackChildren(appCtx *app.App, parent string, gitClient git.Client, tm tmux.Client) {
+	restackChildrenOnto(appCtx, parent, gitClient.GetMainBranch(appCtx.ProjectDir), gitClient, tm)
+}
+
+// restackChildrenOnto is restackChildren for a parent merged into mainBranch,
+// which may be a remote-tracking branch when the parent merged through a PR.
+func restackChildrenOnto(appCtx *app.App, parent, mainBranch string, gitClient git.Client, tm tmux.Client) {
+	children := stackedChildren(filepath.Join(appCtx.PawDir, constants.AgentsDirName), parent)
+	if len(children) == 0 {
+		return
+	}
+
+	for _, child := range children {
+		worktreeDir := child.GetWorktreeDir()
+		if !pathExists(worktreeDir) {
+			// Not started yet; handle-task branches it off main instead
+			unstackTask(child)
+			continue
+		}
+
+		spinner := tui.NewSimpleSpinner(fmt.Sprintf("Rebasing %s onto %s", child.Name, mainBranch))
+		spinner.Start()
+		upstream, err := gitClient.MergeBase(worktreeDir, "HEAD", parent)
+		if err == nil {
+			err = gitClient.RebaseOnto(worktreeDir, mainBranch, upstream)
+		}
+		unstackTask(child)
+		if err != nil {
+			if gitClient.HasOngoingRebase(worktreeDir) {
+				if abortErr := gitClient.RebaseAbort(worktreeDir); abortErr != nil {
+					logging.Warn("restackChildren: failed to abort rebase of %s: %v", child.Name, abortErr)
+				}
+			}
+			spinner.Stop(false, "failed")
+			logging.Warn("restackChildren: failed to rebase %s onto %s: %v", child.Name, mainBranch, err)
+			fmt.Printf("  ⚠️  %s still contains %s's commits; rebase it by hand:\n", child.Name, parent)
+			if upstream != "" {
+				fmt.Printf("     git -C %s rebase --onto %s %s\n", worktreeDir, mainBranch, upstream)
+			}
+			_ = notify.Send("Restack failed", fmt.Sprintf("⚠️ %s could not be rebased onto %s after %s merged", child.Name, mainBranch, parent))
+			continue
+		}
+		spinner.Stop(true, mainBranch)
+		logging.Log("restackChildren: rebased %s onto %s after %s merged", child.Name, mainBranch, parent)
+	}
+	if err := tm.DisplayMessage(fmt.Sprintf("↳ Restacked on %s: %s", mainBranch, taskNames(children)), constants.DisplayMsgStandard); err != nil {
+		logging.Trace("Failed to display message: %v", err)
+	}
+}
+
+// prBaseBranch returns the branch a task's pull request targets: the parent
+// task's branch when the task is stacked, so the PR shows only the task's own
+// commits, and mainBranch otherwise. It also returns the parent, which is
+// not the base when its branch is not on the remote.
+func prBaseBranch(appCtx *app.App, t *task.Task, gitClient git.Client, mainBranch string) (string, string) {
+	opts, err := config.LoadTaskOptions(t.AgentDir)
+	if err != nil || opts.Parent == "" {
+		return mainBranch, ""
+	}
+	head, _, err := gitClient.RemoteBranchStatus(appCtx.ProjectDir, "origin", opts.Parent)
+	if err != nil || head == "" {
+		logging.Warn("prBaseBranch: parent %s of %s is not on the remote (err=%v); targeting %s", opts.Parent, t.Name, err, mainBranch)
+		return mainBranch, opts.Parent
+	}
+	return opts.Parent, opts.Parent
+}
+
+// warnStackedChildren warns that a parent task is being discarded while
+// other tasks are stacked on it. The children keep the parent's commits and
+// are unstacked: merging them will bring those commits in.
+func warnStackedChildren(appCtx *app.App, parent string, tm tmux.Client) {
+	children := stackedChildren(filepath.Join(appCtx.PawDir, constants.AgentsDirName), parent)
+	if len(children) == 0 {
+		return
+	}
+	names := taskNames(children)
+	logging.Warn("warnStackedChildren: %s discarded with stacked tasks %s", parent, names)
+	fmt.Printf("  ⚠️  Tasks stacked on %s keep its commits: %s\n", parent, names)
+	for _, child := range children {
+		unstackTask(child)
+	}
+	_ = notify.Send("Parent task discarded", fmt.Sprintf("⚠️ %s was discarded; %s still contain its commits", parent, names))
+	if err := tm.DisplayMessage(fmt.Sprintf("⚠️ %s discarded; stacked tasks keep its commits: %s", parent, names), constants.DisplayMsgImportant); err != nil {
+		logging.Trace("Failed to display message: %v", err)
+	}
+}
+
+func taskNames(tasks []*task.Task) string {
+	names := make([]string, len(tasks))
+	for i, t := range tasks {
+		names[i] = t.Name
+	}
+	return strings.Join(names, ", ")
+}
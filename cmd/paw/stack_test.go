@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func TestStackedChildren(t *testing.T) {
+	agentsDir := t.TempDir()
+	for name, parent := range map[string]string{
+		"add-auth":   "",
+		"auth-tests": "add-auth",
+		"auth-docs":  "add-auth",
+		"fix-login":  "other-task",
+	} {
+		agentDir := filepath.Join(agentsDir, name)
+		if err := os.MkdirAll(agentDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := (&config.TaskOptions{Parent: parent}).Save(agentDir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	children := stackedChildren(agentsDir, "add-auth")
+	if got := taskNames(children); got != "auth-docs, auth-tests" {
+		t.Fatalf("stackedChildren() = %q, want auth-docs, auth-tests", got)
+	}
+
+	unstackTask(children[0])
+	if got := taskNames(stackedChildren(agentsDir, "add-auth")); got != "auth-tests" {
+		t.Errorf("stackedChildren() after unstacking = %q, want auth-tests", got)
+	}
+	if len(stackedChildren(agentsDir, "missing")) != 0 {
+		t.Error("stackedChildren() of a task without children should be empty")
+	}
+}
+
+func TestPRBaseBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remoteDir := t.TempDir()
+	runGit(t, remoteDir, "init", "--bare")
+	projectDir := t.TempDir()
+	runGit(t, projectDir, "init", "-b", "main")
+	runGit(t, projectDir, "config", "user.email", "test@example.com")
+	runGit(t, projectDir, "config", "user.name", "Test")
+	runGit(t, projectDir, "commit", "--allow-empty", "-m", "initial commit")
+	runGit(t, projectDir, "remote", "add", "origin", remoteDir)
+	runGit(t, projectDir, "branch", "add-auth")
+
+	appCtx := &app.App{ProjectDir: projectDir}
+	gitClient := git.New()
+	agentDir := t.TempDir()
+	child := task.New("auth-tests", agentDir)
+
+	if base, parent := prBaseBranch(appCtx, child, gitClient, "main"); base != "main" || parent != "" {
+		t.Errorf("prBaseBranch(unstacked) = %q, %q; want main, \"\"", base, parent)
+	}
+
+	if err := (&config.TaskOptions{Parent: "add-auth"}).Save(agentDir); err != nil {
+		t.Fatal(err)
+	}
+	if base, parent := prBaseBranch(appCtx, child, gitClient, "main"); base != "main" || parent != "add-auth" {
+		t.Errorf("prBaseBranch(parent not pushed) = %q, %q; want main, add-auth", base, parent)
+	}
+
+	runGit(t, projectDir, "push", "origin", "add-auth")
+	if base, parent := prBaseBranch(appCtx, child, gitClient, "main"); base != "add-auth" || parent != "add-auth" {
+		t.Errorf("prBaseBranch(parent pushed) = %q, %q; want add-auth, add-auth", base, parent)
+	}
+}
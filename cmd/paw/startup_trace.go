@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// startupTraceStart is when --trace-startup began recording (zero if disabled).
+var startupTraceStart time.Time
+
+// startStartupTrace records the logging timers of the startup phases for printStartupTrace.
+func startStartupTrace() {
+	startupTraceStart = time.Now()
+	logging.RecordTimers()
+}
+
+// printStartupTrace prints the recorded startup phases to stderr when --trace-startup is set.
+// It runs right before attaching, so the report is visible after detaching.
+func printStartupTrace() {
+	if startupTraceStart.IsZero() {
+		return
+	}
+	fmt.Fprint(os.Stderr, formatStartupTrace(startupTraceStart, time.Since(startupTraceStart), logging.RecordedTimers()))
+}
+
+// formatStartupTrace renders the timers ordered by start time, with their offset from start.
+func formatStartupTrace(start time.Time, total time.Duration, records []logging.TimerRecord) string {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Start.Before(records[j].Start)
+	})
+
+	out := fmt.Sprintf("Startup trace (%v until attach):\n", total.Round(time.Millisecond))
+	var measured time.Duration
+	for _, r := range records {
+		offset := r.Start.Sub(start)
+		out += fmt.Sprintf("  %8s  %8v  %s\n", "+"+offset.Round(time.Millisecond).String(), r.Elapsed.Round(time.Millisecond), r.Operation)
+		measured += r.Elapsed
+	}
+	if other := total - measured; other > 0 {
+		out += fmt.Sprintf("  %8s  %8v  %s\n", "", other.Round(time.Millisecond), "(other)")
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+func TestFormatStartupTrace(t *testing.T) {
+	start := time.Now()
+	records := []logging.TimerRecord{
+		{Operation: "tmux setup", Start: start.Add(300 * time.Millisecond), Elapsed: 200 * time.Millisecond},
+		{Operation: "config load", Start: start.Add(10 * time.Millisecond), Elapsed: 250 * time.Millisecond},
+	}
+
+	out := formatStartupTrace(start, time.Second, records)
+
+	if !strings.HasPrefix(out, "Startup trace (1s until attach):\n") {
+		t.Errorf("unexpected header:\n%s", out)
+	}
+	if strings.Index(out, "config load") > strings.Index(out, "tmux setup") {
+		t.Errorf("phases should be ordered by start time:\n%s", out)
+	}
+	if !strings.Contains(out, "+300ms") || !strings.Contains(out, "550ms  (other)") {
+		t.Errorf("missing offsets or unmeasured time:\n%s", out)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+var importForce bool
+
+var exportCmd = &cobra.Command{
+	Use:   "export [archive]",
+	Short: "Export the project's PAW state to an archive",
+	Long: `Export the project's PAW state: config, PROMPT.md and prompts/, task
+templates and schedules, input history, and task history (with artifacts).
+
+Task workspaces (worktrees, running and queued tasks) and logs are not
+included. Encrypted history stays encrypted; the new machine needs the
+history key to read it.
+
+The archive format follows the name: .tar, .tar.gz, or .tar.zst (needs zstd).
+
+Examples:
+  paw export paw-state.tar.zst
+  paw export team-baseline.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+
+		count, err := service.ExportState(appCtx.PawDir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Exported %d files to %s\n", count, args[0])
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [archive]",
+	Short: "Import PAW state exported with 'paw export'",
+	Long: `Import PAW state exported with 'paw export' into the current project,
+creating its workspace if needed.
+
+Files that already exist are kept unless --force is given, so importing a
+team baseline never overwrites your own config or history. To create tasks
+from a CI test report instead, see 'paw import junit'.
+
+Examples:
+  paw import paw-state.tar.zst
+  paw import team-baseline.tar.gz --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := newProjectApp()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(appCtx.PawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+
+		imported, skipped, err := service.ImportState(appCtx.PawDir, args[0], importForce)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Imported %d files into %s\n", imported, appCtx.PawDir)
+		if skipped > 0 {
+			fmt.Printf("   Kept %d existing files (use --force to overwrite them)\n", skipped)
+		}
+		if newTmuxClient(appCtx.SessionName).HasSession(appCtx.SessionName) {
+			fmt.Println("   Restart the session to use the imported config")
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importForce, "force", false, "Overwrite existing files")
+}
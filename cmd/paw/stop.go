@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+const (
+	// stopSettleDelay gives interrupted agents time to save their session
+	// before the tmux session goes away.
+	stopSettleDelay = 2 * time.Second
+	// resumeAfterStopMessage is sent to an agent that paw stop interrupted
+	// mid-step once its task is reopened.
+	resumeAfterStopMessage = "PAW was stopped while you were working and has now restarted. Continue the task where you left off."
+)
+
+// sessionWatchers are the background commands that run for a session
+// ("paw internal <command> <session> ...").
+var sessionWatchers = map[string]bool{
+	"watch-wait":     true,
+	"watch-pr":       true,
+	"run-schedules":  true,
+	"editor-server":  true,
+	"autopilot-run":  true,
+	"restore-layout": true,
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the project's session so the next 'paw' resumes every task",
+	Long: `Stop the project's PAW session gracefully, keeping every task to resume.
+
+Agents in the middle of a step are interrupted the way Claude Code expects
+(Escape, which keeps the session) and marked to resume; the session's
+watchers and background processes are stopped, the window layout is saved,
+and the tmux session is closed. The next 'paw' reopens every task with its
+Claude session (--continue) and tells the interrupted agents to continue
+where they left off.
+
+Unlike 'paw kill' or killing tmux, agents are never cut off mid-step.
+
+Examples:
+  paw stop`,
+	Args: cobra.NoArgs,
+	RunE: runStop,
+}
+
+func runStop(_ *cobra.Command, _ []string) error {
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+
+	tm := newTmuxClient(appCtx.SessionName)
+	if !tm.HasSession(appCtx.SessionName) {
+		return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s", appCtx.GetDisplayName())
+	}
+
+	_, cleanup := setupLoggerFromApp(appCtx, "stop", "")
+	defer cleanup()
+
+	// Keep the window order and shell panes for the next session
+	saveSessionLayout(tm, appCtx)
+
+	// Stop watchers first so they don't report the interrupted agents as waiting
+	stopped := stopSessionWatchers(appCtx.SessionName)
+	logging.Log("stop: stopped %d watchers for session %s", stopped, appCtx.SessionName)
+
+	running, interrupted := interruptSessionAgents(appCtx, tm)
+	if interrupted > 0 {
+		time.Sleep(stopSettleDelay)
+	}
+
+	fmt.Printf("⏹️  Stopping %s: %d tasks saved", appCtx.SessionName, running)
+	if interrupted > 0 {
+		fmt.Printf(", %d interrupted mid-step", interrupted)
+	}
+	fmt.Println()
+	fmt.Println("   Run 'paw' to resume them")
+
+	if err := tm.KillSession(appCtx.SessionName); err != nil {
+		return fmt.Errorf("failed to stop session: %w", err)
+	}
+	endCooperativeSession(tm, appCtx.SessionName)
+	return nil
+}
+
+// interruptSessionAgents interrupts the agents of the session's task windows
+// that are mid-step and marks their tasks to resume. It returns how many task
+// windows there were and how many agents were interrupted.
+func interruptSessionAgents(appCtx *app.App, tm tmux.Client) (int, int) {
+	windows, err := tm.ListWindows()
+	if err != nil {
+		logging.Warn("stop: failed to list windows: %v", err)
+		return 0, 0
+	}
+	windowIDs := make(map[string]bool, len(windows))
+	for _, w := range windows {
+		windowIDs[w.ID] = true
+	}
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	tasks, err := mgr.ListTasks()
+	if err != nil {
+		logging.Warn("stop: failed to list tasks: %v", err)
+		return 0, 0
+	}
+
+	running, interrupted := 0, 0
+	for _, t := range tasks {
+		windowID, err := t.LoadWindowID()
+		if err != nil || !windowIDs[windowID] {
+			continue
+		}
+		running++
+
+		paneID := windowID + ".0"
+		content, err := tm.CapturePane(paneID, 10)
+		if err != nil || !agentBusy(content) {
+			continue
+		}
+		if !interruptAgent(tm, paneID) {
+			logging.Warn("stop: agent of %s still looks busy after Escape", t.Name)
+		}
+		if err := t.CreateResumeMarker(); err != nil {
+			logging.Warn("stop: failed to mark %s to resume: %v", t.Name, err)
+			continue
+		}
+		interrupted++
+		logging.Log("stop: interrupted %s", t.Name)
+	}
+	return running, interrupted
+}
+
+// stopSessionWatchers terminates the session's background paw processes and
+// returns how many it stopped.
+func stopSessionWatchers(sessionName string) int {
+	output, err := exec.Command("ps", "-Ao", "pid=,args=").Output()
+	if err != nil {
+		logging.Warn("stop: failed to list processes: %v", err)
+		return 0
+	}
+
+	stopped := 0
+	for _, pid := range parseWatcherPIDs(string(output), sessionName, os.Getpid()) {
+		if err := terminateProcess(pid); err != nil {
+			logging.Debug("stop: failed to stop watcher %d: %v", pid, err)
+			continue
+		}
+		stopped++
+	}
+	return stopped
+}
+
+// parseWatcherPIDs returns the pids of the session's watchers in "ps -o
+// pid=,args=" output, leaving out self.
+func parseWatcherPIDs(psOutput, sessionName string, self int) []int {
+	var pids []int
+	for _, line := range strings.Split(psOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil || pid == self {
+			continue
+		}
+		for i := 1; i+2 < len(fields); i++ {
+			if fields[i] == "internal" && sessionWatchers[fields[i+1]] && fields[i+2] == sessionName {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+	return pids
+}
+
+// resumeStoppedAgent tells an agent that paw stop interrupted mid-step to
+// continue, once its task is reopened.
+func resumeStoppedAgent(appCtx *app.App, tm tmux.Client, claudeClient claude.Client, agentPane string, t *task.Task, windowID string) {
+	if err := claudeClient.SendInputWithRetry(tm, agentPane, resumeAfterStopMessage, 3); err != nil {
+		logging.Warn("Failed to resume interrupted agent of %s: %v", t.Name, err)
+		return
+	}
+	logging.Log("Resumed agent interrupted by paw stop: task=%s", t.Name)
+
+	newName := windowNameForStatus(t.Name, task.StatusWorking)
+	if err := renameWindowWithStatus(tm, windowID, newName, appCtx.PawDir, t.Name, "resume", task.StatusWorking); err != nil {
+		logging.Warn("Failed to rename window: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWatcherPIDs(t *testing.T) {
+	ps := `    1 /sbin/init
+  101 /usr/local/bin/paw internal watch-wait myproj @3 fix-login
+  102 /usr/local/bin/paw internal watch-wait myproj-api @4 fix-api
+  103 /home/me/.paw/bin internal run-schedules myproj
+  104 paw internal handle-task myproj /p/.paw/agents/fix-login
+  105 paw internal editor-server myproj
+  106 vim internal watch-wait
+  107 paw internal autopilot-run myproj
+`
+	got := parseWatcherPIDs(ps, "myproj", 107)
+	want := []int{101, 103, 105}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWatcherPIDs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/exitcode"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// schedulerOptionKey holds the pid of the session's template scheduler.
+const schedulerOptionKey = "@paw_scheduler"
+
+var templateEvery string
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "List, run, and schedule task templates",
+	Long: `List, run, and schedule task templates.
+
+PAW bundles maintenance templates (dependency-bump, flaky-test-hunt,
+todo-triage, changelog-update) next to the project's own templates (Ctrl+T).
+Editing a built-in template in the picker saves a project copy that replaces it.
+
+Scheduled templates start as new tasks while the project's session is running.
+
+Examples:
+  paw template                               # List templates and schedules
+  paw template run todo-triage               # Start a task from a template
+  paw template schedule dependency-bump --every 7d
+  paw template unschedule dependency-bump`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		svc := service.NewTemplateService(appCtx.PawDir)
+		templates, err := svc.LoadTemplates()
+		if err != nil {
+			return fmt.Errorf("failed to load templates: %w", err)
+		}
+		schedules, err := svc.LoadSchedules()
+		if err != nil {
+			return fmt.Errorf("failed to load schedules: %w", err)
+		}
+
+		builtins := make(map[string]string)
+		for _, b := range service.BuiltinTemplates() {
+			builtins[b.Name] = b.Content
+		}
+		scheduled := make(map[string]service.TemplateSchedule)
+		for _, sc := range schedules {
+			scheduled[sc.Name] = sc
+		}
+
+		for _, t := range templates {
+			label := ""
+			if content, ok := builtins[t.Name]; ok {
+				label = "built-in"
+				if content != t.Content {
+					label = "customized"
+				}
+			}
+			line := fmt.Sprintf("  %-24s %-10s %s", t.Name, label, firstLine(t.Content))
+			if sc, ok := scheduled[t.Name]; ok {
+				line += fmt.Sprintf("  ⏰ every %s", sc.Every)
+			}
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+		return nil
+	},
+}
+
+var templateRunCmd = &cobra.Command{
+	Use:   "run [name]",
+	Short: "Start a task from a template in the running session",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		tm := newTmuxClient(appCtx.SessionName)
+		if !tm.HasSession(appCtx.SessionName) {
+			return exitcode.Errorf(exitcode.SessionNotFound, "no running PAW session for %s (run 'paw' first)", appCtx.GetDisplayName())
+		}
+
+		_, cleanup := setupLoggerFromApp(appCtx, "template", "")
+		defer cleanup()
+
+		entry, err := service.NewTemplateService(appCtx.PawDir).FindTemplate(args[0])
+		if err != nil {
+			return err
+		}
+		newTask, err := runTemplate(appCtx, entry)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Started %s from template %s\n", newTask.Name, entry.Name)
+		return nil
+	},
+}
+
+var templateScheduleCmd = &cobra.Command{
+	Use:   "schedule [name]",
+	Short: "Start a task from a template at a fixed interval",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if templateEvery == "" {
+			return errors.New("--every is required (e.g. --every 7d)")
+		}
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		if err := service.NewTemplateService(appCtx.PawDir).SetSchedule(args[0], templateEvery, time.Now()); err != nil {
+			return err
+		}
+		fmt.Printf("⏰ %s scheduled every %s (first run in %s)\n", args[0], templateEvery, templateEvery)
+
+		tm := newTmuxClient(appCtx.SessionName)
+		if tm.HasSession(appCtx.SessionName) {
+			startTemplateScheduler(appCtx, tm, getPawBin())
+		}
+		return nil
+	},
+}
+
+var templateUnscheduleCmd = &cobra.Command{
+	Use:   "unschedule [name]",
+	Short: "Stop running a template on a schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		removed, err := service.NewTemplateService(appCtx.PawDir).RemoveSchedule(args[0])
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("template %q is not scheduled", args[0])
+		}
+		fmt.Printf("✅ %s unscheduled\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	templateScheduleCmd.Flags().StringVar(&templateEvery, "every", "", "Interval between runs (e.g. 12h, 7d, 2w)")
+	templateCmd.AddCommand(templateRunCmd)
+	templateCmd.AddCommand(templateScheduleCmd)
+	templateCmd.AddCommand(templateUnscheduleCmd)
+}
+
+// runTemplate creates a task named after the template and starts it.
+func runTemplate(appCtx *app.App, entry *service.TemplateEntry) (*task.Task, error) {
+	newTask, err := spawnTask(appCtx, entry.Content, &config.TaskOptions{BranchName: entry.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start task from template %s: %w", entry.Name, err)
+	}
+	logging.Log("Template task created: %s (template=%s)", newTask.Name, entry.Name)
+	return newTask, nil
+}
+
+// startTemplateScheduler starts the session's template scheduler unless
+// nothing is scheduled or it is already running.
+func startTemplateScheduler(appCtx *app.App, tm tmux.Client, pawBin string) {
+	schedules, err := service.NewTemplateService(appCtx.PawDir).LoadSchedules()
+	if err != nil || len(schedules) == 0 {
+		return
+	}
+	if sessionProcessRunning(tm, appCtx.SessionName, schedulerOptionKey) {
+		return
+	}
+
+	schedulerCmd := exec.Command(pawBin, "internal", "run-schedules", appCtx.SessionName) //nolint:gosec // G204: pawBin is from getPawBin()
+	schedulerCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := schedulerCmd.Start(); err != nil {
+		logging.Warn("Failed to start template scheduler: %v", err)
+	}
+}
+
+var runSchedulesCmd = &cobra.Command{
+	Use:    "run-schedules [session]",
+	Short:  "Start scheduled templates as tasks while the session runs",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "run-schedules", "")
+		defer cleanup()
+
+		tm := newTmuxClient(sessionName)
+		svc := service.NewTemplateService(appCtx.PawDir)
+		pid := strconv.Itoa(os.Getpid())
+		setOrUnsetOption(tm, schedulerOptionKey, pid)
+
+		for {
+			// Stop with the session, or when another scheduler took over
+			if !tm.HasSession(sessionName) || sessionOption(tm, sessionName, schedulerOptionKey) != pid {
+				logging.Debug("run-schedules: session gone or scheduler replaced, exiting")
+				return nil
+			}
+			schedules, err := svc.LoadSchedules()
+			if err != nil {
+				logging.Warn("run-schedules: failed to load schedules: %v", err)
+			}
+			if err == nil && len(schedules) == 0 {
+				logging.Debug("run-schedules: nothing scheduled, exiting")
+				setOrUnsetOption(tm, schedulerOptionKey, "")
+				return nil
+			}
+
+			now := time.Now()
+			for _, sc := range schedules {
+				if !sc.Due(now) {
+					continue
+				}
+				entry, err := svc.FindTemplate(sc.Name)
+				if err != nil {
+					logging.Warn("run-schedules: %v", err)
+					continue
+				}
+				// Record the run first so a failing template doesn't retry every tick
+				if err := svc.MarkScheduleRun(sc.Name, now); err != nil {
+					logging.Warn("run-schedules: failed to record run of %s: %v", sc.Name, err)
+					continue
+				}
+				newTask, err := runTemplate(appCtx, entry)
+				if err != nil {
+					logging.Warn("run-schedules: %v", err)
+					continue
+				}
+				_ = tm.DisplayMessage(fmt.Sprintf("⏰ Scheduled template %s started as %s", sc.Name, newTask.Name), constants.DisplayMsgStandard)
+			}
+
+			time.Sleep(constants.TemplateScheduleInterval)
+		}
+	},
+}
+
+// firstLine returns the first non-empty line of s.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/clipboard"
+	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
@@ -11,24 +13,15 @@ import (
 // reapplyTmuxConfig re-applies tmux configuration after config reload.
 // This is a subset of setupTmuxConfig that updates settings that depend on config.
 func reapplyTmuxConfig(appCtx *app.App, tm tmux.Client) {
-	applyAttachHooks(tm)
+	applyAttachHooks(tm, appCtx.SessionName)
+	applyLayoutHooks(tm, appCtx.SessionName)
+	refreshMuteIndicator(tm, appCtx)
 
 	// Re-apply keybindings (in case session name changed or for consistency)
-	bindings := buildKeybindings(KeybindingsContext{
-		PawBin:      getPawBin(),
-		SessionName: appCtx.SessionName,
-		PawDir:      appCtx.PawDir,
-		ProjectDir:  appCtx.ProjectDir,
-		DisplayName: appCtx.GetDisplayName(),
-	})
-	for _, b := range bindings {
-		if err := tm.Bind(b); err != nil {
-			logging.Debug("Failed to bind %s: %v", b.Key, err)
-		}
-	}
+	applyKeybindings(appCtx, tm)
 }
 
-func applyAttachHooks(tm tmux.Client) {
+func applyAttachHooks(tm tmux.Client, sessionName string) {
 	filePickerVar := "#{" + filePickerPaneIDKey + "}"
 	filePickerResizeCmd := fmt.Sprintf(`if -F "#{!=:%s,}" "resize-pane -t %s -x %s"`, filePickerVar, filePickerVar, filePickerPaneWidth)
 
@@ -36,43 +29,60 @@ func applyAttachHooks(tm tmux.Client) {
 	attachedLogCmd := fmt.Sprintf("%s internal log-pane-layout '#{session_name}' --reason %s", pawBin, shellQuote("client-attached"))
 	resizedLogCmd := fmt.Sprintf("%s internal log-pane-layout '#{session_name}' --reason %s", pawBin, shellQuote("client-resized"))
 	attachedResizeCmd := fmt.Sprintf("%s internal resize-file-picker '#{session_name}' --reason %s", pawBin, shellQuote("client-attached"))
+	presenceCmd := fmt.Sprintf("%s internal refresh-presence '#{session_name}'", pawBin)
 	resizedResizeCmd := fmt.Sprintf("%s internal resize-file-picker '#{session_name}' --reason %s", pawBin, shellQuote("client-resized"))
 
-	_ = tm.Run("set-hook", "-g", "client-attached", "set-option mouse on; "+filePickerResizeCmd+"; run-shell "+shellQuote(attachedResizeCmd)+"; run-shell "+shellQuote(attachedLogCmd))
-	_ = tm.Run("set-hook", "-g", "client-resized", filePickerResizeCmd+"; run-shell "+shellQuote(resizedResizeCmd)+"; run-shell "+shellQuote(resizedLogCmd))
+	scope := hookScope(sessionName)
+	_ = tm.Run(append(append([]string{"set-hook"}, scope...), "client-attached", "set-option mouse on; "+filePickerResizeCmd+"; run-shell "+shellQuote(attachedResizeCmd)+"; run-shell "+shellQuote(attachedLogCmd)+"; run-shell -b "+shellQuote(presenceCmd))...)
+	_ = tm.Run(append(append([]string{"set-hook"}, scope...), "client-detached", "run-shell -b "+shellQuote(presenceCmd))...)
+	_ = tm.Run(append(append([]string{"set-hook"}, scope...), "client-resized", filePickerResizeCmd+"; run-shell "+shellQuote(resizedResizeCmd)+"; run-shell "+shellQuote(resizedLogCmd))...)
 }
 
 // setupTmuxConfig configures tmux keybindings and options
 func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
+	// Appearance options (theme, status bar, titles) are session options, so
+	// they don't leak to other sessions sharing the server.
+
 	// Detect terminal theme and apply theme-aware colors (auto only)
 	resolved := resolveThemePreset(ThemeAuto)
 	applyTmuxTheme(tm, resolved)
 
-	// Change prefix to an unused key (M-F12) so C-b is available for toggle-bottom
-	// Note: "None" is not a valid tmux key, so we use an obscure key instead
-	_ = tm.SetOption("prefix", "M-F12", true)
-	_ = tm.SetOption("prefix2", "M-F12", true)
+	// Cooperative sessions keep the user's prefix and root keys
+	cooperative := tmux.IsCooperative(appCtx.SessionName)
+	if cooperative {
+		// tmux.conf isn't loaded on the user's server; Alt keys need a short escape-time
+		_ = tm.SetOption("escape-time", "0", true)
+	} else {
+		// Change prefix to an unused key (M-F12) so C-b is available for toggle-bottom
+		// Note: "None" is not a valid tmux key, so we use an obscure key instead
+		_ = tm.SetOption("prefix", "M-F12", true)
+		_ = tm.SetOption("prefix2", "M-F12", true)
+	}
 
 	// Setup terminal title (for iTerm2 tab naming)
 	// This makes tmux set the terminal title, which works better than OSC sequences
 	// because iTerm otherwise shows the running command (tmux /var/...)
-	_ = tm.SetOption("set-titles", "on", true)
-	_ = tm.SetOption("set-titles-string", "[paw] "+appCtx.GetDisplayName(), true)
+	_ = tm.SetSessionOption("set-titles", "on")
+	_ = tm.SetSessionOption("set-titles-string", "[paw] "+appCtx.GetDisplayName())
 
 	// Setup status bar
 	// Use DisplayName for user-friendly display (e.g., "repo/subdir" format)
-	_ = tm.SetOption("status", "on", true)
-	_ = tm.SetOption("status-position", "bottom", true)
-	_ = tm.SetOption("status-left", " "+appCtx.GetDisplayName()+" ", true)
-	_ = tm.SetOption("status-left-length", "30", true)
-	_ = tm.SetOption("status-right", " ⌥←→:windows ⌥↑↓:reorder ^K:shell ^/:help ", true)
-	_ = tm.SetOption("status-right-length", "100", true)
+	_ = tm.SetSessionOption("status", "on")
+	_ = tm.SetSessionOption("status-position", "bottom")
+	_ = tm.SetSessionOption("status-left", " "+appCtx.GetDisplayName()+" ")
+	_ = tm.SetSessionOption("status-left-length", "30")
+	_ = tm.SetSessionOption("status-right", presenceStatus+"#{?"+focusFollowOptionKey+",#{"+focusFollowOptionKey+"} │,}#{?"+muteOptionKey+",#{"+muteOptionKey+"} │,} ⌥←→:windows ⌥↑↓:reorder ^K:shell ^/:help ")
+	_ = tm.SetSessionOption("status-right-length", "100")
+	refreshMuteIndicator(tm, appCtx)
+	if appCtx.Config != nil && appCtx.Config.FocusFollow {
+		setFocusFollow(tm, true)
+	}
 
 	// Window status separator (no separator between windows)
-	_ = tm.SetOption("window-status-separator", "", true)
+	_ = tm.SetSessionOption("window-status-separator", "")
 
 	// Popup styling (use terminal colors for content, border is set by applyTmuxTheme)
-	_ = tm.SetOption("popup-style", "fg=terminal,bg=terminal", true)
+	_ = tm.SetSessionOption("popup-style", "fg=terminal,bg=terminal")
 
 	// Enable mouse mode
 	_ = tm.SetOption("mouse", "on", true)
@@ -80,7 +90,10 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 	// Re-enable mouse mode on client attach (needed because Ctrl+Q disables it before detach)
 	// This ensures mouse mode works correctly after reattaching to a session.
 	// Also re-apply file picker width after attach/resize because tmux scales panes on attach.
-	applyAttachHooks(tm)
+	applyAttachHooks(tm, appCtx.SessionName)
+
+	// Save window order and shell panes as they change, for restoring after a restart
+	applyLayoutHooks(tm, appCtx.SessionName)
 
 	// Enable focus events (required for tea.FocusMsg to work)
 	// This is required for auto-focusing the input textarea when switching windows
@@ -92,10 +105,12 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 	// The #{m:pattern,string} format checks if string matches pattern (⭐* = starts with ⭐).
 	// The #{&&:...} format combines multiple conditions with AND.
 	// Note: ⭐️ is multi-byte UTF-8, so we use the prefix check pattern.
-	_ = tm.Run("bind", "-n", "MouseDrag1Pane",
-		"if-shell", "-F", "#{&&:#{m:⭐*,#{window_name}},#{==:#{pane_index},0}}",
-		"send-keys -M", // Forward mouse event to pane (TUI handles it)
-		"copy-mode -M") // Enter copy-mode with mouse selection
+	if !cooperative {
+		_ = tm.Run("bind", "-n", "MouseDrag1Pane",
+			"if-shell", "-F", "#{&&:#{m:⭐*,#{window_name}},#{==:#{pane_index},0}}",
+			"send-keys -M", // Forward mouse event to pane (TUI handles it)
+			"copy-mode -M") // Enter copy-mode with mouse selection
+	}
 
 	// Enable vi-style copy mode and clipboard integration
 	_ = tm.SetOption("mode-keys", "vi", true)
@@ -107,18 +122,39 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 
 	// Auto-copy to system clipboard when mouse selection ends
 	// In copy-mode, commands must use "send-keys -X" format
-	_ = tm.Bind(tmux.BindOpts{
-		Key:     "MouseDragEnd1Pane",
-		Command: "send-keys -X copy-pipe-and-cancel 'pbcopy'",
-		Table:   "copy-mode-vi",
-	})
+	if !cooperative {
+		_ = tm.Bind(tmux.BindOpts{
+			Key:     "MouseDragEnd1Pane",
+			Command: copySelectionCommand(),
+			Table:   "copy-mode-vi",
+		})
+	}
 
 	// Unbind C-b from root table before setting up keybindings
 	// This ensures C-b doesn't act as prefix even if tmux.conf wasn't reloaded
 	// (tmux.conf is only loaded when server starts, not on reconnect)
-	_ = tm.Run("unbind-key", "-T", "root", "C-b")
+	if !cooperative {
+		_ = tm.Run("unbind-key", "-T", "root", "C-b")
+	}
 
 	// Setup keybindings (English + Korean layouts)
+	applyKeybindings(appCtx, tm)
+}
+
+// copySelectionCommand returns the copy-mode command that copies the
+// selection to the clipboard. Without a clipboard command, tmux sends the
+// selection to the terminal with OSC 52 (set-clipboard on).
+func copySelectionCommand() string {
+	if command := clipboard.Command(); command != "" {
+		return "send-keys -X copy-pipe-and-cancel " + shellQuote(command)
+	}
+	return "send-keys -X copy-selection-and-cancel"
+}
+
+// applyKeybindings binds PAW's keys. Cooperative sessions share the user's
+// server, so their keys go in a session key table entered with prefix + P
+// instead of the root table.
+func applyKeybindings(appCtx *app.App, tm tmux.Client) {
 	bindings := buildKeybindings(KeybindingsContext{
 		PawBin:      getPawBin(),
 		SessionName: appCtx.SessionName,
@@ -126,9 +162,74 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 		ProjectDir:  appCtx.ProjectDir,
 		DisplayName: appCtx.GetDisplayName(),
 	})
+	if tmux.IsCooperative(appCtx.SessionName) {
+		table := tmux.CooperativeKeyTable(appCtx.SessionName)
+		for i := range bindings {
+			if bindings[i].NoPrefix {
+				bindings[i].NoPrefix = false
+				bindings[i].Table = table
+			}
+		}
+		// switch-client -T doesn't expand formats, so go through run-shell;
+		// the table follows the client's session, so one key serves every cooperative session
+		_ = tm.Bind(tmux.BindOpts{
+			Key:     tmux.CooperativeEntryKey,
+			Command: `run-shell "tmux -S '#{socket_path}' switch-client -c '#{client_name}' -T '` + constants.TmuxSocketPrefix + `#{session_name}'"`,
+			Table:   "prefix",
+		})
+	}
 	for _, b := range bindings {
 		if err := tm.Bind(b); err != nil {
 			logging.Debug("Failed to bind %s: %v", b.Key, err)
 		}
 	}
 }
+
+// hookScope returns the set-hook flags for PAW's hooks: global on PAW's own
+// server, or the session on a shared (cooperative) server.
+func hookScope(sessionName string) []string {
+	if tmux.IsCooperative(sessionName) {
+		return []string{"-t", sessionName}
+	}
+	return []string{"-g"}
+}
+
+// applyTmuxMode picks the tmux server for a session that isn't running yet:
+// PAW's own server, or the user's default server in cooperative mode. A
+// running session keeps its server until it ends.
+func applyTmuxMode(appCtx *app.App) error {
+	sessionName := appCtx.SessionName
+	if newTmuxClient(sessionName).HasSession(sessionName) {
+		return nil
+	}
+	// The previous cooperative session ended without restoring the user's options
+	if tmux.IsCooperative(sessionName) {
+		if err := tmux.RestoreCooperative(sessionName); err != nil {
+			logging.Warn("Failed to restore tmux options of the previous session: %v", err)
+		}
+	}
+	if appCtx.Config == nil || appCtx.Config.TmuxMode != constants.TmuxModeCooperative {
+		return nil
+	}
+
+	if err := tmux.EnableCooperative(sessionName); err != nil {
+		return fmt.Errorf("failed to enable cooperative tmux mode: %w", err)
+	}
+	// Without the marker, a session of this name on the default server is the user's
+	if newTmuxClient(sessionName).HasSession(sessionName) {
+		_ = tmux.DisableCooperative(sessionName)
+		return fmt.Errorf("a tmux session named %q already exists on your tmux server; rename it or use tmux_mode: %s", sessionName, constants.TmuxModeDedicated)
+	}
+	return nil
+}
+
+// endCooperativeSession restores the user's tmux options once a cooperative
+// session is gone.
+func endCooperativeSession(tm tmux.Client, sessionName string) {
+	if !tmux.IsCooperative(sessionName) || tm.HasSession(sessionName) {
+		return
+	}
+	if err := tmux.RestoreCooperative(sessionName); err != nil {
+		logging.Warn("Failed to restore tmux options: %v", err)
+	}
+}
@@ -80,20 +80,20 @@ func applyTmuxTheme(tm tmux.Client, preset ThemePreset) {
 
 	// Status bar style
 	statusStyle := "fg=" + colors.statusFg + ",bg=" + colors.statusBg
-	_ = tm.SetOption("status-style", statusStyle, true)
+	_ = tm.SetSessionOption("status-style", statusStyle)
 
 	// Window status format with theme colors
 	windowFormat := "#[fg=" + colors.windowFg + "] #W "
 	windowCurrentFormat := "#[fg=" + colors.windowCurrentFg + ",bg=" + colors.windowCurrentBg + ",bold] #W "
-	_ = tm.SetOption("window-status-format", windowFormat, true)
-	_ = tm.SetOption("window-status-current-format", windowCurrentFormat, true)
+	_ = tm.SetSessionOption("window-status-format", windowFormat)
+	_ = tm.SetSessionOption("window-status-current-format", windowCurrentFormat)
 
 	// Pane borders
-	_ = tm.SetOption("pane-border-style", "fg="+colors.paneBorderFg, true)
-	_ = tm.SetOption("pane-active-border-style", "fg="+colors.paneActiveBorderFg+",bold", true)
+	_ = tm.SetSessionOption("pane-border-style", "fg="+colors.paneBorderFg)
+	_ = tm.SetSessionOption("pane-active-border-style", "fg="+colors.paneActiveBorderFg+",bold")
 
 	// Popup styling
-	_ = tm.SetOption("popup-border-style", "fg="+colors.popupBorderFg, true)
+	_ = tm.SetSessionOption("popup-border-style", "fg="+colors.popupBorderFg)
 
 	logging.Debug("Applied tmux theme: %s", preset)
 }
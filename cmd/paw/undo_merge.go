@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	undoMergeNoPush    bool
+	undoMergeNoRestore bool
+)
+
+var undoMergeCmd = &cobra.Command{
+	Use:   "undo-merge <task|commit>",
+	Short: "Revert a task merged by PAW and restore it for further work",
+	Long: `Find the squash/merge commit PAW created for a task, revert it on the
+main branch, and push the revert.
+
+The task is then restored with a new branch and worktree that re-applies the
+reverted changes, so you can keep working on it and merge again. If a PAW
+session is running, the task window is started right away.
+
+The argument is a task name or a commit created by PAW (with a PAW-Task trailer).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUndoMerge,
+}
+
+func init() {
+	undoMergeCmd.Flags().BoolVar(&undoMergeNoPush, "no-push", false, "Revert locally without pushing")
+	undoMergeCmd.Flags().BoolVar(&undoMergeNoRestore, "no-restore", false, "Only revert; don't restore the task branch and worktree")
+}
+
+func runUndoMerge(_ *cobra.Command, args []string) error {
+	appCtx, err := getAppFromProject()
+	if err != nil {
+		return err
+	}
+	if !appCtx.IsGitRepo {
+		return errors.New("undo-merge requires a git repository")
+	}
+
+	_, cleanup := setupLoggerFromApp(appCtx, "undo-merge", "")
+	defer cleanup()
+
+	gitClient := git.New()
+	projectDir := appCtx.ProjectDir
+	mainBranch := gitClient.GetMainBranch(projectDir)
+
+	mergeCommit, taskName, err := resolveUndoMergeTarget(gitClient, projectDir, args[0], mainBranch)
+	if err != nil {
+		return err
+	}
+
+	if gitClient.HasChanges(projectDir) {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them first", projectDir)
+	}
+	if current, _ := gitClient.GetCurrentBranch(projectDir); current != mainBranch {
+		if err := gitClient.Checkout(projectDir, mainBranch); err != nil {
+			return fmt.Errorf("failed to checkout %s: %w", mainBranch, err)
+		}
+	}
+
+	fmt.Printf("  Task:   %s\n", taskName)
+	fmt.Printf("  Commit: %s\n\n", mergeCommit)
+
+	// Revert the merge on main
+	revertSpinner := tui.NewSimpleSpinner("Reverting merge")
+	revertSpinner.Start()
+	if err := gitClient.RevertCommit(projectDir, mergeCommit, ""); err != nil {
+		revertSpinner.Stop(false, "Failed")
+		_ = gitClient.RevertAbort(projectDir)
+		logging.Warn("undo-merge: revert failed: %v", err)
+		fmt.Println()
+		fmt.Println("  ⚠️  Manual resolution required:")
+		fmt.Printf("     cd %s\n", projectDir)
+		fmt.Printf("     git revert %s\n", mergeCommit)
+		return fmt.Errorf("failed to revert %s: %w", mergeCommit, err)
+	}
+	revertCommit, err := gitClient.GetHeadCommit(projectDir)
+	if err != nil {
+		revertSpinner.Stop(false, "Failed")
+		return err
+	}
+	revertSpinner.Stop(true, "Reverted")
+	logging.Log("undo-merge: reverted %s (task %s) as %s", mergeCommit, taskName, revertCommit)
+
+	// Push the revert
+	if !undoMergeNoPush && gitClient.HasRemote(projectDir, "origin") {
+		pushSpinner := tui.NewSimpleSpinner("Pushing revert")
+		pushSpinner.Start()
+		if err := gitClient.Push(projectDir, "origin", mainBranch, false); err != nil {
+			pushSpinner.Stop(false, "Push failed")
+			logging.Warn("undo-merge: push failed: %v", err)
+			fmt.Printf("  ⚠️  Reverted locally but push failed. Run: git push origin %s\n", mainBranch)
+		} else {
+			pushSpinner.Stop(true, "Pushed")
+		}
+	}
+
+	if undoMergeNoRestore {
+		fmt.Println()
+		fmt.Printf("  ✅ Merge of %s reverted\n", taskName)
+		return nil
+	}
+	if !appCtx.IsWorktreeMode() {
+		fmt.Println()
+		fmt.Printf("  ✅ Merge of %s reverted (task not restored: worktree mode is off)\n", taskName)
+		return nil
+	}
+
+	restored, err := restoreUndoneTask(appCtx, gitClient, taskName, mergeCommit, revertCommit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	tm := newTmuxClient(appCtx.SessionName)
+	if tm.HasSession(appCtx.SessionName) {
+		if err := startTaskHandler(appCtx, restored); err != nil {
+			return err
+		}
+		fmt.Printf("  ✅ Merge reverted; %s restored in %s\n", restored.Name, appCtx.SessionName)
+	} else {
+		fmt.Printf("  ✅ Merge reverted; %s restored (run 'paw' to continue working on it)\n", restored.Name)
+	}
+	return nil
+}
+
+// resolveUndoMergeTarget resolves a task name or PAW merge commit to the merge
+// commit on the main branch and the task name.
+func resolveUndoMergeTarget(gitClient git.Client, projectDir, ref, mainBranch string) (string, string, error) {
+	// A commit created by PAW carries the task name in its trailer
+	if message, err := gitClient.GetCommitMessage(projectDir, ref); err == nil {
+		if taskName := git.ParseTaskTrailer(message); taskName != "" {
+			return ref, taskName, nil
+		}
+	}
+
+	commit, err := gitClient.FindTaskMergeCommit(projectDir, ref, mainBranch)
+	if err != nil || commit == "" {
+		return "", "", fmt.Errorf("no merge by PAW found for %q on %s", ref, mainBranch)
+	}
+	return commit, ref, nil
+}
+
+// restoreUndoneTask recreates the task with a fresh branch from main and
+// re-applies the reverted changes there, so a later merge brings them back.
+func restoreUndoneTask(appCtx *app.App, gitClient git.Client, taskName, mergeCommit, revertCommit string) (*task.Task, error) {
+	content := loadTaskContentFromHistory(appCtx, taskName)
+	if content == "" {
+		content, _ = gitClient.GetCommitMessage(appCtx.ProjectDir, mergeCommit)
+	}
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	restoreSpinner := tui.NewSimpleSpinner("Restoring task")
+	restoreSpinner.Start()
+
+	// The old branch may still exist (e.g. kept after merge); CreateTask then picks a new name
+	t, err := mgr.CreateTask(content, taskName)
+	if err != nil {
+		restoreSpinner.Stop(false, "Failed")
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	opts := &config.TaskOptions{BranchName: t.Name}
+	if err := opts.Save(t.AgentDir); err != nil {
+		logging.Warn("undo-merge: failed to save task options: %v", err)
+	}
+
+	if err := mgr.SetupWorktree(t); err != nil {
+		restoreSpinner.Stop(false, "Failed")
+		return nil, fmt.Errorf("failed to setup worktree: %w", err)
+	}
+
+	// Revert the revert on the task branch
+	if err := gitClient.RevertCommit(t.GetWorktreeDir(), revertCommit, ""); err != nil {
+		restoreSpinner.Stop(false, "Failed")
+		return nil, fmt.Errorf("failed to re-apply changes in %s: %w", t.GetWorktreeDir(), err)
+	}
+
+	restoreSpinner.Stop(true, t.Name)
+	logging.Log("undo-merge: restored task %s with branch %s", taskName, t.Name)
+	return t, nil
+}
+
+// loadTaskContentFromHistory returns the content of the latest completed
+// history entry for the task, or "" if none exists.
+func loadTaskContentFromHistory(appCtx *app.App, taskName string) string {
+	historyService := service.NewHistoryService(appCtx.GetHistoryDir())
+	files, err := historyService.ListHistoryFiles()
+	if err != nil {
+		return ""
+	}
+
+	// File names start with a timestamp, so reverse name order is newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	for _, file := range files {
+		if service.IsCancelled(file) || service.ExtractTaskName(file) != taskName {
+			continue
+		}
+		content, err := historyService.LoadTaskContent(file)
+		if err == nil && strings.TrimSpace(content) != "" {
+			return strings.TrimSpace(content)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+var variantsCmd = &cobra.Command{
+	Use:   "variants",
+	Short: "Compare the prompt variants' completion rates and durations",
+	Long: `Compare the system prompt variants set in prompt_variants. Each new task is
+assigned a variant at random, or the one in its prompt_variant option, and
+how it ended is recorded in .paw/prompt-variants.jsonl.
+
+Completed tasks are the ones finished with anything but drop or cancel; the
+completion rate is over finished tasks, and durations run from the task's
+start to its finish.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		appCtx, err := getAppFromProject()
+		if err != nil {
+			return err
+		}
+		events, err := service.LoadPromptVariantEvents(appCtx.PawDir)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt variants: %w", err)
+		}
+		summary := service.SummarizePromptVariants(events)
+		if len(summary) == 0 {
+			fmt.Fprintln(os.Stderr, "No tasks ran with a prompt variant yet (set prompt_variants in .paw/config)")
+			return nil
+		}
+
+		fmt.Printf("%-20s %6s %6s %10s %10s %10s\n", "VARIANT", "TASKS", "OPEN", "COMPLETION", "MEAN", "MEDIAN")
+		for _, s := range summary {
+			rate := "-"
+			if r := s.CompletionRate(); r >= 0 {
+				rate = fmt.Sprintf("%.0f%%", r*100)
+			}
+			fmt.Printf("%-20s %6d %6d %10s %10s %10s\n", s.Variant, s.Tasks, s.Open, rate,
+				formatVariantDuration(s.MeanDuration(), len(s.Durations)), formatVariantDuration(s.MedianDuration(), len(s.Durations)))
+		}
+		return nil
+	},
+}
+
+func formatVariantDuration(d time.Duration, samples int) string {
+	if samples == 0 {
+		return "-"
+	}
+	return d.Round(time.Minute).String()
+}
+
+// assignPromptVariant returns the system prompt of the task's prompt variant,
+// or "" for PAW's own prompt. On the task's first start a variant is picked
+// at random unless its options name one, then saved and recorded.
+func assignPromptVariant(appCtx *app.App, t *task.Task, taskOpts *config.TaskOptions, agentDir string, isReopen bool) string {
+	if appCtx.Config == nil || len(appCtx.Config.PromptVariants) == 0 {
+		return ""
+	}
+	variant, ok := appCtx.Config.FindPromptVariant(taskOpts.PromptVariant)
+	if !ok {
+		if isReopen {
+			// Started before prompt_variants was set, or its variant was removed
+			return ""
+		}
+		if taskOpts.PromptVariant != "" {
+			logging.Warn("Unknown prompt variant %q; picking one at random", taskOpts.PromptVariant)
+		}
+		variant = service.ChoosePromptVariant(appCtx.Config.PromptVariants)
+		taskOpts.PromptVariant = variant.Name
+		if err := taskOpts.Save(agentDir); err != nil {
+			logging.Warn("Failed to save prompt variant: %v", err)
+		}
+	}
+	if !isReopen {
+		event := service.PromptVariantEvent{Task: t.Name, Variant: variant.Name, Event: service.VariantEventStart}
+		if err := service.RecordPromptVariant(appCtx.PawDir, event); err != nil {
+			logging.Warn("Failed to record prompt variant: %v", err)
+		}
+	}
+	logging.Info("Prompt variant: task=%s variant=%s", t.Name, variant.Name)
+
+	prompt, err := service.LoadVariantPrompt(appCtx.PawDir, variant)
+	if err != nil {
+		logging.Warn("Failed to read prompt of variant %s, using the default prompt: %v", variant.Name, err)
+		return ""
+	}
+	return prompt
+}
+
+// recordPromptVariantFinish records how a task that ran with a prompt variant
+// ended (the finish action, or cancelled).
+func recordPromptVariantFinish(appCtx *app.App, t *task.Task, outcome string) {
+	taskOpts, err := config.LoadTaskOptions(t.AgentDir)
+	if err != nil || taskOpts.PromptVariant == "" {
+		return
+	}
+	event := service.PromptVariantEvent{Task: t.Name, Variant: taskOpts.PromptVariant, Event: service.VariantEventFinish, Outcome: outcome}
+	if err := service.RecordPromptVariant(appCtx.PawDir, event); err != nil {
+		logging.Warn("Failed to record prompt variant outcome: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -38,8 +39,13 @@ var askUserQuestionUIMarkers = []string{
 //
 // This watcher only:
 //  1. Detects when window is in WAITING state (set by hooks)
-//  2. Parses prompt content and sends notifications
+//  2. Parses prompt content and sends notifications (with the question asked)
 //  3. Handles notification action responses
+//  4. Finishes done tasks left unreviewed past confirm_timeout_hours
+//  5. Holds notifications for snoozed tasks and re-alerts when the snooze ends
+//  6. Switches to the task window when it starts waiting (focus-follow)
+//  7. Answers prompts matching the auto_answers rules
+//  8. Pauses the agent when working_hours end and resumes it when they start
 var watchWaitCmd = &cobra.Command{
 	Use:   "watch-wait [session] [window-id] [task-name]",
 	Short: "Watch agent output and notify when user input is needed",
@@ -60,12 +66,20 @@ var watchWaitCmd = &cobra.Command{
 		logging.Debug("-> watchWaitCmd(session=%s, windowID=%s, task=%s)", sessionName, windowID, taskName)
 		defer logging.Debug("<- watchWaitCmd")
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		paneID := windowID + ".0"
 
 		var lastContent string
 		var lastPromptKey string
+		var lastQuestion string
 		notified := false
+		followed := false
+		timebox := newConfirmTimebox(app)
+		answerer := newAutoAnswerer(app)
+		hoursGate := newWorkingHoursGate(app, time.Now())
+		t := task.New(taskName, app.GetAgentDir(taskName))
+		// Drop a question left over from a previous watcher
+		_ = t.ClearQuestion()
 
 		for {
 			if !tm.HasPane(paneID) {
@@ -90,17 +104,64 @@ var watchWaitCmd = &cobra.Command{
 				}
 			}
 
+			if timebox != nil {
+				timebox.poll(app, tm, sessionName, windowID, taskName, windowName)
+			}
+
 			isWaiting := isWaitingWindow(windowName)
 
+			// Paused outside the working hours: nothing to notify about
+			if hoursGate != nil && hoursGate.poll(app, tm, windowID, paneID, t, isWaiting) {
+				time.Sleep(waitPollInterval)
+				continue
+			}
+
+			// Auto-answer known prompts of a waiting agent (a working agent's
+			// output may quote them); skip notifying while it picks up the answer
+			if answerer != nil {
+				if isWaiting {
+					answerer.poll(tm, paneID, t)
+				}
+				if answerer.settling(time.Now()) {
+					time.Sleep(waitPollInterval)
+					continue
+				}
+			}
+
 			// Reset notified flag when window leaves waiting state
 			// This allows re-notification when a new wait state begins
 			if !isWaiting {
 				notified = false
+				followed = false
 				lastPromptKey = ""
+				if lastQuestion != "" {
+					lastQuestion = ""
+					if err := t.ClearQuestion(); err != nil {
+						logging.Debug("Failed to clear question: %v", err)
+					}
+				}
+			}
+
+			// Snoozed tasks stay quiet; once the snooze ends, alert again
+			snoozed, snoozeExpired := snoozeCheck(t, time.Now())
+			if snoozeExpired {
+				logging.Info("watchWaitCmd: snooze ended for task=%s", taskName)
+				if isWaiting {
+					notified = false
+					lastPromptKey = ""
+					lastContent = ""
+				} else if isFinalWindow(windowName) {
+					notifySnoozeEnded(taskName)
+				}
+			}
+
+			// Focus-follow: jump to the task once it waits (held while the user types)
+			if isWaiting && !followed && !snoozed {
+				followed = followWaitingTask(tm, sessionName, windowID, taskName)
 			}
 
 			// Only process notifications when in WAITING state (set by hooks)
-			if isWaiting && !notified {
+			if isWaiting && !notified && !snoozed {
 				content, err := tm.CapturePane(paneID, waitCaptureLines)
 				if err != nil {
 					errStr := err.Error()
@@ -129,6 +190,18 @@ var watchWaitCmd = &cobra.Command{
 						}
 					}
 
+					// Record the question for the Kanban card
+					question := prompt.Question
+					if !ok {
+						question = extractWaitingQuestion(content)
+					}
+					if question != "" && question != lastQuestion {
+						lastQuestion = question
+						if err := t.SetQuestion(questionPreview(question)); err != nil {
+							logging.Debug("Failed to save question: %v", err)
+						}
+					}
+
 					if ok {
 						promptKey := prompt.key()
 						// Only notify if: prompt is valid and it's a new prompt
@@ -136,7 +209,7 @@ var watchWaitCmd = &cobra.Command{
 							lastPromptKey = promptKey
 							notified = true
 							// Try notification actions for simple prompts
-							choice := tryNotificationAction(taskName, prompt)
+							choice := tryNotificationAction(sessionName, taskName, prompt)
 							// If user selects an action from notification, send it to the agent
 							if choice != "" {
 								if sendErr := sendAgentResponse(tm, paneID, choice); sendErr != nil {
@@ -149,7 +222,7 @@ var watchWaitCmd = &cobra.Command{
 					} else {
 						// No parseable prompt, send simple notification
 						logging.Debug("Wait state detected, sending notification")
-						notifyWaitingWithDisplay(tm, taskName, "input needed")
+						notifyWaitingWithDisplay(tm, sessionName, taskName, question)
 						notified = true
 					}
 				}
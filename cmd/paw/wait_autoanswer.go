@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+const (
+	// autoAnswerTailLines is how many non-empty lines at the bottom of the
+	// pane the auto-answer rules are matched against.
+	autoAnswerTailLines = 10
+	// autoAnswerSettle holds back wait notifications right after an
+	// auto-answer, while the agent picks up the response.
+	autoAnswerSettle = 5 * time.Second
+)
+
+// autoAnswerer sends configured responses to prompts that appear in a task's
+// pane and records every answer in the task's auto-answer log.
+type autoAnswerer struct {
+	rules      []service.AutoAnswerRule
+	lastMatch  string    // Text answered last; not answered again while still on screen
+	answeredAt time.Time // When the last answer was sent
+}
+
+// newAutoAnswerer returns an answerer for the app's auto_answers rules, or nil
+// if there are none.
+func newAutoAnswerer(appCtx *app.App) *autoAnswerer {
+	if appCtx.Config == nil || len(appCtx.Config.AutoAnswers) == 0 {
+		return nil
+	}
+	rules, errs := service.ParseAutoAnswerRules(appCtx.Config.AutoAnswers)
+	for _, err := range errs {
+		logging.Warn("newAutoAnswerer: skipping invalid rule: %v", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return &autoAnswerer{rules: rules}
+}
+
+// poll matches the rules against the pane tail and answers a new match.
+// It reports whether an answer was sent.
+func (a *autoAnswerer) poll(tm tmux.Client, paneID string, t *task.Task) bool {
+	content, err := tm.CapturePane(paneID, waitCaptureLines)
+	if err != nil {
+		return false
+	}
+	rule, match := service.MatchAutoAnswer(a.rules, service.PaneTail(content, autoAnswerTailLines))
+	if rule == nil {
+		a.lastMatch = ""
+		return false
+	}
+	if match == a.lastMatch {
+		return false
+	}
+	a.lastMatch = match
+
+	logging.Info("autoAnswerer: task=%s matched %q, answering %q", t.Name, match, rule.Response)
+	if err := newClaudeClient().SendInputWithRetry(tm, paneID, rule.Response, 3); err != nil {
+		logging.Warn("autoAnswerer: failed to send answer: %v", err)
+		return false
+	}
+	a.answeredAt = time.Now()
+
+	rec := service.AutoAnswerRecord{
+		Time:     a.answeredAt,
+		Task:     t.Name,
+		Rule:     rule.Source,
+		Matched:  match,
+		Response: rule.Response,
+	}
+	if err := service.AppendAutoAnswer(t.GetAutoAnswersPath(), rec); err != nil {
+		logging.Warn("autoAnswerer: failed to record answer: %v", err)
+	}
+	_ = tm.DisplayMessage(fmt.Sprintf("🤖 %s: answered %q", t.Name, rule.Response), constants.DisplayMsgQuick)
+	return true
+}
+
+// settling reports whether an answer was sent too recently to notify.
+func (a *autoAnswerer) settling(now time.Time) bool {
+	return now.Sub(a.answeredAt) < autoAnswerSettle
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// confirmTimebox finishes a task left waiting for review (done, confirm mode)
+// once it has been untouched for confirm_timeout_hours. Tasks with a recorded
+// failure are never finished automatically, nor merged with a diff over the
+// auto-merge limits.
+type confirmTimebox struct {
+	timeout time.Duration
+	action  string
+
+	active       bool      // Window is done and the task is in confirm mode
+	doneSince    time.Time // When the watcher first saw the window done
+	warnedFor    time.Time // Deadline the warning was sent for
+	snoozedUntil time.Time // Latest snooze deadline seen while done
+	fired        bool
+}
+
+// newConfirmTimebox returns a timebox for the app's config, or nil if the
+// timeout is off.
+func newConfirmTimebox(appCtx *app.App) *confirmTimebox {
+	if appCtx.Config == nil || appCtx.Config.ConfirmTimeoutHours <= 0 {
+		return nil
+	}
+	return &confirmTimebox{
+		timeout: time.Duration(appCtx.Config.ConfirmTimeoutHours) * time.Hour,
+		action:  appCtx.Config.ConfirmTimeoutAction,
+	}
+}
+
+// step advances the timebox for a done window last active at lastActive.
+// It reports whether to send the warning and whether to finish the task now.
+func (b *confirmTimebox) step(now, lastActive time.Time) (warn, finish bool) {
+	if b.fired {
+		return false, false
+	}
+	if lastActive.Before(b.doneSince) {
+		lastActive = b.doneSince
+	}
+	deadline := lastActive.Add(b.timeout)
+	if !now.Before(deadline) {
+		b.fired = true
+		return false, true
+	}
+	if !now.Before(deadline.Add(-constants.ConfirmTimeoutWarning)) && !b.warnedFor.Equal(deadline) {
+		b.warnedFor = deadline
+		return true, false
+	}
+	return false, false
+}
+
+// poll checks the task window on each watcher iteration.
+func (b *confirmTimebox) poll(appCtx *app.App, tm tmux.Client, sessionName, windowID, taskName, windowName string) {
+	if !isFinalWindow(windowName) {
+		*b = confirmTimebox{timeout: b.timeout, action: b.action}
+		return
+	}
+	if !b.active {
+		if b.fired || taskOnComplete(appCtx.Config, appCtx.PawDir, taskName) != constants.OnCompleteConfirm {
+			return
+		}
+		// Tasks that ended on a failure need a human decision
+		if service.LoadFailureRecord(filepath.Join(appCtx.GetAgentDir(taskName), constants.FailureFileName)) != nil {
+			return
+		}
+		b.active = true
+		b.doneSince = time.Now()
+	}
+
+	// A snooze postpones the deadline as if the user had looked at the task
+	// when it ended. Remember it: the watcher clears the snooze once it expires.
+	if until := task.New(taskName, appCtx.GetAgentDir(taskName)).SnoozedUntil(); until.After(b.snoozedUntil) {
+		b.snoozedUntil = until
+	}
+	lastActive := windowActivity(tm, windowID)
+	if b.snoozedUntil.After(lastActive) {
+		lastActive = b.snoozedUntil
+	}
+	warn, finish := b.step(time.Now(), lastActive)
+	if warn {
+		logging.Info("confirmTimebox: task=%s auto-finishes (%s) in %s", taskName, b.action, constants.ConfirmTimeoutWarning)
+		_ = notify.Send("Review timing out", fmt.Sprintf("⏰ %s will be finished (%s) in %d minutes", taskName, b.action, int(constants.ConfirmTimeoutWarning.Minutes())))
+	}
+	if finish {
+		if holdLargeDiff(appCtx.Config, appCtx.PawDir, taskName, b.action) {
+			return
+		}
+		logging.Info("confirmTimebox: task=%s untouched for %s, finishing with %s", taskName, b.timeout, b.action)
+		if err := startEndTaskUI(sessionName, windowID, appCtx.PawDir, b.action); err != nil {
+			logging.Warn("confirmTimebox: failed to start end-task: %v", err)
+		}
+	}
+}
+
+// windowActivity returns the time of the last activity (output or input) in a window.
+func windowActivity(tm tmux.Client, windowID string) time.Time {
+	out, err := tm.RunWithOutput("display-message", "-t", windowID, "-p", "#{window_activity}")
+	if err != nil {
+		return time.Time{}
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
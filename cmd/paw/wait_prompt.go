@@ -206,29 +206,71 @@ func isUIHintLine(line string) bool {
 	return false
 }
 
-func notifyWaiting(taskName, reason string) {
-	logging.Debug("-> notifyWaiting(task=%s, reason=%s)", taskName, reason)
+// waitingQuestionScanLines is how many non-empty lines at the bottom of the
+// pane are searched for the agent's question.
+const waitingQuestionScanLines = 15
+
+// waitingQuestionMaxLen caps the question shown in notifications and the Kanban.
+const waitingQuestionMaxLen = 160
+
+// extractWaitingQuestion finds the question the agent asked in the pane tail:
+// the last line ending with a question mark. Returns "" if there is none.
+func extractWaitingQuestion(content string) string {
+	lines := trimTrailingEmpty(strings.Split(content, "\n"))
+	scanned := 0
+	for i := len(lines) - 1; i >= 0 && scanned < waitingQuestionScanLines; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		scanned++
+		if isUIHintLine(line) || isUIHeaderLine(line) {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "⏺"))
+		if strings.HasSuffix(line, "?") || strings.HasSuffix(line, "？") {
+			return line
+		}
+	}
+	return ""
+}
+
+// questionPreview shortens a question for notification bodies and the Kanban.
+func questionPreview(question string) string {
+	question = strings.Join(strings.Fields(question), " ")
+	runes := []rune(question)
+	if len(runes) <= waitingQuestionMaxLen {
+		return question
+	}
+	return string(runes[:waitingQuestionMaxLen-1]) + "…"
+}
+
+func notifyWaiting(sessionName, taskName, question string) {
+	logging.Debug("-> notifyWaiting(task=%s, question=%q)", taskName, question)
 	defer logging.Debug("<- notifyWaiting")
 
 	title := taskName
 	message := "Waiting for your response"
+	if question != "" {
+		message = questionPreview(question)
+	}
 	logging.Trace("notifyWaiting: sending notifications title=%s", title)
-	// Send desktop notification
-	_ = notify.Send(title, message)
+	// Send desktop notification (clicking it focuses the task window)
+	_ = notify.SendWithLink(title, message, notify.TaskLink(sessionName, taskName))
 	// Play sound to alert user
 	logging.Trace("notifyWaiting: playing SoundNeedInput")
 	notify.PlaySound(notify.SoundNeedInput)
 }
 
-func notifyWaitingWithDisplay(tm tmux.Client, taskName, reason string) {
-	logging.Debug("-> notifyWaitingWithDisplay(task=%s, reason=%s)", taskName, reason)
+func notifyWaitingWithDisplay(tm tmux.Client, sessionName, taskName, question string) {
+	logging.Debug("-> notifyWaitingWithDisplay(task=%s, question=%q)", taskName, question)
 	defer logging.Debug("<- notifyWaitingWithDisplay")
 
-	notifyWaiting(taskName, reason)
+	notifyWaiting(sessionName, taskName, question)
 	// Show message in tmux status bar
 	displayMsg := fmt.Sprintf("💬 %s needs input", taskName)
-	if reason != "" && reason != "window" && reason != "marker" {
-		displayMsg = fmt.Sprintf("💬 %s: %s", taskName, reason)
+	if question != "" {
+		displayMsg = fmt.Sprintf("💬 %s: %s", taskName, questionPreview(question))
 	}
 	logging.Trace("notifyWaitingWithDisplay: displaying message=%s", displayMsg)
 	if err := tm.DisplayMessage(displayMsg, 3000); err != nil {
@@ -250,7 +292,7 @@ func sendAgentResponse(tm tmux.Client, paneID, response string) error {
 // for simple prompts (2-5 options). Returns the selected option or empty string
 // if notification was not shown or user didn't select an action.
 // Always sends a notification: either with action buttons (2-5 options) or a simple one (fallback).
-func tryNotificationAction(taskName string, prompt askPrompt) string {
+func tryNotificationAction(sessionName, taskName string, prompt askPrompt) string {
 	logging.Debug("-> tryNotificationAction(task=%s, question=%q, options=%v)",
 		taskName, prompt.Question, prompt.Options)
 	defer logging.Debug("<- tryNotificationAction")
@@ -260,13 +302,13 @@ func tryNotificationAction(taskName string, prompt askPrompt) string {
 	if len(prompt.Options) < 2 || len(prompt.Options) > notifyMaxActions {
 		logging.Trace("tryNotificationAction: option count=%d not in range [2,%d], sending simple notification",
 			len(prompt.Options), notifyMaxActions)
-		notifyWaiting(taskName, prompt.Question)
+		notifyWaiting(sessionName, taskName, prompt.Question)
 		return ""
 	}
 
 	// Show notification with actions (no icon attachment - use only app icon on left side)
 	title := taskName
-	message := prompt.Question
+	message := questionPreview(prompt.Question)
 
 	logging.Debug("tryNotificationAction: showing notification with %d actions", len(prompt.Options))
 	index, err := notify.SendWithActions(title, message, "", prompt.Options, notifyTimeoutSec)
@@ -3,6 +3,10 @@ package main
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
 )
 
 func TestDetectWaitInContentAskUserQuestionUI(t *testing.T) {
@@ -275,3 +279,137 @@ func TestDetectDoneInContent(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmTimeboxStep(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	b := &confirmTimebox{timeout: 2 * time.Hour, action: "merge", active: true, doneSince: start}
+
+	if warn, finish := b.step(start.Add(time.Hour), time.Time{}); warn || finish {
+		t.Errorf("1h in: warn=%v finish=%v, want neither", warn, finish)
+	}
+	if warn, _ := b.step(start.Add(106*time.Minute), time.Time{}); !warn {
+		t.Error("14m before the deadline: no warning")
+	}
+	if warn, _ := b.step(start.Add(107*time.Minute), time.Time{}); warn {
+		t.Error("warning sent twice for the same deadline")
+	}
+
+	// Activity in the window pushes the deadline out and re-arms the warning
+	touched := start.Add(110 * time.Minute)
+	if _, finish := b.step(start.Add(2*time.Hour), touched); finish {
+		t.Error("finished although the window was touched 10m ago")
+	}
+	if warn, _ := b.step(touched.Add(110*time.Minute), touched); !warn {
+		t.Error("no warning for the new deadline")
+	}
+	if _, finish := b.step(touched.Add(2*time.Hour), touched); !finish {
+		t.Error("not finished at the deadline")
+	}
+	if warn, finish := b.step(touched.Add(3*time.Hour), touched); warn || finish {
+		t.Error("timebox fired twice")
+	}
+}
+
+func TestWorkingHoursGateStep(t *testing.T) {
+	hours, err := config.ParseWorkingHours("08:00-20:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(hour int) time.Time { return time.Date(2026, 1, 1, hour, 0, 0, 0, time.Local) }
+	g := &workingHoursGate{hours: hours, inside: true}
+
+	if pause, resume := g.step(at(19)); pause || resume {
+		t.Errorf("19:00: pause=%v resume=%v, want neither", pause, resume)
+	}
+	if pause, _ := g.step(at(20)); !pause {
+		t.Error("20:00: hours ended without a pause")
+	}
+	if pause, _ := g.step(at(23)); pause {
+		t.Error("23:00: paused twice")
+	}
+
+	// Nothing was paused (the agent was idle), so nothing to resume
+	if _, resume := g.step(at(8)); resume {
+		t.Error("08:00: resumed an agent that was not paused")
+	}
+
+	g.step(at(20))
+	g.paused = true
+	if _, resume := g.step(at(8)); !resume {
+		t.Error("08:00: paused agent not resumed")
+	}
+}
+
+func TestParseSnoozeArg(t *testing.T) {
+	tests := []struct {
+		arg     string
+		snoozed bool
+		want    time.Duration
+		wantErr bool
+	}{
+		{arg: "", snoozed: false, want: constants.DefaultSnoozeDuration},
+		{arg: "", snoozed: true, want: 0},
+		{arg: "off", snoozed: true, want: 0},
+		{arg: "30m", want: 30 * time.Minute},
+		{arg: "2h", snoozed: true, want: 2 * time.Hour},
+		{arg: "-5m", wantErr: true},
+		{arg: "lunch", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSnoozeArg(tt.arg, tt.snoozed)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSnoozeArg(%q, %t) error = %v, wantErr %t", tt.arg, tt.snoozed, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSnoozeArg(%q, %t) = %s, want %s", tt.arg, tt.snoozed, got, tt.want)
+		}
+	}
+}
+
+func TestExtractWaitingQuestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "last question in tail",
+			content: strings.Join([]string{
+				"⏺ Is this the right file?",
+				"⏺ I found two configs. Should I update both or only the staging one?",
+				"",
+				"> ",
+				"",
+			}, "\n"),
+			want: "I found two configs. Should I update both or only the staging one?",
+		},
+		{
+			name:    "no question",
+			content: "⏺ Done editing the file.\n> \n",
+			want:    "",
+		},
+		{
+			name: "question above the scan window",
+			content: "Which branch should I use?\n" +
+				strings.Repeat("output line\n", waitingQuestionScanLines),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		if got := extractWaitingQuestion(tt.content); got != tt.want {
+			t.Errorf("%s: extractWaitingQuestion() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuestionPreview(t *testing.T) {
+	if got := questionPreview("Proceed  with\nthe   migration?"); got != "Proceed with the migration?" {
+		t.Errorf("questionPreview() = %q", got)
+	}
+	long := strings.Repeat("가", waitingQuestionMaxLen+10)
+	got := []rune(questionPreview(long))
+	if len(got) != waitingQuestionMaxLen || got[len(got)-1] != '…' {
+		t.Errorf("questionPreview() of long question has %d runes, want %d ending in …", len(got), waitingQuestionMaxLen)
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/github"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
@@ -48,7 +50,7 @@ var watchPRCmd = &cobra.Command{
 			return nil
 		}
 
-		tm := tmux.New(sessionName)
+		tm := newTmuxClient(sessionName)
 		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 
 		ticker := time.NewTicker(constants.PRWatchInterval)
@@ -83,7 +85,9 @@ var watchPRCmd = &cobra.Command{
 					return nil
 				}
 
-				if err := mgr.CleanupTask(t); err != nil {
+				recordPromptVariantFinish(appCtx, t, constants.ActionPR)
+				restackAfterPRMerge(appCtx, taskName, tm)
+				if err := mgr.CompleteTask(t, false); err != nil {
 					logging.Warn("Failed to clean up task: %v", err)
 				}
 
@@ -111,3 +115,16 @@ var watchPRCmd = &cobra.Command{
 		}
 	},
 }
+
+// restackAfterPRMerge rebases the tasks stacked on a task whose PR merged
+// onto the remote main branch, which has the merge. Must run before the
+// task's branch is deleted.
+func restackAfterPRMerge(appCtx *app.App, taskName string, tm tmux.Client) {
+	gitClient := git.New()
+	if err := gitClient.Fetch(appCtx.ProjectDir, "origin"); err != nil {
+		logging.Warn("restackAfterPRMerge: failed to fetch origin: %v", err)
+		return
+	}
+	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+	restackChildrenOnto(appCtx, taskName, "origin/"+mainBranch, gitClient, tm)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// workingHoursResumePrompt is sent to agents paused at the end of the
+// working hours once the hours start again.
+const workingHoursResumePrompt = "Working hours have started again. Please continue the task from where you stopped."
+
+// waitForWorkingHours queues a task created outside the working hours until
+// they start again.
+func waitForWorkingHours(appCtx *app.App, tm tmux.Client, windowID string, t *task.Task) {
+	hours := appCtx.Config.GetWorkingHours()
+	now := time.Now()
+	if hours == nil || hours.Contains(now) {
+		return
+	}
+
+	next := hours.NextStart(now)
+	logging.Info("Task %s queued until %s (working_hours %s)", t.Name, next.Format(time.RFC3339), hours)
+	waitName := windowNameForStatus(t.Name, task.StatusWaiting)
+	_ = renameWindowWithStatus(tm, windowID, waitName, appCtx.PawDir, t.Name, "working-hours", task.StatusWaiting)
+	_ = tm.DisplayMessage(fmt.Sprintf("🌙 %s queued until %s (working hours %s)", t.Name, next.Format("15:04"), hours), 3000)
+
+	for !hours.Contains(time.Now()) {
+		time.Sleep(constants.WorkingHoursPollInterval)
+	}
+
+	logging.Info("Task %s leaving the working hours queue", t.Name)
+	workingName := windowNameForStatus(t.Name, task.StatusWorking)
+	_ = renameWindowWithStatus(tm, windowID, workingName, appCtx.PawDir, t.Name, "working-hours", task.StatusWorking)
+}
+
+// workingHoursGate pauses a task's agent when the working hours end and
+// resumes it when they start again.
+type workingHoursGate struct {
+	hours  *config.WorkingHours
+	inside bool // Whether the last poll was within the working hours
+	paused bool // Whether the gate paused the agent
+}
+
+// newWorkingHoursGate returns a gate for the app's working_hours, or nil if
+// agents may run at any time.
+func newWorkingHoursGate(appCtx *app.App, now time.Time) *workingHoursGate {
+	hours := appCtx.Config.GetWorkingHours()
+	if hours == nil {
+		return nil
+	}
+	return &workingHoursGate{hours: hours, inside: hours.Contains(now)}
+}
+
+// step advances the gate to now. It reports whether the hours just ended
+// (pause a busy agent) or just started again after a pause (resume it).
+func (g *workingHoursGate) step(now time.Time) (pause, resume bool) {
+	inside := g.hours.Contains(now)
+	defer func() { g.inside = inside }()
+	switch {
+	case g.inside && !inside:
+		return true, false
+	case !g.inside && inside && g.paused:
+		return false, true
+	}
+	return false, false
+}
+
+// poll pauses or resumes the agent at the working hours boundaries. It
+// reports whether the agent is paused, so wait notifications are held back.
+// A paused task that left the waiting state was resumed by the user.
+func (g *workingHoursGate) poll(appCtx *app.App, tm tmux.Client, windowID, paneID string, t *task.Task, isWaiting bool) bool {
+	if g.paused && !isWaiting {
+		g.paused = false
+	}
+	pause, resume := g.step(time.Now())
+	switch {
+	case pause:
+		content, err := tm.CapturePane(paneID, 10)
+		if err != nil || !agentBusy(content) {
+			return false
+		}
+		interruptAgent(tm, paneID)
+		g.paused = true
+
+		next := g.hours.NextStart(time.Now())
+		logging.Info("workingHoursGate: paused task=%s until %s", t.Name, next.Format(time.RFC3339))
+		waitName := windowNameForStatus(t.Name, task.StatusWaiting)
+		_ = renameWindowWithStatus(tm, windowID, waitName, appCtx.PawDir, t.Name, "working-hours", task.StatusWaiting)
+		_ = notify.Send("Agent paused", fmt.Sprintf("🌙 %s paused until %s (working hours)", t.Name, next.Format("15:04")))
+
+	case resume:
+		g.paused = false
+		logging.Info("workingHoursGate: resuming task=%s", t.Name)
+		if err := newClaudeClient().SendInputWithRetry(tm, paneID, workingHoursResumePrompt, 3); err != nil {
+			logging.Warn("workingHoursGate: failed to resume task=%s: %v", t.Name, err)
+			return false
+		}
+		workingName := windowNameForStatus(t.Name, task.StatusWorking)
+		_ = renameWindowWithStatus(tm, windowID, workingName, appCtx.PawDir, t.Name, "working-hours", task.StatusWorking)
+		_ = notify.Send("Agent resumed", fmt.Sprintf("☀️ %s resumed (working hours)", t.Name))
+	}
+	return g.paused
+}
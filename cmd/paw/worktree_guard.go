@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// taskWorktree is a PAW task's worktree (<paw-dir>/agents/<task>/worktree).
+type taskWorktree struct {
+	TaskName   string
+	AgentDir   string
+	ProjectDir string // Empty if it can't be resolved
+}
+
+// findTaskWorktree returns the PAW task worktree containing dir, if any.
+func findTaskWorktree(dir string) (*taskWorktree, bool) {
+	for d := filepath.Clean(dir); ; {
+		if filepath.Base(d) == constants.WorktreeDirName {
+			agentDir := filepath.Dir(d)
+			agentsDir := filepath.Dir(agentDir)
+			pawDir := filepath.Dir(agentsDir)
+			if filepath.Base(agentsDir) == constants.AgentsDirName && pathExists(filepath.Join(pawDir, constants.ConfigFileName)) {
+				return &taskWorktree{
+					TaskName:   filepath.Base(agentDir),
+					AgentDir:   agentDir,
+					ProjectDir: worktreeProjectDir(pawDir, agentDir),
+				}, true
+			}
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return nil, false
+		}
+		d = parent
+	}
+}
+
+// worktreeProjectDir returns the project a task belongs to: the target of
+// its origin link (or the path in its origin file, with link_mode: copy),
+// else the workspace's project.
+func worktreeProjectDir(pawDir, agentDir string) string {
+	originPath := filepath.Join(agentDir, constants.OriginLinkName)
+	if info, err := os.Lstat(originPath); err == nil {
+		if info.Mode()&os.ModeSymlink != 0 {
+			if target, err := filepath.EvalSymlinks(originPath); err == nil {
+				return target
+			}
+		} else if data, err := os.ReadFile(originPath); err == nil { //nolint:gosec // G304: origin file of a PAW task
+			if target := strings.TrimSpace(string(data)); target != "" {
+				return target
+			}
+		}
+	}
+	if filepath.Base(pawDir) == constants.PawDirName {
+		return filepath.Dir(pawDir)
+	}
+	if data, err := os.ReadFile(filepath.Join(pawDir, constants.ProjectPathFileName)); err == nil { //nolint:gosec // G304: pawDir is a PAW workspace
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
+
+// refuseTaskWorktree stops 'paw' from treating a task worktree as a new
+// project. When the task's project session is running, it offers to attach
+// to it with the task's window selected.
+func refuseTaskWorktree(wt *taskWorktree) error {
+	logging.Warn("paw started inside the worktree of task %s", wt.TaskName)
+	if wt.ProjectDir == "" {
+		return fmt.Errorf("this is the worktree of PAW task %s, not a project; run 'paw' in the project instead", wt.TaskName)
+	}
+
+	projectApp, err := app.NewWithGitInfo(wt.ProjectDir, git.New().IsGitRepo(wt.ProjectDir))
+	if err != nil {
+		return fmt.Errorf("failed to create app: %w", err)
+	}
+	sessionName := projectApp.SessionName
+	tm := newTmuxClient(sessionName)
+	if !tm.HasSession(sessionName) {
+		return fmt.Errorf("this is the worktree of PAW task %s, not a project; run 'paw' in %s instead", wt.TaskName, wt.ProjectDir)
+	}
+
+	fmt.Printf("⚠️  This is the worktree of PAW task %s (project %s)\n", wt.TaskName, wt.ProjectDir)
+	if !canPrompt() || !confirmPrompt(fmt.Sprintf("Attach to the %s session instead? [y/N]: ", sessionName)) {
+		return fmt.Errorf("not starting PAW inside a task worktree; attach with 'paw attach %s'", sessionName)
+	}
+
+	if windowID, err := task.New(wt.TaskName, wt.AgentDir).LoadWindowID(); err == nil && windowID != "" {
+		if err := tm.SelectWindow(windowID); err != nil {
+			logging.Debug("refuseTaskWorktree: failed to select task window: %v", err)
+		}
+	}
+	return attachToPawSession(pawSession{Name: sessionName})
+}
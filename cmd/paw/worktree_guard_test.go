@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindTaskWorktree(t *testing.T) {
+	projectDir := t.TempDir()
+	pawDir := filepath.Join(projectDir, ".paw")
+	agentDir := filepath.Join(pawDir, "agents", "fix-login")
+	subDir := filepath.Join(agentDir, "worktree", "internal", "auth")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pawDir, "config"), []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, ok := findTaskWorktree(subDir)
+	if !ok {
+		t.Fatal("findTaskWorktree() did not detect the task worktree")
+	}
+	if wt.TaskName != "fix-login" || wt.AgentDir != agentDir || wt.ProjectDir != projectDir {
+		t.Errorf("findTaskWorktree() = %+v", wt)
+	}
+
+	if _, ok := findTaskWorktree(projectDir); ok {
+		t.Error("findTaskWorktree(project) detected a task worktree")
+	}
+
+	// A directory that merely looks like one isn't a task worktree
+	lookalike := filepath.Join(t.TempDir(), "agents", "x", "worktree")
+	if err := os.MkdirAll(lookalike, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findTaskWorktree(lookalike); ok {
+		t.Error("findTaskWorktree() detected a worktree without a PAW workspace")
+	}
+}
+
+func TestWorktreeProjectDirOrigin(t *testing.T) {
+	pawDir := filepath.Join(t.TempDir(), "workspace")
+	agentDir := filepath.Join(pawDir, "agents", "fix-login")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectDir := t.TempDir()
+	originPath := filepath.Join(agentDir, "origin")
+
+	// link_mode: copy writes the project path into a plain file
+	if err := os.WriteFile(originPath, []byte(projectDir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := worktreeProjectDir(pawDir, agentDir); got != projectDir {
+		t.Errorf("worktreeProjectDir(origin file) = %q, want %q", got, projectDir)
+	}
+
+	if err := os.Remove(originPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(projectDir, originPath); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(projectDir)
+	if got := worktreeProjectDir(pawDir, agentDir); got != want {
+		t.Errorf("worktreeProjectDir(origin link) = %q, want %q", got, want)
+	}
+}
@@ -10,8 +10,11 @@ import (
 
 	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/embed"
 	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
 )
 
 // App represents the main application context with all dependencies.
@@ -28,7 +31,8 @@ type App struct {
 
 	// State
 	IsGitRepo bool           // Whether the project is a git repository
-	Config    *config.Config // Project configuration
+	Config    *config.Config // Project configuration (with the organization policy applied)
+	Policy    *config.Policy // Organization policy (nil if none)
 
 	// Runtime
 	Debug bool // Debug mode enabled
@@ -107,6 +111,9 @@ func (a *App) Initialize() error {
 
 // LoadConfig loads the project configuration.
 func (a *App) LoadConfig() error {
+	// Overrides apply even when the config cannot be loaded
+	embed.SetOverrideDir(filepath.Join(a.PawDir, constants.OverridesDirName))
+
 	cfg, err := config.Load(a.PawDir)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -114,7 +121,25 @@ func (a *App) LoadConfig() error {
 	for _, warning := range cfg.Normalize() {
 		logging.Warn("config: %s", warning)
 	}
+	policy, err := config.LoadPolicy()
+	if err != nil {
+		return err
+	}
+	for _, note := range policy.Apply(cfg) {
+		logging.Info("policy: %s", note)
+	}
 	a.Config = cfg
+	a.Policy = policy
+	notify.SetChannels(notify.Channels{
+		SlackToken:   cfg.Notifications.SlackToken,
+		SlackChannel: cfg.Notifications.SlackChannel,
+		NtfyServer:   cfg.Notifications.NtfyServer,
+		NtfyTopic:    cfg.Notifications.NtfyTopic,
+	})
+	notify.SetMuteFile(filepath.Join(a.PawDir, constants.MuteFileName))
+	git.SetNetworkRetries(cfg.GitNetworkRetries)
+	e := cfg.StatusEmojis
+	constants.SetStatusEmojis(e.Working, e.Waiting, e.Review, e.Warning, e.Done)
 	return nil
 }
 
@@ -134,6 +159,11 @@ func (a *App) GetLogPath() string {
 	return filepath.Join(a.PawDir, constants.LogFileName)
 }
 
+// GetAuditLogPath returns the path to the git audit log.
+func (a *App) GetAuditLogPath() string {
+	return filepath.Join(a.PawDir, constants.AuditLogFileName)
+}
+
 // GetHistoryDir returns the path to the history directory.
 func (a *App) GetHistoryDir() string {
 	return filepath.Join(a.PawDir, constants.HistoryDirName)
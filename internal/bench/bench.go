@@ -0,0 +1,222 @@
+// Package bench provides a load test harness that simulates projects with many tasks
+// to measure task discovery, Kanban rendering, and recovery scans without tmux.
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tmux/tmuxtest"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// Kanban size used for render measurements (a wide terminal).
+const (
+	KanbanWidth  = 180
+	KanbanHeight = 50
+)
+
+// sessionName is the session name of simulated projects.
+const sessionName = "bench"
+
+// agentCapture is the pane content of simulated working agents.
+var agentCapture = strings.Join([]string{
+	"⏺ Read(internal/service/taskdiscovery.go)",
+	"  ⎿  Read 420 lines",
+	"",
+	"⏺ Update(internal/service/taskdiscovery.go)",
+	"  ⎿  Updated internal/service/taskdiscovery.go with 12 additions and 3 removals",
+	"",
+	"✻ Running tests… (1m 36s · ↓ 5.9k tokens · esc to interrupt)",
+	"",
+	"────────────────────────────────────────────────────────────",
+	"> ",
+	"────────────────────────────────────────────────────────────",
+	"  ⏵⏵ bypass permissions on (shift+tab to cycle)",
+}, "\n")
+
+// Scenario is a simulated project with fake tasks and their tmux windows.
+// Every tenth task has lost its window, so recovery scans have work to do.
+type Scenario struct {
+	Tasks int
+
+	dir       string
+	pawDir    string
+	agentsDir string
+	tmux      *tmuxtest.Fake
+	manager   *task.Manager
+	discovery *service.TaskDiscoveryService
+	kanban    *tui.KanbanView
+}
+
+// NewScenario creates a project with the given number of tasks in a temporary directory.
+// Call Close to remove it.
+func NewScenario(tasks int) (*Scenario, error) {
+	dir, err := os.MkdirTemp("", "paw-bench-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scenario directory: %w", err)
+	}
+
+	s := &Scenario{
+		Tasks:     tasks,
+		dir:       dir,
+		pawDir:    filepath.Join(dir, constants.PawDirName),
+		agentsDir: filepath.Join(dir, constants.PawDirName, constants.AgentsDirName),
+		tmux:      tmuxtest.New(sessionName),
+		discovery: service.NewTaskDiscoveryService(),
+		kanban:    tui.NewKanbanView(true),
+	}
+	s.kanban.SetSize(KanbanWidth, KanbanHeight)
+
+	for i := range tasks {
+		if err := s.addTask(i); err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+
+	s.manager = task.NewManager(s.agentsDir, dir, s.pawDir, false, config.DefaultConfig())
+	s.manager.SetTmuxClient(s.tmux)
+	return s, nil
+}
+
+// addTask creates the agent directory of the i-th task and, except for every tenth task, its window.
+func (s *Scenario) addTask(i int) error {
+	name := fmt.Sprintf("bench-task-%04d-refactor-discovery", i)
+	t := task.New(name, filepath.Join(s.agentsDir, name))
+	if err := os.MkdirAll(t.AgentDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return fmt.Errorf("failed to create agent directory: %w", err)
+	}
+	if err := t.SaveContent(fmt.Sprintf("Task %d: refactor the discovery service and add tests", i)); err != nil {
+		return fmt.Errorf("failed to save task content: %w", err)
+	}
+	if _, err := t.CreateTabLock(); err != nil {
+		return fmt.Errorf("failed to create tab lock: %w", err)
+	}
+
+	emoji := constants.EmojiWorking
+	switch i % 3 {
+	case 1:
+		emoji = constants.EmojiWaiting
+	case 2:
+		emoji = constants.EmojiDone
+	}
+	windowID, err := s.tmux.NewWindow(tmux.WindowOpts{
+		Name:    emoji + constants.TruncateForWindowName(name),
+		Command: "claude",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create window: %w", err)
+	}
+	if err := t.SaveWindowID(windowID); err != nil {
+		return fmt.Errorf("failed to save window ID: %w", err)
+	}
+	if i%10 == 9 {
+		// Lost window: reopened by recovery
+		return s.tmux.KillWindow(windowID)
+	}
+	s.tmux.SetPaneContent(windowID+".0", agentCapture)
+	return nil
+}
+
+// Close removes the scenario's directory.
+func (s *Scenario) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// Discover discovers the tasks of the scenario's session, grouped like DiscoverAll.
+func (s *Scenario) Discover() (working, waiting, done []*service.DiscoveredTask) {
+	for _, t := range s.discovery.DiscoverSession(s.tmux, sessionName, s.pawDir) {
+		switch t.Status {
+		case service.DiscoveredWorking:
+			working = append(working, t)
+		case service.DiscoveredWaiting:
+			waiting = append(waiting, t)
+		case service.DiscoveredDone:
+			done = append(done, t)
+		}
+	}
+	return working, waiting, done
+}
+
+// RenderKanban renders the Kanban board of the discovered tasks without the render cache.
+func (s *Scenario) RenderKanban(working, waiting, done []*service.DiscoveredTask) string {
+	s.kanban.SetTasks(working, waiting, done)
+	return s.kanban.Render()
+}
+
+// Recover runs the scans done when attaching to a session: orphaned windows,
+// incomplete tasks, and stopped agents.
+func (s *Scenario) Recover() error {
+	if _, err := s.manager.FindOrphanedWindows(); err != nil {
+		return fmt.Errorf("orphaned window scan failed: %w", err)
+	}
+	if _, err := s.manager.FindIncompleteTasks(sessionName); err != nil {
+		return fmt.Errorf("incomplete task scan failed: %w", err)
+	}
+	if _, err := s.manager.FindStoppedTasks(); err != nil {
+		return fmt.Errorf("stopped agent scan failed: %w", err)
+	}
+	return nil
+}
+
+// Result is the measurement of one operation.
+type Result struct {
+	Name       string
+	Iterations int
+	Total      time.Duration
+}
+
+// PerOp returns the average duration of one iteration.
+func (r Result) PerOp() time.Duration {
+	if r.Iterations == 0 {
+		return 0
+	}
+	return r.Total / time.Duration(r.Iterations)
+}
+
+// Run measures discovery, Kanban rendering, and recovery of a scenario with the given
+// number of tasks, averaging over iterations.
+func Run(tasks, iterations int) ([]Result, error) {
+	s, err := NewScenario(tasks)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = s.Close() }()
+
+	working, waiting, done := s.Discover()
+	measure := func(name string, fn func() error) (Result, error) {
+		start := time.Now()
+		for range iterations {
+			if err := fn(); err != nil {
+				return Result{}, err
+			}
+		}
+		return Result{Name: name, Iterations: iterations, Total: time.Since(start)}, nil
+	}
+
+	var results []Result
+	for _, op := range []struct {
+		name string
+		fn   func() error
+	}{
+		{"discovery", func() error { s.Discover(); return nil }},
+		{"kanban render", func() error { s.RenderKanban(working, waiting, done); return nil }},
+		{"recovery", s.Recover},
+	} {
+		r, err := measure(op.name, op.fn)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -0,0 +1,90 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScenario(t *testing.T) {
+	s, err := NewScenario(30)
+	if err != nil {
+		t.Fatalf("NewScenario() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	working, waiting, done := s.Discover()
+	// 3 of 30 tasks have no window; the rest cycle working/waiting/done
+	if len(working) != 9 || len(waiting) != 9 || len(done) != 9 {
+		t.Errorf("Discover() = %d working, %d waiting, %d done; want 9 each", len(working), len(waiting), len(done))
+	}
+	if len(working) > 0 && working[0].CurrentAction == "" {
+		t.Error("Discover() did not extract the current action of working tasks")
+	}
+
+	if board := s.RenderKanban(working, waiting, done); !strings.Contains(board, "Working (9)") {
+		t.Errorf("RenderKanban() does not show the working column:\n%s", board)
+	}
+
+	incomplete, err := s.manager.FindIncompleteTasks(sessionName)
+	if err != nil {
+		t.Fatalf("FindIncompleteTasks() error = %v", err)
+	}
+	if len(incomplete) != 3 {
+		t.Errorf("FindIncompleteTasks() = %d tasks, want 3", len(incomplete))
+	}
+}
+
+func TestRun(t *testing.T) {
+	results, err := Run(10, 2)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Run() returned %d results, want 3", len(results))
+	}
+	for _, r := range results {
+		if r.Iterations != 2 || r.PerOp() <= 0 {
+			t.Errorf("result %q = %d iterations, %v per op", r.Name, r.Iterations, r.PerOp())
+		}
+	}
+}
+
+func benchmarkScenario(b *testing.B, tasks int, op func(*Scenario)) {
+	b.Helper()
+	s, err := NewScenario(tasks)
+	if err != nil {
+		b.Fatalf("NewScenario() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		op(s)
+	}
+}
+
+func BenchmarkDiscovery100(b *testing.B) {
+	benchmarkScenario(b, 100, func(s *Scenario) { s.Discover() })
+}
+
+func BenchmarkKanbanRender100(b *testing.B) {
+	s, err := NewScenario(100)
+	if err != nil {
+		b.Fatalf("NewScenario() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	working, waiting, done := s.Discover()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.RenderKanban(working, waiting, done)
+	}
+}
+
+func BenchmarkRecovery100(b *testing.B) {
+	benchmarkScenario(b, 100, func(s *Scenario) {
+		if err := s.Recover(); err != nil {
+			b.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package claudetest provides a script-driven claude.Client for tests.
+//
+// Fake answers the generation calls with fixed values and replies to the input
+// sent to an agent pane by following a Script: each input consumes the next step,
+// whose output is appended to the pane when the tmux client supports it (such as
+// tmuxtest.Fake):
+//
+//	agent := claudetest.New(
+//		claudetest.Step{Expect: "Read", Output: "⏺ Reading the task"},
+//	)
+package claudetest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// Step is one scripted reply of the agent.
+type Step struct {
+	// Expect, if set, must be contained in the input; otherwise the input fails.
+	Expect string
+	// Output is appended to the pane after the input was received.
+	Output string
+	// Err is returned for the input instead of replying.
+	Err error
+}
+
+// Fake is a script-driven claude.Client. It is safe for concurrent use.
+type Fake struct {
+	// Values returned by the generation calls.
+	TaskName  string
+	Summary   string
+	SplitPlan string
+	SelfEval  string
+	Review    string
+
+	// ReadyErr is returned by WaitForReady and VerifyPaneAlive.
+	ReadyErr error
+	// Stopped makes IsClaudeRunning report that the agent exited.
+	Stopped bool
+
+	mu     sync.Mutex
+	script []Step
+	inputs []string
+}
+
+// Compile-time check that Fake implements claude.Client.
+var _ claude.Client = (*Fake)(nil)
+
+// paneWriter is implemented by tmux fakes that let the agent print to a pane.
+type paneWriter interface {
+	AppendPaneContent(target, content string)
+}
+
+// New creates a fake agent that follows script.
+func New(script ...Step) *Fake {
+	return &Fake{
+		TaskName:  "fake-task",
+		Summary:   "Fake summary",
+		SplitPlan: `{"tasks":[]}`,
+		SelfEval:  `{"score": 5, "requirements": "met", "summary": "Fake evaluation"}`,
+		Review:    `{"verdict": "approve", "summary": "Fake review"}`,
+		script:    script,
+	}
+}
+
+// GenerateTaskName returns TaskName.
+func (f *Fake) GenerateTaskName(string) (string, error) {
+	return f.TaskName, nil
+}
+
+// GenerateSummary returns Summary.
+func (f *Fake) GenerateSummary(string) (string, error) {
+	return f.Summary, nil
+}
+
+// GenerateTaskSplit returns SplitPlan.
+func (f *Fake) GenerateTaskSplit(string) (string, error) {
+	return f.SplitPlan, nil
+}
+
+// GenerateSelfEval returns SelfEval.
+func (f *Fake) GenerateSelfEval(string, string, string) (string, error) {
+	return f.SelfEval, nil
+}
+
+// GenerateReview returns Review.
+func (f *Fake) GenerateReview(string, string, string) (string, error) {
+	return f.Review, nil
+}
+
+// WaitForReady returns ReadyErr without waiting.
+func (f *Fake) WaitForReady(tmux.Client, string) error {
+	return f.ReadyErr
+}
+
+// SendInput records the input and replies with the next script step.
+func (f *Fake) SendInput(tm tmux.Client, target, input string) error {
+	f.mu.Lock()
+	f.inputs = append(f.inputs, input)
+	if len(f.script) == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	step := f.script[0]
+	f.script = f.script[1:]
+	f.mu.Unlock()
+
+	if step.Expect != "" && !strings.Contains(input, step.Expect) {
+		return fmt.Errorf("unexpected input %q: want it to contain %q", input, step.Expect)
+	}
+	if step.Err != nil {
+		return step.Err
+	}
+	if w, ok := tm.(paneWriter); ok && step.Output != "" {
+		w.AppendPaneContent(target, step.Output)
+	}
+	return nil
+}
+
+// SendInputWithRetry sends the input once; the fake never needs retries.
+func (f *Fake) SendInputWithRetry(tm tmux.Client, target, input string, _ int) error {
+	return f.SendInput(tm, target, input)
+}
+
+// SendTrustResponse does nothing; the fake never asks for trust.
+func (f *Fake) SendTrustResponse(tmux.Client, string) error {
+	return nil
+}
+
+// VerifyPaneAlive returns ReadyErr.
+func (f *Fake) VerifyPaneAlive(tmux.Client, string, time.Duration) error {
+	return f.ReadyErr
+}
+
+// IsClaudeRunning reports !Stopped.
+func (f *Fake) IsClaudeRunning(tmux.Client, string) bool {
+	return !f.Stopped
+}
+
+// ScrollToFirstSpinner returns immediately.
+func (f *Fake) ScrollToFirstSpinner(tmux.Client, string, time.Duration) error {
+	return nil
+}
+
+// Inputs returns the inputs sent to the agent.
+func (f *Fake) Inputs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.inputs...)
+}
+
+// Remaining returns the number of script steps not yet consumed.
+func (f *Fake) Remaining() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.script)
+}
@@ -0,0 +1,52 @@
+package claudetest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tmux/tmuxtest"
+)
+
+func TestFakeFollowsScript(t *testing.T) {
+	tm := tmuxtest.New("proj")
+	id, _ := tm.NewWindow(tmux.WindowOpts{Name: "task"})
+	pane := id + ".0"
+
+	errBusy := errors.New("busy")
+	agent := New(
+		Step{Expect: "task", Output: "⏺ Reading the task"},
+		Step{Err: errBusy},
+	)
+
+	if err := agent.SendInput(tm, pane, "Read the task file"); err != nil {
+		t.Fatalf("SendInput() error = %v", err)
+	}
+	content, _ := tm.CapturePane(pane, 100)
+	if !strings.Contains(content, "⏺ Reading the task") {
+		t.Errorf("pane content = %q, want the scripted output", content)
+	}
+
+	if err := agent.SendInputWithRetry(tm, pane, "again", 3); !errors.Is(err, errBusy) {
+		t.Errorf("SendInputWithRetry() error = %v, want %v", err, errBusy)
+	}
+	if agent.Remaining() != 0 {
+		t.Errorf("Remaining() = %d, want 0", agent.Remaining())
+	}
+	if got := agent.Inputs(); len(got) != 2 {
+		t.Errorf("Inputs() = %q, want 2 inputs", got)
+	}
+
+	// Inputs past the script are accepted silently
+	if err := agent.SendInput(tm, pane, "extra"); err != nil {
+		t.Errorf("SendInput() after script error = %v", err)
+	}
+}
+
+func TestFakeUnexpectedInput(t *testing.T) {
+	agent := New(Step{Expect: "plan"})
+	if err := agent.SendInput(tmuxtest.New("proj"), "@1.0", "something else"); err == nil {
+		t.Error("SendInput() should fail when the input does not match Expect")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
@@ -33,6 +34,18 @@ type Client interface {
 	// GenerateSummary generates a brief summary of the task work from pane content.
 	GenerateSummary(paneContent string) (string, error)
 
+	// GenerateTaskSplit asks Claude to decompose a large task into smaller
+	// dependent tasks. Returns the raw JSON plan for service.ParseSplitPlan.
+	GenerateTaskSplit(content string) (string, error)
+
+	// GenerateSelfEval asks Claude to score finished work against its task.
+	// Returns the raw JSON rubric for service.ParseSelfEval.
+	GenerateSelfEval(taskContent, diff, paneContent string) (string, error)
+
+	// GenerateReview asks another model to review a task's diff before it is
+	// merged. Returns the raw JSON review for service.ParseCodeReview.
+	GenerateReview(model, taskContent, diff string) (string, error)
+
 	// WaitForReady waits for Claude to be ready in a tmux pane.
 	WaitForReady(tm tmux.Client, target string) error
 
@@ -78,18 +91,6 @@ func New() Client {
 // TaskNamePattern validates task name format.
 var TaskNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{6,30}[a-z0-9]$`)
 
-// ReadyPatterns matches Claude ready prompts.
-// Added more patterns to improve detection reliability:
-// - Trust/bypass: initial trust prompt
-// - ╭─: Claude's box drawing UI
-// - >: input prompt
-// - claude: Claude branding in output
-// - Cost: token/cost display
-var ReadyPatterns = regexp.MustCompile(`(?i)(Trust|bypass permissions|╭─|^>\s*$|claude|Cost:?\s*\$)`)
-
-// TrustPattern matches trust confirmation prompt.
-var TrustPattern = regexp.MustCompile(`(?i)trust`)
-
 // SummaryTimeout is the timeout for summary generation.
 const SummaryTimeout = 15 * time.Second
 
@@ -123,6 +124,110 @@ Write a concise summary only. Do NOT include any header like "Summary:" or "**Su
 	return summary, nil
 }
 
+// GenerateTaskSplit decomposes a large task description into smaller tasks.
+// The response is a JSON array; parsing and validation is left to the caller.
+func (c *claudeClient) GenerateTaskSplit(content string) (string, error) {
+	prompt := fmt.Sprintf(`Split the following task into 2-8 smaller tasks that can each be completed and merged independently by a coding agent.
+
+Rules:
+- Each task needs a short name (8-32 lowercase chars, hyphens only, verb-noun format like "add-login-feature")
+- Each task's content must be a self-contained instruction (the agent will not see the other tasks)
+- A task may depend on at most ONE earlier task, by name; it only starts after that task finishes successfully
+- Prefer independent tasks; only add a dependency when the work truly requires it
+- List tasks in execution order
+
+Task:
+%s
+
+Respond with ONLY a JSON array, nothing else:
+[{"name": "task-name", "content": "what to do", "depends_on": "earlier-task-name or empty"}]`, content)
+
+	logging.Trace("GenerateTaskSplit: starting with content length=%d", len(content))
+
+	plan, err := c.runClaudeWithModel(prompt, "sonnet", false, constants.ClaudeSplitTimeout)
+	if err != nil {
+		logging.Debug("GenerateTaskSplit: failed: %v", err)
+		return "", err
+	}
+
+	logging.Debug("GenerateTaskSplit: success, length=%d", len(plan))
+	return plan, nil
+}
+
+// GenerateSelfEval scores a task's work against its description from the
+// diff and the end of the agent's pane. The response is a JSON object;
+// parsing is left to the caller.
+func (c *claudeClient) GenerateSelfEval(taskContent, diff, paneContent string) (string, error) {
+	if len(diff) > constants.SelfEvalMaxDiff {
+		diff = diff[:constants.SelfEvalMaxDiff] + "\n[diff truncated]"
+	}
+	if len(paneContent) > constants.SummaryMaxLen {
+		paneContent = paneContent[len(paneContent)-constants.SummaryMaxLen:]
+	}
+
+	prompt := fmt.Sprintf(`You are reviewing work a coding agent just finished. Evaluate it strictly against the task.
+
+Task:
+%s
+
+Changes (diff):
+%s
+
+End of the agent's terminal output:
+%s
+
+Score the work from 1 (task not done) to 5 (fully done, verified, low risk):
+- Does it meet every requirement of the task?
+- What could break or was done in a risky way?
+- Which changed behavior is not covered by tests or was never run?
+
+Respond with ONLY a JSON object, nothing else:
+{"score": 1-5, "requirements": "met, partial, or unmet", "summary": "one sentence", "risks": ["..."], "untested": ["..."]}`, taskContent, diff, paneContent)
+
+	logging.Trace("GenerateSelfEval: starting with diff length=%d", len(diff))
+
+	rubric, err := c.runClaudeWithModel(prompt, "sonnet", false, constants.ClaudeSelfEvalTimeout)
+	if err != nil {
+		logging.Debug("GenerateSelfEval: failed: %v", err)
+		return "", err
+	}
+
+	logging.Debug("GenerateSelfEval: success, length=%d", len(rubric))
+	return rubric, nil
+}
+
+// GenerateReview reviews a task's diff against its description with the
+// given model. The response is a JSON object; parsing is left to the caller.
+func (c *claudeClient) GenerateReview(model, taskContent, diff string) (string, error) {
+	if len(diff) > constants.ReviewMaxDiff {
+		diff = diff[:constants.ReviewMaxDiff] + "\n[diff truncated]"
+	}
+
+	prompt := fmt.Sprintf(`You are a senior engineer reviewing a change written by another coding agent before it is merged into the main branch.
+
+Task the change was written for:
+%s
+
+Diff against the main branch:
+%s
+
+Approve the change if it does what the task asks and is safe to merge. Request changes only for real problems: bugs, missing requirements, security issues, broken or missing tests for changed behavior. Do not request changes for style preferences.
+
+Respond with ONLY a JSON object, nothing else:
+{"verdict": "approve" or "request_changes", "summary": "one or two sentences", "comments": [{"file": "path", "line": 0, "comment": "what to change and why"}]}`, taskContent, diff)
+
+	logging.Trace("GenerateReview: starting with model=%s diff length=%d", model, len(diff))
+
+	review, err := c.runClaudeWithModel(prompt, model, false, constants.ClaudeReviewTimeout)
+	if err != nil {
+		logging.Debug("GenerateReview: failed: %v", err)
+		return "", err
+	}
+
+	logging.Debug("GenerateReview: success, length=%d", len(review))
+	return review, nil
+}
+
 // modelAttempt defines a model escalation attempt configuration.
 type modelAttempt struct {
 	model    string
@@ -273,12 +378,18 @@ func sanitizeTaskName(name string) string {
 }
 
 // WaitForReady waits for Claude to be ready in the specified tmux pane.
+// Readiness is decided by the active ReadinessRules; when no pattern matches
+// but the pane stops changing for the ruleset's stable timeout, Claude is
+// assumed ready (its UI probably changed).
 // Uses exponential backoff starting from pollInterval, capped at 2 seconds.
 func (c *claudeClient) WaitForReady(tm tmux.Client, target string) error {
+	rules := activeReadinessRules()
 	currentInterval := c.pollInterval
 	maxInterval := 2 * time.Second
 	emptyCount := 0
 	maxEmptyBeforeWarn := 10
+	var lastContent string
+	var stableSince time.Time
 
 	for i := 0; i < c.maxAttempts; i++ {
 		// First verify the pane exists
@@ -316,11 +427,21 @@ func (c *claudeClient) WaitForReady(tm tmux.Client, target string) error {
 		emptyCount = 0
 		currentInterval = c.pollInterval
 
-		if ReadyPatterns.MatchString(content) {
+		if rules.IsReady(content) {
 			logging.Debug("WaitForReady: Claude ready detected (attempt %d/%d)", i+1, c.maxAttempts)
 			return nil
 		}
 
+		// Fallback: content that stopped changing means Claude is idle
+		if content != lastContent {
+			lastContent = content
+			stableSince = time.Now()
+		} else if stable := rules.StableTimeout(); stable > 0 && time.Since(stableSince) >= stable {
+			logging.Warn("WaitForReady: no readiness pattern matched, pane unchanged for %s; assuming ready (update %s if Claude's UI changed)",
+				stable, constants.ReadinessFileName)
+			return nil
+		}
+
 		// Log what we're seeing for debugging (only first 100 chars)
 		preview := trimmedContent
 		if len(preview) > 100 {
@@ -388,8 +509,9 @@ func (c *claudeClient) SendTrustResponse(tm tmux.Client, target string) error {
 		return fmt.Errorf("failed to capture pane: %w", err)
 	}
 
-	if TrustPattern.MatchString(content) {
-		if err := tm.SendKeys(target, "y", "Enter"); err != nil {
+	rules := activeReadinessRules()
+	if rules.IsTrustPrompt(content) {
+		if err := tm.SendKeys(target, rules.TrustKeys...); err != nil {
 			return fmt.Errorf("failed to send trust response: %w", err)
 		}
 	}
@@ -607,8 +729,20 @@ func (c *claudeClient) ScrollToFirstSpinner(tm tmux.Client, target string, timeo
 	return fmt.Errorf("timeout waiting for spinner in pane %s", target)
 }
 
-// BuildSystemPrompt builds the system prompt from global and project prompts.
-func BuildSystemPrompt(globalPrompt, projectPrompt string) string {
+// ContextFile is a file attached to the system prompt as extra context.
+type ContextFile struct {
+	Path    string // Shown as the section heading (relative to the project)
+	Content string
+}
+
+// contextTruncatedNote is appended to context files cut off by the budget.
+const contextTruncatedNote = "\n\n[... truncated to fit the context budget; read %s for the rest]"
+
+// BuildSystemPrompt builds the system prompt from global and project prompts,
+// followed by the context files. The context files share a budget of
+// contextBudget bytes (0 = unlimited): a file that doesn't fit is truncated,
+// and files after the budget is used up are only listed by path.
+func BuildSystemPrompt(globalPrompt, projectPrompt string, contextFiles []ContextFile, contextBudget int) string {
 	var sb strings.Builder
 
 	if globalPrompt != "" {
@@ -622,9 +756,57 @@ func BuildSystemPrompt(globalPrompt, projectPrompt string) string {
 		sb.WriteString(projectPrompt)
 	}
 
+	if len(contextFiles) == 0 {
+		return sb.String()
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n\n---\n\n")
+	}
+	sb.WriteString("# Context files\n")
+
+	remaining := contextBudget
+	var omitted []string
+	for _, f := range contextFiles {
+		content := strings.TrimRight(f.Content, "\n")
+		if contextBudget > 0 {
+			if remaining <= 0 {
+				omitted = append(omitted, f.Path)
+				continue
+			}
+			if len(content) > remaining {
+				content = truncateContext(content, remaining) + fmt.Sprintf(contextTruncatedNote, f.Path)
+				remaining = 0
+			} else {
+				remaining -= len(content)
+			}
+		}
+		sb.WriteString("\n## ")
+		sb.WriteString(f.Path)
+		sb.WriteString("\n\n")
+		sb.WriteString(content)
+		sb.WriteString("\n")
+	}
+	if len(omitted) > 0 {
+		sb.WriteString("\nNot included (over the context budget; read them if relevant): ")
+		sb.WriteString(strings.Join(omitted, ", "))
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
+// truncateContext cuts content to at most limit bytes, preferring a line
+// boundary and never splitting a UTF-8 sequence.
+func truncateContext(content string, limit int) string {
+	if idx := strings.LastIndexByte(content[:limit], '\n'); idx > limit/2 {
+		return content[:idx]
+	}
+	for limit > 0 && !utf8.RuneStart(content[limit]) {
+		limit--
+	}
+	return content[:limit]
+}
+
 // BuildClaudeCommand builds the claude command with the given options.
 func BuildClaudeCommand(systemPrompt string, dangerouslySkipPermissions bool) []string {
 	args := []string{"claude"}
@@ -2,9 +2,13 @@ package claude
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -148,15 +152,16 @@ func TestReadyPatterns(t *testing.T) {
 		"Running tests",
 	}
 
+	rules := DefaultReadinessRules()
 	for _, s := range shouldMatch {
-		if !ReadyPatterns.MatchString(s) {
-			t.Errorf("ReadyPatterns should match %q", s)
+		if !rules.IsReady(s) {
+			t.Errorf("IsReady should match %q", s)
 		}
 	}
 
 	for _, s := range shouldNotMatch {
-		if ReadyPatterns.MatchString(s) {
-			t.Errorf("ReadyPatterns should not match %q", s)
+		if rules.IsReady(s) {
+			t.Errorf("IsReady should not match %q", s)
 		}
 	}
 }
@@ -175,15 +180,16 @@ func TestTrustPattern(t *testing.T) {
 		"Running tests",
 	}
 
+	rules := DefaultReadinessRules()
 	for _, s := range shouldMatch {
-		if !TrustPattern.MatchString(s) {
-			t.Errorf("TrustPattern should match %q", s)
+		if !rules.IsTrustPrompt(s) {
+			t.Errorf("IsTrustPrompt should match %q", s)
 		}
 	}
 
 	for _, s := range shouldNotMatch {
-		if TrustPattern.MatchString(s) {
-			t.Errorf("TrustPattern should not match %q", s)
+		if rules.IsTrustPrompt(s) {
+			t.Errorf("IsTrustPrompt should not match %q", s)
 		}
 	}
 }
@@ -223,7 +229,7 @@ func TestBuildSystemPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := BuildSystemPrompt(tt.globalPrompt, tt.projectPrompt)
+			got := BuildSystemPrompt(tt.globalPrompt, tt.projectPrompt, nil, 0)
 			if got != tt.want {
 				t.Errorf("BuildSystemPrompt() = %q, want %q", got, tt.want)
 			}
@@ -231,6 +237,41 @@ func TestBuildSystemPrompt(t *testing.T) {
 	}
 }
 
+func TestBuildSystemPromptContextFiles(t *testing.T) {
+	files := []ContextFile{
+		{Path: "ARCHITECTURE.md", Content: "Layers: cmd, internal\n"},
+		{Path: "CONTRIBUTING.md", Content: "line one\nline two\nline three\n"},
+		{Path: "STYLE.md", Content: "never shown"},
+	}
+
+	got := BuildSystemPrompt("Global", "", files, 0)
+	want := "Global\n\n---\n\n# Context files\n" +
+		"\n## ARCHITECTURE.md\n\nLayers: cmd, internal\n" +
+		"\n## CONTRIBUTING.md\n\nline one\nline two\nline three\n" +
+		"\n## STYLE.md\n\nnever shown\n"
+	if got != want {
+		t.Errorf("unlimited budget:\n got %q\nwant %q", got, want)
+	}
+
+	// 21 bytes for ARCHITECTURE.md leaves 20: CONTRIBUTING.md is cut at a line boundary
+	got = BuildSystemPrompt("", "", files, 41)
+	if !strings.Contains(got, "## CONTRIBUTING.md\n\nline one\nline two\n\n[... truncated") {
+		t.Errorf("truncated file missing or cut badly:\n%s", got)
+	}
+	if strings.Contains(got, "never shown") || !strings.Contains(got, "Not included (over the context budget; read them if relevant): STYLE.md") {
+		t.Errorf("file over budget should only be listed:\n%s", got)
+	}
+}
+
+func TestTruncateContext(t *testing.T) {
+	if got := truncateContext("한글abc", 4); got != "한" {
+		t.Errorf("truncateContext() = %q, want %q (no split runes)", got, "한")
+	}
+	if got := truncateContext("abcdef\nghijkl", 10); got != "abcdef" {
+		t.Errorf("truncateContext() = %q, want %q (line boundary)", got, "abcdef")
+	}
+}
+
 func TestBuildClaudeCommand(t *testing.T) {
 	tests := []struct {
 		name                       string
@@ -307,6 +348,10 @@ func (m *mockTmuxClient) CapturePane(target string, lines int) (string, error) {
 	return m.capturePaneContent, m.capturePaneErr
 }
 
+func (m *mockTmuxClient) CapturePaneOpts(target string, opts tmux.CaptureOpts) (string, error) {
+	return m.CapturePane(target, opts.Lines)
+}
+
 func (m *mockTmuxClient) SendKeys(target string, keys ...string) error {
 	return m.sendKeysErr
 }
@@ -343,6 +388,7 @@ func (m *mockTmuxClient) WaitForPane(target string, maxWait time.Duration, minLe
 }
 func (m *mockTmuxClient) DisplayPopup(opts tmux.PopupOpts, command string) error { return nil }
 func (m *mockTmuxClient) SetOption(key, value string, global bool) error         { return nil }
+func (m *mockTmuxClient) SetSessionOption(key, value string) error               { return nil }
 func (m *mockTmuxClient) GetOption(key string) (string, error)                   { return "", nil }
 func (m *mockTmuxClient) SetMultipleOptions(options map[string]string) error     { return nil }
 func (m *mockTmuxClient) SetEnv(key, value string) error                         { return nil }
@@ -568,3 +614,72 @@ func TestSummaryTimeout(t *testing.T) {
 		t.Errorf("SummaryTimeout too long: %v", SummaryTimeout)
 	}
 }
+
+func TestReadinessHints(t *testing.T) {
+	rules, err := ParseReadinessRules([]byte(`{"ready": ["^READY$"], "hints": ["welcome", "/help", "cwd:"], "min_hints": 2}`))
+	if err != nil {
+		t.Fatalf("ParseReadinessRules() error = %v", err)
+	}
+	if rules.IsReady("welcome back") {
+		t.Error("IsReady should need two hints")
+	}
+	if !rules.IsReady("welcome back\n/help for help") {
+		t.Error("IsReady should match two hints")
+	}
+	if got := strings.Join(rules.TrustKeys, " "); got != "y Enter" {
+		t.Errorf("TrustKeys default = %q, want %q", got, "y Enter")
+	}
+}
+
+func TestLoadReadinessRules(t *testing.T) {
+	pawDir := t.TempDir()
+
+	rules, err := LoadReadinessRules(pawDir)
+	if err != nil || !rules.IsReady("bypass permissions") {
+		t.Fatalf("LoadReadinessRules() without override = %v, %v", rules, err)
+	}
+
+	path := filepath.Join(pawDir, constants.ReadinessFileName)
+	if err := os.WriteFile(path, []byte(`{"ready": ["NEW UI READY"], "stable_seconds": 0}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadReadinessRules(pawDir)
+	if err != nil {
+		t.Fatalf("LoadReadinessRules() error = %v", err)
+	}
+	if !rules.IsReady("NEW UI READY") || rules.IsReady("bypass permissions") {
+		t.Error("override should replace the embedded rules")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"ready": ["("]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadReadinessRules(pawDir)
+	if err == nil {
+		t.Error("LoadReadinessRules() with invalid pattern should report an error")
+	}
+	if rules == nil || !rules.IsReady("bypass permissions") {
+		t.Error("invalid override should fall back to the embedded rules")
+	}
+}
+
+func TestWaitForReadyStableFallback(t *testing.T) {
+	rules, err := ParseReadinessRules([]byte(`{"ready": ["NEVER"], "stable_seconds": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetReadinessRules(rules)
+	defer SetReadinessRules(nil)
+
+	client := &claudeClient{maxAttempts: 100, pollInterval: 50 * time.Millisecond}
+	mock := &mockTmuxClient{hasPaneResult: true, capturePaneContent: "some new Claude UI"}
+	if err := client.WaitForReady(mock, "@0"); err != nil {
+		t.Errorf("WaitForReady() with stable content error = %v", err)
+	}
+
+	client = &claudeClient{maxAttempts: 3, pollInterval: time.Millisecond}
+	SetReadinessRules(&ReadinessRules{})
+	if err := client.WaitForReady(mock, "@0"); err == nil {
+		t.Error("WaitForReady() without rules or fallback should time out")
+	}
+}
@@ -0,0 +1,186 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/embed"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// ReadinessRules decides when Claude is ready for input from the pane content.
+// The defaults are embedded (assets/readiness.json); a project can replace them
+// with .paw/readiness.json when a Claude Code update changes its UI.
+type ReadinessRules struct {
+	// Ready patterns: any match means Claude is ready.
+	Ready []string `json:"ready"`
+	// Hints are weaker signals: MinHints of them must match together.
+	Hints    []string `json:"hints"`
+	MinHints int      `json:"min_hints"`
+	// Trust patterns detect the trust prompt, answered with TrustKeys.
+	Trust     []string `json:"trust"`
+	TrustKeys []string `json:"trust_keys"`
+	// StableSeconds is the fallback: once the pane shows the same content
+	// for this long without any pattern matching, Claude is assumed ready
+	// (0 = wait for a pattern until the attempts run out).
+	StableSeconds int `json:"stable_seconds"`
+
+	ready []*regexp.Regexp
+	hints []*regexp.Regexp
+	trust []*regexp.Regexp
+}
+
+var (
+	readinessMu     sync.RWMutex
+	activeReadiness *ReadinessRules
+)
+
+// ParseReadinessRules parses and compiles a JSON ruleset.
+func ParseReadinessRules(data []byte) (*ReadinessRules, error) {
+	var rules ReadinessRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid readiness rules: %w", err)
+	}
+	if len(rules.Ready) == 0 && len(rules.Hints) == 0 {
+		return nil, fmt.Errorf("invalid readiness rules: no ready or hint patterns")
+	}
+
+	var err error
+	if rules.ready, err = compilePatterns(rules.Ready); err != nil {
+		return nil, err
+	}
+	if rules.hints, err = compilePatterns(rules.Hints); err != nil {
+		return nil, err
+	}
+	if rules.trust, err = compilePatterns(rules.Trust); err != nil {
+		return nil, err
+	}
+	if rules.MinHints < 1 {
+		rules.MinHints = 1
+	}
+	if len(rules.TrustKeys) == 0 {
+		rules.TrustKeys = []string{"y", "Enter"}
+	}
+	return &rules, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid readiness pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// DefaultReadinessRules returns the embedded ruleset.
+func DefaultReadinessRules() *ReadinessRules {
+	data, err := embed.GetReadinessRules()
+	if err != nil {
+		panic(fmt.Sprintf("embedded readiness rules missing: %v", err))
+	}
+	rules, err := ParseReadinessRules(data)
+	if err != nil {
+		panic(fmt.Sprintf("embedded readiness rules: %v", err))
+	}
+	return rules
+}
+
+// LoadReadinessRules returns the project's ruleset (.paw/readiness.json), or
+// the embedded one when the project has none. An invalid override is reported
+// along with the embedded ruleset so callers can warn and carry on.
+func LoadReadinessRules(pawDir string) (*ReadinessRules, error) {
+	if pawDir == "" {
+		return DefaultReadinessRules(), nil
+	}
+	data, err := os.ReadFile(filepath.Join(pawDir, constants.ReadinessFileName)) //nolint:gosec // G304: path is in the paw directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultReadinessRules(), nil
+		}
+		return DefaultReadinessRules(), err
+	}
+	rules, err := ParseReadinessRules(data)
+	if err != nil {
+		return DefaultReadinessRules(), fmt.Errorf("%s: %w", constants.ReadinessFileName, err)
+	}
+	return rules, nil
+}
+
+// SetReadinessRules sets the ruleset used by clients in this process.
+func SetReadinessRules(rules *ReadinessRules) {
+	readinessMu.Lock()
+	defer readinessMu.Unlock()
+	activeReadiness = rules
+}
+
+// activeReadinessRules returns the ruleset set with SetReadinessRules, or the
+// embedded one.
+func activeReadinessRules() *ReadinessRules {
+	readinessMu.RLock()
+	rules := activeReadiness
+	readinessMu.RUnlock()
+	if rules != nil {
+		return rules
+	}
+
+	readinessMu.Lock()
+	defer readinessMu.Unlock()
+	if activeReadiness == nil {
+		activeReadiness = DefaultReadinessRules()
+	}
+	return activeReadiness
+}
+
+// IsReady reports whether the content shows Claude ready for input (or
+// waiting on the trust prompt, which must be answered first).
+func (r *ReadinessRules) IsReady(content string) bool {
+	for _, re := range r.ready {
+		if re.MatchString(content) {
+			logging.Trace("IsReady: matched ready pattern %q", re.String())
+			return true
+		}
+	}
+	if r.IsTrustPrompt(content) {
+		return true
+	}
+	if len(r.hints) == 0 {
+		return false
+	}
+	matched := 0
+	for _, re := range r.hints {
+		if re.MatchString(content) {
+			matched++
+		}
+	}
+	if matched >= r.MinHints {
+		logging.Trace("IsReady: matched %d hint patterns", matched)
+		return true
+	}
+	return false
+}
+
+// IsTrustPrompt reports whether the content shows the trust prompt.
+func (r *ReadinessRules) IsTrustPrompt(content string) bool {
+	for _, re := range r.trust {
+		if re.MatchString(content) {
+			return true
+		}
+	}
+	return false
+}
+
+// StableTimeout returns how long unchanged pane content counts as ready
+// (0 if the fallback is off).
+func (r *ReadinessRules) StableTimeout() time.Duration {
+	return time.Duration(r.StableSeconds) * time.Second
+}
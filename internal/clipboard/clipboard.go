@@ -0,0 +1,111 @@
+// Package clipboard copies text to the system clipboard with a detected or
+// configured command, falling back to OSC 52 where no command is available
+// (e.g. over SSH).
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+var (
+	mu   sync.Mutex
+	mode = constants.ClipboardAuto
+
+	// lookPath and getenv are replaced in tests.
+	lookPath = exec.LookPath
+	getenv   = os.Getenv
+)
+
+// SetMode sets the clipboard config option: auto, osc52, or a command that
+// reads the text from stdin.
+func SetMode(m string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if m = strings.TrimSpace(m); m == "" {
+		m = constants.ClipboardAuto
+	}
+	mode = m
+}
+
+// Command returns the shell command that copies its stdin to the clipboard,
+// or "" if OSC 52 is used instead.
+func Command() string {
+	mu.Lock()
+	m := mode
+	mu.Unlock()
+
+	switch m {
+	case constants.ClipboardAuto:
+		return detect()
+	case constants.ClipboardOSC52:
+		return ""
+	}
+	return m
+}
+
+// detect returns the first available clipboard command for the platform and
+// display server, or "" if there is none.
+func detect() string {
+	if runtime.GOOS == "darwin" {
+		if _, err := lookPath("pbcopy"); err == nil {
+			return "pbcopy"
+		}
+		return ""
+	}
+	if getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := lookPath("wl-copy"); err == nil {
+			return "wl-copy"
+		}
+	}
+	if getenv("DISPLAY") != "" {
+		if _, err := lookPath("xclip"); err == nil {
+			return "xclip -selection clipboard"
+		}
+		if _, err := lookPath("xsel"); err == nil {
+			return "xsel --clipboard --input"
+		}
+	}
+	return ""
+}
+
+// Write copies text to the clipboard.
+func Write(text string) error {
+	command := Command()
+	if command == "" {
+		return writeOSC52(text)
+	}
+	cmd := exec.Command("sh", "-c", command) //nolint:gosec // G204: command comes from the user's config or detection
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("clipboard command %q failed: %w: %s", command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// OSC52 returns the escape sequence that asks the terminal to set its
+// clipboard to text. tmux forwards it when set-clipboard is on.
+func OSC52(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
+
+// writeOSC52 writes the OSC 52 sequence to the controlling terminal, which
+// is the pane when running inside tmux.
+func writeOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open terminal for OSC 52: %w", err)
+	}
+	defer func() { _ = tty.Close() }()
+	if _, err := tty.WriteString(OSC52(text)); err != nil {
+		return fmt.Errorf("failed to write OSC 52: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package clipboard
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestOSC52(t *testing.T) {
+	if got, want := OSC52("hello"), "\x1b]52;c;aGVsbG8=\a"; got != want {
+		t.Errorf("OSC52() = %q, want %q", got, want)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("detection uses pbcopy on macOS")
+	}
+	origLookPath, origGetenv := lookPath, getenv
+	t.Cleanup(func() {
+		SetMode("")
+		lookPath, getenv = origLookPath, origGetenv
+	})
+
+	env := map[string]string{}
+	bins := map[string]bool{}
+	getenv = func(key string) string { return env[key] }
+	lookPath = func(name string) (string, error) {
+		if bins[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	tests := []struct {
+		name string
+		mode string
+		env  map[string]string
+		bins []string
+		want string
+	}{
+		{"wayland", constants.ClipboardAuto, map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, "wl-copy"},
+		{"x11 xclip", constants.ClipboardAuto, map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel"}, "xclip -selection clipboard"},
+		{"x11 xsel", constants.ClipboardAuto, map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, "xsel --clipboard --input"},
+		{"no display", constants.ClipboardAuto, nil, []string{"xclip"}, ""},
+		{"osc52", constants.ClipboardOSC52, map[string]string{"DISPLAY": ":0"}, []string{"xclip"}, ""},
+		{"custom", "clip.exe", nil, nil, "clip.exe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env = tt.env
+			bins = map[string]bool{}
+			for _, b := range tt.bins {
+				bins[b] = true
+			}
+			SetMode(tt.mode)
+			if got := Command(); got != tt.want {
+				t.Errorf("Command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
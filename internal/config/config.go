@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/fileutil"
@@ -34,6 +36,20 @@ func GlobalWorkspacesDir() string {
 	return filepath.Join(home, constants.GlobalDataDir, constants.GlobalWorkspacesDir)
 }
 
+// XDGStateWorkspacesDir returns the XDG state directory for project workspaces
+// ($XDG_STATE_HOME/paw, defaulting to $HOME/.local/state/paw).
+func XDGStateWorkspacesDir() string {
+	// The XDG spec says relative paths are invalid and must be ignored
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" && filepath.IsAbs(stateHome) {
+		return filepath.Join(stateHome, "paw")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, constants.GlobalStateDir)
+}
+
 // ProjectWorkspaceID generates a unique workspace ID for a project directory.
 // Uses a hash of the absolute path to ensure uniqueness while keeping names reasonable.
 func ProjectWorkspaceID(projectDir string) string {
@@ -65,7 +81,8 @@ func ProjectWorkspaceID(projectDir string) string {
 }
 
 // GetWorkspaceDir returns the workspace directory for a project.
-// It uses auto mode (git -> global, non-git -> local) unless overridden.
+// Auto mode follows workspace_location in the global config, and otherwise
+// uses the global workspace for git repos and .paw for non-git projects.
 func GetWorkspaceDir(projectDir string, pawInProject PawInProject, isGitRepo bool) string {
 	localPawDir := filepath.Join(projectDir, constants.PawDirName)
 
@@ -76,7 +93,15 @@ func GetWorkspaceDir(projectDir string, pawInProject PawInProject, isGitRepo boo
 		useLocal = true
 	case PawInProjectGlobal:
 		useLocal = false
+	case PawInProjectXDG:
+		if stateDir := XDGStateWorkspacesDir(); stateDir != "" {
+			return filepath.Join(stateDir, ProjectWorkspaceID(projectDir))
+		}
+		useLocal = true
 	case PawInProjectAuto:
+		if location := GlobalWorkspaceLocation(); location != PawInProjectAuto {
+			return GetWorkspaceDir(projectDir, location, isGitRepo)
+		}
 		// Auto: git repo -> global, non-git -> local
 		useLocal = !isGitRepo
 	default:
@@ -98,6 +123,41 @@ func GetWorkspaceDir(projectDir string, pawInProject PawInProject, isGitRepo boo
 	return filepath.Join(globalDir, ProjectWorkspaceID(projectDir))
 }
 
+// GlobalWorkspaceLocation returns workspace_location from the global config,
+// or PawInProjectAuto if it is unset or invalid.
+func GlobalWorkspaceLocation() PawInProject {
+	globalDir := GlobalPawDir()
+	if globalDir == "" {
+		return PawInProjectAuto
+	}
+	cfg, err := Load(globalDir)
+	if err != nil {
+		return PawInProjectAuto
+	}
+	location := PawInProject(cfg.WorkspaceLocation)
+	if !location.IsValid() {
+		return PawInProjectAuto
+	}
+	return location
+}
+
+// FindExistingWorkspace returns a workspace of the project that exists in
+// another location than target (local .paw, global, or XDG state), or "".
+// A directory only counts as a workspace if it has an agents directory,
+// so a committed .paw/config alone is never picked up.
+func FindExistingWorkspace(projectDir string, isGitRepo bool, target string) string {
+	for _, location := range []PawInProject{PawInProjectLocal, PawInProjectGlobal, PawInProjectXDG} {
+		dir := GetWorkspaceDir(projectDir, location, isGitRepo)
+		if filepath.Clean(dir) == filepath.Clean(target) {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(dir, constants.AgentsDirName)); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
 // LoadGlobal reads the global configuration from $HOME/.config/paw/config.
 // If the config file doesn't exist, it creates one with default values.
 func LoadGlobal() (*Config, error) {
@@ -173,8 +233,18 @@ const (
 	PawInProjectAuto   PawInProject = "auto"   // Git repo -> global, non-git -> local
 	PawInProjectGlobal PawInProject = "global" // Always use global workspace
 	PawInProjectLocal  PawInProject = "local"  // Always use local workspace
+	PawInProjectXDG    PawInProject = "xdg"    // Always use $XDG_STATE_HOME/paw
 )
 
+// IsValid reports whether the workspace location is a known option.
+func (p PawInProject) IsValid() bool {
+	switch p {
+	case PawInProjectAuto, PawInProjectGlobal, PawInProjectLocal, PawInProjectXDG:
+		return true
+	}
+	return false
+}
+
 // Config represents the PAW project configuration.
 type Config struct {
 	PreWorktreeHook string `yaml:"pre_worktree_hook"`
@@ -185,6 +255,411 @@ type Config struct {
 	LogFormat       string `yaml:"log_format"`
 	LogMaxSizeMB    int    `yaml:"log_max_size_mb"`
 	LogMaxBackups   int    `yaml:"log_max_backups"`
+	FailureRetries  int    `yaml:"failure_retries"`
+
+	// GitNetworkRetries is how many times git push, fetch, and pull are
+	// retried with exponential backoff when they fail on a network error
+	// (0 = no retries).
+	GitNetworkRetries int `yaml:"git_network_retries"`
+
+	// Commands are the project's build/test/lint/run commands, injected into
+	// task prompts so agents don't have to rediscover them.
+	Commands Commands `yaml:"commands"`
+
+	// VerifyBeforePush runs the build, lint, and test commands in the task
+	// worktree before pushing it (merge & push, PR); failures keep the task.
+	VerifyBeforePush bool `yaml:"verify_before_push"`
+
+	// OnComplete is what happens when an agent reports a task done: confirm
+	// (wait for ⌃F), or finish it automatically with merge, merge-push, or pr.
+	OnComplete string `yaml:"on_complete"`
+
+	// OnCompleteBranches overrides on_complete for tasks based on matching
+	// branches (e.g. release/* → confirm). The first matching rule wins.
+	OnCompleteBranches []BranchRule `yaml:"on_complete_branches"`
+
+	// ConfirmTimeoutHours finishes a done task waiting for review (confirm
+	// mode) after it has been untouched this long, using ConfirmTimeoutAction.
+	// 0 disables it.
+	ConfirmTimeoutHours  int    `yaml:"confirm_timeout_hours"`
+	ConfirmTimeoutAction string `yaml:"confirm_timeout_action"`
+
+	// AutoMergeMaxFiles and AutoMergeMaxLines cap the diff a task may have
+	// to be merged automatically (on_complete, confirm timeout). Larger
+	// tasks wait for review in confirm mode. 0 disables a limit.
+	AutoMergeMaxFiles int `yaml:"auto_merge_max_files"`
+	AutoMergeMaxLines int `yaml:"auto_merge_max_lines"`
+
+	// SelfEval has Claude score a task's work against its description when
+	// it is finished (merge, merge-push, pr). The rubric is saved with
+	// the task's artifacts; a score below SelfEvalMinScore (1-5) flags the
+	// task for review and holds its first merge.
+	SelfEval         bool `yaml:"self_eval"`
+	SelfEvalMinScore int  `yaml:"self_eval_min_score"`
+
+	// ReviewerModel runs a reviewer pass with this model (ideally another
+	// than the tasks') over a task's diff before it is merged. A rejection
+	// of an automatic merge (on_complete, confirm timeout) keeps the task
+	// for review as in confirm mode. Empty disables it.
+	ReviewerModel string `yaml:"reviewer_model"`
+
+	// KeepBranch keeps a finished task's branch when its worktree and
+	// window are cleaned up (e.g. for a follow-up PR review). ⌃F can
+	// toggle it per task; dropped tasks always lose their branch.
+	KeepBranch bool `yaml:"keep_branch"`
+
+	// CleanupPolicy decides what happens to a finished task's worktree and
+	// agent directory: immediate removes them, delayed parks them in
+	// .paw/parked/ for CleanupRetentionDays, manual parks them until
+	// 'paw parked prune'.
+	CleanupPolicy        string `yaml:"cleanup_policy"`
+	CleanupRetentionDays int    `yaml:"cleanup_retention_days"`
+
+	// SkipPermissions starts agents with --dangerously-skip-permissions;
+	// when off, Claude asks before running tools.
+	SkipPermissions bool `yaml:"skip_permissions"`
+
+	// FocusFollow starts sessions with focus-follow on: the active window
+	// switches to a task as soon as it needs input (toggle with ⌥F).
+	FocusFollow bool `yaml:"focus_follow"`
+
+	// EditorEndpoint serves the editor endpoint (HTTP on the .paw/editor.sock
+	// Unix socket) while the session runs, so editor plugins can create tasks
+	// from a code selection.
+	EditorEndpoint bool `yaml:"editor_endpoint"`
+
+	// WorkingHours limits when agents may run, as a local-time window
+	// ("08:00-20:00"). Tasks created outside it are queued and running
+	// agents are paused at its end. Empty means always.
+	WorkingHours string `yaml:"working_hours"`
+
+	// DailyTokenBudget caps the tokens the project's agents may use per day
+	// (input, output, and cache writes). Once it is used up, new tasks are
+	// queued until the next day or 'paw budget --override'. 0 disables it.
+	DailyTokenBudget int `yaml:"daily_token_budget"`
+
+	// Notifications configures remote notification channels (Slack, ntfy)
+	// used in addition to desktop notifications.
+	Notifications Notifications `yaml:"notifications"`
+
+	// StatusEmojis replaces the task status emojis shown in window names
+	// and the Kanban. Empty fields keep the defaults.
+	StatusEmojis StatusEmojis `yaml:"status_emojis"`
+
+	// TaskLayout arranges the panes of task windows (split direction and
+	// size, the user pane, and an optional extra pane).
+	TaskLayout TaskLayout `yaml:"task_layout"`
+
+	// ApprovalCommands lists command patterns that require user approval
+	// before an agent may run them (e.g. "rm -rf", "terraform apply").
+	ApprovalCommands []string `yaml:"approval_commands"`
+
+	// AutoAnswers are "pattern => response" rules: when the regex matches
+	// the tail of a task's pane, watch-wait sends the response to the agent.
+	AutoAnswers []string `yaml:"auto_answers"`
+
+	// DiffChecks are policy rules run on a task's diff before it is merged
+	// or pushed for a PR: "name [glob] => regex" fails on added lines that
+	// match, "name => $ command" fails when the command exits non-zero.
+	DiffChecks []string `yaml:"diff_checks"`
+
+	// SecurityScanners run on a task's commits before it is merged or
+	// pushed (gitleaks, semgrep). Reports go to the task's artifacts;
+	// critical findings keep the task open.
+	SecurityScanners []string `yaml:"security_scanners"`
+
+	// Changelog records merged tasks in the project's changelog: "fragment"
+	// writes changelog.d/<task>.<type>.md, "append" adds an entry to
+	// CHANGELOG.md. Empty disables it. The entry is part of the merge commit.
+	Changelog string `yaml:"changelog"`
+
+	// ChangelogTemplate formats changelog entries with {type}, {scope},
+	// {section}, {subject}, {task}, and {date} from the merge commit.
+	ChangelogTemplate string `yaml:"changelog_template"`
+
+	// PasteTemplate wraps the tmux paste buffer in a new task (⌥V); {buffer}
+	// is replaced with the buffer.
+	PasteTemplate string `yaml:"paste_template"`
+
+	// ReleaseVersionFiles are the files 'paw release' asks the release task
+	// to bump the version in (e.g. package.json, VERSION).
+	ReleaseVersionFiles []string `yaml:"release_version_files"`
+
+	// HistoryEncryption encrypts history files at rest (key from
+	// PAW_HISTORY_KEY or the OS keychain).
+	HistoryEncryption bool `yaml:"history_encryption"`
+
+	// LinkMode sets how task directories link to shared files: symlink, or
+	// copy for filesystems that deny symlinks.
+	LinkMode string `yaml:"link_mode"`
+
+	// TmuxMode selects the tmux server: dedicated (PAW's own server and
+	// keys), or cooperative (the user's default server, with PAW's keys
+	// behind prefix P and options restored when the session ends).
+	TmuxMode string `yaml:"tmux_mode"`
+
+	// AgentMode sets how agents run: interactive (status scraped from the
+	// pane), or stream-json (a headless run whose structured events give
+	// PAW exact status and cost, then an interactive session to continue).
+	AgentMode string `yaml:"agent_mode"`
+
+	// Clipboard is how copied text reaches the system clipboard: auto
+	// (detect pbcopy, wl-copy, xclip, or xsel, else OSC 52), osc52, or a
+	// command that reads the text from stdin.
+	Clipboard string `yaml:"clipboard"`
+
+	// GitignoreManagement controls adding PAW's .paw/ rules to the project's
+	// .gitignore at session start: auto, prompt (ask; auto when headless),
+	// or never.
+	GitignoreManagement string `yaml:"gitignore_management"`
+
+	// PaneCaptureLines is how many lines of pane history are captured when
+	// a task stops or finishes. Tasks can override it in their options.
+	PaneCaptureLines int `yaml:"pane_capture_lines"`
+
+	// PaneCaptureFormat sets whether the capture saved to history keeps
+	// colors: plain (stripped) or ansi. Tasks can override it in their options.
+	PaneCaptureFormat string `yaml:"pane_capture_format"`
+
+	// ContextFiles lists files (relative to the project) attached to every
+	// task's system prompt, e.g. ARCHITECTURE.md or CONTRIBUTING.md.
+	ContextFiles []string `yaml:"context_files"`
+
+	// ContextMaxKB is the size budget (in KB) shared by the context files;
+	// files over budget are truncated (0 = no limit).
+	ContextMaxKB int `yaml:"context_max_kb"`
+
+	// FileHistory adds the recent git log of files a task mentions (and the
+	// commits that last touched the lines it names) to the task's prompt.
+	FileHistory bool `yaml:"file_history"`
+
+	// PromptVariants are alternative system prompts for A/B experiments: each
+	// new task is assigned one at random (or the one in its options) and the
+	// outcome is recorded so 'paw variants' can compare them.
+	PromptVariants []PromptVariant `yaml:"prompt_variants"`
+
+	// MinFreeDiskMB is the free disk space (in MB) that must remain after
+	// creating a task worktree; task creation fails early otherwise.
+	MinFreeDiskMB int `yaml:"min_free_disk_mb"`
+
+	// ExcludeNestedRepos leaves untracked nested git repositories (that are
+	// not submodules) out of PAW's automatic commits instead of adding them
+	// as embedded repositories.
+	ExcludeNestedRepos bool `yaml:"exclude_nested_repos"`
+
+	// LargeFileMB is the size (in MB) above which automatic commits treat a
+	// file as large and apply LargeFileAction: skip, lfs, or allow. Files
+	// matching LFS patterns are skipped when git-lfs is missing. 0 disables
+	// the size check.
+	LargeFileMB     int    `yaml:"large_file_mb"`
+	LargeFileAction string `yaml:"large_file_action"`
+
+	// WorkspaceLocation sets where project workspaces are stored in auto mode
+	// (auto, local, global, xdg). Only read from the global config.
+	WorkspaceLocation string `yaml:"workspace_location"`
+}
+
+// Commands holds the project command registry.
+type Commands struct {
+	Build string `yaml:"build"`
+	Test  string `yaml:"test"`
+	Lint  string `yaml:"lint"`
+	Run   string `yaml:"run"`
+
+	// Coverage prints the project's total test coverage; the last
+	// percentage in its output is used. When set, finishing a task reports
+	// its coverage against the commit it forked from.
+	Coverage string `yaml:"coverage"`
+}
+
+// CommandEntry is a named project command.
+type CommandEntry struct {
+	Name    string
+	Command string
+}
+
+// Entries returns the configured commands in build, lint, test, run,
+// coverage order.
+func (c Commands) Entries() []CommandEntry {
+	var entries []CommandEntry
+	for _, e := range []CommandEntry{{"build", c.Build}, {"lint", c.Lint}, {"test", c.Test}, {"run", c.Run}, {"coverage", c.Coverage}} {
+		if e.Command != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// VerifyEntries returns the configured commands that verify a task before
+// it is pushed (build, lint, test).
+func (c Commands) VerifyEntries() []CommandEntry {
+	var entries []CommandEntry
+	for _, e := range c.Entries() {
+		if e.Name != "run" && e.Name != "coverage" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// BranchRule sets on_complete for tasks whose base branch matches Pattern.
+type BranchRule struct {
+	Pattern    string // path.Match glob, e.g. "release/*"
+	OnComplete string
+}
+
+// PromptVariant is a named system prompt for A/B experiments.
+type PromptVariant struct {
+	Name   string
+	Prompt string // File relative to .paw replacing PAW's system prompt; "default" keeps it
+}
+
+// DefaultPromptVariant is the Prompt of a variant that keeps PAW's own
+// system prompt (the control group).
+const DefaultPromptVariant = "default"
+
+// FindPromptVariant returns the prompt variant with the given name.
+func (c *Config) FindPromptVariant(name string) (PromptVariant, bool) {
+	for _, v := range c.PromptVariants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return PromptVariant{}, false
+}
+
+// OnCompleteFor returns the on_complete action for a task based on branch.
+func (c *Config) OnCompleteFor(branch string) string {
+	for _, rule := range c.OnCompleteBranches {
+		if matched, _ := path.Match(rule.Pattern, branch); matched {
+			return rule.OnComplete
+		}
+	}
+	return c.OnComplete
+}
+
+// PaneCapture returns how many lines of a task's pane to capture and whether
+// the capture keeps ANSI colors: the task's overrides when set, else the
+// project's settings.
+func (c *Config) PaneCapture(opts *TaskOptions) (lines int, ansi bool) {
+	lines, format := c.PaneCaptureLines, c.PaneCaptureFormat
+	if opts != nil {
+		if opts.PaneCaptureLines > 0 {
+			lines = opts.PaneCaptureLines
+		}
+		if opts.PaneCaptureFormat == constants.PaneCaptureFormatPlain || opts.PaneCaptureFormat == constants.PaneCaptureFormatANSI {
+			format = opts.PaneCaptureFormat
+		}
+	}
+	if lines <= 0 {
+		lines = constants.PaneCaptureLines
+	}
+	return lines, format == constants.PaneCaptureFormatANSI
+}
+
+// validOnComplete reports whether value is a valid on_complete action.
+func validOnComplete(value string) bool {
+	switch value {
+	case constants.OnCompleteConfirm, constants.ActionMerge, constants.ActionMergePush, constants.ActionPR:
+		return true
+	}
+	return false
+}
+
+// validReviewerModel reports whether model names a Claude model: an alias
+// (haiku, sonnet, opus) or a full model name such as claude-sonnet-4-5.
+func validReviewerModel(model string) bool {
+	for _, m := range ValidModels() {
+		if model == string(m) {
+			return true
+		}
+	}
+	return strings.HasPrefix(model, "claude-") && !strings.ContainsFunc(model, unicode.IsSpace)
+}
+
+// validVariantName reports whether name can name a prompt variant: letters,
+// digits, '-', '_' and '.', so it reads well in the variants ledger and tables.
+func validVariantName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// Notifications holds the remote notification channel settings. Tokens may
+// reference environment variables ($VAR) so secrets stay out of the config.
+type Notifications struct {
+	SlackToken   string `yaml:"slack_token"`
+	SlackChannel string `yaml:"slack_channel"`
+	NtfyServer   string `yaml:"ntfy_server"`
+	NtfyTopic    string `yaml:"ntfy_topic"`
+}
+
+// Slack reports whether Slack notifications are configured.
+func (n Notifications) Slack() bool {
+	return n.SlackToken != "" && n.SlackChannel != ""
+}
+
+// Ntfy reports whether ntfy notifications are configured.
+func (n Notifications) Ntfy() bool {
+	return n.NtfyTopic != ""
+}
+
+// StatusEmojis holds custom task status emojis.
+type StatusEmojis struct {
+	Working string `yaml:"working"`
+	Waiting string `yaml:"waiting"`
+	Review  string `yaml:"review"`
+	Warning string `yaml:"warning"`
+	Done    string `yaml:"done"`
+}
+
+// Task window split directions.
+const (
+	TaskSplitHorizontal = "horizontal" // Panes side by side
+	TaskSplitVertical   = "vertical"   // Panes stacked
+)
+
+// TaskLayout configures the panes of task windows. The zero value is the
+// default: the user pane beside the agent pane, splitting the window in half.
+type TaskLayout struct {
+	Split        string `yaml:"split"`          // TaskSplitHorizontal (default) or TaskSplitVertical
+	UserPaneSize int    `yaml:"user_pane_size"` // Percent of the window; 0 splits in half
+	NoUserPane   bool   `yaml:"-"`              // Set by user_pane: false
+	ExtraPane    string `yaml:"extra_pane"`     // Command for a third pane (e.g. a test watcher)
+}
+
+// Horizontal reports whether panes are placed side by side.
+func (l TaskLayout) Horizontal() bool {
+	return l.Split != TaskSplitVertical
+}
+
+// PaneCount returns the number of panes a task window starts with.
+func (l TaskLayout) PaneCount() int {
+	count := 1
+	if !l.NoUserPane {
+		count++
+	}
+	if l.ExtraPane != "" {
+		count++
+	}
+	return count
+}
+
+// statusEmojiField is a status_emojis setting and its config key.
+type statusEmojiField struct {
+	key   string
+	value *string
+}
+
+// fields returns the emoji settings in status order.
+func (e *StatusEmojis) fields() []statusEmojiField {
+	return []statusEmojiField{{"working", &e.Working}, {"waiting", &e.Waiting}, {"review", &e.Review}, {"warning", &e.Warning}, {"done", &e.Done}}
 }
 
 // Normalize validates configuration values, applying safe defaults when needed.
@@ -210,6 +685,218 @@ func (c *Config) Normalize() []string {
 	if c.LogMaxBackups < 0 {
 		c.LogMaxBackups = 3
 	}
+	if c.FailureRetries < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid failure_retries %d; disabling auto-retry", c.FailureRetries))
+		c.FailureRetries = 0
+	}
+	if c.GitNetworkRetries < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid git_network_retries %d; defaulting to %d", c.GitNetworkRetries, constants.DefaultGitNetworkRetries))
+		c.GitNetworkRetries = constants.DefaultGitNetworkRetries
+	}
+	switch c.LinkMode = strings.TrimSpace(c.LinkMode); c.LinkMode {
+	case "":
+		c.LinkMode = constants.LinkModeSymlink
+	case constants.LinkModeSymlink, constants.LinkModeCopy:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid link_mode %q; defaulting to %q", c.LinkMode, constants.LinkModeSymlink))
+		c.LinkMode = constants.LinkModeSymlink
+	}
+	if c.Clipboard = strings.TrimSpace(c.Clipboard); c.Clipboard == "" {
+		c.Clipboard = constants.ClipboardAuto
+	}
+	switch c.TmuxMode = strings.ToLower(strings.TrimSpace(c.TmuxMode)); c.TmuxMode {
+	case "":
+		c.TmuxMode = constants.TmuxModeDedicated
+	case constants.TmuxModeDedicated, constants.TmuxModeCooperative:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid tmux_mode %q; defaulting to %q", c.TmuxMode, constants.TmuxModeDedicated))
+		c.TmuxMode = constants.TmuxModeDedicated
+	}
+	switch c.AgentMode = strings.ToLower(strings.TrimSpace(c.AgentMode)); c.AgentMode {
+	case "":
+		c.AgentMode = constants.AgentModeInteractive
+	case constants.AgentModeInteractive, constants.AgentModeStreamJSON:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid agent_mode %q; defaulting to %q", c.AgentMode, constants.AgentModeInteractive))
+		c.AgentMode = constants.AgentModeInteractive
+	}
+	switch c.GitignoreManagement = strings.ToLower(strings.TrimSpace(c.GitignoreManagement)); c.GitignoreManagement {
+	case "":
+		c.GitignoreManagement = constants.GitignorePrompt
+	case constants.GitignoreAuto, constants.GitignorePrompt, constants.GitignoreNever:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid gitignore_management %q; defaulting to %q", c.GitignoreManagement, constants.GitignorePrompt))
+		c.GitignoreManagement = constants.GitignorePrompt
+	}
+	switch c.PaneCaptureFormat = strings.ToLower(strings.TrimSpace(c.PaneCaptureFormat)); c.PaneCaptureFormat {
+	case "":
+		c.PaneCaptureFormat = constants.PaneCaptureFormatPlain
+	case constants.PaneCaptureFormatPlain, constants.PaneCaptureFormatANSI:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid pane_capture_format %q; defaulting to %q", c.PaneCaptureFormat, constants.PaneCaptureFormatPlain))
+		c.PaneCaptureFormat = constants.PaneCaptureFormatPlain
+	}
+	if c.OnComplete = strings.TrimSpace(c.OnComplete); c.OnComplete == "" {
+		c.OnComplete = constants.OnCompleteConfirm
+	} else if !validOnComplete(c.OnComplete) {
+		warnings = append(warnings, fmt.Sprintf("invalid on_complete %q; defaulting to %q", c.OnComplete, constants.OnCompleteConfirm))
+		c.OnComplete = constants.OnCompleteConfirm
+	}
+	switch c.CleanupPolicy = strings.ToLower(strings.TrimSpace(c.CleanupPolicy)); c.CleanupPolicy {
+	case "":
+		c.CleanupPolicy = constants.CleanupImmediate
+	case constants.CleanupImmediate, constants.CleanupDelayed, constants.CleanupManual:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid cleanup_policy %q; defaulting to %q", c.CleanupPolicy, constants.CleanupImmediate))
+		c.CleanupPolicy = constants.CleanupImmediate
+	}
+	if c.CleanupRetentionDays <= 0 {
+		c.CleanupRetentionDays = constants.DefaultCleanupRetentionDays
+	}
+	if c.WorkingHours = strings.TrimSpace(c.WorkingHours); c.WorkingHours != "" {
+		if _, err := ParseWorkingHours(c.WorkingHours); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%v; agents may run at any time", err))
+			c.WorkingHours = ""
+		}
+	}
+	if c.DailyTokenBudget < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid daily_token_budget %d; disabling it", c.DailyTokenBudget))
+		c.DailyTokenBudget = 0
+	}
+	if c.ConfirmTimeoutHours < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid confirm_timeout_hours %d; disabling it", c.ConfirmTimeoutHours))
+		c.ConfirmTimeoutHours = 0
+	}
+	if c.ConfirmTimeoutAction = strings.TrimSpace(c.ConfirmTimeoutAction); c.ConfirmTimeoutAction == "" {
+		c.ConfirmTimeoutAction = constants.ActionMerge
+	} else if c.ConfirmTimeoutAction == constants.OnCompleteConfirm || !validOnComplete(c.ConfirmTimeoutAction) {
+		warnings = append(warnings, fmt.Sprintf("invalid confirm_timeout_action %q; defaulting to %q", c.ConfirmTimeoutAction, constants.ActionMerge))
+		c.ConfirmTimeoutAction = constants.ActionMerge
+	}
+	if c.AutoMergeMaxFiles < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid auto_merge_max_files %d; defaulting to %d", c.AutoMergeMaxFiles, constants.DefaultAutoMergeMaxFiles))
+		c.AutoMergeMaxFiles = constants.DefaultAutoMergeMaxFiles
+	}
+	if c.AutoMergeMaxLines < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid auto_merge_max_lines %d; defaulting to %d", c.AutoMergeMaxLines, constants.DefaultAutoMergeMaxLines))
+		c.AutoMergeMaxLines = constants.DefaultAutoMergeMaxLines
+	}
+	if c.SelfEvalMinScore < 0 || c.SelfEvalMinScore > 5 {
+		warnings = append(warnings, fmt.Sprintf("invalid self_eval_min_score %d; defaulting to %d", c.SelfEvalMinScore, constants.DefaultSelfEvalMinScore))
+		c.SelfEvalMinScore = constants.DefaultSelfEvalMinScore
+	} else if c.SelfEvalMinScore == 0 {
+		c.SelfEvalMinScore = constants.DefaultSelfEvalMinScore
+	}
+	if c.ReviewerModel != "" && !validReviewerModel(c.ReviewerModel) {
+		warnings = append(warnings, fmt.Sprintf("invalid reviewer_model %q; disabling the reviewer", c.ReviewerModel))
+		c.ReviewerModel = ""
+	}
+	rules := c.OnCompleteBranches[:0]
+	for _, rule := range c.OnCompleteBranches {
+		if _, err := path.Match(rule.Pattern, ""); err != nil || rule.Pattern == "" {
+			warnings = append(warnings, fmt.Sprintf("invalid on_complete_branches pattern %q; ignoring it", rule.Pattern))
+			continue
+		}
+		if !validOnComplete(rule.OnComplete) {
+			warnings = append(warnings, fmt.Sprintf("invalid on_complete %q for branches %q; ignoring it", rule.OnComplete, rule.Pattern))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	c.OnCompleteBranches = rules
+	variants := c.PromptVariants[:0]
+	seenVariants := make(map[string]bool)
+	for _, v := range c.PromptVariants {
+		if !validVariantName(v.Name) || seenVariants[v.Name] {
+			warnings = append(warnings, fmt.Sprintf("invalid or duplicate prompt_variants name %q; ignoring it", v.Name))
+			continue
+		}
+		if v.Prompt == "" {
+			v.Prompt = DefaultPromptVariant
+		}
+		seenVariants[v.Name] = true
+		variants = append(variants, v)
+	}
+	c.PromptVariants = variants
+	if c.MinFreeDiskMB < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid min_free_disk_mb %d; defaulting to %d", c.MinFreeDiskMB, constants.DefaultMinFreeDiskMB))
+		c.MinFreeDiskMB = constants.DefaultMinFreeDiskMB
+	}
+	scanners := c.SecurityScanners[:0]
+	for _, name := range c.SecurityScanners {
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case constants.SecurityScannerGitleaks, constants.SecurityScannerSemgrep:
+			scanners = append(scanners, name)
+		default:
+			warnings = append(warnings, fmt.Sprintf("unknown security scanner %q; ignoring it", name))
+		}
+	}
+	c.SecurityScanners = scanners
+	switch c.Changelog = strings.ToLower(strings.TrimSpace(c.Changelog)); c.Changelog {
+	case "", constants.ChangelogFragment, constants.ChangelogAppend:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid changelog %q; disabling it (use %q or %q)", c.Changelog, constants.ChangelogFragment, constants.ChangelogAppend))
+		c.Changelog = ""
+	}
+	if c.LargeFileMB < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid large_file_mb %d; defaulting to %d", c.LargeFileMB, constants.DefaultLargeFileMB))
+		c.LargeFileMB = constants.DefaultLargeFileMB
+	}
+	switch c.LargeFileAction = strings.ToLower(strings.TrimSpace(c.LargeFileAction)); c.LargeFileAction {
+	case "":
+		c.LargeFileAction = constants.LargeFileSkip
+	case constants.LargeFileSkip, constants.LargeFileLFS, constants.LargeFileAllow:
+	default:
+		warnings = append(warnings, fmt.Sprintf("invalid large_file_action %q; defaulting to %q", c.LargeFileAction, constants.LargeFileSkip))
+		c.LargeFileAction = constants.LargeFileSkip
+	}
+	if c.PaneCaptureLines < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid pane_capture_lines %d; defaulting to %d", c.PaneCaptureLines, constants.PaneCaptureLines))
+		c.PaneCaptureLines = constants.PaneCaptureLines
+	} else if c.PaneCaptureLines == 0 {
+		c.PaneCaptureLines = constants.PaneCaptureLines
+	}
+	if c.ContextMaxKB < 0 {
+		warnings = append(warnings, fmt.Sprintf("invalid context_max_kb %d; defaulting to %d", c.ContextMaxKB, constants.DefaultContextMaxKB))
+		c.ContextMaxKB = constants.DefaultContextMaxKB
+	}
+	// Window names are matched by emoji prefix, so emojis must be distinct
+	// and must not prefix one another
+	emojiFields := c.StatusEmojis.fields()
+	effective := []string{constants.EmojiWorking, constants.EmojiWaiting, constants.EmojiReview, constants.EmojiWarning, constants.EmojiDone}
+	for i, f := range emojiFields {
+		*f.value = strings.Trim(strings.TrimSpace(*f.value), `"'`)
+		if *f.value != "" {
+			effective[i] = *f.value
+		}
+	}
+	for i, f := range emojiFields {
+		if *f.value == "" {
+			continue
+		}
+		others := append([]string{constants.EmojiNew}, effective[:i]...)
+		others = append(others, effective[i+1:]...)
+		for _, other := range others {
+			if strings.HasPrefix(*f.value, other) || strings.HasPrefix(other, *f.value) {
+				warnings = append(warnings, fmt.Sprintf("status_emojis.%s %q clashes with %q; using the default", f.key, *f.value, other))
+				*f.value = ""
+				break
+			}
+		}
+	}
+	c.TaskLayout.Split = strings.ToLower(strings.TrimSpace(c.TaskLayout.Split))
+	if c.TaskLayout.Split != "" && c.TaskLayout.Split != TaskSplitHorizontal && c.TaskLayout.Split != TaskSplitVertical {
+		warnings = append(warnings, fmt.Sprintf("invalid task_layout.split %q; defaulting to %q", c.TaskLayout.Split, TaskSplitHorizontal))
+		c.TaskLayout.Split = ""
+	}
+	if c.TaskLayout.UserPaneSize != 0 && (c.TaskLayout.UserPaneSize < 10 || c.TaskLayout.UserPaneSize > 90) {
+		warnings = append(warnings, fmt.Sprintf("invalid task_layout.user_pane_size %d; must be 10-90, splitting in half", c.TaskLayout.UserPaneSize))
+		c.TaskLayout.UserPaneSize = 0
+	}
+	if c.WorkspaceLocation != "" && !PawInProject(c.WorkspaceLocation).IsValid() {
+		warnings = append(warnings, fmt.Sprintf("invalid workspace_location %q; defaulting to %q", c.WorkspaceLocation, PawInProjectAuto))
+		c.WorkspaceLocation = ""
+	}
 
 	return warnings
 }
@@ -217,9 +904,32 @@ func (c *Config) Normalize() []string {
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		LogFormat:     constants.LogFormatText,
-		LogMaxSizeMB:  10,
-		LogMaxBackups: 3,
+		LogFormat:            constants.LogFormatText,
+		LogMaxSizeMB:         10,
+		LogMaxBackups:        3,
+		LinkMode:             constants.LinkModeSymlink,
+		TmuxMode:             constants.TmuxModeDedicated,
+		AgentMode:            constants.AgentModeInteractive,
+		Clipboard:            constants.ClipboardAuto,
+		GitignoreManagement:  constants.GitignorePrompt,
+		MinFreeDiskMB:        constants.DefaultMinFreeDiskMB,
+		ExcludeNestedRepos:   true,
+		LargeFileMB:          constants.DefaultLargeFileMB,
+		LargeFileAction:      constants.LargeFileSkip,
+		PaneCaptureLines:     constants.PaneCaptureLines,
+		PaneCaptureFormat:    constants.PaneCaptureFormatPlain,
+		ContextMaxKB:         constants.DefaultContextMaxKB,
+		VerifyBeforePush:     true,
+		OnComplete:           constants.OnCompleteConfirm,
+		ConfirmTimeoutAction: constants.ActionMerge,
+		CleanupPolicy:        constants.CleanupImmediate,
+		CleanupRetentionDays: constants.DefaultCleanupRetentionDays,
+		GitNetworkRetries:    constants.DefaultGitNetworkRetries,
+		AutoMergeMaxFiles:    constants.DefaultAutoMergeMaxFiles,
+		AutoMergeMaxLines:    constants.DefaultAutoMergeMaxLines,
+		SelfEvalMinScore:     constants.DefaultSelfEvalMinScore,
+		SkipPermissions:      true,
+		FileHistory:          true,
 	}
 }
 
@@ -229,6 +939,30 @@ func (c *Config) Clone() *Config {
 		return nil
 	}
 	clone := *c
+	if c.ApprovalCommands != nil {
+		clone.ApprovalCommands = append([]string(nil), c.ApprovalCommands...)
+	}
+	if c.AutoAnswers != nil {
+		clone.AutoAnswers = append([]string(nil), c.AutoAnswers...)
+	}
+	if c.DiffChecks != nil {
+		clone.DiffChecks = append([]string(nil), c.DiffChecks...)
+	}
+	if c.SecurityScanners != nil {
+		clone.SecurityScanners = append([]string(nil), c.SecurityScanners...)
+	}
+	if c.ReleaseVersionFiles != nil {
+		clone.ReleaseVersionFiles = append([]string(nil), c.ReleaseVersionFiles...)
+	}
+	if c.ContextFiles != nil {
+		clone.ContextFiles = append([]string(nil), c.ContextFiles...)
+	}
+	if c.OnCompleteBranches != nil {
+		clone.OnCompleteBranches = append([]BranchRule(nil), c.OnCompleteBranches...)
+	}
+	if c.PromptVariants != nil {
+		clone.PromptVariants = append([]PromptVariant(nil), c.PromptVariants...)
+	}
 	return &clone
 }
 
@@ -272,13 +1006,229 @@ log_format: %s
 log_max_size_mb: %d
 log_max_backups: %d
 
+# Auto-retry failed agents (build/test errors, conflicts) with a refined prompt
+# Number of retries per task (0 = disabled)
+failure_retries: %d
+
+# Retry git push/fetch/pull that fail on a network error, with exponential
+# backoff and jitter (0 = disabled)
+git_network_retries: %d
+
 # Hooks (optional) (supports multi-line command with ': |')
 # pre_worktree_hook: echo "pre worktree"
 # pre_task_hook: echo "pre task"
 # post_task_hook: echo "post task"
 # pre_merge_hook: echo "pre merge"
 # post_merge_hook: echo "post merge"
-`, c.LogFormat, c.LogMaxSizeMB, c.LogMaxBackups)
+
+# Project commands, included in every task's prompt so agents don't have to
+# rediscover how to build and test the project. coverage prints the total
+# coverage (the last percentage is used); finishing a task reports its delta
+# commands:
+#   build: go build ./...
+#   lint: go vet ./...
+#   test: go test ./...
+#   run: go run ./cmd/app
+#   coverage: go test -coverprofile=/tmp/cover.out ./... >/dev/null && go tool cover -func=/tmp/cover.out | tail -1
+
+# Run the build, lint, and test commands before pushing a task (merge & push, PR)
+verify_before_push: %t
+
+# What happens when an agent reports a task done: confirm (wait for ⌃F), or
+# finish it automatically with merge, merge-push, or pr
+on_complete: %s
+
+# Per-branch on_complete for tasks started from matching branches (first match wins)
+# on_complete_branches:
+#   release/*: confirm
+#   main: pr
+
+# In confirm mode, finish a done task that has been left untouched for this many
+# hours with confirm_timeout_action (merge, merge-push, pr); 0 = off
+confirm_timeout_hours: %d
+confirm_timeout_action: %s
+
+# Tasks whose diff changes more files or lines than this are never merged
+# automatically: they wait for review in confirm mode (0 = no limit)
+auto_merge_max_files: %d
+auto_merge_max_lines: %d
+
+# Have Claude score a task's work (1-5) against its description when it is
+# finished; lower scores than self_eval_min_score flag it for review and hold
+# its first merge
+self_eval: %t
+self_eval_min_score: %d
+
+# Have another model (haiku, sonnet, opus, or a full model name) review a
+# task's diff before it is merged; a rejection keeps automatic merges for review
+# reviewer_model: sonnet
+
+# Keep a finished task's branch when its worktree and window are cleaned up
+# (toggle per task with b in the ⌃F picker; Drop always deletes it)
+keep_branch: %t
+
+# What happens to a finished task's worktree and agent directory: immediate
+# (remove), delayed (park in .paw/parked/ for cleanup_retention_days), or
+# manual (park until 'paw parked prune')
+cleanup_policy: %s
+cleanup_retention_days: %d
+
+# Start agents with --dangerously-skip-permissions (false = Claude asks before running tools)
+skip_permissions: %t
+
+# Switch to a task's window as soon as it needs input, unless you are typing
+# (toggle in the session with ⌥F)
+focus_follow: %t
+
+# Let editor plugins (VS Code, Neovim) create tasks from a code selection over
+# HTTP on the .paw/editor.sock Unix socket while the session runs
+editor_endpoint: %t
+
+# Hours when agents may run, in local time (empty = always). Tasks created
+# outside them are queued until the next start; running agents are paused at
+# the end with a notification and resumed when the hours start again
+# working_hours: 08:00-20:00
+
+# Tokens the project's agents may use per day (0 = no limit). New tasks are
+# queued once it is used up; inspect or override it with 'paw budget'
+# daily_token_budget: 5000000
+
+# Remote notifications in addition to desktop notifications (set up with 'paw setup')
+# Tokens can reference environment variables so they stay out of this file
+# notifications:
+#   slack_token: $PAW_SLACK_TOKEN
+#   slack_channel: #paw
+#   ntfy_topic: my-paw-alerts
+#   ntfy_server: https://ntfy.sh
+
+# Custom task status emojis for window names and the Kanban (restart the session to apply)
+# status_emojis:
+#   working: 🔨
+#   done: 🎉
+
+# Task window panes: split (horizontal = side by side, vertical = stacked),
+# user pane size in percent, user_pane: false to skip the shell pane, and an
+# extra pane running a command next to it
+# task_layout:
+#   split: vertical
+#   user_pane_size: 30
+#   extra_pane: npm run test:watch
+
+# Commands that need your approval before an agent runs them (one per line)
+# approval_commands: |
+#   rm -rf
+#   terraform apply
+#   kubectl delete
+
+# Prompts answered automatically: "regex => response", one per line. When the
+# regex matches the bottom of a task's pane, the response is sent to the agent
+# (recorded per task, see 'paw audit --auto-answers')
+# auto_answers: |
+#   Proceed with npm install\? => y
+#   Do you want to overwrite .*\? => n
+
+# Policy checks run on a task's diff before merging or opening a PR, one per
+# line: "name [glob] => regex" fails on added lines that match (glob optional),
+# "name => $ command" fails when the command exits non-zero (run in the worktree
+# with PAW_DIFF_BASE and PAW_CHANGED_FILES). Violations keep the task open
+# diff_checks: |
+#   no-todo => TODO|FIXME
+#   no-println [*.go] => fmt\.Println
+#   license => $ ./scripts/check-license-headers.sh
+
+# Security scanners run on a task's commits before merging or pushing: gitleaks
+# (every leaked secret blocks) and semgrep (ERROR findings block). Reports are
+# saved to the task's artifacts; scanners that aren't installed are skipped
+# security_scanners: gitleaks, semgrep
+
+# Changelog entry for each merged task, derived from its conventional commit:
+# fragment (changelog.d/<task>.<type>.md) or append (CHANGELOG.md, below its
+# "Unreleased" heading). The template takes {type}, {scope}, {section},
+# {subject}, {task}, and {date}
+# changelog: fragment
+# changelog_template: - {type}: {subject}
+
+# Task pre-filled by ⌥V from the tmux paste buffer (e.g. an error you copied);
+# {buffer} is replaced with the buffer
+# paste_template: |
+#   Investigate and fix:
+#
+#   {buffer}
+
+# Files the 'paw release' task bumps the version in
+# release_version_files: package.json, VERSION
+
+# Files attached to every task's system prompt (one per line, relative to the project)
+# context_files: |
+#   ARCHITECTURE.md
+#   CONTRIBUTING.md
+
+# Size budget (KB) shared by the context files; larger files are truncated
+# (0 = no limit)
+context_max_kb: %d
+
+# Add the recent git log of files a task mentions (and blame for the lines it
+# names, as in path/to/file.go:42-58) to the task's prompt
+file_history: %t
+
+# System prompt variants for A/B experiments: each new task gets one at random
+# (or the one set in its options); compare them with 'paw variants'. Values are
+# prompt files relative to .paw, or "default" for PAW's own prompt
+# prompt_variants:
+#   control: default
+#   concise: variants/concise.md
+
+# Encrypt task history at rest (AES-256-GCM; key from PAW_HISTORY_KEY or the
+# OS keychain, see 'paw history init-key')
+history_encryption: %t
+
+# How task directories link to shared files (.claude, project root, paw binary):
+# symlink, or copy for filesystems that deny symlinks (exFAT, some NFS mounts)
+link_mode: %s
+
+# tmux server: dedicated (PAW's own server, prefix, and options), or cooperative
+# (your default server and config; PAW's keys are behind prefix + P, and the
+# options PAW changes are restored when the session ends)
+tmux_mode: %s
+
+# How agents run: interactive (PAW reads status from the terminal), or
+# stream-json (the task runs headless with --output-format stream-json, giving
+# PAW exact status and cost, then continues as an interactive session)
+agent_mode: %s
+
+# How copied text reaches the clipboard: auto (pbcopy, wl-copy, xclip, or xsel,
+# else OSC 52), osc52 (terminal escape sequence, works over SSH), or a command
+# that reads the text from stdin (e.g. clip.exe)
+clipboard: %s
+
+# Add the .paw/ rules to .gitignore at session start: auto (without asking),
+# prompt (ask; auto when stdin is not a terminal), or never
+gitignore_management: %s
+
+# Lines of pane history captured when a task stops or finishes, and whether the
+# capture saved to history keeps colors: plain or ansi (for HTML export). A task
+# can override both (pane_capture_lines, pane_capture_format) in its .options.json
+pane_capture_lines: %d
+pane_capture_format: %s
+
+# Free disk space (MB) to keep after creating a task worktree; task creation
+# fails early if the checkout would leave less (0 = only require the checkout size)
+min_free_disk_mb: %d
+
+# Leave untracked nested git repositories (not submodules) out of automatic
+# commits instead of committing them as embedded repositories
+exclude_nested_repos: %t
+
+# Files over this size (MB) in automatic commits are left out (skip), tracked
+# with git-lfs (lfs), or committed anyway with a warning (allow). Files matching
+# LFS patterns in .gitattributes are left out while git-lfs is missing (0 = no size limit)
+large_file_mb: %d
+large_file_action: %s
+
+# Where project workspaces are stored (global config only): auto, local, global, xdg
+# xdg keeps logs, history, and agents in $XDG_STATE_HOME/paw/<project-id>/
+# workspace_location: xdg
+`, c.LogFormat, c.LogMaxSizeMB, c.LogMaxBackups, c.FailureRetries, c.GitNetworkRetries, c.VerifyBeforePush, c.OnComplete, c.ConfirmTimeoutHours, c.ConfirmTimeoutAction, c.AutoMergeMaxFiles, c.AutoMergeMaxLines, c.SelfEval, c.SelfEvalMinScore, c.KeepBranch, c.CleanupPolicy, c.CleanupRetentionDays, c.SkipPermissions, c.FocusFollow, c.EditorEndpoint, c.ContextMaxKB, c.FileHistory, c.HistoryEncryption, c.LinkMode, c.TmuxMode, c.AgentMode, c.Clipboard, c.GitignoreManagement, c.PaneCaptureLines, c.PaneCaptureFormat, c.MinFreeDiskMB, c.ExcludeNestedRepos, c.LargeFileMB, c.LargeFileAction)
 
 	// Add hooks if set
 	if c.PreWorktreeHook != "" {
@@ -296,6 +1246,94 @@ log_max_backups: %d
 	if c.PostMergeHook != "" {
 		content += formatHook("post_merge_hook", c.PostMergeHook)
 	}
+	if entries := c.Commands.Entries(); len(entries) > 0 {
+		content += "commands:\n"
+		for _, e := range entries {
+			content += fmt.Sprintf("  %s: %s\n", e.Name, e.Command)
+		}
+	}
+	if len(c.OnCompleteBranches) > 0 {
+		content += "on_complete_branches:\n"
+		for _, rule := range c.OnCompleteBranches {
+			content += fmt.Sprintf("  %s: %s\n", rule.Pattern, rule.OnComplete)
+		}
+	}
+	if len(c.PromptVariants) > 0 {
+		content += "prompt_variants:\n"
+		for _, v := range c.PromptVariants {
+			content += fmt.Sprintf("  %s: %s\n", v.Name, v.Prompt)
+		}
+	}
+	if n := c.Notifications; n != (Notifications{}) {
+		content += "notifications:\n"
+		for _, kv := range [][2]string{{"slack_token", n.SlackToken}, {"slack_channel", n.SlackChannel}, {"ntfy_server", n.NtfyServer}, {"ntfy_topic", n.NtfyTopic}} {
+			if kv[1] != "" {
+				content += fmt.Sprintf("  %s: %s\n", kv[0], kv[1])
+			}
+		}
+	}
+	if c.StatusEmojis != (StatusEmojis{}) {
+		content += "status_emojis:\n"
+		for _, f := range c.StatusEmojis.fields() {
+			if *f.value != "" {
+				content += fmt.Sprintf("  %s: %s\n", f.key, *f.value)
+			}
+		}
+	}
+	if l := c.TaskLayout; l != (TaskLayout{}) {
+		content += "task_layout:\n"
+		if l.Split != "" {
+			content += fmt.Sprintf("  split: %s\n", l.Split)
+		}
+		if l.UserPaneSize != 0 {
+			content += fmt.Sprintf("  user_pane_size: %d\n", l.UserPaneSize)
+		}
+		if l.NoUserPane {
+			content += "  user_pane: false\n"
+		}
+		if l.ExtraPane != "" {
+			content += fmt.Sprintf("  extra_pane: %s\n", l.ExtraPane)
+		}
+	}
+	if len(c.ApprovalCommands) > 0 {
+		content += formatHook("approval_commands", strings.Join(c.ApprovalCommands, "\n"))
+	}
+	if len(c.AutoAnswers) > 0 {
+		content += formatHook("auto_answers", strings.Join(c.AutoAnswers, "\n"))
+	}
+	if len(c.DiffChecks) > 0 {
+		content += formatHook("diff_checks", strings.Join(c.DiffChecks, "\n"))
+	}
+	if len(c.SecurityScanners) > 0 {
+		content += fmt.Sprintf("security_scanners: %s\n", strings.Join(c.SecurityScanners, ", "))
+	}
+	if c.Changelog != "" {
+		content += fmt.Sprintf("changelog: %s\n", c.Changelog)
+	}
+	if c.ChangelogTemplate != "" {
+		content += formatHook("changelog_template", c.ChangelogTemplate)
+	}
+	if c.PasteTemplate != "" {
+		content += formatHook("paste_template", c.PasteTemplate)
+	}
+	if len(c.ReleaseVersionFiles) > 0 {
+		content += fmt.Sprintf("release_version_files: %s\n", strings.Join(c.ReleaseVersionFiles, ", "))
+	}
+	if len(c.ContextFiles) > 0 {
+		content += formatHook("context_files", strings.Join(c.ContextFiles, "\n"))
+	}
+	if c.WorkingHours != "" {
+		content += fmt.Sprintf("working_hours: %s\n", c.WorkingHours)
+	}
+	if c.ReviewerModel != "" {
+		content += fmt.Sprintf("reviewer_model: %s\n", c.ReviewerModel)
+	}
+	if c.DailyTokenBudget > 0 {
+		content += fmt.Sprintf("daily_token_budget: %d\n", c.DailyTokenBudget)
+	}
+	if c.WorkspaceLocation != "" {
+		content += fmt.Sprintf("workspace_location: %s\n", c.WorkspaceLocation)
+	}
 
 	if err := fileutil.WriteFileAtomic(configPath, []byte(content), 0644); err != nil {
 		logging.Debug("config.Save: failed to write config: %v", err)
@@ -35,6 +35,33 @@ func parseConfig(content string) *Config {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		if value == "" && hasIndentedBlock(lines, i) {
+			switch key {
+			case "commands":
+				parseNestedBlock(lines, &i, cfg.Commands.set)
+				continue
+			case "notifications":
+				parseNestedBlock(lines, &i, cfg.Notifications.set)
+				continue
+			case "status_emojis":
+				parseNestedBlock(lines, &i, cfg.StatusEmojis.set)
+				continue
+			case "task_layout":
+				parseNestedBlock(lines, &i, cfg.TaskLayout.set)
+				continue
+			case "on_complete_branches":
+				parseNestedBlock(lines, &i, func(pattern, action string) {
+					cfg.OnCompleteBranches = append(cfg.OnCompleteBranches, BranchRule{Pattern: strings.Trim(pattern, `"'`), OnComplete: action})
+				})
+				continue
+			case "prompt_variants":
+				parseNestedBlock(lines, &i, func(name, prompt string) {
+					cfg.PromptVariants = append(cfg.PromptVariants, PromptVariant{Name: name, Prompt: strings.Trim(prompt, `"'`)})
+				})
+				continue
+			}
+		}
+
 		// Skip unsupported nested blocks to avoid mis-parsing indented content.
 		if value == "" && hasIndentedBlock(lines, i) {
 			skipIndentedBlock(lines, &i)
@@ -98,12 +125,168 @@ func parseConfig(content string) *Config {
 			if parsed, err := strconv.Atoi(value); err == nil {
 				cfg.LogMaxBackups = parsed
 			}
+		case "failure_retries":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.FailureRetries = parsed
+			}
+		case "git_network_retries":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.GitNetworkRetries = parsed
+			}
+		case "verify_before_push":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.VerifyBeforePush = parsed
+			}
+		case "on_complete":
+			cfg.OnComplete = value
+		case "cleanup_policy":
+			cfg.CleanupPolicy = value
+		case "cleanup_retention_days":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.CleanupRetentionDays = parsed
+			}
+		case "keep_branch":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.KeepBranch = parsed
+			}
+		case "confirm_timeout_hours":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.ConfirmTimeoutHours = parsed
+			}
+		case "confirm_timeout_action":
+			cfg.ConfirmTimeoutAction = value
+		case "auto_merge_max_files":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.AutoMergeMaxFiles = parsed
+			}
+		case "auto_merge_max_lines":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.AutoMergeMaxLines = parsed
+			}
+		case "self_eval":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.SelfEval = parsed
+			}
+		case "self_eval_min_score":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.SelfEvalMinScore = parsed
+			}
+		case "skip_permissions":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.SkipPermissions = parsed
+			}
+		case "focus_follow":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.FocusFollow = parsed
+			}
+		case "editor_endpoint":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.EditorEndpoint = parsed
+			}
+		case "approval_commands":
+			cfg.ApprovalCommands = parseList(value)
+		case "auto_answers":
+			cfg.AutoAnswers = parseLines(value)
+		case "diff_checks":
+			cfg.DiffChecks = parseLines(value)
+		case "security_scanners":
+			cfg.SecurityScanners = parseList(value)
+		case "changelog":
+			cfg.Changelog = value
+		case "changelog_template":
+			cfg.ChangelogTemplate = value
+		case "paste_template":
+			cfg.PasteTemplate = value
+		case "release_version_files":
+			cfg.ReleaseVersionFiles = parseList(value)
+		case "context_files":
+			cfg.ContextFiles = parseList(value)
+		case "context_max_kb":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.ContextMaxKB = parsed
+			}
+		case "file_history":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.FileHistory = parsed
+			}
+		case "history_encryption":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.HistoryEncryption = parsed
+			}
+		case "link_mode":
+			cfg.LinkMode = value
+		case "tmux_mode":
+			cfg.TmuxMode = value
+		case "agent_mode":
+			cfg.AgentMode = value
+		case "clipboard":
+			cfg.Clipboard = value
+		case "gitignore_management":
+			cfg.GitignoreManagement = value
+		case "pane_capture_lines":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.PaneCaptureLines = parsed
+			}
+		case "pane_capture_format":
+			cfg.PaneCaptureFormat = value
+		case "min_free_disk_mb":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.MinFreeDiskMB = parsed
+			}
+		case "exclude_nested_repos":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.ExcludeNestedRepos = parsed
+			}
+		case "large_file_mb":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.LargeFileMB = parsed
+			}
+		case "large_file_action":
+			cfg.LargeFileAction = value
+		case "working_hours":
+			cfg.WorkingHours = value
+		case "reviewer_model":
+			cfg.ReviewerModel = value
+		case "daily_token_budget":
+			if parsed, err := strconv.Atoi(value); err == nil {
+				cfg.DailyTokenBudget = parsed
+			}
+		case "workspace_location":
+			cfg.WorkspaceLocation = value
 		}
 	}
 
 	return cfg
 }
 
+// parseList parses a list value, one item per line or comma-separated.
+// Empty items are dropped.
+func parseList(value string) []string {
+	sep := ","
+	if strings.Contains(value, "\n") {
+		sep = "\n"
+	}
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseLines splits a block value into trimmed, non-empty lines. Unlike
+// parseList, commas never separate items (rules may contain them).
+func parseLines(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getIndentLevel returns the indentation level of a line at the given index.
 func getIndentLevel(lines []string, index int) int {
 	if index < 0 || index >= len(lines) {
@@ -159,6 +342,87 @@ func skipIndentedBlock(lines []string, i *int) {
 	}
 }
 
+// parseNestedBlock parses the indented "key: value" lines of a nested block,
+// calling set for each entry.
+func parseNestedBlock(lines []string, i *int, set func(key, value string)) {
+	baseIndent := getIndentLevel(lines, *i)
+	*i++ // Move past the parent line
+
+	for *i < len(lines) {
+		line := lines[*i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			*i++
+			continue
+		}
+		if countLeadingSpaces(line) <= baseIndent {
+			break
+		}
+		*i++
+
+		if key, value, ok := strings.Cut(trimmed, ":"); ok {
+			set(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	}
+}
+
+// set assigns a command from a commands block. Unknown names are ignored.
+func (c *Commands) set(key, value string) {
+	switch key {
+	case "build":
+		c.Build = value
+	case "test":
+		c.Test = value
+	case "lint":
+		c.Lint = value
+	case "run":
+		c.Run = value
+	case "coverage":
+		c.Coverage = value
+	}
+}
+
+// set assigns a channel setting from a notifications block. Unknown keys are ignored.
+func (n *Notifications) set(key, value string) {
+	switch key {
+	case "slack_token":
+		n.SlackToken = value
+	case "slack_channel":
+		n.SlackChannel = value
+	case "ntfy_server":
+		n.NtfyServer = value
+	case "ntfy_topic":
+		n.NtfyTopic = value
+	}
+}
+
+// set assigns an emoji from a status_emojis block. Unknown keys are ignored.
+func (e *StatusEmojis) set(key, value string) {
+	for _, f := range e.fields() {
+		if f.key == key {
+			*f.value = strings.Trim(value, `"'`)
+		}
+	}
+}
+
+// set assigns a task_layout setting. Unknown keys are ignored.
+func (l *TaskLayout) set(key, value string) {
+	switch key {
+	case "split":
+		l.Split = value
+	case "user_pane_size":
+		if n, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil {
+			l.UserPaneSize = n
+		}
+	case "user_pane":
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			l.NoUserPane = !enabled
+		}
+	case "extra_pane":
+		l.ExtraPane = value
+	}
+}
+
 // formatHook formats a hook command for saving.
 // Multi-line values use YAML-like '|' syntax.
 func formatHook(key, hook string) string {
@@ -125,6 +125,984 @@ func TestConfigNormalize_InvalidLogFormat(t *testing.T) {
 	}
 }
 
+func TestParseConfig_FailureRetries(t *testing.T) {
+	cfg := parseConfig("failure_retries: 2\n")
+	if cfg.FailureRetries != 2 {
+		t.Errorf("FailureRetries = %d, want 2", cfg.FailureRetries)
+	}
+}
+
+func TestParseConfig_ApprovalCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"multi-line", "approval_commands: |\n  rm -rf\n\n  terraform apply\n", []string{"rm -rf", "terraform apply"}},
+		{"comma-separated", "approval_commands: rm -rf, kubectl delete\n", []string{"rm -rf", "kubectl delete"}},
+		{"empty", "approval_commands:\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := parseConfig(tt.content)
+			if strings.Join(cfg.ApprovalCommands, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ApprovalCommands = %q, want %q", cfg.ApprovalCommands, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip_ApprovalCommands(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.ApprovalCommands = []string{"rm -rf", "terraform apply"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if strings.Join(loaded.ApprovalCommands, "|") != "rm -rf|terraform apply" {
+		t.Errorf("ApprovalCommands = %q", loaded.ApprovalCommands)
+	}
+}
+
+func TestRoundTrip_AutoAnswers(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.AutoAnswers = []string{`Proceed with npm install\? => y`, `Overwrite (a, b)\? => n`}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if strings.Join(loaded.AutoAnswers, "|") != strings.Join(cfg.AutoAnswers, "|") {
+		t.Errorf("AutoAnswers = %q, want %q", loaded.AutoAnswers, cfg.AutoAnswers)
+	}
+}
+
+func TestRoundTrip_DiffChecks(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.DiffChecks = []string{`no-println [*.go] => fmt\.Println`, `license => $ ./check.sh --all, --strict`}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if strings.Join(loaded.DiffChecks, "|") != strings.Join(cfg.DiffChecks, "|") {
+		t.Errorf("DiffChecks = %q, want %q", loaded.DiffChecks, cfg.DiffChecks)
+	}
+}
+
+func TestRoundTrip_SecurityScanners(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.SecurityScanners = []string{constants.SecurityScannerGitleaks, constants.SecurityScannerSemgrep}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if strings.Join(loaded.SecurityScanners, ",") != "gitleaks,semgrep" {
+		t.Errorf("SecurityScanners = %q, want [gitleaks semgrep]", loaded.SecurityScanners)
+	}
+}
+
+func TestConfigNormalize_UnknownSecurityScanner(t *testing.T) {
+	cfg := &Config{LogFormat: "text", SecurityScanners: []string{"Gitleaks", "trivy"}}
+
+	warnings := cfg.Normalize()
+
+	if strings.Join(cfg.SecurityScanners, ",") != constants.SecurityScannerGitleaks {
+		t.Errorf("SecurityScanners = %q, want [gitleaks]", cfg.SecurityScanners)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_ChangelogAndRelease(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.Changelog = constants.ChangelogAppend
+	cfg.ChangelogTemplate = "- **{scope}**: {subject} ({task})"
+	cfg.ReleaseVersionFiles = []string{"package.json", "VERSION"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Changelog != cfg.Changelog || loaded.ChangelogTemplate != cfg.ChangelogTemplate {
+		t.Errorf("Changelog = %q, ChangelogTemplate = %q; want %q, %q", loaded.Changelog, loaded.ChangelogTemplate, cfg.Changelog, cfg.ChangelogTemplate)
+	}
+	if strings.Join(loaded.ReleaseVersionFiles, ",") != "package.json,VERSION" {
+		t.Errorf("ReleaseVersionFiles = %q, want [package.json VERSION]", loaded.ReleaseVersionFiles)
+	}
+}
+
+func TestConfigNormalize_InvalidChangelog(t *testing.T) {
+	cfg := &Config{LogFormat: "text", Changelog: "keep-a-changelog"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.Changelog != "" {
+		t.Errorf("Changelog = %q, want disabled", cfg.Changelog)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_HistoryEncryption(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.HistoryEncryption = true
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.HistoryEncryption {
+		t.Error("HistoryEncryption = false, want true")
+	}
+}
+
+func TestRoundTrip_LinkMode(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.LinkMode = constants.LinkModeCopy
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.LinkMode != constants.LinkModeCopy {
+		t.Errorf("LinkMode = %q, want %q", loaded.LinkMode, constants.LinkModeCopy)
+	}
+}
+
+func TestConfigNormalize_InvalidLinkMode(t *testing.T) {
+	cfg := &Config{LogFormat: "text", LinkMode: "hardlink"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.LinkMode != constants.LinkModeSymlink {
+		t.Errorf("LinkMode = %q, want %q", cfg.LinkMode, constants.LinkModeSymlink)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_TmuxMode(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.TmuxMode = constants.TmuxModeCooperative
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.TmuxMode != constants.TmuxModeCooperative {
+		t.Errorf("TmuxMode = %q, want %q", loaded.TmuxMode, constants.TmuxModeCooperative)
+	}
+}
+
+func TestRoundTrip_AgentMode(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.AgentMode = constants.AgentModeStreamJSON
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.AgentMode != constants.AgentModeStreamJSON {
+		t.Errorf("AgentMode = %q, want %q", loaded.AgentMode, constants.AgentModeStreamJSON)
+	}
+}
+
+func TestRoundTrip_GitignoreManagement(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.GitignoreManagement = constants.GitignoreNever
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.GitignoreManagement != constants.GitignoreNever {
+		t.Errorf("GitignoreManagement = %q, want %q", loaded.GitignoreManagement, constants.GitignoreNever)
+	}
+}
+
+func TestRoundTrip_PaneCapture(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.PaneCaptureLines = 2000
+	cfg.PaneCaptureFormat = constants.PaneCaptureFormatANSI
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.PaneCaptureLines != 2000 || loaded.PaneCaptureFormat != constants.PaneCaptureFormatANSI {
+		t.Errorf("PaneCaptureLines = %d, PaneCaptureFormat = %q", loaded.PaneCaptureLines, loaded.PaneCaptureFormat)
+	}
+}
+
+func TestPaneCapture(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if lines, ansi := cfg.PaneCapture(nil); lines != constants.PaneCaptureLines || ansi {
+		t.Errorf("PaneCapture(nil) = %d, %v", lines, ansi)
+	}
+	opts := &TaskOptions{PaneCaptureLines: 50000, PaneCaptureFormat: constants.PaneCaptureFormatANSI}
+	if lines, ansi := cfg.PaneCapture(opts); lines != 50000 || !ansi {
+		t.Errorf("PaneCapture(override) = %d, %v", lines, ansi)
+	}
+	opts = &TaskOptions{PaneCaptureFormat: "html"}
+	if lines, ansi := cfg.PaneCapture(opts); lines != constants.PaneCaptureLines || ansi {
+		t.Errorf("PaneCapture(invalid override) = %d, %v", lines, ansi)
+	}
+}
+
+func TestRoundTrip_Clipboard(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.Clipboard = "xclip -selection primary"
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Clipboard != "xclip -selection primary" {
+		t.Errorf("Clipboard = %q, want %q", loaded.Clipboard, "xclip -selection primary")
+	}
+}
+
+func TestRoundTrip_WorkingHours(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.WorkingHours = "08:00-20:00"
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.WorkingHours != "08:00-20:00" {
+		t.Errorf("WorkingHours = %q, want %q", loaded.WorkingHours, "08:00-20:00")
+	}
+}
+
+func TestConfigNormalize_InvalidWorkingHours(t *testing.T) {
+	cfg := &Config{LogFormat: "text", WorkingHours: "mornings"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.WorkingHours != "" {
+		t.Errorf("WorkingHours = %q, want empty", cfg.WorkingHours)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_DailyTokenBudget(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.DailyTokenBudget = 5000000
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.DailyTokenBudget != 5000000 {
+		t.Errorf("DailyTokenBudget = %d, want %d", loaded.DailyTokenBudget, 5000000)
+	}
+}
+
+func TestConfigNormalize_InvalidDailyTokenBudget(t *testing.T) {
+	cfg := &Config{LogFormat: "text", DailyTokenBudget: -1}
+
+	warnings := cfg.Normalize()
+
+	if cfg.DailyTokenBudget != 0 {
+		t.Errorf("DailyTokenBudget = %d, want 0", cfg.DailyTokenBudget)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_FocusFollow(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.FocusFollow = true
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.FocusFollow {
+		t.Error("FocusFollow = false, want true")
+	}
+}
+
+func TestRoundTrip_ExcludeNestedRepos(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	if !cfg.ExcludeNestedRepos {
+		t.Fatal("DefaultConfig().ExcludeNestedRepos = false, want true")
+	}
+	cfg.ExcludeNestedRepos = false
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ExcludeNestedRepos {
+		t.Error("ExcludeNestedRepos = true, want false")
+	}
+}
+
+func TestRoundTrip_LargeFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.LargeFileMB = 100
+	cfg.LargeFileAction = constants.LargeFileLFS
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.LargeFileMB != 100 || loaded.LargeFileAction != constants.LargeFileLFS {
+		t.Errorf("LargeFileMB, LargeFileAction = %d, %q; want 100, %q", loaded.LargeFileMB, loaded.LargeFileAction, constants.LargeFileLFS)
+	}
+}
+
+func TestConfigNormalize_InvalidLargeFile(t *testing.T) {
+	cfg := &Config{LogFormat: "text", LargeFileMB: -5, LargeFileAction: "ignore"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.LargeFileMB != constants.DefaultLargeFileMB {
+		t.Errorf("LargeFileMB = %d, want %d", cfg.LargeFileMB, constants.DefaultLargeFileMB)
+	}
+	if cfg.LargeFileAction != constants.LargeFileSkip {
+		t.Errorf("LargeFileAction = %q, want %q", cfg.LargeFileAction, constants.LargeFileSkip)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("warnings len = %d, want 2", len(warnings))
+	}
+}
+
+func TestRoundTrip_AutoMergeMax(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.AutoMergeMaxFiles = 20
+	cfg.AutoMergeMaxLines = 0
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.AutoMergeMaxFiles != 20 || loaded.AutoMergeMaxLines != 0 {
+		t.Errorf("AutoMergeMaxFiles, AutoMergeMaxLines = %d, %d; want 20, 0", loaded.AutoMergeMaxFiles, loaded.AutoMergeMaxLines)
+	}
+}
+
+func TestConfigNormalize_InvalidAutoMergeMax(t *testing.T) {
+	cfg := &Config{LogFormat: "text", AutoMergeMaxFiles: -1, AutoMergeMaxLines: -1}
+
+	warnings := cfg.Normalize()
+
+	if cfg.AutoMergeMaxFiles != constants.DefaultAutoMergeMaxFiles || cfg.AutoMergeMaxLines != constants.DefaultAutoMergeMaxLines {
+		t.Errorf("AutoMergeMaxFiles, AutoMergeMaxLines = %d, %d", cfg.AutoMergeMaxFiles, cfg.AutoMergeMaxLines)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("warnings len = %d, want 2", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidAgentMode(t *testing.T) {
+	cfg := &Config{LogFormat: "text", AgentMode: "headless"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.AgentMode != constants.AgentModeInteractive {
+		t.Errorf("AgentMode = %q, want %q", cfg.AgentMode, constants.AgentModeInteractive)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidCleanupPolicy(t *testing.T) {
+	cfg := &Config{LogFormat: "text", CleanupPolicy: "Weekly", CleanupRetentionDays: -3}
+
+	warnings := cfg.Normalize()
+
+	if cfg.CleanupPolicy != constants.CleanupImmediate {
+		t.Errorf("CleanupPolicy = %q, want %q", cfg.CleanupPolicy, constants.CleanupImmediate)
+	}
+	if cfg.CleanupRetentionDays != constants.DefaultCleanupRetentionDays {
+		t.Errorf("CleanupRetentionDays = %d, want %d", cfg.CleanupRetentionDays, constants.DefaultCleanupRetentionDays)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidGitignoreManagement(t *testing.T) {
+	cfg := &Config{LogFormat: "text", GitignoreManagement: "always"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.GitignoreManagement != constants.GitignorePrompt {
+		t.Errorf("GitignoreManagement = %q, want %q", cfg.GitignoreManagement, constants.GitignorePrompt)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidPaneCapture(t *testing.T) {
+	cfg := &Config{LogFormat: "text", PaneCaptureLines: -1, PaneCaptureFormat: "html"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.PaneCaptureLines != constants.PaneCaptureLines {
+		t.Errorf("PaneCaptureLines = %d, want %d", cfg.PaneCaptureLines, constants.PaneCaptureLines)
+	}
+	if cfg.PaneCaptureFormat != constants.PaneCaptureFormatPlain {
+		t.Errorf("PaneCaptureFormat = %q, want %q", cfg.PaneCaptureFormat, constants.PaneCaptureFormatPlain)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("warnings len = %d, want 2", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidTmuxMode(t *testing.T) {
+	cfg := &Config{LogFormat: "text", TmuxMode: "shared"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.TmuxMode != constants.TmuxModeDedicated {
+		t.Errorf("TmuxMode = %q, want %q", cfg.TmuxMode, constants.TmuxModeDedicated)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidOnComplete(t *testing.T) {
+	cfg := &Config{LogFormat: "text", OnComplete: "auto"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.OnComplete != constants.OnCompleteConfirm {
+		t.Errorf("OnComplete = %q, want %q", cfg.OnComplete, constants.OnCompleteConfirm)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestParseConfig_OnCompleteBranches(t *testing.T) {
+	cfg := parseConfig(`on_complete: merge-push
+on_complete_branches:
+  "release/*": confirm
+  main: pr
+  hotfix/*: ship-it
+failure_retries: 1
+`)
+	if cfg.FailureRetries != 1 {
+		t.Errorf("FailureRetries = %d, want 1 (block must end at the next key)", cfg.FailureRetries)
+	}
+	warnings := cfg.Normalize()
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one for the invalid action", warnings)
+	}
+
+	for branch, want := range map[string]string{
+		"release/1.0":   constants.OnCompleteConfirm,
+		"main":          constants.ActionPR,
+		"hotfix/urgent": constants.ActionMergePush,
+		"feature/x":     constants.ActionMergePush,
+	} {
+		if got := cfg.OnCompleteFor(branch); got != want {
+			t.Errorf("OnCompleteFor(%q) = %q, want %q", branch, got, want)
+		}
+	}
+}
+
+func TestRoundTrip_OnCompleteBranches(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.OnCompleteBranches = []BranchRule{{Pattern: "release/*", OnComplete: constants.OnCompleteConfirm}}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.OnCompleteBranches) != 1 || loaded.OnCompleteBranches[0] != cfg.OnCompleteBranches[0] {
+		t.Errorf("OnCompleteBranches = %+v, want %+v", loaded.OnCompleteBranches, cfg.OnCompleteBranches)
+	}
+}
+
+func TestParseConfig_PromptVariants(t *testing.T) {
+	cfg := parseConfig(`prompt_variants:
+  control: default
+  concise: "variants/concise.md"
+  bad name: other.md
+  control: variants/dup.md
+  plain:
+failure_retries: 1
+`)
+	if cfg.FailureRetries != 1 {
+		t.Errorf("FailureRetries = %d, want 1 (block must end at the next key)", cfg.FailureRetries)
+	}
+	warnings := cfg.Normalize()
+	if len(warnings) != 2 {
+		t.Errorf("warnings = %v, want ones for the invalid and duplicate names", warnings)
+	}
+
+	want := []PromptVariant{
+		{Name: "control", Prompt: DefaultPromptVariant},
+		{Name: "concise", Prompt: "variants/concise.md"},
+		{Name: "plain", Prompt: DefaultPromptVariant},
+	}
+	if len(cfg.PromptVariants) != len(want) {
+		t.Fatalf("PromptVariants = %+v, want %+v", cfg.PromptVariants, want)
+	}
+	for i := range want {
+		if cfg.PromptVariants[i] != want[i] {
+			t.Errorf("PromptVariants[%d] = %+v, want %+v", i, cfg.PromptVariants[i], want[i])
+		}
+	}
+	if v, ok := cfg.FindPromptVariant("concise"); !ok || v.Prompt != "variants/concise.md" {
+		t.Errorf("FindPromptVariant(concise) = %+v, %v", v, ok)
+	}
+	if _, ok := cfg.FindPromptVariant("missing"); ok {
+		t.Error("FindPromptVariant(missing) found a variant")
+	}
+}
+
+func TestRoundTrip_PromptVariants(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.PromptVariants = []PromptVariant{{Name: "control", Prompt: DefaultPromptVariant}, {Name: "concise", Prompt: "variants/concise.md"}}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.PromptVariants) != 2 || loaded.PromptVariants[1] != cfg.PromptVariants[1] {
+		t.Errorf("PromptVariants = %+v, want %+v", loaded.PromptVariants, cfg.PromptVariants)
+	}
+}
+
+func TestRoundTrip_ConfirmTimeout(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.ConfirmTimeoutHours = 8
+	cfg.ConfirmTimeoutAction = constants.ActionPR
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ConfirmTimeoutHours != 8 || loaded.ConfirmTimeoutAction != constants.ActionPR {
+		t.Errorf("ConfirmTimeoutHours = %d, ConfirmTimeoutAction = %q", loaded.ConfirmTimeoutHours, loaded.ConfirmTimeoutAction)
+	}
+}
+
+func TestConfigNormalize_InvalidConfirmTimeout(t *testing.T) {
+	cfg := &Config{LogFormat: "text", ConfirmTimeoutHours: -1, ConfirmTimeoutAction: constants.OnCompleteConfirm}
+
+	warnings := cfg.Normalize()
+
+	if cfg.ConfirmTimeoutHours != 0 || cfg.ConfirmTimeoutAction != constants.ActionMerge {
+		t.Errorf("ConfirmTimeoutHours = %d, ConfirmTimeoutAction = %q", cfg.ConfirmTimeoutHours, cfg.ConfirmTimeoutAction)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("warnings = %v, want 2", warnings)
+	}
+}
+
+func TestRoundTrip_MinFreeDiskMB(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.MinFreeDiskMB = 2048
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.MinFreeDiskMB != 2048 {
+		t.Errorf("MinFreeDiskMB = %d, want 2048", loaded.MinFreeDiskMB)
+	}
+}
+
+func TestConfigNormalize_NegativeMinFreeDiskMB(t *testing.T) {
+	cfg := &Config{LogFormat: "text", MinFreeDiskMB: -1}
+
+	warnings := cfg.Normalize()
+
+	if cfg.MinFreeDiskMB != constants.DefaultMinFreeDiskMB {
+		t.Errorf("MinFreeDiskMB = %d, want %d", cfg.MinFreeDiskMB, constants.DefaultMinFreeDiskMB)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_ContextFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.ContextFiles = []string{"ARCHITECTURE.md", "docs/CONTRIBUTING.md"}
+	cfg.ContextMaxKB = 16
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if strings.Join(loaded.ContextFiles, "|") != "ARCHITECTURE.md|docs/CONTRIBUTING.md" {
+		t.Errorf("ContextFiles = %q", loaded.ContextFiles)
+	}
+	if loaded.ContextMaxKB != 16 {
+		t.Errorf("ContextMaxKB = %d, want 16", loaded.ContextMaxKB)
+	}
+}
+
+func TestRoundTrip_ContextMaxKBUnlimited(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.ContextMaxKB = 0
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ContextMaxKB != 0 {
+		t.Errorf("ContextMaxKB = %d, want 0 (no limit)", loaded.ContextMaxKB)
+	}
+}
+
+func TestParseConfig_Commands(t *testing.T) {
+	cfg := parseConfig(`commands:
+  build: go build ./...
+  # lint is optional
+  test: go test -race ./...
+  deploy: ./deploy.sh
+log_format: jsonl
+`)
+
+	want := Commands{Build: "go build ./...", Test: "go test -race ./..."}
+	if cfg.Commands != want {
+		t.Errorf("Commands = %+v, want %+v", cfg.Commands, want)
+	}
+	if cfg.LogFormat != "jsonl" {
+		t.Errorf("LogFormat = %q, want jsonl (parsing must resume after the block)", cfg.LogFormat)
+	}
+}
+
+func TestRoundTrip_Commands(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.Commands = Commands{Build: "make build", Test: "make test", Lint: "make lint", Run: "make run", Coverage: "make cover | tail -1"}
+	cfg.VerifyBeforePush = false
+	cfg.KeepBranch = true
+	cfg.CleanupPolicy = constants.CleanupDelayed
+	cfg.CleanupRetentionDays = 14
+	cfg.GitNetworkRetries = 0
+	cfg.SelfEval = true
+	cfg.SelfEvalMinScore = 4
+	cfg.ReviewerModel = "claude-sonnet-4-5"
+	cfg.EditorEndpoint = true
+	cfg.FileHistory = false
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Commands != cfg.Commands {
+		t.Errorf("Commands = %+v, want %+v", loaded.Commands, cfg.Commands)
+	}
+	if loaded.VerifyBeforePush {
+		t.Error("VerifyBeforePush = true, want false")
+	}
+	if !loaded.KeepBranch {
+		t.Error("KeepBranch = false, want true")
+	}
+	if loaded.CleanupPolicy != constants.CleanupDelayed || loaded.CleanupRetentionDays != 14 {
+		t.Errorf("CleanupPolicy, CleanupRetentionDays = %q, %d; want delayed, 14", loaded.CleanupPolicy, loaded.CleanupRetentionDays)
+	}
+	if loaded.GitNetworkRetries != 0 {
+		t.Errorf("GitNetworkRetries = %d, want 0 (disabled)", loaded.GitNetworkRetries)
+	}
+	if !loaded.SelfEval || loaded.SelfEvalMinScore != 4 {
+		t.Errorf("SelfEval, SelfEvalMinScore = %v, %d; want true, 4", loaded.SelfEval, loaded.SelfEvalMinScore)
+	}
+	if loaded.ReviewerModel != "claude-sonnet-4-5" {
+		t.Errorf("ReviewerModel = %q, want claude-sonnet-4-5", loaded.ReviewerModel)
+	}
+	if !loaded.EditorEndpoint {
+		t.Error("EditorEndpoint = false, want true")
+	}
+	if loaded.FileHistory {
+		t.Error("FileHistory = true, want false")
+	}
+}
+
+func TestRoundTrip_Notifications(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.Notifications = Notifications{SlackToken: "$PAW_SLACK_TOKEN", SlackChannel: "#paw", NtfyTopic: "paw-alerts"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Notifications != cfg.Notifications {
+		t.Errorf("Notifications = %+v, want %+v", loaded.Notifications, cfg.Notifications)
+	}
+	if !loaded.Notifications.Slack() || !loaded.Notifications.Ntfy() {
+		t.Errorf("Slack() = %v, Ntfy() = %v, want both configured", loaded.Notifications.Slack(), loaded.Notifications.Ntfy())
+	}
+}
+
+func TestRoundTrip_StatusEmojis(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.StatusEmojis = StatusEmojis{Working: "🔨", Done: "🎉"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.StatusEmojis != cfg.StatusEmojis {
+		t.Errorf("StatusEmojis = %+v, want %+v", loaded.StatusEmojis, cfg.StatusEmojis)
+	}
+}
+
+func TestRoundTrip_TaskLayout(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.TaskLayout = TaskLayout{Split: TaskSplitVertical, UserPaneSize: 30, NoUserPane: true, ExtraPane: "npm run test:watch"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.TaskLayout != cfg.TaskLayout {
+		t.Errorf("TaskLayout = %+v, want %+v", loaded.TaskLayout, cfg.TaskLayout)
+	}
+	if loaded.TaskLayout.Horizontal() || loaded.TaskLayout.PaneCount() != 2 {
+		t.Errorf("Horizontal() = %v, PaneCount() = %d, want false, 2", loaded.TaskLayout.Horizontal(), loaded.TaskLayout.PaneCount())
+	}
+}
+
+func TestCommandsVerifyEntries(t *testing.T) {
+	cmds := Commands{Test: "go test ./...", Run: "go run .", Build: "go build ./...", Coverage: "make cover"}
+
+	var names []string
+	for _, e := range cmds.VerifyEntries() {
+		names = append(names, e.Name)
+	}
+	if got := strings.Join(names, ","); got != "build,test" {
+		t.Errorf("VerifyEntries() = %q, want build,test", got)
+	}
+	if got := len(cmds.Entries()); got != 4 {
+		t.Errorf("len(Entries()) = %d, want 4", got)
+	}
+}
+
+func TestConfigNormalize_NegativeContextMaxKB(t *testing.T) {
+	cfg := &Config{LogFormat: "text", ContextMaxKB: -1}
+
+	warnings := cfg.Normalize()
+
+	if cfg.ContextMaxKB != constants.DefaultContextMaxKB {
+		t.Errorf("ContextMaxKB = %d, want %d", cfg.ContextMaxKB, constants.DefaultContextMaxKB)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_NegativeFailureRetries(t *testing.T) {
+	cfg := &Config{LogFormat: "text", FailureRetries: -1}
+
+	warnings := cfg.Normalize()
+
+	if cfg.FailureRetries != 0 {
+		t.Errorf("FailureRetries = %d, want 0", cfg.FailureRetries)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_NegativeGitNetworkRetries(t *testing.T) {
+	cfg := &Config{LogFormat: "text", GitNetworkRetries: -2}
+
+	warnings := cfg.Normalize()
+
+	if cfg.GitNetworkRetries != constants.DefaultGitNetworkRetries {
+		t.Errorf("GitNetworkRetries = %d, want %d", cfg.GitNetworkRetries, constants.DefaultGitNetworkRetries)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidSelfEvalMinScore(t *testing.T) {
+	cfg := &Config{LogFormat: "text", SelfEvalMinScore: 6}
+
+	warnings := cfg.Normalize()
+
+	if cfg.SelfEvalMinScore != constants.DefaultSelfEvalMinScore {
+		t.Errorf("SelfEvalMinScore = %d, want %d", cfg.SelfEvalMinScore, constants.DefaultSelfEvalMinScore)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigNormalize_InvalidReviewerModel(t *testing.T) {
+	cfg := &Config{LogFormat: "text", ReviewerModel: "gpt 4"}
+
+	warnings := cfg.Normalize()
+
+	if cfg.ReviewerModel != "" {
+		t.Errorf("ReviewerModel = %q, want empty (reviewer disabled)", cfg.ReviewerModel)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+
+	cfg = &Config{LogFormat: "text", ReviewerModel: "opus"}
+	if warnings := cfg.Normalize(); len(warnings) != 0 || cfg.ReviewerModel != "opus" {
+		t.Errorf("ReviewerModel = %q, warnings = %v; want opus, none", cfg.ReviewerModel, warnings)
+	}
+}
+
+func TestConfigNormalize_StatusEmojiClash(t *testing.T) {
+	cfg := &Config{LogFormat: "text", StatusEmojis: StatusEmojis{Working: "'🔨'", Done: constants.EmojiWaiting}}
+
+	warnings := cfg.Normalize()
+
+	if cfg.StatusEmojis.Working != "🔨" {
+		t.Errorf("Working = %q, want 🔨", cfg.StatusEmojis.Working)
+	}
+	if cfg.StatusEmojis.Done != "" {
+		t.Errorf("Done = %q, want reset to default", cfg.StatusEmojis.Done)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want 1", warnings)
+	}
+}
+
+func TestConfigNormalize_InvalidTaskLayout(t *testing.T) {
+	cfg := &Config{LogFormat: "text", TaskLayout: TaskLayout{Split: "Diagonal", UserPaneSize: 95}}
+
+	warnings := cfg.Normalize()
+
+	if cfg.TaskLayout != (TaskLayout{}) {
+		t.Errorf("TaskLayout = %+v, want defaults", cfg.TaskLayout)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("warnings = %v, want 2", warnings)
+	}
+}
+
 func TestLoad_NoConfigFile(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -445,3 +1423,108 @@ func TestEnsureConfigInDir_CreatesDirectoryIfMissing(t *testing.T) {
 		t.Fatal("Config file should exist after ensureConfigInDir")
 	}
 }
+
+func TestGetWorkspaceDir_XDGLocation(t *testing.T) {
+	tempDir := t.TempDir()
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+
+	result := GetWorkspaceDir(tempDir, PawInProjectXDG, true)
+	expected := filepath.Join(stateHome, "paw", ProjectWorkspaceID(tempDir))
+	if result != expected {
+		t.Errorf("GetWorkspaceDir() = %q, want %q", result, expected)
+	}
+
+	// Relative XDG_STATE_HOME is ignored per the XDG spec
+	t.Setenv("XDG_STATE_HOME", "relative/state")
+	t.Setenv("HOME", stateHome)
+	if dir := XDGStateWorkspacesDir(); dir != filepath.Join(stateHome, constants.GlobalStateDir) {
+		t.Errorf("XDGStateWorkspacesDir() = %q, want default under HOME", dir)
+	}
+}
+
+func TestGetWorkspaceDir_AutoFollowsGlobalLocation(t *testing.T) {
+	home := t.TempDir()
+	stateHome := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	projectDir := t.TempDir()
+
+	globalDir := filepath.Join(home, constants.GlobalConfigDir)
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(globalDir, constants.ConfigFileName), []byte("workspace_location: xdg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, isGitRepo := range []bool{true, false} {
+		result := GetWorkspaceDir(projectDir, PawInProjectAuto, isGitRepo)
+		if !strings.HasPrefix(result, filepath.Join(stateHome, "paw")) {
+			t.Errorf("GetWorkspaceDir(auto, git=%v) = %q, expected XDG state workspace", isGitRepo, result)
+		}
+	}
+
+	// Explicit locations still win over the global setting
+	if result := GetWorkspaceDir(projectDir, PawInProjectLocal, true); result != filepath.Join(projectDir, constants.PawDirName) {
+		t.Errorf("GetWorkspaceDir(local) = %q, want local .paw", result)
+	}
+}
+
+func TestFindExistingWorkspace(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", "")
+	projectDir := t.TempDir()
+	target := GetWorkspaceDir(projectDir, PawInProjectXDG, true)
+
+	// A committed .paw/config alone is not a workspace
+	localPawDir := filepath.Join(projectDir, constants.PawDirName)
+	if err := os.MkdirAll(localPawDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if found := FindExistingWorkspace(projectDir, true, target); found != "" {
+		t.Errorf("FindExistingWorkspace() = %q, want \"\"", found)
+	}
+
+	globalWorkspace := GetWorkspaceDir(projectDir, PawInProjectGlobal, true)
+	if err := os.MkdirAll(filepath.Join(globalWorkspace, constants.AgentsDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if found := FindExistingWorkspace(projectDir, true, target); found != globalWorkspace {
+		t.Errorf("FindExistingWorkspace() = %q, want %q", found, globalWorkspace)
+	}
+	if found := FindExistingWorkspace(projectDir, true, globalWorkspace); found != "" {
+		t.Errorf("FindExistingWorkspace(target=global) = %q, want \"\"", found)
+	}
+}
+
+func TestParseConfig_WorkspaceLocation(t *testing.T) {
+	cfg := parseConfig("workspace_location: xdg\n")
+	if cfg.WorkspaceLocation != "xdg" {
+		t.Errorf("WorkspaceLocation = %q, want %q", cfg.WorkspaceLocation, "xdg")
+	}
+
+	cfg = parseConfig("workspace_location: elsewhere\n")
+	warnings := cfg.Normalize()
+	if cfg.WorkspaceLocation != "" || len(warnings) != 1 {
+		t.Errorf("Normalize() kept invalid workspace_location %q (warnings: %v)", cfg.WorkspaceLocation, warnings)
+	}
+}
+
+func TestRoundTrip_PasteTemplate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.PasteTemplate = "Investigate this error:\n\n{buffer}\n\nAdd a regression test."
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.PasteTemplate != cfg.PasteTemplate {
+		t.Errorf("PasteTemplate = %q, want %q", loaded.PasteTemplate, cfg.PasteTemplate)
+	}
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// Policy holds organization-level constraints read from a system-wide file
+// (DefaultPolicyPath, or the path in $PAW_POLICY). Project configs cannot
+// override them: the policy is applied after the config is loaded.
+type Policy struct {
+	Path string
+
+	// DenyAutoMerge turns merges that run without the user (on_complete and
+	// confirm_timeout_action merge/merge-push) into confirmations.
+	DenyAutoMerge bool
+
+	// RequireVerifyCommand forces verify_before_push on. Projects without a
+	// build, lint, or test command cannot merge automatically.
+	RequireVerifyCommand bool
+
+	// ForbidSkipPermissions forces skip_permissions off, so Claude asks
+	// before running tools.
+	ForbidSkipPermissions bool
+}
+
+// PolicyPath returns the organization policy file path.
+func PolicyPath() string {
+	if path := os.Getenv(constants.PolicyFileEnv); path != "" {
+		return path
+	}
+	return constants.DefaultPolicyPath
+}
+
+// LoadPolicy reads the organization policy file. It returns nil without an
+// error when the default file does not exist; a path set in $PAW_POLICY must
+// exist.
+func LoadPolicy() (*Policy, error) {
+	path := PolicyPath()
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the admin-controlled policy file
+	if err != nil {
+		if os.IsNotExist(err) && os.Getenv(constants.PolicyFileEnv) == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+	p, err := parsePolicy(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	p.Path = path
+	return p, nil
+}
+
+// parsePolicy parses the TOML subset policy files use: "key = true|false"
+// lines, comments, and an optional [policy] table header.
+func parsePolicy(content string) (*Policy, error) {
+	p := &Policy{}
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || line == "[policy]" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		key = strings.TrimSpace(key)
+		if before, _, found := strings.Cut(value, "#"); found {
+			value = before
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s must be true or false", i+1, key)
+		}
+		switch key {
+		case "deny_auto_merge":
+			p.DenyAutoMerge = enabled
+		case "require_verify_command":
+			p.RequireVerifyCommand = enabled
+		case "forbid_skip_permissions":
+			p.ForbidSkipPermissions = enabled
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", i+1, key)
+		}
+	}
+	return p, nil
+}
+
+// Apply enforces the policy on c and returns a note for each setting it
+// changed. A nil policy changes nothing.
+func (p *Policy) Apply(c *Config) []string {
+	if p == nil {
+		return nil
+	}
+	var notes []string
+	if p.ForbidSkipPermissions && c.SkipPermissions {
+		c.SkipPermissions = false
+		notes = append(notes, "skip_permissions is forbidden by policy; Claude will ask before running tools")
+	}
+	if p.RequireVerifyCommand && !c.VerifyBeforePush {
+		c.VerifyBeforePush = true
+		notes = append(notes, "verify_before_push is required by policy")
+	}
+	if p.RequireVerifyCommand && len(c.Commands.VerifyEntries()) == 0 {
+		notes = append(notes, "policy requires a verify command but no build, lint, or test command is set; tasks will not merge automatically")
+	}
+
+	if !p.AllowsAutoMerge(c) {
+		if IsMergeAction(c.OnComplete) {
+			notes = append(notes, fmt.Sprintf("on_complete %q is denied by policy; using %q", c.OnComplete, constants.OnCompleteConfirm))
+			c.OnComplete = constants.OnCompleteConfirm
+		}
+		if c.ConfirmTimeoutHours > 0 && IsMergeAction(c.ConfirmTimeoutAction) {
+			notes = append(notes, fmt.Sprintf("confirm_timeout_action %q is denied by policy; tasks wait for the user", c.ConfirmTimeoutAction))
+			c.ConfirmTimeoutHours = 0
+		}
+		for i, rule := range c.OnCompleteBranches {
+			if IsMergeAction(rule.OnComplete) {
+				c.OnCompleteBranches[i].OnComplete = constants.OnCompleteConfirm
+			}
+		}
+	}
+	return notes
+}
+
+// AllowsAutoMerge reports whether tasks of c may merge without the user.
+func (p *Policy) AllowsAutoMerge(c *Config) bool {
+	if p == nil {
+		return true
+	}
+	if p.DenyAutoMerge {
+		return false
+	}
+	return !p.RequireVerifyCommand || len(c.Commands.VerifyEntries()) > 0
+}
+
+// OnComplete returns action, or confirm if the policy denies it as an
+// automatic merge (for per-task overrides that bypass the config).
+func (p *Policy) OnComplete(c *Config, action string) string {
+	if IsMergeAction(action) && !p.AllowsAutoMerge(c) {
+		return constants.OnCompleteConfirm
+	}
+	return action
+}
+
+// Rules describes the enforced constraints, for display.
+func (p *Policy) Rules() []string {
+	if p == nil {
+		return nil
+	}
+	var rules []string
+	if p.DenyAutoMerge {
+		rules = append(rules, "deny_auto_merge")
+	}
+	if p.RequireVerifyCommand {
+		rules = append(rules, "require_verify_command")
+	}
+	if p.ForbidSkipPermissions {
+		rules = append(rules, "forbid_skip_permissions")
+	}
+	return rules
+}
+
+// IsMergeAction reports whether an end-task action merges the task.
+func IsMergeAction(action string) bool {
+	return action == constants.ActionMerge || action == constants.ActionMergePush
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := parsePolicy(`# Company policy
+[policy]
+deny_auto_merge = true
+require_verify_command = false  # projects without tests are fine
+forbid_skip_permissions = true
+`)
+	if err != nil {
+		t.Fatalf("parsePolicy() error = %v", err)
+	}
+	if !p.DenyAutoMerge || p.RequireVerifyCommand || !p.ForbidSkipPermissions {
+		t.Errorf("policy = %+v", p)
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"deny_auto_merge = yes please",
+		"allow_everything = true",
+		"deny_auto_merge",
+	} {
+		if _, err := parsePolicy(content); err == nil {
+			t.Errorf("parsePolicy(%q) error = nil, want error", content)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.toml")
+	t.Setenv(constants.PolicyFileEnv, path)
+
+	if _, err := LoadPolicy(); err == nil {
+		t.Error("LoadPolicy() with a missing $PAW_POLICY file error = nil, want error")
+	}
+
+	if err := os.WriteFile(path, []byte("deny_auto_merge = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy()
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if !p.DenyAutoMerge || p.Path != path {
+		t.Errorf("policy = %+v", p)
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.OnComplete = constants.ActionMergePush
+	cfg.ConfirmTimeoutHours = 4
+	cfg.SkipPermissions = true
+	cfg.VerifyBeforePush = false
+	cfg.OnCompleteBranches = []BranchRule{{Pattern: "release/*", OnComplete: constants.ActionMerge}}
+
+	p := &Policy{DenyAutoMerge: true, RequireVerifyCommand: true, ForbidSkipPermissions: true}
+	notes := p.Apply(cfg)
+
+	if cfg.OnComplete != constants.OnCompleteConfirm {
+		t.Errorf("OnComplete = %q, want %q", cfg.OnComplete, constants.OnCompleteConfirm)
+	}
+	if cfg.ConfirmTimeoutHours != 0 {
+		t.Errorf("ConfirmTimeoutHours = %d, want 0", cfg.ConfirmTimeoutHours)
+	}
+	if cfg.SkipPermissions || !cfg.VerifyBeforePush {
+		t.Errorf("SkipPermissions = %v, VerifyBeforePush = %v", cfg.SkipPermissions, cfg.VerifyBeforePush)
+	}
+	if got := cfg.OnCompleteFor("release/1.0"); got != constants.OnCompleteConfirm {
+		t.Errorf("OnCompleteFor(release/1.0) = %q, want %q", got, constants.OnCompleteConfirm)
+	}
+	if len(notes) == 0 {
+		t.Error("Apply() returned no notes")
+	}
+}
+
+func TestPolicyOnComplete(t *testing.T) {
+	withTests := DefaultConfig()
+	withTests.Commands.Test = "go test ./..."
+	noCommands := DefaultConfig()
+
+	tests := []struct {
+		name   string
+		policy *Policy
+		cfg    *Config
+		action string
+		want   string
+	}{
+		{"no policy", nil, noCommands, constants.ActionMerge, constants.ActionMerge},
+		{"deny merge", &Policy{DenyAutoMerge: true}, withTests, constants.ActionMergePush, constants.OnCompleteConfirm},
+		{"deny keeps pr", &Policy{DenyAutoMerge: true}, withTests, constants.ActionPR, constants.ActionPR},
+		{"verify with tests", &Policy{RequireVerifyCommand: true}, withTests, constants.ActionMerge, constants.ActionMerge},
+		{"verify without commands", &Policy{RequireVerifyCommand: true}, noCommands, constants.ActionMerge, constants.OnCompleteConfirm},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.OnComplete(tt.cfg, tt.action); got != tt.want {
+			t.Errorf("%s: OnComplete(%q) = %q, want %q", tt.name, tt.action, got, tt.want)
+		}
+	}
+}
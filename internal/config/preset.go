@@ -0,0 +1,72 @@
+package config
+
+import "github.com/dongho-jung/paw/internal/constants"
+
+// Preset is a named combination of completion, verification, and permission
+// settings. Applying a preset keeps everything else (commands, notification
+// channels, hooks) as configured.
+type Preset struct {
+	Name        string
+	Description string
+	apply       func(c *Config)
+}
+
+// Apply sets the preset's values on c.
+func (p Preset) Apply(c *Config) {
+	p.apply(c)
+}
+
+// destructiveCommands need approval in the safer presets.
+var destructiveCommands = []string{"rm -rf", "git push --force", "git reset --hard"}
+
+var presets = []Preset{
+	{
+		Name:        "solo-yolo",
+		Description: "Merge & push as soon as the agent is done; no verification or approvals",
+		apply: func(c *Config) {
+			c.OnComplete = constants.ActionMergePush
+			c.VerifyBeforePush = false
+			c.SkipPermissions = true
+			c.ApprovalCommands = nil
+			c.FailureRetries = 2
+		},
+	},
+	{
+		Name:        "team-safe",
+		Description: "Review every task, verify before pushing, approve destructive commands",
+		apply: func(c *Config) {
+			c.OnComplete = constants.OnCompleteConfirm
+			c.VerifyBeforePush = true
+			c.SkipPermissions = true
+			c.ApprovalCommands = append([]string(nil), destructiveCommands...)
+			c.FailureRetries = 1
+		},
+	},
+	{
+		Name:        "ci-strict",
+		Description: "Verify, then open a PR automatically; Claude asks before running tools",
+		apply: func(c *Config) {
+			c.OnComplete = constants.ActionPR
+			c.VerifyBeforePush = true
+			c.SkipPermissions = false
+			c.ApprovalCommands = append([]string(nil), destructiveCommands...)
+			c.FailureRetries = 0
+		},
+	},
+}
+
+// Presets returns the built-in presets.
+// The returned slice should not be modified.
+func Presets() []Preset {
+	return presets
+}
+
+// FindPreset returns the preset with the given name.
+func FindPreset(name string) (Preset, bool) {
+	for _, p := range presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestRoundTrip_Presets(t *testing.T) {
+	for _, p := range Presets() {
+		t.Run(p.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := DefaultConfig()
+			cfg.Commands.Test = "go test ./..."
+			cfg.Notifications.NtfyTopic = "paw-alerts"
+			p.Apply(cfg)
+			if err := cfg.Save(dir); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			loaded, err := Load(dir)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if loaded.OnComplete != cfg.OnComplete || loaded.VerifyBeforePush != cfg.VerifyBeforePush ||
+				loaded.SkipPermissions != cfg.SkipPermissions || loaded.FailureRetries != cfg.FailureRetries ||
+				len(loaded.ApprovalCommands) != len(cfg.ApprovalCommands) {
+				t.Errorf("loaded %+v, want %+v", loaded, cfg)
+			}
+			if warnings := loaded.Normalize(); len(warnings) > 0 {
+				t.Errorf("Normalize() warnings = %v", warnings)
+			}
+			if loaded.Commands.Test != "go test ./..." || loaded.Notifications.NtfyTopic != "paw-alerts" {
+				t.Error("preset changed settings outside its scope")
+			}
+		})
+	}
+}
+
+func TestFindPreset(t *testing.T) {
+	p, ok := FindPreset("ci-strict")
+	if !ok {
+		t.Fatal("FindPreset(ci-strict) not found")
+	}
+	cfg := DefaultConfig()
+	p.Apply(cfg)
+	if cfg.OnComplete != constants.ActionPR || cfg.SkipPermissions {
+		t.Errorf("ci-strict: OnComplete = %q, SkipPermissions = %v", cfg.OnComplete, cfg.SkipPermissions)
+	}
+
+	if _, ok := FindPreset("yolo"); ok {
+		t.Error("FindPreset(yolo) found an unknown preset")
+	}
+}
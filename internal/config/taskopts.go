@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"unicode"
 
 	"github.com/dongho-jung/paw/internal/fileutil"
 )
@@ -62,6 +64,65 @@ type TaskOptions struct {
 
 	// BranchName specifies a custom branch name (default: auto-generated from task content)
 	BranchName string `json:"branch_name,omitempty"`
+
+	// Research marks a read-only task: no worktree/branch, no file edits,
+	// and the answer is saved to history instead of being committed
+	Research bool `json:"research,omitempty"`
+
+	// Pair marks a pair task: the agent cannot edit files and proposes
+	// patches instead, which are applied once the user approves them
+	Pair bool `json:"pair,omitempty"`
+
+	// Autopilot is the ID of the autopilot run that started the task; its
+	// verify commands run before it merges, whatever verify_before_push says
+	Autopilot string `json:"autopilot,omitempty"`
+
+	// ContextFiles lists extra files (relative to the project) attached to
+	// this task's system prompt, in addition to the project's context_files
+	ContextFiles []string `json:"context_files,omitempty"`
+
+	// OnComplete overrides the project's on_complete for this task; set when
+	// an on_complete_branches rule matches the task's base branch
+	OnComplete string `json:"on_complete,omitempty"`
+
+	// Labels tag the task (e.g. bug, feature, chore); shown as chips in the
+	// Kanban and usable as a filter there
+	Labels []string `json:"labels,omitempty"`
+
+	// PaneCaptureLines overrides the project's pane_capture_lines, e.g. for
+	// very chatty agents
+	PaneCaptureLines int `json:"pane_capture_lines,omitempty"`
+
+	// PaneCaptureFormat overrides the project's pane_capture_format (plain, ansi)
+	PaneCaptureFormat string `json:"pane_capture_format,omitempty"`
+
+	// Parent stacks this task on another active task: its worktree branches
+	// off the parent's branch and is rebased onto the main branch once the
+	// parent merges
+	Parent string `json:"parent,omitempty"`
+
+	// FollowUp links this task to the earlier task it follows up on (its
+	// input references that task's summary and branch)
+	FollowUp string `json:"follow_up,omitempty"`
+
+	// PromptVariant assigns the task to a prompt_variants entry; a variant
+	// is picked at random on the task's first start when empty
+	PromptVariant string `json:"prompt_variant,omitempty"`
+}
+
+// ParseLabels splits a comma- or space-separated label list, lowercasing
+// labels and dropping duplicates.
+func ParseLabels(s string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, label := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		label = strings.ToLower(label)
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
 }
 
 // DefaultTaskOptions returns the default task options.
@@ -132,14 +193,70 @@ func (o *TaskOptions) Merge(other *TaskOptions) {
 	if other.BranchName != "" {
 		o.BranchName = other.BranchName
 	}
+
+	if other.Research {
+		o.Research = true
+	}
+
+	if other.Pair {
+		o.Pair = true
+	}
+
+	if other.Autopilot != "" {
+		o.Autopilot = other.Autopilot
+	}
+
+	if len(other.ContextFiles) > 0 {
+		o.ContextFiles = append([]string(nil), other.ContextFiles...)
+	}
+
+	if other.OnComplete != "" {
+		o.OnComplete = other.OnComplete
+	}
+
+	if len(other.Labels) > 0 {
+		o.Labels = append([]string(nil), other.Labels...)
+	}
+
+	if other.PaneCaptureLines > 0 {
+		o.PaneCaptureLines = other.PaneCaptureLines
+	}
+
+	if other.PaneCaptureFormat != "" {
+		o.PaneCaptureFormat = other.PaneCaptureFormat
+	}
+
+	if other.Parent != "" {
+		o.Parent = other.Parent
+	}
+
+	if other.FollowUp != "" {
+		o.FollowUp = other.FollowUp
+	}
 }
 
 // Clone creates a deep copy of the task options.
 func (o *TaskOptions) Clone() *TaskOptions {
 	clone := &TaskOptions{
-		Model:           o.Model,
-		PreWorktreeHook: o.PreWorktreeHook,
-		BranchName:      o.BranchName,
+		Model:             o.Model,
+		PreWorktreeHook:   o.PreWorktreeHook,
+		BranchName:        o.BranchName,
+		Research:          o.Research,
+		Pair:              o.Pair,
+		Autopilot:         o.Autopilot,
+		OnComplete:        o.OnComplete,
+		PaneCaptureLines:  o.PaneCaptureLines,
+		PaneCaptureFormat: o.PaneCaptureFormat,
+		Parent:            o.Parent,
+		FollowUp:          o.FollowUp,
+	}
+
+	if o.ContextFiles != nil {
+		clone.ContextFiles = append([]string(nil), o.ContextFiles...)
+	}
+
+	if o.Labels != nil {
+		clone.Labels = append([]string(nil), o.Labels...)
 	}
 
 	if o.DependsOn != nil {
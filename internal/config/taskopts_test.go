@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -150,6 +151,102 @@ func TestTaskOptionsMerge(t *testing.T) {
 	}
 }
 
+func TestTaskOptionsMergeResearch(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{Research: true})
+	if !base.Research {
+		t.Error("Expected research to be set after merge")
+	}
+
+	if clone := base.Clone(); !clone.Research {
+		t.Error("Expected clone to keep research flag")
+	}
+}
+
+func TestTaskOptionsMergePair(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{Pair: true})
+	if !base.Pair {
+		t.Error("Expected pair to be set after merge")
+	}
+
+	if clone := base.Clone(); !clone.Pair {
+		t.Error("Expected clone to keep pair flag")
+	}
+}
+
+func TestTaskOptionsMergeAutopilot(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{Autopilot: "run-1"})
+	if base.Autopilot != "run-1" {
+		t.Errorf("Autopilot after merge = %q, want run-1", base.Autopilot)
+	}
+
+	if clone := base.Clone(); clone.Autopilot != "run-1" {
+		t.Errorf("clone Autopilot = %q, want run-1", clone.Autopilot)
+	}
+}
+
+func TestTaskOptionsMergeContextFiles(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{ContextFiles: []string{"docs/api.md"}})
+	if len(base.ContextFiles) != 1 || base.ContextFiles[0] != "docs/api.md" {
+		t.Errorf("ContextFiles after merge = %q", base.ContextFiles)
+	}
+
+	clone := base.Clone()
+	clone.ContextFiles[0] = "modified.md"
+	if base.ContextFiles[0] != "docs/api.md" {
+		t.Error("Clone ContextFiles should be a separate slice")
+	}
+}
+
+func TestTaskOptionsMergeParent(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{Parent: "add-auth"})
+	if base.Parent != "add-auth" {
+		t.Errorf("Parent after merge = %q, want add-auth", base.Parent)
+	}
+
+	base.Merge(&TaskOptions{Model: ModelHaiku})
+	if base.Parent != "add-auth" {
+		t.Errorf("Parent after merging options without one = %q, want add-auth", base.Parent)
+	}
+	if clone := base.Clone(); clone.Parent != "add-auth" {
+		t.Errorf("Clone().Parent = %q, want add-auth", clone.Parent)
+	}
+}
+
+func TestTaskOptionsMergeFollowUp(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{FollowUp: "fix-login"})
+	base.Merge(&TaskOptions{Model: ModelHaiku})
+	if base.FollowUp != "fix-login" {
+		t.Errorf("FollowUp after merge = %q, want fix-login", base.FollowUp)
+	}
+	if clone := base.Clone(); clone.FollowUp != "fix-login" {
+		t.Errorf("Clone().FollowUp = %q, want fix-login", clone.FollowUp)
+	}
+}
+
+func TestTaskOptionsLabels(t *testing.T) {
+	labels := ParseLabels("Bug, feature  chore,bug")
+	if strings.Join(labels, ",") != "bug,feature,chore" {
+		t.Errorf("ParseLabels() = %q, want [bug feature chore]", labels)
+	}
+	if ParseLabels(" , ") != nil {
+		t.Error("ParseLabels() of separators only should be nil")
+	}
+
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{Labels: labels})
+	clone := base.Clone()
+	clone.Labels[0] = "modified"
+	if base.Labels[0] != "bug" {
+		t.Error("Clone Labels should be a separate slice")
+	}
+}
+
 func TestTaskOptionsMergeNil(t *testing.T) {
 	base := DefaultTaskOptions()
 	originalModel := base.Model
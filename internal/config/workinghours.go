@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// workingHoursLayouts are the accepted clock formats for working_hours.
+var workingHoursLayouts = []string{"15:04", "3:04pm", "3pm", "15"}
+
+// WorkingHours is a daily local-time window in which agents may run. A window
+// whose end is before its start wraps past midnight (e.g. 22:00-06:00).
+type WorkingHours struct {
+	Start time.Duration // Offset from midnight
+	End   time.Duration
+}
+
+// ParseWorkingHours parses "HH:MM-HH:MM" (or "8am-8pm").
+func ParseWorkingHours(s string) (*WorkingHours, error) {
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid working_hours %q: expected start-end (e.g. 08:00-20:00)", s)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working_hours %q: %w", s, err)
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working_hours %q: %w", s, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid working_hours %q: start and end are the same", s)
+	}
+	return &WorkingHours{Start: start, End: end}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, layout := range workingHoursLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+		}
+	}
+	if s == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("unrecognized time %q", s)
+}
+
+// Contains reports whether t falls within the window.
+func (w *WorkingHours) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// NextStart returns when the window next opens after t (t itself if it is
+// exactly the start).
+func (w *WorkingHours) NextStart(t time.Time) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	next := midnight.Add(w.Start)
+	if next.Before(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()).Add(w.Start)
+	}
+	return next
+}
+
+// String formats the window as "HH:MM-HH:MM".
+func (w *WorkingHours) String() string {
+	return formatClock(w.Start) + "-" + formatClock(w.End)
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// GetWorkingHours returns the configured working hours, or nil when agents
+// may run at any time.
+func (c *Config) GetWorkingHours() *WorkingHours {
+	if c == nil || c.WorkingHours == "" {
+		return nil
+	}
+	w, err := ParseWorkingHours(c.WorkingHours)
+	if err != nil {
+		return nil
+	}
+	return w
+}
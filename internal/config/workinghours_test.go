@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"08:00-20:00", "08:00-20:00", false},
+		{"8am-8pm", "08:00-20:00", false},
+		{" 9:30am - 6pm ", "09:30-18:00", false},
+		{"22:00-06:00", "22:00-06:00", false},
+		{"00:00-24:00", "00:00-24:00", false},
+		{"08:00", "", true},
+		{"08:00-08:00", "", true},
+		{"soon-later", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			w, err := ParseWorkingHours(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseWorkingHours(%q) = %v, want error", tt.input, w)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWorkingHours(%q) error = %v", tt.input, err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("ParseWorkingHours(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursContains(t *testing.T) {
+	day := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.Local)
+	}
+
+	daytime, _ := ParseWorkingHours("08:00-20:00")
+	overnight, _ := ParseWorkingHours("22:00-06:00")
+	tests := []struct {
+		name string
+		w    *WorkingHours
+		at   time.Time
+		want bool
+	}{
+		{"day start", daytime, day(8, 0), true},
+		{"day middle", daytime, day(13, 30), true},
+		{"day end", daytime, day(20, 0), false},
+		{"day before", daytime, day(7, 59), false},
+		{"night late", overnight, day(23, 0), true},
+		{"night early", overnight, day(5, 59), true},
+		{"night noon", overnight, day(12, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.at.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursNextStart(t *testing.T) {
+	w, _ := ParseWorkingHours("08:00-20:00")
+
+	evening := time.Date(2026, 3, 10, 21, 0, 0, 0, time.Local)
+	if got, want := w.NextStart(evening), time.Date(2026, 3, 11, 8, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("NextStart(evening) = %v, want %v", got, want)
+	}
+
+	early := time.Date(2026, 3, 10, 6, 0, 0, 0, time.Local)
+	if got, want := w.NextStart(early), time.Date(2026, 3, 10, 8, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("NextStart(early) = %v, want %v", got, want)
+	}
+}
+
+func TestConfigGetWorkingHours(t *testing.T) {
+	if (&Config{}).GetWorkingHours() != nil {
+		t.Error("GetWorkingHours() without working_hours should be nil")
+	}
+	if w := (&Config{WorkingHours: "8am-8pm"}).GetWorkingHours(); w == nil || w.String() != "08:00-20:00" {
+		t.Errorf("GetWorkingHours() = %v, want 08:00-20:00", w)
+	}
+}
@@ -12,16 +12,23 @@ import (
 // Task names are converted frequently in hot paths like kanban rendering.
 var camelCaseCache sync.Map
 
-// Window status emojis
-const (
+// Window status emojis. The task status emojis can be customized with
+// status_emojis in the config (see SetStatusEmojis).
+var (
 	EmojiWorking = "🤖"
 	EmojiWaiting = "💬"
 	EmojiReview  = "👀"
 	EmojiWarning = "⚠️"
 	EmojiDone    = "✅"
-	EmojiNew     = "⭐️"
 )
 
+// EmojiNew marks the main (new task) window. It is not customizable because
+// tmux keybindings match it literally.
+const EmojiNew = "⭐️"
+
+// EmojiSnoozed marks snoozed tasks in the Kanban (not a window status).
+const EmojiSnoozed = "💤"
+
 // TaskEmojis contains all emojis used for task windows.
 var TaskEmojis = []string{
 	EmojiWorking,
@@ -31,6 +38,20 @@ var TaskEmojis = []string{
 	EmojiDone,
 }
 
+// SetStatusEmojis overrides the task status emojis; empty values keep the
+// current emoji. Call it once at startup, before any window names are built.
+func SetStatusEmojis(working, waiting, review, warning, done string) {
+	for _, o := range []struct {
+		target *string
+		value  string
+	}{{&EmojiWorking, working}, {&EmojiWaiting, waiting}, {&EmojiReview, review}, {&EmojiWarning, warning}, {&EmojiDone, done}} {
+		if o.value != "" {
+			*o.target = o.value
+		}
+	}
+	TaskEmojis = []string{EmojiWorking, EmojiWaiting, EmojiReview, EmojiWarning, EmojiDone}
+}
+
 // IsTaskWindow returns true if the window name has a task emoji prefix.
 func IsTaskWindow(windowName string) bool {
 	for _, emoji := range TaskEmojis {
@@ -148,12 +169,16 @@ const (
 	ClaudeNameGenTimeout2   = 2 * time.Minute // sonnet
 	ClaudeNameGenTimeout3   = 3 * time.Minute // opus
 	ClaudeNameGenTimeout4   = 4 * time.Minute // opus with thinking
+	ClaudeSplitTimeout      = 3 * time.Minute // sonnet, task splitting
+	ClaudeSelfEvalTimeout   = 2 * time.Minute // sonnet, self-evaluation on finish
+	ClaudeReviewTimeout     = 5 * time.Minute // reviewer_model, review before merge
 )
 
 // Git/Worktree timeouts
 const (
 	WorktreeTimeout       = 30 * time.Second
 	WindowCreationTimeout = 30 * time.Second
+	LayoutRestoreTimeout  = 60 * time.Second // Wait for reopened task windows before restoring the layout
 )
 
 // PR watch interval
@@ -163,7 +188,42 @@ const (
 
 // Hook execution timeout
 const (
-	DefaultHookTimeout = 5 * time.Minute // Default timeout for hooks
+	DefaultHookTimeout   = 5 * time.Minute  // Default timeout for hooks
+	DefaultVerifyTimeout = 15 * time.Minute // Timeout for each verify-before-push command
+)
+
+// ConfirmTimeoutWarning is how long before a timed-out review auto-finishes
+// the user is warned.
+const ConfirmTimeoutWarning = 15 * time.Minute
+
+// DefaultSnoozeDuration is how long Alt+Z snoozes a task's notifications.
+const DefaultSnoozeDuration = 1 * time.Hour
+
+// FocusFollowTypingGrace is how recently a key must have been pressed for
+// focus-follow to treat the user as typing and hold off switching windows.
+const FocusFollowTypingGrace = 3 * time.Second
+
+// ControlIdleTimeout is how long the user holding the new-task window of a
+// shared session must be idle before ⌥C hands control over without asking.
+const ControlIdleTimeout = 2 * time.Minute
+
+// Git audit log settings
+const (
+	AuditLogMaxBytes = 10 * 1024 * 1024 // Rotate the audit log (one backup) past this size
+	AuditErrorMaxLen = 500              // Max stderr characters stored per failed command
+)
+
+// Command approval settings
+const (
+	ApprovalTimeout      = 10 * time.Minute       // Deny if the user doesn't decide in time
+	ApprovalPollInterval = 500 * time.Millisecond // Interval between approval decision checks
+)
+
+// Pair mode settings
+const (
+	PairDisallowedTools   = "Edit,Write,MultiEdit,NotebookEdit" // Claude tools disabled in pair tasks
+	PatchReviewTimeout    = 30 * time.Minute                    // Reject a proposed patch if the user doesn't decide in time
+	PatchProposalMaxBytes = 2 * 1024 * 1024                     // Largest patch a pair agent may propose
 )
 
 // Tmux command timeout
@@ -177,30 +237,91 @@ const (
 const (
 	DefaultMainBranch = "main"
 	DefaultWorkMode   = "worktree"
+
+	DefaultMinFreeDiskMB = 512 // Free space to keep after creating a worktree
+	DefaultContextMaxKB  = 64  // Budget for context files attached to the system prompt
+	DefaultLargeFileMB   = 50  // Size above which auto-commit treats a file as large
+
+	DefaultAutoMergeMaxFiles = 100  // Changed files above which auto-merge waits for review
+	DefaultAutoMergeMaxLines = 5000 // Changed lines above which auto-merge waits for review
+	DefaultSelfEvalMinScore  = 3    // Self-evaluation score (1-5) below which a completion is low-confidence
+
+	MergePreviewMaxCommits = 10 // Incoming commits listed before a merge
 )
 
 // End-task action names
 const (
 	ActionDone       = "done"
 	ActionDrop       = "drop"
-	ActionKeep       = "keep"
+	ActionKeep       = "keep" // Commit only; the task stays open
+	ActionPush       = "push" // Commit and push the task branch; the task stays open
 	ActionMerge      = "merge"
 	ActionMergePush  = "merge-push"
 	ActionPR         = "pr"
 	ActionCreateMain = "create-main" // Create main branch and merge
 )
 
+// OnCompleteConfirm waits for the user to finish a task (⌃F). Other
+// on_complete values are end-task actions run when the agent reports done.
+const OnCompleteConfirm = "confirm"
+
+// Cleanup policies for finished tasks (cleanup_policy)
+const (
+	CleanupImmediate = "immediate" // Remove the worktree and agent directory right away
+	CleanupDelayed   = "delayed"   // Park them, remove after cleanup_retention_days
+	CleanupManual    = "manual"    // Park them until 'paw parked prune'
+
+	DefaultCleanupRetentionDays = 7
+	ParkedDirName               = "parked"     // Parked tasks in the PAW directory
+	ParkedAtFileName            = ".parked-at" // When a task was parked (RFC3339)
+)
+
 // Log format constants
 const (
 	LogFormatText  = "text"
 	LogFormatJSONL = "jsonl"
 )
 
+// Link mode constants (how task directories link to shared files)
+const (
+	LinkModeSymlink = "symlink"
+	LinkModeCopy    = "copy" // For filesystems without symlink support (exFAT, some NFS mounts)
+)
+
+// Clipboard constants (the clipboard config option; other values are
+// commands that read the text from stdin, e.g. "xclip -selection clipboard")
+const (
+	ClipboardAuto  = "auto"  // Detect pbcopy, wl-copy, xclip, or xsel, else OSC 52
+	ClipboardOSC52 = "osc52" // Terminal escape sequence (works over SSH)
+)
+
+// Tmux mode constants (which tmux server a session runs on)
+const (
+	TmuxModeDedicated   = "dedicated"   // Own server with PAW's config ("paw-<session>" socket)
+	TmuxModeCooperative = "cooperative" // User's default server, keeping its prefix and options
+)
+
+// Agent mode constants (how the agent runs in its pane)
+const (
+	AgentModeInteractive = "interactive" // Interactive Claude session, status scraped from the pane
+	AgentModeStreamJSON  = "stream-json" // Headless stream-json run rendered by PAW, then interactive
+)
+
+// Gitignore management constants (whether PAW adds its .paw/ rules to the
+// project's .gitignore)
+const (
+	GitignoreAuto   = "auto"   // Add the rules without asking
+	GitignorePrompt = "prompt" // Ask first; behaves like auto when stdin is not a terminal
+	GitignoreNever  = "never"  // Leave .gitignore alone
+)
+
 // Global PAW directories (relative to $HOME)
 const (
 	GlobalConfigDir     = ".config/paw"       // Global config directory ($HOME/.config/paw)
 	GlobalDataDir       = ".local/share/paw"  // Base directory for global PAW data
 	GlobalWorkspacesDir = "workspaces"        // Subdirectory for project workspaces
+	GlobalStateDir      = ".local/state/paw"  // XDG state directory when $XDG_STATE_HOME is unset
+	ClaudeProjectsDir   = ".claude/projects"  // Claude Code session transcripts (token usage)
 )
 
 // Directory and file names
@@ -209,8 +330,15 @@ const (
 	AgentsDirName         = "agents"
 	HistoryDirName        = "history"
 	WindowMapFileName     = "window-map.json"
+	SessionLayoutFileName = "session-layout.json"
+	TemplateScheduleFile  = "template-schedules.json"
+	RepoMapFileName       = "repo-map.md"
+	CoverageCacheFileName = "coverage-cache.json"
+	CoverageReportName    = "coverage.txt" // Coverage delta in the task's artifacts
 	ConfigFileName        = "config"
+	ReadinessFileName     = "readiness.json"
 	LogFileName           = "log"
+	AuditLogFileName      = "audit.jsonl"
 	PromptFileName        = "PROMPT.md"
 	TaskFileName          = "task"
 	TaskContextFileName   = ".task-context"
@@ -222,8 +350,12 @@ const (
 	ClaudeLink            = ".claude"
 	BinSymlinkName        = "bin"                  // Symlink to current paw binary (updated on attach)
 	VersionFileName       = ".version"             // Stores PAW version for upgrade detection
+	MuteFileName          = ".muted"               // Notification mute deadline (empty: until unmuted)
+	BudgetOverrideFile    = ".budget-override"     // Day the daily token budget is overridden ('paw budget --override')
 	HistorySelectionFile  = ".history-selection"   // Temp file for Ctrl+R history selection
 	TemplateSelectionFile = ".template-selection"  // Temp file for Ctrl+T template selection
+	CloneSelectionFile    = ".clone-selection"     // Temp file for a task cloned into the new-task input
+	PasteSelectionFile    = ".paste-selection"     // Temp file for a task pasted from the tmux buffer (⌥V)
 	YaziSelectionFile     = ".yazi-selection"      // Temp file for yazi file picker selection
 	TemplateDraftFile     = ".template-draft"      // Temp file for Ctrl+T template creation
 	StatusSignalFileName  = ".status-signal"       // Temp file for Claude to signal status directly
@@ -236,16 +368,33 @@ const (
 	WorktreeDirName         = "worktree"         // Git worktree directory
 	StatusFileName          = ".status"          // Task status file (working/waiting/done)
 	SessionStartedFile      = ".session-started" // Session marker file
+	ResumeMarkerFile        = ".resume"          // Written by paw stop when it interrupted the agent mid-step
+	CompletedMarkerFile     = ".completed"       // Written once end-task has finished the task
 	AgentSystemPromptFile   = ".system-prompt"   // Agent's system prompt file (in agent dir)
 	AgentUserPromptFile     = ".user-prompt"     // Agent's user prompt file (in agent dir)
 	VerifyLogFile           = ".verify.log"      // Verify log file
 	VerifyJSONFile          = ".verify.json"     // Verify JSON result file
+	TimelineFileName        = ".timeline.json"   // Structured tool-call timeline
+	FailureFileName         = ".failure.json"    // Last classified agent failure
+	ReviewFileName          = ".review"          // Why the task needs human review before it is finished
+	ResearchAnswerFile      = "answer.md"        // Research task answer (saved to history)
+	ApprovalFileName        = ".approval.json"   // Pending command approval request
+	PatchProposalFileName   = ".patch.json"      // Pending pair-mode patch proposal
+	PairTreeFileName        = ".pair-tree"       // Git tree of a pair task's files as last approved
+	SnoozeFileName          = ".snooze"          // Snooze deadline (RFC3339) for notifications
+	QuestionFileName        = ".question"        // Question the agent is waiting on (notifications, Kanban)
+	AutoAnswersFileName     = ".answers.jsonl"   // Auto-answers given to the task (paw audit --auto-answers)
+	StreamStateFileName     = ".stream.json"     // Status and cost from stream-json supervision
+	ApprovalShimDirName     = ".approval-bin"    // PATH shims for commands that need approval
 	StartAgentScriptName    = "start-agent"      // Agent start script
+	ArtifactsDirName        = "artifacts"        // Task reports and build outputs (saved to history)
+	HeldBackDirName         = "held-back"        // Artifacts subdirectory for files left out of the task's commits
 )
 
 // Prompts directory and file names
 const (
 	PromptsDirName          = "prompts"           // Directory for custom prompts
+	OverridesDirName        = "overrides"         // Directory whose files replace embedded prompt/help assets
 	TaskNamePromptFile      = "task-name.md"      // Task name generation rules
 	MergeConflictPromptFile = "merge-conflict.md" // Merge conflict resolution prompt
 	PRDescriptionPromptFile = "pr-description.md" // PR title/body template
@@ -261,14 +410,57 @@ const (
 
 // Pane capture settings
 const (
-	PaneCaptureLines = 10000 // Number of lines to capture from pane history
+	PaneCaptureLines = 10000 // Default number of lines to capture from pane history
 	SummaryMaxLen    = 8000  // Max characters to send for summary generation
+	SelfEvalMaxDiff  = 20000 // Max characters of the diff to send for self-evaluation
+	ReviewMaxDiff    = 60000 // Max characters of the diff to send to the reviewer
+)
+
+// Pane capture format constants (whether captures saved to history keep colors)
+const (
+	PaneCaptureFormatPlain = "plain" // Escape sequences stripped
+	PaneCaptureFormatANSI  = "ansi"  // Colors and attributes kept (e.g. for HTML export)
 )
 
-// Merge lock settings
+// Large file action constants (what auto-commit does with files over
+// large_file_mb, or matching LFS patterns while git-lfs is missing)
 const (
-	MergeLockMaxRetries    = 900             // Maximum retries to acquire merge lock (15 minutes)
-	MergeLockRetryInterval = 1 * time.Second // Interval between lock retries
+	LargeFileSkip  = "skip"  // Leave them out of the commit, with a warning
+	LargeFileLFS   = "lfs"   // Track them with git-lfs (skip when git-lfs is missing)
+	LargeFileAllow = "allow" // Commit them as regular files, with a warning
+)
+
+// Changelog constants (changelog, written when a task is merged)
+const (
+	ChangelogFragment = "fragment" // One file per task in ChangelogFragmentDir
+	ChangelogAppend   = "append"   // Entries added to ChangelogFileName
+
+	ChangelogFragmentDir     = "changelog.d"
+	ChangelogFileName        = "CHANGELOG.md"
+	DefaultChangelogTemplate = "- {type}: {subject}"
+)
+
+// DefaultPasteTemplate wraps the tmux paste buffer in a task (paste_template, ⌥V).
+const DefaultPasteTemplate = "Investigate and fix:\n\n```\n{buffer}\n```"
+
+// Security scanner constants (security_scanners, run before push and merge)
+const (
+	SecurityScannerGitleaks = "gitleaks" // Secrets in the task's commits; every finding blocks
+	SecurityScannerSemgrep  = "semgrep"  // Static analysis; ERROR findings block
+
+	SecurityScanTimeout = 10 * time.Minute // Timeout per scanner run
+)
+
+// Lock settings (internal/lock)
+const (
+	MergeLockFileName      = "merge.lock"     // Workspace lock held while a task merges into the main branch
+	MergeLockTimeout       = 15 * time.Minute // How long to wait for another merge to finish
+	MergeLockTTL           = time.Hour        // Merge locks older than this are taken over even if their process runs
+	MergeLockRetryInterval = 1 * time.Second  // Interval between lock retries
+	HandleLockFileName     = ".handle.lock"   // Task lock held while handle-task sets up the task's window
+	HandleLockTTL          = 10 * time.Minute // Handle locks older than this are taken over
+	EndTaskLockFileName    = ".end-task.lock" // Task lock held while end-task finishes the task
+	EndTaskLockTTL         = time.Hour        // End-task locks older than this are taken over
 )
 
 // Task dependency settings
@@ -276,6 +468,41 @@ const (
 	DependencyPollInterval = 5 * time.Second // Interval for checking dependency status
 )
 
+// Working hours settings
+const (
+	WorkingHoursPollInterval = 30 * time.Second // Interval for checking whether queued tasks may start
+)
+
+// Token budget settings
+const (
+	TokenBudgetPollInterval = time.Minute // Interval for re-checking usage while tasks are queued over budget
+)
+
+// Template schedule settings
+const (
+	TemplateScheduleInterval = 10 * time.Minute // Interval for checking scheduled templates in a running session
+)
+
+// Editor endpoint settings
+const (
+	EditorSocketFile          = "editor.sock"   // Unix socket of the editor endpoint (in the workspace)
+	EditorSocketMaxPath       = 103             // Longest socket path that fits a sockaddr_un on every platform
+	EditorProtocolVersion     = 1               // Version of the editor endpoint's HTTP protocol
+	EditorRequestMaxBytes     = 1024 * 1024     // Largest request body the endpoint accepts
+	EditorRequestTimeout      = 2 * time.Minute // Time to read a request and create its task
+	EditorSessionPollInterval = 5 * time.Second // Interval for checking that the session still runs
+)
+
+// Autopilot settings
+const (
+	AutopilotFileName           = "autopilot.json"      // State of the latest autopilot run (in the workspace)
+	AutopilotReportFileName     = "autopilot-report.md" // Report of the latest autopilot run (in the workspace)
+	AutopilotPollInterval       = 30 * time.Second      // Interval for checking the run's tasks
+	AutopilotStallTimeout       = 15 * time.Minute      // A task idle this long without merging is reported blocked
+	AutopilotDefaultTaskTimeout = 3 * time.Hour         // A task still running after this is reported blocked
+	AutopilotMaxParallel        = 8                     // Most tasks an autopilot run starts at a time
+)
+
 // Commit message templates
 const (
 	CommitMessageAutoCommit      = "chore: auto-commit on task end\n\n%s"
@@ -446,7 +673,7 @@ const (
 
 	// Compact size for the finish picker popup.
 	PopupWidthFinish  = "80%"
-	PopupHeightFinish = "15"
+	PopupHeightFinish = "32"
 
 	// Compact size for the project picker popup.
 	PopupWidthProject  = "80%"
@@ -455,6 +682,22 @@ const (
 	// Compact size for the task name input popup.
 	PopupWidthTaskName  = "60%"
 	PopupHeightTaskName = "10"
+
+	// Compact size for the command approval popup.
+	PopupWidthApproval  = "80%"
+	PopupHeightApproval = "14"
+
+	// Size for the pair-mode patch review popup.
+	PopupWidthPatchReview  = PopupWidthFull
+	PopupHeightPatchReview = PopupHeightFull
+
+	// Compact size for the quick reply popup.
+	PopupWidthQuickReply  = "80%"
+	PopupHeightQuickReply = "20"
+
+	// Compact size for the interrupt (new direction) popup.
+	PopupWidthSteer  = "60%"
+	PopupHeightSteer = "8"
 )
 
 // Pane sizes for split panes
@@ -477,7 +720,48 @@ const (
 	DisplayMsgCritical  = 4000 // Critical messages requiring user attention
 )
 
+// Git network retry settings (git_network_retries).
+const (
+	DefaultGitNetworkRetries = 3                // Retries of a git push/fetch/pull that failed on a network error
+	GitRetryBaseDelay        = 2 * time.Second  // Backoff before the first retry, doubled for each further one
+	GitRetryMaxDelay         = 30 * time.Second // Longest backoff between retries
+)
+
 // Conflict resolution settings.
 const (
 	ConflictResolutionTimeout = 10 * time.Minute // Timeout for merge conflict resolution
+	ConflictWorktreeDirName   = "conflicts"      // Temporary worktrees for conflict resolution, in the PAW directory
+)
+
+// Non-interactive mode and error output settings.
+const (
+	NonInteractiveEnv = "PAW_NONINTERACTIVE" // Env var that makes prompts take their default or fail (like --non-interactive)
+	ErrorFormatEnv    = "PAW_ERROR_FORMAT"   // Env var setting the error output format (like --error-format)
+	ErrorFormatText   = "text"               // Errors as plain text on stderr
+	ErrorFormatJSON   = "json"               // Errors as one JSON object per line on stderr
+)
+
+// Organization policy settings.
+const (
+	PolicyFileEnv     = "PAW_POLICY"           // Env var pointing to the organization policy file
+	DefaultPolicyPath = "/etc/paw/policy.toml" // Organization policy file when PAW_POLICY is unset
+)
+
+// History encryption settings.
+const (
+	HistoryKeyEnv          = "PAW_HISTORY_KEY" // Env var with the history encryption key
+	HistoryKeychainService = "paw"             // OS keychain service of the history key
+	HistoryKeychainAccount = "history-key"     // OS keychain account of the history key
+	HistoryEncryptedHeader = "PAWENC1\n"       // Prefix of encrypted history files
+	HistoryKeychainTimeout = 5 * time.Second   // Timeout for keychain lookups
+)
+
+// Proxy and custom CA settings.
+var (
+	// ProxyEnvVars are the proxy settings passed from the shell that started PAW
+	// to agents and git, whatever environment the tmux server was started with.
+	ProxyEnvVars = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "ALL_PROXY", "all_proxy", "NO_PROXY", "no_proxy"}
+	// CAEnvVars are the custom CA bundles passed along with the proxy settings:
+	// git reads GIT_SSL_CAINFO, Claude (Node.js) reads NODE_EXTRA_CA_CERTS.
+	CAEnvVars = []string{"GIT_SSL_CAINFO", "GIT_SSL_CAPATH", "SSL_CERT_FILE", "NODE_EXTRA_CA_CERTS"}
 )
@@ -0,0 +1,212 @@
+package embed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// States of a Claude file on disk compared with the embedded one.
+const (
+	ClaudeFileCurrent  = "current"  // Matches the embedded file and its overlay
+	ClaudeFileMissing  = "missing"  // Not written yet
+	ClaudeFileOutdated = "outdated" // PAW's copy of an older embedded file or overlay; rewritten
+	ClaudeFileEdited   = "edited"   // Changed since PAW wrote it; kept until removed
+)
+
+// LocalOverlaySuffix names the file holding local additions to a Claude file:
+// CLAUDE.md.local is appended to CLAUDE.md, and the JSON object in
+// settings.local.json.local is merged into settings.local.json (see mergeJSON).
+const LocalOverlaySuffix = ".local"
+
+// claudeHashesFile records the hash of every Claude file PAW wrote, so later
+// writes can tell PAW's copies from user edits.
+const claudeHashesFile = ".paw-hashes.json"
+
+// ClaudeFile is an embedded Claude file compared with its copy on disk.
+type ClaudeFile struct {
+	Path  string // Relative to the .claude directory
+	State string
+	Want  []byte // Embedded content with the overlay applied
+	Have  []byte // Content on disk, nil if missing
+
+	OverlayErr error // Why the overlay was not applied; the file is then left as is
+}
+
+// DiffClaudeFiles compares the embedded Claude files with their copies in
+// targetDir without changing anything.
+func DiffClaudeFiles(targetDir string) ([]ClaudeFile, error) {
+	files, _, err := inspectClaudeFiles(targetDir)
+	return files, err
+}
+
+// syncClaudeFiles writes the Claude files that are missing or outdated and
+// keeps the ones edited since PAW wrote them.
+func syncClaudeFiles(targetDir string) error {
+	if err := os.MkdirAll(targetDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	files, hashes, err := inspectClaudeFiles(targetDir)
+	if files == nil {
+		return err
+	}
+
+	for _, f := range files {
+		switch f.State {
+		case ClaudeFileMissing, ClaudeFileOutdated:
+			if f.OverlayErr != nil && f.State == ClaudeFileOutdated {
+				continue
+			}
+			targetPath := filepath.Join(targetDir, f.Path)
+			if mkErr := os.MkdirAll(filepath.Dir(targetPath), 0755); mkErr != nil { //nolint:gosec // G301: standard directory permissions
+				return mkErr
+			}
+			if writeErr := os.WriteFile(targetPath, f.Want, 0644); writeErr != nil { //nolint:gosec // G306: config files need to be readable
+				return writeErr
+			}
+			hashes[f.Path] = contentHash(f.Want)
+		case ClaudeFileCurrent:
+			hashes[f.Path] = contentHash(f.Want)
+		case ClaudeFileEdited:
+			logging.Warn("Keeping edited %s; move your changes to %s%s to get PAW's updates (paw assets diff)",
+				f.Path, f.Path, LocalOverlaySuffix)
+		}
+	}
+
+	data, marshalErr := json.MarshalIndent(hashes, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if writeErr := os.WriteFile(filepath.Join(targetDir, claudeHashesFile), append(data, '\n'), 0644); writeErr != nil { //nolint:gosec // G306: config files need to be readable
+		return writeErr
+	}
+	return err
+}
+
+// inspectClaudeFiles returns the embedded Claude files with their state and
+// the recorded hashes. Files with an invalid overlay are compared without it,
+// and the overlay errors are returned with the files.
+func inspectClaudeFiles(targetDir string) ([]ClaudeFile, map[string]string, error) {
+	hashes := make(map[string]string)
+	if data, err := os.ReadFile(filepath.Join(targetDir, claudeHashesFile)); err == nil { //nolint:gosec // G304: path is inside the .claude directory
+		_ = json.Unmarshal(data, &hashes)
+	}
+
+	var files []ClaudeFile
+	var overlayErrs []error
+	err := fs.WalkDir(Assets, "assets/claude", func(assetPath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := Assets.ReadFile(assetPath)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel("assets/claude", assetPath)
+		if err != nil {
+			return err
+		}
+
+		targetPath := filepath.Join(targetDir, rel)
+		f := ClaudeFile{Path: rel, Want: data}
+		if want, overlayErr := applyOverlay(data, targetPath+LocalOverlaySuffix); overlayErr != nil {
+			f.OverlayErr = fmt.Errorf("%s%s: %w", rel, LocalOverlaySuffix, overlayErr)
+			overlayErrs = append(overlayErrs, f.OverlayErr)
+		} else {
+			f.Want = want
+		}
+
+		have, readErr := os.ReadFile(targetPath) //nolint:gosec // G304: path is inside the .claude directory
+		switch {
+		case readErr != nil:
+			f.State = ClaudeFileMissing
+		case bytes.Equal(have, f.Want):
+			f.State = ClaudeFileCurrent
+		case hashes[rel] == "" || hashes[rel] == contentHash(have):
+			// Unrecorded files were written before hashes were kept
+			f.State = ClaudeFileOutdated
+		default:
+			f.State = ClaudeFileEdited
+		}
+		if readErr == nil {
+			f.Have = have
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, hashes, errors.Join(overlayErrs...)
+}
+
+// applyOverlay adds the overlay file, if any, to an embedded file's content.
+func applyOverlay(data []byte, overlayPath string) ([]byte, error) {
+	overlay, err := os.ReadFile(overlayPath) //nolint:gosec // G304: path is inside the .claude directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, err
+	}
+
+	if path.Ext(overlayPath[:len(overlayPath)-len(LocalOverlaySuffix)]) != ".json" {
+		out := bytes.TrimRight(data, "\n")
+		out = append(out, "\n\n"...)
+		out = append(out, bytes.TrimRight(overlay, "\n")...)
+		return append(out, '\n'), nil
+	}
+
+	var base, extra map[string]any
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(overlay, &extra); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	var merged bytes.Buffer
+	enc := json.NewEncoder(&merged)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(mergeJSON(base, extra)); err != nil {
+		return nil, err
+	}
+	return merged.Bytes(), nil
+}
+
+// mergeJSON merges overlay into base: objects are merged key by key, arrays
+// are appended to, and other values are replaced.
+func mergeJSON(base, overlay map[string]any) map[string]any {
+	for key, value := range overlay {
+		switch v := value.(type) {
+		case map[string]any:
+			if obj, ok := base[key].(map[string]any); ok {
+				base[key] = mergeJSON(obj, v)
+				continue
+			}
+		case []any:
+			if arr, ok := base[key].([]any); ok {
+				base[key] = append(arr, v...)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
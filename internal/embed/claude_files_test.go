@@ -0,0 +1,154 @@
+package embed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func claudeFileStates(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files, err := DiffClaudeFiles(dir)
+	if err != nil {
+		t.Fatalf("DiffClaudeFiles() error = %v", err)
+	}
+	states := make(map[string]string, len(files))
+	for _, f := range files {
+		states[f.Path] = f.State
+	}
+	return states
+}
+
+func TestWriteClaudeFilesOnlyRewritesChanges(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".claude")
+	if got := claudeFileStates(t, dir)["CLAUDE.md"]; got != ClaudeFileMissing {
+		t.Fatalf("CLAUDE.md before writing = %s, want missing", got)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatalf("WriteClaudeFiles() error = %v", err)
+	}
+	for path, state := range claudeFileStates(t, dir) {
+		if state != ClaudeFileCurrent {
+			t.Errorf("%s after writing = %s, want current", path, state)
+		}
+	}
+
+	// Unchanged files are not rewritten
+	claudeMd := filepath.Join(dir, "CLAUDE.md")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(claudeMd, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatalf("WriteClaudeFiles() error = %v", err)
+	}
+	if info, err := os.Stat(claudeMd); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("unchanged CLAUDE.md was rewritten")
+	}
+}
+
+func TestWriteClaudeFilesKeepsEdits(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".claude")
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	claudeMd := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(claudeMd, []byte("my rules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := claudeFileStates(t, dir)["CLAUDE.md"]; got != ClaudeFileEdited {
+		t.Fatalf("edited CLAUDE.md = %s, want edited", got)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(claudeMd); string(data) != "my rules\n" {
+		t.Errorf("edited CLAUDE.md was overwritten: %q", data)
+	}
+
+	// Moved to the overlay, the edit is appended to PAW's file
+	if err := os.Remove(claudeMd); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(claudeMd+LocalOverlaySuffix, []byte("my rules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	embedded, _ := Assets.ReadFile("assets/claude/CLAUDE.md")
+	data, _ := os.ReadFile(claudeMd)
+	if !strings.HasPrefix(string(data), strings.TrimRight(string(embedded), "\n")) || !strings.HasSuffix(string(data), "\n\nmy rules\n") {
+		t.Errorf("CLAUDE.md with overlay = %q", data)
+	}
+
+	// Changing the overlay updates PAW's copy
+	if err := os.WriteFile(claudeMd+LocalOverlaySuffix, []byte("other rules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := claudeFileStates(t, dir)["CLAUDE.md"]; got != ClaudeFileOutdated {
+		t.Fatalf("CLAUDE.md after changing the overlay = %s, want outdated", got)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(claudeMd); !strings.HasSuffix(string(data), "\n\nother rules\n") {
+		t.Errorf("CLAUDE.md was not updated with the overlay: %q", data)
+	}
+}
+
+func TestWriteClaudeFilesReplacesUnrecordedFiles(t *testing.T) {
+	// Files written before hashes were recorded are PAW's
+	dir := filepath.Join(t.TempDir(), ".claude")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), []byte("old PAW rules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := claudeFileStates(t, dir)["CLAUDE.md"]; got != ClaudeFileCurrent {
+		t.Errorf("unrecorded CLAUDE.md = %s, want current", got)
+	}
+}
+
+func TestWriteClaudeFilesMergesJSONOverlay(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".claude")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := filepath.Join(dir, "settings.local.json")
+	overlay := `{"permissions": {"allow": ["Bash(make:*)"]}, "model": "opus"}`
+	if err := os.WriteFile(settings+LocalOverlaySuffix, []byte(overlay), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClaudeFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"Bash(echo:*)"`, `"Bash(make:*)"`, `"model": "opus"`, "internal stop-hook"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("merged settings missing %s:\n%s", want, data)
+		}
+	}
+
+	// An invalid overlay is reported and leaves PAW's copy alone
+	if err := os.WriteFile(settings+LocalOverlaySuffix, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClaudeFiles(dir); err == nil || !strings.Contains(err.Error(), "settings.local.json.local") {
+		t.Errorf("WriteClaudeFiles() with an invalid overlay error = %v", err)
+	}
+	if after, _ := os.ReadFile(settings); string(after) != string(data) {
+		t.Errorf("settings were rewritten despite the invalid overlay")
+	}
+}
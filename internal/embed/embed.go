@@ -4,7 +4,6 @@ package embed
 import (
 	"bytes"
 	"embed"
-	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -16,7 +15,7 @@ var Assets embed.FS
 
 // GetHelp returns the help content.
 func GetHelp() (string, error) {
-	data, err := Assets.ReadFile("assets/HELP.md")
+	data, err := readAsset("HELP.md")
 	if err != nil {
 		return "", err
 	}
@@ -25,11 +24,30 @@ func GetHelp() (string, error) {
 
 // GetPrompt returns the system prompt content based on git mode.
 func GetPrompt(isGitRepo bool) (string, error) {
-	filename := "assets/PROMPT-nogit.md"
+	filename := "PROMPT-nogit.md"
 	if isGitRepo {
-		filename = "assets/PROMPT.md"
+		filename = "PROMPT.md"
 	}
-	data, err := Assets.ReadFile(filename)
+	data, err := readAsset(filename)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetResearchPrompt returns the system prompt for read-only research tasks.
+func GetResearchPrompt() (string, error) {
+	data, err := readAsset("PROMPT-research.md")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetPairPrompt returns the instructions appended to the system prompt of
+// pair tasks (the agent proposes patches instead of editing files).
+func GetPairPrompt() (string, error) {
+	data, err := readAsset("PROMPT-pair.md")
 	if err != nil {
 		return "", err
 	}
@@ -38,16 +56,21 @@ func GetPrompt(isGitRepo bool) (string, error) {
 
 // GetTmuxConfig returns the PAW-specific tmux configuration content.
 func GetTmuxConfig() (string, error) {
-	data, err := Assets.ReadFile("assets/tmux.conf")
+	data, err := readAsset("tmux.conf")
 	if err != nil {
 		return "", err
 	}
 	return string(data), nil
 }
 
+// GetReadinessRules returns the default Claude readiness detection ruleset (JSON).
+func GetReadinessRules() ([]byte, error) {
+	return readAsset("readiness.json")
+}
+
 // GetPawHelp returns the PAW help content for agents.
 func GetPawHelp() (string, error) {
-	data, err := Assets.ReadFile("assets/HELP-FOR-PAW.md")
+	data, err := readAsset("HELP-FOR-PAW.md")
 	if err != nil {
 		return "", err
 	}
@@ -66,52 +89,18 @@ func WritePawHelpFile(pawDir string) error {
 }
 
 // WriteClaudeFiles writes the embedded claude directory to the target path.
-// This copies Claude settings to .paw/.claude/
+// This copies Claude settings to .paw/.claude/. Files are only rewritten when
+// their content changed, with their .local overlay applied; files edited since
+// PAW wrote them are kept.
 func WriteClaudeFiles(targetDir string) error {
-	// Create target directory
-	if err := os.MkdirAll(targetDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
-		return err
-	}
-
-	// Walk through embedded claude directory and copy files
-	return fs.WalkDir(Assets, "assets/claude", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Calculate relative path from assets/claude
-		relPath, err := filepath.Rel("assets/claude", path)
-		if err != nil {
-			return err
-		}
-
-		// Skip root directory
-		if relPath == "." {
-			return nil
-		}
-
-		targetPath := filepath.Join(targetDir, relPath)
-
-		if d.IsDir() {
-			return os.MkdirAll(targetPath, 0755) //nolint:gosec // G301: standard directory permissions
-		}
-
-		// Read embedded file
-		data, err := Assets.ReadFile(path)
-		if err != nil {
-			return err
-		}
-
-		// Write to target
-		return os.WriteFile(targetPath, data, 0644) //nolint:gosec // G306: config files need to be readable
-	})
+	return syncClaudeFiles(targetDir)
 }
 
-// GetDefaultPrompt returns the default prompt content by name.
+// GetDefaultPrompt returns the default prompt content by name, from the
+// override directory if it has one (see SetOverrideDir).
 // Available prompts: task-name, merge-conflict, pr-description, commit-message
 func GetDefaultPrompt(name string) (string, error) {
-	filename := "assets/prompts/" + name + ".md"
-	data, err := Assets.ReadFile(filename)
+	data, err := readAsset("prompts/" + name + ".md")
 	if err != nil {
 		return "", err
 	}
@@ -138,6 +127,28 @@ func GetCommitMessagePrompt() (string, error) {
 	return GetDefaultPrompt("commit-message")
 }
 
+// GetTaskTemplates returns the built-in maintenance task templates, keyed by
+// name (the file name without .md).
+func GetTaskTemplates() (map[string]string, error) {
+	entries, err := Assets.ReadDir("assets/templates")
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".md" {
+			continue
+		}
+		data, err := readAsset("templates/" + name)
+		if err != nil {
+			return nil, err
+		}
+		templates[name[:len(name)-len(".md")]] = string(data)
+	}
+	return templates, nil
+}
+
 // WriteDefaultPrompt writes a default prompt to the target directory if it doesn't exist.
 // Returns the path to the prompt file.
 func WriteDefaultPrompt(promptsDir, name string) (string, error) {
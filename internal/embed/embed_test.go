@@ -45,6 +45,17 @@ func TestGetPromptNoGit(t *testing.T) {
 	}
 }
 
+func TestGetResearchPrompt(t *testing.T) {
+	content, err := GetResearchPrompt()
+	if err != nil {
+		t.Fatalf("GetResearchPrompt() error = %v", err)
+	}
+
+	if !strings.Contains(content, "answer.md") {
+		t.Error("GetResearchPrompt() should mention the answer file")
+	}
+}
+
 func TestWriteClaudeFiles(t *testing.T) {
 	tempDir := t.TempDir()
 	targetDir := filepath.Join(tempDir, ".claude")
@@ -434,3 +445,15 @@ func TestAssetsContainsHooksDir(t *testing.T) {
 		t.Error("Assets should contain 'hooks' directory")
 	}
 }
+
+func TestGetTaskTemplates(t *testing.T) {
+	templates, err := GetTaskTemplates()
+	if err != nil {
+		t.Fatalf("GetTaskTemplates() error = %v", err)
+	}
+	for _, name := range []string{"dependency-bump", "flaky-test-hunt", "todo-triage", "changelog-update"} {
+		if strings.TrimSpace(templates[name]) == "" {
+			t.Errorf("built-in template %q is missing or empty", name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package embed
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// overrides holds the directory whose files replace embedded assets. It is
+// set from the project's .paw/overrides by SetOverrideDir.
+var overrides struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// SetOverrideDir sets the directory whose files replace the embedded prompt
+// and help assets with the same path, e.g. PROMPT.md or prompts/task-name.md.
+// An empty dir uses the embedded assets only.
+func SetOverrideDir(dir string) {
+	overrides.mu.Lock()
+	defer overrides.mu.Unlock()
+	overrides.dir = dir
+}
+
+func overrideDir() string {
+	overrides.mu.RLock()
+	defer overrides.mu.RUnlock()
+	return overrides.dir
+}
+
+// readAsset reads the asset at name (relative to assets/), preferring its
+// override.
+func readAsset(name string) ([]byte, error) {
+	if dir := overrideDir(); dir != "" && overridable(name) {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name))) //nolint:gosec // G304: name is an embedded asset path
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return Assets.ReadFile(path.Join("assets", name))
+}
+
+// overridable reports whether an embedded asset can be overridden. Claude
+// files have .local overlays instead, and the pre-commit hook is not a prompt.
+func overridable(name string) bool {
+	return !strings.HasPrefix(name, "claude/") && !strings.HasPrefix(name, "hooks/")
+}
+
+// ListOverrides returns the files in the override directory that replace an
+// embedded asset, and the ones that match none (which are ignored).
+func ListOverrides() (active, unknown []string, err error) {
+	dir := overrideDir()
+	if dir == "" {
+		return nil, nil, nil
+	}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if _, statErr := fs.Stat(Assets, path.Join("assets", name)); statErr == nil && overridable(name) {
+			active = append(active, name)
+		} else {
+			unknown = append(unknown, name)
+		}
+		return nil
+	})
+	sort.Strings(active)
+	sort.Strings(unknown)
+	return active, unknown, err
+}
@@ -0,0 +1,64 @@
+package embed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOverrides(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"PROMPT.md":                "team prompt\n",
+		"prompts/task-name.md":     "team task names\n",
+		"templates/todo-triage.md": "team triage\n",
+		"claude/CLAUDE.md":         "not applied\n",
+		"prompts/typo.md":          "ignored\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	SetOverrideDir(dir)
+	t.Cleanup(func() { SetOverrideDir("") })
+
+	if got, err := GetPrompt(true); err != nil || got != "team prompt\n" {
+		t.Errorf("GetPrompt(true) = %q, %v; want the override", got, err)
+	}
+	if got, _ := GetPrompt(false); got == "team prompt\n" || got == "" {
+		t.Errorf("GetPrompt(false) = %q, want the embedded prompt", got)
+	}
+	if got, _ := GetTaskNamePrompt(); got != "team task names\n" {
+		t.Errorf("GetTaskNamePrompt() = %q, want the override", got)
+	}
+	templates, err := GetTaskTemplates()
+	if err != nil || templates["todo-triage"] != "team triage\n" || templates["changelog-update"] == "" {
+		t.Errorf("GetTaskTemplates() = %v, %v; want todo-triage overridden", templates, err)
+	}
+
+	active, unknown, err := ListOverrides()
+	if err != nil {
+		t.Fatalf("ListOverrides() error = %v", err)
+	}
+	if strings.Join(active, ",") != "PROMPT.md,prompts/task-name.md,templates/todo-triage.md" {
+		t.Errorf("ListOverrides() active = %q", active)
+	}
+	if strings.Join(unknown, ",") != "claude/CLAUDE.md,prompts/typo.md" {
+		t.Errorf("ListOverrides() unknown = %q", unknown)
+	}
+
+	// A missing override directory lists nothing
+	SetOverrideDir(filepath.Join(dir, "missing"))
+	if active, unknown, err := ListOverrides(); err != nil || active != nil || unknown != nil {
+		t.Errorf("ListOverrides() of a missing directory = %q, %q, %v", active, unknown, err)
+	}
+	if got, _ := GetTaskNamePrompt(); got == "team task names\n" {
+		t.Error("GetTaskNamePrompt() still returns the override")
+	}
+}
@@ -0,0 +1,132 @@
+// Package exitcode defines the documented exit codes of the paw CLI, so
+// wrappers can branch on the kind of failure.
+package exitcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Exit codes. Errors without a code exit with Failure.
+const (
+	OK      = 0
+	Failure = 1 // Unclassified error
+
+	ConfigMissing   = 10 // No PAW workspace for the project (run 'paw' first)
+	TmuxUnavailable = 11 // tmux is not installed
+	ConfigInvalid   = 12 // The config or organization policy could not be loaded
+	SessionNotFound = 13 // The project has no running PAW session
+	TaskNotFound    = 14 // No task with the given name or window
+
+	MergeConflict      = 20 // Merging the task failed (conflicts, or another merge held the lock)
+	VerificationFailed = 21 // A build/lint/test verify command failed before pushing
+	PolicyViolation    = 22 // A diff check (diff_checks) failed before merging or pushing
+	SecurityFindings   = 23 // A security scanner (security_scanners) reported critical findings
+	LowSelfEval        = 24 // The self-evaluation (self_eval) scored the work low; the merge is held once
+	ReviewRejected     = 25 // The reviewer (reviewer_model) requested changes to an automatic merge
+)
+
+// Error is an error that exits the CLI with Code. Task and Hint add
+// context for structured error output.
+type Error struct {
+	Code int
+	Err  error
+	Task string // Task the error is about, if any
+	Hint string // Remediation hint; defaults to the code's hint
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns err with an exit code. A nil err stays nil.
+func New(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Errorf formats an error with an exit code.
+func Errorf(code int, format string, args ...any) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Code returns the exit code for err: OK for nil, the code of the
+// outermost *Error in its chain, or Failure.
+func Code(err error) int {
+	if err == nil {
+		return OK
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return Failure
+}
+
+// WithTask records the task err is about, adding an *Error (Failure) if err
+// has none.
+func WithTask(err error, task string) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return &Error{Code: Failure, Err: err, Task: task}
+	}
+	e.Task = task
+	return err
+}
+
+// hints are the default remediation hints by exit code.
+var hints = map[int]string{
+	ConfigMissing:      "Run 'paw' in the project to create its workspace",
+	TmuxUnavailable:    "Install tmux (e.g. 'brew install tmux'), then run 'paw check'",
+	ConfigInvalid:      "Fix .paw/config or the organization policy file named in the message",
+	SessionNotFound:    "Start the project's session with 'paw', or pass the name of a running session",
+	TaskNotFound:       "Check the task name on the Kanban board or with 'paw history'",
+	MergeConflict:      "Resolve the conflicts in the project directory, then finish the task again",
+	VerificationFailed: "Fix the failing verify command (output in the task's hook log), then finish the task again",
+	PolicyViolation:    "Fix the diff_checks violations listed in the task window, then finish the task again",
+	SecurityFindings:   "Fix the critical findings (reports in the task's artifacts), then finish the task again",
+	LowSelfEval:        "Review self-eval.md in the task's artifacts, then finish the task again to merge it anyway",
+	ReviewRejected:     "Address the comments in code-review.md in the task's artifacts, or finish the task with ⌃F to merge it anyway",
+}
+
+// Report is the structured form of an error, printed as JSON with
+// --error-format json.
+type Report struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Task    string `json:"task,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// NewReport describes err.
+func NewReport(err error) Report {
+	r := Report{Code: Code(err), Message: err.Error()}
+	var e *Error
+	if errors.As(err, &e) {
+		r.Task = e.Task
+		r.Hint = e.Hint
+	}
+	if r.Hint == "" {
+		r.Hint = hints[r.Code]
+	}
+	return r
+}
+
+// JSON returns the report as a single line of JSON.
+func (r Report) JSON() string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Sprintf(`{"code":%d,"message":%q}`, r.Code, r.Message)
+	}
+	return string(data)
+}
@@ -0,0 +1,50 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	base := errors.New("boom")
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, OK},
+		{"plain", base, Failure},
+		{"coded", New(MergeConflict, base), MergeConflict},
+		{"wrapped", fmt.Errorf("end-task: %w", Errorf(VerificationFailed, "test failed")), VerificationFailed},
+	}
+	for _, tt := range tests {
+		if got := Code(tt.err); got != tt.want {
+			t.Errorf("%s: Code() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if New(ConfigMissing, nil) != nil {
+		t.Error("New(code, nil) != nil")
+	}
+	if err := New(TaskNotFound, base); !errors.Is(err, base) || err.Error() != "boom" {
+		t.Errorf("New() = %v, want to wrap %v", err, base)
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	err := fmt.Errorf("end-task: %w", WithTask(Errorf(VerificationFailed, "test failed"), "fix-login"))
+	r := NewReport(err)
+	if r.Code != VerificationFailed || r.Task != "fix-login" || r.Message != "end-task: test failed" || r.Hint == "" {
+		t.Errorf("NewReport() = %+v", r)
+	}
+	if got, want := NewReport(&Error{Code: SessionNotFound, Err: errors.New("gone"), Hint: "custom"}).JSON(),
+		`{"code":13,"message":"gone","hint":"custom"}`; got != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+
+	plain := NewReport(WithTask(errors.New("boom"), "t1"))
+	if plain.Code != Failure || plain.Task != "t1" || plain.Hint != "" {
+		t.Errorf("NewReport(plain) = %+v", plain)
+	}
+}
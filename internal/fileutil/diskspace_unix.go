@@ -0,0 +1,18 @@
+//go:build !windows
+
+package fileutil
+
+import "syscall"
+
+// freeDiskSpace returns the free space of the filesystem holding an existing path.
+func freeDiskSpace(path string) (DiskSpace, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return DiskSpace{}, err
+	}
+	return DiskSpace{
+		FreeBytes:   st.Bavail * uint64(st.Bsize), //nolint:gosec // G115: block size is never negative
+		FreeInodes:  st.Ffree,
+		TotalInodes: st.Files,
+	}, nil
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package fileutil
+
+import "golang.org/x/sys/windows"
+
+// freeDiskSpace returns the free space of the volume holding an existing path.
+// Windows volumes have no inode limit, so TotalInodes is 0.
+func freeDiskSpace(path string) (DiskSpace, error) {
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return DiskSpace{}, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(dir, &free, nil, nil); err != nil {
+		return DiskSpace{}, err
+	}
+	return DiskSpace{FreeBytes: free}, nil
+}
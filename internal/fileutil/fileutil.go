@@ -78,3 +78,77 @@ func BackupCorruptFile(path string) error {
 
 	return os.Rename(path, backupPath)
 }
+
+// CopyFile copies src to dst atomically, keeping the file mode and modification time.
+func CopyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src) //nolint:gosec // G304: callers copy files they manage
+	if err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(dst, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// CopyDir copies the files in src into dst, creating directories as needed.
+// With newerOnly, files are skipped unless they are newer than the existing copy.
+// Symlinks inside src are skipped.
+func CopyDir(src, dst string, newerOnly bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0755) //nolint:gosec // G301: standard directory permissions
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		if newerOnly {
+			srcInfo, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if dstInfo, err := os.Stat(target); err == nil && !srcInfo.ModTime().After(dstInfo.ModTime()) {
+				return nil
+			}
+		}
+		return CopyFile(path, target)
+	})
+}
+
+// DiskSpace describes the free space of a filesystem.
+type DiskSpace struct {
+	FreeBytes   uint64
+	FreeInodes  uint64
+	TotalInodes uint64 // 0 if the filesystem does not report inodes
+}
+
+// FreeDiskSpace returns the free space of the filesystem holding path.
+// If path does not exist yet, its nearest existing parent is used.
+func FreeDiskSpace(path string) (DiskSpace, error) {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	return freeDiskSpace(path)
+}
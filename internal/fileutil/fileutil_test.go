@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestWriteFileAtomic(t *testing.T) {
@@ -108,3 +109,63 @@ func TestBackupCorruptFile(t *testing.T) {
 		t.Fatal("Expected rotated backup to contain new content")
 	}
 }
+
+func TestCopyDir(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "a.json"), []byte("a"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "b"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyDir(src, dst, false); err != nil {
+		t.Fatalf("CopyDir() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "sub", "b")); err != nil || string(data) != "b" {
+		t.Fatalf("copied sub/b = %q, %v", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(dst, "a.json")); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("copied a.json mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	// An older source file must not overwrite a newer copy
+	if err := os.WriteFile(filepath.Join(dst, "a.json"), []byte("changed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(src, "a.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyDir(src, dst, true); err != nil {
+		t.Fatalf("CopyDir(newerOnly) error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "a.json")); string(data) != "changed" {
+		t.Errorf("CopyDir(newerOnly) overwrote a newer file: %q", data)
+	}
+}
+
+func TestFreeDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+
+	space, err := FreeDiskSpace(dir)
+	if err != nil {
+		t.Fatalf("FreeDiskSpace() error = %v", err)
+	}
+	if space.FreeBytes == 0 {
+		t.Error("FreeDiskSpace() FreeBytes = 0, want > 0")
+	}
+
+	// Paths that do not exist yet are measured on their nearest existing parent
+	missing, err := FreeDiskSpace(filepath.Join(dir, "not", "created"))
+	if err != nil {
+		t.Fatalf("FreeDiskSpace(missing) error = %v", err)
+	}
+	if missing.TotalInodes != space.TotalInodes {
+		t.Errorf("FreeDiskSpace(missing) TotalInodes = %d, want %d", missing.TotalInodes, space.TotalInodes)
+	}
+}
@@ -0,0 +1,146 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// AuditEntry is a single git command recorded in the audit log.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Script     string    `json:"script,omitempty"`
+	Task       string    `json:"task,omitempty"`
+	Dir        string    `json:"dir,omitempty"`
+	Args       []string  `json:"args"`
+	ExitCode   int       `json:"exit_code"`
+	DurationMS int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// auditLog is the process-wide audit destination. Auditing is off until SetAuditLog is called.
+var auditLog struct {
+	mu     sync.Mutex
+	path   string
+	script string
+	task   string
+}
+
+// SetAuditLog enables audit logging of every git command run by the client.
+// script and task identify the PAW command that ran it. An empty path disables auditing.
+func SetAuditLog(path, script, task string) {
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+	auditLog.path = path
+	auditLog.script = script
+	auditLog.task = task
+}
+
+// recordAudit appends a git command to the audit log. Failures are ignored
+// so auditing never breaks git operations.
+func recordAudit(dir string, args []string, start time.Time, runErr error, stderr string) {
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+	if auditLog.path == "" {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:       start,
+		Script:     auditLog.script,
+		Task:       auditLog.task,
+		Dir:        dir,
+		Args:       args,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if runErr != nil {
+		entry.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			entry.ExitCode = exitErr.ExitCode()
+		}
+		entry.Error = truncateAuditError(stderr, runErr)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	rotateAuditLog(auditLog.path)
+	f, err := os.OpenFile(auditLog.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec // G304: path is from PAW workspace
+	if err != nil {
+		return
+	}
+	_, _ = f.Write(append(data, '\n'))
+	_ = f.Close()
+}
+
+// CombinedOutput runs a git command in dir and returns its combined output,
+// recording it in the audit log. It is for callers that show git's own output
+// (e.g. colored diffs) instead of going through a Client.
+func CombinedOutput(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	recordAudit(dir, args, start, err, string(output))
+	return output, err
+}
+
+func truncateAuditError(stderr string, err error) string {
+	msg := stderr
+	if msg == "" {
+		msg = err.Error()
+	}
+	if len(msg) > constants.AuditErrorMaxLen {
+		msg = msg[:constants.AuditErrorMaxLen] + "..."
+	}
+	return msg
+}
+
+// rotateAuditLog keeps one backup once the audit log exceeds its size limit.
+func rotateAuditLog(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < constants.AuditLogMaxBytes {
+		return
+	}
+	_ = os.Rename(path, path+".1")
+}
+
+// ReadAuditLog reads audit entries from the log and its backup, oldest first.
+// Malformed lines are skipped.
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p) //nolint:gosec // G304: path is from PAW workspace
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var entry AuditEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+				entries = append(entries, entry)
+			}
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+	}
+	return entries, nil
+}
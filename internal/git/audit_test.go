@@ -0,0 +1,83 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	auditPath := filepath.Join(dir, "audit.jsonl")
+
+	SetAuditLog(auditPath, "end-task", "my-task")
+	defer SetAuditLog("", "", "")
+
+	client := New()
+	_ = client.IsGitRepo(dir) // fails: not a repo
+	if err := runGitCmd(dir, "init").Run(); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+	_ = client.IsGitRepo(dir)
+
+	entries, err := ReadAuditLog(auditPath)
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.Script != "end-task" || first.Task != "my-task" || first.Dir != dir {
+		t.Errorf("unexpected entry context: %+v", first)
+	}
+	if first.ExitCode == 0 || first.Error == "" {
+		t.Errorf("expected failed entry with error, got %+v", first)
+	}
+	if len(first.Args) == 0 || first.Args[0] != "rev-parse" {
+		t.Errorf("Args = %v, want rev-parse ...", first.Args)
+	}
+	if entries[1].ExitCode != 0 {
+		t.Errorf("expected success entry, got exit code %d", entries[1].ExitCode)
+	}
+}
+
+func TestAuditLogHelpers(t *testing.T) {
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	SetAuditLog(auditPath, "sync-with-main", "my-task")
+	defer SetAuditLog("", "", "")
+
+	if err := AddToExcludeFile(gitDir, ".claude"); err != nil {
+		t.Fatalf("AddToExcludeFile() error = %v", err)
+	}
+	if count, err := New().CountCommits(gitDir, "HEAD", "HEAD"); err != nil || count != 0 {
+		t.Fatalf("CountCommits() = %d, %v; want 0", count, err)
+	}
+
+	entries, err := ReadAuditLog(auditPath)
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Args[0] != "rev-parse" || entries[1].Args[0] != "rev-list" {
+		t.Errorf("audit entries = %+v, want rev-parse then rev-list", entries)
+	}
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	dir := t.TempDir()
+	auditPath := filepath.Join(dir, "audit.jsonl")
+
+	SetAuditLog("", "", "")
+	_ = New().IsGitRepo(dir)
+
+	entries, err := ReadAuditLog(auditPath)
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
@@ -4,10 +4,12 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,12 +31,18 @@ type Client interface {
 	GetRepoRoot(dir string) (string, error)
 	GetMainBranch(dir string) string
 	HasRemote(dir, remote string) bool
+	TreeSize(dir, ref string) (size int64, files int, err error)
 
 	// Worktree
 	WorktreeAdd(projectDir, worktreeDir, branch string, createBranch bool) error
+	WorktreeAddFrom(projectDir, worktreeDir, branch, startPoint string) error
+	WorktreeAddDetached(projectDir, worktreeDir, commit string) error // Worktree on a detached HEAD, no branch
 	WorktreeRemove(projectDir, worktreeDir string, force bool) error
 	WorktreePrune(projectDir string) error
+	WorktreeRepair(projectDir string, worktreeDirs ...string) error
 	WorktreeList(projectDir string) ([]Worktree, error)
+	SubmoduleUpdate(dir string) error
+	NestedRepos(dir string) ([]string, error)
 
 	// Branch
 	BranchExists(dir, branch string) bool
@@ -44,12 +52,15 @@ type Client interface {
 	BranchCreateOrphan(dir, branch string) error // Create orphan branch (no parent)
 	GetCurrentBranch(dir string) (string, error)
 	GetHeadCommit(dir string) (string, error)
+	GetBranchHead(dir, branch string) (string, error)
+	ResetBranch(dir, branch, commit string) error
 
 	// Changes
 	HasChanges(dir string) bool
 	HasStagedChanges(dir string) bool
 	HasUntrackedFiles(dir string) bool
 	GetUntrackedFiles(dir string) ([]string, error)
+	StagedFiles(dir string) ([]string, error)
 	StashCreate(dir string) (string, error)
 	StashApply(dir, stashHash string) error
 	StashPush(dir, message string) error
@@ -61,36 +72,64 @@ type Client interface {
 	// Commit
 	Add(dir, path string) error
 	AddAll(dir string) error
+	AddAllExcept(dir string, paths []string) error
 	Commit(dir, message string) error
+	CommitAmend(dir string) error // Add the staged changes to HEAD, keeping its message
 	GetDiffStat(dir string) (string, error)
+	DiffSize(dir, base string) (files, lines int, err error)
+	MergeBase(dir, a, b string) (string, error)
+	DiffPatch(dir, commit string) (string, error)
+	DiffFiles(dir, commit string, paths []string) (string, error)
+	ChangedFiles(dir, commit string) ([]string, error)
+	WorktreeTree(dir string) (string, error)
+	TreeChangedFiles(dir, from, to string) ([]string, error)
+
+	// LFS
+	LFSAvailable(dir string) bool
+	LFSPaths(dir string, paths []string) (map[string]bool, error)
+	LFSTrack(dir, path string) error
 
 	// Remote
 	Push(dir, remote, branch string, setUpstream bool) error
+	PushWithLease(dir, remote, branch, expectedHead string) error
+	RemoteBranchStatus(dir, remote, branch string) (head string, missing int, err error)
 	Fetch(dir, remote string) error
 	Pull(dir string) error
 
 	// Merge
 	Merge(dir, branch string, noFF bool, message string) error
 	MergeSquash(dir, branch, message string) error
+	MergeFastForward(dir, ref string) error // Fails instead of creating a merge commit
 	MergeAbort(dir string) error
 	HasConflicts(dir string) (bool, []string, error)
 	HasOngoingMerge(dir string) bool
+	MergeTree(dir, into, branch string) ([]string, error) // Files that would conflict, without touching the checkout
 	CheckoutOurs(dir, path string) error
 	CheckoutTheirs(dir, path string) error
 	FindMergeCommit(dir, branch, into string) (string, error)
+	FindTaskMergeCommit(dir, taskName, into string) (string, error)
+	TaskMerges(dir, since, into string) ([]TaskMerge, error)
+	GetCommitMessage(dir, commit string) (string, error)
 	RevertCommit(dir, commitHash, message string) error
+	RevertAbort(dir string) error
 
 	// Rebase
 	Rebase(dir, onto string) error
+	RebaseOnto(dir, newBase, upstream string) error
 	RebaseAbort(dir string) error
 	HasOngoingRebase(dir string) bool
 
 	// Status
 	Status(dir string) (string, error)
 	Checkout(dir, target string) error
+	CheckoutDetach(dir string) error // Detach HEAD at the current commit
 
 	// Log
 	GetBranchCommits(dir, branch, baseBranch string, maxCount int) ([]CommitInfo, error)
+	CountCommits(dir, from, to string) (int, error)
+	LatestTag(dir, ref string) (string, error)
+	FileLog(dir, path string, maxCount int) ([]FileCommit, error)                // Commits that changed a file, newest first
+	BlameCommits(dir, path string, startLine, endLine int) ([]FileCommit, error) // Commits that last changed the lines
 
 	// Index
 	UpdateIndexAssumeUnchanged(dir, path string) error
@@ -99,12 +138,27 @@ type Client interface {
 	ResetPath(dir, path string) error
 }
 
+// TaskMerge is a commit that merged a PAW task (it has a PAW-Task trailer).
+type TaskMerge struct {
+	Hash    string
+	Message string
+	Task    string
+}
+
 // CommitInfo represents basic information about a git commit.
 type CommitInfo struct {
 	Hash    string
 	Subject string
 }
 
+// FileCommit is a commit that changed a file.
+type FileCommit struct {
+	Hash    string // Abbreviated hash
+	Date    string // Author date (YYYY-MM-DD)
+	Author  string
+	Subject string
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path   string
@@ -188,7 +242,10 @@ func (c *gitClient) run(dir string, args ...string) error {
 
 	cmd.Stderr = stderr
 
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err := cmd.Run()
+	recordAudit(dir, args, start, err, stderr.String())
+	if err != nil {
 		return fmt.Errorf("%w: %s", err, stderr.String())
 	}
 	return nil
@@ -211,7 +268,10 @@ func (c *gitClient) runOutput(dir string, args ...string) (string, error) {
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err := cmd.Run()
+	recordAudit(dir, args, start, err, stderr.String())
+	if err != nil {
 		return "", fmt.Errorf("%w: %s", err, stderr.String())
 	}
 	return strings.TrimSpace(stdout.String()), nil
@@ -265,6 +325,37 @@ func (c *gitClient) HasRemote(dir, remote string) bool {
 	return false
 }
 
+// TreeSize returns the total size in bytes and the number of files of the tree at ref,
+// i.e. roughly what a checkout of ref writes to disk.
+func (c *gitClient) TreeSize(dir, ref string) (int64, int, error) {
+	if !isValidGitRef(ref) {
+		return 0, 0, fmt.Errorf("invalid ref: %q", ref)
+	}
+	output, err := c.runOutput(dir, "ls-tree", "-r", "-l", ref)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var size int64
+	files := 0
+	for _, line := range strings.Split(output, "\n") {
+		// Format is "<mode> <type> <object> <size>\t<path>"; submodules have size "-"
+		meta, _, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 {
+			continue
+		}
+		files++
+		if n, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			size += n
+		}
+	}
+	return size, files, nil
+}
+
 // Worktree
 
 func (c *gitClient) WorktreeAdd(projectDir, worktreeDir, branch string, createBranch bool) error {
@@ -279,6 +370,18 @@ func (c *gitClient) WorktreeAdd(projectDir, worktreeDir, branch string, createBr
 	return c.run(projectDir, args...)
 }
 
+// WorktreeAddFrom creates a worktree on a new branch starting at startPoint
+// instead of the project's HEAD.
+func (c *gitClient) WorktreeAddFrom(projectDir, worktreeDir, branch, startPoint string) error {
+	return c.run(projectDir, "worktree", "add", "-b", branch, worktreeDir, startPoint)
+}
+
+// WorktreeAddDetached creates a worktree with a detached HEAD at commit,
+// for scratch work that must not hold a branch.
+func (c *gitClient) WorktreeAddDetached(projectDir, worktreeDir, commit string) error {
+	return c.run(projectDir, "worktree", "add", "--detach", worktreeDir, commit)
+}
+
 func (c *gitClient) WorktreeRemove(projectDir, worktreeDir string, force bool) error {
 	args := []string{"worktree", "remove"}
 	if force {
@@ -292,6 +395,12 @@ func (c *gitClient) WorktreePrune(projectDir string) error {
 	return c.run(projectDir, "worktree", "prune")
 }
 
+// WorktreeRepair fixes the links between the repository and worktrees that were moved.
+func (c *gitClient) WorktreeRepair(projectDir string, worktreeDirs ...string) error {
+	args := append([]string{"worktree", "repair"}, worktreeDirs...)
+	return c.run(projectDir, args...)
+}
+
 func (c *gitClient) WorktreeList(projectDir string) ([]Worktree, error) {
 	output, err := c.runOutput(projectDir, "worktree", "list", "--porcelain")
 	if err != nil {
@@ -331,6 +440,32 @@ func (c *gitClient) WorktreeList(projectDir string) ([]Worktree, error) {
 
 // Branch
 
+// SubmoduleUpdate checks out the submodules recorded in dir, recursively.
+func (c *gitClient) SubmoduleUpdate(dir string) error {
+	return c.run(dir, "submodule", "update", "--init", "--recursive")
+}
+
+// NestedRepos returns the untracked directories in dir that are git
+// repositories of their own (not registered as submodules), relative to dir.
+// git lists each of them as a single entry with a trailing slash.
+func (c *gitClient) NestedRepos(dir string) ([]string, error) {
+	output, err := c.runOutput(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	var repos []string
+	for _, line := range strings.Split(output, "\n") {
+		path, ok := strings.CutSuffix(line, "/")
+		if !ok || path == "" {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(dir, path, ".git")); err == nil {
+			repos = append(repos, path)
+		}
+	}
+	return repos, nil
+}
+
 func (c *gitClient) BranchExists(dir, branch string) bool {
 	err := c.run(dir, "rev-parse", "--verify", "refs/heads/"+branch)
 	return err == nil
@@ -388,6 +523,19 @@ func (c *gitClient) GetHeadCommit(dir string) (string, error) {
 	return c.runOutput(dir, "rev-parse", "HEAD")
 }
 
+func (c *gitClient) GetBranchHead(dir, branch string) (string, error) {
+	return c.runOutput(dir, "rev-parse", "--verify", "refs/heads/"+branch)
+}
+
+// ResetBranch moves branch to commit. If the branch is checked out in dir,
+// the working tree is updated with `reset --keep`, which keeps local changes.
+func (c *gitClient) ResetBranch(dir, branch, commit string) error {
+	if current, err := c.GetCurrentBranch(dir); err == nil && current == branch {
+		return c.run(dir, "reset", "--keep", commit)
+	}
+	return c.run(dir, "update-ref", "refs/heads/"+branch, commit)
+}
+
 // Changes
 
 func (c *gitClient) HasChanges(dir string) bool {
@@ -427,6 +575,21 @@ func (c *gitClient) GetUntrackedFiles(dir string) ([]string, error) {
 	return strings.Split(output, "\n"), nil
 }
 
+// StagedFiles returns the files added or modified in the index.
+func (c *gitClient) StagedFiles(dir string) ([]string, error) {
+	output, err := c.runOutput(dir, "diff", "--cached", "--name-only", "--diff-filter=AM", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(output, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
 func (c *gitClient) StashCreate(dir string) (string, error) {
 	return c.runOutput(dir, "stash", "create")
 }
@@ -530,14 +693,236 @@ func (c *gitClient) AddAll(dir string) error {
 	return c.run(dir, "add", "-A")
 }
 
+// AddAllExcept stages all changes except those under the given paths.
+func (c *gitClient) AddAllExcept(dir string, paths []string) error {
+	if len(paths) == 0 {
+		return c.AddAll(dir)
+	}
+	args := []string{"add", "-A", "--", "."}
+	for _, path := range paths {
+		args = append(args, ":(exclude,literal)"+path)
+	}
+	return c.run(dir, args...)
+}
+
 func (c *gitClient) Commit(dir, message string) error {
 	return c.run(dir, "commit", "-m", message)
 }
 
+func (c *gitClient) CommitAmend(dir string) error {
+	return c.run(dir, "commit", "--amend", "--no-edit")
+}
+
 func (c *gitClient) GetDiffStat(dir string) (string, error) {
 	return c.runOutput(dir, "diff", "--cached", "--stat")
 }
 
+// DiffSize returns the files and lines (added plus deleted) changed in dir's
+// working tree since it forked from base. Untracked files count as added,
+// so changes the agent has not committed yet are measured too.
+func (c *gitClient) DiffSize(dir, base string) (files, lines int, err error) {
+	mergeBase, err := c.MergeBase(dir, base, "HEAD")
+	if err != nil {
+		return 0, 0, err
+	}
+	output, err := c.runOutput(dir, "diff", "--numstat", mergeBase)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		files++
+		// Binary files show "-" for both counts
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		lines += added + deleted
+	}
+
+	untracked, err := c.GetUntrackedFiles(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, f := range untracked {
+		files++
+		lines += countLines(filepath.Join(dir, f))
+	}
+	return files, lines, nil
+}
+
+// countLines returns the number of lines in a file, or 0 for binary files
+// (as git diff --numstat counts them) and files that can't be read.
+func countLines(path string) int {
+	f, err := os.Open(path) //nolint:gosec // G304: path is an untracked file in the worktree
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, 32*1024)
+	lines := 0
+	first := true
+	var last byte
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			// git treats files with a NUL byte near the start as binary
+			if first && bytes.IndexByte(buf[:min(n, 8000)], 0) >= 0 {
+				return 0
+			}
+			first = false
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if err != nil {
+			break
+		}
+	}
+	if !first && last != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// MergeBase returns the best common ancestor of a and b.
+func (c *gitClient) MergeBase(dir, a, b string) (string, error) {
+	return c.runOutput(dir, "merge-base", a, b)
+}
+
+// DiffPatch returns the working tree's changes since commit as a unified
+// diff without context lines.
+func (c *gitClient) DiffPatch(dir, commit string) (string, error) {
+	return c.runOutput(dir, "diff", "--no-color", "--no-ext-diff", "-U0", commit)
+}
+
+// DiffFiles returns the diff of paths in the working tree against commit.
+func (c *gitClient) DiffFiles(dir, commit string, paths []string) (string, error) {
+	args := append([]string{"diff", "--no-color", "--no-ext-diff", commit, "--"}, paths...)
+	return c.runOutput(dir, args...)
+}
+
+// ChangedFiles returns the files changed in the working tree since commit,
+// excluding deleted files.
+func (c *gitClient) ChangedFiles(dir, commit string) ([]string, error) {
+	output, err := c.runOutput(dir, "diff", "--name-only", "--diff-filter=d", commit)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// WorktreeTree returns the tree object of dir's working tree as it is now,
+// tracked and untracked (not ignored) files alike, without touching the index
+// or HEAD. Two calls return the same hash only if no file changed.
+func (c *gitClient) WorktreeTree(dir string) (string, error) {
+	indexPath, err := c.runOutput(dir, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp("", "paw-index-*")
+	if err != nil {
+		return "", err
+	}
+	tmpIndex := tmp.Name()
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmpIndex) }()
+
+	// Start from a copy of the real index so unchanged files aren't re-hashed
+	if data, err := os.ReadFile(indexPath); err == nil { //nolint:gosec // G304: path is from git rev-parse
+		if err := os.WriteFile(tmpIndex, data, 0600); err != nil {
+			return "", err
+		}
+	} else {
+		_ = os.Remove(tmpIndex)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	for _, args := range [][]string{{"add", "-A"}, {"write-tree"}} {
+		cmd := c.cmd(ctx, dir, args...)
+		cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+tmpIndex)
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		if args[0] == "write-tree" {
+			return strings.TrimSpace(string(output)), nil
+		}
+	}
+	return "", nil
+}
+
+// TreeChangedFiles returns the paths that differ between two trees or commits.
+func (c *gitClient) TreeChangedFiles(dir, from, to string) ([]string, error) {
+	output, err := c.runOutput(dir, "diff", "--name-only", "--no-renames", from, to)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// LFS
+
+// LFSAvailable reports whether git-lfs is installed.
+func (c *gitClient) LFSAvailable(dir string) bool {
+	return c.run(dir, "lfs", "version") == nil
+}
+
+// LFSPaths returns which of paths match an LFS pattern (filter=lfs) in the
+// repository's attributes.
+func (c *gitClient) LFSPaths(dir string, paths []string) (map[string]bool, error) {
+	matches := make(map[string]bool)
+	if len(paths) == 0 {
+		return matches, nil
+	}
+	// Paths go through stdin so any number of them takes a single call
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	args := []string{"check-attr", "--stdin", "-z", "filter"}
+	cmd := c.cmd(ctx, dir, args...)
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	start := time.Now()
+	out, err := cmd.Output()
+	recordAudit(dir, args, start, err, stderr.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	output := string(out)
+	// Records are path NUL attribute NUL value NUL
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+2] == "lfs" {
+			matches[fields[i]] = true
+		}
+	}
+	return matches, nil
+}
+
+// LFSTrack tracks a single file with git-lfs and restages it through the
+// LFS filter, together with the updated .gitattributes.
+func (c *gitClient) LFSTrack(dir, path string) error {
+	if err := c.run(dir, "lfs", "track", "--filename", "--", path); err != nil {
+		return err
+	}
+	if err := c.run(dir, "add", "--", ".gitattributes"); err != nil {
+		return err
+	}
+	if err := c.run(dir, "rm", "--cached", "-q", "--", path); err != nil {
+		return err
+	}
+	return c.run(dir, "add", "--", path)
+}
+
 // Remote
 
 func (c *gitClient) Push(dir, remote, branch string, setUpstream bool) error {
@@ -546,15 +931,53 @@ func (c *gitClient) Push(dir, remote, branch string, setUpstream bool) error {
 		args = append(args, "-u")
 	}
 	args = append(args, remote, branch)
-	return c.run(dir, args...)
+	return c.runNetwork(dir, args...)
+}
+
+// PushWithLease pushes branch and sets its upstream, overwriting the remote branch
+// only if it is still at expectedHead (empty means it must not exist yet).
+func (c *gitClient) PushWithLease(dir, remote, branch, expectedHead string) error {
+	return c.runNetwork(dir, "push", "-u", "--force-with-lease=refs/heads/"+branch+":"+expectedHead, remote, branch)
+}
+
+// RemoteBranchStatus fetches the remote branch and returns its head and the number
+// of its commits that are not in the local branch. head is empty if the remote
+// branch doesn't exist.
+func (c *gitClient) RemoteBranchStatus(dir, remote, branch string) (string, int, error) {
+	if !isValidGitRef(branch) {
+		return "", 0, fmt.Errorf("invalid branch name: %q", branch)
+	}
+
+	output, err := c.runNetworkOutput(dir, "ls-remote", "--heads", remote, "refs/heads/"+branch)
+	if err != nil {
+		return "", 0, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", 0, nil
+	}
+	head := fields[0]
+
+	if err := c.runNetwork(dir, "fetch", remote, "refs/heads/"+branch); err != nil {
+		return "", 0, err
+	}
+	count, err := c.runOutput(dir, "rev-list", "--count", "refs/heads/"+branch+".."+head)
+	if err != nil {
+		return "", 0, err
+	}
+	missing, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil {
+		return "", 0, fmt.Errorf("unexpected rev-list output: %q", count)
+	}
+	return head, missing, nil
 }
 
 func (c *gitClient) Fetch(dir, remote string) error {
-	return c.run(dir, "fetch", remote)
+	return c.runNetwork(dir, "fetch", remote)
 }
 
 func (c *gitClient) Pull(dir string) error {
-	return c.run(dir, "pull")
+	return c.runNetwork(dir, "pull")
 }
 
 // Merge
@@ -584,7 +1007,18 @@ func (c *gitClient) MergeSquash(dir, branch, message string) error {
 	return c.Commit(dir, message)
 }
 
+// MergeFastForward moves the current branch to ref if it is a descendant,
+// and fails without touching the checkout otherwise.
+func (c *gitClient) MergeFastForward(dir, ref string) error {
+	return c.run(dir, "merge", "--ff-only", ref)
+}
+
+// MergeAbort aborts a conflicted merge. A squash merge leaves no MERGE_HEAD,
+// so its conflicts are reset with git reset --merge instead.
 func (c *gitClient) MergeAbort(dir string) error {
+	if !c.HasOngoingMerge(dir) {
+		return c.run(dir, "reset", "--merge")
+	}
 	return c.run(dir, "merge", "--abort")
 }
 
@@ -620,6 +1054,47 @@ func (c *gitClient) HasOngoingMerge(dir string) bool {
 	return err == nil
 }
 
+// MergeTree merges branch into into in memory (git merge-tree) and returns
+// the files that would conflict. The working tree and index are not touched.
+// It needs git 2.38 or later.
+func (c *gitClient) MergeTree(dir, into, branch string) ([]string, error) {
+	if !isValidGitRef(into) || !isValidGitRef(branch) {
+		return nil, fmt.Errorf("invalid branch name: %q or %q", into, branch)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	args := []string{"merge-tree", "--write-tree", "--name-only", "--no-messages", into, branch}
+	cmd := c.cmd(ctx, dir, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	recordAudit(dir, args, start, err, stderr.String())
+	if err == nil {
+		return nil, nil
+	}
+	// Exit code 1 means the merge has conflicts; anything else is a failure
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+
+	// The first line is the resulting tree, followed by the conflicted files
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	seen := make(map[string]bool, len(lines))
+	var files []string
+	for _, line := range lines[1:] {
+		if line = strings.TrimSpace(line); line != "" && !seen[line] {
+			seen[line] = true
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 func (c *gitClient) CheckoutOurs(dir, path string) error {
 	return c.run(dir, "checkout", "--ours", path)
 }
@@ -660,13 +1135,97 @@ func (c *gitClient) FindMergeCommit(dir, branch, into string) (string, error) {
 	return strings.TrimSpace(output), nil
 }
 
+// FindTaskMergeCommit finds the latest commit on into that PAW created when
+// merging the task (identified by its PAW-Task trailer). Falls back to
+// FindMergeCommit for merges made without the trailer.
+func (c *gitClient) FindTaskMergeCommit(dir, taskName, into string) (string, error) {
+	if !isValidGitRef(taskName) {
+		return "", fmt.Errorf("invalid task name: %q", taskName)
+	}
+	if !isValidGitRef(into) {
+		return "", fmt.Errorf("invalid target branch name: %q", into)
+	}
+
+	output, err := c.runOutput(dir, "log", into, "--grep=^"+TaskTrailerKey+": "+taskName+"$", "-1", "--format=%H")
+	if err != nil {
+		return "", err
+	}
+	if output != "" {
+		return strings.TrimSpace(output), nil
+	}
+	return c.FindMergeCommit(dir, taskName, into)
+}
+
+// TaskMerges returns the task merge commits on into since the since ref
+// (all of them if since is empty), newest first.
+func (c *gitClient) TaskMerges(dir, since, into string) ([]TaskMerge, error) {
+	if !isValidGitRef(into) || (since != "" && !isValidGitRef(since)) {
+		return nil, fmt.Errorf("invalid range: %q..%q", since, into)
+	}
+	rangeExpr := into
+	if since != "" {
+		rangeExpr = since + ".." + into
+	}
+	output, err := c.runOutput(dir, "log", "--grep=^"+TaskTrailerKey+": ", "--format=%H%x1f%B%x1e", rangeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	var merges []TaskMerge
+	for _, record := range strings.Split(output, "\x1e") {
+		hash, message, ok := strings.Cut(strings.TrimSpace(record), "\x1f")
+		if !ok {
+			continue
+		}
+		if taskName := ParseTaskTrailer(message); taskName != "" {
+			merges = append(merges, TaskMerge{Hash: hash, Message: strings.TrimSpace(message), Task: taskName})
+		}
+	}
+	return merges, nil
+}
+
+// LatestTag returns the most recent tag reachable from ref, or "" if there
+// is none.
+func (c *gitClient) LatestTag(dir, ref string) (string, error) {
+	if !isValidGitRef(ref) {
+		return "", fmt.Errorf("invalid ref: %q", ref)
+	}
+	if output, err := c.runOutput(dir, "tag", "--merged", ref); err != nil || output == "" {
+		return "", err
+	}
+	return c.runOutput(dir, "describe", "--tags", "--abbrev=0", ref)
+}
+
+// GetCommitMessage returns the full message of a commit.
+func (c *gitClient) GetCommitMessage(dir, commit string) (string, error) {
+	if !isValidGitRef(commit) {
+		return "", fmt.Errorf("invalid commit: %q", commit)
+	}
+	return c.runOutput(dir, "log", "-1", "--format=%B", commit)
+}
+
 // RevertCommit creates a revert commit for the given commit hash.
+// Merge commits are reverted against their first parent (mainline).
 func (c *gitClient) RevertCommit(dir, commitHash, message string) error {
-	args := []string{"revert", "--no-edit", "-m", "1", commitHash}
-	if message != "" {
-		args = []string{"revert", "-m", "1", "--no-edit", commitHash}
+	args := []string{"revert", "--no-edit"}
+	if c.isMergeCommit(dir, commitHash) {
+		args = append(args, "-m", "1")
 	}
-	return c.run(dir, args...)
+	return c.run(dir, append(args, commitHash)...)
+}
+
+// RevertAbort aborts an ongoing revert operation.
+func (c *gitClient) RevertAbort(dir string) error {
+	return c.run(dir, "revert", "--abort")
+}
+
+// isMergeCommit reports whether the commit has more than one parent.
+func (c *gitClient) isMergeCommit(dir, commit string) bool {
+	output, err := c.runOutput(dir, "rev-list", "--parents", "-n", "1", commit)
+	if err != nil {
+		return false
+	}
+	return len(strings.Fields(output)) > 2
 }
 
 // Rebase
@@ -676,6 +1235,12 @@ func (c *gitClient) Rebase(dir, onto string) error {
 	return c.run(dir, "rebase", onto)
 }
 
+// RebaseOnto replays the current branch's commits after upstream onto
+// newBase, stashing local changes around the rebase.
+func (c *gitClient) RebaseOnto(dir, newBase, upstream string) error {
+	return c.run(dir, "rebase", "--autostash", "--onto", newBase, upstream)
+}
+
 // RebaseAbort aborts an ongoing rebase operation.
 func (c *gitClient) RebaseAbort(dir string) error {
 	return c.run(dir, "rebase", "--abort")
@@ -719,6 +1284,21 @@ func (c *gitClient) Checkout(dir, target string) error {
 	return c.run(dir, "checkout", target)
 }
 
+// CheckoutDetach detaches HEAD at the current commit, releasing the branch
+// so it can be deleted while the checkout stays.
+func (c *gitClient) CheckoutDetach(dir string) error {
+	return c.run(dir, "checkout", "--detach")
+}
+
+// CountCommits returns the number of commits in to that are not in from.
+func (c *gitClient) CountCommits(dir, from, to string) (int, error) {
+	output, err := c.runOutput(dir, "rev-list", "--count", from+".."+to)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(output)
+}
+
 // GetBranchCommits returns commit information for commits unique to a branch.
 // It returns commits that are in 'branch' but not in 'baseBranch'.
 func (c *gitClient) GetBranchCommits(dir, branch, baseBranch string, maxCount int) ([]CommitInfo, error) {
@@ -768,6 +1348,80 @@ func (c *gitClient) GetBranchCommits(dir, branch, baseBranch string, maxCount in
 	return commits, nil
 }
 
+// FileLog returns the commits that changed a file, newest first, following
+// renames.
+func (c *gitClient) FileLog(dir, path string, maxCount int) ([]FileCommit, error) {
+	args := []string{"log", "--follow", "--date=short", "--format=%h%x1f%ad%x1f%an%x1f%s"}
+	if maxCount > 0 {
+		args = append(args, "-n"+strconv.Itoa(maxCount))
+	}
+	output, err := c.runOutput(dir, append(args, "--", path)...)
+	if err != nil || output == "" {
+		return nil, err
+	}
+
+	lines := strings.Split(output, "\n")
+	commits := make([]FileCommit, 0, len(lines))
+	for _, line := range lines {
+		parts := strings.SplitN(line, "\x1f", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		commits = append(commits, FileCommit{Hash: parts[0], Date: parts[1], Author: parts[2], Subject: parts[3]})
+	}
+	return commits, nil
+}
+
+// BlameCommits returns the commits that last changed lines startLine to
+// endLine (1-based, inclusive) of a file, in the order their lines appear.
+// Uncommitted lines are left out.
+func (c *gitClient) BlameCommits(dir, path string, startLine, endLine int) ([]FileCommit, error) {
+	if startLine < 1 || endLine < startLine {
+		return nil, fmt.Errorf("invalid line range %d-%d", startLine, endLine)
+	}
+	output, err := c.runOutput(dir, "blame", "--porcelain", "-L", strconv.Itoa(startLine)+","+strconv.Itoa(endLine), "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []FileCommit
+	index := make(map[string]int)
+	current := -1
+	for _, line := range strings.Split(output, "\n") {
+		// Header: "<sha> <orig-line> <final-line> [<group-lines>]"; the
+		// commit's details follow only its first header.
+		if fields := strings.Fields(line); len(fields) >= 3 && len(fields[0]) == 40 && !strings.HasPrefix(line, "\t") {
+			sha := fields[0]
+			if strings.Trim(sha, "0") == "" {
+				current = -1
+				continue
+			}
+			i, ok := index[sha]
+			if !ok {
+				i = len(commits)
+				index[sha] = i
+				commits = append(commits, FileCommit{Hash: sha[:7]})
+			}
+			current = i
+			continue
+		}
+		if current < 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "author "):
+			commits[current].Author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			if sec, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				commits[current].Date = time.Unix(sec, 0).Format("2006-01-02")
+			}
+		case strings.HasPrefix(line, "summary "):
+			commits[current].Subject = strings.TrimPrefix(line, "summary ")
+		}
+	}
+	return commits, nil
+}
+
 // GenerateMergeCommitMessage generates a well-formatted merge commit message.
 // It includes the inferred commit type, formatted subject, and commit history.
 func GenerateMergeCommitMessage(taskName string, commits []CommitInfo) string {
@@ -792,9 +1446,48 @@ func GenerateMergeCommitMessage(taskName string, commits []CommitInfo) string {
 		}
 	}
 
+	// Trailer lets PAW find this merge later (paw undo-merge)
+	msg.WriteString(fmt.Sprintf("\n%s: %s\n", TaskTrailerKey, taskName))
+
 	return msg.String()
 }
 
+// TaskTrailerKey is the commit trailer PAW adds to the commits it merges.
+const TaskTrailerKey = "PAW-Task"
+
+// ParseTaskTrailer returns the task name from a commit message's PAW-Task trailer, or "".
+func ParseTaskTrailer(message string) string {
+	lines := strings.Split(message, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(lines[i]), TaskTrailerKey+":"); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// protectedBranchRejections are messages hosts print when a push to a protected branch is rejected.
+var protectedBranchRejections = []string{
+	"protected branch",             // GitHub (GH006), GitLab
+	"can only be modified through", // Bitbucket ("... through pull requests")
+	"must use a pull request",      // Azure DevOps
+}
+
+// IsProtectedBranchRejection reports whether a push error means the remote
+// branch is protected against direct pushes.
+func IsProtectedBranchRejection(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range protectedBranchRejections {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // CopyUntrackedFiles copies untracked files from source to destination.
 func CopyUntrackedFiles(files []string, srcDir, dstDir string) error {
 	for _, file := range files {
@@ -862,13 +1555,11 @@ func (c *gitClient) ResetPath(dir, path string) error {
 // This provides worktree-specific exclusion without modifying .gitignore.
 func AddToExcludeFile(worktreeDir, pattern string) error {
 	// In a worktree, .git is a file (not a directory), so we need to resolve the actual git directory
-	cmd := exec.Command("git", "-C", worktreeDir, "rev-parse", "--git-dir")
-	output, err := cmd.Output()
+	gitDir, err := New().(*gitClient).runOutput(worktreeDir, "rev-parse", "--git-dir")
 	if err != nil {
 		return fmt.Errorf("failed to get git directory: %w", err)
 	}
 
-	gitDir := strings.TrimSpace(string(output))
 	// Make gitDir absolute if it's relative
 	if !filepath.IsAbs(gitDir) {
 		gitDir = filepath.Join(worktreeDir, gitDir)
@@ -1,9 +1,13 @@
 package git
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -269,6 +273,7 @@ func TestGenerateMergeCommitMessage(t *testing.T) {
 			commits:  nil,
 			wantContains: []string{
 				"fix: kanban drag select",
+				"PAW-Task: fix-kanban-drag-select",
 			},
 			wantNotContains: []string{
 				"Changes:",
@@ -589,6 +594,193 @@ func TestAddAndCommit(t *testing.T) {
 	if hash == "" {
 		t.Error("No commit hash after commit")
 	}
+
+	// Test CommitAmend
+	_ = os.WriteFile(filepath.Join(gitDir, "more.txt"), []byte("more"), 0644)
+	if err := client.Add(gitDir, "more.txt"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := client.CommitAmend(gitDir); err != nil {
+		t.Fatalf("CommitAmend() error = %v", err)
+	}
+	files, err := runGitCmd(gitDir, "show", "--name-only", "--format=%s", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("git show error = %v", err)
+	}
+	if got := strings.Fields(string(files)); strings.Join(got, " ") != "Add new file more.txt new.txt" {
+		t.Errorf("amended HEAD = %q, want message and both files", got)
+	}
+}
+
+func TestNestedReposAndAddAllExcept(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+
+	// A repository cloned inside the project, plus an ordinary new file
+	nestedDir := filepath.Join(gitDir, "vendor", "lib")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := runGitCmd(nestedDir, "init").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init nested repo: %v\nOutput: %s", err, output)
+	}
+	_ = os.WriteFile(filepath.Join(nestedDir, "lib.go"), []byte("package lib"), 0644)
+	_ = os.WriteFile(filepath.Join(gitDir, "new.txt"), []byte("content"), 0644)
+
+	repos, err := client.NestedRepos(gitDir)
+	if err != nil {
+		t.Fatalf("NestedRepos() error = %v", err)
+	}
+	if len(repos) != 1 || repos[0] != "vendor/lib" {
+		t.Fatalf("NestedRepos() = %v, want [vendor/lib]", repos)
+	}
+
+	if err := client.AddAllExcept(gitDir, repos); err != nil {
+		t.Fatalf("AddAllExcept() error = %v", err)
+	}
+	staged, err := runGitCmd(gitDir, "diff", "--cached", "--name-only").Output()
+	if err != nil {
+		t.Fatalf("git diff --cached error = %v", err)
+	}
+	if got := strings.TrimSpace(string(staged)); got != "new.txt" {
+		t.Errorf("staged = %q, want %q", got, "new.txt")
+	}
+}
+
+func TestStagedFilesAndLFSPaths(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, ".gitattributes", "*.bin filter=lfs diff=lfs merge=lfs -text\n", "Initial commit")
+
+	_ = os.WriteFile(filepath.Join(gitDir, "model.bin"), []byte("weights"), 0644)
+	_ = os.WriteFile(filepath.Join(gitDir, "main.go"), []byte("package main"), 0644)
+	if err := runGitCmd(gitDir, "add", "model.bin", "main.go").Run(); err != nil {
+		t.Fatalf("Failed to add files: %v", err)
+	}
+
+	staged, err := client.StagedFiles(gitDir)
+	if err != nil {
+		t.Fatalf("StagedFiles() error = %v", err)
+	}
+	if len(staged) != 2 {
+		t.Fatalf("StagedFiles() = %v, want 2 files", staged)
+	}
+
+	lfs, err := client.LFSPaths(gitDir, staged)
+	if err != nil {
+		t.Fatalf("LFSPaths() error = %v", err)
+	}
+	if !lfs["model.bin"] || lfs["main.go"] {
+		t.Errorf("LFSPaths() = %v, want only model.bin", lfs)
+	}
+
+	// More paths than fit on a command line, some with spaces
+	var many []string
+	for i := 0; i < 20000; i++ {
+		many = append(many, fmt.Sprintf("data set/weights-%05d.bin", i))
+	}
+	lfs, err = client.LFSPaths(gitDir, many)
+	if err != nil {
+		t.Fatalf("LFSPaths(many) error = %v", err)
+	}
+	if len(lfs) != len(many) || !lfs[many[len(many)-1]] {
+		t.Errorf("LFSPaths(many) matched %d of %d paths", len(lfs), len(many))
+	}
+}
+
+func TestDiffSize(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "one\ntwo\n", "Initial commit")
+	base, err := client.GetCurrentBranch(gitDir)
+	if err != nil {
+		t.Fatalf("GetCurrentBranch() error = %v", err)
+	}
+
+	if err := client.BranchCreate(gitDir, "task", base); err != nil {
+		t.Fatalf("BranchCreate() error = %v", err)
+	}
+	if err := client.Checkout(gitDir, "task"); err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	createCommit(t, gitDir, "a.txt", "1\n2\n3\n", "Add a")
+	// Uncommitted changes count too
+	_ = os.WriteFile(filepath.Join(gitDir, "README.md"), []byte("one\n"), 0644)
+
+	files, lines, err := client.DiffSize(gitDir, base)
+	if err != nil {
+		t.Fatalf("DiffSize() error = %v", err)
+	}
+	if files != 2 || lines != 4 {
+		t.Errorf("DiffSize() = %d files, %d lines; want 2, 4", files, lines)
+	}
+}
+
+func TestDiffSize_CountsUntrackedFiles(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "one\n", "Initial commit")
+	base, err := client.GetCurrentBranch(gitDir)
+	if err != nil {
+		t.Fatalf("GetCurrentBranch() error = %v", err)
+	}
+	if err := client.BranchCreate(gitDir, "task", base); err != nil {
+		t.Fatalf("BranchCreate() error = %v", err)
+	}
+	if err := client.Checkout(gitDir, "task"); err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+
+	// A task that only adds new files, none of them committed yet
+	large := strings.Repeat("generated line\n", 5000) + "no trailing newline"
+	_ = os.WriteFile(filepath.Join(gitDir, "generated.txt"), []byte(large), 0644)
+	_ = os.WriteFile(filepath.Join(gitDir, "image.bin"), []byte{0x89, 'P', 'N', 'G', 0, 1, '\n'}, 0644)
+
+	files, lines, err := client.DiffSize(gitDir, base)
+	if err != nil {
+		t.Fatalf("DiffSize() error = %v", err)
+	}
+	if files != 2 || lines != 5001 {
+		t.Errorf("DiffSize() = %d files, %d lines; want 2, 5001", files, lines)
+	}
+}
+
+func TestWorktreeTree(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "one\n", "Initial commit")
+
+	before, err := client.WorktreeTree(gitDir)
+	if err != nil {
+		t.Fatalf("WorktreeTree() error = %v", err)
+	}
+	out, _ := runGitCmd(gitDir, "rev-parse", "HEAD^{tree}").Output()
+	if head := strings.TrimSpace(string(out)); before != head {
+		t.Errorf("WorktreeTree() of a clean worktree = %q, want HEAD tree %q", before, head)
+	}
+
+	// Untracked and modified files change the tree; the index does not
+	_ = os.WriteFile(filepath.Join(gitDir, "new.txt"), []byte("new\n"), 0644)
+	_ = os.WriteFile(filepath.Join(gitDir, "README.md"), []byte("two\n"), 0644)
+	after, err := client.WorktreeTree(gitDir)
+	if err != nil {
+		t.Fatalf("WorktreeTree() error = %v", err)
+	}
+	if after == before {
+		t.Fatal("WorktreeTree() did not change after editing files")
+	}
+	if untracked, _ := client.GetUntrackedFiles(gitDir); len(untracked) != 1 {
+		t.Errorf("WorktreeTree() touched the index: untracked = %v", untracked)
+	}
+
+	files, err := client.TreeChangedFiles(gitDir, before, after)
+	if err != nil {
+		t.Fatalf("TreeChangedFiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"README.md", "new.txt"}) {
+		t.Errorf("TreeChangedFiles() = %v, want [README.md new.txt]", files)
+	}
 }
 
 func TestAddAll(t *testing.T) {
@@ -702,6 +894,90 @@ func TestBranchMerged(t *testing.T) {
 	}
 }
 
+func TestFindTaskMergeCommitAndRevert(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	mainBranch, _ := client.GetCurrentBranch(gitDir)
+
+	_ = client.BranchCreate(gitDir, "add-feature", "")
+	_ = client.Checkout(gitDir, "add-feature")
+	createCommit(t, gitDir, "feature.txt", "feature content", "Add feature")
+	_ = client.Checkout(gitDir, mainBranch)
+
+	msg := GenerateMergeCommitMessage("add-feature", nil)
+	if err := client.MergeSquash(gitDir, "add-feature", msg); err != nil {
+		t.Fatalf("MergeSquash() error = %v", err)
+	}
+	head, _ := client.GetHeadCommit(gitDir)
+
+	commit, err := client.FindTaskMergeCommit(gitDir, "add-feature", mainBranch)
+	if err != nil {
+		t.Fatalf("FindTaskMergeCommit() error = %v", err)
+	}
+	if commit != head {
+		t.Errorf("FindTaskMergeCommit() = %q, want %q", commit, head)
+	}
+
+	message, err := client.GetCommitMessage(gitDir, commit)
+	if err != nil {
+		t.Fatalf("GetCommitMessage() error = %v", err)
+	}
+	if got := ParseTaskTrailer(message); got != "add-feature" {
+		t.Errorf("ParseTaskTrailer() = %q, want %q", got, "add-feature")
+	}
+
+	// Squash commits have a single parent, so they are reverted without -m
+	if err := client.RevertCommit(gitDir, commit, ""); err != nil {
+		t.Fatalf("RevertCommit() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "feature.txt")); !os.IsNotExist(err) {
+		t.Error("feature.txt should be removed by the revert")
+	}
+}
+
+func TestTaskMergesAndLatestTag(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	mainBranch, _ := client.GetCurrentBranch(gitDir)
+	if tag, err := client.LatestTag(gitDir, mainBranch); err != nil || tag != "" {
+		t.Errorf("LatestTag() without tags = %q, %v; want empty", tag, err)
+	}
+	createCommit(t, gitDir, "a.txt", "a", GenerateMergeCommitMessage("add-a", nil))
+	if err := runGitCmd(gitDir, "tag", "v1.0.0").Run(); err != nil {
+		t.Fatalf("git tag error = %v", err)
+	}
+	createCommit(t, gitDir, "b.txt", "b", GenerateMergeCommitMessage("fix-b", nil))
+	createCommit(t, gitDir, "c.txt", "c", "Manual commit")
+
+	tag, err := client.LatestTag(gitDir, mainBranch)
+	if err != nil || tag != "v1.0.0" {
+		t.Fatalf("LatestTag() = %q, %v; want v1.0.0", tag, err)
+	}
+	merges, err := client.TaskMerges(gitDir, tag, mainBranch)
+	if err != nil {
+		t.Fatalf("TaskMerges() error = %v", err)
+	}
+	if len(merges) != 1 || merges[0].Task != "fix-b" || !strings.HasPrefix(merges[0].Message, "fix: ") {
+		t.Errorf("TaskMerges() = %+v, want only fix-b", merges)
+	}
+	if all, _ := client.TaskMerges(gitDir, "", mainBranch); len(all) != 2 {
+		t.Errorf("TaskMerges(all) = %+v, want 2", all)
+	}
+}
+
+func TestParseTaskTrailer(t *testing.T) {
+	if got := ParseTaskTrailer("feat: thing\n\nChanges:\n- x\n\nPAW-Task: add-thing\n"); got != "add-thing" {
+		t.Errorf("ParseTaskTrailer() = %q, want %q", got, "add-thing")
+	}
+	if got := ParseTaskTrailer("fix: manual commit"); got != "" {
+		t.Errorf("ParseTaskTrailer() = %q, want empty", got)
+	}
+}
+
 func TestGetBranchCommits(t *testing.T) {
 	client := New()
 	gitDir := setupGitRepo(t)
@@ -736,6 +1012,49 @@ func TestGetBranchCommits(t *testing.T) {
 	}
 }
 
+func TestFileLogAndBlameCommits(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	createCommit(t, gitDir, "main.go", "a\nb\nc\n", "Add main")
+	createCommit(t, gitDir, "main.go", "a\nB\nc\n", "Change second line")
+
+	commits, err := client.FileLog(gitDir, "main.go", 0)
+	if err != nil {
+		t.Fatalf("FileLog() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "Change second line" || commits[1].Subject != "Add main" {
+		t.Fatalf("FileLog() = %+v, want the two main.go commits newest first", commits)
+	}
+	if commits[0].Hash == "" || commits[0].Date == "" || commits[0].Author == "" {
+		t.Errorf("FileLog()[0] = %+v, want hash, date, and author", commits[0])
+	}
+	if limited, _ := client.FileLog(gitDir, "main.go", 1); len(limited) != 1 {
+		t.Errorf("FileLog(maxCount=1) returned %d commits, want 1", len(limited))
+	}
+
+	// Line 2 was changed last; lines 1 and 3 come from the first commit
+	blamed, err := client.BlameCommits(gitDir, "main.go", 1, 3)
+	if err != nil {
+		t.Fatalf("BlameCommits() error = %v", err)
+	}
+	if len(blamed) != 2 || blamed[0].Subject != "Add main" || blamed[1].Subject != "Change second line" {
+		t.Fatalf("BlameCommits() = %+v, want Add main then Change second line", blamed)
+	}
+	if blamed[1].Hash != commits[0].Hash[:7] || blamed[1].Date != commits[0].Date {
+		t.Errorf("BlameCommits()[1] = %+v, want hash %s and date %s", blamed[1], commits[0].Hash[:7], commits[0].Date)
+	}
+
+	// Uncommitted lines are left out
+	if err := os.WriteFile(filepath.Join(gitDir, "main.go"), []byte("x\nB\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if blamed, _ := client.BlameCommits(gitDir, "main.go", 1, 1); len(blamed) != 0 {
+		t.Errorf("BlameCommits() of an uncommitted line = %+v, want none", blamed)
+	}
+}
+
 func TestHasOngoingMerge(t *testing.T) {
 	client := New()
 	gitDir := setupGitRepo(t)
@@ -1117,3 +1436,289 @@ func TestIsValidGitRef(t *testing.T) {
 		})
 	}
 }
+
+func TestWorktreeRepair(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+
+	worktreeDir := filepath.Join(t.TempDir(), "old", "worktree")
+	if err := client.WorktreeAdd(gitDir, worktreeDir, "moved-task", true); err != nil {
+		t.Fatalf("WorktreeAdd() error = %v", err)
+	}
+
+	movedDir := filepath.Join(t.TempDir(), "new")
+	if err := os.Rename(filepath.Dir(worktreeDir), movedDir); err != nil {
+		t.Fatal(err)
+	}
+	movedWorktree := filepath.Join(movedDir, "worktree")
+
+	if err := client.WorktreeRepair(gitDir, movedWorktree); err != nil {
+		t.Fatalf("WorktreeRepair() error = %v", err)
+	}
+	if branch, err := client.GetCurrentBranch(movedWorktree); err != nil || branch != "moved-task" {
+		t.Errorf("GetCurrentBranch(moved worktree) = %q, %v; want moved-task", branch, err)
+	}
+
+	worktrees, err := client.WorktreeList(gitDir)
+	if err != nil {
+		t.Fatalf("WorktreeList() error = %v", err)
+	}
+	found := false
+	for _, wt := range worktrees {
+		if resolved, _ := filepath.EvalSymlinks(wt.Path); resolved == movedWorktree || wt.Path == movedWorktree {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("WorktreeList() = %+v, want moved worktree %s", worktrees, movedWorktree)
+	}
+}
+
+func TestWorktreeAddFromAndRebaseOnto(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	mainBranch, _ := client.GetCurrentBranch(gitDir)
+
+	parentDir := filepath.Join(t.TempDir(), "parent")
+	if err := client.WorktreeAdd(gitDir, parentDir, "parent", true); err != nil {
+		t.Fatalf("WorktreeAdd() error = %v", err)
+	}
+	createCommit(t, parentDir, "parent.txt", "parent", "Parent change")
+
+	childDir := filepath.Join(t.TempDir(), "child")
+	if err := client.WorktreeAddFrom(gitDir, childDir, "child", "parent"); err != nil {
+		t.Fatalf("WorktreeAddFrom() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(childDir, "parent.txt")); err != nil {
+		t.Fatalf("child worktree is missing the parent's commit: %v", err)
+	}
+	createCommit(t, childDir, "child.txt", "child", "Child change")
+
+	// Squash-merge the parent, as end-task does
+	createCommit(t, gitDir, "parent.txt", "parent", "Squashed parent")
+	upstream, err := client.MergeBase(childDir, "HEAD", "parent")
+	if err != nil {
+		t.Fatalf("MergeBase() error = %v", err)
+	}
+	if err := client.RebaseOnto(childDir, mainBranch, upstream); err != nil {
+		t.Fatalf("RebaseOnto() error = %v", err)
+	}
+
+	output, err := runGitCmd(childDir, "log", "--format=%s", mainBranch+"..HEAD").Output()
+	if err != nil {
+		t.Fatalf("git log error = %v", err)
+	}
+	if got := strings.TrimSpace(string(output)); got != "Child change" {
+		t.Errorf("commits after rebase = %q, want only %q", got, "Child change")
+	}
+}
+
+func TestPushWithLeaseAndRemoteBranchStatus(t *testing.T) {
+	client := New()
+	remoteDir := t.TempDir()
+	if output, err := runGitCmd(remoteDir, "init", "--bare").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init bare repo: %v\nOutput: %s", err, output)
+	}
+
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	if err := runGitCmd(gitDir, "remote", "add", "origin", remoteDir).Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.BranchCreate(gitDir, "task", "HEAD"); err != nil {
+		t.Fatalf("BranchCreate() error = %v", err)
+	}
+
+	head, missing, err := client.RemoteBranchStatus(gitDir, "origin", "task")
+	if err != nil || head != "" || missing != 0 {
+		t.Fatalf("RemoteBranchStatus(absent) = %q, %d, %v; want \"\", 0, nil", head, missing, err)
+	}
+	if err := client.PushWithLease(gitDir, "origin", "task", ""); err != nil {
+		t.Fatalf("PushWithLease(new branch) error = %v", err)
+	}
+	pushedHead, missing, err := client.RemoteBranchStatus(gitDir, "origin", "task")
+	if err != nil || pushedHead == "" || missing != 0 {
+		t.Fatalf("RemoteBranchStatus(pushed) = %q, %d, %v", pushedHead, missing, err)
+	}
+
+	// Someone else pushes a commit to the task branch
+	otherDir := t.TempDir()
+	if output, err := runGitCmd("", "clone", "-b", "task", remoteDir, otherDir).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to clone: %v\nOutput: %s", err, output)
+	}
+	_ = runGitCmd(otherDir, "config", "user.name", "Other User").Run()
+	_ = runGitCmd(otherDir, "config", "user.email", "other@example.com").Run()
+	createCommit(t, otherDir, "other.txt", "other", "Other commit")
+	if output, err := runGitCmd(otherDir, "push", "origin", "task").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to push: %v\nOutput: %s", err, output)
+	}
+
+	remoteHead, missing, err := client.RemoteBranchStatus(gitDir, "origin", "task")
+	if err != nil || missing != 1 || remoteHead == pushedHead {
+		t.Fatalf("RemoteBranchStatus(diverged) = %q, %d, %v; want new head, 1, nil", remoteHead, missing, err)
+	}
+
+	// A stale lease must not clobber the other commit
+	if err := client.PushWithLease(gitDir, "origin", "task", pushedHead); err == nil {
+		t.Error("PushWithLease(stale lease) succeeded, want rejection")
+	}
+	if err := client.PushWithLease(gitDir, "origin", "task", remoteHead); err != nil {
+		t.Errorf("PushWithLease(current lease) error = %v", err)
+	}
+}
+
+func TestIsProtectedBranchRejection(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"exit status 1: remote: error: GH006: Protected branch update failed for refs/heads/main.", true},
+		{"exit status 1: remote: GitLab: You are not allowed to push code to protected branches on this project.", true},
+		{"exit status 1: remote: Branch refs/heads/main can only be modified through pull requests.", true},
+		{"exit status 1: ! [rejected] main -> main (fetch first)", false},
+	}
+	for _, tt := range tests {
+		if got := IsProtectedBranchRejection(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("IsProtectedBranchRejection(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+	if IsProtectedBranchRejection(nil) {
+		t.Error("IsProtectedBranchRejection(nil) = true")
+	}
+}
+
+func TestResetBranch(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "test", "Initial commit")
+	base, _ := client.GetHeadCommit(gitDir)
+	branch, _ := client.GetCurrentBranch(gitDir)
+
+	if err := client.BranchCreate(gitDir, "other", "HEAD"); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "merged.txt", "merged", "Merge commit")
+
+	// Checked-out branch
+	if err := client.ResetBranch(gitDir, branch, base); err != nil {
+		t.Fatalf("ResetBranch(current) error = %v", err)
+	}
+	if head, _ := client.GetBranchHead(gitDir, branch); head != base {
+		t.Errorf("GetBranchHead(%s) = %s, want %s", branch, head, base)
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "merged.txt")); !os.IsNotExist(err) {
+		t.Error("ResetBranch(current) should update the working tree")
+	}
+
+	// Branch that isn't checked out
+	createCommit(t, gitDir, "next.txt", "next", "Next commit")
+	next, _ := client.GetHeadCommit(gitDir)
+	if err := client.ResetBranch(gitDir, "other", next); err != nil {
+		t.Fatalf("ResetBranch(other) error = %v", err)
+	}
+	if head, _ := client.GetBranchHead(gitDir, "other"); head != next {
+		t.Errorf("GetBranchHead(other) = %s, want %s", head, next)
+	}
+}
+
+func TestTreeSize(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "hello", "Initial commit")
+	createCommit(t, gitDir, "guide.md", "0123456789", "Add guide")
+
+	size, files, err := client.TreeSize(gitDir, "HEAD")
+	if err != nil {
+		t.Fatalf("TreeSize() error = %v", err)
+	}
+	if size != 15 {
+		t.Errorf("TreeSize() size = %d, want 15", size)
+	}
+	if files != 2 {
+		t.Errorf("TreeSize() files = %d, want 2", files)
+	}
+
+	if _, _, err := client.TreeSize(gitDir, "HEAD; rm -rf /"); err == nil {
+		t.Error("TreeSize() with invalid ref should fail")
+	}
+}
+
+func TestMergeTree(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "base\n", "Initial commit")
+	mainBranch, err := client.GetCurrentBranch(gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, branch := range []string{"conflicting", "clean"} {
+		if err := client.BranchCreate(gitDir, branch, mainBranch); err != nil {
+			t.Fatalf("BranchCreate(%s) error = %v", branch, err)
+		}
+	}
+	if err := client.Checkout(gitDir, "conflicting"); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "README.md", "task\n", "Change README on the task")
+	if err := client.Checkout(gitDir, "clean"); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "NOTES.md", "notes\n", "Add notes")
+	if err := client.Checkout(gitDir, mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "README.md", "main\n", "Change README on main")
+
+	conflicts, err := client.MergeTree(gitDir, mainBranch, "conflicting")
+	if err != nil {
+		t.Fatalf("MergeTree() error = %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "README.md" {
+		t.Errorf("MergeTree(conflicting) = %q, want [README.md]", conflicts)
+	}
+	if conflicts, err := client.MergeTree(gitDir, mainBranch, "clean"); err != nil || conflicts != nil {
+		t.Errorf("MergeTree(clean) = %q, %v; want no conflicts", conflicts, err)
+	}
+
+	// The checkout is left alone
+	if branch, _ := client.GetCurrentBranch(gitDir); branch != mainBranch {
+		t.Errorf("current branch = %s, want %s", branch, mainBranch)
+	}
+	if data, _ := os.ReadFile(filepath.Join(gitDir, "README.md")); string(data) != "main\n" {
+		t.Errorf("README.md = %q, want main", data)
+	}
+}
+
+func TestMergeAbortAfterSquashConflict(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "base\n", "Initial commit")
+	mainBranch, _ := client.GetCurrentBranch(gitDir)
+	if err := client.BranchCreate(gitDir, "task", mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Checkout(gitDir, "task"); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "README.md", "task\n", "Change README on the task")
+	if err := client.Checkout(gitDir, mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "README.md", "main\n", "Change README on main")
+
+	if err := client.MergeSquash(gitDir, "task", "Squash task"); err == nil {
+		t.Fatal("MergeSquash() should fail with conflicts")
+	}
+	if err := client.MergeAbort(gitDir); err != nil {
+		t.Fatalf("MergeAbort() error = %v", err)
+	}
+	if hasConflicts, files, _ := client.HasConflicts(gitDir); hasConflicts {
+		t.Errorf("conflicts remain after MergeAbort(): %v", files)
+	}
+	if data, _ := os.ReadFile(filepath.Join(gitDir, "README.md")); string(data) != "main\n" {
+		t.Errorf("README.md = %q, want main", data)
+	}
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// networkRetries is how many times a remote git command is retried after a
+// network failure. It is set from git_network_retries by SetNetworkRetries.
+var networkRetries = struct {
+	mu sync.Mutex
+	n  int
+}{n: constants.DefaultGitNetworkRetries}
+
+// retrySleep waits between retries; tests replace it to avoid real backoff.
+var retrySleep = time.Sleep
+
+// SetNetworkRetries sets how many times push, fetch, and pull are retried
+// when they fail on a network error. 0 disables retries.
+func SetNetworkRetries(n int) {
+	networkRetries.mu.Lock()
+	defer networkRetries.mu.Unlock()
+	networkRetries.n = max(n, 0)
+}
+
+func getNetworkRetries() int {
+	networkRetries.mu.Lock()
+	defer networkRetries.mu.Unlock()
+	return networkRetries.n
+}
+
+// networkErrorPatterns are git/ssh/curl messages of transient network failures.
+var networkErrorPatterns = []string{
+	"could not resolve host",
+	"could not resolve hostname",
+	"temporary failure in name resolution",
+	"connection timed out",
+	"connection refused",
+	"connection reset",
+	"operation timed out",
+	"network is unreachable",
+	"failed to connect",
+	"couldn't connect to server",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rpc failed",
+	"gnutls_handshake",
+	"ssl_connect",
+	"ssl_read",
+	"returned error: 502",
+	"returned error: 503",
+	"returned error: 504",
+}
+
+// authErrorPatterns mark failures that retrying cannot fix, even when git
+// also reports a dropped connection.
+var authErrorPatterns = []string{
+	"permission denied",
+	"authentication failed",
+	"could not read username",
+	"repository not found",
+	"returned error: 401",
+	"returned error: 403",
+	"returned error: 404",
+}
+
+// isNetworkError reports whether a failed git command looks like a transient
+// network failure worth retrying.
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, p := range authErrorPatterns {
+		if strings.Contains(msg, p) {
+			return false
+		}
+	}
+	for _, p := range networkErrorPatterns {
+		if strings.Contains(msg, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay returns the backoff before the given retry (1-based): the base
+// delay doubled per retry, capped, with up to 50% jitter either way.
+func retryDelay(retry int) time.Duration {
+	delay := constants.GitRetryBaseDelay << (retry - 1)
+	if delay <= 0 || delay > constants.GitRetryMaxDelay {
+		delay = constants.GitRetryMaxDelay
+	}
+	return delay/2 + rand.N(delay) //nolint:gosec // G404: jitter does not need a secure source
+}
+
+// withNetworkRetry runs fn, retrying it with exponential backoff while it
+// fails on a network error.
+func withNetworkRetry(args []string, fn func() error) error {
+	retries := getNetworkRetries()
+	err := fn()
+	for retry := 1; retry <= retries && isNetworkError(err); retry++ {
+		delay := retryDelay(retry)
+		logging.Warn("git %s failed on a network error, retrying in %s (%d/%d): %s",
+			strings.Join(args, " "), delay.Round(100*time.Millisecond), retry, retries, strings.TrimSpace(err.Error()))
+		retrySleep(delay)
+		err = fn()
+	}
+	return err
+}
+
+// runNetwork is run for commands that talk to a remote.
+func (c *gitClient) runNetwork(dir string, args ...string) error {
+	return withNetworkRetry(args, func() error {
+		return c.run(dir, args...)
+	})
+}
+
+// runNetworkOutput is runOutput for commands that talk to a remote.
+func (c *gitClient) runNetworkOutput(dir string, args ...string) (string, error) {
+	var output string
+	err := withNetworkRetry(args, func() error {
+		var err error
+		output, err = c.runOutput(dir, args...)
+		return err
+	})
+	return output, err
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestIsNetworkError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"fatal: unable to access 'https://github.com/a/b.git/': Could not resolve host: github.com", true},
+		{"ssh: connect to host github.com port 22: Connection timed out", true},
+		{"fatal: the remote end hung up unexpectedly", true},
+		{"error: RPC failed; curl 56 GnuTLS recv error (-9)", true},
+		{"fatal: unable to access 'https://example.com/': The requested URL returned error: 503", true},
+		{"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", false},
+		{"remote: Repository not found.\nfatal: the remote end hung up unexpectedly", false},
+		{"! [rejected]        main -> main (non-fast-forward)", false},
+		{"fatal: 'origin' does not appear to be a git repository", false},
+	}
+	for _, tt := range tests {
+		err := errors.New("exit status 128: " + tt.stderr)
+		if got := isNetworkError(err); got != tt.want {
+			t.Errorf("isNetworkError(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+	if isNetworkError(nil) {
+		t.Error("isNetworkError(nil) = true")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for retry := 1; retry <= 10; retry++ {
+		base := min(constants.GitRetryBaseDelay<<(retry-1), constants.GitRetryMaxDelay)
+		if got := retryDelay(retry); got < base/2 || got >= base*3/2 {
+			t.Errorf("retryDelay(%d) = %s, want within [%s, %s)", retry, got, base/2, base*3/2)
+		}
+	}
+}
+
+func TestFetchRetriesNetworkErrors(t *testing.T) {
+	var sleeps []time.Duration
+	retrySleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	t.Cleanup(func() {
+		retrySleep = time.Sleep
+		SetNetworkRetries(constants.DefaultGitNetworkRetries)
+	})
+
+	client := New()
+	gitDir := setupGitRepo(t)
+	createCommit(t, gitDir, "README.md", "base\n", "Initial commit")
+
+	// Nothing listens on port 1, so the connection is refused
+	SetNetworkRetries(2)
+	if err := client.Fetch(gitDir, "http://127.0.0.1:1/repo.git"); err == nil {
+		t.Fatal("Fetch() from an unreachable remote succeeded")
+	}
+	if len(sleeps) != 2 {
+		t.Errorf("Fetch() retried %d times, want 2", len(sleeps))
+	}
+
+	// A missing repository is not a network failure
+	sleeps = nil
+	if err := client.Fetch(gitDir, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("Fetch() from a missing repository succeeded")
+	}
+	if len(sleeps) != 0 {
+		t.Errorf("Fetch() of a missing repository retried %d times, want 0", len(sleeps))
+	}
+
+	SetNetworkRetries(0)
+	if err := client.Fetch(gitDir, "http://127.0.0.1:1/repo.git"); err == nil {
+		t.Fatal("Fetch() from an unreachable remote succeeded")
+	}
+	if len(sleeps) != 0 {
+		t.Errorf("Fetch() with retries disabled retried %d times", len(sleeps))
+	}
+}
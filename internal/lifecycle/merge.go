@@ -0,0 +1,357 @@
+// Package lifecycle implements the part of finishing a task shared by
+// end-task and merge-task: squash-merging the task branch into the main
+// branch of the project checkout, with the merge hooks around it.
+package lifecycle
+
+import (
+	"fmt"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// Merge squash-merges a task's branch into the main branch in the project
+// directory.
+type Merge struct {
+	App        *app.App
+	Task       *task.Task
+	WindowID   string
+	WorkDir    string // Task working directory, passed to the merge hooks
+	MainBranch string
+	Git        git.Client
+	Timer      *logging.Timer
+
+	// ResolveConflicts resolves the conflicts of squash-merging the task
+	// without touching the project checkout, leaving the main branch at the
+	// result. Without it, a conflicting merge fails.
+	ResolveConflicts func(mergeMsg string) bool
+	// AutoResolve repairs a squash merge that failed without conflicts in
+	// the project checkout. Without it, such a merge fails.
+	AutoResolve func() error
+	// AfterMerge runs once the merge is committed; returning false marks the
+	// merge as failed.
+	AfterMerge func() bool
+}
+
+// Run merges the task, stashing local changes of the project checkout
+// around the merge and restoring its branch afterwards. Returns false if the
+// merge failed. The caller must hold the merge lock.
+func (m *Merge) Run() bool {
+	projectDir := m.App.ProjectDir
+
+	// Check for ongoing merge or conflicts in project dir
+	hasConflicts, conflictFiles, _ := m.Git.HasConflicts(projectDir)
+	hasOngoingMerge := m.Git.HasOngoingMerge(projectDir)
+
+	if hasConflicts || hasOngoingMerge {
+		logging.Warn("Project directory has ongoing merge or conflicts")
+		fmt.Println()
+		fmt.Println("  ⚠️  Project directory has unresolved conflicts or ongoing merge")
+		if hasConflicts && len(conflictFiles) > 0 {
+			fmt.Println("  Conflicting files:")
+			for _, f := range conflictFiles {
+				fmt.Printf("    - %s\n", f)
+			}
+		}
+		fmt.Println()
+		fmt.Println("  Please resolve conflicts in the project directory first:")
+		fmt.Printf("    cd %s\n", projectDir)
+		fmt.Println("    git status  # View current state")
+		fmt.Println("    # Resolve conflicts, then: git add . && git commit")
+		fmt.Println("    # Or abort merge: git merge --abort")
+		fmt.Println()
+		m.Timer.StopWithResult(false, "project has conflicts")
+		return false
+	}
+
+	// Stash any uncommitted changes in project dir
+	hasLocalChanges := m.Git.HasChanges(projectDir)
+	if hasLocalChanges {
+		logging.Debug("Stashing local changes...")
+		if err := m.Git.StashPush(projectDir, constants.MergeStashMessage); err != nil {
+			logging.Warn("Failed to stash changes: %v", err)
+		}
+	}
+
+	// Remember current branch to restore later
+	currentBranch, _ := m.Git.GetCurrentBranch(projectDir)
+
+	mergeSuccess := m.perform(currentBranch)
+
+	// Restore stashed changes by message (not blind pop)
+	if hasLocalChanges {
+		restoreSpinner := tui.NewSimpleSpinner("Restoring stashed changes")
+		restoreSpinner.Start()
+		if err := m.Git.StashPopByMessage(projectDir, constants.MergeStashMessage); err != nil {
+			logging.Warn("Failed to restore stashed changes: %v", err)
+			restoreSpinner.Stop(false, err.Error())
+		} else {
+			restoreSpinner.Stop(true, "")
+		}
+	}
+
+	return mergeSuccess
+}
+
+// perform executes the git merge operation.
+func (m *Merge) perform(currentBranch string) bool {
+	projectDir := m.App.ProjectDir
+	name, mainBranch := m.Task.Name, m.MainBranch
+
+	// Check if remote origin exists
+	hasRemote := m.Git.HasRemote(projectDir, "origin")
+
+	// Fetch latest from origin (only if remote exists)
+	if hasRemote {
+		fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
+		fetchSpinner.Start()
+		logging.Debug("Fetching from origin...")
+		if err := m.Git.Fetch(projectDir, "origin"); err != nil {
+			logging.Warn("Failed to fetch: %v", err)
+			fetchSpinner.Stop(false, err.Error())
+		} else {
+			fetchSpinner.Stop(true, "")
+		}
+	} else {
+		logging.Debug("No remote 'origin' found, skipping fetch")
+		fmt.Println("  ○ No remote origin (local repo)")
+	}
+
+	// Check if main branch exists before checkout
+	if !m.Git.BranchExists(projectDir, mainBranch) {
+		logging.Warn("Main branch %s does not exist", mainBranch)
+		m.Timer.StopWithResult(false, "main branch not found")
+		fmt.Printf("\n  ✗ Branch '%s' does not exist\n", mainBranch)
+		fmt.Println("    This appears to be a new repository without a main branch.")
+		fmt.Println("    Consider renaming your task branch to main instead of merging.")
+		return false
+	}
+
+	// Checkout main
+	checkoutSpinner := tui.NewSimpleSpinner("Checking out " + mainBranch)
+	checkoutSpinner.Start()
+	logging.Debug("Checking out %s...", mainBranch)
+	if err := m.Git.Checkout(projectDir, mainBranch); err != nil {
+		logging.Warn("Failed to checkout %s: %v", mainBranch, err)
+		m.Timer.StopWithResult(false, "checkout failed")
+		checkoutSpinner.Stop(false, err.Error())
+		return false
+	}
+	checkoutSpinner.Stop(true, "")
+
+	// Pull latest (only if remote exists)
+	if hasRemote {
+		pullSpinner := tui.NewSimpleSpinner("Pulling latest changes")
+		pullSpinner.Start()
+		logging.Debug("Pulling latest changes...")
+		if err := m.Git.Pull(projectDir); err != nil {
+			logging.Warn("Failed to pull: %v", err)
+			pullSpinner.Stop(false, err.Error())
+		} else {
+			pullSpinner.Stop(true, "")
+		}
+	}
+
+	// Preview against the pulled main, which the merge goes into
+	expectedConflicts := m.preview()
+
+	// Merge task branch (squash)
+	mergeSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Merging %s into %s", name, mainBranch))
+	mergeSpinner.Start()
+	logging.Debug("Squash merging branch %s into %s...", name, mainBranch)
+
+	branchCommits, _ := m.Git.GetBranchCommits(projectDir, name, mainBranch, 20)
+	mergeMsg := git.GenerateMergeCommitMessage(name, branchCommits)
+	mergeConflictOccurred := false
+	mergeSuccess := true
+
+	if len(expectedConflicts) > 0 {
+		// Don't merge into the checkout only to find the conflicts there
+		mergeSpinner.Stop(false, "conflicts expected")
+		mergeConflictOccurred = true
+		mergeSuccess = m.resolveConflicts(mergeMsg)
+	} else if err := m.Git.MergeSquash(projectDir, name, mergeMsg); err != nil {
+		logging.Warn("Merge failed: %v - checking for conflicts", err)
+		mergeSpinner.Stop(false, "conflict")
+		mergeConflictOccurred = true
+
+		mergeSuccess = m.handleConflicts(mergeMsg)
+	}
+
+	if mergeSuccess {
+		if !mergeConflictOccurred {
+			mergeSpinner.Stop(true, "")
+		}
+		m.Timer.StopWithResult(true, fmt.Sprintf("squash merged %s into %s (local only)", name, mainBranch))
+		if m.AfterMerge != nil {
+			mergeSuccess = m.AfterMerge()
+		}
+	}
+
+	if mergeSuccess {
+		RunMergeHook(m.App, m.Task, "post-merge", m.WorkDir, m.WindowID)
+	}
+
+	// Restore original branch if different from main
+	if currentBranch != "" && currentBranch != mainBranch {
+		restoreSpinner := tui.NewSimpleSpinner("Restoring " + currentBranch)
+		restoreSpinner.Start()
+		if err := m.Git.Checkout(projectDir, currentBranch); err != nil {
+			logging.Warn("Failed to restore branch: %v", err)
+			restoreSpinner.Stop(false, err.Error())
+		} else {
+			restoreSpinner.Stop(true, "")
+		}
+	}
+
+	return mergeSuccess
+}
+
+// preview shows the commits that merging the task brings into the main
+// branch and the files expected to conflict, once the main branch is
+// checked out and pulled and before anything is merged into it.
+// Expected conflicts are also sent as a notification and returned.
+func (m *Merge) preview() []string {
+	projectDir := m.App.ProjectDir
+	name, mainBranch := m.Task.Name, m.MainBranch
+
+	total, _ := m.Git.CountCommits(projectDir, mainBranch, name)
+	commits, err := m.Git.GetBranchCommits(projectDir, name, mainBranch, constants.MergePreviewMaxCommits)
+	if err != nil {
+		logging.Debug("Failed to list commits to merge: %v", err)
+	} else if len(commits) > 0 {
+		fmt.Printf("  Incoming commits (%d):\n", max(total, len(commits)))
+		for _, c := range commits {
+			fmt.Printf("    %s %s\n", shortHash(c.Hash), c.Subject)
+		}
+		if total > len(commits) {
+			fmt.Printf("    … %d more\n", total-len(commits))
+		}
+	}
+
+	conflicts, err := m.Git.MergeTree(projectDir, mainBranch, name)
+	if err != nil {
+		// merge-tree --write-tree needs git 2.38+; conflicts then show up during the merge
+		logging.Debug("Skipping conflict preview: %v", err)
+		return nil
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("  ✓ No conflicts expected")
+		return nil
+	}
+	logging.Warn("Merge of %s into %s is expected to conflict: %v", name, mainBranch, conflicts)
+	fmt.Printf("  ⚠️  Conflicts expected in %d file(s):\n", len(conflicts))
+	for _, f := range conflicts {
+		fmt.Printf("      - %s\n", f)
+	}
+	fmt.Println("     Claude will try to resolve them; the merge is aborted if it can't")
+	_ = notify.Send("Merge conflicts expected", fmt.Sprintf("⚠️ %s: %d file(s) conflict with %s", name, len(conflicts), mainBranch))
+	return conflicts
+}
+
+// resolveConflicts hands the conflicts of the merge to ResolveConflicts.
+func (m *Merge) resolveConflicts(mergeMsg string) bool {
+	if m.ResolveConflicts == nil {
+		m.Timer.StopWithResult(false, "merge conflicts")
+		return false
+	}
+	return m.ResolveConflicts(mergeMsg)
+}
+
+// handleConflicts handles a squash merge that failed in the project
+// checkout: conflicts are aborted and resolved by ResolveConflicts, other
+// failures are repaired by AutoResolve.
+func (m *Merge) handleConflicts(mergeMsg string) bool {
+	projectDir := m.App.ProjectDir
+	hasConflicts, conflictFiles, _ := m.Git.HasConflicts(projectDir)
+	if hasConflicts && len(conflictFiles) > 0 {
+		fmt.Println()
+		fmt.Printf("  ⚠️  Merge conflicts detected in %d file(s):\n", len(conflictFiles))
+		for _, f := range conflictFiles {
+			fmt.Printf("      - %s\n", f)
+		}
+		fmt.Println()
+
+		// Restore the checkout; the conflicts are resolved in a temporary worktree
+		if abortErr := m.Git.MergeAbort(projectDir); abortErr != nil {
+			logging.Warn("Failed to abort merge: %v", abortErr)
+			m.Timer.StopWithResult(false, "abort failed")
+			return false
+		}
+		return m.resolveConflicts(mergeMsg)
+	}
+
+	// No conflicts detected, but merge still failed - try auto-resolution
+	logging.Warn("Merge failed without conflicts - attempting auto-resolution")
+	fmt.Println()
+	fmt.Println("  ⚠️  Merge failed without obvious conflicts")
+	fmt.Println()
+
+	err := fmt.Errorf("no auto-resolution")
+	if m.AutoResolve != nil {
+		autoResolveSpinner := tui.NewSimpleSpinner("Attempting auto-resolution with Claude")
+		autoResolveSpinner.Start()
+		if err = m.AutoResolve(); err != nil {
+			autoResolveSpinner.Stop(false, "failed")
+		} else {
+			autoResolveSpinner.Stop(true, "resolved")
+		}
+	}
+	if err != nil {
+		logging.Warn("Auto-resolution failed: %v", err)
+		if abortErr := m.Git.MergeAbort(projectDir); abortErr != nil {
+			logging.Warn("Failed to abort merge: %v", abortErr)
+		}
+		m.Timer.StopWithResult(false, "merge failed")
+		return false
+	}
+
+	logging.Log("Merge issue resolved by auto-resolution")
+	return true
+}
+
+// RunMergeHook runs the configured pre-merge or post-merge hook, if any.
+// Hook failures are logged but do not stop the merge.
+func RunMergeHook(appCtx *app.App, t *task.Task, name, workDir, windowID string) {
+	if appCtx.Config == nil {
+		return
+	}
+	command := appCtx.Config.PreMergeHook
+	if name == "post-merge" {
+		command = appCtx.Config.PostMergeHook
+	}
+	if command == "" {
+		return
+	}
+
+	hookSpinner := tui.NewSimpleSpinner("Running " + name + " hook")
+	hookSpinner.Start()
+	if _, err := service.RunHook(
+		name,
+		command,
+		appCtx.ProjectDir,
+		appCtx.GetEnvVars(t.Name, workDir, windowID),
+		t.GetHookOutputPath(name),
+		t.GetHookMetaPath(name),
+		constants.DefaultHookTimeout,
+	); err != nil {
+		logging.Warn("%s hook failed: %v", name, err)
+		hookSpinner.Stop(false, err.Error())
+	} else {
+		hookSpinner.Stop(true, "")
+	}
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
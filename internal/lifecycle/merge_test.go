@@ -0,0 +1,146 @@
+package lifecycle
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// newMergeRepo creates a project on main with a task branch "add-greeting"
+// that adds greeting.txt and changes README.md to readme, and returns a
+// Merge of that branch whose post-merge hook creates a marker file.
+func newMergeRepo(t *testing.T, readme string) (*Merge, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	projectDir := t.TempDir()
+	runGit(t, projectDir, "init", "-b", "main")
+	runGit(t, projectDir, "config", "user.email", "test@example.com")
+	runGit(t, projectDir, "config", "user.name", "Test")
+	writeFile(t, filepath.Join(projectDir, "README.md"), "# project\n")
+	runGit(t, projectDir, "add", ".")
+	runGit(t, projectDir, "commit", "-m", "initial commit")
+
+	runGit(t, projectDir, "checkout", "-b", "add-greeting")
+	writeFile(t, filepath.Join(projectDir, "greeting.txt"), "hello\n")
+	writeFile(t, filepath.Join(projectDir, "README.md"), readme)
+	runGit(t, projectDir, "add", ".")
+	runGit(t, projectDir, "commit", "-m", "Add greeting")
+	runGit(t, projectDir, "checkout", "main")
+
+	hookMarker := filepath.Join(t.TempDir(), "post-merge-ran")
+	appCtx := &app.App{
+		ProjectDir: projectDir,
+		PawDir:     filepath.Join(projectDir, ".paw"),
+		Config:     &config.Config{PostMergeHook: "touch '" + hookMarker + "'"},
+	}
+	return &Merge{
+		App:        appCtx,
+		Task:       task.New("add-greeting", t.TempDir()),
+		MainBranch: "main",
+		Git:        git.New(),
+		Timer:      logging.StartTimer("merge test"),
+	}, hookMarker
+}
+
+func TestMergeRun(t *testing.T) {
+	m, hookMarker := newMergeRepo(t, "# project\n")
+	projectDir := m.App.ProjectDir
+
+	// The checkout is on another branch with an uncommitted change
+	runGit(t, projectDir, "checkout", "-b", "wip")
+	writeFile(t, filepath.Join(projectDir, "README.md"), "# project (draft)\n")
+
+	afterMerge := 0
+	m.AfterMerge = func() bool {
+		afterMerge++
+		return true
+	}
+	if !m.Run() {
+		t.Fatal("Run() = false, want a successful merge")
+	}
+
+	if afterMerge != 1 {
+		t.Errorf("AfterMerge ran %d times, want 1", afterMerge)
+	}
+	if got := runGit(t, projectDir, "show", "main:greeting.txt"); got != "hello\n" {
+		t.Errorf("greeting.txt on main = %q, want the task's file", got)
+	}
+	if got := strings.TrimSpace(runGit(t, projectDir, "rev-list", "--count", "main")); got != "2" {
+		t.Errorf("main has %s commits, want the initial commit and one squash commit", got)
+	}
+	if got := strings.TrimSpace(runGit(t, projectDir, "branch", "--show-current")); got != "wip" {
+		t.Errorf("checkout branch after Run() = %q, want wip", got)
+	}
+	if data, _ := os.ReadFile(filepath.Join(projectDir, "README.md")); string(data) != "# project (draft)\n" {
+		t.Errorf("README.md after Run() = %q, want the stashed change restored", data)
+	}
+	if _, err := os.Stat(hookMarker); err != nil {
+		t.Errorf("post-merge hook did not run: %v", err)
+	}
+}
+
+func TestMergeRunAfterMergeFails(t *testing.T) {
+	m, hookMarker := newMergeRepo(t, "# project\n")
+	m.AfterMerge = func() bool { return false }
+
+	if m.Run() {
+		t.Fatal("Run() = true, want false when AfterMerge fails")
+	}
+	if _, err := os.Stat(hookMarker); !os.IsNotExist(err) {
+		t.Errorf("post-merge hook ran after a failed merge: %v", err)
+	}
+}
+
+func TestMergeRunConflicts(t *testing.T) {
+	m, _ := newMergeRepo(t, "# greeting project\n")
+	projectDir := m.App.ProjectDir
+	writeFile(t, filepath.Join(projectDir, "README.md"), "# main project\n")
+	runGit(t, projectDir, "commit", "-am", "Rename project")
+	head := runGit(t, projectDir, "rev-parse", "main")
+
+	var mergeMsg string
+	m.ResolveConflicts = func(msg string) bool {
+		mergeMsg = msg
+		return false
+	}
+	if m.Run() {
+		t.Fatal("Run() = true, want false when the conflicts are not resolved")
+	}
+	if !strings.Contains(mergeMsg, "add-greeting") {
+		t.Errorf("ResolveConflicts got message %q, want the task's merge message", mergeMsg)
+	}
+	if got := runGit(t, projectDir, "rev-parse", "main"); got != head {
+		t.Errorf("main moved to %s after an unresolved conflict", got)
+	}
+	if git.New().HasOngoingMerge(projectDir) {
+		t.Error("Run() left a merge in progress in the checkout")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
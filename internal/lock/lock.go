@@ -0,0 +1,241 @@
+// Package lock provides exclusive lock files shared by PAW processes.
+//
+// A lock file records its owner, PID, and the process start time. A lock is
+// stale, and taken over by the next acquirer, when its process has exited,
+// when the PID was reused by another process, or when it is older than its TTL.
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// ErrLocked is returned when the lock is held by a live process.
+var ErrLocked = errors.New("lock is held by another process")
+
+// DefaultRetryInterval is how often Acquire retries a held lock.
+const DefaultRetryInterval = time.Second
+
+// Options configures how a lock is acquired.
+type Options struct {
+	// TTL is how long a lock is honored even if its process is still
+	// running (0 = no limit)
+	TTL time.Duration
+	// RetryInterval is how often Acquire retries (default: DefaultRetryInterval)
+	RetryInterval time.Duration
+}
+
+// Info describes the holder of a lock.
+type Info struct {
+	Owner      string    `json:"owner"`
+	PID        int       `json:"pid"`
+	Started    string    `json:"started,omitempty"` // Process start time, to detect PID reuse
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// Lock is a held lock file.
+type Lock struct {
+	path string
+}
+
+// TryAcquire takes the lock at path for owner, taking over a stale lock.
+// It returns ErrLocked if a live process holds it.
+func TryAcquire(path, owner string, opts Options) (*Lock, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		err := create(path, owner)
+		if err == nil {
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		info, err := Read(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if info != nil && !info.Stale(opts.TTL, time.Now()) {
+			return nil, fmt.Errorf("%w (%s, pid %d)", ErrLocked, info.Owner, info.PID)
+		}
+		if info != nil {
+			logging.Debug("Taking over stale lock %s (owner=%s, pid=%d)", path, info.Owner, info.PID)
+		}
+		if err := takeOver(path, opts.TTL); err != nil {
+			return nil, err
+		}
+	}
+	return nil, ErrLocked
+}
+
+// takeOver moves a stale lock out of the way. Renaming is atomic, so of the
+// processes that found the lock stale only one moves it. The moved lock is
+// checked again: if it is live, another process took over the stale lock
+// first and this is its new lock, so it is put back and ErrLocked returned.
+func takeOver(path string, ttl time.Duration) error {
+	moved := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, moved); err != nil {
+		if os.IsNotExist(err) {
+			// Released or moved by another process; retry creating it
+			return nil
+		}
+		return fmt.Errorf("failed to take over stale lock: %w", err)
+	}
+
+	info, err := Read(moved)
+	if err == nil && !info.Stale(ttl, time.Now()) {
+		// Link fails if yet another process created the lock meanwhile
+		if err := os.Link(moved, path); err != nil {
+			logging.Warn("Failed to restore lock %s taken by %s (pid %d): %v", path, info.Owner, info.PID, err)
+		}
+		_ = os.Remove(moved)
+		return fmt.Errorf("%w (%s, pid %d)", ErrLocked, info.Owner, info.PID)
+	}
+	if err := os.Remove(moved); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale lock: %w", err)
+	}
+	return nil
+}
+
+// Acquire takes the lock at path for owner, waiting until it is released,
+// goes stale, or ctx is done.
+func Acquire(ctx context.Context, path, owner string, opts Options) (*Lock, error) {
+	interval := opts.RetryInterval
+	if interval <= 0 {
+		interval = DefaultRetryInterval
+	}
+	for {
+		l, err := TryAcquire(path, owner, opts)
+		if !errors.Is(err, ErrLocked) {
+			return l, err
+		}
+		logging.Trace("Waiting for lock %s: %v", path, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Release removes the lock file.
+func (l *Lock) Release() error {
+	if l == nil {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Read returns the holder of the lock at path.
+func Read(path string) (*Info, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: lock paths are constructed by PAW
+	if err != nil {
+		return nil, err
+	}
+	return parseInfo(data), nil
+}
+
+// Stale reports whether the lock can be taken over: its process is gone, the
+// PID now belongs to another process, or it is older than ttl.
+func (i *Info) Stale(ttl time.Duration, now time.Time) bool {
+	if i.PID <= 0 {
+		return true
+	}
+	if ttl > 0 && !i.AcquiredAt.IsZero() && now.Sub(i.AcquiredAt) > ttl {
+		return true
+	}
+	if !processRunning(i.PID) {
+		return true
+	}
+	if i.Started != "" {
+		if started, err := processStartTime(i.PID); err == nil && started != i.Started {
+			return true
+		}
+	}
+	return false
+}
+
+// create writes a new lock file, failing if one exists.
+func create(path, owner string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: lock files are readable by design
+	if err != nil {
+		return err
+	}
+
+	info := Info{Owner: owner, PID: os.Getpid(), AcquiredAt: time.Now()}
+	info.Started, _ = processStartTime(info.PID)
+	data, err := json.Marshal(info)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// parseInfo parses a lock file. Lock files of older PAW versions hold the
+// owner and PID on two lines; anything unparsable yields a stale lock.
+func parseInfo(data []byte) *Info {
+	var info Info
+	if err := json.Unmarshal(data, &info); err == nil {
+		return &info
+	}
+	lines := strings.Split(string(data), "\n")
+	info = Info{Owner: strings.TrimSpace(lines[0])}
+	if len(lines) > 1 {
+		info.PID, _ = strconv.Atoi(strings.TrimSpace(lines[1]))
+	}
+	return &info
+}
+
+// processRunning reports whether a process with the PID exists.
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 checks for existence without sending a signal
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// processStartTime returns an opaque start time of the process, which
+// changes when its PID is reused.
+func processStartTime(pid int) (string, error) {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// Field 22 is the start time; the command (field 2) may contain spaces
+		stat := string(data)
+		fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+		if len(fields) > 19 {
+			return fields[19], nil
+		}
+		return "", errors.New("unexpected /proc stat format")
+	}
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output() //nolint:gosec // G204: pid is an integer
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,151 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTryAcquireAndRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	l, err := TryAcquire(path, "first", Options{})
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	info, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if info.Owner != "first" || info.PID != os.Getpid() {
+		t.Errorf("Read() = %+v, want owner first and pid %d", info, os.Getpid())
+	}
+
+	if _, err := TryAcquire(path, "second", Options{}); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryAcquire() on held lock error = %v, want ErrLocked", err)
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Release() should remove the lock file")
+	}
+	l2, err := TryAcquire(path, "second", Options{})
+	if err != nil {
+		t.Fatalf("TryAcquire() after release error = %v", err)
+	}
+	_ = l2.Release()
+}
+
+func TestTryAcquireTakesOverStaleLock(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts Options
+	}{
+		{
+			name: "dead process",
+			data: `{"owner":"old","pid":999999999,"acquired_at":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "reused pid",
+			data: `{"owner":"old","pid":` + strconv.Itoa(os.Getpid()) + `,"started":"not-a-start-time","acquired_at":"` + time.Now().Format(time.RFC3339) + `"}`,
+		},
+		{
+			name: "expired ttl",
+			data: `{"owner":"old","pid":` + strconv.Itoa(os.Getpid()) + `,"acquired_at":"2024-01-01T00:00:00Z"}`,
+			opts: Options{TTL: time.Hour},
+		},
+		{
+			name: "legacy format",
+			data: "old\n999999999",
+		},
+		{
+			name: "garbage",
+			data: "not a lock",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.lock")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			l, err := TryAcquire(path, "new", tt.opts)
+			if err != nil {
+				t.Fatalf("TryAcquire() error = %v", err)
+			}
+			defer func() { _ = l.Release() }()
+			if info, _ := Read(path); info == nil || info.Owner != "new" {
+				t.Errorf("Read() = %+v, want owner new", info)
+			}
+		})
+	}
+}
+
+func TestTakeOverRestoresLiveLock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.lock")
+
+	// Another process took over the stale lock between our check and takeover
+	l, err := TryAcquire(path, "winner", Options{})
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	defer func() { _ = l.Release() }()
+
+	if err := takeOver(path, 0); !errors.Is(err, ErrLocked) {
+		t.Errorf("takeOver(live lock) error = %v, want ErrLocked", err)
+	}
+	if info, _ := Read(path); info == nil || info.Owner != "winner" {
+		t.Errorf("Read() after takeOver = %+v, want the winner's lock back", info)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("lock dir has %d entries, want only the lock", len(entries))
+	}
+
+	// A lock already moved by another process is left to the retry
+	if err := takeOver(filepath.Join(dir, "missing.lock"), 0); err != nil {
+		t.Errorf("takeOver(missing) error = %v, want nil", err)
+	}
+}
+
+func TestTryAcquireHonorsLiveLegacyLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	if err := os.WriteFile(path, []byte("old\n"+strconv.Itoa(os.Getpid())), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryAcquire(path, "new", Options{TTL: time.Hour}); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryAcquire() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	held, err := TryAcquire(path, "first", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Acquire(ctx, path, "second", Options{RetryInterval: 10 * time.Millisecond}); !errors.Is(err, ErrLocked) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() on held lock error = %v, want ErrLocked and DeadlineExceeded", err)
+	}
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = held.Release()
+	}()
+	l, err := Acquire(context.Background(), path, "second", Options{RetryInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	_ = l.Release()
+}
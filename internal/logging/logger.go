@@ -130,9 +130,46 @@ type Timer struct {
 	logger    *fileLogger
 }
 
+// TimerRecord is a stopped timer kept by RecordTimers.
+type TimerRecord struct {
+	Operation string
+	Start     time.Time
+	Elapsed   time.Duration
+}
+
+var (
+	timerRecordsMu sync.Mutex
+	timerRecords   []TimerRecord
+	recordTimers   bool
+)
+
+// RecordTimers makes every timer stopped from now on kept for RecordedTimers
+// (used by --trace-startup).
+func RecordTimers() {
+	timerRecordsMu.Lock()
+	defer timerRecordsMu.Unlock()
+	recordTimers = true
+}
+
+// RecordedTimers returns the timers stopped since RecordTimers, in stop order.
+func RecordedTimers() []TimerRecord {
+	timerRecordsMu.Lock()
+	defer timerRecordsMu.Unlock()
+	return append([]TimerRecord(nil), timerRecords...)
+}
+
+func (t *Timer) record(elapsed time.Duration) {
+	timerRecordsMu.Lock()
+	defer timerRecordsMu.Unlock()
+	if recordTimers {
+		timerRecords = append(timerRecords, TimerRecord{Operation: t.operation, Start: t.start, Elapsed: elapsed})
+	}
+}
+
 // Stop stops the timer and logs the elapsed time
 func (t *Timer) Stop() time.Duration {
 	elapsed := time.Since(t.start)
+	t.record(elapsed)
 	if t.logger != nil {
 		t.logger.logWithLevel(LevelDebug, "%s completed in %v", t.operation, elapsed)
 	}
@@ -142,6 +179,7 @@ func (t *Timer) Stop() time.Duration {
 // StopWithResult stops the timer and logs the result
 func (t *Timer) StopWithResult(success bool, detail string) time.Duration {
 	elapsed := time.Since(t.start)
+	t.record(elapsed)
 	if t.logger != nil {
 		status := "completed"
 		level := LevelDebug
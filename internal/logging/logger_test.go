@@ -322,3 +322,20 @@ func TestLoggerContext(t *testing.T) {
 		t.Errorf("Log file should contain script:task context, got: %s", content)
 	}
 }
+
+func TestRecordTimers(t *testing.T) {
+	logger := NewStdout(false)
+
+	logger.StartTimer("before recording").Stop()
+	RecordTimers()
+	logger.StartTimer("config load").Stop()
+	logger.StartTimer("tmux setup").StopWithResult(false, "")
+
+	var ops []string
+	for _, r := range RecordedTimers() {
+		ops = append(ops, r.Operation)
+	}
+	if got := strings.Join(ops, ","); got != "config load,tmux setup" {
+		t.Errorf("RecordedTimers() operations = %q, want %q", got, "config load,tmux setup")
+	}
+}
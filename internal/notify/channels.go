@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// DefaultNtfyServer is used when no ntfy server is configured.
+const DefaultNtfyServer = "https://ntfy.sh"
+
+// channelTimeout bounds each remote notification request.
+const channelTimeout = 5 * time.Second
+
+// slackAPIURL is the Slack Web API base URL (overridden in tests).
+var slackAPIURL = "https://slack.com/api"
+
+// Channels are remote notification channels used in addition to desktop notifications.
+// Tokens starting with '$' are read from the environment.
+type Channels struct {
+	SlackToken   string
+	SlackChannel string
+	NtfyServer   string
+	NtfyTopic    string
+}
+
+var (
+	channelsMu sync.Mutex
+	channels   Channels
+	httpClient = &http.Client{Timeout: channelTimeout}
+)
+
+// SetChannels configures the remote channels notifications are also sent to.
+func SetChannels(c Channels) {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+	channels = c
+}
+
+func currentChannels() Channels {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+	return channels
+}
+
+// sendChannels delivers a notification to the configured remote channels.
+// Failures are logged; they never block desktop notifications.
+func sendChannels(title, message string, opts Options) {
+	c := currentChannels()
+	if c.SlackToken != "" && c.SlackChannel != "" {
+		if err := PostSlack(c.SlackToken, c.SlackChannel, fmt.Sprintf("*%s*\n%s", title, message)); err != nil {
+			logging.Warn("Slack notification failed: %v", err)
+		}
+	}
+	if c.NtfyTopic != "" {
+		if err := PostNtfy(c.NtfyServer, c.NtfyTopic, title, message, opts); err != nil {
+			logging.Warn("ntfy notification failed: %v", err)
+		}
+	}
+}
+
+// resolveSecret expands a "$VAR" reference to the variable's value.
+func resolveSecret(value string) string {
+	if strings.HasPrefix(value, "$") {
+		return os.ExpandEnv(value)
+	}
+	return value
+}
+
+// slackResponse is the common envelope of Slack Web API responses.
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	Team  string `json:"team"`
+	User  string `json:"user"`
+}
+
+// TestSlack checks a Slack token with auth.test and returns the workspace and
+// bot user it belongs to.
+func TestSlack(token string) (string, error) {
+	resp, err := callSlack("auth.test", token, nil)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s (%s)", resp.Team, resp.User), nil
+}
+
+// PostSlack posts a message to a Slack channel with chat.postMessage.
+func PostSlack(token, channel, text string) error {
+	_, err := callSlack("chat.postMessage", token, map[string]string{"channel": channel, "text": text})
+	return err
+}
+
+func callSlack(method, token string, payload map[string]string) (*slackResponse, error) {
+	token = resolveSecret(token)
+	if token == "" {
+		return nil, errors.New("slack token is empty")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, slackAPIURL+"/"+method, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("slack %s: %w", method, err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var resp slackResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("slack %s: unexpected response (HTTP %d)", method, res.StatusCode)
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("slack %s: %s", method, resp.Error)
+	}
+	return &resp, nil
+}
+
+// TestNtfy sends a test notification to an ntfy topic.
+func TestNtfy(server, topic string) error {
+	return PostNtfy(server, topic, "PAW", "✅ ntfy notifications are set up", Options{Urgency: UrgencyNormal})
+}
+
+// PostNtfy publishes a notification to an ntfy topic.
+// opts.Link becomes the notification's click action.
+func PostNtfy(server, topic, title, message string, opts Options) error {
+	if topic == "" {
+		return errors.New("ntfy topic is empty")
+	}
+	if server == "" {
+		server = DefaultNtfyServer
+	}
+	endpoint, err := url.JoinPath(server, url.PathEscape(topic))
+	if err != nil {
+		return fmt.Errorf("invalid ntfy server %q: %w", server, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", title)
+	switch opts.Urgency {
+	case UrgencyLow:
+		req.Header.Set("Priority", "low")
+	case UrgencyCritical:
+		req.Header.Set("Priority", "high")
+	}
+	if opts.Link != "" {
+		req.Header.Set("Click", opts.Link)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ntfy: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("ntfy: HTTP %d", res.StatusCode)
+	}
+	return nil
+}
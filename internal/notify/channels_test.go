@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTestSlack(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/auth.test" {
+			t.Errorf("path = %q, want /auth.test", r.URL.Path)
+		}
+		if gotAuth != "Bearer xoxb-valid" {
+			_, _ = io.WriteString(w, `{"ok": false, "error": "invalid_auth"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"ok": true, "team": "Acme", "user": "paw-bot"}`)
+	}))
+	defer server.Close()
+	prev := slackAPIURL
+	slackAPIURL = server.URL
+	defer func() { slackAPIURL = prev }()
+
+	t.Setenv("PAW_TEST_SLACK_TOKEN", "xoxb-valid")
+	got, err := TestSlack("$PAW_TEST_SLACK_TOKEN")
+	if err != nil {
+		t.Fatalf("TestSlack() error = %v", err)
+	}
+	if got != "Acme (paw-bot)" {
+		t.Errorf("TestSlack() = %q, want %q", got, "Acme (paw-bot)")
+	}
+
+	if _, err := TestSlack("xoxb-wrong"); err == nil || !strings.Contains(err.Error(), "invalid_auth") {
+		t.Errorf("TestSlack(wrong token) error = %v, want invalid_auth", err)
+	}
+	if _, err := TestSlack("$PAW_TEST_UNSET_TOKEN"); err == nil {
+		t.Error("TestSlack(unset env token) succeeded, want error")
+	}
+}
+
+func TestPostSlack(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = io.WriteString(w, `{"ok": true}`)
+	}))
+	defer server.Close()
+	prev := slackAPIURL
+	slackAPIURL = server.URL
+	defer func() { slackAPIURL = prev }()
+
+	if err := PostSlack("xoxb-token", "#paw", "hello"); err != nil {
+		t.Fatalf("PostSlack() error = %v", err)
+	}
+	if payload["channel"] != "#paw" || payload["text"] != "hello" {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestPostNtfy(t *testing.T) {
+	var gotPath, gotTitle, gotPriority, gotClick, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotTitle = r.Header.Get("Title")
+		gotPriority = r.Header.Get("Priority")
+		gotClick = r.Header.Get("Click")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if strings.HasSuffix(r.URL.Path, "/forbidden") {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	if err := PostNtfy(server.URL, "paw-alerts", "Merge failed", "my-task", Options{Urgency: UrgencyCritical, Link: "paw://app/my-task"}); err != nil {
+		t.Fatalf("PostNtfy() error = %v", err)
+	}
+	if gotPath != "/paw-alerts" || gotTitle != "Merge failed" || gotPriority != "high" || gotClick != "paw://app/my-task" || gotBody != "my-task" {
+		t.Errorf("request = path %q title %q priority %q click %q body %q", gotPath, gotTitle, gotPriority, gotClick, gotBody)
+	}
+
+	if err := TestNtfy(server.URL, "forbidden"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("TestNtfy(forbidden) error = %v, want HTTP 403", err)
+	}
+	if err := PostNtfy(server.URL, "", "t", "m", Options{}); err == nil {
+		t.Error("PostNtfy(empty topic) succeeded, want error")
+	}
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeepLinkScheme is the URL scheme handled by 'paw url-handler install'.
+const DeepLinkScheme = "paw"
+
+// TaskLink returns the deep link that focuses a task window: paw://{session}/{task}.
+func TaskLink(sessionName, taskName string) string {
+	if sessionName == "" || taskName == "" {
+		return ""
+	}
+	return DeepLinkScheme + "://" + escapeLinkSegment(sessionName) + "/" + escapeLinkSegment(taskName)
+}
+
+// escapeLinkSegment path-escapes s, including ':' which session names may
+// contain ("repo:subdir") and which would otherwise read as a port.
+func escapeLinkSegment(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), ":", "%3A")
+}
+
+// ParseTaskLink extracts the session and task names from a paw:// deep link.
+// The task is empty for links that only name a project (paw://{session}).
+func ParseTaskLink(link string) (sessionName, taskName string, err error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(link), DeepLinkScheme+":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid link %q: expected %s:// scheme", link, DeepLinkScheme)
+	}
+	rest = strings.TrimPrefix(rest, "//")
+	rest, _, _ = strings.Cut(rest, "?")
+	rest = strings.TrimSuffix(rest, "/")
+
+	rawSession, rawTask, _ := strings.Cut(rest, "/")
+	if strings.Contains(rawTask, "/") {
+		return "", "", fmt.Errorf("invalid link %q: expected %s://project/task", link, DeepLinkScheme)
+	}
+	if sessionName, err = url.PathUnescape(rawSession); err != nil {
+		return "", "", fmt.Errorf("invalid link %q: %w", link, err)
+	}
+	if taskName, err = url.PathUnescape(rawTask); err != nil {
+		return "", "", fmt.Errorf("invalid link %q: %w", link, err)
+	}
+	if sessionName == "" {
+		return "", "", fmt.Errorf("invalid link %q: missing project", link)
+	}
+	return sessionName, taskName, nil
+}
@@ -0,0 +1,48 @@
+package notify
+
+import "testing"
+
+func TestTaskLinkRoundTrip(t *testing.T) {
+	link := TaskLink("my app:web", "fix-login")
+	if link != "paw://my%20app%3Aweb/fix-login" {
+		t.Fatalf("TaskLink() = %q", link)
+	}
+	session, task, err := ParseTaskLink(link)
+	if err != nil {
+		t.Fatalf("ParseTaskLink() error = %v", err)
+	}
+	if session != "my app:web" || task != "fix-login" {
+		t.Errorf("ParseTaskLink() = (%q, %q), want (%q, %q)", session, task, "my app:web", "fix-login")
+	}
+
+	if TaskLink("", "fix-login") != "" {
+		t.Error("TaskLink() without session should be empty")
+	}
+}
+
+func TestParseTaskLink(t *testing.T) {
+	tests := []struct {
+		link        string
+		wantSession string
+		wantTask    string
+		wantErr     bool
+	}{
+		{link: "paw://myapp/fix-login", wantSession: "myapp", wantTask: "fix-login"},
+		{link: "paw://myapp/fix-login/", wantSession: "myapp", wantTask: "fix-login"},
+		{link: "paw://myapp", wantSession: "myapp"},
+		{link: "paw:myapp/fix-login", wantSession: "myapp", wantTask: "fix-login"},
+		{link: "https://myapp/fix-login", wantErr: true},
+		{link: "paw:///fix-login", wantErr: true},
+		{link: "paw://myapp/a/b", wantErr: true},
+	}
+	for _, tt := range tests {
+		session, task, err := ParseTaskLink(tt.link)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTaskLink(%q) error = %v, wantErr %v", tt.link, err, tt.wantErr)
+			continue
+		}
+		if session != tt.wantSession || task != tt.wantTask {
+			t.Errorf("ParseTaskLink(%q) = (%q, %q), want (%q, %q)", tt.link, session, task, tt.wantSession, tt.wantTask)
+		}
+	}
+}
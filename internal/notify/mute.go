@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+var (
+	muteMu   sync.Mutex
+	mutePath string
+)
+
+// SetMuteFile sets the mute marker checked before every notification and sound.
+// An empty path disables muting.
+func SetMuteFile(path string) {
+	muteMu.Lock()
+	defer muteMu.Unlock()
+	mutePath = path
+}
+
+func currentMuteFile() string {
+	muteMu.Lock()
+	defer muteMu.Unlock()
+	return mutePath
+}
+
+// Mute writes a mute marker at path that silences notifications until the
+// given time. A zero time mutes until Unmute is called.
+func Mute(path string, until time.Time) error {
+	content := ""
+	if !until.IsZero() {
+		content = until.UTC().Format(time.RFC3339)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644) //nolint:gosec // G306: marker file, not sensitive
+}
+
+// Unmute removes the mute marker at path.
+func Unmute(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// MuteState reports whether the marker at path mutes notifications at now,
+// and until when (zero if muted until unmuted).
+func MuteState(path string, now time.Time) (muted bool, until time.Time) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the session's mute marker
+	if err != nil {
+		return false, time.Time{}
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return true, time.Time{}
+	}
+	until, err = time.Parse(time.RFC3339, value)
+	if err != nil {
+		// Unreadable deadline: stay muted rather than surprise the user
+		return true, time.Time{}
+	}
+	return now.Before(until), until
+}
+
+// isMuted returns true if the configured mute marker silences notifications now.
+func isMuted() bool {
+	path := currentMuteFile()
+	if path == "" {
+		return false
+	}
+	muted, _ := MuteState(path, time.Now())
+	if muted {
+		logging.Debug("notifications muted (%s)", path)
+	}
+	return muted
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMuteState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".muted")
+	now := time.Now()
+
+	if muted, _ := MuteState(path, now); muted {
+		t.Fatal("MuteState() muted without a marker")
+	}
+
+	if err := Mute(path, time.Time{}); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	if muted, until := MuteState(path, now); !muted || !until.IsZero() {
+		t.Errorf("MuteState() = %t, %v; want muted indefinitely", muted, until)
+	}
+
+	deadline := now.Add(2 * time.Hour).Truncate(time.Second)
+	if err := Mute(path, deadline); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	if muted, until := MuteState(path, now); !muted || !until.Equal(deadline) {
+		t.Errorf("MuteState() = %t, %v; want muted until %v", muted, until, deadline)
+	}
+	if muted, _ := MuteState(path, deadline); muted {
+		t.Error("MuteState() still muted at the deadline")
+	}
+
+	if err := Unmute(path); err != nil {
+		t.Fatalf("Unmute() error = %v", err)
+	}
+	if muted, _ := MuteState(path, now); muted {
+		t.Error("MuteState() muted after Unmute()")
+	}
+	if err := Unmute(path); err != nil {
+		t.Errorf("Unmute() without marker error = %v", err)
+	}
+}
+
+func TestSendWhileMuted(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), ".muted")
+	SetChannels(Channels{NtfyServer: server.URL, NtfyTopic: "paw-alerts"})
+	SetMuteFile(path)
+	defer SetChannels(Channels{})
+	defer SetMuteFile("")
+
+	if err := Mute(path, time.Time{}); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	_ = Send("Task ready", "muted-task")
+	if requests != 0 {
+		t.Errorf("muted Send() made %d channel requests, want 0", requests)
+	}
+
+	if err := Unmute(path); err != nil {
+		t.Fatalf("Unmute() error = %v", err)
+	}
+	_ = Send("Task ready", "loud-task")
+	if requests != 1 {
+		t.Errorf("unmuted Send() made %d channel requests, want 1", requests)
+	}
+}
@@ -98,6 +98,7 @@ const (
 type Options struct {
 	Urgency Urgency // Notification urgency level (default: UrgencyNormal)
 	Icon    Icon    // Standard icon name (default: none)
+	Link    string  // Deep link opened on click (paw://project/task), when supported
 }
 
 // Send shows a desktop notification using terminal escape sequences.
@@ -112,12 +113,24 @@ func SendWithUrgency(title, message string, urgency Urgency) error {
 	return SendWithOptions(title, message, Options{Urgency: urgency})
 }
 
+// SendWithLink shows a desktop notification that opens link when clicked.
+// Clicks are only routed on macOS with terminal-notifier and on ntfy; elsewhere
+// this behaves like Send.
+func SendWithLink(title, message, link string) error {
+	return SendWithOptions(title, message, Options{Urgency: UrgencyNormal, Link: link})
+}
+
 // SendWithOptions shows a desktop notification with custom options.
 func SendWithOptions(title, message string, opts Options) error {
 	logging.Info("-> SendWithOptions(title=%q, message=%q, urgency=%d, icon=%q)", title, message, opts.Urgency, opts.Icon)
 	defer logging.Info("<- SendWithOptions")
 
+	if isMuted() {
+		return nil
+	}
+
 	sendTerminalNotification(title, message, opts)
+	sendChannels(title, message, opts)
 	return nil
 }
 
@@ -128,6 +141,13 @@ func sendTerminalNotification(title, message string, opts Options) {
 
 	logging.Trace("sendTerminalNotification: term=%s, inTmux=%v, urgency=%d", term, inTmux, opts.Urgency)
 
+	// OSC notifications can only focus the terminal; use terminal-notifier so
+	// a click can open the task's deep link instead.
+	if opts.Link != "" && runtime.GOOS == "darwin" && tryTerminalNotifier(title, message, opts.Link) {
+		fmt.Fprint(os.Stderr, BEL)
+		return
+	}
+
 	// Send appropriate OSC based on terminal
 	switch term {
 	case termKitty:
@@ -428,12 +448,36 @@ func tryNotifySend(title, message string, opts Options) bool {
 	return true
 }
 
+// tryTerminalNotifier sends a macOS notification that opens link when clicked.
+// Returns false when terminal-notifier is not installed or fails to start.
+func tryTerminalNotifier(title, message, link string) bool {
+	path, err := exec.LookPath("terminal-notifier")
+	if err != nil {
+		return false
+	}
+
+	args := []string{"-title", title, "-message", message, "-open", link, "-group", "paw-" + title}
+	cmd := exec.Command(path, args...) //nolint:gosec // G204: path is resolved via LookPath
+	if err := cmd.Start(); err != nil {
+		logging.Debug("tryTerminalNotifier: failed to start terminal-notifier err=%v", err)
+		return false
+	}
+	go func() { _ = cmd.Wait() }()
+
+	logging.Trace("tryTerminalNotifier: sent notification with link=%s", link)
+	return true
+}
+
 // PlaySound plays an alert sound.
 // On macOS, uses system sounds via afplay. On other platforms, uses terminal bell.
 func PlaySound(soundType SoundType) {
 	logging.Info("-> PlaySound(soundType=%s)", soundType)
 	defer logging.Info("<- PlaySound")
 
+	if isMuted() {
+		return
+	}
+
 	if runtime.GOOS == "darwin" {
 		soundPath := fmt.Sprintf("/System/Library/Sounds/%s.aiff", soundType)
 		if _, err := os.Stat(soundPath); err == nil {
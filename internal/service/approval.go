@@ -0,0 +1,185 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// ApprovalDecision is the user's answer to an approval request.
+type ApprovalDecision string
+
+// Approval decisions.
+const (
+	ApprovalPending  ApprovalDecision = ""
+	ApprovalApproved ApprovalDecision = "approved"
+	ApprovalDenied   ApprovalDecision = "denied"
+	ApprovalTimedOut ApprovalDecision = "timed_out"
+)
+
+// ApprovalRequest is a command an agent is waiting to run.
+// It is stored in the task's agent directory until the user decides.
+type ApprovalRequest struct {
+	ID          string           `json:"id"`
+	TaskName    string           `json:"task_name"`
+	Rule        string           `json:"rule"`
+	Command     string           `json:"command"`
+	Dir         string           `json:"dir,omitempty"`
+	RequestedAt string           `json:"requested_at"`
+	Decision    ApprovalDecision `json:"decision,omitempty"`
+}
+
+// ApprovalCommandNames returns the unique command names (first word) of the rules.
+func ApprovalCommandNames(rules []string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, rule := range rules {
+		fields := strings.Fields(rule)
+		if len(fields) == 0 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		names = append(names, fields[0])
+	}
+	return names
+}
+
+// MatchApprovalRule returns the first rule matching the command, or "" if none.
+// A rule matches when its first word is the command name and every other word
+// appears among the arguments. Short flag clusters match by letter, so "rm -rf"
+// also matches "rm -fr dir" and "rm -r -f dir".
+func MatchApprovalRule(rules []string, name string, args []string) string {
+	name = filepath.Base(name)
+	for _, rule := range rules {
+		fields := strings.Fields(rule)
+		if len(fields) == 0 || fields[0] != name {
+			continue
+		}
+		if matchesApprovalArgs(fields[1:], args) {
+			return rule
+		}
+	}
+	return ""
+}
+
+func matchesApprovalArgs(ruleArgs, args []string) bool {
+	// Collect short flag letters from clusters like "-rf"
+	shortFlags := make(map[rune]bool)
+	for _, arg := range args {
+		if isShortFlagCluster(arg) {
+			for _, r := range arg[1:] {
+				shortFlags[r] = true
+			}
+		}
+	}
+
+	for _, want := range ruleArgs {
+		if isShortFlagCluster(want) {
+			for _, r := range want[1:] {
+				if !shortFlags[r] {
+					return false
+				}
+			}
+			continue
+		}
+		found := false
+		for _, arg := range args {
+			if arg == want || (strings.HasPrefix(want, "--") && strings.HasPrefix(arg, want+"=")) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isShortFlagCluster(s string) bool {
+	return len(s) > 1 && s[0] == '-' && s[1] != '-'
+}
+
+// NewApprovalRequest creates a pending approval request for a command.
+func NewApprovalRequest(taskName, rule, name string, args []string, dir string) *ApprovalRequest {
+	now := time.Now()
+	return &ApprovalRequest{
+		ID:          strconv.FormatInt(now.UnixNano(), 36),
+		TaskName:    taskName,
+		Rule:        rule,
+		Command:     strings.Join(append([]string{name}, args...), " "),
+		Dir:         dir,
+		RequestedAt: now.Format(time.RFC3339),
+	}
+}
+
+// LoadApprovalRequest reads an approval request. Returns nil if none exists.
+func LoadApprovalRequest(path string) *ApprovalRequest {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled agent directory
+	if err != nil {
+		return nil
+	}
+	var req ApprovalRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil
+	}
+	return &req
+}
+
+// SaveApprovalRequest writes an approval request.
+func SaveApprovalRequest(path string, req *ApprovalRequest) error {
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval request: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write approval request: %w", err)
+	}
+	return nil
+}
+
+// DecideApproval records the user's decision for the pending request with the given ID.
+func DecideApproval(path, id string, approved bool) error {
+	req := LoadApprovalRequest(path)
+	if req == nil || req.ID != id {
+		return errors.New("approval request no longer exists")
+	}
+	if req.Decision != ApprovalPending {
+		return fmt.Errorf("approval request already %s", req.Decision)
+	}
+
+	req.Decision = ApprovalDenied
+	if approved {
+		req.Decision = ApprovalApproved
+	}
+	return SaveApprovalRequest(path, req)
+}
+
+// WaitForApproval polls the request file until the request with the given ID
+// is decided or the timeout expires. The request file is removed once decided.
+// A missing or replaced request counts as denied.
+func WaitForApproval(path, id string, timeout, interval time.Duration) ApprovalDecision {
+	deadline := time.Now().Add(timeout)
+	for {
+		req := LoadApprovalRequest(path)
+		if req == nil || req.ID != id {
+			return ApprovalDenied
+		}
+		if req.Decision != ApprovalPending {
+			_ = os.Remove(path)
+			return req.Decision
+		}
+		if !time.Now().Before(deadline) {
+			_ = os.Remove(path)
+			return ApprovalTimedOut
+		}
+		time.Sleep(interval)
+	}
+}
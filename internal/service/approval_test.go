@@ -0,0 +1,83 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMatchApprovalRule(t *testing.T) {
+	rules := []string{"rm -rf", "terraform apply", "kubectl delete", "git push --force"}
+
+	tests := []struct {
+		name string
+		cmd  string
+		args []string
+		want string
+	}{
+		{"exact", "rm", []string{"-rf", "build"}, "rm -rf"},
+		{"reordered cluster", "rm", []string{"-fr", "build"}, "rm -rf"},
+		{"separate flags", "rm", []string{"-r", "-f", "build"}, "rm -rf"},
+		{"missing flag", "rm", []string{"-r", "build"}, ""},
+		{"plain rm", "rm", []string{"file.txt"}, ""},
+		{"absolute path", "/usr/bin/kubectl", []string{"delete", "pod", "x"}, "kubectl delete"},
+		{"subcommand", "terraform", []string{"apply", "-auto-approve"}, "terraform apply"},
+		{"other subcommand", "terraform", []string{"plan"}, ""},
+		{"long flag with value", "git", []string{"push", "--force=true"}, "git push --force"},
+		{"unknown command", "ls", []string{"-rf"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchApprovalRule(rules, tt.cmd, tt.args); got != tt.want {
+				t.Errorf("MatchApprovalRule(%s %v) = %q, want %q", tt.cmd, tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApprovalCommandNames(t *testing.T) {
+	names := ApprovalCommandNames([]string{"rm -rf", "kubectl delete", "kubectl drain", "  "})
+	if len(names) != 2 || names[0] != "rm" || names[1] != "kubectl" {
+		t.Errorf("ApprovalCommandNames() = %v, want [rm kubectl]", names)
+	}
+}
+
+func TestApprovalRequestDecision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".approval.json")
+
+	req := NewApprovalRequest("my-task", "rm -rf", "rm", []string{"-rf", "build"}, "/tmp")
+	if err := SaveApprovalRequest(path, req); err != nil {
+		t.Fatalf("SaveApprovalRequest() error = %v", err)
+	}
+
+	if err := DecideApproval(path, "other-id", true); err == nil {
+		t.Error("DecideApproval() with wrong ID expected error")
+	}
+	if err := DecideApproval(path, req.ID, true); err != nil {
+		t.Fatalf("DecideApproval() error = %v", err)
+	}
+	if err := DecideApproval(path, req.ID, false); err == nil {
+		t.Error("DecideApproval() twice expected error")
+	}
+
+	if got := WaitForApproval(path, req.ID, time.Second, time.Millisecond); got != ApprovalApproved {
+		t.Errorf("WaitForApproval() = %q, want %q", got, ApprovalApproved)
+	}
+	if LoadApprovalRequest(path) != nil {
+		t.Error("request file should be removed after decision")
+	}
+}
+
+func TestWaitForApprovalTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".approval.json")
+
+	req := NewApprovalRequest("my-task", "rm -rf", "rm", []string{"-rf", "/"}, "")
+	if err := SaveApprovalRequest(path, req); err != nil {
+		t.Fatalf("SaveApprovalRequest() error = %v", err)
+	}
+
+	if got := WaitForApproval(path, req.ID, 10*time.Millisecond, time.Millisecond); got != ApprovalTimedOut {
+		t.Errorf("WaitForApproval() = %q, want %q", got, ApprovalTimedOut)
+	}
+}
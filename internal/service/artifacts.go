@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// artifactsHistoryDir is the history subdirectory holding saved task artifacts.
+const artifactsHistoryDir = "artifacts"
+
+// ArtifactSet is a set of artifacts saved to history when a task finished.
+type ArtifactSet struct {
+	Dir   string   // Saved directory (history/artifacts/YYMMDD_HHMMSS_taskname)
+	Files []string // File paths relative to Dir
+}
+
+// SaveArtifacts copies a task's artifacts directory to history, encrypting the
+// files if history encryption is enabled. Returns the saved directory, or ""
+// if the task has no artifacts.
+func (s *HistoryService) SaveArtifacts(taskName, artifactsDir string) (string, error) {
+	files, err := listArtifactFiles(artifactsDir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	timestamp := time.Now().Format("060102_150405")
+	dst := filepath.Join(s.historyDir, artifactsHistoryDir, fmt.Sprintf("%s_%s", timestamp, taskName))
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(artifactsDir, rel)) //nolint:gosec // G304: artifacts are in the task's agent directory
+		if err != nil {
+			return "", fmt.Errorf("failed to read artifact %s: %w", rel, err)
+		}
+		target := filepath.Join(dst, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+			return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+		}
+		if err := s.writeFile(target, data, s.encrypt); err != nil {
+			return "", fmt.Errorf("failed to save artifact %s: %w", rel, err)
+		}
+	}
+
+	logging.Debug("Task artifacts saved: %s (%d files)", dst, len(files))
+	return dst, nil
+}
+
+// ListArtifacts returns the artifacts saved to history for a task, newest first.
+func (s *HistoryService) ListArtifacts(taskName string) ([]ArtifactSet, error) {
+	root := filepath.Join(s.historyDir, artifactsHistoryDir)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read artifacts history: %w", err)
+	}
+
+	var sets []ArtifactSet
+	for _, entry := range entries {
+		if !entry.IsDir() || ExtractTaskName(entry.Name()) != taskName {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		files, err := listArtifactFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, ArtifactSet{Dir: dir, Files: files})
+	}
+
+	// Directory names start with a timestamp, so they sort chronologically
+	sort.Slice(sets, func(i, j int) bool {
+		return filepath.Base(sets[i].Dir) > filepath.Base(sets[j].Dir)
+	})
+	return sets, nil
+}
+
+// listArtifactFiles returns the regular files under dir relative to dir, sorted.
+// A missing directory has no artifacts. Hidden files and symlinks are skipped.
+func listArtifactFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ListTaskArtifacts returns the files in a running task's artifacts directory.
+func ListTaskArtifacts(artifactsDir string) ([]string, error) {
+	return listArtifactFiles(artifactsDir)
+}
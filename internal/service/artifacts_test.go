@@ -0,0 +1,70 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveAndListArtifacts(t *testing.T) {
+	historyDir := t.TempDir()
+	artifactsDir := filepath.Join(t.TempDir(), "artifacts")
+	for name, content := range map[string]string{
+		"report.md":         "# Report\n",
+		"build/out.txt":     "ok\n",
+		".hidden":           "skip\n",
+		".cache/ignored.db": "skip\n",
+	} {
+		path := filepath.Join(artifactsDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write artifact: %v", err)
+		}
+	}
+
+	svc := NewHistoryService(historyDir)
+	saved, err := svc.SaveArtifacts("my-task", artifactsDir)
+	if err != nil {
+		t.Fatalf("SaveArtifacts() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(saved, "build", "out.txt")); err != nil || string(data) != "ok\n" {
+		t.Errorf("saved build/out.txt = %q, %v", data, err)
+	}
+
+	sets, err := svc.ListArtifacts("my-task")
+	if err != nil {
+		t.Fatalf("ListArtifacts() error = %v", err)
+	}
+	if len(sets) != 1 || sets[0].Dir != saved {
+		t.Fatalf("ListArtifacts() = %+v, want one set at %s", sets, saved)
+	}
+	if want := []string{filepath.Join("build", "out.txt"), "report.md"}; !reflect.DeepEqual(sets[0].Files, want) {
+		t.Errorf("Files = %q, want %q", sets[0].Files, want)
+	}
+
+	if sets, _ := svc.ListArtifacts("other-task"); len(sets) != 0 {
+		t.Errorf("ListArtifacts(other-task) = %+v, want none", sets)
+	}
+
+	// Saved artifacts must not show up as history entries
+	if files, _ := svc.ListHistoryFiles(); len(files) != 0 {
+		t.Errorf("ListHistoryFiles() = %q, want none", files)
+	}
+}
+
+func TestSaveArtifactsEmpty(t *testing.T) {
+	svc := NewHistoryService(t.TempDir())
+
+	saved, err := svc.SaveArtifacts("my-task", filepath.Join(t.TempDir(), "missing"))
+	if err != nil || saved != "" {
+		t.Errorf("SaveArtifacts(missing) = %q, %v; want \"\", nil", saved, err)
+	}
+
+	saved, err = svc.SaveArtifacts("my-task", t.TempDir())
+	if err != nil || saved != "" {
+		t.Errorf("SaveArtifacts(empty) = %q, %v; want \"\", nil", saved, err)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// autoAnswerSeparator separates the pattern from the response in a rule.
+const autoAnswerSeparator = "=>"
+
+// AutoAnswerRule answers an agent prompt whose text matches Pattern.
+type AutoAnswerRule struct {
+	Source   string // Rule as written in the config
+	Pattern  *regexp.Regexp
+	Response string
+}
+
+// AutoAnswerRecord is a single auto-answer given to a task.
+type AutoAnswerRecord struct {
+	Time     time.Time `json:"time"`
+	Task     string    `json:"task"`
+	Rule     string    `json:"rule"`
+	Matched  string    `json:"matched"`
+	Response string    `json:"response"`
+}
+
+// ParseAutoAnswerRules parses "pattern => response" rules. Invalid rules are
+// skipped and reported in errs.
+func ParseAutoAnswerRules(lines []string) (rules []AutoAnswerRule, errs []error) {
+	for _, line := range lines {
+		pattern, response, ok := strings.Cut(line, autoAnswerSeparator)
+		pattern = strings.TrimSpace(pattern)
+		response = strings.TrimSpace(response)
+		if !ok || pattern == "" || response == "" {
+			errs = append(errs, fmt.Errorf("auto answer %q: expected \"pattern %s response\"", line, autoAnswerSeparator))
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("auto answer %q: %w", line, err))
+			continue
+		}
+		rules = append(rules, AutoAnswerRule{Source: line, Pattern: re, Response: response})
+	}
+	return rules, errs
+}
+
+// MatchAutoAnswer returns the first rule matching the pane tail and the
+// matched text, or nil if no rule matches.
+func MatchAutoAnswer(rules []AutoAnswerRule, tail string) (*AutoAnswerRule, string) {
+	for i := range rules {
+		if match := rules[i].Pattern.FindString(tail); match != "" {
+			return &rules[i], match
+		}
+	}
+	return nil, ""
+}
+
+// PaneTail returns the last n non-empty lines of captured pane content.
+func PaneTail(content string, n int) string {
+	lines := strings.Split(content, "\n")
+	tail := make([]string, 0, n)
+	for i := len(lines) - 1; i >= 0 && len(tail) < n; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			tail = append(tail, lines[i])
+		}
+	}
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return strings.Join(tail, "\n")
+}
+
+// AppendAutoAnswer appends a record to a task's auto-answer log.
+func AppendAutoAnswer(path string, rec AutoAnswerRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: log is read by paw audit
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadAutoAnswers reads a task's auto-answer log. A missing log yields no records.
+func LoadAutoAnswers(path string) ([]AutoAnswerRecord, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is in the agent directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []AutoAnswerRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec AutoAnswerRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue // Skip partial lines
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAutoAnswerRules(t *testing.T) {
+	rules, errs := ParseAutoAnswerRules([]string{
+		`Proceed with npm install\? => y`,
+		`Overwrite (.*)\? (\[y/N\])? => n`,
+		`missing separator`,
+		`([unclosed => y`,
+		` => y`,
+	})
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	if len(errs) != 3 {
+		t.Errorf("got %d errors, want 3: %v", len(errs), errs)
+	}
+	if rules[0].Response != "y" || rules[1].Response != "n" {
+		t.Errorf("responses = %q, %q", rules[0].Response, rules[1].Response)
+	}
+}
+
+func TestMatchAutoAnswer(t *testing.T) {
+	rules, _ := ParseAutoAnswerRules([]string{
+		`Proceed with npm install\? => y`,
+		`(?i)overwrite .*\? => n`,
+	})
+
+	rule, match := MatchAutoAnswer(rules, "added 12 packages\nProceed with npm install? (y)")
+	if rule == nil || rule.Response != "y" || match != "Proceed with npm install?" {
+		t.Errorf("MatchAutoAnswer() = %v, %q", rule, match)
+	}
+	if rule, _ := MatchAutoAnswer(rules, "OVERWRITE config.yaml?"); rule == nil || rule.Response != "n" {
+		t.Errorf("case-insensitive rule did not match")
+	}
+	if rule, _ := MatchAutoAnswer(rules, "Done."); rule != nil {
+		t.Errorf("MatchAutoAnswer() matched %q, want no match", rule.Source)
+	}
+}
+
+func TestPaneTail(t *testing.T) {
+	content := "one\ntwo\n\nthree\nfour\n\n\n"
+	if got := PaneTail(content, 2); got != "three\nfour" {
+		t.Errorf("PaneTail(2) = %q", got)
+	}
+	if got := PaneTail(content, 10); got != "one\ntwo\nthree\nfour" {
+		t.Errorf("PaneTail(10) = %q", got)
+	}
+}
+
+func TestAutoAnswerLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".answers.jsonl")
+
+	records, err := LoadAutoAnswers(path)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("LoadAutoAnswers(missing) = %v, %v", records, err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	for _, resp := range []string{"y", "n"} {
+		rec := AutoAnswerRecord{Time: now, Task: "my-task", Rule: "x => " + resp, Matched: "x", Response: resp}
+		if err := AppendAutoAnswer(path, rec); err != nil {
+			t.Fatalf("AppendAutoAnswer() error = %v", err)
+		}
+	}
+
+	records, err = LoadAutoAnswers(path)
+	if err != nil {
+		t.Fatalf("LoadAutoAnswers() error = %v", err)
+	}
+	if len(records) != 2 || records[0].Response != "y" || records[1].Response != "n" || !records[0].Time.Equal(now) {
+		t.Errorf("LoadAutoAnswers() = %+v", records)
+	}
+}
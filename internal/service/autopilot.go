@@ -0,0 +1,531 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// AutopilotManifest is a work manifest for 'paw autopilot': the tasks to run
+// unattended and how to run them.
+type AutopilotManifest struct {
+	Parallel    int           // Tasks running at a time
+	OnComplete  string        // merge, merge-push, or pr
+	Model       string        // Default model of the tasks
+	TaskTimeout time.Duration // 0 means no timeout
+	Tasks       []AutopilotTask
+}
+
+// AutopilotTask is a task in a work manifest.
+type AutopilotTask struct {
+	Name      string
+	Prompt    string
+	Model     string
+	DependsOn string // Starts once this earlier task merged
+}
+
+// ParseAutopilotManifest parses a work manifest. The format is a YAML subset:
+//
+//	parallel: 2
+//	on_complete: merge
+//	tasks:
+//	  - name: fix-login-timeout
+//	    prompt: Fix the login timeout in auth/session.go
+//	  - name: update-docs
+//	    depends_on: fix-login-timeout
+//	    prompt: |
+//	      Document the new timeout setting.
+func ParseAutopilotManifest(content string) (*AutopilotManifest, error) {
+	m := &AutopilotManifest{
+		Parallel:    1,
+		OnComplete:  constants.ActionMerge,
+		TaskTimeout: constants.AutopilotDefaultTaskTimeout,
+	}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); {
+		if isManifestBlank(lines[i]) {
+			i++
+			continue
+		}
+		if countLeadingSpaces(lines[i]) > 0 {
+			return nil, fmt.Errorf("line %d: unexpected indentation", i+1)
+		}
+		key, value, ok := manifestKeyValue(lines[i])
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", i+1)
+		}
+		lineNo := i + 1
+		i++
+
+		if key == "tasks" {
+			tasks, next, err := parseManifestTasks(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			m.Tasks = append(m.Tasks, tasks...)
+			i = next
+			continue
+		}
+		if err := m.set(key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *AutopilotManifest) set(key, value string) error {
+	switch key {
+	case "parallel":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > constants.AutopilotMaxParallel {
+			return fmt.Errorf("parallel must be 1-%d", constants.AutopilotMaxParallel)
+		}
+		m.Parallel = n
+	case "on_complete":
+		switch value {
+		case constants.ActionMerge, constants.ActionMergePush, constants.ActionPR:
+			m.OnComplete = value
+		default:
+			return fmt.Errorf("on_complete must be merge, merge-push, or pr, not %q", value)
+		}
+	case "model":
+		m.Model = value
+	case "task_timeout":
+		if value == "0" {
+			m.TaskTimeout = 0
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid task_timeout %q (e.g. 3h, 90m, 0 for none)", value)
+		}
+		m.TaskTimeout = d
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// parseManifestTasks parses the "- key: value" items of the tasks list that
+// starts at line i. Returns the tasks and the first line after the list.
+func parseManifestTasks(lines []string, i int) ([]AutopilotTask, int, error) {
+	var tasks []AutopilotTask
+	keyIndent := -1
+	for i < len(lines) {
+		line := lines[i]
+		if isManifestBlank(line) {
+			i++
+			continue
+		}
+		indent := countLeadingSpaces(line)
+		if indent == 0 {
+			break
+		}
+		lineNo := i + 1
+		text := line[indent:]
+		if text == "-" || strings.HasPrefix(text, "- ") {
+			tasks = append(tasks, AutopilotTask{})
+			keyIndent = indent + 2
+			text = strings.TrimSpace(strings.TrimPrefix(text, "-"))
+			if text == "" {
+				i++
+				continue
+			}
+		} else if len(tasks) == 0 || indent != keyIndent {
+			return nil, 0, fmt.Errorf("line %d: expected a \"- name: ...\" task item", lineNo)
+		}
+
+		key, value, ok := manifestKeyValue(text)
+		if !ok {
+			return nil, 0, fmt.Errorf("line %d: expected key: value", lineNo)
+		}
+		i++
+		if value == "|" {
+			value, i = manifestBlock(lines, i, keyIndent)
+		}
+		t := &tasks[len(tasks)-1]
+		switch key {
+		case "name":
+			t.Name = value
+		case "prompt":
+			t.Prompt = value
+		case "model":
+			t.Model = value
+		case "depends_on":
+			t.DependsOn = value
+		default:
+			return nil, 0, fmt.Errorf("line %d: unknown task key %q", lineNo, key)
+		}
+	}
+	return tasks, i, nil
+}
+
+// manifestBlock reads a "|" block scalar: the lines after i indented deeper
+// than parentIndent, with their common indentation removed.
+func manifestBlock(lines []string, i, parentIndent int) (string, int) {
+	var block []string
+	strip := -1
+	for i < len(lines) {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			block = append(block, "")
+			i++
+			continue
+		}
+		indent := countLeadingSpaces(line)
+		if indent <= parentIndent {
+			break
+		}
+		if strip < 0 {
+			strip = indent
+		}
+		block = append(block, line[min(indent, strip):])
+		i++
+	}
+	return strings.TrimRight(strings.Join(block, "\n"), "\n"), i
+}
+
+// manifestKeyValue splits a "key: value" line. Values may be quoted; an
+// unquoted value ends at a " #" comment.
+func manifestKeyValue(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+	if !ok || strings.TrimSpace(key) == "" {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return strings.TrimSpace(key), value[1 : end+1], true
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return strings.TrimSpace(key), value, true
+}
+
+func isManifestBlank(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+func countLeadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
+
+func (m *AutopilotManifest) validate() error {
+	if len(m.Tasks) == 0 {
+		return errors.New("manifest has no tasks")
+	}
+	if m.Model != "" && !validModel(m.Model) {
+		return fmt.Errorf("unknown model %q", m.Model)
+	}
+	seen := make(map[string]bool, len(m.Tasks))
+	for i, t := range m.Tasks {
+		if t.Name == "" || strings.TrimSpace(t.Prompt) == "" {
+			return fmt.Errorf("task %d is missing a name or prompt", i+1)
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("duplicate task name: %s", t.Name)
+		}
+		if t.Model != "" && !validModel(t.Model) {
+			return fmt.Errorf("task %s: unknown model %q", t.Name, t.Model)
+		}
+		if t.DependsOn != "" && !seen[t.DependsOn] {
+			return fmt.Errorf("task %s depends on %q, which is not an earlier task", t.Name, t.DependsOn)
+		}
+		seen[t.Name] = true
+	}
+	return nil
+}
+
+// AutopilotState is where an autopilot task stands.
+type AutopilotState string
+
+// Autopilot task states. Queued and running are the only non-final ones.
+const (
+	AutopilotQueued   AutopilotState = "queued"
+	AutopilotRunning  AutopilotState = "running"
+	AutopilotMerged   AutopilotState = "merged"
+	AutopilotFinished AutopilotState = "finished" // Ended without a merge (e.g. a PR was opened)
+	AutopilotBlocked  AutopilotState = "blocked"  // Needs the user; the task is kept
+	AutopilotDropped  AutopilotState = "dropped"
+	AutopilotSkipped  AutopilotState = "skipped" // Its dependency did not merge
+)
+
+// AutopilotRun is the state of an autopilot run, saved in the workspace.
+type AutopilotRun struct {
+	ID                 string          `json:"id"`
+	Manifest           string          `json:"manifest"`
+	Parallel           int             `json:"parallel"`
+	OnComplete         string          `json:"on_complete"`
+	TaskTimeoutSeconds int64           `json:"task_timeout_seconds,omitempty"`
+	StartedAt          string          `json:"started_at"`
+	FinishedAt         string          `json:"finished_at,omitempty"`
+	Items              []AutopilotItem `json:"items"`
+}
+
+// AutopilotItem is a manifest task in a run.
+type AutopilotItem struct {
+	Name         string         `json:"name"`
+	Prompt       string         `json:"prompt"`
+	Model        string         `json:"model,omitempty"`
+	DependsOn    string         `json:"depends_on,omitempty"`
+	Task         string         `json:"task,omitempty"` // Name of the created task
+	State        AutopilotState `json:"state"`
+	Reason       string         `json:"reason,omitempty"`
+	Commit       string         `json:"commit,omitempty"` // Merge commit
+	StartedAt    string         `json:"started_at,omitempty"`
+	FinishedAt   string         `json:"finished_at,omitempty"`
+	StalledSince string         `json:"stalled_since,omitempty"` // Idle without merging since
+}
+
+// NewAutopilotRun creates a run with every manifest task queued.
+func NewAutopilotRun(m *AutopilotManifest, manifestPath string, now time.Time) *AutopilotRun {
+	r := &AutopilotRun{
+		ID:                 now.Format("20060102-150405"),
+		Manifest:           manifestPath,
+		Parallel:           m.Parallel,
+		OnComplete:         m.OnComplete,
+		TaskTimeoutSeconds: int64(m.TaskTimeout / time.Second),
+		StartedAt:          now.Format(time.RFC3339),
+	}
+	for _, t := range m.Tasks {
+		model := t.Model
+		if model == "" {
+			model = m.Model
+		}
+		r.Items = append(r.Items, AutopilotItem{
+			Name:      t.Name,
+			Prompt:    strings.TrimSpace(t.Prompt),
+			Model:     model,
+			DependsOn: t.DependsOn,
+			State:     AutopilotQueued,
+		})
+	}
+	return r
+}
+
+// TaskTimeout returns how long a task may run before it is reported blocked
+// (0 means no limit).
+func (r *AutopilotRun) TaskTimeout() time.Duration {
+	return time.Duration(r.TaskTimeoutSeconds) * time.Second
+}
+
+// item returns the item with the given manifest name.
+func (r *AutopilotRun) item(name string) *AutopilotItem {
+	for i := range r.Items {
+		if r.Items[i].Name == name {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
+
+// Startable skips queued items whose dependency ended without merging, and
+// returns the indexes of the queued items to start now: those whose
+// dependency merged, up to the run's parallelism.
+func (r *AutopilotRun) Startable(now time.Time) []int {
+	running := 0
+	for _, it := range r.Items {
+		if it.State == AutopilotRunning {
+			running++
+		}
+	}
+
+	var ready []int
+	for i := range r.Items {
+		it := &r.Items[i]
+		if it.State != AutopilotQueued {
+			continue
+		}
+		if it.DependsOn != "" {
+			dep := r.item(it.DependsOn)
+			switch {
+			case dep == nil || dep.State == AutopilotMerged:
+			case dep.State == AutopilotQueued || dep.State == AutopilotRunning:
+				continue
+			default:
+				// Items are in manifest order, so a skip reaches later dependents
+				r.Finish(i, AutopilotSkipped, fmt.Sprintf("%s was not merged (%s)", dep.Name, dep.State), "", now)
+				continue
+			}
+		}
+		if running+len(ready) < r.Parallel {
+			ready = append(ready, i)
+		}
+	}
+	return ready
+}
+
+// Start marks an item running as the given task.
+func (r *AutopilotRun) Start(i int, taskName string, now time.Time) {
+	it := &r.Items[i]
+	it.Task = taskName
+	it.State = AutopilotRunning
+	it.StartedAt = now.Format(time.RFC3339)
+}
+
+// Finish records an item's outcome.
+func (r *AutopilotRun) Finish(i int, state AutopilotState, reason, commit string, now time.Time) {
+	it := &r.Items[i]
+	it.State = state
+	it.Reason = reason
+	it.Commit = commit
+	it.StalledSince = ""
+	it.FinishedAt = now.Format(time.RFC3339)
+}
+
+// Done reports whether every item reached a final state.
+func (r *AutopilotRun) Done() bool {
+	for _, it := range r.Items {
+		if it.State == AutopilotQueued || it.State == AutopilotRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of items in a state.
+func (r *AutopilotRun) Count(state AutopilotState) int {
+	n := 0
+	for _, it := range r.Items {
+		if it.State == state {
+			n++
+		}
+	}
+	return n
+}
+
+// LoadAutopilotRun reads the latest run. Returns nil without error if there
+// is none.
+func LoadAutopilotRun(pawDir string) (*AutopilotRun, error) {
+	data, err := os.ReadFile(filepath.Join(pawDir, constants.AutopilotFileName)) //nolint:gosec // G304: path is constructed from pawDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read autopilot run: %w", err)
+	}
+	var r AutopilotRun
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse autopilot run: %w", err)
+	}
+	return &r, nil
+}
+
+// SaveAutopilotRun writes the run's state.
+func SaveAutopilotRun(pawDir string, r *AutopilotRun) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal autopilot run: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(pawDir, constants.AutopilotFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write autopilot run: %w", err)
+	}
+	return nil
+}
+
+// autopilotReportGroups orders the report's sections.
+var autopilotReportGroups = []struct {
+	state AutopilotState
+	title string
+}{
+	{AutopilotMerged, "✅ Merged"},
+	{AutopilotFinished, "📬 Finished without merging"},
+	{AutopilotBlocked, "⛔ Blocked"},
+	{AutopilotDropped, "🗑️ Dropped"},
+	{AutopilotSkipped, "⏭️ Skipped"},
+	{AutopilotRunning, "🔄 Running"},
+	{AutopilotQueued, "⏳ Queued"},
+}
+
+// FormatAutopilotReport renders the run as a Markdown report: a summary
+// line, then the tasks grouped by outcome.
+func FormatAutopilotReport(r *AutopilotRun) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Autopilot report %s\n\n", r.ID)
+	fmt.Fprintf(&sb, "- Manifest: %s\n", r.Manifest)
+	fmt.Fprintf(&sb, "- Started: %s\n", formatReportTime(r.StartedAt))
+	if r.FinishedAt != "" {
+		fmt.Fprintf(&sb, "- Finished: %s\n", formatReportTime(r.FinishedAt))
+	}
+	fmt.Fprintf(&sb, "- on_complete: %s, %d at a time\n\n", r.OnComplete, r.Parallel)
+
+	var counts []string
+	for _, g := range autopilotReportGroups {
+		if n := r.Count(g.state); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, g.state))
+		}
+	}
+	sb.WriteString(strings.Join(counts, ", ") + "\n")
+
+	for _, g := range autopilotReportGroups {
+		if r.Count(g.state) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s\n\n", g.title)
+		for _, it := range r.Items {
+			if it.State != g.state {
+				continue
+			}
+			sb.WriteString("- " + it.Name)
+			if it.Task != "" && it.Task != it.Name {
+				sb.WriteString(" (task " + it.Task + ")")
+			}
+			var details []string
+			if it.Commit != "" {
+				details = append(details, shortHash(it.Commit))
+			}
+			if d := itemDuration(it); d > 0 {
+				details = append(details, d.String())
+			}
+			if it.Reason != "" {
+				details = append(details, it.Reason)
+			}
+			if len(details) > 0 {
+				sb.WriteString(": " + strings.Join(details, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func formatReportTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// itemDuration returns how long a finished item ran, to the minute.
+func itemDuration(it AutopilotItem) time.Duration {
+	start, err1 := time.Parse(time.RFC3339, it.StartedAt)
+	end, err2 := time.Parse(time.RFC3339, it.FinishedAt)
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Round(time.Minute)
+}
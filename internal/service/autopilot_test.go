@@ -0,0 +1,173 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+const testAutopilotManifest = `# Overnight work
+parallel: 2
+on_complete: merge-push   # push what merges
+model: sonnet
+task_timeout: 90m
+
+tasks:
+  - name: fix-login-timeout
+    prompt: "Fix the login timeout in auth/session.go"
+  - name: update-docs
+    depends_on: fix-login-timeout
+    model: haiku
+    prompt: |
+      Document the new timeout setting.
+
+        Keep the example indented.
+  -
+    name: bump-deps
+    prompt: Bump the dependencies # trailing comment
+`
+
+func TestParseAutopilotManifest(t *testing.T) {
+	m, err := ParseAutopilotManifest(testAutopilotManifest)
+	if err != nil {
+		t.Fatalf("ParseAutopilotManifest() error = %v", err)
+	}
+	if m.Parallel != 2 || m.OnComplete != constants.ActionMergePush || m.Model != "sonnet" || m.TaskTimeout != 90*time.Minute {
+		t.Errorf("manifest = %+v", m)
+	}
+	if len(m.Tasks) != 3 {
+		t.Fatalf("got %d tasks, want 3: %+v", len(m.Tasks), m.Tasks)
+	}
+	if m.Tasks[0].Prompt != "Fix the login timeout in auth/session.go" {
+		t.Errorf("tasks[0].Prompt = %q", m.Tasks[0].Prompt)
+	}
+	if got, want := m.Tasks[1].Prompt, "Document the new timeout setting.\n\n  Keep the example indented."; got != want {
+		t.Errorf("tasks[1].Prompt = %q, want %q", got, want)
+	}
+	if m.Tasks[1].DependsOn != "fix-login-timeout" || m.Tasks[1].Model != "haiku" {
+		t.Errorf("tasks[1] = %+v", m.Tasks[1])
+	}
+	if m.Tasks[2].Name != "bump-deps" || m.Tasks[2].Prompt != "Bump the dependencies" {
+		t.Errorf("tasks[2] = %+v", m.Tasks[2])
+	}
+
+	m, err = ParseAutopilotManifest("tasks:\n  - name: a\n    prompt: do a\n")
+	if err != nil {
+		t.Fatalf("ParseAutopilotManifest(minimal) error = %v", err)
+	}
+	if m.Parallel != 1 || m.OnComplete != constants.ActionMerge || m.TaskTimeout != constants.AutopilotDefaultTaskTimeout {
+		t.Errorf("defaults = %+v", m)
+	}
+}
+
+func TestParseAutopilotManifest_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no tasks":          "parallel: 1\n",
+		"bad parallel":      "parallel: 99\ntasks:\n  - name: a\n    prompt: x\n",
+		"bad on_complete":   "on_complete: drop\ntasks:\n  - name: a\n    prompt: x\n",
+		"bad timeout":       "task_timeout: soon\ntasks:\n  - name: a\n    prompt: x\n",
+		"unknown key":       "paralel: 2\ntasks:\n  - name: a\n    prompt: x\n",
+		"unknown task key":  "tasks:\n  - name: a\n    promt: x\n",
+		"missing prompt":    "tasks:\n  - name: a\n",
+		"duplicate name":    "tasks:\n  - name: a\n    prompt: x\n  - name: a\n    prompt: y\n",
+		"later dependency":  "tasks:\n  - name: a\n    depends_on: b\n    prompt: x\n  - name: b\n    prompt: y\n",
+		"unknown model":     "model: gpt\ntasks:\n  - name: a\n    prompt: x\n",
+		"not a list item":   "tasks:\n  name: a\n",
+		"stray indentation": "  parallel: 2\n",
+	}
+	for name, manifest := range tests {
+		if _, err := ParseAutopilotManifest(manifest); err == nil {
+			t.Errorf("%s: ParseAutopilotManifest() error = nil, want error", name)
+		}
+	}
+}
+
+func TestAutopilotRun_Startable(t *testing.T) {
+	m, err := ParseAutopilotManifest("parallel: 2\ntasks:\n" +
+		"  - name: a\n    prompt: x\n" +
+		"  - name: b\n    depends_on: a\n    prompt: x\n" +
+		"  - name: c\n    depends_on: b\n    prompt: x\n" +
+		"  - name: d\n    prompt: x\n" +
+		"  - name: e\n    prompt: x\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	r := NewAutopilotRun(m, "plan.yaml", now)
+
+	// b and c wait for a; two start at a time
+	if got := r.Startable(now); len(got) != 2 || got[0] != 0 || got[1] != 3 {
+		t.Fatalf("Startable() = %v, want [0 3]", got)
+	}
+	r.Start(0, "a", now)
+	r.Start(3, "d", now)
+	if got := r.Startable(now); len(got) != 0 {
+		t.Fatalf("Startable() with two running = %v, want none", got)
+	}
+
+	// a is blocked: b and c (through b) are skipped, e takes the free slot
+	r.Finish(0, AutopilotBlocked, "verification failed", "", now)
+	if got := r.Startable(now); len(got) != 1 || got[0] != 4 {
+		t.Fatalf("Startable() = %v, want [4]", got)
+	}
+	if r.Items[1].State != AutopilotSkipped || r.Items[2].State != AutopilotSkipped {
+		t.Errorf("dependents = %s, %s; want skipped", r.Items[1].State, r.Items[2].State)
+	}
+	if !strings.Contains(r.Items[1].Reason, "a was not merged") {
+		t.Errorf("skip reason = %q", r.Items[1].Reason)
+	}
+
+	r.Start(4, "e", now)
+	if r.Done() {
+		t.Error("Done() = true with running items")
+	}
+	r.Finish(3, AutopilotMerged, "", "abcdef123456", now)
+	r.Finish(4, AutopilotMerged, "", "0123456789ab", now)
+	if !r.Done() || r.Count(AutopilotMerged) != 2 || r.Count(AutopilotSkipped) != 2 {
+		t.Errorf("Done() = %v, merged = %d, skipped = %d", r.Done(), r.Count(AutopilotMerged), r.Count(AutopilotSkipped))
+	}
+}
+
+func TestAutopilotRun_SaveLoadAndReport(t *testing.T) {
+	dir := t.TempDir()
+	if r, err := LoadAutopilotRun(dir); r != nil || err != nil {
+		t.Fatalf("LoadAutopilotRun(empty) = %v, %v; want nil, nil", r, err)
+	}
+
+	m, err := ParseAutopilotManifest("tasks:\n  - name: fix-login\n    prompt: x\n  - name: bump-deps\n    prompt: y\n  - name: docs\n    prompt: z\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 15, 22, 0, 0, 0, time.UTC)
+	r := NewAutopilotRun(m, "plan.yaml", start)
+	r.Start(0, "fix-login", start)
+	r.Finish(0, AutopilotMerged, "", "a1b2c3d4e5f6", start.Add(72*time.Minute))
+	r.Start(1, "bump-deps-2", start)
+	r.Finish(1, AutopilotBlocked, "verification failed (test)", "", start.Add(2*time.Hour))
+
+	if err := SaveAutopilotRun(dir, r); err != nil {
+		t.Fatalf("SaveAutopilotRun() error = %v", err)
+	}
+	loaded, err := LoadAutopilotRun(dir)
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadAutopilotRun() = %v, %v", loaded, err)
+	}
+	if loaded.ID != "20261015-220000" || loaded.TaskTimeout() != constants.AutopilotDefaultTaskTimeout || loaded.Items[1].Task != "bump-deps-2" {
+		t.Errorf("loaded run = %+v", loaded)
+	}
+
+	report := FormatAutopilotReport(loaded)
+	for _, want := range []string{
+		"# Autopilot report 20261015-220000",
+		"1 merged, 1 blocked, 1 queued",
+		"## ✅ Merged\n\n- fix-login: a1b2c3d, 1h12m0s\n",
+		"## ⛔ Blocked\n\n- bump-deps (task bump-deps-2): 2h0m0s, verification failed (test)\n",
+		"## ⏳ Queued\n\n- docs\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// ChangelogEntry is a merged task's changelog entry, derived from its
+// conventional commit header ("type(scope)!: subject").
+type ChangelogEntry struct {
+	Type     string
+	Scope    string
+	Subject  string
+	Breaking bool
+	Task     string
+	Date     time.Time
+}
+
+var conventionalHeaderPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changelogSections maps commit types to release note sections.
+var changelogSections = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"refactor": "Refactoring",
+	"docs":     "Documentation",
+	"test":     "Tests",
+	"build":    "Build",
+	"ci":       "CI",
+	"style":    "Style",
+	"chore":    "Chores",
+}
+
+// ParseChangelogEntry builds an entry from a commit message's header. A
+// header that isn't a conventional commit becomes a "chore" entry.
+func ParseChangelogEntry(message, taskName string, date time.Time) ChangelogEntry {
+	header := strings.TrimSpace(firstLine(strings.TrimSpace(message)))
+	entry := ChangelogEntry{Type: "chore", Subject: header, Task: taskName, Date: date}
+	if m := conventionalHeaderPattern.FindStringSubmatch(header); m != nil {
+		entry.Type = strings.ToLower(m[1])
+		entry.Scope = m[2]
+		entry.Breaking = m[3] == "!"
+		entry.Subject = m[4]
+	}
+	return entry
+}
+
+// Section returns the release note section for the entry's type.
+func (e ChangelogEntry) Section() string {
+	if e.Breaking {
+		return "Breaking Changes"
+	}
+	if section, ok := changelogSections[e.Type]; ok {
+		return section
+	}
+	return "Other"
+}
+
+// Render fills a changelog template's {type}, {scope}, {section},
+// {subject}, {task}, and {date} placeholders.
+func (e ChangelogEntry) Render(template string) string {
+	return strings.NewReplacer(
+		"{type}", e.Type,
+		"{scope}", e.Scope,
+		"{section}", e.Section(),
+		"{subject}", e.Subject,
+		"{task}", e.Task,
+		"{date}", e.Date.Format("2006-01-02"),
+	).Replace(template)
+}
+
+// WriteChangelogFragment writes content to dir/<task>.<type>.md and returns
+// its path.
+func WriteChangelogFragment(dir string, e ChangelogEntry, content string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.%s.md", e.Task, e.Type))
+	if err := fileutil.WriteFileAtomic(path, []byte(strings.TrimRight(content, "\n")+"\n"), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// unreleasedHeadingPattern matches "## Unreleased" and "## [Unreleased]".
+var unreleasedHeadingPattern = regexp.MustCompile(`(?i)^#+\s*\[?unreleased\]?\s*$`)
+
+// AppendChangelog adds content to a changelog file: right below its
+// "Unreleased" heading if it has one, else at the end. A missing file is
+// created with an Unreleased heading.
+func AppendChangelog(path, content string) error {
+	content = strings.TrimRight(content, "\n")
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the project's changelog
+	if os.IsNotExist(err) {
+		data, err = []byte("# Changelog\n\n## Unreleased\n"), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	heading := -1
+	for i, line := range lines {
+		if unreleasedHeadingPattern.MatchString(strings.TrimSpace(line)) {
+			heading = i
+			break
+		}
+	}
+
+	var out []string
+	if heading < 0 {
+		out = lines
+		if last := strings.TrimSpace(lines[len(lines)-1]); !strings.HasPrefix(last, "-") && !strings.HasPrefix(last, "*") {
+			out = append(out, "")
+		}
+		out = append(out, content)
+	} else {
+		// Newest first, in the list right below the heading
+		rest := lines[heading+1:]
+		for len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+			rest = rest[1:]
+		}
+		out = append(out, lines[:heading+1]...)
+		out = append(out, "", content)
+		if len(rest) > 0 && strings.HasPrefix(strings.TrimSpace(rest[0]), "#") {
+			out = append(out, "")
+		}
+		out = append(out, rest...)
+	}
+	return fileutil.WriteFileAtomic(path, []byte(strings.Join(out, "\n")+"\n"), 0644)
+}
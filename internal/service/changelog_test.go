@@ -0,0 +1,97 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseChangelogEntry(t *testing.T) {
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		message string
+		want    ChangelogEntry
+		section string
+	}{
+		{"feat: Add dark mode\n\nChanges:\n- x", ChangelogEntry{Type: "feat", Subject: "Add dark mode"}, "Features"},
+		{"fix(api)!: Drop v1 endpoints", ChangelogEntry{Type: "fix", Scope: "api", Breaking: true, Subject: "Drop v1 endpoints"}, "Breaking Changes"},
+		{"Update readme", ChangelogEntry{Type: "chore", Subject: "Update readme"}, "Chores"},
+	}
+	for _, tt := range tests {
+		got := ParseChangelogEntry(tt.message, "task", date)
+		tt.want.Task, tt.want.Date = "task", date
+		if got != tt.want {
+			t.Errorf("ParseChangelogEntry(%q) = %+v, want %+v", tt.message, got, tt.want)
+		}
+		if got.Section() != tt.section {
+			t.Errorf("Section() = %q, want %q", got.Section(), tt.section)
+		}
+	}
+}
+
+func TestChangelogEntryRender(t *testing.T) {
+	e := ChangelogEntry{Type: "fix", Scope: "ui", Subject: "Fix crash", Task: "fix-crash", Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}
+	got := e.Render("- {section}: {subject} ({scope}, {task}, {date})")
+	if want := "- Bug Fixes: Fix crash (ui, fix-crash, 2026-03-04)"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteChangelogFragment(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "changelog.d")
+	path, err := WriteChangelogFragment(dir, ChangelogEntry{Type: "feat", Task: "add-login"}, "- feat: Add login")
+	if err != nil {
+		t.Fatalf("WriteChangelogFragment() error = %v", err)
+	}
+	if filepath.Base(path) != "add-login.feat.md" {
+		t.Errorf("path = %s, want add-login.feat.md", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "- feat: Add login\n" {
+		t.Errorf("fragment = %q", data)
+	}
+}
+
+func TestAppendChangelog(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name: "missing file",
+			want: "# Changelog\n\n## Unreleased\n\n- new\n",
+		},
+		{
+			name:     "below unreleased heading",
+			existing: "# Changelog\n\n## [Unreleased]\n\n- old\n\n## 1.0.0\n\n- first\n",
+			want:     "# Changelog\n\n## [Unreleased]\n\n- new\n- old\n\n## 1.0.0\n\n- first\n",
+		},
+		{
+			name:     "empty unreleased section",
+			existing: "## Unreleased\n\n## 1.0.0\n- first\n",
+			want:     "## Unreleased\n\n- new\n\n## 1.0.0\n- first\n",
+		},
+		{
+			name:     "no heading",
+			existing: "# Changes\n\n- old\n",
+			want:     "# Changes\n\n- old\n- new\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := AppendChangelog(path, "- new\n"); err != nil {
+				t.Fatalf("AppendChangelog() error = %v", err)
+			}
+			if data, _ := os.ReadFile(path); string(data) != tt.want {
+				t.Errorf("changelog =\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+// CleanKind is the type of a resource removed by `paw clean`.
+type CleanKind string
+
+const (
+	CleanWindow   CleanKind = "window"
+	CleanWorktree CleanKind = "worktree"
+	CleanBranch   CleanKind = "branch"
+	CleanHistory  CleanKind = "history"
+	CleanLogs     CleanKind = "logs"
+	CleanPawDir   CleanKind = "paw_dir"
+)
+
+// Title returns the group heading for items of this kind.
+func (k CleanKind) Title() string {
+	switch k {
+	case CleanWindow:
+		return "Windows"
+	case CleanWorktree:
+		return "Worktrees"
+	case CleanBranch:
+		return "Branches"
+	case CleanHistory:
+		return "History"
+	case CleanLogs:
+		return "Logs"
+	case CleanPawDir:
+		return "Workspace"
+	}
+	return string(k)
+}
+
+// CleanItem is a single resource in a clean plan.
+type CleanItem struct {
+	Kind   CleanKind
+	Name   string // Task or window name
+	Target string // Window ID, worktree/file/directory path, or branch name
+}
+
+// CleanPlan lists the resources `paw clean` will remove.
+type CleanPlan struct {
+	Items []CleanItem
+}
+
+// Add appends an item to the plan.
+func (p *CleanPlan) Add(kind CleanKind, name, target string) {
+	p.Items = append(p.Items, CleanItem{Kind: kind, Name: name, Target: target})
+}
+
+// Requires returns the items that must also be removed for item i to be removed:
+// a branch can't be deleted while its worktree exists, and the .paw directory
+// contains the worktrees, history, and logs and is used by every window of the session.
+func (p *CleanPlan) Requires(i int) []int {
+	item := p.Items[i]
+	var result []int
+	for j, other := range p.Items {
+		if j == i {
+			continue
+		}
+		switch item.Kind {
+		case CleanBranch:
+			if other.Kind == CleanWorktree && other.Name == item.Name {
+				result = append(result, j)
+			}
+		case CleanPawDir:
+			if other.Kind != CleanBranch && other.Kind != CleanPawDir {
+				result = append(result, j)
+			}
+		}
+	}
+	return result
+}
+
+// Dependents returns the items that can't be removed if item i is kept.
+func (p *CleanPlan) Dependents(i int) []int {
+	var result []int
+	for j := range p.Items {
+		for _, r := range p.Requires(j) {
+			if r == i {
+				result = append(result, j)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Select returns a plan with only the selected items.
+func (p *CleanPlan) Select(selected []bool) *CleanPlan {
+	result := &CleanPlan{}
+	for i, item := range p.Items {
+		if i < len(selected) && selected[i] {
+			result.Items = append(result.Items, item)
+		}
+	}
+	return result
+}
+
+// OfKind returns the items of the given kind.
+func (p *CleanPlan) OfKind(kind CleanKind) []CleanItem {
+	var result []CleanItem
+	for _, item := range p.Items {
+		if item.Kind == kind {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Has reports whether the plan removes the given item.
+func (p *CleanPlan) Has(kind CleanKind, name string) bool {
+	for _, item := range p.Items {
+		if item.Kind == kind && item.Name == name {
+			return true
+		}
+	}
+	return false
+}
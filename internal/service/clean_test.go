@@ -0,0 +1,47 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCleanPlanDependencies(t *testing.T) {
+	plan := &CleanPlan{}
+	plan.Add(CleanWindow, "task-a", "@1")                   // 0
+	plan.Add(CleanWorktree, "task-a", "/wt/task-a")         // 1
+	plan.Add(CleanWorktree, "task-b", "/wt/task-b")         // 2
+	plan.Add(CleanBranch, "task-a", "task-a")               // 3
+	plan.Add(CleanBranch, "task-c", "task-c")               // 4
+	plan.Add(CleanPawDir, ".paw", "/repo/.paw")             // 5
+	plan.Add(CleanHistory, "history", "/repo/.paw/history") // 6
+
+	if got := plan.Requires(3); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Requires(branch task-a) = %v, want [1]", got)
+	}
+	if got := plan.Requires(4); len(got) != 0 {
+		t.Errorf("Requires(branch task-c) = %v, want []", got)
+	}
+	if got := plan.Requires(5); !reflect.DeepEqual(got, []int{0, 1, 2, 6}) {
+		t.Errorf("Requires(.paw) = %v, want [0 1 2 6]", got)
+	}
+	if got := plan.Dependents(1); !reflect.DeepEqual(got, []int{3, 5}) {
+		t.Errorf("Dependents(worktree task-a) = %v, want [3 5]", got)
+	}
+	if got := plan.Dependents(0); !reflect.DeepEqual(got, []int{5}) {
+		t.Errorf("Dependents(window) = %v, want [5]", got)
+	}
+
+	selected := plan.Select([]bool{false, true, false, true, false, false, false})
+	if len(selected.Items) != 2 || !selected.Has(CleanWorktree, "task-a") || !selected.Has(CleanBranch, "task-a") {
+		t.Errorf("Select() = %+v", selected.Items)
+	}
+	if selected.Has(CleanWindow, "task-a") {
+		t.Error("Select() kept an unselected window")
+	}
+	if got := plan.Dependents(6); !reflect.DeepEqual(got, []int{5}) {
+		t.Errorf("Dependents(history) = %v, want [5]", got)
+	}
+	if got := plan.OfKind(CleanBranch); len(got) != 2 {
+		t.Errorf("OfKind(branch) = %+v, want 2 items", got)
+	}
+}
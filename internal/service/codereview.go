@@ -0,0 +1,158 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// Reviewer pass files written to a task's artifacts directory, so they are
+// saved to history with the other artifacts when the task finishes.
+const (
+	CodeReviewFile       = "code-review.json"
+	CodeReviewReportFile = "code-review.md"
+)
+
+// Reviewer verdicts.
+const (
+	ReviewApprove        = "approve"
+	ReviewRequestChanges = "request_changes"
+)
+
+// ReviewComment is a reviewer's comment on a change.
+type ReviewComment struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Comment string `json:"comment"`
+}
+
+// String formats the comment as "file:line: comment".
+func (c ReviewComment) String() string {
+	switch {
+	case c.File == "":
+		return c.Comment
+	case c.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", c.File, c.Line, c.Comment)
+	default:
+		return fmt.Sprintf("%s: %s", c.File, c.Comment)
+	}
+}
+
+// CodeReview is the reviewer model's verdict on a task's diff.
+type CodeReview struct {
+	Verdict  string          `json:"verdict"`
+	Summary  string          `json:"summary"`
+	Comments []ReviewComment `json:"comments,omitempty"`
+	Model    string          `json:"model,omitempty"`
+	Head     string          `json:"head,omitempty"` // Commit the review was made at
+	Time     time.Time       `json:"time"`
+}
+
+// ParseCodeReview extracts the review from the reviewer's response.
+func ParseCodeReview(raw string) (*CodeReview, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON object in review response")
+	}
+
+	var review CodeReview
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &review); err != nil {
+		return nil, fmt.Errorf("failed to parse review: %w", err)
+	}
+	switch review.Verdict = strings.ToLower(strings.TrimSpace(review.Verdict)); review.Verdict {
+	case ReviewApprove, ReviewRequestChanges:
+	default:
+		return nil, fmt.Errorf("unknown review verdict %q", review.Verdict)
+	}
+	review.Summary = strings.TrimSpace(review.Summary)
+	comments := review.Comments[:0]
+	for _, c := range review.Comments {
+		if c.Comment = strings.TrimSpace(c.Comment); c.Comment != "" {
+			c.File = strings.TrimSpace(c.File)
+			comments = append(comments, c)
+		}
+	}
+	review.Comments = comments
+	return &review, nil
+}
+
+// Approved reports whether the reviewer approved the change.
+func (r *CodeReview) Approved() bool {
+	return r.Verdict == ReviewApprove
+}
+
+// ReviewReason describes a rejection for the task's review flag (shown on
+// the Kanban card).
+func (r *CodeReview) ReviewReason() string {
+	reason := "reviewer requested changes"
+	if r.Summary != "" {
+		reason += ": " + r.Summary
+	}
+	return reason
+}
+
+// Render formats the review as Markdown.
+func (r *CodeReview) Render(taskName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Review: %s\n\n", taskName)
+	verdict := "Approved"
+	if !r.Approved() {
+		verdict = "Changes requested"
+	}
+	fmt.Fprintf(&b, "- Verdict: %s\n", verdict)
+	if r.Model != "" {
+		fmt.Fprintf(&b, "- Reviewer: %s\n", r.Model)
+	}
+	if r.Head != "" {
+		fmt.Fprintf(&b, "- Commit: %s\n", r.Head)
+	}
+	if r.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", r.Summary)
+	}
+	if len(r.Comments) > 0 {
+		b.WriteString("\n## Comments\n\n")
+		for _, c := range r.Comments {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	return b.String()
+}
+
+// SaveCodeReview writes the review (JSON and Markdown) to artifactsDir.
+func SaveCodeReview(artifactsDir, taskName string, review *CodeReview) error {
+	if err := os.MkdirAll(artifactsDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(review, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(artifactsDir, CodeReviewFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(filepath.Join(artifactsDir, CodeReviewReportFile), []byte(review.Render(taskName)), 0644)
+}
+
+// LoadCodeReview reads the review saved in artifactsDir. A missing review
+// returns nil without an error.
+func LoadCodeReview(artifactsDir string) (*CodeReview, error) {
+	data, err := os.ReadFile(filepath.Join(artifactsDir, CodeReviewFile)) //nolint:gosec // G304: path is in the task's artifacts directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var review CodeReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", CodeReviewFile, err)
+	}
+	return &review, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCodeReview(t *testing.T) {
+	raw := "```json\n" + `{"verdict": " Request_Changes ", "summary": " Missing error handling ",
+"comments": [{"file": "main.go", "line": 12, "comment": "err is ignored"}, {"comment": " "}, {"file": "go.mod", "comment": "Unused dependency"}]}` + "\n```"
+	review, err := ParseCodeReview(raw)
+	if err != nil {
+		t.Fatalf("ParseCodeReview() error = %v", err)
+	}
+	if review.Verdict != ReviewRequestChanges || review.Approved() || review.Summary != "Missing error handling" {
+		t.Errorf("ParseCodeReview() = %+v", review)
+	}
+	if len(review.Comments) != 2 {
+		t.Fatalf("Comments = %+v, want blank comment dropped", review.Comments)
+	}
+	if got := review.Comments[0].String(); got != "main.go:12: err is ignored" {
+		t.Errorf("Comments[0] = %q", got)
+	}
+	if got := review.Comments[1].String(); got != "go.mod: Unused dependency" {
+		t.Errorf("Comments[1] = %q", got)
+	}
+	if got := review.ReviewReason(); got != "reviewer requested changes: Missing error handling" {
+		t.Errorf("ReviewReason() = %q", got)
+	}
+
+	if review, err := ParseCodeReview(`{"verdict": "approve"}`); err != nil || !review.Approved() {
+		t.Errorf("ParseCodeReview() of an approval = %+v, %v", review, err)
+	}
+	for _, bad := range []string{"LGTM", `{"verdict": "maybe"}`, `{"verdict": 1}`} {
+		if _, err := ParseCodeReview(bad); err == nil {
+			t.Errorf("ParseCodeReview(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestSaveCodeReview(t *testing.T) {
+	dir := t.TempDir()
+	if review, err := LoadCodeReview(dir); err != nil || review != nil {
+		t.Fatalf("LoadCodeReview() of a missing review = %+v, %v", review, err)
+	}
+
+	review := &CodeReview{Verdict: ReviewRequestChanges, Summary: "Needs tests", Model: "opus", Head: "abc123",
+		Comments: []ReviewComment{{File: "parser.go", Line: 40, Comment: "No test for empty input"}}}
+	if err := SaveCodeReview(dir, "fix-parser", review); err != nil {
+		t.Fatalf("SaveCodeReview() error = %v", err)
+	}
+	loaded, err := LoadCodeReview(dir)
+	if err != nil || loaded.Verdict != ReviewRequestChanges || loaded.Model != "opus" || loaded.Head != "abc123" || len(loaded.Comments) != 1 {
+		t.Errorf("LoadCodeReview() = %+v, %v", loaded, err)
+	}
+
+	report := review.Render("fix-parser")
+	for _, want := range []string{"# Review: fix-parser", "- Verdict: Changes requested", "- Reviewer: opus", "## Comments\n\n- parser.go:40: No test for empty input"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Render() missing %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// ConflictTranscript records how Claude resolved the conflicts of merging a
+// task. It is saved to the task's artifacts, and from there to history, so
+// the decisions can be audited later.
+type ConflictTranscript struct {
+	Task      string
+	Into      string // Branch the task was merged into
+	Time      time.Time
+	Files     []string          // Conflicting files
+	Conflicts map[string]string // File contents with conflict markers, before resolution
+	Prompt    string
+	Output    string // Claude's output
+	Diff      string // Resolved files against the branch merged into
+	Result    string // "resolved", or why the resolution was abandoned
+}
+
+// FileName returns the artifact name of the transcript.
+func (t *ConflictTranscript) FileName() string {
+	return "conflict-resolution-" + t.Time.Format("060102-150405") + ".md"
+}
+
+// Render formats the transcript as Markdown.
+func (t *ConflictTranscript) Render() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Conflict resolution: %s into %s\n\n", t.Task, t.Into)
+	fmt.Fprintf(&b, "- Time: %s\n", t.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Result: %s\n", t.Result)
+	if len(t.Files) > 0 {
+		fmt.Fprintf(&b, "- Files: %s\n", strings.Join(t.Files, ", "))
+	}
+
+	section := func(title, lang, content string) {
+		if content = strings.TrimRight(content, "\n"); content == "" {
+			return
+		}
+		fence := codeFence(content)
+		fmt.Fprintf(&b, "\n## %s\n\n%s%s\n%s\n%s\n", title, fence, lang, content, fence)
+	}
+	section("Prompt", "", t.Prompt)
+	files := make([]string, 0, len(t.Conflicts))
+	for f := range t.Conflicts {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		section("Conflict: "+f, "", t.Conflicts[f])
+	}
+	section("Claude output", "", t.Output)
+	section("Resolution diff", "diff", t.Diff)
+	return b.String()
+}
+
+// SaveConflictTranscript writes the transcript to artifactsDir and returns
+// its path.
+func SaveConflictTranscript(artifactsDir string, t *ConflictTranscript) (string, error) {
+	if err := os.MkdirAll(artifactsDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+	path := filepath.Join(artifactsDir, t.FileName())
+	if err := fileutil.WriteFileAtomic(path, []byte(t.Render()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write conflict transcript: %w", err)
+	}
+	return path, nil
+}
+
+// codeFence returns a backtick fence longer than any backtick run in content.
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConflictTranscriptRender(t *testing.T) {
+	transcript := &ConflictTranscript{
+		Task:      "add-auth",
+		Into:      "main",
+		Time:      time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC),
+		Files:     []string{"README.md"},
+		Conflicts: map[string]string{"README.md": "<<<<<<< HEAD\n# main\n=======\n# task\n>>>>>>> add-auth\n"},
+		Prompt:    "Resolve the conflicts.\n```go\nx := 1\n```",
+		Output:    "Kept both headings.",
+		Diff:      "-# main\n+# main\n+# task",
+		Result:    "resolved",
+	}
+
+	got := transcript.Render()
+	for _, want := range []string{
+		"# Conflict resolution: add-auth into main\n",
+		"- Result: resolved\n",
+		"- Files: README.md\n",
+		"## Prompt\n\n````\nResolve the conflicts.\n```go\nx := 1\n```\n````\n",
+		"## Conflict: README.md\n\n```\n<<<<<<< HEAD\n",
+		"## Claude output\n\n```\nKept both headings.\n```\n",
+		"## Resolution diff\n\n```diff\n-# main\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if transcript.FileName() != "conflict-resolution-250304-050607.md" {
+		t.Errorf("FileName() = %q", transcript.FileName())
+	}
+
+	// Sections without content are left out
+	failed := &ConflictTranscript{Task: "add-auth", Into: "main", Result: "claude failed"}
+	if got := failed.Render(); strings.Contains(got, "##") {
+		t.Errorf("Render() of an empty transcript has sections:\n%s", got)
+	}
+}
+
+func TestSaveConflictTranscript(t *testing.T) {
+	artifactsDir := filepath.Join(t.TempDir(), "artifacts")
+	transcript := &ConflictTranscript{Task: "add-auth", Into: "main", Time: time.Now(), Result: "resolved"}
+
+	path, err := SaveConflictTranscript(artifactsDir, transcript)
+	if err != nil {
+		t.Fatalf("SaveConflictTranscript() error = %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != transcript.Render() {
+		t.Errorf("saved transcript = %q, %v", data, err)
+	}
+	if filepath.Dir(path) != artifactsDir {
+		t.Errorf("transcript saved to %s, want %s", path, artifactsDir)
+	}
+}
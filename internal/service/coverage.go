@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// coveragePercentPattern matches a percentage such as "74.1%".
+var coveragePercentPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+
+// ParseCoverage returns the last percentage printed by a coverage command,
+// which is taken as the total coverage (e.g. the "total:" line of
+// 'go tool cover -func').
+func ParseCoverage(output string) (float64, bool) {
+	matches := coveragePercentPattern.FindAllStringSubmatch(output, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	percent, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
+
+// MeasureCoverage runs a coverage command in dir and parses its total.
+func MeasureCoverage(command, dir string, env []string, timeout time.Duration) (float64, error) {
+	result, err := RunCommand(command, dir, env, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("coverage command failed: %w: %s", err, firstLine(lastLine(result.Output)))
+	}
+	percent, ok := ParseCoverage(result.Output)
+	if !ok {
+		return 0, fmt.Errorf("no coverage percentage in the output of %s", command)
+	}
+	return percent, nil
+}
+
+// lastLine returns the last non-empty line of a command's output, where
+// errors usually are.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// CoverageDelta is a task's coverage compared to the commit it forked from.
+type CoverageDelta struct {
+	Base float64
+	Task float64
+}
+
+func (d CoverageDelta) String() string {
+	return fmt.Sprintf("%.1f%% → %.1f%% (%+.1f)", d.Base, d.Task, d.Task-d.Base)
+}
+
+// CoverageCache stores coverage results by commit and command, so the main
+// branch is measured once for all the tasks forked from it.
+type CoverageCache struct {
+	path    string
+	Entries map[string]float64 `json:"entries"`
+}
+
+// maxCoverageCacheEntries bounds the cache; older commits are rarely
+// measured again.
+const maxCoverageCacheEntries = 200
+
+// LoadCoverageCache reads the cache at path. A missing or unreadable cache
+// starts empty.
+func LoadCoverageCache(path string) *CoverageCache {
+	cache := &CoverageCache{path: path}
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // G304: path is in the PAW directory
+		if err := json.Unmarshal(data, cache); err != nil {
+			_ = fileutil.BackupCorruptFile(path)
+		}
+	}
+	if cache.Entries == nil {
+		cache.Entries = make(map[string]float64)
+	}
+	return cache
+}
+
+func coverageCacheKey(commit, command string) string {
+	return commit + " " + command
+}
+
+// Get returns the cached coverage of commit measured with command.
+func (c *CoverageCache) Get(commit, command string) (float64, bool) {
+	percent, ok := c.Entries[coverageCacheKey(commit, command)]
+	return percent, ok
+}
+
+// Put records the coverage of commit and saves the cache.
+func (c *CoverageCache) Put(commit, command string, percent float64) error {
+	if len(c.Entries) >= maxCoverageCacheEntries {
+		c.Entries = make(map[string]float64)
+	}
+	c.Entries[coverageCacheKey(commit, command)] = percent
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(c.path, data, 0644)
+}
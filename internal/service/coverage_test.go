@@ -0,0 +1,58 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		output string
+		want   float64
+		ok     bool
+	}{
+		{"github.com/x/a/a.go:10:\tFoo\t\t100.0%\ntotal:\t\t\t(statements)\t74.1%\n", 74.1, true},
+		{"ok  \tgithub.com/x/a\t0.01s\tcoverage: 80 % of statements", 80, true},
+		{"no tests", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseCoverage(tt.output)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseCoverage(%q) = %v, %v; want %v, %v", tt.output, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMeasureCoverage(t *testing.T) {
+	got, err := MeasureCoverage("echo 'total: 61.5%'", t.TempDir(), nil, 0)
+	if err != nil || got != 61.5 {
+		t.Errorf("MeasureCoverage() = %v, %v; want 61.5", got, err)
+	}
+	if _, err := MeasureCoverage("echo boom; exit 1", t.TempDir(), nil, 0); err == nil {
+		t.Error("MeasureCoverage(failing) error = nil")
+	}
+}
+
+func TestCoverageDeltaString(t *testing.T) {
+	if got := (CoverageDelta{Base: 72.4, Task: 74.1}).String(); got != "72.4% → 74.1% (+1.7)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (CoverageDelta{Base: 50, Task: 49.5}).String(); got != "50.0% → 49.5% (-0.5)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCoverageCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage-cache.json")
+	if err := LoadCoverageCache(path).Put("abc123", "make cover", 42.5); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	cache := LoadCoverageCache(path)
+	if got, ok := cache.Get("abc123", "make cover"); !ok || got != 42.5 {
+		t.Errorf("Get() = %v, %v; want 42.5", got, ok)
+	}
+	if _, ok := cache.Get("abc123", "other command"); ok {
+		t.Error("Get() with another command hit the cache")
+	}
+}
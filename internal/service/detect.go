@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+)
+
+// DetectedCommand is a project command found in the repository.
+type DetectedCommand struct {
+	Command string
+	Source  string // File the command was read from (e.g. "Makefile")
+}
+
+// DetectedCommands are the build, test, and lint commands proposed for a project.
+// Empty fields mean nothing suitable was found.
+type DetectedCommands struct {
+	Build DetectedCommand
+	Test  DetectedCommand
+	Lint  DetectedCommand
+}
+
+// Commands returns the detected commands as a config.Commands.
+func (d DetectedCommands) Commands() config.Commands {
+	return config.Commands{Build: d.Build.Command, Test: d.Test.Command, Lint: d.Lint.Command}
+}
+
+// FillEmpty sets the commands c leaves empty to the detected ones.
+func (d DetectedCommands) FillEmpty(c *config.Commands) {
+	for _, f := range []struct {
+		field *string
+		dc    DetectedCommand
+	}{
+		{&c.Build, d.Build},
+		{&c.Test, d.Test},
+		{&c.Lint, d.Lint},
+	} {
+		if *f.field == "" {
+			*f.field = f.dc.Command
+		}
+	}
+}
+
+// justRecipeRegex matches justfile recipe headers like "test:" or "@build target:".
+var justRecipeRegex = regexp.MustCompile(`^@?([A-Za-z][A-Za-z0-9_-]*)(\s+[^:=]*)?:([^=]|$)`)
+
+// ciRunRegex matches single-line "run:" steps in CI workflow files.
+var ciRunRegex = regexp.MustCompile(`^\s*(?:-\s*)?run:\s*(\S.*)$`)
+
+// lintTools are words that mark a CI step as a lint step.
+var lintTools = []string{"lint", "vet", "golangci", "eslint", "ruff", "clippy", "flake8"}
+
+// DetectProjectCommands proposes build, test, and lint commands for a project.
+// Sources are tried in order, and each command is taken from the first source
+// that defines it: CI workflows (what actually gates merges), Makefile and
+// justfile targets, package.json scripts, then toolchain defaults.
+func DetectProjectCommands(projectDir string) DetectedCommands {
+	var d DetectedCommands
+	slots := map[string]*DetectedCommand{"build": &d.Build, "test": &d.Test, "lint": &d.Lint}
+	offer := func(slot, command, source string) {
+		if dc := slots[slot]; dc != nil && dc.Command == "" && command != "" {
+			*dc = DetectedCommand{Command: command, Source: source}
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(projectDir, name))
+		return err == nil
+	}
+
+	for _, step := range ciRunSteps(projectDir) {
+		offer(classifyCIStep(step.Command), step.Command, step.Source)
+	}
+
+	for _, target := range makeTargets(filepath.Join(projectDir, "Makefile")) {
+		offer(target, "make "+target, "Makefile")
+	}
+	for _, name := range []string{"justfile", "Justfile", ".justfile"} {
+		for _, recipe := range justRecipes(filepath.Join(projectDir, name)) {
+			offer(recipe, "just "+recipe, name)
+		}
+	}
+
+	if exists("package.json") {
+		runner := "npm run"
+		switch {
+		case exists("pnpm-lock.yaml"):
+			runner = "pnpm"
+		case exists("yarn.lock"):
+			runner = "yarn"
+		case exists("bun.lockb"), exists("bun.lock"):
+			runner = "bun run"
+		}
+		for _, script := range packageScripts(filepath.Join(projectDir, "package.json")) {
+			offer(script, runner+" "+script, "package.json")
+		}
+	}
+
+	if exists("go.mod") {
+		offer("build", "go build ./...", "go.mod")
+		offer("test", "go test ./...", "go.mod")
+		offer("lint", "go vet ./...", "go.mod")
+	}
+	if exists("Cargo.toml") {
+		offer("build", "cargo build", "Cargo.toml")
+		offer("test", "cargo test", "Cargo.toml")
+		offer("lint", "cargo clippy", "Cargo.toml")
+	}
+	if exists("pyproject.toml") || exists("setup.py") {
+		offer("test", "python -m pytest", "pyproject.toml")
+	}
+	return d
+}
+
+// ciRunSteps returns the single-line run steps of GitHub Actions and GitLab CI
+// workflows. Multi-line scripts are skipped; they rarely map to one command.
+func ciRunSteps(projectDir string) []DetectedCommand {
+	var files []string
+	if matches, err := filepath.Glob(filepath.Join(projectDir, ".github", "workflows", "*.y*ml")); err == nil {
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	files = append(files, filepath.Join(projectDir, ".gitlab-ci.yml"))
+
+	var steps []DetectedCommand
+	for _, path := range files {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: workflow path is constructed from projectDir
+		if err != nil {
+			continue
+		}
+		source, _ := filepath.Rel(projectDir, path)
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			m := ciRunRegex.FindStringSubmatch(scanner.Text())
+			if m == nil {
+				continue
+			}
+			command := strings.Trim(strings.TrimSpace(m[1]), `"'`)
+			if command == "|" || command == ">" || strings.Contains(command, "${{") {
+				continue
+			}
+			steps = append(steps, DetectedCommand{Command: command, Source: source})
+		}
+	}
+	return steps
+}
+
+// classifyCIStep returns which command slot a CI step fills, or "" if none.
+func classifyCIStep(command string) string {
+	lower := strings.ToLower(command)
+	for _, tool := range lintTools {
+		if strings.Contains(lower, tool) {
+			return "lint"
+		}
+	}
+	switch {
+	case strings.Contains(lower, "test"):
+		return "test"
+	case strings.Contains(lower, "build"):
+		return "build"
+	}
+	return ""
+}
+
+// justRecipes returns the recipe names of a justfile in definition order.
+func justRecipes(path string) []string {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: justfile path is constructed from projectDir
+	if err != nil {
+		return nil
+	}
+	var recipes []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if m := justRecipeRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			recipes = append(recipes, m[1])
+		}
+	}
+	return recipes
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/config"
+)
+
+func TestDetectProjectCommands(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root, map[string]string{
+		"go.mod":   "module example.com/app\n",
+		"Makefile": "build:\n\tgo build -o bin/app ./cmd/app\nlint:\n\tgolangci-lint run\n",
+		".github/workflows/ci.yml": `jobs:
+  test:
+    steps:
+      - run: go mod download
+      - name: Run tests
+        run: go test -race ./...
+      - run: |
+          echo multi-line
+`,
+	})
+
+	got := DetectProjectCommands(root)
+	want := DetectedCommands{
+		Build: DetectedCommand{Command: "make build", Source: "Makefile"},
+		Test:  DetectedCommand{Command: "go test -race ./...", Source: ".github/workflows/ci.yml"},
+		Lint:  DetectedCommand{Command: "make lint", Source: "Makefile"},
+	}
+	if got != want {
+		t.Errorf("DetectProjectCommands() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDetectProjectCommandsJustfileAndScripts(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root, map[string]string{
+		"justfile":     "set shell := [\"bash\", \"-c\"]\n\n@test *args:\n    cargo test {{args}}\n",
+		"package.json": `{"scripts": {"build": "vite build", "lint": "eslint ."}}`,
+		"yarn.lock":    "",
+		"Cargo.toml":   "[package]\nname = \"app\"\n",
+	})
+
+	got := DetectProjectCommands(root).Commands()
+	if got.Test != "just test" || got.Build != "yarn build" || got.Lint != "yarn lint" {
+		t.Errorf("DetectProjectCommands() = %+v", got)
+	}
+}
+
+func TestDetectedCommandsFillEmpty(t *testing.T) {
+	detected := DetectedCommands{
+		Build: DetectedCommand{Command: "make build"},
+		Test:  DetectedCommand{Command: "make test"},
+	}
+	cmds := config.Commands{Test: "go test ./..."}
+	detected.FillEmpty(&cmds)
+	if cmds.Build != "make build" || cmds.Test != "go test ./..." || cmds.Lint != "" {
+		t.Errorf("FillEmpty() = %+v", cmds)
+	}
+}
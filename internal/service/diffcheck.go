@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diffCheckSeparator separates a check's name from its rule.
+const diffCheckSeparator = "=>"
+
+// diffCheckScriptPrefix marks a rule as a command instead of a regex.
+const diffCheckScriptPrefix = "$"
+
+// maxScriptViolationLines limits how much of a failing check script's output
+// is reported.
+const maxScriptViolationLines = 10
+
+// DiffCheck is a policy rule run on a task's diff before it is merged:
+// a regex no added line may match, or a command that must exit 0.
+type DiffCheck struct {
+	Source  string         // Rule as written in the config
+	Name    string         // Name reported with violations
+	Glob    string         // Files the regex applies to (e.g. *.go); empty for all
+	Pattern *regexp.Regexp // Regex rule, nil for commands
+	Command string         // Command rule, run in the worktree
+}
+
+// AddedLine is a line added by a diff.
+type AddedLine struct {
+	File string
+	Line int // Line number in the new file
+	Text string
+}
+
+// DiffViolation is a failed diff check.
+type DiffViolation struct {
+	Check string
+	File  string // Empty for command output
+	Line  int
+	Text  string
+}
+
+func (v DiffViolation) String() string {
+	if v.File == "" {
+		return fmt.Sprintf("[%s] %s", v.Check, v.Text)
+	}
+	return fmt.Sprintf("[%s] %s:%d: %s", v.Check, v.File, v.Line, strings.TrimSpace(v.Text))
+}
+
+// ParseDiffChecks parses "name [glob] => regex" and "name => $ command"
+// rules. Invalid rules are skipped and reported in errs.
+func ParseDiffChecks(lines []string) (checks []DiffCheck, errs []error) {
+	for _, line := range lines {
+		head, rule, ok := strings.Cut(line, diffCheckSeparator)
+		head = strings.TrimSpace(head)
+		rule = strings.TrimSpace(rule)
+		if !ok || head == "" || rule == "" {
+			errs = append(errs, fmt.Errorf("diff check %q: expected \"name %s regex\" or \"name %s %s command\"", line, diffCheckSeparator, diffCheckSeparator, diffCheckScriptPrefix))
+			continue
+		}
+
+		check := DiffCheck{Source: line, Name: head}
+		if name, glob, ok := strings.Cut(head, "["); ok && strings.HasSuffix(glob, "]") {
+			check.Name = strings.TrimSpace(name)
+			check.Glob = strings.TrimSpace(strings.TrimSuffix(glob, "]"))
+			if _, err := path.Match(check.Glob, ""); err != nil || check.Glob == "" {
+				errs = append(errs, fmt.Errorf("diff check %q: invalid glob %q", line, check.Glob))
+				continue
+			}
+		}
+
+		if command, ok := strings.CutPrefix(rule, diffCheckScriptPrefix); ok {
+			check.Command = strings.TrimSpace(command)
+			if check.Command == "" || check.Glob != "" {
+				errs = append(errs, fmt.Errorf("diff check %q: command checks take no glob and need a command", line))
+				continue
+			}
+		} else {
+			re, err := regexp.Compile(rule)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("diff check %q: %w", line, err))
+				continue
+			}
+			check.Pattern = re
+		}
+		checks = append(checks, check)
+	}
+	return checks, errs
+}
+
+// ParseAddedLines returns the lines added by a unified diff.
+func ParseAddedLines(diff string) []AddedLine {
+	var added []AddedLine
+	file := ""
+	lineNo := 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			if file == "/dev/null" {
+				file = ""
+			}
+		case strings.HasPrefix(line, "@@ "):
+			// @@ -a,b +c,d @@
+			lineNo = 0
+			if _, after, ok := strings.Cut(line, " +"); ok {
+				start, _, _ := strings.Cut(after, " ")
+				start, _, _ = strings.Cut(start, ",")
+				lineNo, _ = strconv.Atoi(start)
+			}
+		case strings.HasPrefix(line, "+") && file != "":
+			added = append(added, AddedLine{File: file, Line: lineNo, Text: line[1:]})
+			lineNo++
+		case strings.HasPrefix(line, " "):
+			lineNo++
+		}
+	}
+	return added
+}
+
+// CheckAddedLines returns the added lines that match regex checks.
+func CheckAddedLines(checks []DiffCheck, added []AddedLine) []DiffViolation {
+	var violations []DiffViolation
+	for _, check := range checks {
+		if check.Pattern == nil {
+			continue
+		}
+		for _, line := range added {
+			if check.Glob != "" {
+				if ok, _ := path.Match(check.Glob, path.Base(line.File)); !ok {
+					if ok, _ := path.Match(check.Glob, line.File); !ok {
+						continue
+					}
+				}
+			}
+			if check.Pattern.MatchString(line.Text) {
+				violations = append(violations, DiffViolation{Check: check.Name, File: line.File, Line: line.Line, Text: line.Text})
+			}
+		}
+	}
+	return violations
+}
+
+// RunDiffCheckCommand runs a command check in dir. A non-zero exit is a
+// violation, reported with the first lines of its output.
+func RunDiffCheckCommand(check DiffCheck, dir string, env []string, timeout time.Duration) []DiffViolation {
+	result, err := RunCommand(check.Command, dir, env, timeout)
+	if err == nil && result.Success {
+		return nil
+	}
+
+	var violations []DiffViolation
+	for _, line := range strings.Split(strings.TrimSpace(result.Output), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		if len(violations) == maxScriptViolationLines {
+			violations = append(violations, DiffViolation{Check: check.Name, Text: "…"})
+			break
+		}
+		violations = append(violations, DiffViolation{Check: check.Name, Text: line})
+	}
+	if len(violations) == 0 {
+		text := fmt.Sprintf("%s exited with %d", check.Command, result.ExitCode)
+		if result.TimeoutHit {
+			text = fmt.Sprintf("%s timed out", check.Command)
+		}
+		violations = append(violations, DiffViolation{Check: check.Name, Text: text})
+	}
+	return violations
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDiffChecks(t *testing.T) {
+	checks, errs := ParseDiffChecks([]string{
+		`no-todo => TODO|FIXME`,
+		`no-println [*.go] => fmt\.Println`,
+		`license => $ ./check.sh`,
+		`broken => (`,
+		`no rule`,
+		`glob-script [*.go] => $ true`,
+	})
+
+	if len(checks) != 3 {
+		t.Fatalf("ParseDiffChecks() returned %d checks, want 3", len(checks))
+	}
+	if len(errs) != 3 {
+		t.Errorf("ParseDiffChecks() returned %d errors, want 3", len(errs))
+	}
+	if checks[1].Name != "no-println" || checks[1].Glob != "*.go" || checks[1].Pattern == nil {
+		t.Errorf("checks[1] = %+v", checks[1])
+	}
+	if checks[2].Command != "./check.sh" || checks[2].Pattern != nil {
+		t.Errorf("checks[2] = %+v", checks[2])
+	}
+}
+
+func TestParseAddedLines(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -3,0 +4,2 @@ func main() {
++	fmt.Println("debug")
++	// TODO: remove
+@@ -10 +12 @@
+-	old()
++	new()
+diff --git a/gone.txt b/gone.txt
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye`
+
+	added := ParseAddedLines(diff)
+	if len(added) != 3 {
+		t.Fatalf("ParseAddedLines() returned %d lines, want 3: %+v", len(added), added)
+	}
+	if added[0].File != "main.go" || added[0].Line != 4 || added[1].Line != 5 || added[2].Line != 12 {
+		t.Errorf("ParseAddedLines() = %+v", added)
+	}
+}
+
+func TestCheckAddedLines(t *testing.T) {
+	checks, _ := ParseDiffChecks([]string{`no-println [*.go] => fmt\.Println`, `no-todo => TODO`})
+	added := []AddedLine{
+		{File: "cmd/main.go", Line: 4, Text: `	fmt.Println("debug")`},
+		{File: "docs/guide.md", Line: 1, Text: "Use fmt.Println to print"},
+		{File: "docs/guide.md", Line: 2, Text: "TODO: write"},
+	}
+
+	violations := CheckAddedLines(checks, added)
+	if len(violations) != 2 {
+		t.Fatalf("CheckAddedLines() = %v, want 2 violations", violations)
+	}
+	if got := violations[0].String(); got != `[no-println] cmd/main.go:4: fmt.Println("debug")` {
+		t.Errorf("violations[0] = %q", got)
+	}
+	if violations[1].Check != "no-todo" || violations[1].File != "docs/guide.md" {
+		t.Errorf("violations[1] = %+v", violations[1])
+	}
+}
+
+func TestRunDiffCheckCommand(t *testing.T) {
+	pass := DiffCheck{Name: "ok", Command: "true"}
+	if v := RunDiffCheckCommand(pass, t.TempDir(), nil, time.Minute); len(v) != 0 {
+		t.Errorf("RunDiffCheckCommand(pass) = %v, want none", v)
+	}
+
+	fail := DiffCheck{Name: "license", Command: "echo 'a.go: missing header'; exit 1"}
+	v := RunDiffCheckCommand(fail, t.TempDir(), nil, time.Minute)
+	if len(v) != 1 || !strings.Contains(v[0].String(), "a.go: missing header") {
+		t.Errorf("RunDiffCheckCommand(fail) = %v", v)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// EditorTaskRequest is the body of a POST /v1/tasks request to the editor
+// endpoint: a task prompt with the code region it is about.
+type EditorTaskRequest struct {
+	Prompt    string `json:"prompt"`
+	File      string `json:"file,omitempty"`       // Absolute or project-relative path
+	StartLine int    `json:"start_line,omitempty"` // 1-based, inclusive
+	EndLine   int    `json:"end_line,omitempty"`   // 1-based, inclusive
+	Selection string `json:"selection,omitempty"`  // Selected text, included verbatim
+	Language  string `json:"language,omitempty"`   // Code fence language of the selection
+	Model     string `json:"model,omitempty"`      // haiku, sonnet, or opus
+	Name      string `json:"name,omitempty"`       // Task name (generated when empty)
+}
+
+// EditorStatus is the response of GET /v1/status.
+type EditorStatus struct {
+	Protocol int    `json:"protocol"`
+	Project  string `json:"project"`
+	Session  string `json:"session"`
+}
+
+// EditorTaskResponse is the response of a created task.
+type EditorTaskResponse struct {
+	Task string `json:"task"`
+}
+
+// EditorError is the response of a failed request.
+type EditorError struct {
+	Error string `json:"error"`
+}
+
+// Normalize validates the request and makes File relative to projectDir.
+func (r *EditorTaskRequest) Normalize(projectDir string) error {
+	r.Prompt = strings.TrimSpace(r.Prompt)
+	if r.Prompt == "" {
+		return errors.New("prompt is required")
+	}
+	if r.Model != "" && !validModel(r.Model) {
+		return fmt.Errorf("unknown model %q", r.Model)
+	}
+	if r.StartLine < 0 || r.EndLine < 0 {
+		return errors.New("line numbers must be positive")
+	}
+	if r.EndLine == 0 {
+		r.EndLine = r.StartLine
+	}
+	if r.StartLine == 0 && r.EndLine > 0 {
+		r.StartLine = r.EndLine
+	}
+	if r.EndLine < r.StartLine {
+		return fmt.Errorf("end_line %d is before start_line %d", r.EndLine, r.StartLine)
+	}
+
+	r.File = strings.TrimSpace(r.File)
+	if r.File == "" {
+		if r.StartLine > 0 {
+			return errors.New("line numbers need a file")
+		}
+		return nil
+	}
+	path := r.File
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectDir, path)
+	}
+	rel, err := filepath.Rel(projectDir, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("file %s is outside the project", r.File)
+	}
+	r.File = filepath.ToSlash(rel)
+	return nil
+}
+
+func validModel(model string) bool {
+	for _, m := range config.ValidModels() {
+		if string(m) == model {
+			return true
+		}
+	}
+	return false
+}
+
+// Content builds the task content: the prompt, followed by the file and
+// lines it is about and the selected code.
+func (r *EditorTaskRequest) Content() string {
+	if r.File == "" {
+		return r.Prompt
+	}
+
+	var b strings.Builder
+	b.WriteString(r.Prompt)
+	b.WriteString("\n\n## Code context\n\n")
+	fmt.Fprintf(&b, "`%s`", r.File)
+	switch {
+	case r.StartLine > 0 && r.EndLine > r.StartLine:
+		fmt.Fprintf(&b, " lines %d-%d", r.StartLine, r.EndLine)
+	case r.StartLine > 0:
+		fmt.Fprintf(&b, " line %d", r.StartLine)
+	}
+	if r.Selection == "" {
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Use a fence longer than any backtick run in the selection
+	fence := "```"
+	for strings.Contains(r.Selection, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(&b, ":\n\n%s%s\n%s", fence, r.Language, r.Selection)
+	if !strings.HasSuffix(r.Selection, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(fence + "\n")
+	return b.String()
+}
+
+// EditorSocketPath returns the editor endpoint's Unix socket: editor.sock in
+// the workspace, or a per-session socket in the temp directory when that
+// path is too long for a socket address.
+func EditorSocketPath(pawDir, sessionName string) string {
+	path := filepath.Join(pawDir, constants.EditorSocketFile)
+	if len(path) <= constants.EditorSocketMaxPath {
+		return path
+	}
+	sum := sha256.Sum256([]byte(pawDir + "\x00" + sessionName))
+	return filepath.Join(os.TempDir(), "paw-"+hex.EncodeToString(sum[:6])+".sock")
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestEditorTaskRequestNormalize(t *testing.T) {
+	project := t.TempDir()
+
+	req := &EditorTaskRequest{Prompt: "  Add a nil check  ", File: filepath.Join(project, "internal", "db.go"), StartLine: 12}
+	if err := req.Normalize(project); err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if req.Prompt != "Add a nil check" || req.File != "internal/db.go" || req.EndLine != 12 {
+		t.Errorf("Normalize() = %+v", req)
+	}
+
+	tests := []struct {
+		name string
+		req  EditorTaskRequest
+	}{
+		{"no prompt", EditorTaskRequest{Prompt: " ", File: "main.go"}},
+		{"unknown model", EditorTaskRequest{Prompt: "x", Model: "gpt"}},
+		{"reversed lines", EditorTaskRequest{Prompt: "x", File: "main.go", StartLine: 9, EndLine: 3}},
+		{"negative line", EditorTaskRequest{Prompt: "x", File: "main.go", StartLine: -1}},
+		{"lines without file", EditorTaskRequest{Prompt: "x", StartLine: 3}},
+		{"outside project", EditorTaskRequest{Prompt: "x", File: "../other/main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Normalize(project); err == nil {
+				t.Errorf("Normalize(%+v) succeeded", tt.req)
+			}
+		})
+	}
+}
+
+func TestEditorTaskRequestContent(t *testing.T) {
+	req := &EditorTaskRequest{Prompt: "Explain this"}
+	if got := req.Content(); got != "Explain this" {
+		t.Errorf("Content() without a file = %q", got)
+	}
+
+	req = &EditorTaskRequest{Prompt: "Fix the off-by-one", File: "list.go", StartLine: 4, EndLine: 6, Language: "go", Selection: "for i := 0; i <= n; i++ {\n}"}
+	want := "Fix the off-by-one\n\n## Code context\n\n`list.go` lines 4-6:\n\n```go\nfor i := 0; i <= n; i++ {\n}\n```\n"
+	if got := req.Content(); got != want {
+		t.Errorf("Content() = %q, want %q", got, want)
+	}
+
+	req = &EditorTaskRequest{Prompt: "Update the docs", File: "README.md", StartLine: 3, EndLine: 3, Selection: "```sh\nmake\n```\n"}
+	if got := req.Content(); !strings.Contains(got, "`README.md` line 3:") || !strings.Contains(got, "````\n```sh\nmake\n```\n````\n") {
+		t.Errorf("Content() should fence a selection containing backticks with a longer fence:\n%s", got)
+	}
+}
+
+func TestEditorSocketPath(t *testing.T) {
+	if got := EditorSocketPath("/home/me/app/.paw", "app"); got != "/home/me/app/.paw/"+constants.EditorSocketFile {
+		t.Errorf("EditorSocketPath() = %q", got)
+	}
+
+	long := "/" + strings.Repeat("deep/", 30) + ".paw"
+	got := EditorSocketPath(long, "app")
+	if len(got) > constants.EditorSocketMaxPath || !strings.HasSuffix(got, ".sock") {
+		t.Errorf("EditorSocketPath() of a long workspace = %q", got)
+	}
+	if got != EditorSocketPath(long, "app") || got == EditorSocketPath(long, "other") {
+		t.Error("EditorSocketPath() fallback should be stable per workspace and session")
+	}
+}
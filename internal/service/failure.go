@@ -0,0 +1,185 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// FailureKind classifies why an agent ended in a failed state.
+type FailureKind string
+
+// Failure kinds.
+const (
+	FailureNone          FailureKind = ""
+	FailureBuild         FailureKind = "build_error"
+	FailureTest          FailureKind = "test_failure"
+	FailureMergeConflict FailureKind = "merge_conflict"
+	FailureTokenLimit    FailureKind = "token_limit"
+	FailureCrash         FailureKind = "crash"
+	FailureUnknown       FailureKind = "unknown"
+)
+
+// failurePatterns maps failure kinds to the output fragments that identify them.
+// Order matters: earlier kinds win (e.g. "[build failed]" in go test output is a build error).
+var failurePatterns = []struct {
+	kind     FailureKind
+	patterns []string
+}{
+	{FailureMergeConflict, []string{"CONFLICT (", "merge conflict", "Automatic merge failed", "<<<<<<<"}},
+	{FailureTokenLimit, []string{"prompt is too long", "context window", "context low", "usage limit", "token limit", "maximum context length"}},
+	{FailureCrash, []string{"panic:", "fatal error:", "segmentation fault", "sigsegv", "core dumped"}},
+	{FailureBuild, []string{"[build failed]", "build failed", "compilation failed", "undefined:", "syntax error", "cannot find module", "error ts", "error[e"}},
+	{FailureTest, []string{"--- fail", "fail\t", "tests failed", "test failed", "failing", "assertionerror", "failed tests"}},
+}
+
+// FailureRecord is the stored failure classification for a task.
+type FailureRecord struct {
+	Kind      FailureKind `json:"kind"`
+	Detail    string      `json:"detail,omitempty"`
+	Attempts  int         `json:"attempts"`
+	UpdatedAt string      `json:"updated_at"`
+}
+
+// ClassifyFailure inspects the end of a timeline and returns the failure kind
+// with a short detail line. It returns FailureNone if the agent ended cleanly.
+// Only the final command and final message are considered, so failures that
+// the agent already fixed earlier in the session are ignored.
+func ClassifyFailure(tl *Timeline) (FailureKind, string) {
+	if tl == nil || len(tl.Events) == 0 {
+		return FailureNone, ""
+	}
+
+	// Token limit / crash messages appear as the last thing in the transcript
+	last := tl.Events[len(tl.Events)-1]
+	lastText := strings.ToLower(last.Detail + "\n" + strings.Join(last.Output, "\n"))
+	for _, kind := range []FailureKind{FailureTokenLimit, FailureCrash} {
+		if matchesFailure(kind, lastText) {
+			return kind, last.Detail
+		}
+	}
+
+	// Otherwise, the agent failed if its last command failed
+	for i := len(tl.Events) - 1; i >= 0; i-- {
+		ev := tl.Events[i]
+		if ev.Kind != TimelineCommand {
+			continue
+		}
+		if !ev.Error {
+			return FailureNone, ""
+		}
+		text := strings.ToLower(ev.Detail + "\n" + strings.Join(ev.Output, "\n"))
+		for _, fp := range failurePatterns {
+			if matchesFailure(fp.kind, text) {
+				return fp.kind, ev.Detail
+			}
+		}
+		return FailureUnknown, ev.Detail
+	}
+
+	return FailureNone, ""
+}
+
+func matchesFailure(kind FailureKind, lowerText string) bool {
+	for _, fp := range failurePatterns {
+		if fp.kind != kind {
+			continue
+		}
+		for _, p := range fp.patterns {
+			if strings.Contains(lowerText, strings.ToLower(p)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsRetryable reports whether a failure can reasonably be fixed by re-prompting the agent.
+// Token limits and crashes need user intervention.
+func (k FailureKind) IsRetryable() bool {
+	switch k {
+	case FailureBuild, FailureTest, FailureMergeConflict, FailureUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuildRetryPrompt builds a refined prompt asking the agent to fix a classified failure.
+func BuildRetryPrompt(rec *FailureRecord) string {
+	var hint string
+	switch rec.Kind {
+	case FailureBuild:
+		hint = "The build is failing. Read the compiler errors carefully and fix them."
+	case FailureTest:
+		hint = "Tests are failing. Find the root cause (not just the symptom) and fix the code or the test."
+	case FailureMergeConflict:
+		hint = "There are merge conflicts. Resolve every conflict marker while preserving both sides' intent."
+	default:
+		hint = "The last command failed. Investigate the error and fix it."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[PAW auto-retry ")
+	sb.WriteString(strconv.Itoa(rec.Attempts))
+	sb.WriteString("] Your previous attempt ended with a ")
+	sb.WriteString(strings.ReplaceAll(string(rec.Kind), "_", " "))
+	if rec.Detail != "" {
+		sb.WriteString(" (`")
+		sb.WriteString(rec.Detail)
+		sb.WriteString("`)")
+	}
+	sb.WriteString(". ")
+	sb.WriteString(hint)
+	sb.WriteString(" Re-run the failing command to verify before finishing.")
+	return sb.String()
+}
+
+// LoadFailureRecord reads a task's failure record. Returns nil if none exists.
+func LoadFailureRecord(path string) *FailureRecord {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled agent directory
+	if err != nil {
+		return nil
+	}
+	var rec FailureRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil
+	}
+	return &rec
+}
+
+// ClearFailureRecord removes a task's failure record, resetting its attempt
+// counter after the agent ended a turn cleanly.
+func ClearFailureRecord(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear failure record: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure stores a failure classification for a task and increments its
+// attempt counter. Returns the updated record.
+func RecordFailure(path string, kind FailureKind, detail string) (*FailureRecord, error) {
+	rec := LoadFailureRecord(path)
+	if rec == nil {
+		rec = &FailureRecord{}
+	}
+	rec.Kind = kind
+	rec.Detail = detail
+	rec.Attempts++
+	rec.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal failure record: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write failure record: %w", err)
+	}
+	return rec, nil
+}
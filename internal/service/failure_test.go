@@ -0,0 +1,128 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript string
+		want       FailureKind
+	}{
+		{
+			name: "clean finish",
+			transcript: `⏺ Bash(go test ./...)
+  ⎿  ok  github.com/dongho-jung/paw/cmd/paw
+⏺ PAW_DONE`,
+			want: FailureNone,
+		},
+		{
+			name: "fixed earlier failure",
+			transcript: `⏺ Bash(go test ./...)
+  ⎿  Error: FAIL	github.com/dongho-jung/paw/cmd/paw
+⏺ Bash(go test ./...)
+  ⎿  ok  github.com/dongho-jung/paw/cmd/paw`,
+			want: FailureNone,
+		},
+		{
+			name: "test failure",
+			transcript: `⏺ Bash(go test ./...)
+  ⎿  Error: --- FAIL: TestFoo (0.00s)
+⏺ I couldn't get the tests to pass.`,
+			want: FailureTest,
+		},
+		{
+			name: "build error",
+			transcript: `⏺ Bash(go build ./...)
+  ⎿  Error: ./main.go:10:2: undefined: foo`,
+			want: FailureBuild,
+		},
+		{
+			name: "merge conflict",
+			transcript: `⏺ Bash(git merge main)
+  ⎿  Error: CONFLICT (content): Merge conflict in main.go`,
+			want: FailureMergeConflict,
+		},
+		{
+			name:       "token limit",
+			transcript: `⏺ API Error: prompt is too long: 210000 tokens > 200000 maximum`,
+			want:       FailureTokenLimit,
+		},
+		{
+			name: "unknown command failure",
+			transcript: `⏺ Bash(make lint)
+  ⎿  Error: exit status 2`,
+			want: FailureUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ClassifyFailure(ParseTimeline(tt.transcript))
+			if got != tt.want {
+				t.Errorf("ClassifyFailure() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureKindIsRetryable(t *testing.T) {
+	if !FailureTest.IsRetryable() {
+		t.Error("test failures should be retryable")
+	}
+	if FailureTokenLimit.IsRetryable() {
+		t.Error("token limit should not be retryable")
+	}
+	if FailureNone.IsRetryable() {
+		t.Error("no failure should not be retryable")
+	}
+}
+
+func TestRecordFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".failure.json")
+
+	rec, err := RecordFailure(path, FailureTest, "go test ./...")
+	if err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+	if rec.Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", rec.Attempts)
+	}
+
+	rec, err = RecordFailure(path, FailureBuild, "go build ./...")
+	if err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+	if rec.Attempts != 2 || rec.Kind != FailureBuild {
+		t.Errorf("got %+v, want attempts=2 kind=build_error", rec)
+	}
+
+	loaded := LoadFailureRecord(path)
+	if loaded == nil || loaded.Attempts != 2 {
+		t.Errorf("LoadFailureRecord() = %+v", loaded)
+	}
+
+	// A clean turn resets the attempt counter
+	if err := ClearFailureRecord(path); err != nil {
+		t.Fatalf("ClearFailureRecord() error = %v", err)
+	}
+	if rec := LoadFailureRecord(path); rec != nil {
+		t.Errorf("LoadFailureRecord() after clear = %+v, want nil", rec)
+	}
+	if err := ClearFailureRecord(path); err != nil {
+		t.Errorf("ClearFailureRecord(missing) error = %v", err)
+	}
+	if rec, err := RecordFailure(path, FailureTest, "go test ./..."); err != nil || rec.Attempts != 1 {
+		t.Errorf("RecordFailure() after clear = %+v, %v; want attempts=1", rec, err)
+	}
+}
+
+func TestBuildRetryPrompt(t *testing.T) {
+	prompt := BuildRetryPrompt(&FailureRecord{Kind: FailureTest, Detail: "go test ./...", Attempts: 1})
+	if !strings.Contains(prompt, "test failure") || !strings.Contains(prompt, "go test ./...") {
+		t.Errorf("unexpected prompt: %s", prompt)
+	}
+}
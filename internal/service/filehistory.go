@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// File history limits keep the section small next to the task itself.
+const (
+	fileHistoryMaxFiles      = 5   // Mentioned files with history
+	fileHistoryMaxCommits    = 5   // Commits listed per file
+	fileHistoryMaxBlameLines = 200 // Longest line range blamed
+)
+
+// fileMentionRegex matches path-like words, optionally followed by a line
+// range: "path:42", "path:42-58", or "`path` lines 42-58" (the form editor
+// tasks use).
+var fileMentionRegex = regexp.MustCompile("([\\w@+./][\\w@+./-]*)(?::(\\d+)(?:-(\\d+))?|`? lines? (\\d+)(?:-(\\d+))?)?")
+
+// FileMention is a project file a task mentions, with the lines it names.
+type FileMention struct {
+	Path      string // Relative to the project, slash-separated
+	StartLine int    // 0 when no lines are named
+	EndLine   int
+}
+
+// FileHistory is the recent history of a mentioned file.
+type FileHistory struct {
+	FileMention
+	Commits     []git.FileCommit // Recent commits that changed the file
+	LineCommits []git.FileCommit // Commits that last changed the named lines
+}
+
+// FindFileMentions returns the files in projectDir that content mentions,
+// in order of first mention. Words count as paths when they contain a slash
+// or a dot and name an existing file; absolute paths must be in the project.
+func FindFileMentions(content, projectDir string) []FileMention {
+	var mentions []FileMention
+	seen := make(map[string]int)
+	for _, m := range fileMentionRegex.FindAllStringSubmatch(content, -1) {
+		path := strings.TrimRight(m[1], ".")
+		if !strings.ContainsAny(path, "/.") {
+			continue
+		}
+		rel, ok := projectFile(projectDir, path)
+		if !ok {
+			continue
+		}
+
+		start, end := mentionLines(m[2], m[3])
+		if start == 0 {
+			start, end = mentionLines(m[4], m[5])
+		}
+		if i, ok := seen[rel]; ok {
+			// Keep the first line range named for a file
+			if mentions[i].StartLine == 0 {
+				mentions[i].StartLine, mentions[i].EndLine = start, end
+			}
+			continue
+		}
+		if len(mentions) == fileHistoryMaxFiles {
+			continue
+		}
+		seen[rel] = len(mentions)
+		mentions = append(mentions, FileMention{Path: rel, StartLine: start, EndLine: end})
+	}
+	return mentions
+}
+
+// projectFile resolves a mentioned path to a regular file in projectDir.
+func projectFile(projectDir, path string) (string, bool) {
+	path = strings.TrimPrefix(path, "./")
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(projectDir, full)
+	}
+	rel, err := filepath.Rel(projectDir, filepath.Clean(full))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	info, err := os.Stat(full)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// mentionLines parses a mentioned line range, capped at fileHistoryMaxBlameLines.
+func mentionLines(startText, endText string) (int, int) {
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 1 {
+		return 0, 0
+	}
+	end, err := strconv.Atoi(endText)
+	if err != nil || end < start {
+		end = start
+	}
+	return start, min(end, start+fileHistoryMaxBlameLines-1)
+}
+
+// CollectFileHistory finds the files content mentions and reads their recent
+// git history in dir. Untracked files are left out.
+func CollectFileHistory(gitClient git.Client, dir, content string) []FileHistory {
+	var histories []FileHistory
+	for _, mention := range FindFileMentions(content, dir) {
+		commits, err := gitClient.FileLog(dir, mention.Path, fileHistoryMaxCommits)
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		h := FileHistory{FileMention: mention, Commits: commits}
+		if mention.StartLine > 0 {
+			// Lines past the end of the file fail the blame; the log still helps
+			if h.LineCommits, err = gitClient.BlameCommits(dir, mention.Path, mention.StartLine, mention.EndLine); err != nil {
+				logging.Debug("Blame of %s:%d-%d failed: %v", mention.Path, mention.StartLine, mention.EndLine, err)
+			}
+		}
+		histories = append(histories, h)
+	}
+	return histories
+}
+
+// FormatFileHistory renders file histories as Markdown sections for the
+// task prompt. Returns "" when there are none.
+func FormatFileHistory(histories []FileHistory) string {
+	if len(histories) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for i, h := range histories {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "### `%s`\n\n", h.Path)
+		writeFileCommits(&sb, h.Commits)
+		if len(h.LineCommits) == 0 {
+			continue
+		}
+		if h.EndLine > h.StartLine {
+			fmt.Fprintf(&sb, "\nLines %d-%d last changed in:\n\n", h.StartLine, h.EndLine)
+		} else {
+			fmt.Fprintf(&sb, "\nLine %d last changed in:\n\n", h.StartLine)
+		}
+		writeFileCommits(&sb, h.LineCommits)
+	}
+	return sb.String()
+}
+
+func writeFileCommits(sb *strings.Builder, commits []git.FileCommit) {
+	for _, c := range commits {
+		fmt.Fprintf(sb, "- %s %s %s: %s\n", c.Hash, c.Date, c.Author, c.Subject)
+	}
+}
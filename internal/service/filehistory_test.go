@@ -0,0 +1,92 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/git"
+)
+
+func TestFindFileMentions(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "internal/db/conn.go", "README.md", "Makefile"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content := "Fix the timeout in ./internal/db/conn.go:42-58 (see main.go.)\n" +
+		"Makefile, missing.go, and " + filepath.Join(dir, "README.md") + " too.\n" +
+		"Also internal/db/conn.go:7 and /etc/passwd and ../outside.go\n\n" +
+		"`main.go` lines 3-4"
+
+	got := FindFileMentions(content, dir)
+	want := []FileMention{
+		{Path: "internal/db/conn.go", StartLine: 42, EndLine: 58},
+		{Path: "main.go", StartLine: 3, EndLine: 4},
+		{Path: "README.md"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindFileMentions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindFileMentions_Limits(t *testing.T) {
+	dir := t.TempDir()
+	var names []string
+	for i := range fileHistoryMaxFiles + 2 {
+		name := string(rune('a'+i)) + ".go"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	got := FindFileMentions(strings.Join(names, " ")+" a.go:10-9999", dir)
+	if len(got) != fileHistoryMaxFiles {
+		t.Fatalf("FindFileMentions() returned %d files, want %d", len(got), fileHistoryMaxFiles)
+	}
+	if got[0].StartLine != 10 || got[0].EndLine != 10+fileHistoryMaxBlameLines-1 {
+		t.Errorf("a.go lines = %d-%d, want 10-%d", got[0].StartLine, got[0].EndLine, 10+fileHistoryMaxBlameLines-1)
+	}
+}
+
+func TestFormatFileHistory(t *testing.T) {
+	if got := FormatFileHistory(nil); got != "" {
+		t.Errorf("FormatFileHistory(nil) = %q, want empty", got)
+	}
+
+	histories := []FileHistory{
+		{
+			FileMention: FileMention{Path: "internal/db/conn.go", StartLine: 42, EndLine: 58},
+			Commits: []git.FileCommit{
+				{Hash: "a1b2c3d", Date: "2026-09-01", Author: "Kim", Subject: "Retry on timeout"},
+				{Hash: "e4f5a6b", Date: "2026-08-12", Author: "Lee", Subject: "Add connection pool"},
+			},
+			LineCommits: []git.FileCommit{
+				{Hash: "a1b2c3d", Date: "2026-09-01", Author: "Kim", Subject: "Retry on timeout"},
+			},
+		},
+		{
+			FileMention: FileMention{Path: "main.go", StartLine: 3, EndLine: 3},
+			Commits:     []git.FileCommit{{Hash: "0c0ffee", Date: "2026-01-02", Author: "Park", Subject: "Initial commit"}},
+		},
+	}
+	want := "### `internal/db/conn.go`\n\n" +
+		"- a1b2c3d 2026-09-01 Kim: Retry on timeout\n" +
+		"- e4f5a6b 2026-08-12 Lee: Add connection pool\n\n" +
+		"Lines 42-58 last changed in:\n\n" +
+		"- a1b2c3d 2026-09-01 Kim: Retry on timeout\n\n" +
+		"### `main.go`\n\n" +
+		"- 0c0ffee 2026-01-02 Park: Initial commit\n"
+	if got := FormatFileHistory(histories); got != want {
+		t.Errorf("FormatFileHistory() =\n%s\nwant:\n%s", got, want)
+	}
+}
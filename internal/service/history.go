@@ -18,10 +18,27 @@ import (
 	"github.com/dongho-jung/paw/internal/task"
 )
 
+// Files of a history entry. Each entry is a directory named
+// YYMMDD_HHMMSS_taskname[.cancelled]; summary.md is written once the summary
+// is generated and hooks.md only if hooks ran.
+const (
+	historyMetaFile    = "meta.json"
+	historyTaskFile    = "task.md"
+	historySummaryFile = "summary.md"
+	historyCaptureFile = "capture.txt"
+	historyHooksFile   = "hooks.md"
+)
+
 // HistoryService handles task history operations.
 type HistoryService struct {
 	historyDir   string
 	claudeClient claude.Client
+	encrypt      bool
+	key          []byte // Resolved lazily; see historyKey
+
+	// asyncSummary, if set, is called with the history entry path instead of
+	// generating the summary inline, so saving doesn't wait on the Claude CLI.
+	asyncSummary func(historyFile string)
 }
 
 // CommitMetadata describes git commit context for a task.
@@ -76,6 +93,97 @@ func (s *HistoryService) SetClaudeClient(client claude.Client) {
 	s.claudeClient = client
 }
 
+// SetEncryption enables encrypting history files written by the service.
+// Reading always decrypts encrypted files, regardless of this setting.
+func (s *HistoryService) SetEncryption(enabled bool) {
+	s.encrypt = enabled
+}
+
+// SetAsyncSummary makes the service write history without a summary and hand the
+// entry to fn, which is expected to fill it in later (see FillSummary).
+func (s *HistoryService) SetAsyncSummary(fn func(historyFile string)) {
+	s.asyncSummary = fn
+}
+
+// historyKey resolves the encryption key once per service.
+func (s *HistoryService) historyKey() ([]byte, error) {
+	if s.key == nil {
+		key, err := ResolveHistoryKey()
+		if err != nil {
+			return nil, err
+		}
+		s.key = key
+	}
+	return s.key, nil
+}
+
+// ReadHistoryFile reads a history file, decrypting it if it is encrypted.
+func (s *HistoryService) ReadHistoryFile(historyFile string) (string, error) {
+	data, err := os.ReadFile(historyFile) //nolint:gosec // G304: historyFile is from controlled history directory
+	if err != nil {
+		return "", fmt.Errorf("failed to read history file: %w", err)
+	}
+	if !IsEncryptedHistory(data) {
+		return string(data), nil
+	}
+
+	key, err := s.historyKey()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := DecryptHistory(key, data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", filepath.Base(historyFile), err)
+	}
+	return string(plaintext), nil
+}
+
+// EncryptExisting encrypts every plaintext history entry in place.
+// Returns the number of entries encrypted.
+func (s *HistoryService) EncryptExisting() (int, error) {
+	key, err := s.historyKey()
+	if err != nil {
+		return 0, err
+	}
+	paths, err := s.ListHistoryFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, path := range paths {
+		files := []string{path}
+		if entries, err := os.ReadDir(path); err == nil {
+			files = files[:0]
+			for _, entry := range entries {
+				if entry.Type().IsRegular() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+
+		encrypted := false
+		for _, file := range files {
+			data, err := os.ReadFile(file) //nolint:gosec // G304: file is from controlled history directory
+			if err != nil || IsEncryptedHistory(data) {
+				continue
+			}
+			sealed, err := EncryptHistory(key, data)
+			if err != nil {
+				return count, err
+			}
+			if err := fileutil.WriteFileAtomic(file, sealed, 0600); err != nil {
+				return count, fmt.Errorf("failed to write history file: %w", err)
+			}
+			encrypted = true
+		}
+		if encrypted {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // SaveCompleted saves a completed task to history.
 func (s *HistoryService) SaveCompleted(taskName, taskContent, paneContent string) error {
 	return s.save(taskName, taskContent, paneContent, false, nil, nil)
@@ -96,6 +204,21 @@ func (s *HistoryService) SaveCancelledWithDetails(taskName, taskContent, paneCon
 	return s.save(taskName, taskContent, paneContent, true, meta, hookOutputs)
 }
 
+// SaveResearch saves a finished research task to history.
+// The agent's answer is stored as the summary, so no summary is generated.
+func (s *HistoryService) SaveResearch(taskName, taskContent, answer, paneContent string, meta *HistoryMetadata) error {
+	if err := os.MkdirAll(s.historyDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if strings.TrimSpace(answer) == "" && paneContent == "" {
+		return errors.New("empty research answer and pane content")
+	}
+
+	_, err := s.write(taskName, taskContent, strings.TrimSpace(answer), paneContent, false, meta, nil)
+	return err
+}
+
 // RecordStatusTransition records a status transition for a task.
 func (s *HistoryService) RecordStatusTransition(taskName string, from, to task.Status, source, detail string, valid bool) error {
 	statusDir := filepath.Join(s.historyDir, "status")
@@ -142,68 +265,76 @@ func (s *HistoryService) save(taskName, taskContent, paneContent string, cancell
 		return errors.New("empty pane content")
 	}
 
-	// Generate summary using Claude
-	summary, err := s.claudeClient.GenerateSummary(paneContent)
+	// Write the entry first so generating the summary never delays or loses it
+	historyFile, err := s.write(taskName, taskContent, "", paneContent, cancelled, meta, hookOutputs)
 	if err != nil {
-		logging.Warn("Failed to generate summary: %v", err)
-		summary = "" // Continue without summary
-	} else {
-		logging.Debug("Generated summary: %d chars", len(summary))
+		return err
 	}
 
-	// Build history content: task + summary + pane capture
-	var historyContent strings.Builder
-	if meta != nil {
-		if meta.TaskName == "" {
-			meta.TaskName = taskName
-		}
-		metaData, err := json.MarshalIndent(meta, "", "  ")
-		if err == nil {
-			historyContent.WriteString("---meta---\n")
-			historyContent.Write(metaData)
-			historyContent.WriteString("\n")
-		} else {
-			logging.Warn("Failed to marshal history metadata: %v", err)
-		}
-		historyContent.WriteString("---task---\n")
+	if s.asyncSummary != nil {
+		s.asyncSummary(historyFile)
+		return nil
+	}
+	if err := s.FillSummary(historyFile); err != nil {
+		logging.Warn("Failed to generate summary: %v", err) // Continue without summary
+	}
+	return nil
+}
+
+// FillSummary generates a summary from a history entry's pane capture using
+// Claude and writes it to the entry's summary.md.
+func (s *HistoryService) FillSummary(historyFile string) error {
+	entry, err := s.ReadEntry(historyFile)
+	if err != nil {
+		return err
 	}
-	if taskContent != "" {
-		historyContent.WriteString(taskContent)
-		historyContent.WriteString("\n---summary---\n")
+	if entry.Legacy {
+		return errors.New("single-file history entry (run 'paw history migrate')")
 	}
-	if summary != "" {
-		historyContent.WriteString(summary)
+	if entry.Capture == "" {
+		return errors.New("history entry has no pane capture")
 	}
-	historyContent.WriteString("\n---capture---\n")
-	historyContent.WriteString(paneContent)
 
-	if len(hookOutputs) > 0 {
-		historyContent.WriteString("\n---hooks---\n")
-		hookNames := make([]string, 0, len(hookOutputs))
-		for name := range hookOutputs {
-			hookNames = append(hookNames, name)
-		}
-		sort.Strings(hookNames)
-		for _, name := range hookNames {
-			output := hookOutputs[name]
-			historyContent.WriteString(fmt.Sprintf("## %s\n", name))
-			historyContent.WriteString(output)
-			if !strings.HasSuffix(output, "\n") {
-				historyContent.WriteString("\n")
-			}
-		}
+	summary, err := s.claudeClient.GenerateSummary(entry.Capture)
+	if err != nil {
+		return err
+	}
+	logging.Debug("Generated summary: %d chars", len(summary))
+
+	// Encrypt the summary like the rest of the entry
+	raw, err := os.ReadFile(filepath.Join(historyFile, historyCaptureFile)) //nolint:gosec // G304: historyFile is from controlled history directory
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	return s.writeFile(filepath.Join(historyFile, historySummaryFile), []byte(strings.TrimSpace(summary)), IsEncryptedHistory(raw))
+}
+
+// write saves a new history entry. Returns the path of the entry directory.
+func (s *HistoryService) write(taskName, taskContent, summary, paneContent string, cancelled bool, meta *HistoryMetadata, hookOutputs map[string]string) (string, error) {
+	if meta == nil {
+		meta = &HistoryMetadata{}
+	}
+	if meta.TaskName == "" {
+		meta.TaskName = taskName
+	}
+	entry := &HistoryEntry{
+		Meta:    meta,
+		Task:    taskContent,
+		Summary: summary,
+		Capture: paneContent,
+		Hooks:   formatHookOutputs(hookOutputs),
 	}
 
-	// Generate filename: YYMMDD_HHMMSS_taskname[.cancelled]
+	// Entry name: YYMMDD_HHMMSS_taskname[.cancelled]
 	timestamp := time.Now().Format("060102_150405")
-	filename := fmt.Sprintf("%s_%s", timestamp, taskName)
+	name := fmt.Sprintf("%s_%s", timestamp, taskName)
 	if cancelled {
-		filename += ".cancelled"
+		name += ".cancelled"
 	}
 
-	historyFile := filepath.Join(s.historyDir, filename)
-	if err := fileutil.WriteFileAtomic(historyFile, []byte(historyContent.String()), 0644); err != nil {
-		return fmt.Errorf("failed to write history file: %w", err)
+	historyFile := filepath.Join(s.historyDir, name)
+	if err := s.writeEntry(historyFile, entry, s.encrypt); err != nil {
+		return "", err
 	}
 
 	status := "completed"
@@ -212,45 +343,92 @@ func (s *HistoryService) save(taskName, taskContent, paneContent string, cancell
 	}
 	logging.Debug("Task history saved (%s): %s", status, historyFile)
 
-	return nil
+	return historyFile, nil
 }
 
-// LoadTaskContent loads the task content from a history file.
-func (s *HistoryService) LoadTaskContent(historyFile string) (string, error) {
-	data, err := os.ReadFile(historyFile) //nolint:gosec // G304: historyFile is from controlled history directory
-	if err != nil {
-		return "", fmt.Errorf("failed to read history file: %w", err)
+// writeEntry writes the files of a history entry to entryDir.
+func (s *HistoryService) writeEntry(entryDir string, entry *HistoryEntry, encrypt bool) error {
+	if err := os.MkdirAll(entryDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return fmt.Errorf("failed to create history entry: %w", err)
 	}
 
-	content := string(data)
+	files := map[string]string{
+		historyTaskFile:    entry.Task,
+		historyCaptureFile: entry.Capture,
+	}
+	if entry.Meta != nil {
+		metaData, err := json.MarshalIndent(entry.Meta, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal history metadata: %w", err)
+		}
+		files[historyMetaFile] = string(metaData) + "\n"
+	}
+	if entry.Summary != "" {
+		files[historySummaryFile] = entry.Summary
+	}
+	if entry.Hooks != "" {
+		files[historyHooksFile] = entry.Hooks
+	}
 
-	// Extract task content (after ---task--- if present)
-	if idx := strings.Index(content, "\n---task---\n"); idx != -1 {
-		content = content[idx+len("\n---task---\n"):]
-		if idxSummary := strings.Index(content, "\n---summary---\n"); idxSummary != -1 {
-			return content[:idxSummary], nil
+	for name, content := range files {
+		if err := s.writeFile(filepath.Join(entryDir, name), []byte(content), encrypt); err != nil {
+			return err
 		}
-		if idxCapture := strings.Index(content, "\n---capture---\n"); idxCapture != -1 {
-			return content[:idxCapture], nil
+	}
+	return nil
+}
+
+// formatHookOutputs renders hook outputs as "## name" sections sorted by name.
+func formatHookOutputs(hookOutputs map[string]string) string {
+	hookNames := make([]string, 0, len(hookOutputs))
+	for name := range hookOutputs {
+		hookNames = append(hookNames, name)
+	}
+	sort.Strings(hookNames)
+
+	var b strings.Builder
+	for _, name := range hookNames {
+		output := hookOutputs[name]
+		b.WriteString(fmt.Sprintf("## %s\n", name))
+		b.WriteString(output)
+		if !strings.HasSuffix(output, "\n") {
+			b.WriteString("\n")
 		}
-		return content, nil
 	}
+	return b.String()
+}
 
-	// Extract task content (before ---summary---)
-	if idx := strings.Index(content, "\n---summary---\n"); idx != -1 {
-		return content[:idx], nil
+// writeFile writes history file data, encrypting it if requested.
+func (s *HistoryService) writeFile(historyFile string, data []byte, encrypt bool) error {
+	perm := os.FileMode(0644)
+	if encrypt {
+		key, err := s.historyKey()
+		if err != nil {
+			return fmt.Errorf("history encryption is enabled: %w", err)
+		}
+		if data, err = EncryptHistory(key, data); err != nil {
+			return err
+		}
+		perm = 0600
 	}
 
-	// No summary section, check for capture section
-	if idx := strings.Index(content, "\n---capture---\n"); idx != -1 {
-		return content[:idx], nil
+	if err := fileutil.WriteFileAtomic(historyFile, data, perm); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
 	}
+	return nil
+}
 
-	// No sections found, return as-is
-	return content, nil
+// LoadTaskContent loads the task content from a history entry.
+func (s *HistoryService) LoadTaskContent(historyFile string) (string, error) {
+	entry, err := s.ReadEntry(historyFile)
+	if err != nil {
+		return "", err
+	}
+	return entry.Task, nil
 }
 
-// ListHistoryFiles returns all history files sorted by modification time (newest first).
+// ListHistoryFiles returns the paths of all history entries: entry
+// directories, and single files saved before entries were directories.
 func (s *HistoryService) ListHistoryFiles() ([]string, error) {
 	entries, err := os.ReadDir(s.historyDir)
 	if err != nil {
@@ -262,7 +440,8 @@ func (s *HistoryService) ListHistoryFiles() ([]string, error) {
 
 	files := make([]string, 0, len(entries))
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// Other directories (status, artifacts) aren't entries
+		if !entry.IsDir() || isHistoryEntryName(entry.Name()) {
 			files = append(files, filepath.Join(s.historyDir, entry.Name()))
 		}
 	}
@@ -270,12 +449,23 @@ func (s *HistoryService) ListHistoryFiles() ([]string, error) {
 	return files, nil
 }
 
-// IsCancelled checks if a history file is a cancelled task.
+// isHistoryEntryName reports whether name starts with an entry timestamp
+// (YYMMDD_HHMMSS_).
+func isHistoryEntryName(name string) bool {
+	const timestampLen = len("060102_150405")
+	if len(name) <= timestampLen+1 || name[timestampLen] != '_' {
+		return false
+	}
+	_, err := time.Parse("060102_150405", name[:timestampLen])
+	return err == nil
+}
+
+// IsCancelled checks if a history entry is a cancelled task.
 func IsCancelled(historyFile string) bool {
 	return strings.HasSuffix(historyFile, ".cancelled")
 }
 
-// ExtractTaskName extracts the task name from a history filename.
+// ExtractTaskName extracts the task name from a history entry name.
 // Format: YYMMDD_HHMMSS_taskname[.cancelled]
 func ExtractTaskName(historyFile string) string {
 	base := filepath.Base(historyFile)
@@ -1,12 +1,14 @@
 package service
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -27,6 +29,18 @@ func (m *mockClaudeClient) GenerateSummary(paneContent string) (string, error) {
 	return m.summaryToReturn, nil
 }
 
+func (m *mockClaudeClient) GenerateTaskSplit(content string) (string, error) {
+	return "[]", nil
+}
+
+func (m *mockClaudeClient) GenerateSelfEval(taskContent, diff, paneContent string) (string, error) {
+	return "{}", nil
+}
+
+func (m *mockClaudeClient) GenerateReview(model, taskContent, diff string) (string, error) {
+	return "{}", nil
+}
+
 func (m *mockClaudeClient) WaitForReady(tm tmux.Client, target string) error {
 	return nil
 }
@@ -84,21 +98,19 @@ func TestHistoryService_SaveCompleted(t *testing.T) {
 		t.Fatalf("Expected 1 history file, got %d", len(files))
 	}
 
-	// Verify file contents
-	content, err := os.ReadFile(files[0])
-	if err != nil {
-		t.Fatalf("Failed to read history file: %v", err)
-	}
-
-	contentStr := string(content)
-	if !strings.Contains(contentStr, "Task content here") {
-		t.Error("History file should contain task content")
-	}
-	if !strings.Contains(contentStr, "Test summary") {
-		t.Error("History file should contain summary")
+	// Verify entry files
+	for name, want := range map[string]string{
+		"task.md":     "Task content here",
+		"summary.md":  "Test summary",
+		"capture.txt": "Pane content here",
+	} {
+		data, err := os.ReadFile(filepath.Join(files[0], name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
 	}
-	if !strings.Contains(contentStr, "Pane content here") {
-		t.Error("History file should contain pane content")
+	if data, err := os.ReadFile(filepath.Join(files[0], "meta.json")); err != nil || !strings.Contains(string(data), `"task_name": "test-task"`) {
+		t.Errorf("meta.json = %q, %v", data, err)
 	}
 
 	// Verify filename format (not cancelled)
@@ -107,6 +119,39 @@ func TestHistoryService_SaveCompleted(t *testing.T) {
 	}
 }
 
+func TestHistoryService_SaveResearch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	svc := NewHistoryService(tmpDir)
+	svc.SetClaudeClient(&mockClaudeClient{
+		summaryError: errors.New("summary should not be generated"),
+	})
+
+	meta := &HistoryMetadata{TaskOptions: &config.TaskOptions{Research: true}}
+	if err := svc.SaveResearch("research-task", "Why is X slow?", "Because of Y.\n", "Pane content here", meta); err != nil {
+		t.Fatalf("SaveResearch failed: %v", err)
+	}
+
+	files, err := svc.ListHistoryFiles()
+	if err != nil {
+		t.Fatalf("ListHistoryFiles failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 history file, got %d", len(files))
+	}
+
+	entry, err := svc.ReadEntry(files[0])
+	if err != nil {
+		t.Fatalf("ReadEntry failed: %v", err)
+	}
+	if entry.Summary != "Because of Y." {
+		t.Errorf("Summary = %q, want the answer", entry.Summary)
+	}
+	if entry.Meta == nil || entry.Meta.TaskOptions == nil || !entry.Meta.TaskOptions.Research {
+		t.Errorf("Meta = %+v, want research task options", entry.Meta)
+	}
+}
+
 func TestHistoryService_SaveCancelled(t *testing.T) {
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "paw-history-test-*")
@@ -229,3 +274,90 @@ func TestHistoryService_EmptyPaneContent(t *testing.T) {
 		t.Error("Expected error for empty pane content")
 	}
 }
+
+func TestHistoryService_AsyncSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	svc := NewHistoryService(tmpDir)
+	svc.SetClaudeClient(&mockClaudeClient{
+		summaryToReturn: "Async summary",
+	})
+	var pending string
+	svc.SetAsyncSummary(func(historyFile string) {
+		pending = historyFile
+	})
+
+	hooks := map[string]string{"post-task": "ok"}
+	if err := svc.SaveCompletedWithDetails("test-task", "Task content", "Pane content", &HistoryMetadata{}, hooks); err != nil {
+		t.Fatalf("SaveCompletedWithDetails failed: %v", err)
+	}
+	if pending == "" {
+		t.Fatal("async summary callback was not called")
+	}
+
+	if _, err := os.Stat(filepath.Join(pending, "summary.md")); !os.IsNotExist(err) {
+		t.Errorf("History entry should be saved without a summary, stat err = %v", err)
+	}
+
+	if err := svc.FillSummary(pending); err != nil {
+		t.Fatalf("FillSummary failed: %v", err)
+	}
+	entry, err := svc.ReadEntry(pending)
+	if err != nil {
+		t.Fatalf("ReadEntry failed: %v", err)
+	}
+	if entry.Task != "Task content" || entry.Summary != "Async summary" || entry.Capture != "Pane content" || entry.Hooks != "## post-task\nok\n" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestParseLegacyHistory(t *testing.T) {
+	entry := parseLegacyHistory("---meta---\n{\"task_name\": \"old\"}\n---task---\nFix it\n---summary---\nFixed.\n---capture---\npane\n---hooks---\n## post-task\nok\n")
+	if entry.Meta == nil || entry.Meta.TaskName != "old" {
+		t.Errorf("Meta = %+v", entry.Meta)
+	}
+	if entry.Task != "Fix it" || entry.Summary != "Fixed." || entry.Capture != "pane" || entry.Hooks != "## post-task\nok\n" {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	// Saved without a summary
+	entry = parseLegacyHistory("Fix it\n---summary---\n\n---capture---\npane")
+	if entry.Task != "Fix it" || entry.Summary != "" || entry.Capture != "pane" {
+		t.Errorf("entry without summary = %+v", entry)
+	}
+}
+
+func TestHistoryService_MigrateLegacy(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "241231_120000_old-task.cancelled")
+	if err := os.WriteFile(path, []byte("Fix it\n---summary---\nGave up.\n---capture---\npane"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "status"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewHistoryService(tmpDir)
+	count, err := svc.MigrateLegacy()
+	if err != nil || count != 1 {
+		t.Fatalf("MigrateLegacy() = %d, %v; want 1, nil", count, err)
+	}
+	if count, _ := svc.MigrateLegacy(); count != 0 {
+		t.Errorf("MigrateLegacy() migrated %d entries again", count)
+	}
+
+	files, err := svc.ListHistoryFiles()
+	if err != nil || len(files) != 1 || files[0] != path {
+		t.Fatalf("ListHistoryFiles() = %q, %v", files, err)
+	}
+	entry, err := svc.ReadEntry(path)
+	if err != nil {
+		t.Fatalf("ReadEntry failed: %v", err)
+	}
+	if entry.Legacy || entry.Task != "Fix it" || entry.Summary != "Gave up." || entry.Capture != "pane" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Meta == nil || entry.Meta.TaskName != "old-task" {
+		t.Errorf("Meta = %+v", entry.Meta)
+	}
+}
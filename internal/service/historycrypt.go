@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// ErrNoHistoryKey is returned when no history encryption key is configured.
+var ErrNoHistoryKey = errors.New("no history key (set " + constants.HistoryKeyEnv + " or run 'paw history init-key')")
+
+// IsEncryptedHistory reports whether history file data is encrypted.
+func IsEncryptedHistory(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(constants.HistoryEncryptedHeader))
+}
+
+// EncryptHistory encrypts history file data with AES-256-GCM.
+// The output is the header, a random nonce, and the sealed data.
+func EncryptHistory(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newHistoryGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := append([]byte(constants.HistoryEncryptedHeader), nonce...)
+	return gcm.Seal(out, nonce, plaintext, nil), nil
+}
+
+// DecryptHistory decrypts data produced by EncryptHistory.
+func DecryptHistory(key, data []byte) ([]byte, error) {
+	if !IsEncryptedHistory(data) {
+		return nil, errors.New("history file is not encrypted")
+	}
+	gcm, err := newHistoryGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[len(constants.HistoryEncryptedHeader):]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted history file is truncated")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt history file (wrong key?)")
+	}
+	return plaintext, nil
+}
+
+func newHistoryGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid history key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// DeriveHistoryKey turns a secret (from the env or keychain) into an AES-256 key.
+func DeriveHistoryKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return sum[:]
+}
+
+// GenerateHistorySecret returns a new random secret for history encryption.
+func GenerateHistorySecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return "", fmt.Errorf("failed to generate history key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// ResolveHistoryKey returns the history encryption key from PAW_HISTORY_KEY,
+// falling back to the OS keychain (macOS Keychain or Secret Service via secret-tool).
+func ResolveHistoryKey() ([]byte, error) {
+	if secret := os.Getenv(constants.HistoryKeyEnv); strings.TrimSpace(secret) != "" {
+		return DeriveHistoryKey(secret), nil
+	}
+	secret, err := lookupKeychainSecret()
+	if err != nil || secret == "" {
+		return nil, ErrNoHistoryKey
+	}
+	return DeriveHistoryKey(secret), nil
+}
+
+// StoreHistorySecret saves the history secret in the OS keychain. The secret
+// is passed on stdin, never as an argument visible in the process list.
+func StoreHistorySecret(secret string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.HistoryKeychainTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// security -i runs commands read from stdin
+		cmd = exec.CommandContext(ctx, "security", "-i")
+		cmd.Stdin = strings.NewReader(securityStoreCommand(secret))
+	default:
+		cmd = exec.CommandContext(ctx, "secret-tool", "store", "--label=PAW history key",
+			"service", constants.HistoryKeychainService, "account", constants.HistoryKeychainAccount)
+		cmd.Stdin = strings.NewReader(secret)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store history key in keychain: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// security -i exits 0 even when a command fails, so read the key back
+	if stored, err := lookupKeychainSecret(); err != nil || stored != secret {
+		return fmt.Errorf("failed to store history key in keychain: key not found after storing it")
+	}
+	return nil
+}
+
+// securityStoreCommand returns the 'security -i' command line that stores
+// secret as the history key.
+func securityStoreCommand(secret string) string {
+	return fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		securityQuote(constants.HistoryKeychainService), securityQuote(constants.HistoryKeychainAccount), securityQuote(secret))
+}
+
+// securityQuote quotes an argument for a 'security -i' command line.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func lookupKeychainSecret() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.HistoryKeychainTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password",
+			"-s", constants.HistoryKeychainService, "-a", constants.HistoryKeychainAccount, "-w")
+	default:
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup",
+			"service", constants.HistoryKeychainService, "account", constants.HistoryKeychainAccount)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
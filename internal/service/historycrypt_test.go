@@ -0,0 +1,101 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestEncryptDecryptHistory(t *testing.T) {
+	key := DeriveHistoryKey("secret")
+	plaintext := []byte("task\n---summary---\nAPI_KEY=abc123")
+
+	encrypted, err := EncryptHistory(key, plaintext)
+	if err != nil {
+		t.Fatalf("EncryptHistory() error = %v", err)
+	}
+	if !IsEncryptedHistory(encrypted) {
+		t.Fatal("encrypted data has no header")
+	}
+	if strings.Contains(string(encrypted), "abc123") {
+		t.Fatal("encrypted data contains plaintext")
+	}
+
+	decrypted, err := DecryptHistory(key, encrypted)
+	if err != nil {
+		t.Fatalf("DecryptHistory() error = %v", err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Errorf("DecryptHistory() = %q, want %q", decrypted, plaintext)
+	}
+
+	if _, err := DecryptHistory(DeriveHistoryKey("other"), encrypted); err == nil {
+		t.Error("DecryptHistory() with wrong key succeeded")
+	}
+}
+
+func TestHistoryServiceEncryption(t *testing.T) {
+	t.Setenv(constants.HistoryKeyEnv, "test-secret")
+	dir := t.TempDir()
+
+	svc := NewHistoryService(dir)
+	svc.SetEncryption(true)
+	if err := svc.SaveResearch("my-task", "Find the bug", "It is in main.go", "pane capture", nil); err != nil {
+		t.Fatalf("SaveResearch() error = %v", err)
+	}
+
+	files, err := svc.ListHistoryFiles()
+	if err != nil || len(files) != 1 {
+		t.Fatalf("ListHistoryFiles() = %v, %v", files, err)
+	}
+	for _, name := range []string{"meta.json", "task.md", "summary.md", "capture.txt"} {
+		raw, _ := os.ReadFile(filepath.Join(files[0], name))
+		if !IsEncryptedHistory(raw) || strings.Contains(string(raw), "Find the bug") {
+			t.Fatalf("%s is not encrypted", name)
+		}
+	}
+
+	// A fresh reader decrypts transparently
+	reader := NewHistoryService(dir)
+	content, err := reader.LoadTaskContent(files[0])
+	if err != nil {
+		t.Fatalf("LoadTaskContent() error = %v", err)
+	}
+	if content != "Find the bug" {
+		t.Errorf("LoadTaskContent() = %q, want %q", content, "Find the bug")
+	}
+}
+
+func TestHistoryServiceEncryptExisting(t *testing.T) {
+	t.Setenv(constants.HistoryKeyEnv, "test-secret")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "260101_120000_old-task")
+	if err := os.WriteFile(path, []byte("old task\n---summary---\ndone"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewHistoryService(dir)
+	count, err := svc.EncryptExisting()
+	if err != nil || count != 1 {
+		t.Fatalf("EncryptExisting() = %d, %v; want 1, nil", count, err)
+	}
+	if count, _ := svc.EncryptExisting(); count != 0 {
+		t.Errorf("EncryptExisting() re-encrypted %d files", count)
+	}
+
+	content, err := svc.ReadHistoryFile(path)
+	if err != nil || content != "old task\n---summary---\ndone" {
+		t.Errorf("ReadHistoryFile() = %q, %v", content, err)
+	}
+}
+
+func TestSecurityStoreCommand(t *testing.T) {
+	got := securityStoreCommand(`se"cr\et`)
+	want := `add-generic-password -U -s "` + constants.HistoryKeychainService + `" -a "` + constants.HistoryKeychainAccount + `" -w "se\"cr\\et"` + "\n"
+	if got != want {
+		t.Errorf("securityStoreCommand() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Section markers of single-file history entries (saved before entries
+// were directories).
+const (
+	legacyMetaMarker    = "---meta---\n"
+	legacyTaskMarker    = "\n---task---\n"
+	legacySummaryMarker = "\n---summary---\n"
+	legacyCaptureMarker = "\n---capture---\n"
+	legacyHooksMarker   = "\n---hooks---\n"
+)
+
+// HistoryEntry is a task saved to history.
+type HistoryEntry struct {
+	Path    string
+	Meta    *HistoryMetadata // nil if the entry has no metadata
+	Task    string
+	Summary string // Empty until the summary is generated
+	Capture string // Agent pane capture at task end
+	Hooks   string // Hook outputs as "## name" sections
+	Legacy  bool   // Single-file entry (see MigrateLegacy)
+}
+
+// ReadEntry reads a history entry, decrypting its files if they are encrypted.
+// Single-file entries are parsed from their section markers.
+func (s *HistoryService) ReadEntry(historyFile string) (*HistoryEntry, error) {
+	info, err := os.Stat(historyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history entry: %w", err)
+	}
+	if !info.IsDir() {
+		content, err := s.ReadHistoryFile(historyFile)
+		if err != nil {
+			return nil, err
+		}
+		entry := parseLegacyHistory(content)
+		entry.Path = historyFile
+		entry.Legacy = true
+		return entry, nil
+	}
+
+	entry := &HistoryEntry{Path: historyFile}
+	for name, dst := range map[string]*string{
+		historyTaskFile:    &entry.Task,
+		historySummaryFile: &entry.Summary,
+		historyCaptureFile: &entry.Capture,
+		historyHooksFile:   &entry.Hooks,
+	} {
+		content, err := s.ReadHistoryFile(filepath.Join(historyFile, name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		*dst = content
+	}
+
+	metaData, err := s.ReadHistoryFile(filepath.Join(historyFile, historyMetaFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if metaData != "" {
+		var meta HistoryMetadata
+		if err := json.Unmarshal([]byte(metaData), &meta); err != nil {
+			return nil, fmt.Errorf("%s: invalid %s: %w", filepath.Base(historyFile), historyMetaFile, err)
+		}
+		entry.Meta = &meta
+	}
+	return entry, nil
+}
+
+// Render formats the entry for display, one headed section per part.
+func (e *HistoryEntry) Render() string {
+	var b strings.Builder
+	section := func(title, content string) {
+		if content = strings.TrimRight(content, "\n"); content == "" {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("# " + title + "\n\n")
+		b.WriteString(content + "\n")
+	}
+
+	if e.Meta != nil {
+		if metaData, err := json.MarshalIndent(e.Meta, "", "  "); err == nil {
+			section("Metadata", string(metaData))
+		}
+	}
+	section("Task", e.Task)
+	section("Summary", e.Summary)
+	section("Capture", e.Capture)
+	section("Hooks", e.Hooks)
+	return b.String()
+}
+
+// parseLegacyHistory splits a single-file entry into its sections:
+// [---meta--- json ---task---] task [---summary--- summary] ---capture--- capture [---hooks--- hooks]
+func parseLegacyHistory(content string) *HistoryEntry {
+	entry := &HistoryEntry{}
+	if strings.HasPrefix(content, legacyMetaMarker) {
+		rest := content[len(legacyMetaMarker):]
+		if idx := strings.Index(rest, legacyTaskMarker); idx != -1 {
+			var meta HistoryMetadata
+			if err := json.Unmarshal([]byte(rest[:idx]), &meta); err == nil {
+				entry.Meta = &meta
+			}
+			content = rest[idx+len(legacyTaskMarker):]
+		}
+	}
+
+	head := content
+	if idx := strings.Index(content, legacyCaptureMarker); idx != -1 {
+		head = content[:idx]
+		entry.Capture = content[idx+len(legacyCaptureMarker):]
+		if idx := strings.Index(entry.Capture, legacyHooksMarker); idx != -1 {
+			entry.Hooks = entry.Capture[idx+len(legacyHooksMarker):]
+			entry.Capture = entry.Capture[:idx]
+		}
+	}
+
+	// Pad with newlines so a summary marker at either end of head still matches
+	padded := "\n" + head + "\n"
+	if idx := strings.Index(padded, legacySummaryMarker); idx != -1 {
+		entry.Task = strings.TrimPrefix(padded[:idx], "\n")
+		entry.Summary = strings.TrimSpace(padded[idx+len(legacySummaryMarker):])
+	} else {
+		entry.Task = head
+	}
+	return entry
+}
+
+// MigrateLegacy converts single-file history entries to entry directories,
+// keeping their names and encryption. Returns the number of entries migrated;
+// entries that can't be read (e.g. encrypted without the key) are left as
+// they are and reported in the error.
+func (s *HistoryService) MigrateLegacy() (int, error) {
+	paths, err := s.ListHistoryFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var errs []error
+	for _, path := range paths {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		if err := s.migrateEntry(path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
+
+// migrateEntry replaces a single-file entry with an entry directory of the same name.
+func (s *HistoryService) migrateEntry(path string) error {
+	raw, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled history directory
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	entry, err := s.ReadEntry(path)
+	if err != nil {
+		return err
+	}
+	if entry.Meta == nil {
+		entry.Meta = &HistoryMetadata{}
+	}
+	if entry.Meta.TaskName == "" {
+		entry.Meta.TaskName = ExtractTaskName(path)
+	}
+
+	// Write next to the file under a hidden name, then swap it in
+	tmpDir := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".migrating")
+	if err := s.writeEntry(tmpDir, entry, IsEncryptedHistory(raw)); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to remove history file: %w", err)
+	}
+	if err := os.Rename(tmpDir, path); err != nil {
+		return fmt.Errorf("failed to move migrated entry into place: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dongho-jung/paw/internal/fileutil"
@@ -27,6 +28,7 @@ type InputHistoryEntry struct {
 // InputHistoryService handles task input history operations.
 type InputHistoryService struct {
 	pawDir string
+	user   string // Other user of a shared session ("" for the session owner)
 }
 
 // NewInputHistoryService creates a new input history service.
@@ -36,11 +38,35 @@ func NewInputHistoryService(pawDir string) *InputHistoryService {
 	}
 }
 
+// NewUserInputHistoryService creates an input history service that keeps a
+// separate history for another user of a shared session. An empty user uses
+// the project's history.
+func NewUserInputHistoryService(pawDir, user string) *InputHistoryService {
+	return &InputHistoryService{
+		pawDir: pawDir,
+		user:   sanitizeHistoryUser(user),
+	}
+}
+
 // getHistoryPath returns the path to the input history file.
 func (s *InputHistoryService) getHistoryPath() string {
+	if s.user != "" {
+		return filepath.Join(s.pawDir, InputHistoryFile+"-"+s.user)
+	}
 	return filepath.Join(s.pawDir, InputHistoryFile)
 }
 
+// sanitizeHistoryUser keeps the characters of a user name that are safe in
+// a file name.
+func sanitizeHistoryUser(user string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.Trim(user, "."))
+}
+
 // SaveInput saves a task input to history.
 func (s *InputHistoryService) SaveInput(content string) error {
 	if content == "" {
@@ -176,3 +176,32 @@ func TestInputHistoryService_CorruptedFile(t *testing.T) {
 		t.Errorf("Expected a .corrupt backup for input history")
 	}
 }
+
+func TestInputHistoryService_PerUser(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	owner := NewInputHistoryService(tmpDir)
+	guest := NewUserInputHistoryService(tmpDir, "bob")
+
+	if err := owner.SaveInput("owner task"); err != nil {
+		t.Fatalf("SaveInput failed: %v", err)
+	}
+	if err := guest.SaveInput("guest task"); err != nil {
+		t.Fatalf("SaveInput failed: %v", err)
+	}
+
+	contents, err := guest.GetAllContents()
+	if err != nil {
+		t.Fatalf("GetAllContents failed: %v", err)
+	}
+	if len(contents) != 1 || contents[0] != "guest task" {
+		t.Errorf("guest history = %v, want [guest task]", contents)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, InputHistoryFile+"-bob")); err != nil {
+		t.Errorf("guest history file missing: %v", err)
+	}
+
+	if got := NewUserInputHistoryService(tmpDir, "../evil").getHistoryPath(); filepath.Dir(got) != tmpDir {
+		t.Errorf("history path %q escapes the paw directory", got)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// JUnit import limits keep tasks readable when a report has long logs.
+const (
+	junitMaxReportBytes      = 32 << 20 // Largest report read
+	junitMaxOutputBytes      = 8 << 10  // Failure output kept per task
+	junitCombinedOutputBytes = 2 << 10  // Failure output kept per test in a combined task
+	junitFetchTimeout        = 30 * time.Second
+)
+
+// JUnitFailure is a failing (or erroring) test case in a JUnit XML report.
+type JUnitFailure struct {
+	Suite     string
+	ClassName string
+	Name      string
+	File      string
+	Line      string
+	Message   string
+	Type      string
+	Output    string
+}
+
+// ID returns the test's qualified name: class name and test name.
+func (f JUnitFailure) ID() string {
+	if f.ClassName == "" || strings.HasPrefix(f.Name, f.ClassName) {
+		return f.Name
+	}
+	return f.ClassName + "." + f.Name
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Line      string        `xml:"line,attr"`
+	Failures  []junitResult `xml:"failure"`
+	Errors    []junitResult `xml:"error"`
+	SystemOut string        `xml:"system-out"`
+	SystemErr string        `xml:"system-err"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitReport returns the failing test cases of a JUnit XML report
+// (a <testsuites> or <testsuite> root), in report order. A test reported
+// more than once (e.g. retried) is listed once.
+func ParseJUnitReport(data []byte) ([]JUnitFailure, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+	}
+
+	var failures []JUnitFailure
+	seen := make(map[string]bool)
+	var walk func(s junitSuite)
+	walk = func(s junitSuite) {
+		for _, c := range s.Cases {
+			results := append(append([]junitResult(nil), c.Failures...), c.Errors...)
+			if len(results) == 0 {
+				continue
+			}
+			f := JUnitFailure{
+				Suite:     s.Name,
+				ClassName: strings.TrimSpace(c.ClassName),
+				Name:      strings.TrimSpace(c.Name),
+				File:      strings.TrimSpace(c.File),
+				Line:      strings.TrimSpace(c.Line),
+				Message:   strings.TrimSpace(results[0].Message),
+				Type:      strings.TrimSpace(results[0].Type),
+				Output:    strings.TrimSpace(results[0].Text),
+			}
+			if f.Output == "" {
+				f.Output = strings.TrimSpace(strings.TrimSpace(c.SystemOut) + "\n" + strings.TrimSpace(c.SystemErr))
+			}
+			if key := f.Suite + "\x00" + f.ID(); !seen[key] {
+				seen[key] = true
+				failures = append(failures, f)
+			}
+		}
+		for _, child := range s.Suites {
+			walk(child)
+		}
+	}
+	walk(root)
+	return failures, nil
+}
+
+// ReadJUnitReport reads a report from a file, or downloads it when source
+// is an http(s) URL.
+func ReadJUnitReport(source string) ([]byte, error) {
+	var r io.Reader
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: junitFetchTimeout}
+		resp, err := client.Get(source) //nolint:gosec // G107: URL is from user args
+		if err != nil {
+			return nil, fmt.Errorf("failed to download report: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download report: %s", resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(source) //nolint:gosec // G304: file path is from user args
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, junitMaxReportBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	if len(data) > junitMaxReportBytes {
+		return nil, fmt.Errorf("report is larger than %d MB", junitMaxReportBytes>>20)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, errors.New("report is empty")
+	}
+	return data, nil
+}
+
+// JUnitTasks turns failures into tasks: one per failing test, or a single
+// task for all of them when combined is set. source names the report in the
+// task descriptions.
+func JUnitTasks(failures []JUnitFailure, source string, combined bool) []SplitTask {
+	if len(failures) == 0 {
+		return nil
+	}
+	if combined {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Fix the %d failing tests from the CI report %s.\n", len(failures), source)
+		for i, f := range failures {
+			fmt.Fprintf(&sb, "\n## %d. `%s`\n\n", i+1, f.ID())
+			writeJUnitFailure(&sb, f, junitCombinedOutputBytes)
+		}
+		sb.WriteString("\nFailures may share a cause: find it before fixing tests one by one. Fix the test or the code under test, and run the tests to confirm they pass.\n")
+		return []SplitTask{{Name: "fix-ci-tests", Content: sb.String()}}
+	}
+
+	tasks := make([]SplitTask, 0, len(failures))
+	for _, f := range failures {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Fix the failing test `%s` from the CI report %s.\n\n", f.ID(), source)
+		writeJUnitFailure(&sb, f, junitMaxOutputBytes)
+		sb.WriteString("\nFind the cause (the test or the code under test), fix it, and run the test to confirm it passes.\n")
+		tasks = append(tasks, SplitTask{Name: "fix-" + f.Name, Content: sb.String()})
+	}
+	return tasks
+}
+
+// writeJUnitFailure writes where a test is and how it failed, with its
+// output cut to maxOutput bytes.
+func writeJUnitFailure(sb *strings.Builder, f JUnitFailure, maxOutput int) {
+	if f.Suite != "" {
+		fmt.Fprintf(sb, "- Suite: %s\n", f.Suite)
+	}
+	if f.File != "" {
+		file := f.File
+		if f.Line != "" {
+			file += ":" + f.Line
+		}
+		fmt.Fprintf(sb, "- File: %s\n", file)
+	}
+	if f.Message != "" {
+		msg := f.Message
+		if f.Type != "" {
+			msg += " (" + f.Type + ")"
+		}
+		fmt.Fprintf(sb, "- Failure: %s\n", msg)
+	}
+	if f.Output == "" {
+		return
+	}
+
+	output := f.Output
+	if len(output) > maxOutput {
+		output = strings.ToValidUTF8(output[:maxOutput], "") + "\n... (truncated)"
+	}
+	// Use a fence longer than any backtick run in the output
+	fence := "```"
+	for strings.Contains(output, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(sb, "\n%s\n%s\n%s\n", fence, output, fence)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="ci">
+  <testsuite name="github.com/acme/app/db" tests="3" failures="1">
+    <testcase classname="github.com/acme/app/db" name="TestConnect" time="0.01"></testcase>
+    <testcase classname="github.com/acme/app/db" name="TestTimeout" file="db/conn_test.go" line="42">
+      <failure message="Failed" type="">conn_test.go:48: got 0, want 30s</failure>
+    </testcase>
+    <testcase classname="github.com/acme/app/db" name="TestSkipped"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="api">
+    <testsuite name="api.handlers">
+      <testcase classname="api.handlers" name="test_login">
+        <error message="KeyError: 'user'" type="KeyError"></error>
+        <system-err>Traceback (most recent call last):
+KeyError: 'user'</system-err>
+      </testcase>
+      <testcase classname="api.handlers" name="test_login">
+        <error message="KeyError: 'user'" type="KeyError"></error>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func TestParseJUnitReport(t *testing.T) {
+	failures, err := ParseJUnitReport([]byte(testJUnitReport))
+	if err != nil {
+		t.Fatalf("ParseJUnitReport() error = %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("ParseJUnitReport() returned %d failures, want 2 (retried test listed once): %+v", len(failures), failures)
+	}
+
+	f := failures[0]
+	if f.ID() != "github.com/acme/app/db.TestTimeout" || f.File != "db/conn_test.go" || f.Line != "42" || f.Output != "conn_test.go:48: got 0, want 30s" {
+		t.Errorf("failures[0] = %+v", f)
+	}
+	f = failures[1]
+	if f.ID() != "api.handlers.test_login" || f.Suite != "api.handlers" || f.Type != "KeyError" || !strings.Contains(f.Output, "Traceback") {
+		t.Errorf("failures[1] = %+v, want the error with its system-err output", f)
+	}
+
+	// A single <testsuite> root
+	single := `<testsuite name="s"><testcase name="TestA"><failure>boom</failure></testcase></testsuite>`
+	if failures, err := ParseJUnitReport([]byte(single)); err != nil || len(failures) != 1 || failures[0].ID() != "TestA" {
+		t.Errorf("ParseJUnitReport(testsuite root) = %+v, %v", failures, err)
+	}
+
+	if _, err := ParseJUnitReport([]byte("not xml")); err == nil {
+		t.Error("ParseJUnitReport(not xml) error = nil, want error")
+	}
+}
+
+func TestJUnitTasks(t *testing.T) {
+	failures, err := ParseJUnitReport([]byte(testJUnitReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := JUnitTasks(failures, "report.xml", false)
+	if len(tasks) != 2 {
+		t.Fatalf("JUnitTasks() returned %d tasks, want 2", len(tasks))
+	}
+	if tasks[0].Name != "fix-TestTimeout" {
+		t.Errorf("tasks[0].Name = %q, want fix-TestTimeout", tasks[0].Name)
+	}
+	for _, want := range []string{"`github.com/acme/app/db.TestTimeout`", "report.xml", "- File: db/conn_test.go:42", "- Failure: Failed", "```\nconn_test.go:48: got 0, want 30s\n```"} {
+		if !strings.Contains(tasks[0].Content, want) {
+			t.Errorf("tasks[0].Content missing %q:\n%s", want, tasks[0].Content)
+		}
+	}
+
+	combined := JUnitTasks(failures, "report.xml", true)
+	if len(combined) != 1 || combined[0].Name != "fix-ci-tests" {
+		t.Fatalf("JUnitTasks(combined) = %+v, want one fix-ci-tests task", combined)
+	}
+	for _, want := range []string{"Fix the 2 failing tests", "## 1. `github.com/acme/app/db.TestTimeout`", "## 2. `api.handlers.test_login`", "- Failure: KeyError: 'user' (KeyError)"} {
+		if !strings.Contains(combined[0].Content, want) {
+			t.Errorf("combined task missing %q:\n%s", want, combined[0].Content)
+		}
+	}
+
+	if tasks := JUnitTasks(nil, "report.xml", true); tasks != nil {
+		t.Errorf("JUnitTasks(nil) = %+v, want nil", tasks)
+	}
+}
+
+func TestJUnitTasks_TruncatesOutput(t *testing.T) {
+	failures := []JUnitFailure{{Name: "TestLong", Output: strings.Repeat("x", junitMaxOutputBytes+100) + "```"}}
+	content := JUnitTasks(failures, "r.xml", false)[0].Content
+	if !strings.Contains(content, "... (truncated)") || strings.Contains(content, strings.Repeat("x", junitMaxOutputBytes+1)) {
+		t.Error("output was not truncated")
+	}
+}
+
+func TestReadJUnitReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	if err := os.WriteFile(path, []byte(testJUnitReport), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ReadJUnitReport(path); err != nil || string(data) != testJUnitReport {
+		t.Errorf("ReadJUnitReport(file) = %d bytes, %v", len(data), err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/report.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testJUnitReport))
+	}))
+	defer server.Close()
+
+	if data, err := ReadJUnitReport(server.URL + "/report.xml"); err != nil || string(data) != testJUnitReport {
+		t.Errorf("ReadJUnitReport(url) = %d bytes, %v", len(data), err)
+	}
+	if _, err := ReadJUnitReport(server.URL + "/missing.xml"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("ReadJUnitReport(404) error = %v, want 404", err)
+	}
+	if _, err := ReadJUnitReport(filepath.Join(t.TempDir(), "missing.xml")); err == nil {
+		t.Error("ReadJUnitReport(missing file) error = nil, want error")
+	}
+}
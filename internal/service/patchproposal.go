@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// AppliedPatchesDirName is the directory in a pair task's artifacts where
+// the patches the user applied are kept, in order.
+const AppliedPatchesDirName = "patches"
+
+// PatchProposal is a patch a pair-mode agent is waiting to have applied.
+// It is stored in the task's agent directory until the user decides.
+type PatchProposal struct {
+	ID          string           `json:"id"`
+	TaskName    string           `json:"task_name"`
+	Summary     string           `json:"summary,omitempty"`
+	Patch       string           `json:"patch"`
+	Files       []string         `json:"files"`
+	Dir         string           `json:"dir"` // Directory the patch applies in
+	RequestedAt string           `json:"requested_at"`
+	Decision    ApprovalDecision `json:"decision,omitempty"`
+	Feedback    string           `json:"feedback,omitempty"` // Why the user rejected it
+}
+
+// NewPatchProposal creates a pending proposal for a patch.
+func NewPatchProposal(taskName, summary, patch, dir string) *PatchProposal {
+	now := time.Now()
+	return &PatchProposal{
+		ID:          strconv.FormatInt(now.UnixNano(), 36),
+		TaskName:    taskName,
+		Summary:     strings.TrimSpace(summary),
+		Patch:       patch,
+		Files:       PatchFiles(patch),
+		Dir:         dir,
+		RequestedAt: now.Format(time.RFC3339),
+	}
+}
+
+// PatchFiles returns the files a unified diff touches, in order. Deleted
+// files are listed by their old name.
+func PatchFiles(patch string) []string {
+	var files []string
+	seen := make(map[string]bool)
+	oldName := ""
+	for _, line := range strings.Split(patch, "\n") {
+		var name string
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			oldName = patchPath(line[4:])
+			continue
+		case strings.HasPrefix(line, "+++ "):
+			if name = patchPath(line[4:]); name == "" {
+				name = oldName
+			}
+		default:
+			continue
+		}
+		if name != "" && !seen[name] {
+			seen[name] = true
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+// patchPath strips the a/ or b/ prefix and any timestamp from a ---/+++
+// header path; /dev/null returns "".
+func patchPath(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(s, "a/") || strings.HasPrefix(s, "b/") {
+		s = s[2:]
+	}
+	return s
+}
+
+// LoadPatchProposal reads a patch proposal. Returns nil if none exists.
+func LoadPatchProposal(path string) *PatchProposal {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled agent directory
+	if err != nil {
+		return nil
+	}
+	var p PatchProposal
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil
+	}
+	return &p
+}
+
+// SavePatchProposal writes a patch proposal.
+func SavePatchProposal(path string, p *PatchProposal) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch proposal: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write patch proposal: %w", err)
+	}
+	return nil
+}
+
+// DecidePatchProposal records the user's decision for the pending proposal
+// with the given ID, with optional feedback for a rejection.
+func DecidePatchProposal(path, id string, approved bool, feedback string) error {
+	p := LoadPatchProposal(path)
+	if p == nil || p.ID != id {
+		return errors.New("patch proposal no longer exists")
+	}
+	if p.Decision != ApprovalPending {
+		return fmt.Errorf("patch proposal already %s", p.Decision)
+	}
+	p.Decision = ApprovalDenied
+	if approved {
+		p.Decision = ApprovalApproved
+	} else {
+		p.Feedback = strings.TrimSpace(feedback)
+	}
+	return SavePatchProposal(path, p)
+}
+
+// WaitForPatchDecision polls the proposal file until the proposal with the
+// given ID is decided or the timeout expires, and returns it with its
+// decision. The proposal file is removed once decided. A missing or replaced
+// proposal counts as rejected.
+func WaitForPatchDecision(path, id string, timeout, interval time.Duration) *PatchProposal {
+	deadline := time.Now().Add(timeout)
+	for {
+		p := LoadPatchProposal(path)
+		if p == nil || p.ID != id {
+			return &PatchProposal{ID: id, Decision: ApprovalDenied}
+		}
+		if p.Decision != ApprovalPending {
+			_ = os.Remove(path)
+			return p
+		}
+		if !time.Now().Before(deadline) {
+			_ = os.Remove(path)
+			p.Decision = ApprovalTimedOut
+			return p
+		}
+		time.Sleep(interval)
+	}
+}
+
+// SaveAppliedPatch keeps an applied patch in the task's artifacts as
+// patches/NNN.patch and returns its path.
+func SaveAppliedPatch(artifactsDir, patch string) (string, error) {
+	dir := filepath.Join(artifactsDir, AppliedPatchesDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return "", err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%03d.patch", len(entries)+1))
+	if err := fileutil.WriteFileAtomic(path, []byte(patch), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testPatch = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+--- /dev/null
++++ b/docs/new.md	2026-01-01 00:00:00
+@@ -0,0 +1 @@
++hello
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+`
+
+func TestPatchFiles(t *testing.T) {
+	files := PatchFiles(testPatch)
+	want := []string{"main.go", "docs/new.md", "gone.txt"}
+	if len(files) != len(want) {
+		t.Fatalf("PatchFiles() = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("PatchFiles()[%d] = %q, want %q", i, files[i], want[i])
+		}
+	}
+}
+
+func TestPatchProposalDecision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".patch.json")
+
+	p := NewPatchProposal("my-task", " Rename package ", testPatch, "/tmp")
+	if p.Summary != "Rename package" || len(p.Files) != 3 {
+		t.Errorf("NewPatchProposal() = %+v", p)
+	}
+	if err := SavePatchProposal(path, p); err != nil {
+		t.Fatalf("SavePatchProposal() error = %v", err)
+	}
+
+	if err := DecidePatchProposal(path, "other-id", true, ""); err == nil {
+		t.Error("DecidePatchProposal() with wrong ID expected error")
+	}
+	if err := DecidePatchProposal(path, p.ID, false, " Keep the old name "); err != nil {
+		t.Fatalf("DecidePatchProposal() error = %v", err)
+	}
+	if err := DecidePatchProposal(path, p.ID, true, ""); err == nil {
+		t.Error("DecidePatchProposal() twice expected error")
+	}
+
+	decided := WaitForPatchDecision(path, p.ID, time.Second, 10*time.Millisecond)
+	if decided.Decision != ApprovalDenied || decided.Feedback != "Keep the old name" {
+		t.Errorf("WaitForPatchDecision() = %q (%q), want denied with feedback", decided.Decision, decided.Feedback)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("WaitForPatchDecision() should remove the decided proposal")
+	}
+}
+
+func TestWaitForPatchDecisionTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".patch.json")
+	p := NewPatchProposal("my-task", "", testPatch, "/tmp")
+	if err := SavePatchProposal(path, p); err != nil {
+		t.Fatalf("SavePatchProposal() error = %v", err)
+	}
+
+	if got := WaitForPatchDecision(path, p.ID, 30*time.Millisecond, 10*time.Millisecond); got.Decision != ApprovalTimedOut {
+		t.Errorf("WaitForPatchDecision() = %q, want timed_out", got.Decision)
+	}
+}
+
+func TestSaveAppliedPatch(t *testing.T) {
+	dir := t.TempDir()
+	for i, want := range []string{"001.patch", "002.patch"} {
+		path, err := SaveAppliedPatch(dir, testPatch)
+		if err != nil {
+			t.Fatalf("SaveAppliedPatch() #%d error = %v", i+1, err)
+		}
+		if filepath.Base(path) != want {
+			t.Errorf("SaveAppliedPatch() #%d = %s, want %s", i+1, filepath.Base(path), want)
+		}
+	}
+}
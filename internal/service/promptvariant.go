@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// PromptVariantsFile is the ledger (in .paw) of the prompt variant each task
+// ran with and how it ended, one JSON event per line.
+const PromptVariantsFile = "prompt-variants.jsonl"
+
+// Prompt variant events.
+const (
+	VariantEventStart  = "start"
+	VariantEventFinish = "finish"
+)
+
+// VariantOutcomeCancelled is the outcome of a cancelled task; other outcomes
+// are the finish action (merge, pr, done, drop, ...).
+const VariantOutcomeCancelled = "cancelled"
+
+// PromptVariantEvent is a line of the prompt variants ledger.
+type PromptVariantEvent struct {
+	Time    time.Time `json:"time"`
+	Task    string    `json:"task"`
+	Variant string    `json:"variant"`
+	Event   string    `json:"event"`
+	Outcome string    `json:"outcome,omitempty"`
+}
+
+// VariantStats summarizes the tasks that ran with a prompt variant.
+type VariantStats struct {
+	Variant   string
+	Tasks     int             // Tasks started with the variant
+	Open      int             // Not finished yet
+	Completed int             // Finished with anything but drop or cancel
+	Dropped   int             // Dropped or cancelled
+	Durations []time.Duration // Start to finish of the completed tasks, sorted
+}
+
+// CompletionRate returns the share of finished tasks that completed, or -1
+// if none finished yet.
+func (s VariantStats) CompletionRate() float64 {
+	finished := s.Completed + s.Dropped
+	if finished == 0 {
+		return -1
+	}
+	return float64(s.Completed) / float64(finished)
+}
+
+// MeanDuration returns the mean duration of the completed tasks.
+func (s VariantStats) MeanDuration() time.Duration {
+	if len(s.Durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range s.Durations {
+		total += d
+	}
+	return total / time.Duration(len(s.Durations))
+}
+
+// MedianDuration returns the median duration of the completed tasks.
+func (s VariantStats) MedianDuration() time.Duration {
+	n := len(s.Durations)
+	switch {
+	case n == 0:
+		return 0
+	case n%2 == 1:
+		return s.Durations[n/2]
+	default:
+		return (s.Durations[n/2-1] + s.Durations[n/2]) / 2
+	}
+}
+
+// ChoosePromptVariant picks one of the variants uniformly at random.
+func ChoosePromptVariant(variants []config.PromptVariant) config.PromptVariant {
+	return variants[rand.IntN(len(variants))] //nolint:gosec // G404: assignment needs no crypto randomness
+}
+
+// LoadVariantPrompt returns the system prompt of a variant: the content of
+// its prompt file (relative to pawDir), or "" for the default prompt.
+func LoadVariantPrompt(pawDir string, v config.PromptVariant) (string, error) {
+	if v.Prompt == "" || v.Prompt == config.DefaultPromptVariant {
+		return "", nil
+	}
+	path := v.Prompt
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(pawDir, path)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from the project config
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RecordPromptVariant appends an event to the prompt variants ledger.
+func RecordPromptVariant(pawDir string, event PromptVariantEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(pawDir, PromptVariantsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: ledger is read by paw variants
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadPromptVariantEvents reads the prompt variants ledger. A missing ledger
+// has no events; malformed lines are skipped.
+func LoadPromptVariantEvents(pawDir string) ([]PromptVariantEvent, error) {
+	f, err := os.Open(filepath.Join(pawDir, PromptVariantsFile)) //nolint:gosec // G304: path is in the paw directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []PromptVariantEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event PromptVariantEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Task == "" || event.Variant == "" {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// SummarizePromptVariants groups the ledger's tasks by variant, sorted by
+// name. Each start is a run of the task, ended by the first finish after it,
+// so a reused task name counts as a new task.
+func SummarizePromptVariants(events []PromptVariantEvent) []VariantStats {
+	type run struct {
+		variant string
+		start   time.Time
+		finish  *PromptVariantEvent
+	}
+	var runs []*run
+	current := make(map[string]*run)
+	for i := range events {
+		event := events[i]
+		switch event.Event {
+		case VariantEventStart:
+			r := &run{variant: event.Variant, start: event.Time}
+			runs = append(runs, r)
+			current[event.Task] = r
+		case VariantEventFinish:
+			if r := current[event.Task]; r != nil && r.finish == nil {
+				r.finish = &event
+			}
+		}
+	}
+
+	byVariant := make(map[string]*VariantStats)
+	for _, r := range runs {
+		stats := byVariant[r.variant]
+		if stats == nil {
+			stats = &VariantStats{Variant: r.variant}
+			byVariant[r.variant] = stats
+		}
+		stats.Tasks++
+		switch {
+		case r.finish == nil:
+			stats.Open++
+		case r.finish.Outcome == constants.ActionDrop || r.finish.Outcome == VariantOutcomeCancelled:
+			stats.Dropped++
+		default:
+			stats.Completed++
+			stats.Durations = append(stats.Durations, r.finish.Time.Sub(r.start))
+		}
+	}
+
+	summary := make([]VariantStats, 0, len(byVariant))
+	for _, stats := range byVariant {
+		sort.Slice(stats.Durations, func(i, j int) bool { return stats.Durations[i] < stats.Durations[j] })
+		summary = append(summary, *stats)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Variant < summary[j].Variant })
+	return summary
+}
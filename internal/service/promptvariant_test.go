@@ -0,0 +1,75 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestPromptVariantLedger(t *testing.T) {
+	pawDir := t.TempDir()
+	if events, err := LoadPromptVariantEvents(pawDir); err != nil || events != nil {
+		t.Fatalf("LoadPromptVariantEvents() of a missing ledger = %v, %v", events, err)
+	}
+
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	for _, event := range []PromptVariantEvent{
+		{Time: start, Task: "a", Variant: "control", Event: VariantEventStart},
+		{Time: start, Task: "b", Variant: "concise", Event: VariantEventStart},
+		{Time: start.Add(time.Hour), Task: "a", Variant: "control", Event: VariantEventFinish, Outcome: constants.ActionMerge},
+		{Time: start.Add(2 * time.Hour), Task: "b", Variant: "concise", Event: VariantEventFinish, Outcome: VariantOutcomeCancelled},
+		// A reused task name is a new run
+		{Time: start.Add(3 * time.Hour), Task: "a", Variant: "control", Event: VariantEventStart},
+		{Time: start.Add(6 * time.Hour), Task: "a", Variant: "control", Event: VariantEventFinish, Outcome: constants.ActionPR},
+		{Time: start.Add(6 * time.Hour), Task: "c", Variant: "control", Event: VariantEventStart},
+	} {
+		if err := RecordPromptVariant(pawDir, event); err != nil {
+			t.Fatalf("RecordPromptVariant() error = %v", err)
+		}
+	}
+
+	events, err := LoadPromptVariantEvents(pawDir)
+	if err != nil || len(events) != 7 {
+		t.Fatalf("LoadPromptVariantEvents() = %d events, %v; want 7", len(events), err)
+	}
+	summary := SummarizePromptVariants(events)
+	if len(summary) != 2 || summary[0].Variant != "concise" || summary[1].Variant != "control" {
+		t.Fatalf("SummarizePromptVariants() = %+v", summary)
+	}
+
+	concise := summary[0]
+	if concise.Tasks != 1 || concise.Dropped != 1 || concise.CompletionRate() != 0 || concise.MeanDuration() != 0 {
+		t.Errorf("concise = %+v", concise)
+	}
+	control := summary[1]
+	if control.Tasks != 3 || control.Open != 1 || control.Completed != 2 || control.CompletionRate() != 1 {
+		t.Errorf("control = %+v", control)
+	}
+	if control.MeanDuration() != 2*time.Hour || control.MedianDuration() != 2*time.Hour {
+		t.Errorf("control durations = %v, %v; want 2h", control.MeanDuration(), control.MedianDuration())
+	}
+}
+
+func TestLoadVariantPrompt(t *testing.T) {
+	pawDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(pawDir, "variants"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pawDir, "variants", "concise.md"), []byte("be brief\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := LoadVariantPrompt(pawDir, config.PromptVariant{Name: "control", Prompt: config.DefaultPromptVariant}); err != nil || got != "" {
+		t.Errorf("LoadVariantPrompt(default) = %q, %v; want the default prompt", got, err)
+	}
+	if got, err := LoadVariantPrompt(pawDir, config.PromptVariant{Name: "concise", Prompt: "variants/concise.md"}); err != nil || got != "be brief\n" {
+		t.Errorf("LoadVariantPrompt(concise) = %q, %v", got, err)
+	}
+	if _, err := LoadVariantPrompt(pawDir, config.PromptVariant{Name: "gone", Prompt: "variants/gone.md"}); err == nil {
+		t.Error("LoadVariantPrompt() of a missing file succeeded")
+	}
+}
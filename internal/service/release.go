@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// Version bumps for NextVersion.
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
+
+// initialVersion is the first release of a project without tags.
+const initialVersion = "v0.1.0"
+
+// ReleaseItem is a task merged since the last release.
+type ReleaseItem struct {
+	Entry   ChangelogEntry // From the task's merge commit
+	Hash    string
+	Summary string // From the task's history entry, if any
+}
+
+// ReleasePlan is what a release task is asked to do.
+type ReleasePlan struct {
+	Version      string
+	LastTag      string // Empty for a first release
+	Items        []ReleaseItem
+	VersionFiles []string // release_version_files
+	Changelog    string   // The project's changelog mode
+}
+
+// InferBump returns the bump the merged tasks call for: major for breaking
+// changes, minor for features, patch otherwise.
+func InferBump(items []ReleaseItem) string {
+	bump := BumpPatch
+	for _, item := range items {
+		if item.Entry.Breaking {
+			return BumpMajor
+		}
+		if item.Entry.Type == "feat" {
+			bump = BumpMinor
+		}
+	}
+	return bump
+}
+
+// NextVersion bumps a semver tag ("v1.2.3" or "1.2.3"), keeping its "v"
+// prefix. Pre-release and build suffixes are dropped.
+func NextVersion(lastTag, bump string) (string, error) {
+	if lastTag == "" {
+		return initialVersion, nil
+	}
+	prefix := ""
+	version := lastTag
+	if strings.HasPrefix(version, "v") {
+		prefix, version = "v", version[1:]
+	}
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("last tag %q is not a semantic version; pass the version explicitly", lastTag)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("last tag %q is not a semantic version; pass the version explicitly", lastTag)
+		}
+		nums[i] = n
+	}
+
+	switch bump {
+	case BumpMajor:
+		nums = [3]int{nums[0] + 1, 0, 0}
+	case BumpMinor:
+		nums = [3]int{nums[0], nums[1] + 1, 0}
+	case BumpPatch:
+		nums[2]++
+	default:
+		return "", fmt.Errorf("invalid bump %q (use %s, %s, or %s)", bump, BumpMajor, BumpMinor, BumpPatch)
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, nums[0], nums[1], nums[2]), nil
+}
+
+// Prompt returns the release task's content.
+func (p ReleasePlan) Prompt() string {
+	since := "the start of the project"
+	if p.LastTag != "" {
+		since = p.LastTag
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Prepare release %s and open a release PR.\n\n", p.Version)
+	fmt.Fprintf(&sb, "Tasks merged since %s:\n", since)
+	for _, item := range p.Items {
+		hash := item.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		fmt.Fprintf(&sb, "- [%s] %s (task %s, %s)\n", item.Entry.Section(), item.Entry.Subject, item.Entry.Task, hash)
+		if summary := strings.TrimSpace(item.Summary); summary != "" {
+			fmt.Fprintf(&sb, "  Summary: %s\n", strings.ReplaceAll(summary, "\n", "\n  "))
+		}
+	}
+
+	sb.WriteString("\nSteps:\n")
+	sb.WriteString("- Draft user-facing release notes from the tasks above, grouped by section; leave out internal-only changes. Check the commits when a subject is unclear.\n")
+	switch p.Changelog {
+	case constants.ChangelogFragment:
+		fmt.Fprintf(&sb, "- Collect the fragments in %s/ into a %s section of %s and delete the collected fragments.\n", constants.ChangelogFragmentDir, p.Version, constants.ChangelogFileName)
+	case constants.ChangelogAppend:
+		fmt.Fprintf(&sb, "- Turn the Unreleased section of %s into a %s section with the release notes, and start a new empty Unreleased section.\n", constants.ChangelogFileName, p.Version)
+	default:
+		fmt.Fprintf(&sb, "- Add the release notes to the project's changelog as a %s section (create %s if there is none).\n", p.Version, constants.ChangelogFileName)
+	}
+	if len(p.VersionFiles) > 0 {
+		fmt.Fprintf(&sb, "- Bump the version to %s in: %s.\n", strings.TrimPrefix(p.Version, "v"), strings.Join(p.VersionFiles, ", "))
+	} else {
+		fmt.Fprintf(&sb, "- Bump the version to %s wherever the project declares it (package manifests, version constants).\n", strings.TrimPrefix(p.Version, "v"))
+	}
+	fmt.Fprintf(&sb, "- Don't create the %s tag; it is tagged after the release PR is merged.\n", p.Version)
+	return sb.String()
+}
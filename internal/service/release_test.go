@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestInferBump(t *testing.T) {
+	fix := ReleaseItem{Entry: ChangelogEntry{Type: "fix"}}
+	feat := ReleaseItem{Entry: ChangelogEntry{Type: "feat"}}
+	breaking := ReleaseItem{Entry: ChangelogEntry{Type: "fix", Breaking: true}}
+
+	if got := InferBump([]ReleaseItem{fix}); got != BumpPatch {
+		t.Errorf("InferBump(fix) = %q, want patch", got)
+	}
+	if got := InferBump([]ReleaseItem{fix, feat}); got != BumpMinor {
+		t.Errorf("InferBump(fix, feat) = %q, want minor", got)
+	}
+	if got := InferBump([]ReleaseItem{feat, breaking}); got != BumpMajor {
+		t.Errorf("InferBump(feat, breaking) = %q, want major", got)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		last, bump, want string
+		wantErr          bool
+	}{
+		{"", BumpMinor, "v0.1.0", false},
+		{"v1.2.3", BumpPatch, "v1.2.4", false},
+		{"v1.2.3", BumpMinor, "v1.3.0", false},
+		{"1.2.3-rc.1", BumpMajor, "2.0.0", false},
+		{"release-5", BumpPatch, "", true},
+		{"v1.2.3", "huge", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NextVersion(tt.last, tt.bump)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NextVersion(%q, %q) = %q, %v; want %q (error %v)", tt.last, tt.bump, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestReleasePlanPrompt(t *testing.T) {
+	plan := ReleasePlan{
+		Version: "v1.3.0",
+		LastTag: "v1.2.3",
+		Items: []ReleaseItem{
+			{Entry: ChangelogEntry{Type: "feat", Subject: "Add export", Task: "add-export"}, Hash: "0123456789abcdef", Summary: "Adds CSV export."},
+		},
+		VersionFiles: []string{"package.json", "VERSION"},
+		Changelog:    constants.ChangelogFragment,
+	}
+	prompt := plan.Prompt()
+	for _, want := range []string{
+		"Prepare release v1.3.0",
+		"since v1.2.3",
+		"- [Features] Add export (task add-export, 0123456)",
+		"Summary: Adds CSV export.",
+		"changelog.d/",
+		"1.3.0 in: package.json, VERSION",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt() missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// Repo map limits keep the map small enough to inject into every task prompt.
+const (
+	repoMapMaxSubdirs   = 8  // Subdirectories listed per top-level directory
+	repoMapMaxFiles     = 12 // Top-level files listed
+	repoMapMaxPackages  = 20 // Manifest directories listed
+	repoMapMaxCommands  = 12 // Build commands listed
+	repoMapPackageDepth = 3  // Deepest directory searched for manifests
+)
+
+// repoMapFingerprintPrefix starts the first line of the cached map.
+const repoMapFingerprintPrefix = "<!-- paw repo-map "
+
+// repoMapManifests are files that mark a package root.
+var repoMapManifests = []string{"go.mod", "package.json", "Cargo.toml", "pyproject.toml", "setup.py", "pom.xml", "build.gradle", "build.gradle.kts"}
+
+// repoMapSkipDirs are dependency and build output directories left out of the map.
+var repoMapSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"__pycache__":  true,
+	"venv":         true,
+}
+
+var makeTargetRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*):([^=]|$)`)
+
+// EnsureRepoMap returns the repository map for projectDir, regenerating the
+// cached copy in pawDir when the project's layout or root manifests changed.
+func EnsureRepoMap(pawDir, projectDir string) (string, error) {
+	fingerprint := repoMapFingerprint(projectDir)
+	mapPath := filepath.Join(pawDir, constants.RepoMapFileName)
+
+	if data, err := os.ReadFile(mapPath); err == nil { //nolint:gosec // G304: mapPath is constructed from pawDir
+		header, body, _ := strings.Cut(string(data), "\n")
+		if header == repoMapFingerprintPrefix+fingerprint+" -->" {
+			return body, nil
+		}
+	}
+
+	body := GenerateRepoMap(projectDir)
+	content := repoMapFingerprintPrefix + fingerprint + " -->\n" + body
+	if err := fileutil.WriteFileAtomic(mapPath, []byte(content), 0644); err != nil {
+		return body, fmt.Errorf("failed to write repo map: %w", err)
+	}
+	logging.Debug("Repo map regenerated: %s", mapPath)
+	return body, nil
+}
+
+// GenerateRepoMap builds a compact Markdown overview of a project: top-level
+// layout, package roots, and the build/test commands its manifests define.
+func GenerateRepoMap(projectDir string) string {
+	var sb strings.Builder
+	sb.WriteString("## Layout\n\n")
+	dirs, files := readRepoDir(projectDir)
+	for _, dir := range dirs {
+		subdirs, _ := readRepoDir(filepath.Join(projectDir, dir))
+		line := fmt.Sprintf("- %s/", dir)
+		if len(subdirs) > 0 {
+			line += " — " + joinLimited(subdirs, repoMapMaxSubdirs)
+		}
+		sb.WriteString(line + "\n")
+	}
+	if len(files) > 0 {
+		sb.WriteString("- " + joinLimited(files, repoMapMaxFiles) + "\n")
+	}
+
+	if packages := findRepoPackages(projectDir); len(packages) > 0 {
+		sb.WriteString("\n## Packages\n\n")
+		for i, pkg := range packages {
+			if i == repoMapMaxPackages {
+				sb.WriteString(fmt.Sprintf("- (+%d more)\n", len(packages)-i))
+				break
+			}
+			sb.WriteString("- " + pkg + "\n")
+		}
+	}
+
+	if commands := detectBuildCommands(projectDir); len(commands) > 0 {
+		sb.WriteString("\n## Build commands\n\n")
+		for i, cmd := range commands {
+			if i == repoMapMaxCommands {
+				break
+			}
+			sb.WriteString("- `" + cmd + "`\n")
+		}
+	}
+	return sb.String()
+}
+
+// repoMapFingerprint hashes the directory structure (two levels deep) and the
+// root manifests. Edits inside files don't change it; adding or removing
+// directories, or changing build definitions, does.
+func repoMapFingerprint(projectDir string) string {
+	h := sha256.New()
+	dirs, files := readRepoDir(projectDir)
+	for _, dir := range dirs {
+		subdirs, _ := readRepoDir(filepath.Join(projectDir, dir))
+		fmt.Fprintf(h, "d %s %s\n", dir, strings.Join(subdirs, ","))
+	}
+	for _, file := range files {
+		fmt.Fprintf(h, "f %s\n", file)
+	}
+	for _, name := range append(append([]string(nil), repoMapManifests...), "Makefile") {
+		if data, err := os.ReadFile(filepath.Join(projectDir, name)); err == nil { //nolint:gosec // G304: manifest path is constructed from projectDir
+			fmt.Fprintf(h, "m %s %d\n", name, len(data))
+			h.Write(data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// readRepoDir returns the sorted visible directories and files in dir,
+// skipping hidden entries and dependency directories.
+func readRepoDir(dir string) (dirs, files []string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			if !repoMapSkipDirs[name] {
+				dirs = append(dirs, name)
+			}
+			continue
+		}
+		files = append(files, name)
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+	return dirs, files
+}
+
+// findRepoPackages returns the directories (up to repoMapPackageDepth deep)
+// containing a manifest, described as "path (manifest: name)".
+func findRepoPackages(projectDir string) []string {
+	var packages []string
+	var walk func(rel string, depth int)
+	walk = func(rel string, depth int) {
+		dir := filepath.Join(projectDir, rel)
+		for _, manifest := range repoMapManifests {
+			if _, err := os.Stat(filepath.Join(dir, manifest)); err != nil {
+				continue
+			}
+			desc := manifest
+			if name := manifestName(filepath.Join(dir, manifest)); name != "" {
+				desc += ": " + name
+			}
+			packages = append(packages, fmt.Sprintf("%s (%s)", rel, desc))
+			break
+		}
+		if depth == repoMapPackageDepth {
+			return
+		}
+		subdirs, _ := readRepoDir(dir)
+		for _, sub := range subdirs {
+			walk(filepath.Join(rel, sub), depth+1)
+		}
+	}
+	walk(".", 0)
+	return packages
+}
+
+// manifestName extracts the module or package name from a manifest, if any.
+func manifestName(path string) string {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: manifest path is constructed from projectDir
+	if err != nil {
+		return ""
+	}
+	switch filepath.Base(path) {
+	case "go.mod":
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+				return strings.TrimSpace(module)
+			}
+		}
+	case "package.json":
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			return pkg.Name
+		}
+	}
+	return ""
+}
+
+// detectBuildCommands lists the build and test commands defined by the root
+// manifests. Toolchain commands come first so Makefile targets can't crowd them out.
+func detectBuildCommands(projectDir string) []string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(projectDir, name))
+		return err == nil
+	}
+
+	var commands []string
+	if exists("go.mod") {
+		commands = append(commands, "go build ./...", "go test ./...")
+	}
+	if exists("package.json") {
+		runner := "npm run"
+		switch {
+		case exists("pnpm-lock.yaml"):
+			runner = "pnpm"
+		case exists("yarn.lock"):
+			runner = "yarn"
+		case exists("bun.lockb"), exists("bun.lock"):
+			runner = "bun run"
+		}
+		for _, script := range packageScripts(filepath.Join(projectDir, "package.json")) {
+			commands = append(commands, runner+" "+script)
+		}
+	}
+	if exists("Cargo.toml") {
+		commands = append(commands, "cargo build", "cargo test")
+	}
+	if exists("pyproject.toml") || exists("setup.py") {
+		commands = append(commands, "python -m pytest")
+	}
+	if exists("Makefile") {
+		for _, target := range makeTargets(filepath.Join(projectDir, "Makefile")) {
+			commands = append(commands, "make "+target)
+		}
+	}
+	for _, name := range []string{"justfile", "Justfile", ".justfile"} {
+		for _, recipe := range justRecipes(filepath.Join(projectDir, name)) {
+			commands = append(commands, "just "+recipe)
+		}
+	}
+	return commands
+}
+
+// makeTargets returns the explicit targets of a Makefile in definition order.
+func makeTargets(path string) []string {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: Makefile path is constructed from projectDir
+	if err != nil {
+		return nil
+	}
+	var targets []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if m := makeTargetRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			targets = append(targets, m[1])
+		}
+	}
+	return targets
+}
+
+// packageScripts returns the script names of a package.json, sorted.
+func packageScripts(path string) []string {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: package.json path is constructed from projectDir
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return nil
+	}
+	scripts := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts
+}
+
+// joinLimited joins names with ", ", summarizing anything past limit as "+N more".
+func joinLimited(names []string, limit int) string {
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(names[:limit], ", "), len(names)-limit)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func writeRepoFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+}
+
+func TestGenerateRepoMap(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root, map[string]string{
+		"go.mod":                    "module example.com/app\n\ngo 1.22\n",
+		"Makefile":                  ".PHONY: build\nbuild:\n\tgo build ./...\nVERSION := 1\n%.o: %.c\n",
+		"README.md":                 "# app\n",
+		"cmd/app/main.go":           "package main\n",
+		"internal/store/store.go":   "package store\n",
+		"web/package.json":          `{"name": "web-ui", "scripts": {"build": "vite build"}}`,
+		"node_modules/x/index.js":   "",
+		".git/HEAD":                 "ref: refs/heads/main\n",
+		"internal/.cache/ignore.go": "",
+	})
+
+	got := GenerateRepoMap(root)
+	for _, want := range []string{
+		"- cmd/ — app\n",
+		"- internal/ — store\n",
+		"- web/\n",
+		"- Makefile, README.md, go.mod\n",
+		"- . (go.mod: example.com/app)\n",
+		"- web (package.json: web-ui)\n",
+		"- `go test ./...`\n",
+		"- `make build`\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("repo map missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"node_modules", ".git", ".cache", "make VERSION", "make %"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("repo map contains %q:\n%s", unwanted, got)
+		}
+	}
+	if strings.Index(got, "go build") > strings.Index(got, "make build") {
+		t.Errorf("toolchain commands should precede Makefile targets:\n%s", got)
+	}
+}
+
+func TestDetectBuildCommandsPackageRunner(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root, map[string]string{
+		"package.json":   `{"scripts": {"test": "vitest", "lint": "eslint ."}}`,
+		"pnpm-lock.yaml": "",
+	})
+
+	got := strings.Join(detectBuildCommands(root), ",")
+	if got != "pnpm lint,pnpm test" {
+		t.Errorf("detectBuildCommands() = %q, want %q", got, "pnpm lint,pnpm test")
+	}
+}
+
+func TestEnsureRepoMapRefreshesOnLayoutChange(t *testing.T) {
+	root := t.TempDir()
+	pawDir := t.TempDir()
+	writeRepoFiles(t, root, map[string]string{"src/main.go": "package main\n"})
+
+	first, err := EnsureRepoMap(pawDir, root)
+	if err != nil {
+		t.Fatalf("EnsureRepoMap() error = %v", err)
+	}
+	if !strings.Contains(first, "- src/\n") {
+		t.Fatalf("repo map missing src/:\n%s", first)
+	}
+
+	// Content edits keep the cached map
+	mapPath := filepath.Join(pawDir, constants.RepoMapFileName)
+	cached, _ := os.ReadFile(mapPath)
+	if err := os.WriteFile(mapPath, []byte(strings.Replace(string(cached), "- src/", "- cached/", 1)), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	writeRepoFiles(t, root, map[string]string{"src/main.go": "package main\n\nfunc main() {}\n"})
+	if got, _ := EnsureRepoMap(pawDir, root); !strings.Contains(got, "- cached/") {
+		t.Errorf("repo map regenerated after a content-only edit:\n%s", got)
+	}
+
+	// New directories regenerate it
+	writeRepoFiles(t, root, map[string]string{"docs/guide.md": "# guide\n"})
+	got, err := EnsureRepoMap(pawDir, root)
+	if err != nil {
+		t.Fatalf("EnsureRepoMap() error = %v", err)
+	}
+	if !strings.Contains(got, "- docs/\n") || strings.Contains(got, "- cached/") {
+		t.Errorf("repo map not regenerated after adding docs/:\n%s", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"time"
 
@@ -120,3 +121,16 @@ func RunHook(name, command, workDir string, env []string, outputPath, metaPath s
 
 	return meta, err
 }
+
+// LoadHookMetadata reads the metadata RunHook wrote for a hook run.
+func LoadHookMetadata(metaPath string) (*HookMetadata, error) {
+	data, err := os.ReadFile(metaPath) //nolint:gosec // G304: metaPath is a task hook path
+	if err != nil {
+		return nil, err
+	}
+	var meta HookMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse hook metadata: %w", err)
+	}
+	return &meta, nil
+}
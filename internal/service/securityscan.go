@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// ScanFinding is a single security scanner result.
+type ScanFinding struct {
+	Scanner  string
+	Rule     string
+	File     string
+	Line     int
+	Severity string
+	Message  string
+	Critical bool // Blocks the merge
+}
+
+func (f ScanFinding) String() string {
+	return fmt.Sprintf("[%s %s] %s:%d: %s (%s)", f.Scanner, strings.ToLower(f.Severity), f.File, f.Line, f.Message, f.Rule)
+}
+
+// securityScanner runs one scanner on the commits since base and parses its
+// JSON report.
+type securityScanner struct {
+	args  func(base, reportPath string) []string
+	parse func(data []byte) ([]ScanFinding, error)
+}
+
+var securityScanners = map[string]securityScanner{
+	// Every leaked secret is critical; --redact keeps secrets out of the report
+	constants.SecurityScannerGitleaks: {
+		args: func(base, reportPath string) []string {
+			return []string{"detect", "--source", ".", "--log-opts", base + "..HEAD", "--no-banner", "--redact",
+				"--report-format", "json", "--report-path", reportPath, "--exit-code", "0"}
+		},
+		parse: parseGitleaksReport,
+	},
+	// ERROR findings are critical; WARNING and INFO are reported only
+	constants.SecurityScannerSemgrep: {
+		args: func(base, reportPath string) []string {
+			return []string{"scan", "--config", "auto", "--baseline-commit", base, "--json", "--output", reportPath, "--quiet"}
+		},
+		parse: parseSemgrepReport,
+	},
+}
+
+// ErrScannerNotInstalled is returned when a scanner's binary is not in PATH.
+var ErrScannerNotInstalled = errors.New("not installed")
+
+// RunSecurityScan runs a scanner in dir on the commits since base, writing
+// its JSON report to reportDir/<scanner>.json. Returns the findings and the
+// report path.
+func RunSecurityScan(name, dir, base, reportDir string, timeout time.Duration) ([]ScanFinding, string, error) {
+	scanner, ok := securityScanners[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown security scanner %q", name)
+	}
+	bin, err := exec.LookPath(name)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", name, ErrScannerNotInstalled)
+	}
+	if err := os.MkdirAll(reportDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return nil, "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+	reportPath := filepath.Join(reportDir, name+".json")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, scanner.args(base, reportPath)...) //nolint:gosec // G204: fixed scanner arguments
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, "", fmt.Errorf("%s timed out after %s", name, timeout)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+
+	data, err := os.ReadFile(reportPath) //nolint:gosec // G304: reportPath is in the task's artifacts
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s report: %w", name, err)
+	}
+	findings, err := scanner.parse(data)
+	if err != nil {
+		return nil, reportPath, fmt.Errorf("failed to parse %s report: %w", name, err)
+	}
+	return findings, reportPath, nil
+}
+
+func parseGitleaksReport(data []byte) ([]ScanFinding, error) {
+	var report []struct {
+		RuleID      string `json:"RuleID"`
+		Description string `json:"Description"`
+		File        string `json:"File"`
+		StartLine   int    `json:"StartLine"`
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	findings := make([]ScanFinding, 0, len(report))
+	for _, r := range report {
+		findings = append(findings, ScanFinding{
+			Scanner:  constants.SecurityScannerGitleaks,
+			Rule:     r.RuleID,
+			File:     r.File,
+			Line:     r.StartLine,
+			Severity: "secret",
+			Message:  r.Description,
+			Critical: true,
+		})
+	}
+	return findings, nil
+}
+
+func parseSemgrepReport(data []byte) ([]ScanFinding, error) {
+	var report struct {
+		Results []struct {
+			CheckID string `json:"check_id"`
+			Path    string `json:"path"`
+			Start   struct {
+				Line int `json:"line"`
+			} `json:"start"`
+			Extra struct {
+				Message  string `json:"message"`
+				Severity string `json:"severity"`
+			} `json:"extra"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	findings := make([]ScanFinding, 0, len(report.Results))
+	for _, r := range report.Results {
+		findings = append(findings, ScanFinding{
+			Scanner:  constants.SecurityScannerSemgrep,
+			Rule:     r.CheckID,
+			File:     r.Path,
+			Line:     r.Start.Line,
+			Severity: r.Extra.Severity,
+			Message:  firstLine(r.Extra.Message),
+			Critical: strings.EqualFold(r.Extra.Severity, "ERROR"),
+		})
+	}
+	return findings, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseGitleaksReport(t *testing.T) {
+	data := []byte(`[{"RuleID":"aws-access-token","Description":"AWS Access Token","File":"config/aws.go","StartLine":12,"Secret":"REDACTED"}]`)
+
+	findings, err := parseGitleaksReport(data)
+	if err != nil {
+		t.Fatalf("parseGitleaksReport() error = %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("findings = %v, want 1", findings)
+	}
+	f := findings[0]
+	if f.Rule != "aws-access-token" || f.File != "config/aws.go" || f.Line != 12 || !f.Critical {
+		t.Errorf("finding = %+v", f)
+	}
+
+	if findings, err := parseGitleaksReport(nil); err != nil || len(findings) != 0 {
+		t.Errorf("empty report = %v, %v; want no findings", findings, err)
+	}
+}
+
+func TestParseSemgrepReport(t *testing.T) {
+	data := []byte(`{"results":[
+		{"check_id":"go.lang.security.sqli","path":"db.go","start":{"line":40},"extra":{"message":"SQL injection\nmore detail","severity":"ERROR"}},
+		{"check_id":"go.lang.style","path":"main.go","start":{"line":3},"extra":{"message":"Style","severity":"WARNING"}}
+	],"errors":[]}`)
+
+	findings, err := parseSemgrepReport(data)
+	if err != nil {
+		t.Fatalf("parseSemgrepReport() error = %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("findings = %v, want 2", findings)
+	}
+	if !findings[0].Critical || findings[0].Message != "SQL injection" || findings[0].Line != 40 {
+		t.Errorf("findings[0] = %+v", findings[0])
+	}
+	if findings[1].Critical {
+		t.Errorf("findings[1] = %+v, want non-critical", findings[1])
+	}
+}
+
+func TestRunSecurityScan_Errors(t *testing.T) {
+	if _, _, err := RunSecurityScan("trivy", t.TempDir(), "HEAD", t.TempDir(), time.Second); err == nil {
+		t.Error("RunSecurityScan(unknown) error = nil")
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	_, _, err := RunSecurityScan("gitleaks", t.TempDir(), "HEAD", t.TempDir(), time.Second)
+	if !errors.Is(err, ErrScannerNotInstalled) {
+		t.Errorf("RunSecurityScan(missing) error = %v, want ErrScannerNotInstalled", err)
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// Self-evaluation files written to a task's artifacts directory, so they are
+// saved to history with the other artifacts when the task finishes.
+const (
+	SelfEvalFile       = "self-eval.json"
+	SelfEvalReportFile = "self-eval.md"
+)
+
+// Range of self-evaluation scores.
+const (
+	selfEvalMinScore = 1
+	selfEvalMaxScore = 5
+)
+
+// SelfEval is the agent's own assessment of a finished task.
+type SelfEval struct {
+	Score        int       `json:"score"`        // 1 (not done) to 5 (done, verified, low risk)
+	Requirements string    `json:"requirements"` // met, partial, or unmet
+	Summary      string    `json:"summary"`
+	Risks        []string  `json:"risks,omitempty"`
+	Untested     []string  `json:"untested,omitempty"`
+	Head         string    `json:"head,omitempty"` // Commit the evaluation was made at
+	Time         time.Time `json:"time"`
+}
+
+// ParseSelfEval extracts the rubric from Claude's response.
+func ParseSelfEval(raw string) (*SelfEval, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON object in self-evaluation response")
+	}
+
+	var eval SelfEval
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &eval); err != nil {
+		return nil, fmt.Errorf("failed to parse self-evaluation: %w", err)
+	}
+	if eval.Score < selfEvalMinScore || eval.Score > selfEvalMaxScore {
+		return nil, fmt.Errorf("self-evaluation score %d is not between %d and %d", eval.Score, selfEvalMinScore, selfEvalMaxScore)
+	}
+	switch eval.Requirements = strings.ToLower(strings.TrimSpace(eval.Requirements)); eval.Requirements {
+	case "met", "partial", "unmet":
+	default:
+		eval.Requirements = "unknown"
+	}
+	eval.Summary = strings.TrimSpace(eval.Summary)
+	eval.Risks = trimItems(eval.Risks)
+	eval.Untested = trimItems(eval.Untested)
+	return &eval, nil
+}
+
+func trimItems(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// LowConfidence reports whether the score is below minScore.
+func (e *SelfEval) LowConfidence(minScore int) bool {
+	return e.Score < minScore
+}
+
+// ReviewReason describes a low-confidence evaluation for the task's review
+// flag (shown on the Kanban card).
+func (e *SelfEval) ReviewReason() string {
+	reason := fmt.Sprintf("self-eval %d/%d", e.Score, selfEvalMaxScore)
+	if e.Summary != "" {
+		reason += ": " + e.Summary
+	}
+	return reason
+}
+
+// Render formats the rubric as Markdown.
+func (e *SelfEval) Render(taskName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Self-evaluation: %s\n\n", taskName)
+	fmt.Fprintf(&b, "- Score: %d/%d\n", e.Score, selfEvalMaxScore)
+	fmt.Fprintf(&b, "- Requirements: %s\n", e.Requirements)
+	if e.Head != "" {
+		fmt.Fprintf(&b, "- Commit: %s\n", e.Head)
+	}
+	if e.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", e.Summary)
+	}
+	for _, section := range []struct {
+		title string
+		items []string
+	}{{"Risks", e.Risks}, {"Untested", e.Untested}} {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		for _, item := range section.items {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+	return b.String()
+}
+
+// SaveSelfEval writes the rubric (JSON and Markdown) to artifactsDir.
+func SaveSelfEval(artifactsDir, taskName string, eval *SelfEval) error {
+	if err := os.MkdirAll(artifactsDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(eval, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(artifactsDir, SelfEvalFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(filepath.Join(artifactsDir, SelfEvalReportFile), []byte(eval.Render(taskName)), 0644)
+}
+
+// LoadSelfEval reads the rubric saved in artifactsDir. A missing rubric
+// returns nil without an error.
+func LoadSelfEval(artifactsDir string) (*SelfEval, error) {
+	data, err := os.ReadFile(filepath.Join(artifactsDir, SelfEvalFile)) //nolint:gosec // G304: path is in the task's artifacts directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var eval SelfEval
+	if err := json.Unmarshal(data, &eval); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", SelfEvalFile, err)
+	}
+	return &eval, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSelfEval(t *testing.T) {
+	raw := "Here is my evaluation:\n```json\n" + `{"score": 2, "requirements": " Partial ", "summary": " Parser done, CLI flag missing ",
+"risks": ["Changes the config format", " "], "untested": ["--strict flag"]}` + "\n```"
+	eval, err := ParseSelfEval(raw)
+	if err != nil {
+		t.Fatalf("ParseSelfEval() error = %v", err)
+	}
+	if eval.Score != 2 || eval.Requirements != "partial" || eval.Summary != "Parser done, CLI flag missing" {
+		t.Errorf("ParseSelfEval() = %+v", eval)
+	}
+	if len(eval.Risks) != 1 || len(eval.Untested) != 1 {
+		t.Errorf("Risks, Untested = %q, %q; want blank items dropped", eval.Risks, eval.Untested)
+	}
+	if !eval.LowConfidence(3) || eval.LowConfidence(2) {
+		t.Errorf("LowConfidence() wrong for score %d", eval.Score)
+	}
+	if got := eval.ReviewReason(); got != "self-eval 2/5: Parser done, CLI flag missing" {
+		t.Errorf("ReviewReason() = %q", got)
+	}
+
+	if eval, err := ParseSelfEval(`{"score": 4, "requirements": "mostly"}`); err != nil || eval.Requirements != "unknown" {
+		t.Errorf("ParseSelfEval() with unknown requirements = %+v, %v", eval, err)
+	}
+	for _, bad := range []string{"no json here", `{"score": 0}`, `{"score": 9}`, `{"score": "high"}`} {
+		if _, err := ParseSelfEval(bad); err == nil {
+			t.Errorf("ParseSelfEval(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestSaveSelfEval(t *testing.T) {
+	dir := t.TempDir()
+	if eval, err := LoadSelfEval(dir); err != nil || eval != nil {
+		t.Fatalf("LoadSelfEval() of a missing rubric = %+v, %v", eval, err)
+	}
+
+	eval := &SelfEval{Score: 4, Requirements: "met", Summary: "Done", Untested: []string{"Windows paths"}, Head: "abc123"}
+	if err := SaveSelfEval(dir, "fix-login", eval); err != nil {
+		t.Fatalf("SaveSelfEval() error = %v", err)
+	}
+	loaded, err := LoadSelfEval(dir)
+	if err != nil || loaded.Score != 4 || loaded.Head != "abc123" || len(loaded.Untested) != 1 {
+		t.Errorf("LoadSelfEval() = %+v, %v", loaded, err)
+	}
+
+	report := eval.Render("fix-login")
+	for _, want := range []string{"# Self-evaluation: fix-login", "- Score: 4/5", "- Commit: abc123", "## Untested\n\n- Windows paths"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Render() missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "## Risks") {
+		t.Errorf("Render() has an empty Risks section:\n%s", report)
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// SessionLayoutPaneFormat is the list-panes format parsed by ParseSessionLayout.
+const SessionLayoutPaneFormat = "#{window_index}\t#{window_name}\t#{window_active}\t#{window_layout}\t#{pane_index}\t#{pane_active}\t#{window_zoomed_flag}\t#{pane_current_path}"
+
+// SessionLayout is the saved arrangement of a session's task windows, used to
+// restore the session after the tmux server is gone.
+type SessionLayout struct {
+	Windows    []LayoutWindow `json:"windows"`               // Task windows in window order
+	ActiveTask string         `json:"active_task,omitempty"` // Focused task ("" for the main window)
+}
+
+// LayoutWindow is a task window and the panes beside its agent pane.
+type LayoutWindow struct {
+	Task       string   `json:"task"`
+	Layout     string   `json:"layout,omitempty"`      // tmux window_layout
+	ShellDirs  []string `json:"shell_dirs"`            // Working directory of each shell pane
+	ActivePane int      `json:"active_pane,omitempty"` // Index of the focused pane
+	Zoomed     bool     `json:"zoomed,omitempty"`      // Focused pane is zoomed
+}
+
+// Task returns the saved window of a task, or nil.
+func (l *SessionLayout) Task(name string) *LayoutWindow {
+	for i := range l.Windows {
+		if l.Windows[i].Task == name {
+			return &l.Windows[i]
+		}
+	}
+	return nil
+}
+
+// ParseSessionLayout builds a layout from list-panes output in
+// SessionLayoutPaneFormat. Window tokens are resolved to task names with
+// tokenMap; non-task windows are skipped.
+func ParseSessionLayout(output string, tokenMap map[string]string) SessionLayout {
+	type window struct {
+		index int
+		LayoutWindow
+	}
+	var windows []*window
+	byIndex := map[int]*window{}
+	layout := SessionLayout{}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\t", 8)
+		if len(fields) != 8 {
+			continue
+		}
+		windowIndex, err1 := strconv.Atoi(fields[0])
+		paneIndex, err2 := strconv.Atoi(fields[4])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		token, ok := constants.ExtractTaskName(fields[1])
+		if !ok {
+			continue
+		}
+		name := resolveTaskName(token, tokenMap)
+
+		w := byIndex[windowIndex]
+		if w == nil {
+			w = &window{index: windowIndex, LayoutWindow: LayoutWindow{Task: name, Layout: fields[3], Zoomed: fields[6] == "1"}}
+			byIndex[windowIndex] = w
+			windows = append(windows, w)
+			if fields[2] == "1" {
+				layout.ActiveTask = name
+			}
+		}
+		// Pane 0 runs the agent; the rest are shells
+		if paneIndex > 0 {
+			w.ShellDirs = append(w.ShellDirs, fields[7])
+		}
+		if fields[5] == "1" {
+			w.ActivePane = paneIndex
+		}
+	}
+
+	// list-panes lists windows in index order, but don't depend on it
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].index < windows[j].index })
+	for _, w := range windows {
+		layout.Windows = append(layout.Windows, w.LayoutWindow)
+	}
+	return layout
+}
+
+// SaveSessionLayout writes the session layout to the workspace.
+func SaveSessionLayout(pawDir string, layout SessionLayout) error {
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session layout: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(pawDir, constants.SessionLayoutFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write session layout: %w", err)
+	}
+	return nil
+}
+
+// LoadSessionLayout reads the saved session layout. A missing or corrupt file
+// yields an empty layout.
+func LoadSessionLayout(pawDir string) (SessionLayout, error) {
+	layoutPath := filepath.Join(pawDir, constants.SessionLayoutFileName)
+	data, err := os.ReadFile(layoutPath) //nolint:gosec // G304: layoutPath is constructed from pawDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SessionLayout{}, nil
+		}
+		return SessionLayout{}, err
+	}
+
+	var layout SessionLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		_ = fileutil.BackupCorruptFile(layoutPath)
+		return SessionLayout{}, nil //nolint:nilerr // Intentional: return empty layout on corrupt file
+	}
+	return layout, nil
+}
+
+// BuildTokenMap maps window name tokens to full task names for the workspace.
+func BuildTokenMap(pawDir string) map[string]string {
+	return buildTokenMap(pawDir)
+}
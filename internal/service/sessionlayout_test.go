@@ -0,0 +1,63 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestParseSessionLayout(t *testing.T) {
+	rows := [][]string{
+		{"0", constants.NewWindowName, "0", "main-layout", "0", "1", "0", "/repo"},
+		{"3", constants.EmojiWaiting + "fix-log~", "1", "w3-layout", "0", "0", "1", "/wt/fix"},
+		{"3", constants.EmojiWaiting + "fix-log~", "1", "w3-layout", "1", "1", "1", "/wt/fix"},
+		{"3", constants.EmojiWaiting + "fix-log~", "1", "w3-layout", "2", "0", "1", "/wt/fix/web"},
+		{"1", constants.EmojiWorking + "add-api", "0", "w1-layout", "0", "1", "0", "/wt/api"},
+	}
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, "\t"))
+	}
+
+	layout := ParseSessionLayout(strings.Join(lines, "\n"), map[string]string{"fix-log~": "fix-login-redirect"})
+
+	want := SessionLayout{
+		Windows: []LayoutWindow{
+			{Task: "add-api", Layout: "w1-layout"},
+			{Task: "fix-login-redirect", Layout: "w3-layout", ShellDirs: []string{"/wt/fix", "/wt/fix/web"}, ActivePane: 1, Zoomed: true},
+		},
+		ActiveTask: "fix-login-redirect",
+	}
+	if !reflect.DeepEqual(layout, want) {
+		t.Errorf("ParseSessionLayout() = %+v, want %+v", layout, want)
+	}
+	if w := layout.Task("add-api"); w == nil || w.Layout != "w1-layout" {
+		t.Errorf("Task(add-api) = %+v", w)
+	}
+}
+
+func TestSessionLayoutRoundTrip(t *testing.T) {
+	pawDir := t.TempDir()
+
+	empty, err := LoadSessionLayout(pawDir)
+	if err != nil || len(empty.Windows) != 0 {
+		t.Fatalf("LoadSessionLayout() without file = %+v, %v", empty, err)
+	}
+
+	layout := SessionLayout{
+		Windows:    []LayoutWindow{{Task: "add-api", Layout: "w1-layout", ShellDirs: []string{"/wt/api"}}},
+		ActiveTask: "add-api",
+	}
+	if err := SaveSessionLayout(pawDir, layout); err != nil {
+		t.Fatalf("SaveSessionLayout() error = %v", err)
+	}
+	loaded, err := LoadSessionLayout(pawDir)
+	if err != nil {
+		t.Fatalf("LoadSessionLayout() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, layout) {
+		t.Errorf("LoadSessionLayout() = %+v, want %+v", loaded, layout)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SplitTask is a single task in a split plan.
+type SplitTask struct {
+	Name      string `json:"name"`
+	Content   string `json:"content"`
+	DependsOn string `json:"depends_on,omitempty"`
+}
+
+// SplitPlan is an ordered list of tasks produced by decomposing a large task.
+// Every dependency refers to an earlier task in the list.
+type SplitPlan struct {
+	Tasks []SplitTask
+}
+
+// ParseSplitPlan parses Claude's JSON response into a validated plan.
+// Surrounding prose and markdown code fences are ignored.
+func ParseSplitPlan(raw string) (*SplitPlan, error) {
+	start := strings.Index(raw, "[")
+	end := strings.LastIndex(raw, "]")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON array in split response")
+	}
+
+	var tasks []SplitTask
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse split plan: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("split plan has no tasks")
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		t.Name = strings.TrimSpace(t.Name)
+		t.Content = strings.TrimSpace(t.Content)
+		t.DependsOn = strings.TrimSpace(t.DependsOn)
+
+		if t.Name == "" || t.Content == "" {
+			return nil, fmt.Errorf("split task %d is missing a name or content", i+1)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("duplicate split task name: %s", t.Name)
+		}
+		if t.DependsOn != "" && !seen[t.DependsOn] {
+			return nil, fmt.Errorf("task %s depends on %q, which is not an earlier task", t.Name, t.DependsOn)
+		}
+		seen[t.Name] = true
+	}
+
+	return &SplitPlan{Tasks: tasks}, nil
+}
+
+// Dependents returns the indexes of tasks that depend on the task at index i,
+// directly or transitively.
+func (p *SplitPlan) Dependents(i int) []int {
+	names := map[string]bool{p.Tasks[i].Name: true}
+	var result []int
+	for j := i + 1; j < len(p.Tasks); j++ {
+		if names[p.Tasks[j].DependsOn] {
+			names[p.Tasks[j].Name] = true
+			result = append(result, j)
+		}
+	}
+	return result
+}
+
+// ThenDelimiter separates the steps of a chained task input. Each step
+// becomes a task that starts once the previous one succeeds.
+const ThenDelimiter = "---then---"
+
+// SplitThenSteps splits task input on lines holding only ThenDelimiter (case
+// insensitive). Empty steps are dropped; input without the delimiter is a
+// single step.
+func SplitThenSteps(content string) []string {
+	var steps []string
+	var current []string
+	flush := func() {
+		if step := strings.TrimSpace(strings.Join(current, "\n")); step != "" {
+			steps = append(steps, step)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), ThenDelimiter) {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return steps
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSplitPlan(t *testing.T) {
+	raw := "Here is the plan:\n```json\n" + `[
+  {"name": "add-user-model", "content": "Add the User model"},
+  {"name": "add-user-api", "content": "Add CRUD endpoints", "depends_on": "add-user-model"},
+  {"name": "add-user-docs", "content": "Document the API", "depends_on": "add-user-api"},
+  {"name": "fix-lint-warnings", "content": "Fix lint warnings", "depends_on": ""}
+]` + "\n```"
+
+	plan, err := ParseSplitPlan(raw)
+	if err != nil {
+		t.Fatalf("ParseSplitPlan() error = %v", err)
+	}
+	if len(plan.Tasks) != 4 {
+		t.Fatalf("expected 4 tasks, got %d", len(plan.Tasks))
+	}
+	if plan.Tasks[1].DependsOn != "add-user-model" {
+		t.Errorf("Tasks[1].DependsOn = %q", plan.Tasks[1].DependsOn)
+	}
+
+	deps := plan.Dependents(0)
+	if len(deps) != 2 || deps[0] != 1 || deps[1] != 2 {
+		t.Errorf("Dependents(0) = %v, want [1 2]", deps)
+	}
+	if deps := plan.Dependents(3); len(deps) != 0 {
+		t.Errorf("Dependents(3) = %v, want []", deps)
+	}
+}
+
+func TestParseSplitPlanInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"no json", "I cannot split this task."},
+		{"empty", "[]"},
+		{"missing content", `[{"name": "add-user-model"}]`},
+		{"duplicate", `[{"name": "a", "content": "x"}, {"name": "a", "content": "y"}]`},
+		{"forward dependency", `[{"name": "a", "content": "x", "depends_on": "b"}, {"name": "b", "content": "y"}]`},
+		{"unknown dependency", `[{"name": "a", "content": "x", "depends_on": "missing"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSplitPlan(tt.raw); err == nil {
+				t.Errorf("ParseSplitPlan(%q) expected error", tt.raw)
+			}
+		})
+	}
+}
+
+func TestSplitThenSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"single", "fix the login bug", []string{"fix the login bug"}},
+		{"chain", "add the API\n---then---\nwrite docs\n  ---THEN---  \nrelease", []string{"add the API", "write docs", "release"}},
+		{"empty steps", "---then---\nadd the API\n---then---\n\n---then---", []string{"add the API"}},
+		{"inline text kept", "explain ---then--- in the docs", []string{"explain ---then--- in the docs"}},
+	}
+	for _, tt := range tests {
+		got := SplitThenSteps(tt.content)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: SplitThenSteps() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// stateFiles are the top-level .paw files carried by a state archive.
+// Input history files also match with a per-user suffix.
+var stateFiles = []string{
+	constants.ConfigFileName,
+	constants.PromptFileName,
+	constants.ReadinessFileName,
+	constants.TemplateScheduleFile,
+	TemplateFile,
+	InputHistoryFile,
+	PromptVariantsFile,
+}
+
+// stateDirs are the .paw directories carried by a state archive.
+var stateDirs = []string{
+	constants.PromptsDirName,
+	constants.OverridesDirName,
+	constants.HistoryDirName,
+}
+
+// isStatePath reports whether a slash-separated path relative to .paw is
+// part of the exported state. Task workspaces (agents), logs, and runtime
+// files never are.
+func isStatePath(name string) bool {
+	top, _, _ := strings.Cut(name, "/")
+	for _, dir := range stateDirs {
+		if top == dir {
+			return true
+		}
+	}
+	if top != name {
+		return false
+	}
+	for _, file := range stateFiles {
+		if name == file || (file == InputHistoryFile && strings.HasPrefix(name, InputHistoryFile+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportState writes the project's PAW state (config, prompts, templates,
+// schedules, input and task history) to a .tar, .tar.gz/.tgz, or .tar.zst
+// archive. History is copied as stored, so encrypted entries stay encrypted.
+// Returns the number of files written.
+func ExportState(pawDir, archivePath string) (int, error) {
+	if _, err := archiveFormat(archivePath); err != nil {
+		return 0, err
+	}
+	out, err := os.Create(archivePath) //nolint:gosec // G304: archivePath is from the user
+	if err != nil {
+		return 0, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	w, finish, err := compressWriter(archivePath, out)
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return 0, err
+	}
+
+	tw := tar.NewWriter(w)
+	count := 0
+	walkErr := filepath.WalkDir(pawDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(pawDir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !isStatePath(name) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Symlinks point into this machine's layout
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		f, err := os.Open(p) //nolint:gosec // G304: p is under pawDir
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := io.Copy(tw, f); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+
+	err = errors.Join(walkErr, tw.Close(), finish())
+	if err != nil {
+		_ = out.Close()
+		_ = os.Remove(archivePath)
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return count, nil
+}
+
+// ImportState restores PAW state from an archive made by ExportState into
+// pawDir. Existing files are kept unless overwrite is set. Entries outside
+// the exported state are ignored. Returns the number of files imported and
+// skipped.
+func ImportState(pawDir, archivePath string, overwrite bool) (imported, skipped int, err error) {
+	in, err := os.Open(archivePath) //nolint:gosec // G304: archivePath is from the user
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	r, finish, err := decompressReader(archivePath, in)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = finish() }()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, skipped, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if !fs.ValidPath(name) || !isStatePath(name) {
+			continue
+		}
+		target := filepath.Join(pawDir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+				return imported, skipped, err
+			}
+		case tar.TypeReg:
+			if _, err := os.Lstat(target); err == nil && !overwrite {
+				skipped++
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return imported, skipped, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+				return imported, skipped, err
+			}
+			if err := fileutil.WriteFileAtomic(target, data, fs.FileMode(hdr.Mode).Perm()); err != nil { //nolint:gosec // G115: tar mode bits fit in FileMode
+				return imported, skipped, fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			imported++
+		}
+	}
+	return imported, skipped, nil
+}
+
+// archiveFormat returns the compression of an archive from its name:
+// "", "gzip", or "zstd".
+func archiveFormat(archivePath string) (string, error) {
+	name := strings.ToLower(filepath.Base(archivePath))
+	switch {
+	case strings.HasSuffix(name, ".tar"):
+		return "", nil
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "gzip", nil
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return "zstd", nil
+	}
+	return "", fmt.Errorf("unsupported archive %s (use .tar, .tar.gz, or .tar.zst)", filepath.Base(archivePath))
+}
+
+// compressWriter wraps out in the archive's compression. finish flushes the
+// compressor and must be called after writing.
+func compressWriter(archivePath string, out io.Writer) (io.Writer, func() error, error) {
+	format, err := archiveFormat(archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch format {
+	case "gzip":
+		gw := gzip.NewWriter(out)
+		return gw, gw.Close, nil
+	case "zstd":
+		cmd, err := zstdCommand("-q", "-c")
+		if err != nil {
+			return nil, nil, err
+		}
+		cmd.Stdout = out
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, fmt.Errorf("failed to start zstd: %w", err)
+		}
+		return stdin, func() error { return errors.Join(stdin.Close(), cmd.Wait()) }, nil
+	}
+	return out, func() error { return nil }, nil
+}
+
+// decompressReader wraps in in the archive's decompression.
+func decompressReader(archivePath string, in io.Reader) (io.Reader, func() error, error) {
+	format, err := archiveFormat(archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch format {
+	case "gzip":
+		gr, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		return gr, gr.Close, nil
+	case "zstd":
+		cmd, err := zstdCommand("-q", "-d", "-c")
+		if err != nil {
+			return nil, nil, err
+		}
+		cmd.Stdin = in
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, fmt.Errorf("failed to start zstd: %w", err)
+		}
+		// Drain before waiting so zstd never blocks on a full pipe
+		return stdout, func() error {
+			_, _ = io.Copy(io.Discard, stdout)
+			return cmd.Wait()
+		}, nil
+	}
+	return in, func() error { return nil }, nil
+}
+
+// zstdCommand returns a zstd command; .tar.zst archives need the zstd CLI.
+func zstdCommand(args ...string) (*exec.Cmd, error) {
+	bin, err := exec.LookPath("zstd")
+	if err != nil {
+		return nil, errors.New("zstd not found in PATH (install it, or use a .tar.gz archive)")
+	}
+	return exec.Command(bin, args...), nil //nolint:gosec // G204: fixed zstd arguments
+}
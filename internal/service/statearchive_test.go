@@ -0,0 +1,86 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsStatePath(t *testing.T) {
+	tests := map[string]bool{
+		"config":                          true,
+		"input-history":                   true,
+		"input-history-alice":             true,
+		"input-templates":                 true,
+		"history/260101_120000_x/task.md": true,
+		"prompts/system.md":               true,
+		"agents/my-task/task":             false,
+		"log":                             false,
+		"config/nested":                   false,
+		".muted":                          false,
+	}
+	for name, want := range tests {
+		if got := isStatePath(name); got != want {
+			t.Errorf("isStatePath(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestExportImportState(t *testing.T) {
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"config":                            "on_complete: confirm\n",
+		"input-templates":                   "[]\n",
+		"history/260101_120000_fix/task.md": "Fix it",
+		"prompts/system.md":                 "Be brief\n",
+		"agents/fix/task":                   "Fix it",
+		"log":                               "noise\n",
+	} {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive := filepath.Join(t.TempDir(), "state.tar.gz")
+	count, err := ExportState(src, archive)
+	if err != nil || count != 4 {
+		t.Fatalf("ExportState() = %d, %v; want 4, nil", count, err)
+	}
+
+	dst := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dst, "config"), []byte("mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	imported, skipped, err := ImportState(dst, archive, false)
+	if err != nil || imported != 3 || skipped != 1 {
+		t.Fatalf("ImportState() = %d, %d, %v; want 3, 1, nil", imported, skipped, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "config")); string(data) != "mine\n" {
+		t.Errorf("config = %q, want the existing file kept", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "history", "260101_120000_fix", "task.md")); string(data) != "Fix it" {
+		t.Errorf("history task.md = %q", data)
+	}
+	for _, name := range []string{"agents", "log"} {
+		if _, err := os.Stat(filepath.Join(dst, name)); !os.IsNotExist(err) {
+			t.Errorf("%s was imported", name)
+		}
+	}
+
+	if imported, _, err := ImportState(dst, archive, true); err != nil || imported != 4 {
+		t.Errorf("ImportState(overwrite) = %d, %v; want 4, nil", imported, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "config")); string(data) != "on_complete: confirm\n" {
+		t.Errorf("config = %q, want it overwritten", data)
+	}
+}
+
+func TestExportStateUnsupportedArchive(t *testing.T) {
+	if _, err := ExportState(t.TempDir(), filepath.Join(t.TempDir(), "state.zip")); err == nil {
+		t.Error("ExportState(.zip) error = nil")
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// streamResultLines is how many lines of a tool result are rendered.
+const streamResultLines = 3
+
+// streamSummaryKeys are the tool input fields used to summarize a call, in
+// order of preference.
+var streamSummaryKeys = []string{"command", "file_path", "notebook_path", "pattern", "path", "url", "query", "description", "prompt"}
+
+// StreamEvent is a single line of `claude --output-format stream-json`.
+type StreamEvent struct {
+	Type         string         `json:"type"`    // system, assistant, user, result
+	Subtype      string         `json:"subtype"` // init (system); success, error_* (result)
+	SessionID    string         `json:"session_id"`
+	Model        string         `json:"model"`
+	Message      *StreamMessage `json:"message"`
+	IsError      bool           `json:"is_error"`
+	Result       string         `json:"result"`
+	TotalCostUSD float64        `json:"total_cost_usd"`
+	NumTurns     int            `json:"num_turns"`
+	Usage        *StreamUsage   `json:"usage"`
+}
+
+// StreamMessage is the assistant or user message carried by an event.
+type StreamMessage struct {
+	Content []StreamContent `json:"content"`
+}
+
+// StreamContent is a content block: text, tool_use, or tool_result.
+type StreamContent struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Name    string          `json:"name"`    // tool_use
+	Input   json.RawMessage `json:"input"`   // tool_use
+	Content json.RawMessage `json:"content"` // tool_result: a string or text blocks
+	IsError bool            `json:"is_error"`
+}
+
+// StreamUsage is the token usage reported with the result.
+type StreamUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Stream status values.
+const (
+	StreamStatusRunning = "running"
+	StreamStatusDone    = "done"
+	StreamStatusError   = "error"
+)
+
+// StreamState is what PAW learned from a task's stream-json events.
+type StreamState struct {
+	SessionID    string    `json:"session_id,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	Status       string    `json:"status"`
+	ToolCalls    int       `json:"tool_calls"`
+	LastTool     string    `json:"last_tool,omitempty"`
+	Errors       int       `json:"errors"` // Failed tool calls
+	Result       string    `json:"result,omitempty"`
+	CostUSD      float64   `json:"cost_usd"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	Turns        int       `json:"turns"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ParseStreamEvent parses one line of stream-json output.
+func ParseStreamEvent(line []byte) (*StreamEvent, error) {
+	var ev StreamEvent
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return nil, fmt.Errorf("invalid stream event: %w", err)
+	}
+	if ev.Type == "" {
+		return nil, fmt.Errorf("invalid stream event: missing type")
+	}
+	return &ev, nil
+}
+
+// Finished reports whether the run has ended.
+func (s *StreamState) Finished() bool {
+	return s.Status == StreamStatusDone || s.Status == StreamStatusError
+}
+
+// Apply updates the state with an event and returns the event rendered the way
+// Claude Code shows it ("⏺ Tool(args)" with "⎿" results), so the pane reads
+// like an interactive session and the transcript parsers keep working.
+func (s *StreamState) Apply(ev *StreamEvent, now time.Time) []string {
+	s.UpdatedAt = now
+	if s.Status == "" {
+		s.Status = StreamStatusRunning
+	}
+
+	var lines []string
+	switch ev.Type {
+	case "system":
+		if ev.Subtype == "init" {
+			s.SessionID = ev.SessionID
+			s.Model = ev.Model
+		}
+	case "assistant":
+		if ev.Message == nil {
+			break
+		}
+		for _, c := range ev.Message.Content {
+			switch c.Type {
+			case "text":
+				if text := strings.TrimSpace(c.Text); text != "" {
+					lines = append(lines, "", "⏺ "+text)
+				}
+			case "tool_use":
+				s.ToolCalls++
+				s.LastTool = c.Name
+				lines = append(lines, "", fmt.Sprintf("⏺ %s(%s)", c.Name, summarizeToolInput(c.Input)))
+			}
+		}
+	case "user":
+		if ev.Message == nil {
+			break
+		}
+		for _, c := range ev.Message.Content {
+			if c.Type != "tool_result" {
+				continue
+			}
+			if c.IsError {
+				s.Errors++
+			}
+			lines = append(lines, renderToolResult(toolResultText(c.Content), c.IsError)...)
+		}
+	case "result":
+		s.Result = ev.Result
+		s.CostUSD = ev.TotalCostUSD
+		s.Turns = ev.NumTurns
+		if ev.Usage != nil {
+			s.InputTokens = ev.Usage.InputTokens
+			s.OutputTokens = ev.Usage.OutputTokens
+		}
+		if ev.IsError || ev.Subtype != "success" {
+			s.Status = StreamStatusError
+			lines = append(lines, "", fmt.Sprintf("⏺ Error: run ended with %s", ev.Subtype))
+		} else {
+			s.Status = StreamStatusDone
+		}
+		lines = append(lines, "", fmt.Sprintf("✻ %d turns, %d tool calls, %s", s.Turns, s.ToolCalls, FormatCost(s.CostUSD)))
+	}
+	return lines
+}
+
+// summarizeToolInput returns the most telling field of a tool's input.
+func summarizeToolInput(raw json.RawMessage) string {
+	var input map[string]any
+	if err := json.Unmarshal(raw, &input); err != nil || len(input) == 0 {
+		return ""
+	}
+	for _, key := range streamSummaryKeys {
+		if v, ok := input[key].(string); ok && v != "" {
+			return firstLine(v)
+		}
+	}
+	data, _ := json.Marshal(input)
+	return truncateSummary(string(data), 80)
+}
+
+// toolResultText returns the text of a tool_result content field, which is
+// either a string or a list of text blocks.
+func toolResultText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []StreamContent
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderToolResult renders the first lines of a tool result under its call.
+func renderToolResult(text string, isError bool) []string {
+	var out []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line == "" {
+			continue
+		}
+		out = append(out, line)
+	}
+	if isError && (len(out) == 0 || !isTimelineError(out[0])) {
+		out = append([]string{"Error:"}, out...)
+	}
+	if len(out) == 0 {
+		return []string{"  ⎿  (no output)"}
+	}
+
+	hidden := 0
+	if len(out) > streamResultLines {
+		hidden = len(out) - streamResultLines
+		out = out[:streamResultLines]
+	}
+	lines := make([]string, 0, len(out)+1)
+	for i, line := range out {
+		if i == 0 {
+			lines = append(lines, "  ⎿  "+line)
+		} else {
+			lines = append(lines, "     "+line)
+		}
+	}
+	if hidden > 0 {
+		lines = append(lines, fmt.Sprintf("     … +%d lines", hidden))
+	}
+	return lines
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return truncateSummary(line, 80)
+}
+
+func truncateSummary(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-1]) + "…"
+}
+
+// FormatCost formats an API cost in dollars.
+func FormatCost(usd float64) string {
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// SaveStreamState writes the state to path as JSON.
+func SaveStreamState(path string, s *StreamState) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream state: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stream state: %w", err)
+	}
+	return nil
+}
+
+// LoadStreamState reads a state written by SaveStreamState.
+func LoadStreamState(path string) (*StreamState, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled agent directory
+	if err != nil {
+		return nil, err
+	}
+	var s StreamState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid stream state: %w", err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleStream = `{"type":"system","subtype":"init","session_id":"s-1","model":"claude-opus-4"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"I'll run the tests."},{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test ./...","description":"Run tests"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"FAIL github.com/dongho-jung/paw/cmd/paw\nline 2\nline 3\nline 4","is_error":true}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t2","name":"Edit","input":{"file_path":"cmd/paw/main.go","old_string":"a","new_string":"b"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t2","content":[{"type":"text","text":"Updated cmd/paw/main.go"}]}]}}
+{"type":"result","subtype":"success","is_error":false,"result":"Done","total_cost_usd":0.4213,"num_turns":3,"usage":{"input_tokens":1200,"output_tokens":340}}
+`
+
+func applySampleStream(t *testing.T, input string) (*StreamState, string) {
+	t.Helper()
+	state := &StreamState{}
+	var rendered []string
+	for _, line := range strings.Split(strings.TrimSpace(input), "\n") {
+		ev, err := ParseStreamEvent([]byte(line))
+		if err != nil {
+			t.Fatalf("ParseStreamEvent(%q) error = %v", line, err)
+		}
+		rendered = append(rendered, state.Apply(ev, time.Now())...)
+	}
+	return state, strings.Join(rendered, "\n")
+}
+
+func TestStreamStateApply(t *testing.T) {
+	state, _ := applySampleStream(t, sampleStream)
+
+	if state.SessionID != "s-1" || state.Model != "claude-opus-4" {
+		t.Errorf("session = %q/%q, want s-1/claude-opus-4", state.SessionID, state.Model)
+	}
+	if state.Status != StreamStatusDone || !state.Finished() {
+		t.Errorf("Status = %q, want %q", state.Status, StreamStatusDone)
+	}
+	if state.ToolCalls != 2 || state.LastTool != "Edit" {
+		t.Errorf("ToolCalls = %d, LastTool = %q, want 2, Edit", state.ToolCalls, state.LastTool)
+	}
+	if state.Errors != 1 {
+		t.Errorf("Errors = %d, want 1", state.Errors)
+	}
+	if state.CostUSD != 0.4213 || state.Turns != 3 || state.InputTokens != 1200 || state.OutputTokens != 340 {
+		t.Errorf("unexpected result totals: %+v", state)
+	}
+}
+
+func TestStreamStateApply_ErrorResult(t *testing.T) {
+	state, rendered := applySampleStream(t, `{"type":"result","subtype":"error_max_turns","is_error":true,"total_cost_usd":1.5}`)
+
+	if state.Status != StreamStatusError {
+		t.Errorf("Status = %q, want %q", state.Status, StreamStatusError)
+	}
+	if !strings.Contains(rendered, "error_max_turns") || !strings.Contains(rendered, "$1.50") {
+		t.Errorf("rendered = %q, want the error subtype and cost", rendered)
+	}
+}
+
+func TestStreamRenderFeedsTimeline(t *testing.T) {
+	_, rendered := applySampleStream(t, sampleStream)
+
+	tl := ParseTimeline(rendered)
+	if got := tl.Commands(); len(got) != 1 || got[0] != "go test ./..." {
+		t.Errorf("Commands() = %v, want [go test ./...]", got)
+	}
+	if got := tl.FilesEdited(); len(got) != 1 || got[0] != "cmd/paw/main.go" {
+		t.Errorf("FilesEdited() = %v, want [cmd/paw/main.go]", got)
+	}
+	if errs := tl.Errors(); len(errs) != 1 || errs[0].Tool != "Bash" {
+		t.Errorf("Errors() = %+v, want the failed Bash call", errs)
+	}
+	if !strings.Contains(rendered, "… +1 lines") {
+		t.Errorf("rendered = %q, want long results truncated", rendered)
+	}
+}
+
+func TestParseStreamEvent_Invalid(t *testing.T) {
+	for _, line := range []string{"not json", `{"subtype":"init"}`} {
+		if _, err := ParseStreamEvent([]byte(line)); err == nil {
+			t.Errorf("ParseStreamEvent(%q) error = nil, want error", line)
+		}
+	}
+}
+
+func TestStreamStateSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".stream.json")
+	state := &StreamState{Status: StreamStatusDone, CostUSD: 0.25, ToolCalls: 4}
+	if err := SaveStreamState(path, state); err != nil {
+		t.Fatalf("SaveStreamState() error = %v", err)
+	}
+
+	loaded, err := LoadStreamState(path)
+	if err != nil {
+		t.Fatalf("LoadStreamState() error = %v", err)
+	}
+	if loaded.Status != StreamStatusDone || loaded.CostUSD != 0.25 || loaded.ToolCalls != 4 {
+		t.Errorf("loaded = %+v, want %+v", loaded, state)
+	}
+}
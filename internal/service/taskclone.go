@@ -0,0 +1,140 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// TaskClone is the content and options of a task to re-run as a new task, or
+// to start a follow-up to it.
+type TaskClone struct {
+	Source  string              `json:"source,omitempty"` // Name of the cloned task
+	Content string              `json:"content"`
+	Options *config.TaskOptions `json:"options,omitempty"`
+
+	// FollowUp marks input for a follow-up to Source rather than a re-run
+	FollowUp bool `json:"follow_up,omitempty"`
+}
+
+// NewTaskClone returns a clone of a task's content and options. The branch
+// name and dependency are dropped: they belong to the original task.
+func NewTaskClone(source, content string, opts *config.TaskOptions) *TaskClone {
+	if opts == nil {
+		opts = config.DefaultTaskOptions()
+	} else {
+		opts = opts.Clone()
+	}
+	opts.BranchName = ""
+	opts.DependsOn = nil
+	return &TaskClone{Source: source, Content: strings.TrimSpace(content), Options: opts}
+}
+
+// LoadTaskClone clones the task in agentDir.
+func LoadTaskClone(name, agentDir string) (*TaskClone, error) {
+	content, err := task.New(name, agentDir).LoadContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task content: %w", err)
+	}
+	opts, err := config.LoadTaskOptions(agentDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskClone(name, content, opts), nil
+}
+
+// HistoryTaskClone clones the task of a history entry.
+func HistoryTaskClone(entry *HistoryEntry) (*TaskClone, error) {
+	if strings.TrimSpace(entry.Task) == "" {
+		return nil, errors.New("history entry has no task content")
+	}
+	var source string
+	var opts *config.TaskOptions
+	if entry.Meta != nil {
+		source = entry.Meta.TaskName
+		opts = entry.Meta.TaskOptions
+	}
+	return NewTaskClone(source, entry.Task, opts), nil
+}
+
+// NewFollowUp returns input for a task that follows up on an earlier one: the
+// content references the earlier task's request, summary, and branch, and the
+// options link to it.
+func NewFollowUp(source, branch, request, summary string) *TaskClone {
+	var sb strings.Builder
+	sb.WriteString("Follow-up to task " + source)
+	if branch != "" {
+		sb.WriteString(" (branch " + branch + ")")
+	}
+	sb.WriteString(".\n")
+	if request = strings.TrimSpace(request); request != "" {
+		sb.WriteString("\nPrevious task:\n" + request + "\n")
+	}
+	if summary = strings.TrimSpace(summary); summary != "" {
+		sb.WriteString("\nSummary:\n" + summary + "\n")
+	}
+	sb.WriteString("\n")
+
+	opts := config.DefaultTaskOptions()
+	opts.FollowUp = source
+	return &TaskClone{Source: source, Content: sb.String(), Options: opts, FollowUp: true}
+}
+
+// LoadFollowUp returns input for a follow-up to the task in agentDir.
+func LoadFollowUp(name, agentDir, branch string) (*TaskClone, error) {
+	content, err := task.New(name, agentDir).LoadContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task content: %w", err)
+	}
+	return NewFollowUp(name, branch, content, ""), nil
+}
+
+// HistoryFollowUp returns input for a follow-up to the task of a history entry.
+func HistoryFollowUp(entry *HistoryEntry) (*TaskClone, error) {
+	if entry.Meta == nil || entry.Meta.TaskName == "" {
+		return nil, errors.New("history entry has no task name")
+	}
+	var branch string
+	if entry.Meta.Commit != nil {
+		branch = entry.Meta.Commit.Branch
+	}
+	return NewFollowUp(entry.Meta.TaskName, branch, entry.Task, entry.Summary), nil
+}
+
+// WriteCloneSelection hands a clone to the new-task input, which picks it
+// up and pre-fills its content and options.
+func WriteCloneSelection(pawDir string, clone *TaskClone) error {
+	data, err := json.Marshal(clone)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task clone: %w", err)
+	}
+	return fileutil.WriteFileAtomic(filepath.Join(pawDir, constants.CloneSelectionFile), data, 0644)
+}
+
+// ReadCloneSelection reads and removes the pending clone selection.
+// It returns nil if there is none.
+func ReadCloneSelection(pawDir string) (*TaskClone, error) {
+	path := filepath.Join(pawDir, constants.CloneSelectionFile)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed from pawDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	_ = os.Remove(path)
+
+	var clone TaskClone
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to parse clone selection: %w", err)
+	}
+	return &clone, nil
+}
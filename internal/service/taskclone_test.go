@@ -0,0 +1,94 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestLoadTaskClone(t *testing.T) {
+	agentDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentDir, constants.TaskFileName), []byte("Fix the login form\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts := &config.TaskOptions{
+		Model:      "opus",
+		BranchName: "fix-login",
+		Labels:     []string{"bug"},
+		Parent:     "add-auth",
+		DependsOn:  &config.TaskDependency{TaskName: "setup", Condition: config.DependsOnSuccess},
+	}
+	if err := opts.Save(agentDir); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := LoadTaskClone("fix-login", agentDir)
+	if err != nil {
+		t.Fatalf("LoadTaskClone() error: %v", err)
+	}
+	if clone.Source != "fix-login" || clone.Content != "Fix the login form" {
+		t.Errorf("LoadTaskClone() = %q/%q, want fix-login/Fix the login form", clone.Source, clone.Content)
+	}
+	if clone.Options.BranchName != "" || clone.Options.DependsOn != nil {
+		t.Errorf("clone kept the branch name or dependency: %+v", clone.Options)
+	}
+	if clone.Options.Model != "opus" || clone.Options.Parent != "add-auth" || len(clone.Options.Labels) != 1 {
+		t.Errorf("clone lost options: %+v", clone.Options)
+	}
+
+	pawDir := t.TempDir()
+	if err := WriteCloneSelection(pawDir, clone); err != nil {
+		t.Fatalf("WriteCloneSelection() error: %v", err)
+	}
+	got, err := ReadCloneSelection(pawDir)
+	if err != nil || got == nil || got.Content != clone.Content || got.Options.Model != "opus" {
+		t.Fatalf("ReadCloneSelection() = %+v, %v", got, err)
+	}
+	if got, err := ReadCloneSelection(pawDir); got != nil || err != nil {
+		t.Errorf("ReadCloneSelection() should consume the selection, got %+v, %v", got, err)
+	}
+}
+
+func TestHistoryTaskClone(t *testing.T) {
+	if _, err := HistoryTaskClone(&HistoryEntry{}); err == nil {
+		t.Error("HistoryTaskClone() of an entry without content should fail")
+	}
+
+	clone, err := HistoryTaskClone(&HistoryEntry{Task: "Add docs"})
+	if err != nil {
+		t.Fatalf("HistoryTaskClone() error: %v", err)
+	}
+	if clone.Options == nil || clone.Options.Model != config.DefaultTaskOptions().Model {
+		t.Errorf("entry without metadata should clone default options, got %+v", clone.Options)
+	}
+}
+
+func TestHistoryFollowUp(t *testing.T) {
+	entry := &HistoryEntry{
+		Meta: &HistoryMetadata{
+			TaskName: "fix-login",
+			Commit:   &CommitMetadata{Branch: "feature/fix-login"},
+		},
+		Task:    "Fix the login form",
+		Summary: "Validated the email field.",
+	}
+
+	followUp, err := HistoryFollowUp(entry)
+	if err != nil {
+		t.Fatalf("HistoryFollowUp() error: %v", err)
+	}
+	want := "Follow-up to task fix-login (branch feature/fix-login).\n\nPrevious task:\nFix the login form\n\nSummary:\nValidated the email field.\n\n"
+	if followUp.Content != want {
+		t.Errorf("Content = %q, want %q", followUp.Content, want)
+	}
+	if !followUp.FollowUp || followUp.Options.FollowUp != "fix-login" {
+		t.Errorf("follow-up is not linked to fix-login: %+v", followUp)
+	}
+
+	if _, err := HistoryFollowUp(&HistoryEntry{Task: "Fix it"}); err == nil {
+		t.Error("HistoryFollowUp() of an entry without a task name should fail")
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -27,6 +29,30 @@ type DiscoveredTask struct {
 	Duration      string    // Task duration (e.g., "1m 36s") extracted from Claude status
 	Tokens        string    // Token count (e.g., "↓ 5.9k") extracted from Claude status
 	CreatedAt     time.Time // Estimated creation time
+	SnoozedUntil  time.Time // Notifications are suppressed until this time (zero if not snoozed)
+	Question      string    // Question the agent is waiting on (waiting tasks only)
+	Review        string    // Why the task needs human review before it is finished (done tasks only)
+	Cost          string    // API cost reported by stream-json supervision (e.g. "$0.42")
+	Labels        []string  // Task labels from the task options (e.g. bug, feature)
+	DependsOn     string    // Task this one runs after (chained and dependent tasks)
+	Parent        string    // Task this one is stacked on (branches off its branch)
+	FollowUp      string    // Earlier task this one follows up on
+	AgentDir      string    // Task's agent directory ("" if the workspace is unknown)
+}
+
+// HasLabel returns true if the task has the given label.
+func (t *DiscoveredTask) HasLabel(label string) bool {
+	for _, l := range t.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSnoozed returns true if the task is snoozed at the given time.
+func (t *DiscoveredTask) IsSnoozed(now time.Time) bool {
+	return now.Before(t.SnoozedUntil)
 }
 
 // DiscoveredStatus represents the status of a discovered task.
@@ -68,14 +94,19 @@ func (s *TaskDiscoveryService) DiscoverAll() (working, waiting, done []*Discover
 	var allTasks []*DiscoveredTask
 
 	for _, socket := range sockets {
+		if tmux.IsCooperative(strings.TrimPrefix(socket, constants.TmuxSocketPrefix)) {
+			continue
+		}
 		tasks := s.discoverFromSocket(socket)
 		allTasks = append(allTasks, tasks...)
 	}
+	// Cooperative sessions run on the user's default server
+	// (a leftover "paw-" socket of the same name is skipped above)
+	for _, sessionName := range tmux.CooperativeSessions() {
+		allTasks = append(allTasks, s.discoverFromSocket(constants.TmuxSocketPrefix+sessionName)...)
+	}
 
-	// Sort by creation time (oldest first within each status)
-	sort.Slice(allTasks, func(i, j int) bool {
-		return allTasks[i].CreatedAt.Before(allTasks[j].CreatedAt)
-	})
+	sortDiscoveredTasks(allTasks, time.Now())
 
 	// Group by status
 	for _, task := range allTasks {
@@ -92,6 +123,18 @@ func (s *TaskDiscoveryService) DiscoverAll() (working, waiting, done []*Discover
 	return working, waiting, done
 }
 
+// sortDiscoveredTasks orders tasks by creation time (oldest first), with
+// snoozed tasks demoted below the rest.
+func sortDiscoveredTasks(tasks []*DiscoveredTask, now time.Time) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		si, sj := tasks[i].IsSnoozed(now), tasks[j].IsSnoozed(now)
+		if si != sj {
+			return sj
+		}
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+}
+
 // findPawSockets finds all PAW tmux sockets.
 func (s *TaskDiscoveryService) findPawSockets() []string {
 	entries, err := os.ReadDir(s.socketDir)
@@ -128,8 +171,14 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 		return nil
 	}
 
-	pawDir := resolvePawDir(tm, sessionName)
+	return s.DiscoverSession(tm, sessionName, resolvePawDir(tm, sessionName))
+}
+
+// DiscoverSession discovers the tasks of one session through tm.
+// pawDir is used to resolve truncated window names to task names (may be empty).
+func (s *TaskDiscoveryService) DiscoverSession(tm tmux.Client, sessionName, pawDir string) []*DiscoveredTask {
 	tokenMap := buildTokenMap(pawDir)
+	windowTasks := LoadWindowTasks(pawDir)
 
 	// List windows
 	windows, err := tm.ListWindows()
@@ -147,7 +196,7 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 			continue // Not a task window
 		}
 
-		taskName := resolveTaskName(taskToken, tokenMap)
+		taskName := resolveWindowTask(taskToken, w.ID, tokenMap, windowTasks)
 
 		task := &DiscoveredTask{
 			Name:        taskName,
@@ -157,6 +206,26 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 			WindowID:    w.ID,
 			CreatedAt:   time.Now(), // We don't have exact creation time
 		}
+		if pawDir != "" {
+			agentDir := filepath.Join(pawDir, constants.AgentsDirName, taskName)
+			task.AgentDir = agentDir
+			task.SnoozedUntil = loadSnoozedUntil(taskName, agentDir)
+			if status == DiscoveredWaiting {
+				task.Question = loadQuestion(taskName, agentDir)
+			}
+			if status == DiscoveredDone {
+				task.Review = loadReview(taskName, agentDir)
+			}
+			task.Cost = loadStreamCost(agentDir)
+			if opts, err := config.LoadTaskOptions(agentDir); err == nil {
+				task.Labels = opts.Labels
+				if opts.DependsOn != nil {
+					task.DependsOn = opts.DependsOn.TaskName
+				}
+				task.Parent = opts.Parent
+				task.FollowUp = opts.FollowUp
+			}
+		}
 
 		// Only capture pane content for Working tasks (performance optimization)
 		// Done and Waiting tasks don't need continuous monitoring since their
@@ -181,6 +250,32 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 	return tasks
 }
 
+// loadSnoozedUntil returns the snooze deadline of a task.
+func loadSnoozedUntil(taskName, agentDir string) time.Time {
+	return task.New(taskName, agentDir).SnoozedUntil()
+}
+
+// loadQuestion returns the question a waiting task asked, recorded by watch-wait.
+func loadQuestion(taskName, agentDir string) string {
+	return task.New(taskName, agentDir).Question()
+}
+
+// loadReview returns why a done task was held for review instead of being
+// finished automatically.
+func loadReview(taskName, agentDir string) string {
+	return task.New(taskName, agentDir).ReviewReason()
+}
+
+// loadStreamCost returns the API cost of a task run under stream-json
+// supervision, or "" for interactive tasks.
+func loadStreamCost(agentDir string) string {
+	state, err := LoadStreamState(filepath.Join(agentDir, constants.StreamStateFileName))
+	if err != nil || state.CostUSD == 0 {
+		return ""
+	}
+	return FormatCost(state.CostUSD)
+}
+
 func resolvePawDir(tm tmux.Client, sessionName string) string {
 	sessionPath, err := tm.RunWithOutput("display-message", "-p", "-t", sessionName, "#{session_path}")
 	if err != nil {
@@ -209,28 +304,36 @@ func buildTokenMap(pawDir string) map[string]string {
 
 	if fileMap, err := LoadWindowMap(pawDir); err == nil {
 		for token, name := range fileMap {
-			mapping[token] = name
+			if consistentWindowMapEntry(pawDir, token, name) {
+				mapping[token] = name
+			}
 		}
 	} else if !os.IsNotExist(err) {
 		logging.Debug("Failed to load window map from %s: %v", pawDir, err)
 	}
 
-	agentsDir := filepath.Join(pawDir, constants.AgentsDirName)
-	entries, err := os.ReadDir(agentsDir)
-	if err != nil {
-		return mapping
-	}
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+	// Live scan: every existing task maps from its own token, so stale or
+	// missing map entries don't hide it
+	for _, name := range listAgentTasks(pawDir) {
+		token := constants.TruncateForWindowName(name)
+		if _, ok := mapping[token]; !ok {
+			mapping[token] = name
 		}
-		name := entry.Name()
-		mapping[constants.TruncateForWindowName(name)] = name
 	}
 
 	return mapping
 }
 
+// resolveWindowTask returns the task a window belongs to. The window ID in
+// the task's tab-lock wins over the token map, which can't tell apart task
+// names that truncate to the same token.
+func resolveWindowTask(token, windowID string, tokenMap, windowTasks map[string]string) string {
+	if name, ok := windowTasks[windowID]; ok && constants.TruncateForWindowName(name) == token {
+		return name
+	}
+	return resolveTaskName(token, tokenMap)
+}
+
 func resolveTaskName(token string, tokenMap map[string]string) string {
 	if token == "" {
 		return token
@@ -3,6 +3,7 @@ package service
 
 import (
 	"testing"
+	"time"
 )
 
 func TestExtractCurrentAction(t *testing.T) {
@@ -294,3 +295,22 @@ func TestParseWindowName(t *testing.T) {
 		})
 	}
 }
+
+func TestSortDiscoveredTasksDemotesSnoozed(t *testing.T) {
+	now := time.Now()
+	tasks := []*DiscoveredTask{
+		{Name: "snoozed", CreatedAt: now.Add(-3 * time.Hour), SnoozedUntil: now.Add(time.Hour)},
+		{Name: "expired", CreatedAt: now.Add(-2 * time.Hour), SnoozedUntil: now.Add(-time.Minute)},
+		{Name: "newest", CreatedAt: now.Add(-time.Hour)},
+		{Name: "oldest", CreatedAt: now.Add(-4 * time.Hour)},
+	}
+
+	sortDiscoveredTasks(tasks, now)
+
+	want := []string{"oldest", "expired", "newest", "snoozed"}
+	for i, name := range want {
+		if tasks[i].Name != name {
+			t.Errorf("tasks[%d] = %s, want %s", i, tasks[i].Name, name)
+		}
+	}
+}
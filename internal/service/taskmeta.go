@@ -0,0 +1,100 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// WorktreeDir returns the task's git worktree, or "" if it has none (e.g.
+// non-git projects, or the workspace couldn't be resolved).
+func (t *DiscoveredTask) WorktreeDir() string {
+	if t.AgentDir == "" {
+		return ""
+	}
+	entries, err := os.ReadDir(t.AgentDir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(t.AgentDir, entry.Name())
+		// A worktree has a .git file pointing at the main repository
+		if info, err := os.Stat(filepath.Join(dir, ".git")); err == nil && !info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// Branch returns the branch checked out in the task's worktree, falling
+// back to the task name (which PAW uses as the branch name).
+func (t *DiscoveredTask) Branch() string {
+	if branch := worktreeBranch(t.WorktreeDir()); branch != "" {
+		return branch
+	}
+	return t.Name
+}
+
+// worktreeBranch reads the current branch of a worktree from its HEAD,
+// without running git.
+func worktreeBranch(worktreeDir string) string {
+	if worktreeDir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(worktreeDir, ".git")) //nolint:gosec // G304: path is inside the task's agent dir
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return ""
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(worktreeDir, gitDir)
+	}
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD")) //nolint:gosec // G304: path comes from the worktree's .git file
+	if err != nil {
+		return ""
+	}
+	branch, _ := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: refs/heads/")
+	if branch == strings.TrimSpace(string(head)) {
+		return "" // Detached HEAD
+	}
+	return branch
+}
+
+// PRNumber returns the number of the task's pull request, or 0.
+func (t *DiscoveredTask) PRNumber() int {
+	if t.AgentDir == "" {
+		return 0
+	}
+	n, _ := task.New(t.Name, t.AgentDir).LoadPRNumber()
+	return n
+}
+
+// StatusLine formats the task for pasting into chat, e.g.
+// "🤖 fix-login [myapp] working · 12m 3s · PR #42".
+func (t *DiscoveredTask) StatusLine() string {
+	var sb strings.Builder
+	if t.StatusEmoji != "" {
+		sb.WriteString(t.StatusEmoji + " ")
+	}
+	sb.WriteString(t.Name)
+	if t.Session != "" {
+		sb.WriteString(" [" + t.Session + "]")
+	}
+	sb.WriteString(" " + string(t.Status))
+	if t.Duration != "" {
+		sb.WriteString(" · " + t.Duration)
+	}
+	if n := t.PRNumber(); n > 0 {
+		sb.WriteString(" · PR #" + strconv.Itoa(n))
+	}
+	return sb.String()
+}
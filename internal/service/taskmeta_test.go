@@ -0,0 +1,65 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestDiscoveredTaskMetadata(t *testing.T) {
+	agentDir := t.TempDir()
+	gitDir := filepath.Join(t.TempDir(), "worktrees", "fix-login")
+	worktree := filepath.Join(agentDir, "myapp")
+	for _, dir := range []string{gitDir, worktree, filepath.Join(agentDir, ".tab-lock")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(worktree, ".git"):               "gitdir: " + gitDir + "\n",
+		filepath.Join(gitDir, "HEAD"):                 "ref: refs/heads/feature/fix-login\n",
+		filepath.Join(agentDir, constants.PRFileName): "42",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	task := &DiscoveredTask{
+		Name:        "fix-login",
+		Session:     "myapp",
+		Status:      DiscoveredWorking,
+		StatusEmoji: constants.EmojiWorking,
+		Duration:    "12m 3s",
+		AgentDir:    agentDir,
+	}
+	if got := task.WorktreeDir(); got != worktree {
+		t.Errorf("WorktreeDir() = %q, want %q", got, worktree)
+	}
+	if got := task.Branch(); got != "feature/fix-login" {
+		t.Errorf("Branch() = %q, want %q", got, "feature/fix-login")
+	}
+	if got := task.PRNumber(); got != 42 {
+		t.Errorf("PRNumber() = %d, want 42", got)
+	}
+	want := constants.EmojiWorking + " fix-login [myapp] working · 12m 3s · PR #42"
+	if got := task.StatusLine(); got != want {
+		t.Errorf("StatusLine() = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoveredTaskMetadataWithoutWorkspace(t *testing.T) {
+	task := &DiscoveredTask{Name: "fix-login", Status: DiscoveredDone}
+	if got := task.WorktreeDir(); got != "" {
+		t.Errorf("WorktreeDir() = %q, want empty", got)
+	}
+	if got := task.Branch(); got != "fix-login" {
+		t.Errorf("Branch() = %q, want task name", got)
+	}
+	if got := task.StatusLine(); got != "fix-login done" {
+		t.Errorf("StatusLine() = %q, want %q", got, "fix-login done")
+	}
+}
@@ -3,10 +3,16 @@ package service
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/embed"
 	"github.com/dongho-jung/paw/internal/fileutil"
 )
 
@@ -38,8 +44,55 @@ func (s *TemplateService) templatePath() string {
 	return filepath.Join(s.pawDir, TemplateFile)
 }
 
-// LoadTemplates loads templates from file.
+// BuiltinTemplates returns the maintenance templates bundled with PAW,
+// sorted by name.
+func BuiltinTemplates() []TemplateEntry {
+	contents, err := embed.GetTaskTemplates()
+	if err != nil {
+		return nil
+	}
+	entries := make([]TemplateEntry, 0, len(contents))
+	for name, content := range contents {
+		entries = append(entries, TemplateEntry{Name: name, Content: content})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// LoadTemplates loads the project's templates followed by the built-in
+// templates. A project template with a built-in's name replaces it.
 func (s *TemplateService) LoadTemplates() ([]TemplateEntry, error) {
+	entries, err := s.loadProjectTemplates()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		names[e.Name] = true
+	}
+	for _, b := range BuiltinTemplates() {
+		if !names[b.Name] {
+			entries = append(entries, b)
+		}
+	}
+	return entries, nil
+}
+
+// FindTemplate returns the template with the given name.
+func (s *TemplateService) FindTemplate(name string) (*TemplateEntry, error) {
+	entries, err := s.LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].Name == name {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("template %q not found", name)
+}
+
+func (s *TemplateService) loadProjectTemplates() ([]TemplateEntry, error) {
 	path := s.templatePath()
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from templatePath()
 	if err != nil {
@@ -58,8 +111,21 @@ func (s *TemplateService) LoadTemplates() ([]TemplateEntry, error) {
 	return entries, nil
 }
 
-// SaveTemplates saves templates to file.
+// SaveTemplates saves templates to file. Unchanged built-in templates are
+// not stored, so they keep following the bundled version.
 func (s *TemplateService) SaveTemplates(entries []TemplateEntry) error {
+	builtins := make(map[string]string)
+	for _, b := range BuiltinTemplates() {
+		builtins[b.Name] = b.Content
+	}
+	custom := make([]TemplateEntry, 0, len(entries))
+	for _, e := range entries {
+		if content, ok := builtins[e.Name]; !ok || content != e.Content {
+			custom = append(custom, e)
+		}
+	}
+	entries = custom
+
 	path := s.templatePath()
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
 		return err
@@ -70,3 +136,137 @@ func (s *TemplateService) SaveTemplates(entries []TemplateEntry) error {
 	}
 	return fileutil.WriteFileAtomic(path, data, 0644)
 }
+
+// TemplateSchedule runs a template as a new task at a fixed interval while
+// the project's session is running.
+type TemplateSchedule struct {
+	Name    string    `json:"name"`
+	Every   string    `json:"every"`
+	LastRun time.Time `json:"last_run"`
+}
+
+// ParseScheduleInterval parses a schedule interval: a Go duration ("12h")
+// or a number of days or weeks ("7d", "2w").
+func ParseScheduleInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	var d time.Duration
+	if unit > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		d = time.Duration(n) * unit
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid interval %q (use e.g. 12h, 7d, or 2w)", s)
+		}
+	}
+	if d < time.Hour {
+		return 0, fmt.Errorf("interval %q is shorter than 1h", s)
+	}
+	return d, nil
+}
+
+// Due reports whether the schedule should run at now.
+func (sc TemplateSchedule) Due(now time.Time) bool {
+	every, err := ParseScheduleInterval(sc.Every)
+	if err != nil {
+		return false
+	}
+	return !now.Before(sc.LastRun.Add(every))
+}
+
+func (s *TemplateService) schedulePath() string {
+	return filepath.Join(s.pawDir, constants.TemplateScheduleFile)
+}
+
+// LoadSchedules loads the template schedules.
+func (s *TemplateService) LoadSchedules() ([]TemplateSchedule, error) {
+	path := s.schedulePath()
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from schedulePath()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var schedules []TemplateSchedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		_ = fileutil.BackupCorruptFile(path)
+		return nil, nil //nolint:nilerr // Intentional: return empty list on corrupt file
+	}
+	return schedules, nil
+}
+
+// SaveSchedules saves the template schedules.
+func (s *TemplateService) SaveSchedules(schedules []TemplateSchedule) error {
+	if err := os.MkdirAll(s.pawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(s.schedulePath(), data, 0644)
+}
+
+// SetSchedule schedules the template every interval, replacing an existing
+// schedule for it. The first run is one interval from now.
+func (s *TemplateService) SetSchedule(name, every string, now time.Time) error {
+	if _, err := ParseScheduleInterval(every); err != nil {
+		return err
+	}
+	if _, err := s.FindTemplate(name); err != nil {
+		return err
+	}
+	schedules, err := s.LoadSchedules()
+	if err != nil {
+		return err
+	}
+	schedule := TemplateSchedule{Name: name, Every: every, LastRun: now}
+	for i := range schedules {
+		if schedules[i].Name == name {
+			schedules[i] = schedule
+			return s.SaveSchedules(schedules)
+		}
+	}
+	return s.SaveSchedules(append(schedules, schedule))
+}
+
+// RemoveSchedule unschedules the template. It reports whether it was
+// scheduled.
+func (s *TemplateService) RemoveSchedule(name string) (bool, error) {
+	schedules, err := s.LoadSchedules()
+	if err != nil {
+		return false, err
+	}
+	for i := range schedules {
+		if schedules[i].Name == name {
+			return true, s.SaveSchedules(append(schedules[:i], schedules[i+1:]...))
+		}
+	}
+	return false, nil
+}
+
+// MarkScheduleRun records that the scheduled template ran at now.
+func (s *TemplateService) MarkScheduleRun(name string, now time.Time) error {
+	schedules, err := s.LoadSchedules()
+	if err != nil {
+		return err
+	}
+	for i := range schedules {
+		if schedules[i].Name == name {
+			schedules[i].LastRun = now
+		}
+	}
+	return s.SaveSchedules(schedules)
+}
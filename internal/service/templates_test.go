@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplateService_BuiltinsMerged(t *testing.T) {
+	svc := NewTemplateService(t.TempDir())
+
+	templates, err := svc.LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	if len(templates) != len(BuiltinTemplates()) || len(templates) == 0 {
+		t.Fatalf("LoadTemplates() = %d templates, want the %d built-ins", len(templates), len(BuiltinTemplates()))
+	}
+
+	// Saving unchanged built-ins stores nothing; a customized one replaces the built-in.
+	templates[0].Content = "custom"
+	templates = append(templates, TemplateEntry{Name: "mine", Content: "my task"})
+	if err := svc.SaveTemplates(templates); err != nil {
+		t.Fatalf("SaveTemplates() error = %v", err)
+	}
+	stored, err := svc.loadProjectTemplates()
+	if err != nil {
+		t.Fatalf("loadProjectTemplates() error = %v", err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("stored %d templates, want 2: %+v", len(stored), stored)
+	}
+
+	got, err := svc.FindTemplate(templates[0].Name)
+	if err != nil {
+		t.Fatalf("FindTemplate() error = %v", err)
+	}
+	if got.Content != "custom" {
+		t.Errorf("FindTemplate(%q).Content = %q, want %q", templates[0].Name, got.Content, "custom")
+	}
+	if _, err := svc.FindTemplate("missing"); err == nil {
+		t.Error("FindTemplate(missing) error = nil, want error")
+	}
+}
+
+func TestParseScheduleInterval(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"7d", 7 * 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"12h", 12 * time.Hour, true},
+		{"5m", 0, false},
+		{"xd", 0, false},
+		{"weekly", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseScheduleInterval(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseScheduleInterval(%q) = %v, %v; want %v, ok=%v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTemplateSchedules(t *testing.T) {
+	svc := NewTemplateService(t.TempDir())
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := svc.SetSchedule("todo-triage", "7d", now); err != nil {
+		t.Fatalf("SetSchedule() error = %v", err)
+	}
+	if err := svc.SetSchedule("missing", "7d", now); err == nil {
+		t.Error("SetSchedule(missing) error = nil, want error")
+	}
+
+	schedules, err := svc.LoadSchedules()
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("LoadSchedules() = %+v, %v", schedules, err)
+	}
+	if schedules[0].Due(now.Add(6 * 24 * time.Hour)) {
+		t.Error("schedule due after 6 days, want after 7")
+	}
+	later := now.Add(7 * 24 * time.Hour)
+	if !schedules[0].Due(later) {
+		t.Error("schedule not due after 7 days")
+	}
+
+	if err := svc.MarkScheduleRun("todo-triage", later); err != nil {
+		t.Fatalf("MarkScheduleRun() error = %v", err)
+	}
+	schedules, _ = svc.LoadSchedules()
+	if schedules[0].Due(later) {
+		t.Error("schedule still due right after running")
+	}
+
+	if removed, err := svc.RemoveSchedule("todo-triage"); err != nil || !removed {
+		t.Fatalf("RemoveSchedule() = %v, %v", removed, err)
+	}
+	if schedules, _ := svc.LoadSchedules(); len(schedules) != 0 {
+		t.Errorf("schedules after remove = %+v", schedules)
+	}
+}
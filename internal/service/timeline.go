@@ -0,0 +1,249 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// TimelineEventKind classifies a timeline event.
+type TimelineEventKind string
+
+// Timeline event kinds.
+const (
+	TimelineTool      TimelineEventKind = "tool"      // Generic tool call (Read, Grep, ...)
+	TimelineEdit      TimelineEventKind = "edit"      // File edit (Edit, Update, Write, ...)
+	TimelineCommand   TimelineEventKind = "command"   // Shell command (Bash)
+	TimelineMessage   TimelineEventKind = "message"   // Assistant message
+	TimelineInterrupt TimelineEventKind = "interrupt" // User interrupted the agent (detail: new direction)
+)
+
+// TimelineEvent is a single entry in a task's tool-call timeline.
+type TimelineEvent struct {
+	Kind   TimelineEventKind `json:"kind"`
+	Tool   string            `json:"tool,omitempty"`
+	Detail string            `json:"detail"`
+	Output []string          `json:"output,omitempty"`
+	Error  bool              `json:"error,omitempty"`
+}
+
+// Timeline is the structured view of an agent transcript.
+type Timeline struct {
+	Events []TimelineEvent `json:"events"`
+}
+
+// toolCallPattern matches Claude Code tool call lines like "⏺ Bash(go test ./...)".
+var toolCallPattern = regexp.MustCompile(`^⏺\s+([A-Z][A-Za-z]*)\((.*)\)\s*$`)
+
+// interruptPattern matches Claude Code's notice after the user pressed Escape
+// ("⎿  Interrupted by user", "⎿  Interrupted · What should Claude do instead?").
+var interruptPattern = regexp.MustCompile(`^⎿\s+Interrupted\b`)
+
+// editTools lists the tool names that modify files.
+var editTools = map[string]bool{
+	"Edit":         true,
+	"MultiEdit":    true,
+	"Update":       true,
+	"Write":        true,
+	"NotebookEdit": true,
+}
+
+// commandTools lists the tool names that run shell commands.
+var commandTools = map[string]bool{
+	"Bash": true,
+}
+
+// timelineErrorPrefixes are result line prefixes that indicate a failed tool call.
+var timelineErrorPrefixes = []string{
+	"Error:",
+	"Error ",
+	"error:",
+	"Exit code ",
+	"FAIL",
+	"panic:",
+}
+
+// ParseTimeline parses captured pane content into a structured timeline.
+// Tool calls ("⏺ Tool(args)") become events and the "⎿" result lines that
+// follow are attached as output. Plain "⏺" lines are kept as messages.
+func ParseTimeline(content string) *Timeline {
+	tl := &Timeline{}
+	var current *TimelineEvent
+
+	flush := func() {
+		if current != nil {
+			tl.Events = append(tl.Events, *current)
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "⏺") {
+			flush()
+			current = newTimelineEvent(trimmed)
+			continue
+		}
+
+		if interruptPattern.MatchString(trimmed) {
+			flush()
+			current = &TimelineEvent{Kind: TimelineInterrupt}
+			continue
+		}
+
+		if current == nil || current.Kind == TimelineMessage {
+			continue
+		}
+
+		if current.Kind == TimelineInterrupt {
+			// The user's next prompt ("> ...") is the new direction
+			if current.Detail == "" && strings.HasPrefix(trimmed, ">") {
+				current.Detail = strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			}
+			continue
+		}
+
+		// Result lines start with "⎿"; continuation lines are indented below it
+		isResult := strings.HasPrefix(trimmed, "⎿")
+		if !isResult && len(current.Output) == 0 {
+			continue
+		}
+		if !isResult && !strings.HasPrefix(line, " ") {
+			// Unindented text ends the tool result block
+			flush()
+			continue
+		}
+
+		out := strings.TrimSpace(strings.TrimPrefix(trimmed, "⎿"))
+		if out == "" {
+			continue
+		}
+		current.Output = append(current.Output, out)
+		if isTimelineError(out) {
+			current.Error = true
+		}
+	}
+	flush()
+
+	return tl
+}
+
+// newTimelineEvent builds an event from a "⏺" line.
+func newTimelineEvent(line string) *TimelineEvent {
+	if m := toolCallPattern.FindStringSubmatch(line); m != nil {
+		tool, detail := m[1], strings.TrimSpace(m[2])
+		kind := TimelineTool
+		switch {
+		case editTools[tool]:
+			kind = TimelineEdit
+		case commandTools[tool]:
+			kind = TimelineCommand
+		}
+		return &TimelineEvent{Kind: kind, Tool: tool, Detail: detail}
+	}
+	return &TimelineEvent{
+		Kind:   TimelineMessage,
+		Detail: strings.TrimSpace(strings.TrimPrefix(line, "⏺")),
+	}
+}
+
+func isTimelineError(line string) bool {
+	for _, prefix := range timelineErrorPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Interrupts returns the events where the user interrupted the agent.
+func (tl *Timeline) Interrupts() []TimelineEvent {
+	var out []TimelineEvent
+	for _, ev := range tl.Events {
+		if ev.Kind == TimelineInterrupt {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// FilesEdited returns the unique files touched by edit events, in order.
+func (tl *Timeline) FilesEdited() []string {
+	return tl.uniqueDetails(TimelineEdit)
+}
+
+// Commands returns the unique commands run by the agent, in order.
+func (tl *Timeline) Commands() []string {
+	return tl.uniqueDetails(TimelineCommand)
+}
+
+// Errors returns the events whose output indicates a failure.
+func (tl *Timeline) Errors() []TimelineEvent {
+	var errs []TimelineEvent
+	for _, ev := range tl.Events {
+		if ev.Error {
+			errs = append(errs, ev)
+		}
+	}
+	return errs
+}
+
+func (tl *Timeline) uniqueDetails(kind TimelineEventKind) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, ev := range tl.Events {
+		if ev.Kind != kind || ev.Detail == "" || seen[ev.Detail] {
+			continue
+		}
+		seen[ev.Detail] = true
+		out = append(out, ev.Detail)
+	}
+	return out
+}
+
+// SaveTimeline writes the timeline to path as JSON.
+func SaveTimeline(path string, tl *Timeline) error {
+	data, err := json.MarshalIndent(tl, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal timeline: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write timeline: %w", err)
+	}
+	return nil
+}
+
+// LoadTimeline reads a timeline previously written by SaveTimeline.
+func LoadTimeline(path string) (*Timeline, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from controlled agent directory
+	if err != nil {
+		return nil, err
+	}
+	var tl Timeline
+	if err := json.Unmarshal(data, &tl); err != nil {
+		return nil, fmt.Errorf("failed to parse timeline: %w", err)
+	}
+	return &tl, nil
+}
+
+// AppendTimelineEvent adds an event to the timeline stored at path, creating
+// it if needed.
+func AppendTimelineEvent(path string, ev TimelineEvent) error {
+	tl, err := LoadTimeline(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		tl = &Timeline{}
+	}
+	tl.Events = append(tl.Events, ev)
+	return SaveTimeline(path, tl)
+}
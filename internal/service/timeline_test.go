@@ -0,0 +1,142 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const sampleTranscript = `> add a flag
+
+⏺ I'll add the flag.
+
+⏺ Read(cmd/paw/main.go)
+  ⎿  Read 120 lines (ctrl+r to expand)
+
+⏺ Update(cmd/paw/main.go)
+  ⎿  Updated cmd/paw/main.go with 3 additions
+
+⏺ Bash(go test ./...)
+  ⎿  Error: exit status 1
+     FAIL github.com/dongho-jung/paw/cmd/paw
+
+⏺ Update(cmd/paw/main.go)
+  ⎿  Updated cmd/paw/main.go with 1 addition
+
+⏺ Bash(go test ./...)
+  ⎿  ok  github.com/dongho-jung/paw/cmd/paw
+
+⏺ PAW_DONE
+`
+
+func TestParseTimeline(t *testing.T) {
+	tl := ParseTimeline(sampleTranscript)
+
+	if len(tl.Events) != 7 {
+		t.Fatalf("expected 7 events, got %d: %+v", len(tl.Events), tl.Events)
+	}
+
+	wantKinds := []TimelineEventKind{
+		TimelineMessage, TimelineTool, TimelineEdit, TimelineCommand,
+		TimelineEdit, TimelineCommand, TimelineMessage,
+	}
+	for i, want := range wantKinds {
+		if tl.Events[i].Kind != want {
+			t.Errorf("event %d: kind = %q, want %q", i, tl.Events[i].Kind, want)
+		}
+	}
+
+	failed := tl.Events[3]
+	if !failed.Error {
+		t.Errorf("expected failed Bash event to be marked as error")
+	}
+	if len(failed.Output) != 2 {
+		t.Errorf("expected 2 output lines, got %d: %v", len(failed.Output), failed.Output)
+	}
+	if tl.Events[5].Error {
+		t.Errorf("expected passing Bash event not to be marked as error")
+	}
+}
+
+func TestTimelineSummaries(t *testing.T) {
+	tl := ParseTimeline(sampleTranscript)
+
+	files := tl.FilesEdited()
+	if len(files) != 1 || files[0] != "cmd/paw/main.go" {
+		t.Errorf("FilesEdited() = %v", files)
+	}
+
+	cmds := tl.Commands()
+	if len(cmds) != 1 || cmds[0] != "go test ./..." {
+		t.Errorf("Commands() = %v", cmds)
+	}
+
+	if errs := tl.Errors(); len(errs) != 1 {
+		t.Errorf("Errors() returned %d events, want 1", len(errs))
+	}
+}
+
+func TestParseTimelineEmpty(t *testing.T) {
+	tl := ParseTimeline("")
+	if len(tl.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(tl.Events))
+	}
+}
+
+func TestSaveLoadTimeline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".timeline.json")
+	tl := ParseTimeline(sampleTranscript)
+
+	if err := SaveTimeline(path, tl); err != nil {
+		t.Fatalf("SaveTimeline() error = %v", err)
+	}
+
+	loaded, err := LoadTimeline(path)
+	if err != nil {
+		t.Fatalf("LoadTimeline() error = %v", err)
+	}
+	if len(loaded.Events) != len(tl.Events) {
+		t.Errorf("loaded %d events, want %d", len(loaded.Events), len(tl.Events))
+	}
+}
+
+func TestParseTimelineInterrupt(t *testing.T) {
+	transcript := `⏺ Bash(npm run build)
+  ⎿  Running…
+  ⎿  Interrupted by user
+
+> use pnpm instead of npm
+
+⏺ Bash(pnpm build)
+  ⎿  done
+`
+	tl := ParseTimeline(transcript)
+
+	interrupts := tl.Interrupts()
+	if len(interrupts) != 1 {
+		t.Fatalf("Interrupts() returned %d events, want 1: %+v", len(interrupts), tl.Events)
+	}
+	if interrupts[0].Detail != "use pnpm instead of npm" {
+		t.Errorf("interrupt detail = %q, want the new direction", interrupts[0].Detail)
+	}
+	if got := tl.Commands(); len(got) != 2 {
+		t.Errorf("Commands() = %v, want both builds", got)
+	}
+}
+
+func TestAppendTimelineEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".timeline.json")
+
+	for _, detail := range []string{"first", "second"} {
+		if err := AppendTimelineEvent(path, TimelineEvent{Kind: TimelineInterrupt, Detail: detail}); err != nil {
+			t.Fatalf("AppendTimelineEvent() error = %v", err)
+		}
+	}
+
+	loaded, err := LoadTimeline(path)
+	if err != nil {
+		t.Fatalf("LoadTimeline() error = %v", err)
+	}
+	if len(loaded.Events) != 2 || loaded.Events[1].Detail != "second" {
+		t.Errorf("loaded events = %+v, want both interrupts", loaded.Events)
+	}
+}
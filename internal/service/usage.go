@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// transcriptMaxLineSize bounds a single transcript line (large tool results).
+const transcriptMaxLineSize = 16 * 1024 * 1024
+
+// TokenUsage counts the tokens used by agent messages.
+type TokenUsage struct {
+	Input         int64 `json:"input_tokens"`
+	Output        int64 `json:"output_tokens"`
+	CacheCreation int64 `json:"cache_creation_input_tokens"`
+	CacheRead     int64 `json:"cache_read_input_tokens"`
+}
+
+// Budgeted returns the tokens counted against the daily budget. Cache reads
+// are left out: they are billed at a fraction of the input price and would
+// dwarf everything else in long sessions.
+func (u TokenUsage) Budgeted() int64 {
+	return u.Input + u.Output + u.CacheCreation
+}
+
+func (u *TokenUsage) add(o TokenUsage) {
+	u.Input += o.Input
+	u.Output += o.Output
+	u.CacheCreation += o.CacheCreation
+	u.CacheRead += o.CacheRead
+}
+
+// UsageReport is a project's token usage for one day.
+type UsageReport struct {
+	Day    time.Time             // Local midnight of the day
+	Total  TokenUsage            // All sessions in the project
+	ByTask map[string]TokenUsage // Per task ("" for sessions outside task worktrees)
+}
+
+// transcriptLine is the part of a Claude Code transcript line PAW reads.
+type transcriptLine struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Cwd       string    `json:"cwd"`
+	Message   struct {
+		ID    string      `json:"id"`
+		Usage *TokenUsage `json:"usage"`
+	} `json:"message"`
+}
+
+// LoadDailyUsage sums the token usage recorded in Claude Code transcripts
+// (transcriptsDir, usually ~/.claude/projects) on the given day, for sessions
+// running in projectDir or in a task directory under agentsDir.
+func LoadDailyUsage(transcriptsDir, projectDir, agentsDir string, day time.Time) (*UsageReport, error) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+	report := &UsageReport{Day: start, ByTask: make(map[string]TokenUsage)}
+
+	files, err := filepath.Glob(filepath.Join(transcriptsDir, "*", "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, path := range files {
+		if info, err := os.Stat(path); err != nil || info.ModTime().Before(start) {
+			continue
+		}
+		if err := addTranscriptUsage(path, projectDir, agentsDir, start, end, seen, report); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	return report, nil
+}
+
+func addTranscriptUsage(path, projectDir, agentsDir string, start, end time.Time, seen map[string]bool, report *UsageReport) error {
+	f, err := os.Open(path) //nolint:gosec // G304: path is under the Claude transcripts directory
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), transcriptMaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(`"usage"`)) {
+			continue
+		}
+		var entry transcriptLine
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Type != "assistant" || entry.Message.Usage == nil {
+			continue
+		}
+		if entry.Timestamp.Before(start) || !entry.Timestamp.Before(end) {
+			continue
+		}
+		taskName, ok := usageOwner(entry.Cwd, projectDir, agentsDir)
+		if !ok {
+			continue
+		}
+		// A message is written once per content block with the same usage
+		if id := entry.Message.ID; id != "" {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+
+		report.Total.add(*entry.Message.Usage)
+		byTask := report.ByTask[taskName]
+		byTask.add(*entry.Message.Usage)
+		report.ByTask[taskName] = byTask
+	}
+	return scanner.Err()
+}
+
+// usageOwner returns the task a session directory belongs to ("" for the
+// project itself) and whether it belongs to the project at all.
+func usageOwner(cwd, projectDir, agentsDir string) (string, bool) {
+	if cwd == "" {
+		return "", false
+	}
+	if rel, ok := relWithin(agentsDir, cwd); ok {
+		if rel == "." {
+			return "", true
+		}
+		taskName, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		return taskName, true
+	}
+	if _, ok := relWithin(projectDir, cwd); ok {
+		return "", true
+	}
+	return "", false
+}
+
+// relWithin returns path relative to base if it is base or inside it.
+func relWithin(base, path string) (string, bool) {
+	if base == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// FormatTokens formats a token count compactly (e.g. 950, 12.3k, 4.1M).
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// budgetDayLayout is the format of the day stored in the budget override file.
+const budgetDayLayout = "2006-01-02"
+
+// SetBudgetOverride lets tasks start for the rest of day even when the daily
+// token budget is used up.
+func SetBudgetOverride(path string, day time.Time) error {
+	return os.WriteFile(path, []byte(day.Format(budgetDayLayout)+"\n"), 0644) //nolint:gosec // G306: read by paw
+}
+
+// ClearBudgetOverride removes the override. A missing file is not an error.
+func ClearBudgetOverride(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// BudgetOverridden reports whether the budget is overridden for now's day.
+func BudgetOverridden(path string, now time.Time) bool {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is in the paw directory
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == now.Format(budgetDayLayout)
+}
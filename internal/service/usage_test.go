@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func usageLine(id, cwd string, ts time.Time, input, output int) string {
+	return fmt.Sprintf(`{"type":"assistant","timestamp":%q,"cwd":%q,"message":{"id":%q,"usage":{"input_tokens":%d,"output_tokens":%d,"cache_creation_input_tokens":10,"cache_read_input_tokens":1000}}}`,
+		ts.Format(time.RFC3339), cwd, id, input, output)
+}
+
+func TestLoadDailyUsage(t *testing.T) {
+	transcripts := t.TempDir()
+	projectDir := "/work/app"
+	agentsDir := "/data/paw/app/agents"
+	day := time.Now()
+	yesterday := day.AddDate(0, 0, -1)
+
+	lines := []string{
+		`{"type":"user","timestamp":"` + day.Format(time.RFC3339) + `","cwd":"/work/app","message":{"content":"hi"}}`,
+		usageLine("m1", projectDir, day, 100, 50),
+		usageLine("m1", projectDir, day, 100, 50), // same message, next content block
+		usageLine("m2", filepath.Join(agentsDir, "fix-login", "src"), day, 200, 20),
+		usageLine("m3", filepath.Join(agentsDir, "fix-login"), day, 300, 30),
+		usageLine("m4", "/work/other", day, 999, 999),
+		usageLine("m5", projectDir, yesterday, 999, 999),
+	}
+	sessionDir := filepath.Join(transcripts, "-work-app")
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sessionDir, "s1.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := LoadDailyUsage(transcripts, projectDir, agentsDir, day)
+	if err != nil {
+		t.Fatalf("LoadDailyUsage() error = %v", err)
+	}
+
+	if report.Total.Input != 600 || report.Total.Output != 100 {
+		t.Errorf("Total = %+v, want input 600 and output 100", report.Total)
+	}
+	if got := report.Total.Budgeted(); got != 730 {
+		t.Errorf("Total.Budgeted() = %d, want 730", got)
+	}
+	if got := report.ByTask["fix-login"].Input; got != 500 {
+		t.Errorf("ByTask[fix-login].Input = %d, want 500", got)
+	}
+	if got := report.ByTask[""].Input; got != 100 {
+		t.Errorf("ByTask[\"\"].Input = %d, want 100", got)
+	}
+}
+
+func TestLoadDailyUsage_NoTranscripts(t *testing.T) {
+	report, err := LoadDailyUsage(filepath.Join(t.TempDir(), "missing"), "/work/app", "/data/agents", time.Now())
+	if err != nil {
+		t.Fatalf("LoadDailyUsage() error = %v", err)
+	}
+	if report.Total.Budgeted() != 0 {
+		t.Errorf("Total = %+v, want zero", report.Total)
+	}
+}
+
+func TestBudgetOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".budget-override")
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.Local)
+
+	if BudgetOverridden(path, now) {
+		t.Fatal("BudgetOverridden() = true without an override")
+	}
+	if err := SetBudgetOverride(path, now); err != nil {
+		t.Fatalf("SetBudgetOverride() error = %v", err)
+	}
+	if !BudgetOverridden(path, now) {
+		t.Error("BudgetOverridden() = false on the override day")
+	}
+	if BudgetOverridden(path, now.AddDate(0, 0, 1)) {
+		t.Error("BudgetOverridden() = true on the next day")
+	}
+	if err := ClearBudgetOverride(path); err != nil {
+		t.Fatalf("ClearBudgetOverride() error = %v", err)
+	}
+	if err := ClearBudgetOverride(path); err != nil {
+		t.Errorf("ClearBudgetOverride() on missing file error = %v", err)
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{950, "950"},
+		{12_345, "12.3k"},
+		{4_100_000, "4.1M"},
+	}
+	for _, tt := range tests {
+		if got := FormatTokens(tt.n); got != tt.want {
+			t.Errorf("FormatTokens(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/tmux"
 )
 
 // UpdateWindowMap records the mapping between a window token and full task name.
@@ -58,3 +61,83 @@ func LoadWindowMap(pawDir string) (map[string]string, error) {
 	}
 	return mapping, nil
 }
+
+// RebuildWindowMap rewrites the window map from the session's live windows
+// and the tasks' tab-locks, dropping entries for tasks that no longer exist.
+// windows may be nil when the session isn't running. Returns the new map.
+func RebuildWindowMap(pawDir string, windows []tmux.Window) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, name := range listAgentTasks(pawDir) {
+		mapping[constants.TruncateForWindowName(name)] = name
+	}
+
+	// A window's tab-lock says which task it belongs to, even when several
+	// task names truncate to the same token
+	windowTasks := LoadWindowTasks(pawDir)
+	for _, w := range windows {
+		token, _ := parseWindowName(w.Name)
+		if token == "" {
+			continue
+		}
+		if name, ok := windowTasks[w.ID]; ok && constants.TruncateForWindowName(name) == token {
+			mapping[token] = name
+		}
+	}
+
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal window map: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(pawDir, constants.WindowMapFileName), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write window map: %w", err)
+	}
+	return mapping, nil
+}
+
+// LoadWindowTasks maps tmux window IDs to task names from the tasks'
+// tab-locks (the window ID recorded when the task's window was created).
+func LoadWindowTasks(pawDir string) map[string]string {
+	windowTasks := map[string]string{}
+	for _, name := range listAgentTasks(pawDir) {
+		path := filepath.Join(pawDir, constants.AgentsDirName, name, constants.TabLockDirName, constants.WindowIDFileName)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed from pawDir
+		if err != nil {
+			continue
+		}
+		if windowID := strings.TrimSpace(string(data)); windowID != "" {
+			windowTasks[windowID] = name
+		}
+	}
+	return windowTasks
+}
+
+// listAgentTasks returns the names of the tasks with an agent directory.
+func listAgentTasks(pawDir string) []string {
+	if pawDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(filepath.Join(pawDir, constants.AgentsDirName))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// consistentWindowMapEntry reports whether a window map entry still points
+// at an existing task with that token. Entries can go stale after crashes.
+func consistentWindowMapEntry(pawDir, token, name string) bool {
+	if constants.TruncateForWindowName(name) != token {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(pawDir, constants.AgentsDirName, name)); err != nil {
+		logging.Debug("Window map entry %s -> %s is stale, using a live scan", token, name)
+		return false
+	}
+	return true
+}
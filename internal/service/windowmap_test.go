@@ -0,0 +1,87 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// writeAgentTask creates a task's agent directory, with its tab-lock window ID if set.
+func writeAgentTask(t *testing.T, pawDir, name, windowID string) {
+	t.Helper()
+	lockDir := filepath.Join(pawDir, constants.AgentsDirName, name, constants.TabLockDirName)
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if windowID != "" {
+		if err := os.WriteFile(filepath.Join(lockDir, constants.WindowIDFileName), []byte(windowID+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRebuildWindowMap(t *testing.T) {
+	pawDir := t.TempDir()
+	// Both names truncate to the same window token
+	first, second := "refactor-authentication-flow", "refactor-authentication-tests"
+	token := constants.TruncateForWindowName(first)
+	if constants.TruncateForWindowName(second) != token {
+		t.Fatalf("test names should share a token")
+	}
+	writeAgentTask(t, pawDir, first, "@3")
+	writeAgentTask(t, pawDir, second, "@7")
+	writeAgentTask(t, pawDir, "fix-login", "")
+	if _, err := UpdateWindowMap(pawDir, "removed-task"); err != nil {
+		t.Fatal(err)
+	}
+
+	mapping, err := RebuildWindowMap(pawDir, []tmux.Window{
+		{ID: "@7", Name: constants.EmojiWorking + token},
+		{ID: "@1", Name: constants.NewWindowName},
+	})
+	if err != nil {
+		t.Fatalf("RebuildWindowMap() error: %v", err)
+	}
+	if mapping[token] != second {
+		t.Errorf("map[%s] = %q, want %q (the task whose tab-lock owns the live window)", token, mapping[token], second)
+	}
+	if mapping["fixLogin"] != "fix-login" {
+		t.Errorf("map[fixLogin] = %q, want fix-login", mapping["fixLogin"])
+	}
+	if _, ok := mapping[constants.TruncateForWindowName("removed-task")]; ok {
+		t.Error("rebuilt map should drop tasks that no longer exist")
+	}
+
+	loaded, err := LoadWindowMap(pawDir)
+	if err != nil || len(loaded) != len(mapping) {
+		t.Errorf("LoadWindowMap() = %v, %v; want the rebuilt map", loaded, err)
+	}
+}
+
+func TestResolveWindowTaskFallsBackToLiveScan(t *testing.T) {
+	pawDir := t.TempDir()
+	first, second := "refactor-authentication-flow", "refactor-authentication-tests"
+	token := constants.TruncateForWindowName(first)
+	writeAgentTask(t, pawDir, first, "@3")
+	writeAgentTask(t, pawDir, second, "@7")
+	// Map entry left behind by a task that was removed after a crash
+	if _, err := UpdateWindowMap(pawDir, "stale-task-name"); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenMap := buildTokenMap(pawDir)
+	if got := resolveTaskName("staleTaskName", tokenMap); got != "staleTaskName" {
+		t.Errorf("stale map entry resolved to %q, want it ignored", got)
+	}
+
+	windowTasks := LoadWindowTasks(pawDir)
+	if got := resolveWindowTask(token, "@3", tokenMap, windowTasks); got != first {
+		t.Errorf("resolveWindowTask(@3) = %q, want %q", got, first)
+	}
+	if got := resolveWindowTask(token, "@7", tokenMap, windowTasks); got != second {
+		t.Errorf("resolveWindowTask(@7) = %q, want %q", got, second)
+	}
+}
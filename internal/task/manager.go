@@ -60,6 +60,12 @@ func (m *Manager) shouldUseWorktree() bool {
 	return m.isGitRepo
 }
 
+// usesWorktree returns true if the given task runs in its own worktree.
+// Research tasks run read-only in the project directory.
+func (m *Manager) usesWorktree(task *Task) bool {
+	return m.shouldUseWorktree() && !task.IsResearch()
+}
+
 func (m *Manager) preferredWorktreeDir(task *Task) string {
 	return filepath.Join(task.AgentDir, m.projectWorktreeName())
 }
@@ -236,7 +242,7 @@ func (m *Manager) createTaskDirectory(baseName string) (string, error) {
 func (m *Manager) GetTask(name string) (*Task, error) {
 	agentDir := filepath.Join(m.agentsDir, name)
 	if _, err := os.Stat(agentDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("task not found: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, name)
 	}
 
 	task := New(name, agentDir)
@@ -255,7 +261,7 @@ func (m *Manager) GetTask(name string) (*Task, error) {
 	_, _ = task.LoadPRNumber()
 
 	// Set worktree directory
-	if m.shouldUseWorktree() {
+	if m.usesWorktree(task) {
 		task.WorktreeDir = m.resolveWorktreeDir(task)
 	}
 
@@ -80,6 +80,12 @@ func (m *Manager) FindIncompleteTasks(_ string) ([]*Task, error) {
 			continue
 		}
 
+		// Skip if end-task finished the task but its cleanup was interrupted;
+		// the cleanup is resumed instead of reopening the task
+		if task.IsCompleted() {
+			continue
+		}
+
 		// Skip if task is merged
 		if mainBranch != "" && m.isTaskMerged(task, mainBranch) {
 			continue
@@ -131,6 +137,9 @@ func (m *Manager) FindCorruptedTasks() ([]*Task, error) {
 
 	var corrupted []*Task
 	for _, task := range tasks {
+		if task.IsResearch() {
+			continue // Research tasks have no worktree or branch
+		}
 		reason := m.checkWorktreeStatus(task)
 		if reason != "" {
 			task.Status = StatusCorrupted
@@ -157,6 +166,9 @@ func (m *Manager) FindMergedTasks() ([]*Task, error) {
 
 	var merged []*Task
 	for _, task := range tasks {
+		if task.IsResearch() {
+			continue // No branch to merge; finished via Ctrl+F
+		}
 		if m.isTaskMerged(task, mainBranch) {
 			task.Status = StatusDone
 			merged = append(merged, task)
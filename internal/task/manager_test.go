@@ -59,6 +59,43 @@ func TestFindMergedTasks_ExternallyCleanedUp(t *testing.T) {
 	}
 }
 
+func TestResearchTaskSkipsWorktree(t *testing.T) {
+	tempDir := t.TempDir()
+	agentsDir := filepath.Join(tempDir, ".paw", "agents")
+	taskDir := filepath.Join(agentsDir, "research-task")
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		t.Fatalf("Failed to create task dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(taskDir, "task"), []byte("investigate X"), 0644); err != nil {
+		t.Fatalf("Failed to create task file: %v", err)
+	}
+	if err := (&config.TaskOptions{Research: true}).Save(taskDir); err != nil {
+		t.Fatalf("Failed to save task options: %v", err)
+	}
+
+	mgr := NewManager(agentsDir, tempDir, filepath.Join(tempDir, ".paw"), true, &config.Config{})
+
+	task, err := mgr.GetTask("research-task")
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if task.WorktreeDir != "" {
+		t.Errorf("Expected no worktree dir for research task, got %s", task.WorktreeDir)
+	}
+	if got := mgr.GetWorkingDirectory(task); got != tempDir {
+		t.Errorf("GetWorkingDirectory() = %s, want project dir %s", got, tempDir)
+	}
+
+	// Research tasks have no branch, so they must not look externally merged
+	merged, err := mgr.FindMergedTasks()
+	if err != nil {
+		t.Fatalf("FindMergedTasks failed: %v", err)
+	}
+	if len(merged) != 0 {
+		t.Errorf("Expected research task not to be reported as merged, got %d", len(merged))
+	}
+}
+
 func TestFindTaskByTruncatedName(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "paw-task-*")
 	if err != nil {
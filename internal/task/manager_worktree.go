@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
 )
@@ -76,30 +77,14 @@ func (m *Manager) checkWorktreeStatus(task *Task) CorruptedReason {
 
 // CleanupTask cleans up a task's resources.
 func (m *Manager) CleanupTask(task *Task) error {
-	if m.shouldUseWorktree() {
-		worktreeDir := task.GetWorktreeDir()
-
-		// Remove worktree
-		if _, err := os.Stat(worktreeDir); err == nil {
-			if err := m.gitClient.WorktreeRemove(m.projectDir, worktreeDir, true); err != nil {
-				logging.Trace("WorktreeRemove failed, trying force remove: %v", err)
-				// Try force remove if normal remove fails
-				if removeErr := os.RemoveAll(worktreeDir); removeErr != nil {
-					logging.Warn("Force remove worktree failed: %v", removeErr)
-				}
-			}
-		}
-
-		// Prune worktrees (error is non-fatal)
-		if err := m.gitClient.WorktreePrune(m.projectDir); err != nil {
-			logging.Trace("WorktreePrune failed: %v", err)
-		}
+	return m.cleanupTask(task, false)
+}
 
-		// Delete branch (error is non-fatal)
-		if m.gitClient.BranchExists(m.projectDir, task.Name) {
-			if err := m.gitClient.BranchDelete(m.projectDir, task.Name, true); err != nil {
-				logging.Trace("BranchDelete failed: %v", err)
-			}
+func (m *Manager) cleanupTask(task *Task, keepBranch bool) error {
+	if m.usesWorktree(task) {
+		m.RemoveWorktree(task)
+		if !keepBranch {
+			m.DeleteBranch(task)
 		}
 	}
 
@@ -112,6 +97,37 @@ func (m *Manager) CleanupTask(task *Task) error {
 	return err
 }
 
+// RemoveWorktree removes a task's worktree and prunes stale worktree entries.
+// Errors are non-fatal.
+func (m *Manager) RemoveWorktree(task *Task) {
+	worktreeDir := task.GetWorktreeDir()
+
+	// Remove worktree
+	if _, err := os.Stat(worktreeDir); err == nil {
+		if err := m.gitClient.WorktreeRemove(m.projectDir, worktreeDir, true); err != nil {
+			logging.Trace("WorktreeRemove failed, trying force remove: %v", err)
+			// Try force remove if normal remove fails
+			if removeErr := os.RemoveAll(worktreeDir); removeErr != nil {
+				logging.Warn("Force remove worktree failed: %v", removeErr)
+			}
+		}
+	}
+
+	// Prune worktrees (error is non-fatal)
+	if err := m.gitClient.WorktreePrune(m.projectDir); err != nil {
+		logging.Trace("WorktreePrune failed: %v", err)
+	}
+}
+
+// DeleteBranch force-deletes a task's branch if it exists. Errors are non-fatal.
+func (m *Manager) DeleteBranch(task *Task) {
+	if m.gitClient.BranchExists(m.projectDir, task.Name) {
+		if err := m.gitClient.BranchDelete(m.projectDir, task.Name, true); err != nil {
+			logging.Trace("BranchDelete failed: %v", err)
+		}
+	}
+}
+
 // PruneWorktrees removes stale worktree entries from git's database.
 // This should be called before any git operations to prevent errors
 // when worktree directories have been deleted but git still references them.
@@ -127,31 +143,61 @@ func (m *Manager) PruneWorktrees() {
 
 // SetupWorktree creates a git worktree for the task.
 func (m *Manager) SetupWorktree(task *Task) error {
-	if !m.shouldUseWorktree() {
+	return m.setupWorktree(task, "")
+}
+
+// SetupStackedWorktree creates a git worktree for the task on a new branch
+// off the parent task's branch. The project's uncommitted changes stay out
+// of it: the parent's worktree already got them.
+func (m *Manager) SetupStackedWorktree(task *Task, parent string) error {
+	if !m.gitClient.BranchExists(m.projectDir, parent) {
+		return fmt.Errorf("parent task branch %s does not exist", parent)
+	}
+	return m.setupWorktree(task, parent)
+}
+
+func (m *Manager) setupWorktree(task *Task, parent string) error {
+	if !m.usesWorktree(task) {
 		return nil
 	}
 
 	worktreeDir := task.GetWorktreeDir()
 	task.WorktreeDir = worktreeDir
 
-	// Stash any uncommitted changes (error is non-fatal)
-	stashHash, err := m.gitClient.StashCreate(m.projectDir)
-	if err != nil {
-		logging.Warn("SetupWorktree: stash create failed: %v", err)
-		stashHash = ""
+	var stashHash string
+	var untrackedFiles []string
+	if parent == "" {
+		// Stash any uncommitted changes (error is non-fatal)
+		var err error
+		stashHash, err = m.gitClient.StashCreate(m.projectDir)
+		if err != nil {
+			logging.Warn("SetupWorktree: stash create failed: %v", err)
+			stashHash = ""
+		}
+
+		// Get untracked files (error is non-fatal)
+		untrackedFiles, err = m.gitClient.GetUntrackedFiles(m.projectDir)
+		if err != nil {
+			logging.Warn("SetupWorktree: failed to list untracked files: %v", err)
+			untrackedFiles = nil
+		}
 	}
 
-	// Get untracked files (error is non-fatal)
-	untrackedFiles, err := m.gitClient.GetUntrackedFiles(m.projectDir)
-	if err != nil {
-		logging.Warn("SetupWorktree: failed to list untracked files: %v", err)
-		untrackedFiles = nil
+	// Fail before creating anything if the checkout would not fit
+	if err := m.checkDiskSpace(worktreeDir, untrackedFiles); err != nil {
+		return err
 	}
 
 	// Create worktree with new branch
-	if err := m.gitClient.WorktreeAdd(m.projectDir, worktreeDir, task.Name, true); err != nil {
+	if parent != "" {
+		if err := m.gitClient.WorktreeAddFrom(m.projectDir, worktreeDir, task.Name, parent); err != nil {
+			return fmt.Errorf("failed to create worktree on %s: %w", parent, err)
+		}
+		logging.Debug("SetupWorktree: stacked %s on %s", task.Name, parent)
+	} else if err := m.gitClient.WorktreeAdd(m.projectDir, worktreeDir, task.Name, true); err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
+	updateSubmodules(m.gitClient, worktreeDir)
 
 	// Apply stash to worktree if there were changes (error is non-fatal)
 	if stashHash != "" {
@@ -169,9 +215,12 @@ func (m *Manager) SetupWorktree(task *Task) error {
 
 	// Create .claude symlink in agent directory (outside worktree, avoids git tracking)
 	// Claude Code searches parent directories, so it will find .claude in AgentDir
+	// With link_mode: copy, handle-task copies .claude into the agent directory instead
 	claudeLink := filepath.Join(filepath.Dir(worktreeDir), constants.ClaudeLink)
 	claudeTarget := filepath.Join(m.pawDir, constants.ClaudeLink)
-	if err := os.Symlink(claudeTarget, claudeLink); err != nil && !os.IsExist(err) {
+	if m.config.LinkMode == constants.LinkModeCopy {
+		logging.Debug("SetupWorktree: link_mode is copy; skipping .claude symlink")
+	} else if err := os.Symlink(claudeTarget, claudeLink); err != nil && !os.IsExist(err) {
 		logging.Warn("SetupWorktree: failed to create claude symlink: %v", err)
 	} else {
 		logging.Debug("SetupWorktree: created .claude symlink in agent directory (outside git)")
@@ -185,6 +234,58 @@ func (m *Manager) SetupWorktree(task *Task) error {
 	return nil
 }
 
+// updateSubmodules checks out the submodules of a new worktree, which
+// 'git worktree add' leaves empty. Failures are logged, not fatal.
+func updateSubmodules(gitClient git.Client, worktreeDir string) {
+	if _, err := os.Stat(filepath.Join(worktreeDir, ".gitmodules")); err != nil {
+		return
+	}
+	if err := gitClient.SubmoduleUpdate(worktreeDir); err != nil {
+		logging.Warn("Failed to update submodules in %s: %v", worktreeDir, err)
+		return
+	}
+	logging.Debug("Updated submodules in %s", worktreeDir)
+}
+
+// checkDiskSpace returns an error if the filesystem holding worktreeDir lacks the space or
+// inodes for a checkout of HEAD plus the copied untracked files, keeping min_free_disk_mb free.
+// Errors while measuring are logged and do not block task creation.
+func (m *Manager) checkDiskSpace(worktreeDir string, untrackedFiles []string) error {
+	size, files, err := m.gitClient.TreeSize(m.projectDir, "HEAD")
+	if err != nil {
+		logging.Debug("checkDiskSpace: failed to measure checkout size: %v", err)
+		return nil
+	}
+	for _, f := range untrackedFiles {
+		if info, err := os.Lstat(filepath.Join(m.projectDir, f)); err == nil {
+			size += info.Size()
+			files++
+		}
+	}
+
+	space, err := fileutil.FreeDiskSpace(worktreeDir)
+	if err != nil {
+		logging.Debug("checkDiskSpace: failed to read free space: %v", err)
+		return nil
+	}
+
+	const mb = 1024 * 1024
+	margin := int64(m.config.MinFreeDiskMB) * mb
+	need := size + margin
+	logging.Debug("checkDiskSpace: checkout=%d bytes (%d files), margin=%d bytes, free=%d bytes (%d inodes)",
+		size, files, margin, space.FreeBytes, space.FreeInodes)
+
+	if space.FreeBytes < uint64(need) { //nolint:gosec // G115: need is never negative
+		return fmt.Errorf("not enough disk space for worktree in %s: %d MB free, need %d MB (%d MB checkout + %d MB min_free_disk_mb)",
+			filepath.Dir(worktreeDir), space.FreeBytes/mb, (need+mb-1)/mb, (size+mb-1)/mb, m.config.MinFreeDiskMB)
+	}
+	if space.TotalInodes > 0 && space.FreeInodes < uint64(files) { //nolint:gosec // G115: files is never negative
+		return fmt.Errorf("not enough free inodes for worktree in %s: %d free, need %d",
+			filepath.Dir(worktreeDir), space.FreeInodes, files)
+	}
+	return nil
+}
+
 // executePreWorktreeHook runs the configured pre-worktree hook in the given directory.
 func (m *Manager) executePreWorktreeHook(worktreeDir string) {
 	hook := m.config.PreWorktreeHook
@@ -208,9 +309,9 @@ func (m *Manager) executePreWorktreeHook(worktreeDir string) {
 
 // GetWorkingDirectory returns the working directory for a task.
 // For worktree mode: returns the worktree directory (git worktree)
-// For non-worktree mode: returns the project directory (shared workspace)
+// For non-worktree mode and research tasks: returns the project directory (shared workspace)
 func (m *Manager) GetWorkingDirectory(task *Task) string {
-	if m.shouldUseWorktree() {
+	if m.usesWorktree(task) {
 		return task.GetWorktreeDir()
 	}
 	// Non-worktree mode: Claude runs in the project directory.
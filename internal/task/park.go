@@ -0,0 +1,187 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// ParkedTask is a finished task whose agent directory and worktree were
+// kept for debugging by cleanup_policy.
+type ParkedTask struct {
+	Name     string
+	Dir      string
+	ParkedAt time.Time
+}
+
+// CompleteTask cleans up a finished task according to cleanup_policy: with
+// the delayed or manual policy, its agent directory and worktree are parked
+// instead of removed. The branch is deleted unless keepBranch is set.
+func (m *Manager) CompleteTask(task *Task, keepBranch bool) error {
+	if m.cleanupPolicy() == constants.CleanupImmediate || !m.usesWorktree(task) {
+		return m.cleanupTask(task, keepBranch)
+	}
+	if _, err := os.Stat(task.GetWorktreeDir()); err != nil {
+		return m.cleanupTask(task, keepBranch)
+	}
+
+	if _, err := m.ParkTask(task); err != nil {
+		logging.Warn("Failed to park task %s, removing it: %v", task.Name, err)
+		return m.cleanupTask(task, keepBranch)
+	}
+	if !keepBranch {
+		m.DeleteBranch(task)
+	}
+	m.InvalidateTruncatedNameCache()
+	return nil
+}
+
+// FinishCompletion runs the cleanup a completion marker records, for a task
+// whose cleanup was interrupted after end-task finished it.
+func (m *Manager) FinishCompletion(task *Task, c Completion) error {
+	if c.Drop {
+		return m.CleanupTask(task)
+	}
+	return m.CompleteTask(task, c.KeepBranch)
+}
+
+// ParkTask moves a task's agent directory, worktree included, to the parked
+// directory. The worktree is detached from the task branch so the branch
+// can still be deleted.
+func (m *Manager) ParkTask(task *Task) (*ParkedTask, error) {
+	worktreeDir := task.GetWorktreeDir()
+	rel, err := filepath.Rel(task.AgentDir, worktreeDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("worktree %s is outside the agent directory", worktreeDir)
+	}
+	if err := m.gitClient.CheckoutDetach(worktreeDir); err != nil {
+		return nil, fmt.Errorf("failed to detach worktree: %w", err)
+	}
+
+	now := time.Now()
+	parked := &ParkedTask{
+		Name:     task.Name,
+		Dir:      filepath.Join(m.ParkedDir(), task.Name+"-"+now.Format("20060102-150405")),
+		ParkedAt: now,
+	}
+	if err := os.MkdirAll(m.ParkedDir(), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return nil, fmt.Errorf("failed to create parked directory: %w", err)
+	}
+	_ = task.RemoveTabLock()
+	if err := os.Rename(task.AgentDir, parked.Dir); err != nil {
+		return nil, fmt.Errorf("failed to move agent directory: %w", err)
+	}
+	if err := m.gitClient.WorktreeRepair(m.projectDir, filepath.Join(parked.Dir, rel)); err != nil {
+		logging.Warn("Failed to repair parked worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(parked.Dir, constants.ParkedAtFileName), []byte(now.Format(time.RFC3339)), 0644); err != nil { //nolint:gosec // G306: parked files are readable by design
+		logging.Warn("Failed to write parked time: %v", err)
+	}
+	logging.Info("Parked task %s in %s", task.Name, parked.Dir)
+	return parked, nil
+}
+
+// ParkedDir returns the directory holding parked tasks.
+func (m *Manager) ParkedDir() string {
+	return filepath.Join(filepath.Dir(m.agentsDir), constants.ParkedDirName)
+}
+
+// ListParkedTasks returns the parked tasks, oldest first.
+func (m *Manager) ListParkedTasks() ([]*ParkedTask, error) {
+	entries, err := os.ReadDir(m.ParkedDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var parked []*ParkedTask
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		p := &ParkedTask{Name: entry.Name(), Dir: filepath.Join(m.ParkedDir(), entry.Name())}
+		if data, err := os.ReadFile(filepath.Join(p.Dir, constants.ParkedAtFileName)); err == nil { //nolint:gosec // G304: path is constructed from the parked directory
+			p.ParkedAt, _ = time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+		}
+		if p.ParkedAt.IsZero() {
+			if info, err := entry.Info(); err == nil {
+				p.ParkedAt = info.ModTime()
+			}
+		}
+		// Directory names carry the parking time after the task name
+		if i := strings.LastIndex(p.Name, "-"); i > 0 {
+			if j := strings.LastIndex(p.Name[:i], "-"); j > 0 {
+				if _, err := time.Parse("20060102-150405", p.Name[j+1:]); err == nil {
+					p.Name = p.Name[:j]
+				}
+			}
+		}
+		parked = append(parked, p)
+	}
+	sort.Slice(parked, func(i, j int) bool { return parked[i].ParkedAt.Before(parked[j].ParkedAt) })
+	return parked, nil
+}
+
+// RemoveParkedTask deletes a parked task and its worktree.
+func (m *Manager) RemoveParkedTask(p *ParkedTask) error {
+	entries, _ := os.ReadDir(p.Dir)
+	for _, entry := range entries {
+		dir := filepath.Join(p.Dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, ".git")); entry.IsDir() && err == nil {
+			if err := m.gitClient.WorktreeRemove(m.projectDir, dir, true); err != nil {
+				logging.Trace("WorktreeRemove of parked worktree failed: %v", err)
+			}
+		}
+	}
+	if err := os.RemoveAll(p.Dir); err != nil {
+		return err
+	}
+	if err := m.gitClient.WorktreePrune(m.projectDir); err != nil {
+		logging.Trace("WorktreePrune failed: %v", err)
+	}
+	return nil
+}
+
+// PruneParkedTasks removes the parked tasks older than
+// cleanup_retention_days. It does nothing unless cleanup_policy is delayed.
+func (m *Manager) PruneParkedTasks(now time.Time) ([]*ParkedTask, error) {
+	if m.cleanupPolicy() != constants.CleanupDelayed {
+		return nil, nil
+	}
+	days := constants.DefaultCleanupRetentionDays
+	if m.config != nil && m.config.CleanupRetentionDays > 0 {
+		days = m.config.CleanupRetentionDays
+	}
+
+	parked, err := m.ListParkedTasks()
+	if err != nil {
+		return nil, err
+	}
+	var pruned []*ParkedTask
+	for _, p := range parked {
+		if now.Sub(p.ParkedAt) < time.Duration(days)*24*time.Hour {
+			continue
+		}
+		if err := m.RemoveParkedTask(p); err != nil {
+			logging.Warn("Failed to remove parked task %s: %v", p.Name, err)
+			continue
+		}
+		pruned = append(pruned, p)
+	}
+	return pruned, nil
+}
+
+func (m *Manager) cleanupPolicy() string {
+	if m.config == nil || m.config.CleanupPolicy == "" {
+		return constants.CleanupImmediate
+	}
+	return m.config.CleanupPolicy
+}
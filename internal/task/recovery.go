@@ -76,6 +76,7 @@ func (r *RecoveryManager) recoverMissingWorktree(task *Task) error {
 	if err := r.gitClient.WorktreeAdd(r.projectDir, worktreeDir, task.Name, false); err != nil {
 		return fmt.Errorf("failed to recreate worktree: %w", err)
 	}
+	updateSubmodules(r.gitClient, worktreeDir)
 
 	return nil
 }
@@ -100,6 +101,7 @@ func (r *RecoveryManager) recoverNotInGit(task *Task) error {
 	if err := r.gitClient.WorktreeAdd(r.projectDir, worktreeDir, task.Name, createBranch); err != nil {
 		return fmt.Errorf("failed to recreate worktree: %w", err)
 	}
+	updateSubmodules(r.gitClient, worktreeDir)
 
 	return nil
 }
@@ -131,6 +133,7 @@ func (r *RecoveryManager) recoverInvalidGit(task *Task) error {
 		}
 		return fmt.Errorf("failed to recreate worktree: %w", err)
 	}
+	updateSubmodules(r.gitClient, worktreeDir)
 
 	// Copy files from backup (excluding .git)
 	if err := copyDirContents(backupDir, worktreeDir, []string{".git"}); err != nil {
@@ -2,6 +2,7 @@
 package task
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/fileutil"
 )
@@ -143,6 +145,16 @@ func (t *Task) GetSessionMarkerPath() string {
 	return filepath.Join(t.AgentDir, constants.SessionStartedFile)
 }
 
+// GetResumeMarkerPath returns the path to the resume marker file.
+func (t *Task) GetResumeMarkerPath() string {
+	return filepath.Join(t.AgentDir, constants.ResumeMarkerFile)
+}
+
+// GetCompletedMarkerPath returns the path to the completion marker file.
+func (t *Task) GetCompletedMarkerPath() string {
+	return filepath.Join(t.AgentDir, constants.CompletedMarkerFile)
+}
+
 // GetHookOutputPath returns the output path for a named hook.
 func (t *Task) GetHookOutputPath(name string) string {
 	return filepath.Join(t.AgentDir, fmt.Sprintf(".hook-%s.log", name))
@@ -163,6 +175,163 @@ func (t *Task) GetVerifyMetaPath() string {
 	return filepath.Join(t.AgentDir, constants.VerifyJSONFile)
 }
 
+// GetTimelinePath returns the path to the tool-call timeline file.
+func (t *Task) GetTimelinePath() string {
+	return filepath.Join(t.AgentDir, constants.TimelineFileName)
+}
+
+// GetFailurePath returns the path to the failure classification file.
+func (t *Task) GetFailurePath() string {
+	return filepath.Join(t.AgentDir, constants.FailureFileName)
+}
+
+// GetReviewPath returns the path to the review flag file.
+func (t *Task) GetReviewPath() string {
+	return filepath.Join(t.AgentDir, constants.ReviewFileName)
+}
+
+// SetReviewReason flags the task for human review, with the reason.
+func (t *Task) SetReviewReason(reason string) error {
+	return fileutil.WriteFileAtomic(t.GetReviewPath(), []byte(reason), 0644)
+}
+
+// ReviewReason returns why the task needs human review, or "" if it doesn't.
+func (t *Task) ReviewReason() string {
+	data, err := os.ReadFile(t.GetReviewPath())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// ClearReviewReason removes the review flag.
+func (t *Task) ClearReviewReason() error {
+	if err := os.Remove(t.GetReviewPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetResearchAnswerPath returns the path where a research task writes its answer.
+func (t *Task) GetResearchAnswerPath() string {
+	return filepath.Join(t.AgentDir, constants.ResearchAnswerFile)
+}
+
+// GetArtifactsDir returns the directory where the agent writes reports and build outputs.
+func (t *Task) GetArtifactsDir() string {
+	return filepath.Join(t.AgentDir, constants.ArtifactsDirName)
+}
+
+// GetApprovalPath returns the path to the pending command approval request.
+func (t *Task) GetApprovalPath() string {
+	return filepath.Join(t.AgentDir, constants.ApprovalFileName)
+}
+
+// GetPatchProposalPath returns the path to the pending pair-mode patch proposal.
+func (t *Task) GetPatchProposalPath() string {
+	return filepath.Join(t.AgentDir, constants.PatchProposalFileName)
+}
+
+// GetPairTreePath returns the path to the file holding the git tree of a
+// pair task's files as last approved.
+func (t *Task) GetPairTreePath() string {
+	return filepath.Join(t.AgentDir, constants.PairTreeFileName)
+}
+
+// GetSnoozePath returns the path to the snooze deadline file.
+func (t *Task) GetSnoozePath() string {
+	return filepath.Join(t.AgentDir, constants.SnoozeFileName)
+}
+
+// Snooze suppresses the task's notifications until the given time.
+func (t *Task) Snooze(until time.Time) error {
+	return fileutil.WriteFileAtomic(t.GetSnoozePath(), []byte(until.UTC().Format(time.RFC3339)), 0644)
+}
+
+// SnoozedUntil returns the snooze deadline, or the zero time if the task
+// is not snoozed. Expired snoozes are reported as-is; callers compare with now.
+func (t *Task) SnoozedUntil() time.Time {
+	data, err := os.ReadFile(t.GetSnoozePath())
+	if err != nil {
+		return time.Time{}
+	}
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}
+	}
+	return until
+}
+
+// IsSnoozed returns true if the task is snoozed at the given time.
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return now.Before(t.SnoozedUntil())
+}
+
+// ClearSnooze removes the snooze deadline.
+func (t *Task) ClearSnooze() error {
+	if err := os.Remove(t.GetSnoozePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetQuestionPath returns the path to the pending question file.
+func (t *Task) GetQuestionPath() string {
+	return filepath.Join(t.AgentDir, constants.QuestionFileName)
+}
+
+// SetQuestion records the question the agent is waiting on.
+func (t *Task) SetQuestion(question string) error {
+	return fileutil.WriteFileAtomic(t.GetQuestionPath(), []byte(question), 0644)
+}
+
+// Question returns the question the agent is waiting on, or "" if none.
+func (t *Task) Question() string {
+	data, err := os.ReadFile(t.GetQuestionPath())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// ClearQuestion removes the pending question.
+func (t *Task) ClearQuestion() error {
+	if err := os.Remove(t.GetQuestionPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetAutoAnswersPath returns the path to the log of auto-answers given to the task.
+func (t *Task) GetAutoAnswersPath() string {
+	return filepath.Join(t.AgentDir, constants.AutoAnswersFileName)
+}
+
+// GetApprovalShimDir returns the directory of PATH shims for commands that need approval.
+func (t *Task) GetApprovalShimDir() string {
+	return filepath.Join(t.AgentDir, constants.ApprovalShimDirName)
+}
+
+// IsPair returns true if the task is a pair task (the agent proposes patches
+// and the user applies them).
+func (t *Task) IsPair() bool {
+	opts, err := config.LoadTaskOptions(t.AgentDir)
+	return err == nil && opts.Pair
+}
+
+// IsAutopilot returns true if an autopilot run started the task.
+func (t *Task) IsAutopilot() bool {
+	opts, err := config.LoadTaskOptions(t.AgentDir)
+	return err == nil && opts.Autopilot != ""
+}
+
+// IsResearch returns true if the task is a read-only research task
+// (no worktree or branch; the answer is saved to history).
+func (t *Task) IsResearch() bool {
+	opts, err := config.LoadTaskOptions(t.AgentDir)
+	return err == nil && opts.Research
+}
+
 // GetStatusFilePath returns the path to the status file.
 func (t *Task) GetStatusFilePath() string {
 	return filepath.Join(t.AgentDir, constants.StatusFileName)
@@ -271,6 +440,57 @@ func (t *Task) CreateSessionMarker() error {
 	return fileutil.WriteFileAtomic(t.GetSessionMarkerPath(), []byte(time.Now().Format(time.RFC3339)), 0644)
 }
 
+// CreateResumeMarker records that the agent was interrupted mid-step so the
+// next session tells it to continue.
+func (t *Task) CreateResumeMarker() error {
+	return fileutil.WriteFileAtomic(t.GetResumeMarkerPath(), []byte(time.Now().Format(time.RFC3339)), 0644)
+}
+
+// TakeResumeMarker removes the resume marker and reports whether it existed.
+func (t *Task) TakeResumeMarker() bool {
+	return os.Remove(t.GetResumeMarkerPath()) == nil
+}
+
+// Completion records how end-task finished a task, so a cleanup that was
+// interrupted can be resumed the same way.
+type Completion struct {
+	CompletedAt time.Time `json:"completed_at"`
+	Drop        bool      `json:"drop,omitempty"`        // Task was dropped (removed, never parked)
+	KeepBranch  bool      `json:"keep_branch,omitempty"` // Task branch is kept
+}
+
+// IsCompleted returns true if end-task has already finished the task.
+func (t *Task) IsCompleted() bool {
+	_, err := os.Stat(t.GetCompletedMarkerPath())
+	return err == nil
+}
+
+// MarkCompleted creates the completion marker file.
+func (t *Task) MarkCompleted(c Completion) error {
+	if c.CompletedAt.IsZero() {
+		c.CompletedAt = time.Now()
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(t.GetCompletedMarkerPath(), data, 0644)
+}
+
+// LoadCompletion reads the completion marker. Markers that do not record
+// how the task was finished read as a regular (not dropped) completion.
+func (t *Task) LoadCompletion() (Completion, error) {
+	data, err := os.ReadFile(t.GetCompletedMarkerPath())
+	if err != nil {
+		return Completion{}, err
+	}
+	var c Completion
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Completion{}, nil
+	}
+	return c, nil
+}
+
 // GetOriginPath returns the path to the origin symlink.
 func (t *Task) GetOriginPath() string {
 	return filepath.Join(t.AgentDir, constants.OriginLinkName)
@@ -398,6 +618,19 @@ func (t *Task) SetupSymlinks(projectDir string) error {
 	return nil
 }
 
+// SetupOriginFile records the project root in a plain origin file, for
+// filesystems without symlink support (link_mode: copy).
+func (t *Task) SetupOriginFile(projectDir string) error {
+	originPath := t.GetOriginPath()
+	if err := os.Remove(originPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old origin: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(originPath, []byte(projectDir+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write origin file: %w", err)
+	}
+	return nil
+}
+
 // SetupClaudeSymlink creates a .claude symlink pointing to the PAW directory's
 // .claude folder. The symlink is created in the agent directory (outside git
 // worktree) to avoid accidental commits.
@@ -414,6 +647,34 @@ func (t *Task) SetupClaudeSymlink(pawDir string) error {
 // This is used by SetupClaudeSymlink for worktree mode, and can be called
 // directly with the project directory for non-worktree mode.
 func (t *Task) SetupClaudeSymlinkInDir(pawDir, targetDir string) error {
+	return t.setupClaudeDir(pawDir, targetDir, false)
+}
+
+// CopyClaudeDirInDir is like SetupClaudeSymlinkInDir, but copies the PAW
+// directory's .claude folder instead of linking it (link_mode: copy).
+// Use SyncClaudeDirBack to copy changes back.
+func (t *Task) CopyClaudeDirInDir(pawDir, targetDir string) error {
+	return t.setupClaudeDir(pawDir, targetDir, true)
+}
+
+// SyncClaudeDirBack copies files changed in a copied .claude folder (see
+// CopyClaudeDirInDir) back to the PAW directory. If targetDir is empty, it
+// defaults to the agent directory.
+func (t *Task) SyncClaudeDirBack(pawDir, targetDir string) error {
+	if targetDir == "" {
+		targetDir = t.AgentDir
+	}
+	claudeCopy := filepath.Join(targetDir, constants.ClaudeLink)
+	if fi, err := os.Lstat(claudeCopy); err != nil || !fi.IsDir() {
+		return nil // Not a copy, nothing to sync
+	}
+	if err := fileutil.CopyDir(claudeCopy, filepath.Join(pawDir, constants.ClaudeLink), true); err != nil {
+		return fmt.Errorf("failed to sync .claude back: %w", err)
+	}
+	return nil
+}
+
+func (t *Task) setupClaudeDir(pawDir, targetDir string, copyDir bool) error {
 	if targetDir == "" {
 		// Default to agent directory (outside git worktree)
 		targetDir = t.AgentDir
@@ -469,6 +730,13 @@ func (t *Task) SetupClaudeSymlinkInDir(pawDir, targetDir string) error {
 		}
 	}
 
+	if copyDir {
+		if err := fileutil.CopyDir(claudeSource, claudeTarget, false); err != nil {
+			return fmt.Errorf("failed to copy .claude directory: %w", err)
+		}
+		return nil
+	}
+
 	// Create relative symlink
 	relPath, err := filepath.Rel(targetDir, claudeSource)
 	if err != nil {
@@ -3,7 +3,9 @@ package task
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dongho-jung/paw/internal/constants"
 )
@@ -164,6 +166,83 @@ func TestTaskWindowID(t *testing.T) {
 	}
 }
 
+func TestTaskSnooze(t *testing.T) {
+	agentDir := t.TempDir()
+	task := New("test-task", agentDir)
+	now := time.Now()
+
+	if !task.SnoozedUntil().IsZero() || task.IsSnoozed(now) {
+		t.Fatal("new task should not be snoozed")
+	}
+
+	until := now.Add(time.Hour).Truncate(time.Second)
+	if err := task.Snooze(until); err != nil {
+		t.Fatalf("Snooze() error = %v", err)
+	}
+	if got := task.SnoozedUntil(); !got.Equal(until) {
+		t.Errorf("SnoozedUntil() = %v, want %v", got, until)
+	}
+	if !task.IsSnoozed(now) {
+		t.Error("IsSnoozed() = false before the deadline")
+	}
+	if task.IsSnoozed(until) {
+		t.Error("IsSnoozed() = true at the deadline")
+	}
+
+	if err := task.ClearSnooze(); err != nil {
+		t.Fatalf("ClearSnooze() error = %v", err)
+	}
+	if !task.SnoozedUntil().IsZero() {
+		t.Error("SnoozedUntil() should be zero after ClearSnooze()")
+	}
+	if err := task.ClearSnooze(); err != nil {
+		t.Errorf("ClearSnooze() on unsnoozed task error = %v", err)
+	}
+}
+
+func TestTaskQuestion(t *testing.T) {
+	task := New("test-task", t.TempDir())
+
+	if got := task.Question(); got != "" {
+		t.Fatalf("Question() = %q, want empty", got)
+	}
+	if err := task.SetQuestion("Update both configs?"); err != nil {
+		t.Fatalf("SetQuestion() error = %v", err)
+	}
+	if got := task.Question(); got != "Update both configs?" {
+		t.Errorf("Question() = %q, want %q", got, "Update both configs?")
+	}
+	if err := task.ClearQuestion(); err != nil {
+		t.Fatalf("ClearQuestion() error = %v", err)
+	}
+	if got := task.Question(); got != "" {
+		t.Errorf("Question() after ClearQuestion() = %q, want empty", got)
+	}
+	if err := task.ClearQuestion(); err != nil {
+		t.Errorf("ClearQuestion() without a question error = %v", err)
+	}
+}
+
+func TestTaskReviewReason(t *testing.T) {
+	task := New("test-task", t.TempDir())
+
+	if got := task.ReviewReason(); got != "" {
+		t.Fatalf("ReviewReason() = %q, want empty", got)
+	}
+	if err := task.SetReviewReason("120 files changed (auto_merge_max_files 100)"); err != nil {
+		t.Fatalf("SetReviewReason() error = %v", err)
+	}
+	if got := task.ReviewReason(); got != "120 files changed (auto_merge_max_files 100)" {
+		t.Errorf("ReviewReason() = %q", got)
+	}
+	if err := task.ClearReviewReason(); err != nil {
+		t.Fatalf("ClearReviewReason() error = %v", err)
+	}
+	if got := task.ReviewReason(); got != "" {
+		t.Errorf("ReviewReason() after ClearReviewReason() = %q, want empty", got)
+	}
+}
+
 func TestTaskContent(t *testing.T) {
 	tempDir := t.TempDir()
 	agentDir := filepath.Join(tempDir, "test-task")
@@ -430,6 +509,60 @@ func TestTaskSessionMarker(t *testing.T) {
 	}
 }
 
+func TestTaskResumeMarker(t *testing.T) {
+	agentDir := filepath.Join(t.TempDir(), "test-task")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatalf("Failed to create agent dir: %v", err)
+	}
+
+	task := New("test-task", agentDir)
+	if task.TakeResumeMarker() {
+		t.Error("TakeResumeMarker() = true, want false without a marker")
+	}
+	if err := task.CreateResumeMarker(); err != nil {
+		t.Fatalf("CreateResumeMarker() error = %v", err)
+	}
+	if !task.TakeResumeMarker() {
+		t.Error("TakeResumeMarker() = false, want true after CreateResumeMarker()")
+	}
+	if task.TakeResumeMarker() {
+		t.Error("TakeResumeMarker() = true, want false once taken")
+	}
+}
+
+func TestTaskCompletion(t *testing.T) {
+	agentDir := filepath.Join(t.TempDir(), "test-task")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatalf("Failed to create agent dir: %v", err)
+	}
+
+	task := New("test-task", agentDir)
+	if task.IsCompleted() {
+		t.Error("IsCompleted() = true, want false without a marker")
+	}
+	if err := task.MarkCompleted(Completion{Drop: true}); err != nil {
+		t.Fatalf("MarkCompleted() error = %v", err)
+	}
+	if !task.IsCompleted() {
+		t.Error("IsCompleted() = false, want true after MarkCompleted()")
+	}
+	c, err := task.LoadCompletion()
+	if err != nil {
+		t.Fatalf("LoadCompletion() error = %v", err)
+	}
+	if !c.Drop || c.KeepBranch || c.CompletedAt.IsZero() {
+		t.Errorf("LoadCompletion() = %+v, want a dropped completion with a time", c)
+	}
+
+	// Markers that only hold a timestamp read as a regular completion
+	if err := os.WriteFile(task.GetCompletedMarkerPath(), []byte("2026-01-02T03:04:05Z"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := task.LoadCompletion(); err != nil || c.Drop || c.KeepBranch {
+		t.Errorf("LoadCompletion() of a timestamp marker = %+v, %v; want a regular completion", c, err)
+	}
+}
+
 func TestTaskStatus(t *testing.T) {
 	tempDir := t.TempDir()
 	agentDir := filepath.Join(tempDir, "test-task")
@@ -810,6 +943,74 @@ func TestSetupClaudeSymlinkInDirNonexistentTarget(t *testing.T) {
 	}
 }
 
+func TestCopyClaudeDirInDir(t *testing.T) {
+	tempDir := t.TempDir()
+	agentDir := filepath.Join(tempDir, "agents", "test-task")
+	pawDir := filepath.Join(tempDir, ".paw")
+	claudeSource := filepath.Join(pawDir, ".claude")
+
+	for _, dir := range []string{agentDir, claudeSource} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	settingsFile := filepath.Join(claudeSource, "settings.local.json")
+	if err := os.WriteFile(settingsFile, []byte(`{"test": true}`), 0644); err != nil {
+		t.Fatalf("Failed to create settings file: %v", err)
+	}
+
+	task := New("test-task", agentDir)
+	if err := task.CopyClaudeDirInDir(pawDir, ""); err != nil {
+		t.Fatalf("CopyClaudeDirInDir() error = %v", err)
+	}
+
+	claudeCopy := filepath.Join(agentDir, ".claude")
+	fi, err := os.Lstat(claudeCopy)
+	if err != nil || !fi.IsDir() {
+		t.Fatalf("Expected .claude to be a directory copy, got %v, %v", fi, err)
+	}
+
+	// Changes in the copy are synced back
+	copiedSettings := filepath.Join(claudeCopy, "settings.local.json")
+	if err := os.WriteFile(copiedSettings, []byte(`{"test": false}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(copiedSettings, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.SyncClaudeDirBack(pawDir, ""); err != nil {
+		t.Fatalf("SyncClaudeDirBack() error = %v", err)
+	}
+	if data, _ := os.ReadFile(settingsFile); string(data) != `{"test": false}` {
+		t.Errorf("settings after sync = %s, want the copy's content", data)
+	}
+}
+
+func TestTaskSetupOriginFile(t *testing.T) {
+	tempDir := t.TempDir()
+	agentDir := filepath.Join(tempDir, "agents", "test-task")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	task := New("test-task", agentDir)
+	if err := task.SetupSymlinks(tempDir); err != nil {
+		t.Fatalf("SetupSymlinks() error = %v", err)
+	}
+	if err := task.SetupOriginFile(tempDir); err != nil {
+		t.Fatalf("SetupOriginFile() error = %v", err)
+	}
+
+	fi, err := os.Lstat(task.GetOriginPath())
+	if err != nil || !fi.Mode().IsRegular() {
+		t.Fatalf("Expected origin to be a regular file, got %v, %v", fi, err)
+	}
+	if data, _ := os.ReadFile(task.GetOriginPath()); strings.TrimSpace(string(data)) != tempDir {
+		t.Errorf("origin = %q, want %q", data, tempDir)
+	}
+}
+
 func TestRecoverStatusSignal(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -89,6 +89,7 @@ type Client interface {
 	SendKeys(target string, keys ...string) error
 	SendKeysLiteral(target, text string) error
 	CapturePane(target string, lines int) (string, error)
+	CapturePaneOpts(target string, opts CaptureOpts) (string, error)
 	ClearHistory(target string) error
 	RespawnPane(target, startDir, command string) error
 	WaitForPane(target string, maxWait time.Duration, minContentLen int) error
@@ -99,6 +100,7 @@ type Client interface {
 
 	// Options
 	SetOption(key, value string, global bool) error
+	SetSessionOption(key, value string) error
 	GetOption(key string) (string, error)
 	SetMultipleOptions(options map[string]string) error
 	SetEnv(key, value string) error
@@ -186,6 +188,12 @@ type BreakOpts struct {
 	Name         string // -n flag: window name
 }
 
+// CaptureOpts contains options for capture-pane.
+type CaptureOpts struct {
+	Lines   int  // -S flag: lines of history to include (0 = visible pane only)
+	Escapes bool // -e flag: keep colors and attributes as escape sequences
+}
+
 // Window represents a tmux window.
 type Window struct {
 	ID     string
@@ -198,27 +206,37 @@ type Window struct {
 type tmuxClient struct {
 	socket      string
 	sessionName string
+	cooperative bool // Session runs on the user's default server
 }
 
 // Compile-time check that tmuxClient implements Client interface.
 var _ Client = (*tmuxClient)(nil)
 
 // New creates a new tmux client with the given socket name.
+// Cooperative sessions (see EnableCooperative) use the user's default server.
 func New(sessionName string) Client {
 	return &tmuxClient{
 		socket:      constants.TmuxSocketPrefix + sessionName,
 		sessionName: sessionName,
+		cooperative: IsCooperative(sessionName),
+	}
+}
+
+// serverArgs selects the session's server: PAW's own socket and config, or
+// the default server with the user's config.
+func (c *tmuxClient) serverArgs(args []string) []string {
+	if c.cooperative {
+		return args
 	}
+	return append([]string{"-f", configPath, "-L", c.socket}, args...)
 }
 
 func (c *tmuxClient) cmd(args ...string) *exec.Cmd {
-	allArgs := append([]string{"-f", configPath, "-L", c.socket}, args...)
-	return exec.Command("tmux", allArgs...) //nolint:gosec // G204: args are controlled by internal PAW code
+	return exec.Command("tmux", c.serverArgs(args)...) //nolint:gosec // G204: args are controlled by internal PAW code
 }
 
 func (c *tmuxClient) cmdContext(ctx context.Context, args ...string) *exec.Cmd {
-	allArgs := append([]string{"-f", configPath, "-L", c.socket}, args...)
-	return exec.CommandContext(ctx, "tmux", allArgs...) //nolint:gosec // G204: args are controlled by internal PAW code
+	return exec.CommandContext(ctx, "tmux", c.serverArgs(args)...) //nolint:gosec // G204: args are controlled by internal PAW code
 }
 
 func (c *tmuxClient) Run(args ...string) error {
@@ -545,9 +563,16 @@ func (c *tmuxClient) SendKeysLiteral(target, text string) error {
 }
 
 func (c *tmuxClient) CapturePane(target string, lines int) (string, error) {
+	return c.CapturePaneOpts(target, CaptureOpts{Lines: lines})
+}
+
+func (c *tmuxClient) CapturePaneOpts(target string, opts CaptureOpts) (string, error) {
 	args := []string{"capture-pane", "-t", target, "-p"}
-	if lines > 0 {
-		args = append(args, "-S", fmt.Sprintf("-%d", lines))
+	if opts.Lines > 0 {
+		args = append(args, "-S", fmt.Sprintf("-%d", opts.Lines))
+	}
+	if opts.Escapes {
+		args = append(args, "-e")
 	}
 	return c.RunWithOutput(args...)
 }
@@ -657,12 +682,59 @@ func (c *tmuxClient) DisplayPopup(opts PopupOpts, command string) error {
 func (c *tmuxClient) SetOption(key, value string, global bool) error {
 	args := []string{"set-option"}
 	if global {
+		if c.cooperative {
+			c.recordOption(key)
+		}
 		args = append(args, "-g")
 	}
 	args = append(args, key, value)
 	return c.Run(args...)
 }
 
+// windowOptions are the window options PAW scopes to its session. tmux keeps
+// them per window, so they are set on each window of the session, and by a
+// session hook (at the option's index) on windows created later.
+var windowOptions = []string{
+	"window-status-format",
+	"window-status-current-format",
+	"window-status-separator",
+	"pane-border-style",
+	"pane-active-border-style",
+	"popup-style",
+	"popup-border-style",
+}
+
+// SetSessionOption sets an option for the client's session only, so it
+// doesn't leak to other sessions on the same server.
+func (c *tmuxClient) SetSessionOption(key, value string) error {
+	hookIndex := -1
+	for i, opt := range windowOptions {
+		if opt == key {
+			hookIndex = i
+			break
+		}
+	}
+	if hookIndex < 0 {
+		return c.Run("set-option", "-t", c.sessionName, key, value)
+	}
+
+	windowIDs, err := c.RunWithOutput("list-windows", "-t", c.sessionName, "-F", "#{window_id}")
+	if err != nil {
+		return err
+	}
+	hook := "after-new-window[" + strconv.Itoa(hookIndex) + "]"
+	args := []string{"set-hook", "-t", c.sessionName, hook, "set-option -w " + key + " " + quoteCommandArg(value)}
+	for _, id := range strings.Fields(windowIDs) {
+		args = append(args, ";", "set-option", "-w", "-t", id, key, value)
+	}
+	return c.Run(args...)
+}
+
+// quoteCommandArg quotes a value for a tmux command string.
+func quoteCommandArg(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 func (c *tmuxClient) GetOption(key string) (string, error) {
 	return c.RunWithOutput("show-option", "-gv", key)
 }
@@ -729,6 +801,9 @@ func (c *tmuxClient) SetMultipleOptions(options map[string]string) error {
 	args := make([]string, 0, len(options)*4)
 	first := true
 	for key, value := range options {
+		if c.cooperative {
+			c.recordOption(key)
+		}
 		if !first {
 			args = append(args, ";", "set-option", "-g")
 		} else {
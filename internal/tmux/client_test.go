@@ -107,3 +107,17 @@ func TestTmuxSocketPrefix(t *testing.T) {
 		t.Error("TmuxSocketPrefix should not be empty")
 	}
 }
+
+func TestQuoteCommandArg(t *testing.T) {
+	tests := map[string]string{
+		"":                 "''",
+		"fg=colour39,bold": "'fg=colour39,bold'",
+		"#[fg=red] #W ":    "'#[fg=red] #W '",
+		"it's $HOME":       `'it'\''s $HOME'`,
+	}
+	for in, want := range tests {
+		if got := quoteCommandArg(in); got != want {
+			t.Errorf("quoteCommandArg(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package tmux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// Cooperative sessions run on the user's default tmux server instead of a
+// dedicated "paw-<session>" server. Each one has a marker file that clients
+// use to pick the server, and that records the global options PAW replaced
+// so they can be restored when the session ends.
+
+// CooperativeEntryKey is the prefix-table key that enters a cooperative
+// session's key table.
+const CooperativeEntryKey = "P"
+
+// cooperativeDir returns the directory holding the cooperative session markers.
+var cooperativeDir = func() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "paw", "cooperative")
+}
+
+// cooperativeState is the content of a cooperative session marker.
+type cooperativeState struct {
+	Options map[string]string `json:"options,omitempty"` // Previous values of changed global options
+	Unset   []string          `json:"unset,omitempty"`   // User options (@...) that weren't set before
+}
+
+func (s *cooperativeState) has(key string) bool {
+	if _, ok := s.Options[key]; ok {
+		return true
+	}
+	for _, k := range s.Unset {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func cooperativeMarkerPath(sessionName string) string {
+	return filepath.Join(cooperativeDir(), sessionName+".json")
+}
+
+// IsCooperative reports whether the session runs on the user's default tmux
+// server.
+func IsCooperative(sessionName string) bool {
+	_, err := os.Stat(cooperativeMarkerPath(sessionName))
+	return err == nil
+}
+
+// EnableCooperative marks the session to run on the user's default tmux
+// server. Clients created with New afterwards use that server.
+func EnableCooperative(sessionName string) error {
+	if IsCooperative(sessionName) {
+		return nil
+	}
+	if err := os.MkdirAll(cooperativeDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create cooperative dir: %w", err)
+	}
+	return saveCooperativeState(sessionName, &cooperativeState{})
+}
+
+// DisableCooperative removes the session's marker without restoring any
+// options, for a session that never started.
+func DisableCooperative(sessionName string) error {
+	if err := os.Remove(cooperativeMarkerPath(sessionName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cooperative marker: %w", err)
+	}
+	return nil
+}
+
+// CooperativeSessions returns the names of the sessions marked cooperative.
+// Some may no longer be running.
+func CooperativeSessions() []string {
+	entries, err := os.ReadDir(cooperativeDir())
+	if err != nil {
+		return nil
+	}
+	var sessions []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			sessions = append(sessions, name)
+		}
+	}
+	sort.Strings(sessions)
+	return sessions
+}
+
+// CooperativeKeyTable returns the key table holding a cooperative session's
+// key bindings.
+func CooperativeKeyTable(sessionName string) string {
+	return constants.TmuxSocketPrefix + sessionName
+}
+
+// AttachArgs returns the command line that attaches to a PAW session on
+// its server.
+func AttachArgs(sessionName string) []string {
+	if IsCooperative(sessionName) {
+		return []string{"tmux", "attach-session", "-t", sessionName}
+	}
+	return []string{"tmux", "-L", constants.TmuxSocketPrefix + sessionName, "attach-session", "-t", sessionName}
+}
+
+// RestoreCooperative puts back the global options a cooperative session
+// changed, removes its key bindings, and removes its marker. If another
+// cooperative session is still running, the previous values are handed over
+// to it instead, so they are restored when the last one ends.
+func RestoreCooperative(sessionName string) error {
+	state, err := loadCooperativeState(sessionName)
+	if err != nil {
+		return err
+	}
+	if err := DisableCooperative(sessionName); err != nil {
+		return err
+	}
+
+	c := &tmuxClient{sessionName: sessionName, cooperative: true}
+	_ = c.Run("unbind-key", "-a", "-T", CooperativeKeyTable(sessionName))
+
+	for _, other := range CooperativeSessions() {
+		if c.HasSession(other) {
+			return handOverCooperativeState(other, state)
+		}
+	}
+
+	var errs []error
+	for key, value := range state.Options {
+		if err := c.Run("set-option", "-g", key, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	for _, key := range state.Unset {
+		_ = c.Run("set-option", "-gu", key)
+	}
+	_ = c.Run("unbind-key", "-T", "prefix", CooperativeEntryKey)
+	logging.Debug("tmux.RestoreCooperative: session=%s restored=%d unset=%d", sessionName, len(state.Options), len(state.Unset))
+	return errors.Join(errs...)
+}
+
+// handOverCooperativeState adds previous option values to another running
+// cooperative session's marker, keeping the values it already recorded.
+func handOverCooperativeState(sessionName string, state *cooperativeState) error {
+	target, err := loadCooperativeState(sessionName)
+	if err != nil {
+		return err
+	}
+	for key, value := range state.Options {
+		if !target.has(key) {
+			if target.Options == nil {
+				target.Options = map[string]string{}
+			}
+			target.Options[key] = value
+		}
+	}
+	for _, key := range state.Unset {
+		if !target.has(key) {
+			target.Unset = append(target.Unset, key)
+		}
+	}
+	return saveCooperativeState(sessionName, target)
+}
+
+func loadCooperativeState(sessionName string) (*cooperativeState, error) {
+	data, err := os.ReadFile(cooperativeMarkerPath(sessionName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cooperative marker: %w", err)
+	}
+	state := &cooperativeState{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("failed to parse cooperative marker: %w", err)
+		}
+	}
+	return state, nil
+}
+
+func saveCooperativeState(sessionName string, state *cooperativeState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cooperative marker: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(cooperativeMarkerPath(sessionName), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cooperative marker: %w", err)
+	}
+	return nil
+}
+
+// recordOption saves the current value of a global option before a
+// cooperative client changes it. Only the first value is kept, so the
+// user's own value survives repeated changes.
+func (c *tmuxClient) recordOption(key string) {
+	state, err := loadCooperativeState(c.sessionName)
+	if err != nil || state.has(key) {
+		return
+	}
+	value, err := c.RunWithOutput("show-options", "-gqv", key)
+	if err != nil {
+		logging.Debug("tmux.recordOption: %s: %v", key, err)
+		return
+	}
+	if value == "" && strings.HasPrefix(key, "@") {
+		state.Unset = append(state.Unset, key)
+	} else {
+		if state.Options == nil {
+			state.Options = map[string]string{}
+		}
+		state.Options[key] = value
+	}
+	if err := saveCooperativeState(c.sessionName, state); err != nil {
+		logging.Debug("tmux.recordOption: %v", err)
+	}
+}
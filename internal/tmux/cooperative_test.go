@@ -0,0 +1,85 @@
+package tmux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func useCooperativeDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	orig := cooperativeDir
+	cooperativeDir = func() string { return dir }
+	t.Cleanup(func() { cooperativeDir = orig })
+}
+
+func TestCooperativeMarker(t *testing.T) {
+	useCooperativeDir(t)
+
+	if IsCooperative("proj") {
+		t.Fatal("IsCooperative() = true before EnableCooperative")
+	}
+	if got := AttachArgs("proj"); !reflect.DeepEqual(got, []string{"tmux", "-L", "paw-proj", "attach-session", "-t", "proj"}) {
+		t.Errorf("AttachArgs() = %v", got)
+	}
+
+	for _, name := range []string{"proj", "other"} {
+		if err := EnableCooperative(name); err != nil {
+			t.Fatalf("EnableCooperative(%q) error = %v", name, err)
+		}
+	}
+	if !IsCooperative("proj") {
+		t.Error("IsCooperative() = false after EnableCooperative")
+	}
+	if got := New("proj").(*tmuxClient); !got.cooperative {
+		t.Error("New() client is not cooperative")
+	}
+	if got := AttachArgs("proj"); !reflect.DeepEqual(got, []string{"tmux", "attach-session", "-t", "proj"}) {
+		t.Errorf("AttachArgs() = %v", got)
+	}
+	if got := CooperativeSessions(); !reflect.DeepEqual(got, []string{"other", "proj"}) {
+		t.Errorf("CooperativeSessions() = %v", got)
+	}
+
+	if err := DisableCooperative("proj"); err != nil {
+		t.Fatalf("DisableCooperative() error = %v", err)
+	}
+	if IsCooperative("proj") {
+		t.Error("IsCooperative() = true after DisableCooperative")
+	}
+}
+
+func TestHandOverCooperativeState(t *testing.T) {
+	useCooperativeDir(t)
+
+	if err := EnableCooperative("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveCooperativeState("a", &cooperativeState{
+		Options: map[string]string{"status-left": "[mine]"},
+		Unset:   []string{"@paw_a"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := handOverCooperativeState("a", &cooperativeState{
+		Options: map[string]string{"status-left": "[paw]", "prefix": "C-a"},
+		Unset:   []string{"@paw_a", "@paw_b"},
+	})
+	if err != nil {
+		t.Fatalf("handOverCooperativeState() error = %v", err)
+	}
+
+	state, err := loadCooperativeState("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Values recorded first are the user's and are kept
+	want := map[string]string{"status-left": "[mine]", "prefix": "C-a"}
+	if !reflect.DeepEqual(state.Options, want) {
+		t.Errorf("Options = %v, want %v", state.Options, want)
+	}
+	if !reflect.DeepEqual(state.Unset, []string{"@paw_a", "@paw_b"}) {
+		t.Errorf("Unset = %v", state.Unset)
+	}
+}
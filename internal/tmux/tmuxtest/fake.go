@@ -0,0 +1,730 @@
+// Package tmuxtest provides an in-memory tmux.Client for tests.
+//
+// Fake keeps sessions, windows, panes, options, and environment in memory and
+// records what was sent to them, so code driving tmux can be tested without a
+// tmux server:
+//
+//	tm := tmuxtest.New("my-session")
+//	id, _ := tm.NewWindow(tmux.WindowOpts{Name: "task"})
+//	tm.SetPaneContent(id+".0", "⏺ Working...")
+package tmuxtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// Fake is an in-memory tmux.Client. It is safe for concurrent use.
+//
+// Windows are addressed by ID ("@1"), "session:name", or name; panes by
+// "<window>.<index>" ("@1.0") or by window (its active pane).
+type Fake struct {
+	mu sync.Mutex
+
+	sessions   map[string]string // name -> start dir
+	windows    []*window
+	options    map[string]string
+	env        map[string]string
+	messages   []string
+	calls      []string
+	nextWindow int
+}
+
+type window struct {
+	session string
+	id      string
+	name    string
+	active  bool
+	panes   []*pane
+}
+
+type pane struct {
+	command string
+	dir     string
+	content string
+	keys    []string
+}
+
+// Compile-time check that Fake implements tmux.Client.
+var _ tmux.Client = (*Fake)(nil)
+
+// New creates a fake tmux server with the given sessions already running.
+func New(sessions ...string) *Fake {
+	f := &Fake{
+		sessions: map[string]string{},
+		options:  map[string]string{},
+		env:      map[string]string{},
+	}
+	for _, s := range sessions {
+		f.sessions[s] = ""
+	}
+	return f
+}
+
+// Session management
+
+// HasSession reports whether the session exists.
+func (f *Fake) HasSession(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.sessions[name]
+	return ok
+}
+
+// NewSession creates a session with its first window.
+func (f *Fake) NewSession(opts tmux.SessionOpts) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("new-session", opts.Name)
+	if _, ok := f.sessions[opts.Name]; ok {
+		return fmt.Errorf("duplicate session: %s", opts.Name)
+	}
+	f.sessions[opts.Name] = opts.StartDir
+	f.addWindow(opts.Name, opts.WindowName, opts.StartDir, opts.Command)
+	return nil
+}
+
+// AttachSession records the attach and returns immediately.
+func (f *Fake) AttachSession(name string) error {
+	return f.requireSession("attach-session", name)
+}
+
+// SwitchClient records the switch.
+func (f *Fake) SwitchClient(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("switch-client", target)
+	return nil
+}
+
+// KillSession removes the session and its windows.
+func (f *Fake) KillSession(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-session", name)
+	if _, ok := f.sessions[name]; !ok {
+		return fmt.Errorf("can't find session: %s", name)
+	}
+	delete(f.sessions, name)
+	kept := f.windows[:0]
+	for _, w := range f.windows {
+		if w.session != name {
+			kept = append(kept, w)
+		}
+	}
+	f.windows = kept
+	return nil
+}
+
+// KillServer removes all sessions and windows.
+func (f *Fake) KillServer() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-server")
+	f.sessions = map[string]string{}
+	f.windows = nil
+	return nil
+}
+
+// Window management
+
+// NewWindow creates a window with one pane and returns its ID.
+func (f *Fake) NewWindow(opts tmux.WindowOpts) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("new-window", opts.Name)
+	session := f.defaultSession()
+	if opts.Target != "" {
+		session, _, _ = strings.Cut(opts.Target, ":")
+	}
+	return f.addWindow(session, opts.Name, opts.StartDir, opts.Command).id, nil
+}
+
+// KillWindow removes the window.
+func (f *Fake) KillWindow(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-window", target)
+	for i, w := range f.windows {
+		if f.matchWindow(w, target) {
+			f.windows = append(f.windows[:i], f.windows[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("can't find window: %s", target)
+}
+
+// RenameWindow renames the window.
+func (f *Fake) RenameWindow(target, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("rename-window", target, name)
+	w := f.findWindow(target)
+	if w == nil {
+		return fmt.Errorf("can't find window: %s", target)
+	}
+	w.name = name
+	return nil
+}
+
+// ListWindows lists the windows of all sessions in creation order.
+func (f *Fake) ListWindows() ([]tmux.Window, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	windows := make([]tmux.Window, 0, len(f.windows))
+	for i, w := range f.windows {
+		windows = append(windows, tmux.Window{ID: w.id, Index: i, Name: w.name, Active: w.active})
+	}
+	return windows, nil
+}
+
+// SelectWindow makes the window active.
+func (f *Fake) SelectWindow(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("select-window", target)
+	w := f.findWindow(target)
+	if w == nil {
+		return fmt.Errorf("can't find window: %s", target)
+	}
+	for _, other := range f.windows {
+		other.active = other == w
+	}
+	return nil
+}
+
+// MoveWindow records the move.
+func (f *Fake) MoveWindow(source, target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("move-window", source, target)
+	return nil
+}
+
+// Pane operations
+
+// SplitWindow adds a pane to the window.
+func (f *Fake) SplitWindow(target string, _ bool, startDir string, command string) error {
+	_, err := f.SplitWindowPane(tmux.SplitOpts{Target: target, StartDir: startDir, Command: command})
+	return err
+}
+
+// SplitWindowPane adds a pane to the window and returns its target.
+func (f *Fake) SplitWindowPane(opts tmux.SplitOpts) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("split-window", opts.Target)
+	w := f.findWindow(opts.Target)
+	if w == nil {
+		return "", fmt.Errorf("can't find window: %s", opts.Target)
+	}
+	w.panes = append(w.panes, &pane{command: commandName(opts.Command), dir: opts.StartDir})
+	return w.id + "." + strconv.Itoa(len(w.panes)-1), nil
+}
+
+// SelectPane records the selection.
+func (f *Fake) SelectPane(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("select-pane", target)
+	if f.findPane(target) == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	return nil
+}
+
+// KillPane removes the pane (and its window when it was the last pane).
+func (f *Fake) KillPane(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-pane", target)
+	w, idx := f.locatePane(target)
+	if w == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	w.panes = append(w.panes[:idx], w.panes[idx+1:]...)
+	if len(w.panes) == 0 {
+		for i, other := range f.windows {
+			if other == w {
+				f.windows = append(f.windows[:i], f.windows[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// HasPane reports whether the pane exists.
+func (f *Fake) HasPane(target string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.findPane(target) != nil
+}
+
+// JoinPane moves the source pane into the target window.
+func (f *Fake) JoinPane(source, target string, _ tmux.JoinOpts) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("join-pane", source, target)
+	src, idx := f.locatePane(source)
+	dst := f.findWindow(target)
+	if src == nil || dst == nil {
+		return fmt.Errorf("can't join pane %s to %s", source, target)
+	}
+	p := src.panes[idx]
+	src.panes = append(src.panes[:idx], src.panes[idx+1:]...)
+	dst.panes = append(dst.panes, p)
+	return nil
+}
+
+// BreakPane moves the pane into a new window and returns the window ID.
+func (f *Fake) BreakPane(source string, opts tmux.BreakOpts) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("break-pane", source)
+	src, idx := f.locatePane(source)
+	if src == nil {
+		return "", fmt.Errorf("can't find pane: %s", source)
+	}
+	p := src.panes[idx]
+	src.panes = append(src.panes[:idx], src.panes[idx+1:]...)
+	w := f.addWindow(src.session, opts.Name, p.dir, "")
+	w.panes[0] = p
+	return w.id, nil
+}
+
+// SendKeys records the keys sent to the pane.
+func (f *Fake) SendKeys(target string, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("send-keys", target, strings.Join(keys, " "))
+	p := f.findPane(target)
+	if p == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	p.keys = append(p.keys, keys...)
+	return nil
+}
+
+// SendKeysLiteral records the text sent to the pane.
+func (f *Fake) SendKeysLiteral(target, text string) error {
+	return f.SendKeys(target, text)
+}
+
+// CapturePane returns the last lines of the pane content.
+func (f *Fake) CapturePane(target string, lines int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPane(target)
+	if p == nil {
+		return "", fmt.Errorf("can't find pane: %s", target)
+	}
+	all := strings.Split(p.content, "\n")
+	if lines > 0 && len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n"), nil
+}
+
+// CapturePaneOpts returns the last lines of the pane content; the fake keeps
+// no escape sequences, so Escapes has no effect.
+func (f *Fake) CapturePaneOpts(target string, opts tmux.CaptureOpts) (string, error) {
+	return f.CapturePane(target, opts.Lines)
+}
+
+// ClearHistory clears the pane content.
+func (f *Fake) ClearHistory(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("clear-history", target)
+	p := f.findPane(target)
+	if p == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	p.content = ""
+	return nil
+}
+
+// RespawnPane replaces the pane's command and clears its content.
+func (f *Fake) RespawnPane(target, startDir, command string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("respawn-pane", target, command)
+	p := f.findPane(target)
+	if p == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	p.command = commandName(command)
+	p.dir = startDir
+	p.content = ""
+	return nil
+}
+
+// WaitForPane returns once the pane exists; the fake never waits.
+func (f *Fake) WaitForPane(target string, _ time.Duration, _ int) error {
+	if !f.HasPane(target) {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	return nil
+}
+
+// GetPaneCommand returns the base name of the command the pane was started with.
+func (f *Fake) GetPaneCommand(target string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPane(target)
+	if p == nil {
+		return "", fmt.Errorf("can't find pane: %s", target)
+	}
+	return p.command, nil
+}
+
+// Display popup
+
+// DisplayPopup records the popup command.
+func (f *Fake) DisplayPopup(_ tmux.PopupOpts, command string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("display-popup", command)
+	return nil
+}
+
+// Options
+
+// SetOption sets an option.
+func (f *Fake) SetOption(key, value string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.options[key] = value
+	return nil
+}
+
+// SetSessionOption sets an option.
+func (f *Fake) SetSessionOption(key, value string) error {
+	return f.SetOption(key, value, false)
+}
+
+// GetOption returns an option, or an error if it is not set.
+func (f *Fake) GetOption(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.options[key]
+	if !ok {
+		return "", fmt.Errorf("unknown option: %s", key)
+	}
+	return value, nil
+}
+
+// SetMultipleOptions sets several options.
+func (f *Fake) SetMultipleOptions(options map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for key, value := range options {
+		f.options[key] = value
+	}
+	return nil
+}
+
+// SetEnv sets a session environment variable (see RunWithOutput "show-environment").
+func (f *Fake) SetEnv(key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.env[key] = value
+	return nil
+}
+
+// Keybindings
+
+// Bind records the binding.
+func (f *Fake) Bind(opts tmux.BindOpts) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("bind-key", opts.Key, opts.Command)
+	return nil
+}
+
+// Unbind records the unbinding.
+func (f *Fake) Unbind(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("unbind-key", key)
+	return nil
+}
+
+// Utility
+
+// Run records the raw command.
+func (f *Fake) Run(args ...string) error {
+	_, err := f.RunWithOutput(args...)
+	return err
+}
+
+// RunWithOutput records the raw command. "show-environment" returns the variables
+// set with SetEnv and "display-message -p" is answered like Display.
+func (f *Fake) RunWithOutput(args ...string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record(args...)
+	if len(args) == 0 {
+		return "", nil
+	}
+	switch args[0] {
+	case "show-environment":
+		lines := make([]string, 0, len(f.env))
+		for key, value := range f.env {
+			lines = append(lines, key+"="+value)
+		}
+		return strings.Join(lines, "\n"), nil
+	case "display-message":
+		if len(args) > 1 && args[1] == "-p" {
+			target := ""
+			if len(args) > 3 && args[2] == "-t" {
+				target = args[3]
+			}
+			return f.format(target, args[len(args)-1]), nil
+		}
+	}
+	return "", nil
+}
+
+// Display expands the supported formats (#{session_name}, #{session_path},
+// #{window_id}, #{window_name}, #{pane_current_path}) for the active window.
+func (f *Fake) Display(format string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.format("", format), nil
+}
+
+// DisplayMultiple expands several formats.
+func (f *Fake) DisplayMultiple(formats ...string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]string, 0, len(formats))
+	for _, format := range formats {
+		out = append(out, f.format("", format))
+	}
+	return out, nil
+}
+
+// Notifications
+
+// DisplayMessage records the message (see Messages).
+func (f *Fake) DisplayMessage(message string, _ int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+// Inspection and scripting helpers
+
+// SetPaneContent replaces what CapturePane returns for the pane.
+func (f *Fake) SetPaneContent(target, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPane(target); p != nil {
+		p.content = content
+	}
+}
+
+// AppendPaneContent appends lines to the pane content, as if printed by its command.
+func (f *Fake) AppendPaneContent(target, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPane(target); p != nil {
+		if p.content != "" && !strings.HasSuffix(p.content, "\n") {
+			p.content += "\n"
+		}
+		p.content += content
+	}
+}
+
+// SetPaneCommand sets what GetPaneCommand returns (e.g. "zsh" once the agent exited).
+func (f *Fake) SetPaneCommand(target, command string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPane(target); p != nil {
+		p.command = command
+	}
+}
+
+// SentKeys returns the keys and literal text sent to the pane.
+func (f *Fake) SentKeys(target string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPane(target); p != nil {
+		return append([]string(nil), p.keys...)
+	}
+	return nil
+}
+
+// Window returns the window matching target.
+func (f *Fake) Window(target string) (tmux.Window, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, w := range f.windows {
+		if f.matchWindow(w, target) {
+			return tmux.Window{ID: w.id, Index: i, Name: w.name, Active: w.active}, true
+		}
+	}
+	return tmux.Window{}, false
+}
+
+// PaneCount returns the number of panes in the window.
+func (f *Fake) PaneCount(target string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if w := f.findWindow(target); w != nil {
+		return len(w.panes)
+	}
+	return 0
+}
+
+// Option returns an option set with SetOption, SetSessionOption, or
+// SetMultipleOptions.
+func (f *Fake) Option(key string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.options[key]
+}
+
+// Messages returns the messages shown with DisplayMessage.
+func (f *Fake) Messages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.messages...)
+}
+
+// Calls returns the recorded commands, one "command arg..." string per call.
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *Fake) record(args ...string) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+}
+
+func (f *Fake) requireSession(command, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record(command, name)
+	if _, ok := f.sessions[name]; !ok {
+		return fmt.Errorf("can't find session: %s", name)
+	}
+	return nil
+}
+
+func (f *Fake) defaultSession() string {
+	for name := range f.sessions {
+		return name
+	}
+	return ""
+}
+
+func (f *Fake) addWindow(session, name, dir, command string) *window {
+	f.nextWindow++
+	w := &window{
+		session: session,
+		id:      "@" + strconv.Itoa(f.nextWindow),
+		name:    name,
+		panes:   []*pane{{command: commandName(command), dir: dir}},
+	}
+	f.windows = append(f.windows, w)
+	return w
+}
+
+func (f *Fake) matchWindow(w *window, target string) bool {
+	if session, name, ok := strings.Cut(target, ":"); ok {
+		return w.session == session && (w.name == name || w.id == name)
+	}
+	return w.id == target || w.name == target
+}
+
+func (f *Fake) findWindow(target string) *window {
+	target, _, _ = strings.Cut(target, ".")
+	for _, w := range f.windows {
+		if f.matchWindow(w, target) {
+			return w
+		}
+	}
+	return nil
+}
+
+func (f *Fake) locatePane(target string) (*window, int) {
+	windowTarget, index, hasIndex := strings.Cut(target, ".")
+	w := f.findWindow(windowTarget)
+	if w == nil || len(w.panes) == 0 {
+		return nil, 0
+	}
+	if !hasIndex {
+		return w, 0
+	}
+	idx, err := strconv.Atoi(index)
+	if err != nil || idx < 0 || idx >= len(w.panes) {
+		return nil, 0
+	}
+	return w, idx
+}
+
+func (f *Fake) findPane(target string) *pane {
+	w, idx := f.locatePane(target)
+	if w == nil {
+		return nil
+	}
+	return w.panes[idx]
+}
+
+func (f *Fake) format(target, format string) string {
+	var w *window
+	if target != "" {
+		w = f.findWindow(target)
+	}
+	if w == nil {
+		for _, candidate := range f.windows {
+			if candidate.active || w == nil {
+				w = candidate
+			}
+		}
+	}
+	session := target
+	if _, ok := f.sessions[session]; !ok {
+		session = f.defaultSession()
+		if w != nil {
+			session = w.session
+		}
+	}
+
+	replacements := []string{
+		"#{session_name}", session,
+		"#{session_path}", f.sessions[session],
+	}
+	if w != nil {
+		dir := ""
+		if len(w.panes) > 0 {
+			dir = w.panes[0].dir
+		}
+		replacements = append(replacements,
+			"#{window_id}", w.id,
+			"#{window_name}", w.name,
+			"#{pane_current_path}", dir,
+		)
+	}
+	return strings.NewReplacer(replacements...).Replace(format)
+}
+
+// commandName returns the program name of a shell command ("" for the default shell).
+func commandName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return filepath.Base(strings.Trim(fields[0], `'"`))
+}
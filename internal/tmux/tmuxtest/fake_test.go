@@ -0,0 +1,74 @@
+package tmuxtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+func TestFakeWindowsAndPanes(t *testing.T) {
+	tm := New("proj")
+
+	id, err := tm.NewWindow(tmux.WindowOpts{Name: "task", StartDir: "/work"})
+	if err != nil {
+		t.Fatalf("NewWindow() error = %v", err)
+	}
+	if err := tm.SplitWindow(id, true, "/work", "bash"); err != nil {
+		t.Fatalf("SplitWindow() error = %v", err)
+	}
+	if got := tm.PaneCount(id); got != 2 {
+		t.Errorf("PaneCount() = %d, want 2", got)
+	}
+
+	tm.SetPaneContent(id+".0", "⏺ Working")
+	tm.AppendPaneContent(id+".0", "done")
+	got, err := tm.CapturePane(id+".0", 100)
+	if err != nil {
+		t.Fatalf("CapturePane() error = %v", err)
+	}
+	if !strings.Contains(got, "⏺ Working") || !strings.Contains(got, "done") {
+		t.Errorf("CapturePane() = %q, want both lines", got)
+	}
+
+	if err := tm.SendKeys(id+".0", "C-l"); err != nil {
+		t.Fatalf("SendKeys() error = %v", err)
+	}
+	if keys := tm.SentKeys(id + ".0"); len(keys) != 1 || keys[0] != "C-l" {
+		t.Errorf("SentKeys() = %q, want [C-l]", keys)
+	}
+
+	if err := tm.KillWindow(id); err != nil {
+		t.Fatalf("KillWindow() error = %v", err)
+	}
+	if _, ok := tm.Window(id); ok {
+		t.Error("window still exists after KillWindow()")
+	}
+	if _, err := tm.CapturePane(id+".0", 100); err == nil {
+		t.Error("CapturePane() of killed window should fail")
+	}
+}
+
+func TestFakeSessionEnvironment(t *testing.T) {
+	tm := New("proj")
+	if !tm.HasSession("proj") || tm.HasSession("other") {
+		t.Fatal("HasSession() does not match the sessions passed to New()")
+	}
+
+	_ = tm.SetEnv("PAW_DIR", "/proj/.paw")
+	out, err := tm.RunWithOutput("show-environment", "-t", "proj")
+	if err != nil {
+		t.Fatalf("RunWithOutput() error = %v", err)
+	}
+	if out != "PAW_DIR=/proj/.paw" {
+		t.Errorf("show-environment = %q, want %q", out, "PAW_DIR=/proj/.paw")
+	}
+
+	name, err := tm.RunWithOutput("display-message", "-p", "-t", "proj", "#{session_name}")
+	if err != nil {
+		t.Fatalf("RunWithOutput() error = %v", err)
+	}
+	if name != "proj" {
+		t.Errorf("display-message = %q, want %q", name, "proj")
+	}
+}
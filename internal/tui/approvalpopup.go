@@ -0,0 +1,175 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// ApprovalPopup asks the user to approve or deny a command an agent wants to run.
+type ApprovalPopup struct {
+	taskName string
+	command  string
+	dir      string
+
+	cursor   int // 0 = Deny, 1 = Approve
+	approved bool
+	decided  bool
+
+	width  int
+	height int
+	isDark bool
+	colors ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleLabel    lipgloss.Style
+	styleBox      lipgloss.Style
+	styleChoice   lipgloss.Style
+	styleSelected lipgloss.Style
+	styleHelp     lipgloss.Style
+	stylesCached  bool
+}
+
+// NewApprovalPopup creates a new approval popup model. Deny is selected by default.
+func NewApprovalPopup(taskName, command, dir string) *ApprovalPopup {
+	isDark := DetectDarkMode()
+	return &ApprovalPopup{
+		taskName: taskName,
+		command:  command,
+		dir:      dir,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the popup.
+func (m *ApprovalPopup) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *ApprovalPopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "h":
+			m.cursor = 0
+		case "right", "l":
+			m.cursor = 1
+		case "y", "Y":
+			m.approved = true
+			m.decided = true
+			return m, tea.Quit
+		case "n", "N":
+			m.approved = false
+			m.decided = true
+			return m, tea.Quit
+		case "enter", " ":
+			m.approved = m.cursor == 1
+			m.decided = true
+			return m, tea.Quit
+		case "esc", "ctrl+c", "q":
+			// Decide later (⌥A reopens the popup)
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the popup.
+func (m *ApprovalPopup) View() tea.View {
+	c := m.colors
+
+	maxWidth := 70
+	if m.width > 0 {
+		maxWidth = min(maxWidth, m.width-10)
+	}
+	if maxWidth < 10 {
+		maxWidth = 10
+	}
+
+	// Update style cache if needed (only on theme change)
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.WarningColor)
+		m.styleLabel = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.styleBox = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(c.Border).
+			Padding(0, 1)
+		m.styleChoice = lipgloss.NewStyle().
+			Foreground(c.TextNormal)
+		m.styleSelected = lipgloss.NewStyle().
+			Foreground(c.TextInverted).
+			Background(c.Accent).
+			Bold(true)
+		m.styleHelp = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.stylesCached = true
+	}
+
+	var denyLabel, approveLabel string
+	if m.cursor == 0 {
+		denyLabel = m.styleSelected.Render("Deny")
+		approveLabel = m.styleChoice.Render("Approve")
+	} else {
+		denyLabel = m.styleChoice.Render("Deny")
+		approveLabel = m.styleSelected.Render("Approve")
+	}
+
+	lines := []string{
+		m.styleTitle.Render("🛑 " + truncateWithEllipsis(m.taskName, maxWidth) + " wants to run"),
+		"",
+		m.styleBox.Render(truncateWithEllipsis(m.command, maxWidth)),
+	}
+	if m.dir != "" {
+		lines = append(lines, m.styleLabel.Render("in "+truncateWithEllipsis(m.dir, maxWidth)))
+	}
+	lines = append(lines,
+		"",
+		m.styleLabel.Render("Run this command?  ")+denyLabel+"  "+approveLabel,
+		m.styleHelp.Render("y: Approve  n: Deny  Enter: Confirm  Esc: Decide later"),
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	if m.width > 0 && m.height > 0 {
+		content = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+	}
+	return tea.NewView(content)
+}
+
+// Result returns whether the command was approved and whether the user decided at all.
+func (m *ApprovalPopup) Result() (approved, decided bool) {
+	return m.approved, m.decided
+}
+
+// RunApprovalPopup runs the approval popup and returns the user's decision.
+// decided is false if the user dismissed the popup without deciding.
+func RunApprovalPopup(taskName, command, dir string) (approved, decided bool, err error) {
+	m := NewApprovalPopup(taskName, command, dir)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, false, err
+	}
+
+	popup := finalModel.(*ApprovalPopup)
+	approved, decided = popup.Result()
+	return approved, decided, nil
+}
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// CleanPreview shows what `paw clean` will remove and lets the user opt out of items.
+type CleanPreview struct {
+	plan      *service.CleanPlan
+	selected  []bool
+	cursor    int
+	confirmed bool
+	width     int
+	isDark    bool
+	colors    ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleGroup    lipgloss.Style
+	styleDesc     lipgloss.Style
+	styleSelected lipgloss.Style
+	styleNormal   lipgloss.Style
+	styleExcluded lipgloss.Style
+	stylesCached  bool
+}
+
+// NewCleanPreview creates a new clean preview with every item selected.
+func NewCleanPreview(plan *service.CleanPlan) *CleanPreview {
+	isDark := DetectDarkMode()
+	selected := make([]bool, len(plan.Items))
+	for i := range selected {
+		selected[i] = true
+	}
+	return &CleanPreview{
+		plan:     plan,
+		selected: selected,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the clean preview.
+func (m *CleanPreview) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages and updates the model.
+func (m *CleanPreview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.plan.Items)-1 {
+				m.cursor++
+			}
+
+		case " ", "space":
+			m.toggle(m.cursor)
+
+		case "a":
+			// Select all if anything is excluded, otherwise exclude all
+			all := m.selectedCount() < len(m.selected)
+			for i := range m.selected {
+				m.selected[i] = all
+			}
+
+		case "enter":
+			if m.selectedCount() > 0 {
+				m.confirmed = true
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// toggle flips an item's selection while keeping the plan consistent:
+// keeping an item keeps what depends on it, removing an item removes what it requires.
+func (m *CleanPreview) toggle(i int) {
+	if m.selected[i] {
+		m.selected[i] = false
+		for _, j := range m.plan.Dependents(i) {
+			m.selected[j] = false
+		}
+		return
+	}
+
+	m.selected[i] = true
+	for _, j := range m.plan.Requires(i) {
+		m.selected[j] = true
+	}
+}
+
+func (m *CleanPreview) selectedCount() int {
+	n := 0
+	for _, s := range m.selected {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// View renders the clean preview.
+func (m *CleanPreview) View() tea.View {
+	c := m.colors
+
+	// Update style cache if needed (only on theme change)
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.WarningColor)
+		m.styleGroup = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.TextNormal)
+		m.styleDesc = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.styleSelected = lipgloss.NewStyle().
+			Foreground(c.Accent).
+			Bold(true)
+		m.styleNormal = lipgloss.NewStyle().
+			Foreground(c.TextNormal)
+		m.styleExcluded = lipgloss.NewStyle().
+			Foreground(c.TextDim).
+			Strikethrough(true)
+		m.stylesCached = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styleTitle.Render("🧹 Clean: " + strconv.Itoa(m.selectedCount()) + "/" + strconv.Itoa(len(m.plan.Items)) + " items will be removed"))
+	sb.WriteString("\n")
+
+	var lastKind service.CleanKind
+	for i, item := range m.plan.Items {
+		if item.Kind != lastKind {
+			sb.WriteString("\n" + m.styleGroup.Render(item.Kind.Title()) + "\n")
+			lastKind = item.Kind
+		}
+
+		cursor := "  "
+		style := m.styleNormal
+		if i == m.cursor {
+			cursor = "▸ "
+			style = m.styleSelected
+		}
+		check := "[x] "
+		if !m.selected[i] {
+			check = "[ ] "
+			style = m.styleExcluded
+		}
+
+		sb.WriteString(cursor + check + style.Render(item.Name))
+		if item.Target != item.Name {
+			sb.WriteString(m.styleDesc.Render("  " + truncateWithEllipsis(item.Target, m.width-len(item.Name)-12)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styleDesc.Render("↑/↓: Navigate  Space: Toggle  a: Toggle all  Enter: Clean  q/Esc: Cancel"))
+
+	return tea.NewView(sb.String())
+}
+
+// Result returns the items to remove, or nil if cancelled.
+func (m *CleanPreview) Result() *service.CleanPlan {
+	if !m.confirmed {
+		return nil
+	}
+	return m.plan.Select(m.selected)
+}
+
+// RunCleanPreview runs the clean preview and returns the items to remove.
+// Returns nil if the user cancelled.
+func RunCleanPreview(plan *service.CleanPlan) (*service.CleanPlan, error) {
+	m := NewCleanPreview(plan)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	ui := finalModel.(*CleanPreview)
+	return ui.Result(), nil
+}
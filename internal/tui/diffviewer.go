@@ -3,7 +3,6 @@ package tui
 
 import (
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -11,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/git"
 )
 
 // Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
@@ -845,9 +846,7 @@ func (m *DiffViewer) copySelection() {
 func (m *DiffViewer) loadDiffOutput() tea.Cmd {
 	return func() tea.Msg {
 		// git diff main...HEAD shows changes on the current branch since it diverged from main
-		cmd := exec.Command("git", "diff", "--color=always", m.mainBranch+"...HEAD") //nolint:gosec // G204: mainBranch comes from git
-		cmd.Dir = m.workDir
-		output, err := cmd.CombinedOutput()
+		output, err := git.CombinedOutput(m.workDir, "diff", "--color=always", m.mainBranch+"...HEAD")
 		if err != nil {
 			// Check if it's just an empty diff (which is not an error)
 			if len(output) == 0 {
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
@@ -18,7 +19,8 @@ const (
 	FinishActionMergePush  FinishAction = "merge-push"
 	FinishActionMerge      FinishAction = "merge"
 	FinishActionPR         FinishAction = "pr"
-	FinishActionKeep       FinishAction = "keep"
+	FinishActionKeep       FinishAction = "keep" // Commit only, keep the task open
+	FinishActionPush       FinishAction = "push" // Commit and push the branch, keep the task open
 	FinishActionDone       FinishAction = "done"
 	FinishActionDrop       FinishAction = "drop"
 	FinishActionCreateMain FinishAction = "create-main" // Create main branch and merge
@@ -32,9 +34,128 @@ type FinishOption struct {
 	Warning     bool // If true, requires confirmation
 }
 
+// FinishSummary describes the task a finish action works on. The picker
+// shows it with the steps the selected action will run.
+type FinishSummary struct {
+	Task        string
+	Branch      string   // Task branch (empty for non-git tasks)
+	MainBranch  string   // Branch the task merges into
+	Commits     int      // Commits on the task branch not yet in the main branch
+	Uncommitted int      // Files with uncommitted changes in the worktree
+	Remote      string   // Remote that pushes and PRs go to (empty if none)
+	Checks      []string // Diff checks and security scanners run before merging or opening a PR
+	Verify      []string // Verification commands run before Merge & Push and PR
+	LastVerify  string   // Result of the task's last verification run (empty if never run)
+	Conflicts   []string // Files expected to conflict when merging into the main branch
+
+	// Default is the action the task's on_complete would run; the picker
+	// starts on it so finishing can still override it for this task
+	Default FinishAction
+
+	// KeepBranch keeps the branch when the task is cleaned up. It starts at
+	// the project's keep_branch and the picker toggles it with b.
+	KeepBranch bool
+}
+
+// Steps returns what finishing the task with action will do, in order.
+func (s *FinishSummary) Steps(action FinishAction) []string {
+	if s == nil {
+		return nil
+	}
+	var steps []string
+	commit := func() {
+		if s.Uncommitted > 0 {
+			steps = append(steps, "Commit "+plural(s.Uncommitted, "uncommitted file"))
+		}
+	}
+	checks := func() {
+		if len(s.Checks) > 0 {
+			steps = append(steps, "Check the diff: "+strings.Join(s.Checks, ", "))
+		}
+	}
+	verify := func() {
+		if len(s.Verify) > 0 {
+			steps = append(steps, "Verify: "+strings.Join(s.Verify, ", ")+" (stops on failure)")
+		}
+	}
+	merge := func() {
+		commits := s.Commits
+		if s.Uncommitted > 0 {
+			commits++ // The commit of the uncommitted changes
+		}
+		steps = append(steps, fmt.Sprintf("Squash-merge %s (%s) into %s", s.Branch, plural(commits, "commit"), s.MainBranch))
+		if len(s.Conflicts) > 0 {
+			steps = append(steps, "Resolve "+plural(len(s.Conflicts), "conflicting file")+" with Claude (aborts if unresolved)")
+		}
+	}
+	cleanup := "Remove the worktree and branch, close the window"
+	if s.Branch == "" {
+		cleanup = "Clean up the task, close the window"
+	} else if s.KeepBranch && action != FinishActionDrop {
+		cleanup = "Remove the worktree, close the window (keep branch " + s.Branch + ")"
+	}
+
+	switch action { //nolint:exhaustive // Cancel changes nothing
+	case FinishActionKeep:
+		commit()
+		steps = append(steps, "Keep the task open")
+	case FinishActionPush:
+		commit()
+		steps = append(steps, fmt.Sprintf("Push %s to %s", s.Branch, s.Remote), "Keep the task open")
+	case FinishActionMergePush:
+		commit()
+		checks()
+		verify()
+		merge()
+		steps = append(steps, fmt.Sprintf("Push %s to %s", s.MainBranch, s.Remote), cleanup)
+	case FinishActionMerge:
+		commit()
+		checks()
+		merge()
+		steps = append(steps, cleanup)
+	case FinishActionCreateMain:
+		commit()
+		steps = append(steps, fmt.Sprintf("Create %s with an empty init commit", s.MainBranch))
+		merge()
+		steps = append(steps, cleanup)
+	case FinishActionPR:
+		commit()
+		checks()
+		verify()
+		steps = append(steps,
+			fmt.Sprintf("Push %s to %s", s.Branch, s.Remote),
+			fmt.Sprintf("Open a pull request into %s", s.MainBranch),
+			"Keep the task open while the PR is reviewed")
+	case FinishActionDone:
+		steps = append(steps, cleanup)
+	case FinishActionDrop:
+		var lost []string
+		if s.Uncommitted > 0 {
+			lost = append(lost, plural(s.Uncommitted, "uncommitted file"))
+		}
+		if s.Commits > 0 {
+			lost = append(lost, plural(s.Commits, "unmerged commit"))
+		}
+		if len(lost) > 0 {
+			steps = append(steps, "Discard "+strings.Join(lost, " and "))
+		}
+		steps = append(steps, cleanup)
+	}
+	return steps
+}
+
+// plural formats a count with a singular or plural noun.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 // FinishPicker is a TUI for selecting how to finish a task.
 type FinishPicker struct {
 	options       []FinishOption
+	summary       *FinishSummary
 	cursor        int
 	selected      FinishAction
 	confirming    bool // True when showing confirmation for drop action
@@ -60,6 +181,8 @@ func gitOptions() []FinishOption {
 		{Action: FinishActionMergePush, Name: "Merge & Push", Description: "Merge to main, push to remote, and clean up"},
 		{Action: FinishActionMerge, Name: "Merge", Description: "Merge branch to main (local only) and clean up"},
 		{Action: FinishActionPR, Name: "PR", Description: "Push branch and create a pull request"},
+		{Action: FinishActionPush, Name: "Commit & Push", Description: "Commit and push the branch, keep the task open"},
+		{Action: FinishActionKeep, Name: "Commit", Description: "Commit changes, keep the task open"},
 		{Action: FinishActionDrop, Name: "Drop", Description: "Discard all changes and clean up", Warning: true},
 	}
 }
@@ -68,6 +191,7 @@ func gitOptions() []FinishOption {
 func gitOptionsNoRemote() []FinishOption {
 	return []FinishOption{
 		{Action: FinishActionMerge, Name: "Merge", Description: "Merge branch to main (local only) and clean up"},
+		{Action: FinishActionKeep, Name: "Commit", Description: "Commit changes, keep the task open"},
 		{Action: FinishActionDrop, Name: "Drop", Description: "Discard all changes and clean up", Warning: true},
 	}
 }
@@ -93,7 +217,8 @@ func noMainOptions() []FinishOption {
 // hasCommits: whether there are commits to merge (only relevant if isGitRepo is true)
 // hasRemote: whether the repository has a remote origin
 // hasMainBranch: whether the main branch exists (only relevant if isGitRepo is true)
-func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) *FinishPicker {
+// summary: the task's state, shown with the steps of the selected action (may be nil)
+func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool, summary *FinishSummary) *FinishPicker {
 	logging.Debug("-> NewFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v)", isGitRepo, hasCommits, hasRemote, hasMainBranch)
 	defer logging.Debug("<- NewFinishPicker")
 
@@ -115,9 +240,21 @@ func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) *Fini
 		options = doneOptions()
 	}
 
+	// Start on the task's on_complete action
+	cursor := 0
+	if summary != nil {
+		for i, opt := range options {
+			if opt.Action == summary.Default {
+				cursor = i
+				break
+			}
+		}
+	}
+
 	return &FinishPicker{
 		options:  options,
-		cursor:   0,
+		summary:  summary,
+		cursor:   cursor,
 		selected: FinishActionCancel,
 		isDark:   isDark,
 		colors:   NewThemeColors(isDark),
@@ -229,6 +366,27 @@ func (m *FinishPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Quit
 				}
 			}
+		case "b", "B":
+			if m.summary != nil && m.summary.Branch != "" {
+				m.summary.KeepBranch = !m.summary.KeepBranch
+			}
+			return m, nil
+		case "c", "C":
+			for i, opt := range m.options {
+				if opt.Action == FinishActionKeep {
+					m.cursor = i
+					m.selected = opt.Action
+					return m, tea.Quit
+				}
+			}
+		case "s", "S":
+			for i, opt := range m.options {
+				if opt.Action == FinishActionPush {
+					m.cursor = i
+					m.selected = opt.Action
+					return m, tea.Quit
+				}
+			}
 		case "n", "N":
 			for i, opt := range m.options {
 				if opt.Action == FinishActionDone {
@@ -316,8 +474,17 @@ func (m *FinishPicker) View() tea.View {
 	}
 
 	// Title
-	sb.WriteString(m.styleTitle.Render("Finish Task"))
-	sb.WriteString("\n\n")
+	title := "Finish Task"
+	if m.summary != nil && m.summary.Task != "" {
+		title += ": " + m.summary.Task
+	}
+	sb.WriteString(m.styleTitle.Render(title))
+	sb.WriteString("\n")
+	for _, line := range m.summary.stateLines() {
+		sb.WriteString(m.styleDim.Render("  " + line))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
 
 	// Options
 	for i, opt := range m.options {
@@ -325,6 +492,9 @@ func (m *FinishPicker) View() tea.View {
 		if opt.Warning {
 			name += " (!)"
 		}
+		if m.summary != nil && opt.Action == m.summary.Default {
+			name += " (on_complete)"
+		}
 
 		if i == m.cursor {
 			sb.WriteString(m.styleSelected.Render("> " + name))
@@ -338,23 +508,81 @@ func (m *FinishPicker) View() tea.View {
 		sb.WriteString("\n")
 	}
 
+	// What the selected action will do
+	if steps := m.summary.Steps(m.options[m.cursor].Action); len(steps) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(m.styleItem.Render("Will run:"))
+		sb.WriteString("\n")
+		for i, step := range steps {
+			sb.WriteString(m.styleDesc.Render(fmt.Sprintf("%d. %s", i+1, step)))
+			sb.WriteString("\n")
+		}
+	}
+
 	// Help
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Select  ⌃F/Esc: Cancel"))
+	help := "↑/↓: Navigate  Enter: Select  ⌃F/Esc: Cancel"
+	if m.summary != nil && m.summary.Branch != "" {
+		if m.summary.KeepBranch {
+			help += "  b: Delete branch"
+		} else {
+			help += "  b: Keep branch"
+		}
+	}
+	sb.WriteString(m.styleHelp.Render(help))
 
 	return tea.NewView(sb.String())
 }
 
+// stateLines describes the task's branch, changes, push target, and
+// verification status.
+func (s *FinishSummary) stateLines() []string {
+	if s == nil {
+		return nil
+	}
+	var lines []string
+	if s.Branch != "" {
+		parts := []string{s.Branch + " → " + s.MainBranch, plural(s.Commits, "commit")}
+		if s.Uncommitted > 0 {
+			parts = append(parts, plural(s.Uncommitted, "uncommitted file"))
+		}
+		if s.Remote != "" {
+			parts = append(parts, "push to "+s.Remote)
+		} else {
+			parts = append(parts, "no remote")
+		}
+		lines = append(lines, strings.Join(parts, " · "))
+	} else if s.Uncommitted > 0 {
+		lines = append(lines, plural(s.Uncommitted, "uncommitted file"))
+	}
+	if len(s.Conflicts) > 0 {
+		files := s.Conflicts
+		if len(files) > 3 {
+			files = append(files[:3:3], fmt.Sprintf("+%d more", len(s.Conflicts)-3))
+		}
+		lines = append(lines, "Conflicts with "+s.MainBranch+": "+strings.Join(files, ", "))
+	}
+	if len(s.Verify) > 0 {
+		status := s.LastVerify
+		if status == "" {
+			status = "not run yet"
+		}
+		lines = append(lines, "Verification: "+status)
+	}
+	return lines
+}
+
 // Result returns the selected action.
 func (m *FinishPicker) Result() FinishAction {
 	return m.selected
 }
 
 // RunFinishPicker runs the finish picker and returns the selected action.
-func RunFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) (FinishAction, error) {
+// Toggling the branch with b updates summary.KeepBranch.
+func RunFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool, summary *FinishSummary) (FinishAction, error) {
 	logging.Debug("-> RunFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v)", isGitRepo, hasCommits, hasRemote, hasMainBranch)
 	defer logging.Debug("<- RunFinishPicker")
 
-	m := NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch)
+	m := NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch, summary)
 	logging.Debug("RunFinishPicker: starting tea.Program")
 	p := tea.NewProgram(m)
 
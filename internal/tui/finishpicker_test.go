@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func TestFinishSummarySteps(t *testing.T) {
+	summary := &FinishSummary{
+		Task:        "add-auth",
+		Branch:      "add-auth",
+		MainBranch:  "main",
+		Commits:     2,
+		Uncommitted: 1,
+		Remote:      "origin",
+		Checks:      []string{"gitleaks"},
+		Verify:      []string{"build", "test"},
+	}
+
+	tests := []struct {
+		action FinishAction
+		want   []string
+	}{
+		{FinishActionMergePush, []string{
+			"Commit 1 uncommitted file",
+			"Check the diff: gitleaks",
+			"Verify: build, test (stops on failure)",
+			"Squash-merge add-auth (3 commits) into main",
+			"Push main to origin",
+			"Remove the worktree and branch, close the window",
+		}},
+		{FinishActionMerge, []string{
+			"Commit 1 uncommitted file",
+			"Check the diff: gitleaks",
+			"Squash-merge add-auth (3 commits) into main",
+			"Remove the worktree and branch, close the window",
+		}},
+		{FinishActionPR, []string{
+			"Commit 1 uncommitted file",
+			"Check the diff: gitleaks",
+			"Verify: build, test (stops on failure)",
+			"Push add-auth to origin",
+			"Open a pull request into main",
+			"Keep the task open while the PR is reviewed",
+		}},
+		{FinishActionPush, []string{
+			"Commit 1 uncommitted file",
+			"Push add-auth to origin",
+			"Keep the task open",
+		}},
+		{FinishActionKeep, []string{
+			"Commit 1 uncommitted file",
+			"Keep the task open",
+		}},
+		{FinishActionDrop, []string{
+			"Discard 1 uncommitted file and 2 unmerged commits",
+			"Remove the worktree and branch, close the window",
+		}},
+	}
+	for _, tt := range tests {
+		if got := summary.Steps(tt.action); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("Steps(%s) = %q, want %q", tt.action, got, tt.want)
+		}
+	}
+
+	summary.Conflicts = []string{"auth.go"}
+	if got := summary.Steps(FinishActionMerge); got[3] != "Resolve 1 conflicting file with Claude (aborts if unresolved)" {
+		t.Errorf("Steps(merge) with conflicts = %q", got)
+	}
+
+	if steps := (*FinishSummary)(nil).Steps(FinishActionMerge); steps != nil {
+		t.Errorf("Steps() of a nil summary = %q, want nil", steps)
+	}
+	done := &FinishSummary{Task: "notes"}
+	if got := done.Steps(FinishActionDone); len(got) != 1 || got[0] != "Clean up the task, close the window" {
+		t.Errorf("Steps(done) = %q", got)
+	}
+}
+
+func TestFinishSummaryStateLines(t *testing.T) {
+	summary := &FinishSummary{Task: "add-auth", Branch: "add-auth", MainBranch: "main", Commits: 1, Uncommitted: 3, Verify: []string{"test"}}
+	want := []string{
+		"add-auth → main · 1 commit · 3 uncommitted files · no remote",
+		"Verification: not run yet",
+	}
+	if got := summary.stateLines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("stateLines() = %q, want %q", got, want)
+	}
+
+	summary.Remote = "origin"
+	summary.Uncommitted = 0
+	summary.LastVerify = "failed (test)"
+	summary.Conflicts = []string{"a.go", "b.go", "c.go", "d.go", "e.go"}
+	want = []string{
+		"add-auth → main · 1 commit · push to origin",
+		"Conflicts with main: a.go, b.go, c.go, +2 more",
+		"Verification: failed (test)",
+	}
+	if got := summary.stateLines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("stateLines() = %q, want %q", got, want)
+	}
+}
+
+func TestFinishPickerStartsOnDefault(t *testing.T) {
+	m := NewFinishPicker(true, true, true, true, &FinishSummary{Default: FinishActionPR})
+	if got := m.options[m.cursor].Action; got != FinishActionPR {
+		t.Errorf("cursor on %s, want pr", got)
+	}
+
+	// An on_complete action the picker does not offer leaves the cursor on top
+	m = NewFinishPicker(true, true, false, true, &FinishSummary{Default: FinishActionPR})
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestFinishPickerTogglesKeepBranch(t *testing.T) {
+	summary := &FinishSummary{Branch: "add-auth", MainBranch: "main"}
+	m := NewFinishPicker(true, true, false, true, summary)
+
+	m.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if !summary.KeepBranch {
+		t.Fatal("b did not turn on KeepBranch")
+	}
+	if got := summary.Steps(FinishActionMerge); got[len(got)-1] != "Remove the worktree, close the window (keep branch add-auth)" {
+		t.Errorf("Steps(merge) cleanup = %q", got[len(got)-1])
+	}
+	if got := summary.Steps(FinishActionDrop); got[len(got)-1] != "Remove the worktree and branch, close the window" {
+		t.Errorf("Steps(drop) cleanup = %q, want the branch deleted", got[len(got)-1])
+	}
+}
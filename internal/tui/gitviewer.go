@@ -3,7 +3,6 @@ package tui
 
 import (
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -11,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/git"
 )
 
 // gitMode represents different git command modes
@@ -948,25 +949,24 @@ func (m *GitViewer) copySelection() {
 // loadGitOutput loads git output based on the current mode.
 func (m *GitViewer) loadGitOutput() tea.Cmd {
 	return func() tea.Msg {
-		var cmd *exec.Cmd
+		var args []string
 
 		switch m.mode {
 		case gitModeStatus:
 			// git status with color (status doesn't support --color flag, use -c config)
-			cmd = exec.Command("git", "-c", "color.status=always", "status")
+			args = []string{"-c", "color.status=always", "status"}
 		case gitModeLog:
 			// git log with color (--color=always forces color output even for non-TTY)
-			cmd = exec.Command("git", "log", "--color=always")
+			args = []string{"log", "--color=always"}
 		case gitModeAll:
 			// git log --all --decorate --oneline --graph with color
-			cmd = exec.Command("git", "log", "--all", "--decorate", "--oneline", "--graph", "--color=always")
+			args = []string{"log", "--all", "--decorate", "--oneline", "--graph", "--color=always"}
 		case gitModeDiff:
 			// git diff main...HEAD shows changes on the current branch since it diverged from main
-			cmd = exec.Command("git", "diff", "--color=always", m.mainBranch+"...HEAD") //nolint:gosec // G204: mainBranch comes from git
+			args = []string{"diff", "--color=always", m.mainBranch + "...HEAD"}
 		}
 
-		cmd.Dir = m.workDir
-		output, err := cmd.CombinedOutput()
+		output, err := git.CombinedOutput(m.workDir, args...)
 		if err != nil {
 			return fmt.Errorf("git command failed: %w\nOutput: %s", err, string(output))
 		}
@@ -5,12 +5,13 @@ import (
 	"image/color"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 
+	"github.com/dongho-jung/paw/internal/clipboard"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/service"
 )
@@ -54,6 +55,11 @@ type KanbanView struct {
 	done           []*service.DiscoveredTask
 	taskCountCache int // Cached total task count, updated on Refresh()
 
+	// Label filter: all holds the unfiltered columns, labelFilter the label
+	// the columns above are filtered by ("" = show all)
+	all         [3][]*service.DiscoveredTask
+	labelFilter string
+
 	// Scroll state
 	scrollOffset int
 	focused      bool
@@ -171,10 +177,14 @@ func (k *KanbanView) taskAreaHeight() int {
 // Refresh updates the cached task data by discovering all tasks.
 // This should be called periodically (e.g., on tick) rather than on every render.
 func (k *KanbanView) Refresh() {
-	k.working, k.waiting, k.done = k.service.DiscoverAll()
-	// Update cached task count to avoid recalculating in isCacheValid/updateCacheState
-	k.taskCountCache = len(k.working) + len(k.waiting) + len(k.done)
-	k.invalidateCache() // Task data changed, need to re-render
+	k.SetTasks(k.service.DiscoverAll())
+}
+
+// SetTasks replaces the cached task data with already discovered tasks.
+// The current label filter is applied to them.
+func (k *KanbanView) SetTasks(working, waiting, done []*service.DiscoveredTask) {
+	k.all = [3][]*service.DiscoveredTask{working, waiting, done}
+	k.applyLabelFilter() // Task data changed, need to re-render
 }
 
 // invalidateCache marks the render cache as invalid.
@@ -281,6 +291,7 @@ func (k *KanbanView) Render() string {
 
 	columnViews := make([]string, 0, 3) // Pre-allocate for 3 columns
 	maxHeight := k.height - 2           // Reserve space for border only (no title)
+	now := time.Now()
 
 	for colIdx, col := range columns {
 		// Determine border color for this column (use foreground from cached action style for dim)
@@ -297,6 +308,9 @@ func (k *KanbanView) Render() string {
 		// Column header (use string concatenation to avoid fmt.Sprintf, use cached style)
 		colHeaderStyle := k.styleHeader.Foreground(col.color)
 		header := col.emoji + " " + col.title + " (" + strconv.Itoa(len(col.tasks)) + ")"
+		if k.labelFilter != "" {
+			header += " #" + k.labelFilter
+		}
 		content.WriteString(colHeaderStyle.Render(header))
 		content.WriteString("\n")
 		content.WriteString(k.getSeparator(columnWidth))
@@ -334,15 +348,20 @@ func (k *KanbanView) Render() string {
 				(task.StatusEmoji == constants.EmojiReview || task.StatusEmoji == constants.EmojiWarning) {
 				displayName = task.StatusEmoji + " " + displayName
 			}
-			displayName = truncateWithEllipsis(displayName, availableWidth)
+			if task.IsSnoozed(now) {
+				displayName = constants.EmojiSnoozed + " " + displayName
+			}
+			// Label chips follow the name when they fit in half the width
+			chips, chipsWidth := renderLabelChips(task.Labels, availableWidth/2)
+			displayName = truncateWithEllipsis(displayName, availableWidth-chipsWidth)
 
 			// Build task lines for scrolling (name + detail lines, use cached styles)
 			// Pre-allocate with estimated capacity (1 name line + actionLinesPerTask detail lines)
 			taskLines := make([]string, 0, 1+actionLinesPerTask)
 			if isSelected {
-				taskLines = append(taskLines, k.styleSelectedTask.Render(displayName))
+				taskLines = append(taskLines, k.styleSelectedTask.Render(displayName)+chips)
 			} else {
-				taskLines = append(taskLines, k.styleTaskName.Render(displayName))
+				taskLines = append(taskLines, k.styleTaskName.Render(displayName)+chips)
 			}
 
 			detailLines := buildTaskDetailLines(task, actionLinesPerTask, availableWidth)
@@ -844,7 +863,7 @@ func (k *KanbanView) CopySelection() error {
 	}
 
 	k.selectedText = strings.Join(selectedLines, "\n")
-	return clipboard.WriteAll(k.selectedText)
+	return clipboard.Write(k.selectedText)
 }
 
 // displayColToByteOffset converts a display column position to a byte offset in the string.
@@ -1113,6 +1132,26 @@ func buildTaskDetailLines(task *service.DiscoveredTask, maxLines, availableWidth
 		}
 	}
 
+	// Waiting tasks show the question they asked
+	if task.Question != "" {
+		baseLines = append(baseLines, "❓ "+task.Question)
+	}
+	if task.Review != "" {
+		baseLines = append(baseLines, "🔍 "+task.Review)
+	}
+	if task.Cost != "" {
+		baseLines = append(baseLines, "💰 "+task.Cost)
+	}
+	if task.DependsOn != "" {
+		baseLines = append(baseLines, "⛓ after "+task.DependsOn)
+	}
+	if task.Parent != "" {
+		baseLines = append(baseLines, "↳ on "+task.Parent)
+	}
+	if task.FollowUp != "" {
+		baseLines = append(baseLines, "↪ follows "+task.FollowUp)
+	}
+
 	if metadata != "" {
 		for _, line := range baseLines {
 			if strings.Contains(line, metadata) {
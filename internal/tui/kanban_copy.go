@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+
+	"github.com/dongho-jung/paw/internal/clipboard"
+	"github.com/dongho-jung/paw/internal/github"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// copyNoticeDuration is how long the copy result stays in the header.
+const copyNoticeDuration = 3 * time.Second
+
+// copyNoticeMsg reports the result of copying task metadata.
+type copyNoticeMsg struct {
+	text string
+}
+
+// copyNoticeClearMsg triggers a redraw after the copy notice expires.
+type copyNoticeClearMsg struct{}
+
+// copyTaskMetadata copies a field of the task to the clipboard:
+// b = branch, w = worktree path, u = PR URL, y = status line.
+func copyTaskMetadata(task *service.DiscoveredTask, key string) tea.Cmd {
+	return func() tea.Msg {
+		label, value, err := taskMetadata(task, key)
+		if err == nil {
+			err = clipboard.Write(value)
+		}
+		if err != nil {
+			return copyNoticeMsg{text: "⚠️  " + err.Error()}
+		}
+		return copyNoticeMsg{text: fmt.Sprintf("📋 Copied %s: %s", label, value)}
+	}
+}
+
+// taskMetadata returns the name and value of the field for the key.
+func taskMetadata(task *service.DiscoveredTask, key string) (string, string, error) {
+	switch key {
+	case "b":
+		return "branch", task.Branch(), nil
+	case "w":
+		dir := task.WorktreeDir()
+		if dir == "" {
+			return "", "", errors.New("task has no worktree")
+		}
+		return "worktree path", dir, nil
+	case "u":
+		n := task.PRNumber()
+		if n == 0 {
+			return "", "", errors.New("task has no PR")
+		}
+		status, err := github.New().GetPRStatus(task.WorktreeDir(), n)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get PR #%d: %w", n, err)
+		}
+		return "PR URL", status.URL, nil
+	case "y":
+		return "status", task.StatusLine(), nil
+	}
+	return "", "", fmt.Errorf("unknown copy key %q", key)
+}
+
+// cloneTask loads the task's content and options into the new-task input.
+func (m *TaskInput) cloneTask(task *service.DiscoveredTask) tea.Cmd {
+	if task.AgentDir == "" {
+		return noticeCmd("⚠️  task workspace not found")
+	}
+	clone, err := service.LoadTaskClone(task.Name, task.AgentDir)
+	if err != nil {
+		return noticeCmd("⚠️  " + err.Error())
+	}
+	m.applyClone(clone)
+	return noticeCmd(cloneNotice(clone))
+}
+
+// followUpTask pre-fills the new-task input with a follow-up to the task.
+func (m *TaskInput) followUpTask(task *service.DiscoveredTask) tea.Cmd {
+	if task.AgentDir == "" {
+		return noticeCmd("⚠️  task workspace not found")
+	}
+	var branch string
+	if task.WorktreeDir() != "" {
+		branch = task.Branch()
+	}
+	followUp, err := service.LoadFollowUp(task.Name, task.AgentDir, branch)
+	if err != nil {
+		return noticeCmd("⚠️  " + err.Error())
+	}
+	m.applyClone(followUp)
+	return noticeCmd(cloneNotice(followUp))
+}
+
+// noticeCmd shows text in the header like a copy result.
+func noticeCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		return copyNoticeMsg{text: text}
+	}
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// labelChipColors maps well-known labels to chip background colors.
+// Other labels get a stable color from labelChipPalette.
+var labelChipColors = map[string]string{
+	"bug":     "160", // Red
+	"feature": "28",  // Green
+	"chore":   "243", // Gray
+}
+
+// labelChipPalette holds background colors for other labels.
+var labelChipPalette = []string{"25", "97", "130", "31", "133", "94"}
+
+// labelChipStyle returns the chip style for a label.
+func labelChipStyle(label string) lipgloss.Style {
+	color, ok := labelChipColors[label]
+	if !ok {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(label))
+		color = labelChipPalette[h.Sum32()%uint32(len(labelChipPalette))]
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("231")).
+		Background(lipgloss.Color(color)).
+		Padding(0, 1)
+}
+
+// renderLabelChips renders labels as colored chips that fit in maxWidth,
+// returning the chips and their visible width. Labels that don't fit are dropped.
+func renderLabelChips(labels []string, maxWidth int) (string, int) {
+	var chips string
+	width := 0
+	for _, label := range labels {
+		chipWidth := 1 + len(label) + 2 // leading space + padding
+		if width+chipWidth > maxWidth {
+			break
+		}
+		chips += " " + labelChipStyle(label).Render(label)
+		width += chipWidth
+	}
+	return chips, width
+}
+
+// LabelFilter returns the label the board is filtered by ("" for none).
+func (k *KanbanView) LabelFilter() string {
+	return k.labelFilter
+}
+
+// Labels returns the distinct labels of all discovered tasks, sorted.
+func (k *KanbanView) Labels() []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, tasks := range k.all {
+		for _, task := range tasks {
+			for _, label := range task.Labels {
+				if !seen[label] {
+					seen[label] = true
+					labels = append(labels, label)
+				}
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// CycleLabelFilter filters the board by the next label, then clears the
+// filter after the last one.
+func (k *KanbanView) CycleLabelFilter() {
+	labels := k.Labels()
+	next := ""
+	if k.labelFilter == "" {
+		if len(labels) > 0 {
+			next = labels[0]
+		}
+	} else {
+		for i, label := range labels {
+			if label == k.labelFilter && i+1 < len(labels) {
+				next = labels[i+1]
+				break
+			}
+		}
+	}
+	k.labelFilter = next
+	k.applyLabelFilter()
+	for col := range k.selectedTaskIdx {
+		k.selectedTaskIdx[col] = -1
+	}
+	k.InitializeColumnSelection(k.focusedCol)
+}
+
+// applyLabelFilter fills the columns from the discovered tasks, keeping only
+// tasks with the filter label. A filter whose label no longer exists is cleared.
+func (k *KanbanView) applyLabelFilter() {
+	if k.labelFilter != "" {
+		found := false
+		for _, label := range k.Labels() {
+			found = found || label == k.labelFilter
+		}
+		if !found {
+			k.labelFilter = ""
+		}
+	}
+
+	columns := [3][]*service.DiscoveredTask{}
+	for col, tasks := range k.all {
+		if k.labelFilter == "" {
+			columns[col] = tasks
+			continue
+		}
+		for _, task := range tasks {
+			if task.HasLabel(k.labelFilter) {
+				columns[col] = append(columns[col], task)
+			}
+		}
+	}
+	k.working, k.waiting, k.done = columns[0], columns[1], columns[2]
+	k.taskCountCache = len(k.working) + len(k.waiting) + len(k.done)
+	k.invalidateCache()
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// snapshotActionLines is the number of detail lines per task in a snapshot.
+const snapshotActionLines = 3
+
+// RenderSnapshot renders the whole board at the given width for sharing,
+// without focus, selection, or scrolling. The height grows to fit every task.
+func (k *KanbanView) RenderSnapshot(width int) string {
+	most := max(len(k.working), len(k.waiting), len(k.done), 1)
+	k.focused = false
+	k.hasSelection = false
+	k.scrollOffset = 0
+	// Border (2) + header + enough lines for each task's name and details
+	k.SetSize(width, 2+kanbanHeaderLines+most*(1+snapshotActionLines))
+	return k.Render()
+}
+
+// snapshotStyle is the text style carried across SGR sequences.
+type snapshotStyle struct {
+	fg, bg                         color.Color
+	bold, faint, italic, underline bool
+}
+
+// css returns the inline CSS for the style ("" for the default style).
+func (s snapshotStyle) css() string {
+	var parts []string
+	if s.fg != nil {
+		parts = append(parts, "color:"+cssColor(s.fg))
+	}
+	if s.bg != nil {
+		parts = append(parts, "background:"+cssColor(s.bg))
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.faint {
+		parts = append(parts, "opacity:.7")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+func cssColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// apply updates the style from SGR parameters such as "1;38;5;39".
+func (s *snapshotStyle) apply(params string) {
+	if params == "" {
+		*s = snapshotStyle{}
+		return
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		n, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case n == 0:
+			*s = snapshotStyle{}
+		case n == 1:
+			s.bold = true
+		case n == 2:
+			s.faint = true
+		case n == 3:
+			s.italic = true
+		case n == 4:
+			s.underline = true
+		case n == 22:
+			s.bold, s.faint = false, false
+		case n == 23:
+			s.italic = false
+		case n == 24:
+			s.underline = false
+		case n >= 30 && n <= 37:
+			s.fg = ansi.BasicColor(n - 30)
+		case n >= 90 && n <= 97:
+			s.fg = ansi.BasicColor(n - 90 + 8)
+		case n >= 40 && n <= 47:
+			s.bg = ansi.BasicColor(n - 40)
+		case n >= 100 && n <= 107:
+			s.bg = ansi.BasicColor(n - 100 + 8)
+		case n == 39:
+			s.fg = nil
+		case n == 49:
+			s.bg = nil
+		case n == 38 || n == 48:
+			c, used := parseExtendedColor(codes[i+1:])
+			i += used
+			if n == 38 {
+				s.fg = c
+			} else {
+				s.bg = c
+			}
+		}
+	}
+}
+
+// parseExtendedColor parses the parameters after 38/48 ("5;N" or "2;R;G;B"),
+// returning the color and how many parameters it used.
+func parseExtendedColor(codes []string) (color.Color, int) {
+	num := func(i int) int {
+		if i >= len(codes) {
+			return 0
+		}
+		n, _ := strconv.Atoi(codes[i])
+		return n
+	}
+	switch num(0) {
+	case 5:
+		return ansi.IndexedColor(uint8(num(1))), 2 //nolint:gosec // G115: palette index
+	case 2:
+		return ansi.RGBColor{R: uint8(num(1)), G: uint8(num(2)), B: uint8(num(3))}, 4 //nolint:gosec // G115: color channels
+	}
+	return nil, 1
+}
+
+// ANSIToHTML converts styled terminal output to HTML spans. Only SGR
+// sequences are kept; other escape sequences are dropped.
+func ANSIToHTML(s string) string {
+	var sb strings.Builder
+	var style snapshotStyle
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		escaped := html.EscapeString(text.String())
+		if css := style.css(); css != "" {
+			sb.WriteString(`<span style="` + css + `">` + escaped + `</span>`)
+		} else {
+			sb.WriteString(escaped)
+		}
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\x1b' {
+			text.WriteByte(s[i])
+			continue
+		}
+		if i+1 >= len(s) || s[i+1] != '[' {
+			continue
+		}
+		// CSI: parameters until the final byte (0x40-0x7e)
+		j := i + 2
+		for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+			j++
+		}
+		if j >= len(s) {
+			break
+		}
+		if s[j] == 'm' {
+			flush()
+			style.apply(s[i+2 : j])
+		}
+		i = j
+	}
+	flush()
+	return sb.String()
+}
+
+// SnapshotHTML wraps a rendered board in a standalone HTML page.
+func SnapshotHTML(board string, isDark bool, taken time.Time) string {
+	fg, bg := "#1f1f1f", "#ffffff"
+	if isDark {
+		fg, bg = "#d0d0d0", "#1c1c1c"
+	}
+	title := "PAW board " + taken.Format("2006-01-02 15:04")
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	sb.WriteString("<style>\n")
+	sb.WriteString("body { margin: 0; padding: 16px; color: " + fg + "; background: " + bg + "; }\n")
+	sb.WriteString("h1 { font: bold 14px sans-serif; }\n")
+	sb.WriteString("pre { font: 13px/1.25 Menlo, Consolas, \"DejaVu Sans Mono\", monospace; }\n")
+	sb.WriteString("</style>\n</head>\n<body>\n")
+	sb.WriteString("<h1>" + html.EscapeString(title) + "</h1>\n")
+	sb.WriteString("<pre>" + ANSIToHTML(board) + "</pre>\n")
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
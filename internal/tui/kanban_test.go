@@ -2,7 +2,10 @@
 package tui
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 func TestCalculateActionLinesPerTask(t *testing.T) {
@@ -302,3 +305,60 @@ func BenchmarkNormalizePreviewLine(b *testing.B) {
 		}
 	}
 }
+
+func TestKanbanCycleLabelFilter(t *testing.T) {
+	k := NewKanbanView(true)
+	bug := &service.DiscoveredTask{Name: "fix-login", Labels: []string{"bug"}}
+	feature := &service.DiscoveredTask{Name: "add-export", Labels: []string{"feature"}}
+	plain := &service.DiscoveredTask{Name: "tidy-up"}
+	k.SetTasks([]*service.DiscoveredTask{bug, plain}, []*service.DiscoveredTask{feature}, nil)
+
+	want := []struct {
+		filter string
+		count  int
+	}{
+		{"bug", 1},
+		{"feature", 1},
+		{"", 3},
+	}
+	for _, w := range want {
+		k.CycleLabelFilter()
+		if k.LabelFilter() != w.filter {
+			t.Fatalf("LabelFilter() = %q, want %q", k.LabelFilter(), w.filter)
+		}
+		if got := len(k.working) + len(k.waiting) + len(k.done); got != w.count {
+			t.Errorf("filter %q shows %d tasks, want %d", w.filter, got, w.count)
+		}
+	}
+
+	// A filter whose label disappears is cleared
+	k.CycleLabelFilter()
+	k.SetTasks([]*service.DiscoveredTask{plain}, nil, nil)
+	if k.LabelFilter() != "" {
+		t.Errorf("LabelFilter() = %q after label disappeared, want empty", k.LabelFilter())
+	}
+}
+
+func TestANSIToHTML(t *testing.T) {
+	got := ANSIToHTML("\x1b[1;38;5;196mBug <1>\x1b[m plain \x1b[48;2;0;128;255mchip\x1b[0m\x1b[?25l")
+	want := `<span style="color:#ff0000;font-weight:bold">Bug &lt;1&gt;</span> plain <span style="background:#0080ff">chip</span>`
+	if got != want {
+		t.Errorf("ANSIToHTML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestKanbanRenderSnapshotFitsAllTasks(t *testing.T) {
+	k := NewKanbanView(true)
+	var working []*service.DiscoveredTask
+	for _, name := range []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot"} {
+		working = append(working, &service.DiscoveredTask{Session: "proj", Name: name, Status: service.DiscoveredWorking})
+	}
+	k.SetTasks(working, nil, nil)
+
+	board := k.RenderSnapshot(120)
+	for _, task := range working {
+		if !strings.Contains(board, task.Name) {
+			t.Errorf("snapshot is missing task %q", task.Name)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Keybindings hint of the patch review status bar
+const (
+	patchReviewHint      = "y:apply n:reject j/k ⌃D/⌃U:scroll esc:later"
+	patchReviewHintShort = "y:apply n:reject"
+)
+
+// PatchReview shows a patch a pair-mode agent proposed and asks the user to
+// apply or reject it. Rejecting asks for optional feedback for the agent.
+type PatchReview struct {
+	taskName string
+	summary  string
+	lines    []string
+
+	scrollPos int
+	width     int
+	height    int
+	isDark    bool
+	colors    ThemeColors
+
+	feedbackMode bool
+	feedback     string
+
+	approved bool
+	decided  bool
+
+	// Style cache (reused across renders)
+	styleTitle   lipgloss.Style
+	styleAdd     lipgloss.Style
+	styleDel     lipgloss.Style
+	styleHunk    lipgloss.Style
+	styleHeader  lipgloss.Style
+	styleStatus  lipgloss.Style
+	stylesCached bool
+}
+
+// NewPatchReview creates a new patch review model.
+func NewPatchReview(taskName, summary, patch string) *PatchReview {
+	isDark := DetectDarkMode()
+	return &PatchReview{
+		taskName: taskName,
+		summary:  summary,
+		lines:    strings.Split(strings.TrimRight(patch, "\n"), "\n"),
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the review.
+func (m *PatchReview) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *PatchReview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.scrollBy(0)
+		return m, nil
+
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.scrollBy(-3)
+		case tea.MouseWheelDown:
+			m.scrollBy(3)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.feedbackMode {
+			return m.handleFeedbackKey(msg)
+		}
+		switch msg.String() {
+		case "y", "Y":
+			m.approved = true
+			m.decided = true
+			return m, tea.Quit
+		case "n", "N":
+			m.feedbackMode = true
+			m.feedback = ""
+		case "esc", "q", "ctrl+c":
+			// Decide later (⌥A reopens the review)
+			return m, tea.Quit
+		case "down", "j":
+			m.scrollBy(1)
+		case "up", "k":
+			m.scrollBy(-1)
+		case "ctrl+d", "pgdown", "space":
+			m.scrollBy(m.contentHeight() / 2)
+		case "ctrl+u", "pgup":
+			m.scrollBy(-m.contentHeight() / 2)
+		case "g", "home":
+			m.scrollPos = 0
+		case "G", "end":
+			m.scrollBy(len(m.lines))
+		}
+	}
+	return m, nil
+}
+
+// handleFeedbackKey edits the rejection feedback; Enter rejects, Esc goes
+// back to the patch.
+func (m *PatchReview) handleFeedbackKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.approved = false
+		m.decided = true
+		return m, tea.Quit
+	case "esc":
+		m.feedbackMode = false
+	case "ctrl+c":
+		return m, tea.Quit
+	case "backspace":
+		if r := []rune(m.feedback); len(r) > 0 {
+			m.feedback = string(r[:len(r)-1])
+		}
+	case "space":
+		m.feedback += " "
+	default:
+		if text := msg.Key().Text; text != "" {
+			m.feedback += text
+		}
+	}
+	return m, nil
+}
+
+// scrollBy moves the view by n lines, keeping it within the patch.
+func (m *PatchReview) scrollBy(n int) {
+	maxPos := max(0, len(m.lines)-m.contentHeight())
+	m.scrollPos = min(max(0, m.scrollPos+n), maxPos)
+}
+
+// contentHeight returns the height available for the patch (title and
+// status bar excluded).
+func (m *PatchReview) contentHeight() int {
+	return max(1, m.height-2)
+}
+
+// View renders the review.
+func (m *PatchReview) View() tea.View {
+	if m.width == 0 || m.height == 0 {
+		return tea.NewView("Loading...")
+	}
+
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.WarningColor)
+		m.styleAdd = lipgloss.NewStyle().Foreground(c.SuccessColor)
+		m.styleDel = lipgloss.NewStyle().Foreground(c.ErrorColor)
+		m.styleHunk = lipgloss.NewStyle().Foreground(c.AccentSecondary)
+		m.styleHeader = lipgloss.NewStyle().Bold(true).Foreground(c.TextBright)
+		m.styleStatus = lipgloss.NewStyle().Background(c.StatusBar).Foreground(c.StatusBarText)
+		m.stylesCached = true
+	}
+
+	var sb strings.Builder
+	title := "🩹 " + m.taskName + " proposes a patch"
+	if m.summary != "" {
+		title += ": " + m.summary
+	}
+	sb.WriteString(m.styleTitle.Render(truncateWithEllipsis(title, m.width)))
+	sb.WriteString("\n")
+
+	contentHeight := m.contentHeight()
+	end := min(m.scrollPos+contentHeight, len(m.lines))
+	for _, line := range m.lines[m.scrollPos:end] {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if ansi.StringWidth(line) > m.width {
+			line = ansi.Cut(line, 0, m.width)
+		}
+		sb.WriteString(m.styleLine(line))
+		sb.WriteString("\n")
+	}
+	for i := end - m.scrollPos; i < contentHeight; i++ {
+		sb.WriteString("\n")
+	}
+
+	var status string
+	if m.feedbackMode {
+		status = " Reject; tell the agent why (Enter: reject, Esc: back): " + m.feedback + "█"
+		status = truncateWithEllipsis(status, m.width)
+		status += getPadding(m.width - ansi.StringWidth(status))
+	} else {
+		status = " Lines " + strconv.Itoa(m.scrollPos+1) + "-" + strconv.Itoa(end) + " of " + strconv.Itoa(len(m.lines)) + " "
+		hint := patchReviewHint
+		if ansi.StringWidth(status)+ansi.StringWidth(hint) > m.width {
+			hint = patchReviewHintShort
+		}
+		status += getPadding(m.width-ansi.StringWidth(status)-ansi.StringWidth(hint)) + hint
+	}
+	sb.WriteString(m.styleStatus.Render(status))
+
+	v := tea.NewView(sb.String())
+	v.AltScreen = true
+	v.MouseMode = tea.MouseModeCellMotion
+	return v
+}
+
+// styleLine colors a unified diff line.
+func (m *PatchReview) styleLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff "):
+		return m.styleHeader.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return m.styleHunk.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return m.styleAdd.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return m.styleDel.Render(line)
+	}
+	return line
+}
+
+// Result returns whether the patch was approved, the feedback given with a
+// rejection, and whether the user decided at all.
+func (m *PatchReview) Result() (approved bool, feedback string, decided bool) {
+	return m.approved, strings.TrimSpace(m.feedback), m.decided
+}
+
+// RunPatchReview runs the patch review and returns the user's decision.
+// decided is false if the user closed the review without deciding.
+func RunPatchReview(taskName, summary, patch string) (approved bool, feedback string, decided bool, err error) {
+	m := NewPatchReview(taskName, summary, patch)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, "", false, err
+	}
+
+	review := finalModel.(*PatchReview)
+	approved, feedback, decided = review.Result()
+	return approved, feedback, decided, nil
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// QuickReplyTask is a waiting task offered in the quick-reply popup.
+type QuickReplyTask struct {
+	Name     string
+	Question string // Question the agent asked ("" if unknown)
+}
+
+// QuickReply lists waiting tasks and sends a short reply to the selected one.
+type QuickReply struct {
+	tasks            []QuickReplyTask
+	cursor           int
+	input            textinput.Model
+	inputOffset      int
+	inputOffsetRight int
+	sent             bool
+
+	width  int
+	height int
+	isDark bool
+	colors ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleTask     lipgloss.Style
+	styleSelected lipgloss.Style
+	styleQuestion lipgloss.Style
+	styleInput    lipgloss.Style
+	styleHelp     lipgloss.Style
+	stylesCached  bool
+}
+
+// NewQuickReply creates a quick-reply popup for the given waiting tasks.
+func NewQuickReply(tasks []QuickReplyTask) *QuickReply {
+	isDark := DetectDarkMode()
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "Reply (e.g., yes, go ahead)"
+	ti.Focus()
+	ti.SetWidth(60)
+	ti.VirtualCursor = false
+
+	return &QuickReply{
+		tasks:  tasks,
+		input:  ti,
+		isDark: isDark,
+		colors: NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the popup.
+func (m *QuickReply) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return nil
+	}
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *QuickReply) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if inputWidth := min(60, m.width-10); inputWidth > 20 {
+			m.input.SetWidth(inputWidth)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "tab":
+			if m.cursor < len(m.tasks)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			if strings.TrimSpace(m.input.Value()) == "" || len(m.tasks) == 0 {
+				return m, nil
+			}
+			m.sent = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	syncTextInputOffset([]rune(m.input.Value()), m.input.Position(), m.input.Width(), &m.inputOffset, &m.inputOffsetRight)
+	return m, cmd
+}
+
+// View renders the popup.
+func (m *QuickReply) View() tea.View {
+	c := m.colors
+
+	maxWidth := 70
+	if m.width > 0 {
+		maxWidth = max(10, m.width-4)
+	}
+
+	// Update style cache if needed (only on theme change)
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.Accent)
+		m.styleTask = lipgloss.NewStyle().
+			Foreground(c.TextNormal)
+		m.styleSelected = lipgloss.NewStyle().
+			Foreground(c.TextInverted).
+			Background(c.Accent).
+			Bold(true)
+		m.styleQuestion = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.styleInput = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(c.BorderFocused).
+			Padding(0, 1)
+		m.styleHelp = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.stylesCached = true
+	}
+
+	var sb strings.Builder
+	line := 0
+
+	sb.WriteString(m.styleTitle.Render("💬 Quick Reply"))
+	sb.WriteString("\n\n")
+	line += 2
+
+	for i, t := range m.tasks {
+		name := truncateWithEllipsis(t.Name, maxWidth-2)
+		if i == m.cursor {
+			sb.WriteString("> " + m.styleSelected.Render(name))
+		} else {
+			sb.WriteString("  " + m.styleTask.Render(name))
+		}
+		sb.WriteString("\n")
+		line++
+		if t.Question != "" {
+			sb.WriteString("    " + m.styleQuestion.Render(truncateWithEllipsis(t.Question, maxWidth-4)))
+			sb.WriteString("\n")
+			line++
+		}
+	}
+	sb.WriteString("\n")
+	line++
+
+	// Input - use custom rendering for proper Korean/CJK cursor positioning
+	inputRender := renderTextInput(m.input.Value(), m.input.Position(), m.input.Width(), m.input.Placeholder, m.inputOffset, m.inputOffsetRight)
+	inputBoxTopY := line
+	sb.WriteString(m.styleInput.Render(inputRender.Text))
+	sb.WriteString("\n")
+	sb.WriteString(m.styleHelp.Render("↑/↓: Select task  Enter: Send  Esc: Cancel"))
+
+	v := tea.NewView(sb.String())
+	v.AltScreen = true
+	if m.input.Focused() {
+		// Cursor position: X = border(1) + padding(1) + cursorX
+		// Y = inputBoxTopY + 1 (skip top border row to reach content row)
+		cursor := tea.NewCursor(2+inputRender.CursorX, inputBoxTopY+1)
+		cursor.Blink = m.input.Styles.Cursor.Blink
+		cursor.Color = m.input.Styles.Cursor.Color
+		cursor.Shape = m.input.Styles.Cursor.Shape
+		v.Cursor = cursor
+	}
+	return v
+}
+
+// Result returns the selected task index and reply, or -1 if nothing was sent.
+func (m *QuickReply) Result() (int, string) {
+	if !m.sent {
+		return -1, ""
+	}
+	return m.cursor, strings.TrimSpace(m.input.Value())
+}
+
+// RunQuickReply runs the quick-reply popup and returns the selected task index
+// and reply. The index is -1 if the user cancelled.
+func RunQuickReply(tasks []QuickReplyTask) (int, string, error) {
+	m := NewQuickReply(tasks)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return -1, "", err
+	}
+
+	index, reply := finalModel.(*QuickReply).Result()
+	return index, reply, nil
+}
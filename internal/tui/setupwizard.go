@@ -0,0 +1,718 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// SetupTesters check notification channel settings from the setup wizard.
+// A nil tester disables the test key for that channel.
+type SetupTesters struct {
+	Slack func(token string) (string, error) // Returns the workspace the token belongs to
+	Ntfy  func(server, topic string) error   // Sends a test notification
+}
+
+type setupStepKind int
+
+const (
+	setupSelect setupStepKind = iota
+	setupText
+	setupSummary
+)
+
+type setupOption struct {
+	label string
+	desc  string
+}
+
+// setupStep is one page of the setup wizard. Select steps read and write the
+// config through selected/choose; text steps through value/setValue.
+type setupStep struct {
+	kind  setupStepKind
+	title string
+	help  string
+	note  func(w *SetupWizard) string // Optional line shown under help
+
+	options  []setupOption
+	selected func(w *SetupWizard) int
+	choose   func(w *SetupWizard, i int)
+
+	placeholder string
+	value       func(w *SetupWizard) string
+	setValue    func(w *SetupWizard, v string)
+	validate    func(v string) error
+	test        func(w *SetupWizard, v string) tea.Cmd
+
+	skip func(w *SetupWizard) bool
+}
+
+// setupTestResultMsg carries the result of a channel test.
+type setupTestResultMsg struct {
+	step   int
+	result string
+	err    error
+}
+
+var ntfyTopicRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// SetupWizard is a step-by-step TUI that edits a project config.
+type SetupWizard struct {
+	cfg      *config.Config
+	base     *config.Config // cfg before a preset was applied
+	preset   int            // Selected profile: 0 = custom, i = Presets()[i-1]
+	detected service.DetectedCommands
+	testers  SetupTesters
+	slack    bool
+	ntfy     bool
+
+	steps  []setupStep
+	index  int
+	cursor int
+	input  textinput.Model
+
+	errMsg     string
+	testStatus string
+	testing    bool
+	saved      bool
+
+	width  int
+	isDark bool
+	colors ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleStep     lipgloss.Style
+	styleHelp     lipgloss.Style
+	styleItem     lipgloss.Style
+	styleSelected lipgloss.Style
+	styleDesc     lipgloss.Style
+	styleError    lipgloss.Style
+	styleSuccess  lipgloss.Style
+	styleKeys     lipgloss.Style
+	stylesCached  bool
+}
+
+// NewSetupWizard creates a setup wizard that edits a copy of cfg.
+// Command fields cfg leaves empty are pre-filled from detected.
+func NewSetupWizard(cfg *config.Config, detected service.DetectedCommands, testers SetupTesters) *SetupWizard {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	isDark := DetectDarkMode()
+
+	ti := textinput.New()
+	ti.Prompt = "› "
+	ti.CharLimit = 256
+	ti.SetWidth(60)
+
+	w := &SetupWizard{
+		cfg:      cfg.Clone(),
+		detected: detected,
+		testers:  testers,
+		slack:    cfg.Notifications.Slack(),
+		ntfy:     cfg.Notifications.Ntfy(),
+		input:    ti,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+	detected.FillEmpty(&w.cfg.Commands)
+	w.base = w.cfg.Clone()
+	w.steps = setupSteps()
+	w.enterStep(0)
+	return w
+}
+
+// setupSteps returns the wizard pages in order.
+func setupSteps() []setupStep {
+	commandStep := func(title, help, placeholder string, field func(c *config.Commands) *string, detected func(d *service.DetectedCommands) service.DetectedCommand) setupStep {
+		return setupStep{
+			kind:  setupText,
+			title: title,
+			help:  help,
+			note: func(w *SetupWizard) string {
+				dc := detected(&w.detected)
+				if dc.Command == "" || dc.Command != w.input.Value() {
+					return ""
+				}
+				return "Detected from " + dc.Source
+			},
+			placeholder: placeholder,
+			value:       func(w *SetupWizard) string { return *field(&w.cfg.Commands) },
+			setValue:    func(w *SetupWizard, v string) { *field(&w.cfg.Commands) = v },
+		}
+	}
+	yesNo := func(yes, no string) []setupOption {
+		return []setupOption{{"Yes", yes}, {"No", no}}
+	}
+
+	profiles := []setupOption{{"Custom", "Keep the current settings and choose each one"}}
+	for _, p := range config.Presets() {
+		profiles = append(profiles, setupOption{p.Name, p.Description})
+	}
+	onComplete := []setupOption{
+		{constants.OnCompleteConfirm, "Wait for you to review and finish (⌃F)"},
+		{constants.ActionMerge, "Merge into the main branch automatically"},
+		{constants.ActionMergePush, "Merge and push automatically"},
+		{constants.ActionPR, "Open a pull request automatically"},
+	}
+
+	return []setupStep{
+		{
+			kind:     setupSelect,
+			title:    "Profile",
+			help:     "Presets set completion, verification, and permission settings together. You can still change each one on the next pages.",
+			options:  profiles,
+			selected: func(w *SetupWizard) int { return w.preset },
+			choose: func(w *SetupWizard, i int) {
+				if i == w.preset {
+					return
+				}
+				w.preset = i
+				cfg := w.base.Clone()
+				if i > 0 {
+					config.Presets()[i-1].Apply(cfg)
+				}
+				w.cfg = cfg
+			},
+		},
+		commandStep("Build command",
+			"Listed in every task's prompt so agents don't have to rediscover how to build the project. Leave empty to skip.",
+			"e.g. go build ./...", func(c *config.Commands) *string { return &c.Build },
+			func(d *service.DetectedCommands) service.DetectedCommand { return d.Build }),
+		commandStep("Test command",
+			"How agents (and verify-before-push) run the tests.",
+			"e.g. go test ./...", func(c *config.Commands) *string { return &c.Test },
+			func(d *service.DetectedCommands) service.DetectedCommand { return d.Test }),
+		commandStep("Lint command",
+			"Optional static checks run before the tests.",
+			"e.g. go vet ./...", func(c *config.Commands) *string { return &c.Lint },
+			func(d *service.DetectedCommands) service.DetectedCommand { return d.Lint }),
+		{
+			kind:    setupSelect,
+			title:   "Verify before push",
+			help:    "Run the build, lint, and test commands in the task worktree before Merge & Push or PR.",
+			options: yesNo("Failures keep the task open and nothing is pushed", "Push without running the commands"),
+			selected: func(w *SetupWizard) int {
+				if w.cfg.VerifyBeforePush {
+					return 0
+				}
+				return 1
+			},
+			choose: func(w *SetupWizard, i int) { w.cfg.VerifyBeforePush = i == 0 },
+			skip:   func(w *SetupWizard) bool { return len(w.cfg.Commands.VerifyEntries()) == 0 },
+		},
+		{
+			kind:    setupSelect,
+			title:   "When a task is done",
+			help:    "What happens when an agent reports its task done. Tasks that end on a failure always wait for you.",
+			options: onComplete,
+			selected: func(w *SetupWizard) int {
+				for i, opt := range onComplete {
+					if opt.label == w.cfg.OnComplete {
+						return i
+					}
+				}
+				return 0
+			},
+			choose: func(w *SetupWizard, i int) { w.cfg.OnComplete = onComplete[i].label },
+		},
+		{
+			kind:    setupSelect,
+			title:   "Skip permission prompts",
+			help:    "Start agents with --dangerously-skip-permissions. Approval commands still ask first.",
+			options: yesNo("Agents run tools without asking", "Claude asks before running tools"),
+			selected: func(w *SetupWizard) int {
+				if w.cfg.SkipPermissions {
+					return 0
+				}
+				return 1
+			},
+			choose: func(w *SetupWizard, i int) { w.cfg.SkipPermissions = i == 0 },
+		},
+		{
+			kind:  setupSelect,
+			title: "Notifications",
+			help:  "Desktop notifications are always on. Add a remote channel to get task updates on your phone or in chat.",
+			options: []setupOption{
+				{"Desktop only", "No remote channel"},
+				{"Slack", "Post to a channel with a bot token (chat:write scope)"},
+				{"ntfy", "Push to an ntfy topic (ntfy.sh or self-hosted)"},
+				{"Slack + ntfy", "Both channels"},
+			},
+			selected: func(w *SetupWizard) int {
+				i := 0
+				if w.slack {
+					i++
+				}
+				if w.ntfy {
+					i += 2
+				}
+				return i
+			},
+			choose: func(w *SetupWizard, i int) {
+				w.slack = i == 1 || i == 3
+				w.ntfy = i == 2 || i == 3
+				if !w.slack {
+					w.cfg.Notifications.SlackToken, w.cfg.Notifications.SlackChannel = "", ""
+				}
+				if !w.ntfy {
+					w.cfg.Notifications.NtfyTopic, w.cfg.Notifications.NtfyServer = "", ""
+				}
+			},
+		},
+		{
+			kind:        setupText,
+			title:       "Slack bot token",
+			help:        "The config may be committed, so prefer an environment variable reference like $PAW_SLACK_TOKEN over the token itself.",
+			placeholder: "$PAW_SLACK_TOKEN or xoxb-...",
+			value:       func(w *SetupWizard) string { return w.cfg.Notifications.SlackToken },
+			setValue:    func(w *SetupWizard, v string) { w.cfg.Notifications.SlackToken = v },
+			validate: func(v string) error {
+				if !strings.HasPrefix(v, "xox") && !strings.HasPrefix(v, "$") {
+					return errors.New("expected a Slack token (xoxb-...) or an environment variable ($VAR)")
+				}
+				return nil
+			},
+			test: func(w *SetupWizard, v string) tea.Cmd {
+				if w.testers.Slack == nil {
+					return nil
+				}
+				step := w.index
+				return func() tea.Msg {
+					team, err := w.testers.Slack(v)
+					return setupTestResultMsg{step: step, result: "Token OK: " + team, err: err}
+				}
+			},
+			skip: func(w *SetupWizard) bool { return !w.slack },
+		},
+		{
+			kind:        setupText,
+			title:       "Slack channel",
+			help:        "Channel name or ID the bot posts to. Invite the bot to the channel first.",
+			placeholder: "#paw",
+			value:       func(w *SetupWizard) string { return w.cfg.Notifications.SlackChannel },
+			setValue:    func(w *SetupWizard, v string) { w.cfg.Notifications.SlackChannel = v },
+			validate: func(v string) error {
+				if v == "" || strings.ContainsAny(v, " \t") {
+					return errors.New("enter a channel name (#paw) or ID without spaces")
+				}
+				return nil
+			},
+			skip: func(w *SetupWizard) bool { return !w.slack },
+		},
+		{
+			kind:        setupText,
+			title:       "ntfy server",
+			help:        "Leave the default unless you self-host ntfy.",
+			placeholder: "https://ntfy.sh",
+			value:       func(w *SetupWizard) string { return w.cfg.Notifications.NtfyServer },
+			setValue:    func(w *SetupWizard, v string) { w.cfg.Notifications.NtfyServer = v },
+			validate: func(v string) error {
+				if v == "" {
+					return nil
+				}
+				u, err := url.Parse(v)
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return errors.New("expected an http(s) URL")
+				}
+				return nil
+			},
+			skip: func(w *SetupWizard) bool { return !w.ntfy },
+		},
+		{
+			kind:        setupText,
+			title:       "ntfy topic",
+			help:        "Anyone who knows the topic name can read it, so pick something hard to guess. Subscribe to it in the ntfy app.",
+			placeholder: "paw-my-project-7f3a",
+			value:       func(w *SetupWizard) string { return w.cfg.Notifications.NtfyTopic },
+			setValue:    func(w *SetupWizard, v string) { w.cfg.Notifications.NtfyTopic = v },
+			validate: func(v string) error {
+				if !ntfyTopicRegex.MatchString(v) {
+					return errors.New("topics use letters, digits, '-' and '_' (up to 64 characters)")
+				}
+				return nil
+			},
+			test: func(w *SetupWizard, v string) tea.Cmd {
+				if w.testers.Ntfy == nil {
+					return nil
+				}
+				step, server := w.index, w.cfg.Notifications.NtfyServer
+				return func() tea.Msg {
+					err := w.testers.Ntfy(server, v)
+					return setupTestResultMsg{step: step, result: "Test notification sent", err: err}
+				}
+			},
+			skip: func(w *SetupWizard) bool { return !w.ntfy },
+		},
+		{
+			kind:  setupSelect,
+			title: "Link mode",
+			help:  "How task directories link to shared files (.claude, project root, paw binary).",
+			options: []setupOption{
+				{constants.LinkModeSymlink, "Recommended"},
+				{constants.LinkModeCopy, "For filesystems that deny symlinks (exFAT, some NFS mounts)"},
+			},
+			selected: func(w *SetupWizard) int {
+				if w.cfg.LinkMode == constants.LinkModeCopy {
+					return 1
+				}
+				return 0
+			},
+			choose: func(w *SetupWizard, i int) {
+				w.cfg.LinkMode = constants.LinkModeSymlink
+				if i == 1 {
+					w.cfg.LinkMode = constants.LinkModeCopy
+				}
+			},
+		},
+		{
+			kind:    setupSelect,
+			title:   "Encrypt task history",
+			help:    "Encrypt history files at rest with AES-256-GCM. The key comes from PAW_HISTORY_KEY or the OS keychain ('paw history init-key').",
+			options: yesNo("History is decrypted transparently by 'paw history'", "Store history as plain text"),
+			selected: func(w *SetupWizard) int {
+				if w.cfg.HistoryEncryption {
+					return 0
+				}
+				return 1
+			},
+			choose: func(w *SetupWizard, i int) { w.cfg.HistoryEncryption = i == 0 },
+		},
+		{
+			kind:  setupSummary,
+			title: "Review",
+			help:  "Press Enter to write the config.",
+		},
+	}
+}
+
+// Init initializes the wizard.
+func (w *SetupWizard) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (w *SetupWizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		w.isDark = msg.IsDark()
+		w.colors = NewThemeColors(w.isDark)
+		w.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(w.isDark)
+		return w, nil
+
+	case tea.WindowSizeMsg:
+		w.width = msg.Width
+		w.input.SetWidth(max(20, min(60, msg.Width-8)))
+		return w, nil
+
+	case setupTestResultMsg:
+		if msg.step != w.index {
+			return w, nil
+		}
+		w.testing = false
+		if msg.err != nil {
+			w.errMsg = "Test failed: " + msg.err.Error()
+			w.testStatus = ""
+		} else {
+			w.errMsg = ""
+			w.testStatus = msg.result
+		}
+		return w, nil
+
+	case tea.KeyMsg:
+		step := w.steps[w.index]
+		switch msg.String() {
+		case "ctrl+c":
+			return w, tea.Quit
+		case "esc", "shift+tab":
+			if prev := w.adjacentStep(-1); prev >= 0 {
+				w.enterStep(prev)
+				return w, nil
+			}
+			if msg.String() == "esc" {
+				return w, tea.Quit
+			}
+			return w, nil
+		case "enter":
+			return w.submit()
+		case "ctrl+t":
+			if step.kind == setupText && step.test != nil && !w.testing {
+				value := strings.TrimSpace(w.input.Value())
+				if step.validate != nil {
+					if err := step.validate(value); err != nil {
+						w.errMsg = err.Error()
+						return w, nil
+					}
+				}
+				if cmd := step.test(w, value); cmd != nil {
+					w.testing = true
+					w.errMsg, w.testStatus = "", "Testing..."
+					return w, cmd
+				}
+			}
+			return w, nil
+		}
+
+		switch step.kind {
+		case setupSelect:
+			switch msg.String() {
+			case "up", "k":
+				if w.cursor > 0 {
+					w.cursor--
+				}
+			case "down", "j":
+				if w.cursor < len(step.options)-1 {
+					w.cursor++
+				}
+			}
+			return w, nil
+		case setupText:
+			var cmd tea.Cmd
+			w.input, cmd = w.input.Update(msg)
+			w.errMsg, w.testStatus = "", ""
+			return w, cmd
+		}
+	}
+
+	return w, nil
+}
+
+// submit applies the current step and advances, or saves on the summary.
+func (w *SetupWizard) submit() (tea.Model, tea.Cmd) {
+	if w.testing {
+		return w, nil // Wait for the channel test to finish
+	}
+	step := w.steps[w.index]
+	switch step.kind {
+	case setupSummary:
+		w.saved = true
+		return w, tea.Quit
+	case setupSelect:
+		step.choose(w, w.cursor)
+	case setupText:
+		value := strings.TrimSpace(w.input.Value())
+		if step.validate != nil {
+			if err := step.validate(value); err != nil {
+				w.errMsg = err.Error()
+				return w, nil
+			}
+		}
+		step.setValue(w, value)
+	}
+	if next := w.adjacentStep(1); next >= 0 {
+		w.enterStep(next)
+	}
+	return w, nil
+}
+
+// adjacentStep returns the next (dir=1) or previous (dir=-1) step that isn't skipped, or -1.
+func (w *SetupWizard) adjacentStep(dir int) int {
+	for i := w.index + dir; i >= 0 && i < len(w.steps); i += dir {
+		if skip := w.steps[i].skip; skip == nil || !skip(w) {
+			return i
+		}
+	}
+	return -1
+}
+
+// enterStep shows step i with its current value.
+func (w *SetupWizard) enterStep(i int) {
+	w.index = i
+	w.errMsg, w.testStatus, w.testing = "", "", false
+	step := w.steps[i]
+	switch step.kind {
+	case setupSelect:
+		w.cursor = step.selected(w)
+		w.input.Blur()
+	case setupText:
+		w.input.SetValue(step.value(w))
+		w.input.Placeholder = step.placeholder
+		w.input.CursorEnd()
+		w.input.Focus()
+	default:
+		w.input.Blur()
+	}
+}
+
+// progress returns the 1-based position of the current step among visible steps.
+func (w *SetupWizard) progress() (int, int) {
+	pos, total := 0, 0
+	for i, step := range w.steps {
+		if step.skip != nil && step.skip(w) {
+			continue
+		}
+		total++
+		if i <= w.index {
+			pos++
+		}
+	}
+	return pos, total
+}
+
+// View renders the wizard.
+func (w *SetupWizard) View() tea.View {
+	c := w.colors
+	if !w.stylesCached {
+		w.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		w.styleStep = lipgloss.NewStyle().Foreground(c.TextDim)
+		w.styleHelp = lipgloss.NewStyle().Foreground(c.TextDim)
+		w.styleItem = lipgloss.NewStyle().Foreground(c.TextNormal).PaddingLeft(2)
+		w.styleSelected = lipgloss.NewStyle().Foreground(c.Accent).Bold(true)
+		w.styleDesc = lipgloss.NewStyle().Foreground(c.TextDim).PaddingLeft(4)
+		w.styleError = lipgloss.NewStyle().Foreground(c.ErrorColor)
+		w.styleSuccess = lipgloss.NewStyle().Foreground(c.SuccessColor)
+		w.styleKeys = lipgloss.NewStyle().Foreground(c.TextDim).MarginTop(1)
+		w.stylesCached = true
+	}
+
+	width := 72
+	if w.width > 0 {
+		width = max(20, min(width, w.width-4))
+	}
+
+	step := w.steps[w.index]
+	pos, total := w.progress()
+
+	var sb strings.Builder
+	sb.WriteString(w.styleTitle.Render("PAW Setup"))
+	sb.WriteString(w.styleStep.Render(fmt.Sprintf("  %d/%d", pos, total)))
+	sb.WriteString("\n\n")
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render(step.title))
+	sb.WriteString("\n")
+	sb.WriteString(w.styleHelp.Width(width).Render(step.help))
+	sb.WriteString("\n")
+	if step.note != nil {
+		if note := step.note(w); note != "" {
+			sb.WriteString(w.styleSuccess.Render("✓ " + note))
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("\n")
+
+	keys := "Enter: Next  Esc: Back  ⌃C: Quit"
+	switch step.kind {
+	case setupSelect:
+		for i, opt := range step.options {
+			if i == w.cursor {
+				sb.WriteString(w.styleSelected.Render("> " + opt.label))
+			} else {
+				sb.WriteString(w.styleItem.Render(opt.label))
+			}
+			sb.WriteString("\n")
+			sb.WriteString(w.styleDesc.Render(opt.desc))
+			sb.WriteString("\n")
+		}
+		keys = "↑/↓: Select  " + keys
+	case setupText:
+		sb.WriteString(w.input.View())
+		sb.WriteString("\n")
+		if step.test != nil {
+			keys = "⌃T: Test  " + keys
+		}
+	case setupSummary:
+		sb.WriteString(w.summary())
+		keys = "Enter: Save  Esc: Back  ⌃C: Quit"
+	}
+
+	if w.errMsg != "" {
+		sb.WriteString("\n")
+		sb.WriteString(w.styleError.Width(width).Render("✗ " + w.errMsg))
+		sb.WriteString("\n")
+	} else if w.testStatus != "" {
+		sb.WriteString("\n")
+		sb.WriteString(w.styleSuccess.Width(width).Render("✓ " + w.testStatus))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(w.styleKeys.Render(keys))
+	return tea.NewView(sb.String())
+}
+
+// summary lists the settings the wizard will write.
+func (w *SetupWizard) summary() string {
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+	onOff := func(b bool) string {
+		if b {
+			return "on"
+		}
+		return "off"
+	}
+
+	n := w.cfg.Notifications
+	var channels []string
+	if n.Slack() {
+		channels = append(channels, "Slack "+n.SlackChannel)
+	}
+	if n.Ntfy() {
+		server := n.NtfyServer
+		if server == "" {
+			server = "https://ntfy.sh"
+		}
+		channels = append(channels, "ntfy "+strings.TrimSuffix(server, "/")+"/"+n.NtfyTopic)
+	}
+	if len(channels) == 0 {
+		channels = append(channels, "desktop only")
+	}
+
+	profile := "custom"
+	if w.preset > 0 {
+		profile = config.Presets()[w.preset-1].Name
+	}
+
+	rows := [][2]string{
+		{"Profile", profile},
+		{"Build", orNone(w.cfg.Commands.Build)},
+		{"Test", orNone(w.cfg.Commands.Test)},
+		{"Lint", orNone(w.cfg.Commands.Lint)},
+		{"Verify before push", onOff(w.cfg.VerifyBeforePush && len(w.cfg.Commands.VerifyEntries()) > 0)},
+		{"When done", w.cfg.OnComplete},
+		{"Skip permissions", onOff(w.cfg.SkipPermissions)},
+		{"Notifications", strings.Join(channels, ", ")},
+		{"Link mode", w.cfg.LinkMode},
+		{"History encryption", onOff(w.cfg.HistoryEncryption)},
+	}
+	var sb strings.Builder
+	for _, row := range rows {
+		sb.WriteString(w.styleItem.Render(fmt.Sprintf("%-20s", row[0])))
+		sb.WriteString(row[1])
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Result returns the edited config and whether the user chose to save it.
+func (w *SetupWizard) Result() (*config.Config, bool) {
+	return w.cfg, w.saved
+}
+
+// RunSetupWizard runs the setup wizard on cfg and returns the edited config.
+// saved is false if the user quit before the review step.
+func RunSetupWizard(cfg *config.Config, detected service.DetectedCommands, testers SetupTesters) (*config.Config, bool, error) {
+	m := NewSetupWizard(cfg, detected, testers)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, saved := finalModel.(*SetupWizard).Result()
+	return result, saved, nil
+}
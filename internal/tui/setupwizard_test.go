@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func wizardKey(w *SetupWizard, key tea.KeyPressMsg) tea.Cmd {
+	_, cmd := w.Update(key)
+	return cmd
+}
+
+func wizardType(w *SetupWizard, text string) {
+	for _, r := range text {
+		wizardKey(w, tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+var (
+	keyEnter = tea.KeyPressMsg{Code: tea.KeyEnter}
+	keyDown  = tea.KeyPressMsg{Code: tea.KeyDown}
+	keyEsc   = tea.KeyPressMsg{Code: tea.KeyEscape}
+	keyTest  = tea.KeyPressMsg{Code: 't', Mod: tea.ModCtrl}
+)
+
+func TestSetupWizardWritesConfig(t *testing.T) {
+	var testedTopic string
+	w := NewSetupWizard(config.DefaultConfig(), service.DetectedCommands{}, SetupTesters{
+		Ntfy: func(_, topic string) error {
+			testedTopic = topic
+			return nil
+		},
+	})
+
+	wizardKey(w, keyEnter) // Profile: custom
+	wizardType(w, "make build")
+	wizardKey(w, keyEnter) // Build
+	wizardType(w, "make test")
+	wizardKey(w, keyEnter) // Test
+	wizardKey(w, keyEnter) // Lint (empty)
+	wizardKey(w, keyEnter) // Verify before push: Yes
+	wizardKey(w, keyEnter) // When done: confirm
+	wizardKey(w, keyEnter) // Skip permissions: Yes
+
+	// Notifications: ntfy
+	wizardKey(w, keyDown)
+	wizardKey(w, keyDown)
+	wizardKey(w, keyEnter)
+	if got := w.steps[w.index].title; got != "ntfy server" {
+		t.Fatalf("step after choosing ntfy = %q, want ntfy server (Slack steps skipped)", got)
+	}
+	wizardKey(w, keyEnter) // Default server
+
+	wizardType(w, "bad topic!")
+	wizardKey(w, keyEnter)
+	if w.errMsg == "" || w.steps[w.index].title != "ntfy topic" {
+		t.Fatalf("invalid topic accepted (err=%q, step=%q)", w.errMsg, w.steps[w.index].title)
+	}
+	w.input.SetValue("paw-alerts")
+	if msg := wizardKey(w, keyTest)(); msg != nil {
+		wizardKey(w, keyEnter) // Ignored while testing
+		w.Update(msg)
+	}
+	if testedTopic != "paw-alerts" || !strings.Contains(w.testStatus, "sent") {
+		t.Errorf("ntfy test: topic=%q status=%q", testedTopic, w.testStatus)
+	}
+	wizardKey(w, keyEnter)
+
+	wizardKey(w, keyEnter) // Link mode: symlink
+	wizardKey(w, keyDown)
+	wizardKey(w, keyEnter) // History encryption: No
+	if w.steps[w.index].kind != setupSummary {
+		t.Fatalf("expected summary step, got %q", w.steps[w.index].title)
+	}
+	wizardKey(w, keyEnter)
+
+	cfg, saved := w.Result()
+	if !saved {
+		t.Fatal("Result() saved = false after Enter on the summary")
+	}
+	want := config.Commands{Build: "make build", Test: "make test"}
+	if cfg.Commands != want {
+		t.Errorf("Commands = %+v, want %+v", cfg.Commands, want)
+	}
+	if !cfg.VerifyBeforePush || cfg.HistoryEncryption {
+		t.Errorf("VerifyBeforePush = %v, HistoryEncryption = %v", cfg.VerifyBeforePush, cfg.HistoryEncryption)
+	}
+	if cfg.Notifications != (config.Notifications{NtfyTopic: "paw-alerts"}) {
+		t.Errorf("Notifications = %+v", cfg.Notifications)
+	}
+}
+
+func TestSetupWizardSlackTestFailure(t *testing.T) {
+	w := NewSetupWizard(config.DefaultConfig(), service.DetectedCommands{}, SetupTesters{
+		Slack: func(string) (string, error) { return "", errors.New("invalid_auth") },
+	})
+	for w.steps[w.index].title != "Notifications" {
+		wizardKey(w, keyEnter)
+	}
+	wizardKey(w, keyDown)
+	wizardKey(w, keyEnter) // Slack
+
+	wizardType(w, "$PAW_SLACK_TOKEN")
+	w.Update(wizardKey(w, keyTest)())
+	if !strings.Contains(w.errMsg, "invalid_auth") {
+		t.Errorf("errMsg = %q, want the test failure", w.errMsg)
+	}
+}
+
+func TestSetupWizardBackAndSkip(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Commands.Test = "go test ./..."
+	w := NewSetupWizard(cfg, service.DetectedCommands{}, SetupTesters{})
+
+	wizardKey(w, keyEnter) // Profile: custom
+	if w.input.Value() != "" {
+		t.Errorf("build input = %q, want empty", w.input.Value())
+	}
+	wizardKey(w, keyEnter)
+	if w.input.Value() != "go test ./..." {
+		t.Errorf("test input = %q, want the configured command", w.input.Value())
+	}
+	wizardKey(w, keyEsc)
+	if w.index != 1 {
+		t.Errorf("Esc did not go back (index=%d)", w.index)
+	}
+
+	// Without commands, the verify step is skipped
+	w.input.SetValue("")
+	wizardKey(w, keyEnter)
+	w.input.SetValue("")
+	wizardKey(w, keyEnter)
+	wizardKey(w, keyEnter)
+	if got := w.steps[w.index].title; got != "When a task is done" {
+		t.Errorf("step = %q, want When a task is done (verify skipped without commands)", got)
+	}
+
+	if _, saved := w.Result(); saved {
+		t.Error("Result() saved = true before the summary")
+	}
+}
+
+func TestSetupWizardPrefillsDetectedCommands(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Commands.Lint = "golangci-lint run"
+	w := NewSetupWizard(cfg, service.DetectedCommands{
+		Build: service.DetectedCommand{Command: "make build", Source: "Makefile"},
+		Lint:  service.DetectedCommand{Command: "go vet ./...", Source: "go.mod"},
+	}, SetupTesters{})
+
+	wizardKey(w, keyEnter) // Profile: custom
+	if w.input.Value() != "make build" {
+		t.Errorf("build input = %q, want the detected command", w.input.Value())
+	}
+	if note := w.steps[w.index].note(w); note != "Detected from Makefile" {
+		t.Errorf("note = %q, want %q", note, "Detected from Makefile")
+	}
+	w.input.SetValue("make all")
+	if note := w.steps[w.index].note(w); note != "" {
+		t.Errorf("note = %q after editing, want none", note)
+	}
+
+	wizardKey(w, keyEnter)
+	wizardKey(w, keyEnter)
+	if w.input.Value() != "golangci-lint run" {
+		t.Errorf("lint input = %q, configured commands must win over detected ones", w.input.Value())
+	}
+}
+
+func TestSetupWizardPreset(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Commands.Test = "go test ./..."
+	w := NewSetupWizard(cfg, service.DetectedCommands{}, SetupTesters{})
+
+	// ci-strict is the third preset
+	for range 3 {
+		wizardKey(w, keyDown)
+	}
+	wizardKey(w, keyEnter)
+	for w.steps[w.index].title != "When a task is done" {
+		wizardKey(w, keyEnter)
+	}
+	if w.cursor != 3 {
+		t.Errorf("on-complete cursor = %d, want 3 (pr from the preset)", w.cursor)
+	}
+	for w.steps[w.index].kind != setupSummary {
+		wizardKey(w, keyEnter)
+	}
+	wizardKey(w, keyEnter)
+
+	got, saved := w.Result()
+	if !saved {
+		t.Fatal("Result() saved = false")
+	}
+	if got.OnComplete != constants.ActionPR || got.SkipPermissions || !got.VerifyBeforePush {
+		t.Errorf("OnComplete = %q, SkipPermissions = %v, VerifyBeforePush = %v", got.OnComplete, got.SkipPermissions, got.VerifyBeforePush)
+	}
+	if got.Commands.Test != "go test ./..." {
+		t.Errorf("Commands.Test = %q, preset must keep commands", got.Commands.Test)
+	}
+}
@@ -3,6 +3,7 @@ package tui
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
@@ -249,3 +250,82 @@ func (s *SimpleSpinner) Stop(success bool, result string) {
 	}
 	fmt.Println()
 }
+
+// ParallelStep is a step run concurrently by RunParallel.
+type ParallelStep struct {
+	Message string
+	Run     func() (string, error)
+}
+
+// parallelStepState tracks the progress of a ParallelStep.
+type parallelStepState struct {
+	done   bool
+	result string
+	err    error
+}
+
+// RunParallel runs steps concurrently, showing a spinner line per step
+// that is replaced by the step's result when it finishes.
+// Returns the error of each step, in order.
+func RunParallel(steps ...ParallelStep) []error {
+	var mu sync.Mutex
+	states := make([]parallelStepState, len(steps))
+
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := step.Run()
+			mu.Lock()
+			states[i] = parallelStepState{done: true, result: result, err: err}
+			mu.Unlock()
+		}()
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	frame := 0
+	render := func(first bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !first {
+			fmt.Printf("\033[%dA", len(steps))
+		}
+		for i, step := range steps {
+			state := states[i]
+			line := spinnerFrames[frame%len(spinnerFrames)] + " " + step.Message
+			switch {
+			case state.done && state.err != nil:
+				line = "✗ " + step.Message + ": " + state.err.Error()
+			case state.done && state.result != "":
+				line = "✓ " + step.Message + ": " + state.result
+			case state.done:
+				line = "✓ " + step.Message
+			}
+			fmt.Printf("\r\033[K  %s\n", line)
+		}
+		frame++
+	}
+
+	render(true)
+	ticker := time.NewTicker(80 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-finished:
+			render(false)
+			errs := make([]error, len(states))
+			for i, state := range states {
+				errs[i] = state.err
+			}
+			return errs
+		case <-ticker.C:
+			render(false)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunParallel(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	failure := errors.New("push rejected")
+
+	errs := RunParallel(
+		ParallelStep{Message: "slow", Run: func() (string, error) {
+			close(started)
+			<-release
+			return "", failure
+		}},
+		ParallelStep{Message: "fast", Run: func() (string, error) {
+			// Runs while the slow step is still in progress
+			select {
+			case <-started:
+			case <-time.After(time.Second):
+				t.Error("steps did not run concurrently")
+			}
+			close(release)
+			return "ok", nil
+		}},
+	)
+
+	if len(errs) != 2 || !errors.Is(errs[0], failure) || errs[1] != nil {
+		t.Errorf("RunParallel() = %v, want [%v <nil>]", errs, failure)
+	}
+}
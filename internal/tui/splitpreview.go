@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// splitPreviewContentLines is the number of content lines shown per task.
+const splitPreviewContentLines = 2
+
+// SplitPreview shows a task split plan and lets the user pick which tasks to create.
+type SplitPreview struct {
+	plan      *service.SplitPlan
+	selected  []bool
+	cursor    int
+	confirmed bool
+	width     int
+	isDark    bool
+	colors    ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleDesc     lipgloss.Style
+	styleSelected lipgloss.Style
+	styleNormal   lipgloss.Style
+	styleExcluded lipgloss.Style
+	stylesCached  bool
+}
+
+// NewSplitPreview creates a new split preview with every task selected.
+func NewSplitPreview(plan *service.SplitPlan) *SplitPreview {
+	isDark := DetectDarkMode()
+	selected := make([]bool, len(plan.Tasks))
+	for i := range selected {
+		selected[i] = true
+	}
+	return &SplitPreview{
+		plan:     plan,
+		selected: selected,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the split preview.
+func (m *SplitPreview) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages and updates the model.
+func (m *SplitPreview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.plan.Tasks)-1 {
+				m.cursor++
+			}
+
+		case " ", "space":
+			m.toggle(m.cursor)
+
+		case "enter":
+			if m.selectedCount() > 0 {
+				m.confirmed = true
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// toggle flips a task's selection while keeping the dependency chain consistent:
+// excluding a task excludes its dependents, including a task includes what it depends on.
+func (m *SplitPreview) toggle(i int) {
+	if m.selected[i] {
+		m.selected[i] = false
+		for _, j := range m.plan.Dependents(i) {
+			m.selected[j] = false
+		}
+		return
+	}
+
+	m.selected[i] = true
+	dep := m.plan.Tasks[i].DependsOn
+	for j := i - 1; j >= 0 && dep != ""; j-- {
+		if m.plan.Tasks[j].Name == dep {
+			m.selected[j] = true
+			dep = m.plan.Tasks[j].DependsOn
+		}
+	}
+}
+
+func (m *SplitPreview) selectedCount() int {
+	n := 0
+	for _, s := range m.selected {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// View renders the split preview.
+func (m *SplitPreview) View() tea.View {
+	c := m.colors
+
+	// Update style cache if needed (only on theme change)
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.Accent)
+		m.styleDesc = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.styleSelected = lipgloss.NewStyle().
+			Foreground(c.Accent).
+			Bold(true)
+		m.styleNormal = lipgloss.NewStyle().
+			Foreground(c.TextNormal)
+		m.styleExcluded = lipgloss.NewStyle().
+			Foreground(c.TextDim).
+			Strikethrough(true)
+		m.stylesCached = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styleTitle.Render("✂️  Split plan: " + strconv.Itoa(m.selectedCount()) + "/" + strconv.Itoa(len(m.plan.Tasks)) + " tasks selected"))
+	sb.WriteString("\n\n")
+
+	for i, t := range m.plan.Tasks {
+		cursor := "  "
+		style := m.styleNormal
+		if i == m.cursor {
+			cursor = "▸ "
+			style = m.styleSelected
+		}
+		check := "[x] "
+		if !m.selected[i] {
+			check = "[ ] "
+			style = m.styleExcluded
+		}
+
+		sb.WriteString(cursor + check + style.Render(strconv.Itoa(i+1)+". "+t.Name))
+		if t.DependsOn != "" {
+			sb.WriteString(m.styleDesc.Render("  ← after " + t.DependsOn))
+		}
+		sb.WriteString("\n")
+
+		for _, line := range m.contentPreview(t.Content) {
+			sb.WriteString("        " + m.styleDesc.Render(line) + "\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styleDesc.Render("↑/↓: Navigate  Space: Toggle  Enter: Create tasks  q/Esc: Cancel"))
+
+	return tea.NewView(sb.String())
+}
+
+// contentPreview returns the first few lines of a task's content, truncated to the width.
+func (m *SplitPreview) contentPreview(content string) []string {
+	lines := strings.Split(content, "\n")
+	if len(lines) > splitPreviewContentLines {
+		lines = lines[:splitPreviewContentLines]
+		lines[len(lines)-1] += " …"
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateWithEllipsis(line, m.width-10)
+	}
+	return lines
+}
+
+// Result returns the selected tasks in plan order, or nil if cancelled.
+func (m *SplitPreview) Result() []service.SplitTask {
+	if !m.confirmed {
+		return nil
+	}
+	tasks := make([]service.SplitTask, 0, m.selectedCount())
+	for i, t := range m.plan.Tasks {
+		if m.selected[i] {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// RunSplitPreview runs the split preview and returns the tasks to create.
+// Returns nil if the user cancelled.
+func RunSplitPreview(plan *service.SplitPlan) ([]service.SplitTask, error) {
+	m := NewSplitPreview(plan)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	ui := finalModel.(*SplitPreview)
+	return ui.Result(), nil
+}
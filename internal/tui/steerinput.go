@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// SteerInput asks for new direction after an agent was interrupted.
+type SteerInput struct {
+	taskName         string
+	input            textinput.Model
+	inputOffset      int
+	inputOffsetRight int
+	sent             bool
+
+	width  int
+	height int
+	isDark bool
+	colors ThemeColors
+
+	// Style cache (reused across renders)
+	styleTitle   lipgloss.Style
+	styleInput   lipgloss.Style
+	styleHelp    lipgloss.Style
+	stylesCached bool
+}
+
+// NewSteerInput creates a steer input for the interrupted task.
+func NewSteerInput(taskName string) *SteerInput {
+	isDark := DetectDarkMode()
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "New direction (e.g., use the existing helper instead)"
+	ti.Focus()
+	ti.SetWidth(60)
+	ti.VirtualCursor = false
+
+	return &SteerInput{
+		taskName: taskName,
+		input:    ti,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+	}
+}
+
+// Init initializes the input.
+func (m *SteerInput) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return nil
+	}
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *SteerInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		m.isDark = msg.IsDark()
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false // Invalidate style cache on theme change
+		setCachedDarkMode(m.isDark)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if inputWidth := min(60, m.width-10); inputWidth > 20 {
+			m.input.SetWidth(inputWidth)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			if strings.TrimSpace(m.input.Value()) == "" {
+				return m, nil
+			}
+			m.sent = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	syncTextInputOffset([]rune(m.input.Value()), m.input.Position(), m.input.Width(), &m.inputOffset, &m.inputOffsetRight)
+	return m, cmd
+}
+
+// View renders the input.
+func (m *SteerInput) View() tea.View {
+	c := m.colors
+
+	maxWidth := 70
+	if m.width > 0 {
+		maxWidth = max(10, m.width-4)
+	}
+
+	// Update style cache if needed (only on theme change)
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(c.Accent)
+		m.styleInput = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(c.BorderFocused).
+			Padding(0, 1)
+		m.styleHelp = lipgloss.NewStyle().
+			Foreground(c.TextDim)
+		m.stylesCached = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString(m.styleTitle.Render(truncateWithEllipsis("⏸ Interrupted: "+m.taskName, maxWidth)))
+	sb.WriteString("\n\n")
+	inputBoxTopY := 2
+
+	// Input - use custom rendering for proper Korean/CJK cursor positioning
+	inputRender := renderTextInput(m.input.Value(), m.input.Position(), m.input.Width(), m.input.Placeholder, m.inputOffset, m.inputOffsetRight)
+	sb.WriteString(m.styleInput.Render(inputRender.Text))
+	sb.WriteString("\n")
+	sb.WriteString(m.styleHelp.Render("Enter: Send  Esc: Leave the agent stopped"))
+
+	v := tea.NewView(sb.String())
+	v.AltScreen = true
+	if m.input.Focused() {
+		// Cursor position: X = border(1) + padding(1) + cursorX
+		// Y = inputBoxTopY + 1 (skip top border row to reach content row)
+		cursor := tea.NewCursor(2+inputRender.CursorX, inputBoxTopY+1)
+		cursor.Blink = m.input.Styles.Cursor.Blink
+		cursor.Color = m.input.Styles.Cursor.Color
+		cursor.Shape = m.input.Styles.Cursor.Shape
+		v.Cursor = cursor
+	}
+	return v
+}
+
+// Result returns the new direction, or "" if the user cancelled.
+func (m *SteerInput) Result() string {
+	if !m.sent {
+		return ""
+	}
+	return strings.TrimSpace(m.input.Value())
+}
+
+// RunSteerInput asks for new direction for an interrupted task. It returns ""
+// if the user cancelled.
+func RunSteerInput(taskName string) (string, error) {
+	m := NewSteerInput(taskName)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	return finalModel.(*SteerInput).Result(), nil
+}
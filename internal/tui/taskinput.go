@@ -30,16 +30,20 @@ type OptField int
 // Option field selection values.
 const (
 	OptFieldModel OptField = iota
+	OptFieldType
+	OptFieldContext
+	OptFieldLabels
 	OptFieldBranchName
+	OptFieldParent
 )
 
 // optFieldCount returns the number of option fields based on git mode.
-// In non-git mode, the Branch field is hidden.
+// In non-git mode, the Branch and Stack fields are hidden.
 func optFieldCount(isGitRepo bool) int {
 	if isGitRepo {
-		return 2 // Model + Branch
+		return 6 // Model + Type + Context + Labels + Branch + Stack
 	}
-	return 1 // Model only
+	return 4 // Model + Type + Context + Labels
 }
 
 // cancelDoublePressTimeout is the time window for double-press cancel detection.
@@ -60,11 +64,12 @@ type TaskInput struct {
 	textarea             textarea.Model
 	submitted            bool
 	cancelled            bool
+	quit                 bool
 	requestTaskNamePopup bool
 	width                int
 	height               int
 	options              *config.TaskOptions
-	activeTasks          []string // Active task names for dependency selection
+	activeTasks          []string // Active task names for dependency and stack selection
 	isDark               bool     // Cached dark mode detection (must be detected before bubbletea starts)
 	isGitRepo            bool     // Whether the project is a git repository
 	pawDir               string
@@ -81,6 +86,8 @@ type TaskInput struct {
 	optField   OptField
 	modelIdx   int
 	branchName string // Custom branch name input (empty = auto)
+	contextIn  string // Extra context files input (comma-separated paths)
+	labelsIn   string // Labels input (comma-separated)
 
 	mouseSelecting  bool
 	selectAnchorRow int
@@ -110,6 +117,10 @@ type TaskInput struct {
 	lastTemplateDraft string
 	templateTipUntil  time.Time
 
+	// Result of copying task metadata from the Kanban, shown in the header
+	copyNotice      string
+	copyNoticeUntil time.Time
+
 	// Keystroke tracking for performance optimization
 	// Skip expensive I/O (like kanban refresh) while user is actively typing
 	lastKeystroke time.Time
@@ -172,6 +183,7 @@ type TaskInputResult struct {
 	Cancelled            bool
 	JumpTarget           *JumpTarget // Non-nil if user requested to jump to an external project task
 	RequestTaskNamePopup bool        // True if user pressed Alt+Enter with empty content
+	Quit                 bool        // True if user asked to stop the new-task loop (Ctrl+D on empty input, q in Kanban)
 }
 
 // NewTaskInputWithOptions creates a new task input model with active task list and git mode flag.
@@ -225,6 +237,8 @@ func NewTaskInputWithOptions(activeTasks []string, isGitRepo bool) *TaskInput {
 		optField:          OptFieldModel,
 		modelIdx:          modelIdx,
 		branchName:        opts.BranchName,
+		contextIn:         strings.Join(opts.ContextFiles, ", "),
+		labelsIn:          strings.Join(opts.Labels, ", "),
 		kanban:            NewKanbanView(isDark),
 		currentTip:        GetTip(),
 		lastTipRefresh:    time.Now(),
@@ -389,7 +403,11 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Check for history/template/yazi selection on tick (not on every keystroke)
 		// This avoids file I/O on every keystroke which causes stuttering
 		m.checkHistorySelection()
+		m.checkPasteSelection()
 		m.checkYaziSelection()
+		if notice := m.checkCloneSelection(); notice != "" {
+			cmds = append(cmds, noticeCmd(notice))
+		}
 		if m.checkTemplateSelection() {
 			cmds = append(cmds, tea.Tick(templateTipDuration, func(_ time.Time) tea.Msg {
 				return templateTipClearMsg{}
@@ -409,6 +427,17 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.cancelKey = ""
 		return m, nil
 
+	case copyNoticeMsg:
+		m.copyNotice = msg.text
+		m.copyNoticeUntil = time.Now().Add(copyNoticeDuration)
+		return m, tea.Tick(copyNoticeDuration, func(_ time.Time) tea.Msg {
+			return copyNoticeClearMsg{}
+		})
+
+	case copyNoticeClearMsg:
+		// No-op; triggers a redraw after the copy notice expires.
+		return m, nil
+
 	case templateTipClearMsg:
 		// No-op; triggers a redraw after the transient tip window elapses.
 		return m, nil
@@ -433,16 +462,22 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// When terminal gains focus (user switches to this window),
 		// automatically focus the task input textarea
 		m.switchFocusTo(FocusPanelLeft)
-		// Check for history/template/yazi selection when window regains focus
-		// (e.g., returning from Ctrl+R history picker, Ctrl+T template picker, or yazi file selection)
+		// Check for history/template/yazi/clone selection when window regains focus
+		// (e.g., returning from Ctrl+R history picker, Ctrl+T template picker, yazi file selection,
+		// or 'paw history clone')
 		m.checkHistorySelection()
+		m.checkPasteSelection()
 		m.checkYaziSelection()
+		var cmd tea.Cmd
+		if notice := m.checkCloneSelection(); notice != "" {
+			cmd = noticeCmd(notice)
+		}
 		if m.checkTemplateSelection() {
-			return m, tea.Tick(templateTipDuration, func(_ time.Time) tea.Msg {
+			return m, tea.Batch(cmd, tea.Tick(templateTipDuration, func(_ time.Time) tea.Msg {
 				return templateTipClearMsg{}
-			})
+			}))
 		}
-		return m, nil
+		return m, cmd
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -541,6 +576,13 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return cancelClearMsg{}
 			})
 
+		// Quit the new-task loop: Ctrl+D on an empty input (otherwise it deletes forward)
+		case "ctrl+d":
+			if m.focusPanel == FocusPanelLeft && strings.TrimSpace(m.textarea.Value()) == "" {
+				m.quit = true
+				return m, tea.Quit
+			}
+
 		// Submit: Alt+Enter or F5
 		case "alt+enter", "f5":
 			m.applyOptionInputValues()
@@ -747,7 +789,7 @@ func (m *TaskInput) View() tea.View {
 			Foreground(lightDark(lipgloss.Color("24"), lipgloss.Color("214"))).Bold(true)
 		m.viewStyleCancelHint = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
 		// Pre-render help text and cache width (avoids lipgloss.Width on each render)
-		m.viewHelpRendered = m.viewStyleHelp.Render("Alt+Enter: Submit  |  Esc×2: Cancel")
+		m.viewHelpRendered = m.viewStyleHelp.Render("Alt+Enter: Submit  |  Esc×2: Cancel  |  Ctrl+D: Quit")
 		m.viewHelpWidth = lipgloss.Width(m.viewHelpRendered)
 		m.viewStylesCached = true
 	}
@@ -791,7 +833,9 @@ func (m *TaskInput) View() tea.View {
 	if isNarrow {
 		tipText = m.viewStyleWarning.Render("  ⚠️  Terminal too small - content may be truncated")
 	} else {
-		if time.Now().Before(m.templateTipUntil) {
+		if time.Now().Before(m.copyNoticeUntil) {
+			tipText = m.viewStyleTemplateTip.Render("  " + m.copyNotice)
+		} else if time.Now().Before(m.templateTipUntil) {
 			tipText = m.viewStyleTemplateTip.Render("  Tip: Tab to jump to next ___ placeholder")
 		} else {
 			tipText = m.viewStyleHelp.Render("  Tip: " + m.currentTip)
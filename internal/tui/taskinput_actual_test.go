@@ -4,7 +4,11 @@ import (
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 func TestTaskInput_RenderOptionsPanel_Actual(t *testing.T) {
@@ -73,3 +77,102 @@ func TestTaskInput_RenderOptionsPanel_Actual(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskInput_CycleParent(t *testing.T) {
+	m := NewTaskInputWithOptions([]string{"add-auth", "fix-login"}, true)
+	m.focusPanel = FocusPanelRight
+	m.optField = OptFieldParent
+
+	want := []string{"add-auth", "fix-login", "", "add-auth"}
+	for i, parent := range want {
+		m.handleOptionRight()
+		if m.options.Parent != parent {
+			t.Fatalf("after %d right presses Parent = %q, want %q", i+1, m.options.Parent, parent)
+		}
+	}
+	m.handleOptionLeft()
+	m.handleOptionLeft()
+	if m.options.Parent != "fix-login" {
+		t.Errorf("after left presses Parent = %q, want fix-login", m.options.Parent)
+	}
+
+	m.textareaHeight = 10
+	if panel := m.renderOptionsPanel(); !strings.Contains(panel, "Stack on:") || !strings.Contains(panel, "fix-login") {
+		t.Errorf("options panel does not show the parent:\n%s", panel)
+	}
+}
+
+func TestTaskInput_CycleTaskType(t *testing.T) {
+	m := NewTaskInputWithOptions(nil, true)
+	m.focusPanel = FocusPanelRight
+	m.optField = OptFieldType
+
+	for i, want := range []struct{ pair, research bool }{{true, false}, {false, true}, {false, true}} {
+		m.handleOptionRight()
+		if m.options.Pair != want.pair || m.options.Research != want.research {
+			t.Fatalf("after %d right presses pair=%v research=%v, want %v/%v", i+1, m.options.Pair, m.options.Research, want.pair, want.research)
+		}
+	}
+	m.handleOptionLeft()
+	if !m.options.Pair || m.options.Research {
+		t.Errorf("after left press pair=%v research=%v, want pair", m.options.Pair, m.options.Research)
+	}
+
+	m.textareaHeight = 10
+	if panel := m.renderOptionsPanel(); !strings.Contains(panel, "[pair]") {
+		t.Errorf("options panel does not show the pair type:\n%s", panel)
+	}
+}
+
+func TestTaskInput_ApplyClone(t *testing.T) {
+	m := NewTaskInputWithOptions([]string{"add-auth"}, true)
+	m.focusPanel = FocusPanelKanban
+	m.branchName = "old-branch"
+
+	m.applyClone(service.NewTaskClone("fix-login", "Fix the login form", &config.TaskOptions{
+		Model:        "opus",
+		Research:     true,
+		ContextFiles: []string{"docs/auth.md"},
+		Labels:       []string{"bug"},
+		Parent:       "finished-task",
+	}))
+
+	if got := m.textarea.Value(); got != "Fix the login form" {
+		t.Errorf("content = %q, want the cloned content", got)
+	}
+	if m.focusPanel != FocusPanelLeft {
+		t.Error("cloning should focus the input for editing")
+	}
+	if config.ValidModels()[m.modelIdx] != "opus" || !m.options.Research {
+		t.Errorf("model/type not cloned: model=%s research=%v", config.ValidModels()[m.modelIdx], m.options.Research)
+	}
+	if m.branchName != "" || m.contextIn != "docs/auth.md" || m.labelsIn != "bug" {
+		t.Errorf("option inputs = %q/%q/%q, want empty branch and cloned context/labels", m.branchName, m.contextIn, m.labelsIn)
+	}
+	if m.options.Parent != "" {
+		t.Errorf("Parent = %q, want it dropped when the parent is no longer active", m.options.Parent)
+	}
+}
+
+func TestTaskInput_QuitKeys(t *testing.T) {
+	ctrlD := tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl}
+
+	m := NewTaskInputWithOptions(nil, true)
+	m.textarea.SetValue("half-written task")
+	m.Update(ctrlD)
+	if m.Result().Quit {
+		t.Error("Ctrl+D with content should not quit")
+	}
+
+	m.textarea.SetValue("")
+	if _, cmd := m.Update(ctrlD); cmd == nil || !m.Result().Quit {
+		t.Error("Ctrl+D on an empty input should quit")
+	}
+
+	m = NewTaskInputWithOptions(nil, true)
+	m.switchFocusTo(FocusPanelKanban)
+	m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if !m.Result().Quit {
+		t.Error("q in the Kanban should quit")
+	}
+}
@@ -1,15 +1,19 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 // isCancelPending returns true if we're waiting for the second ESC/Ctrl+C press.
@@ -68,6 +72,7 @@ func (m *TaskInput) Result() TaskInputResult {
 		Content:              strings.TrimSpace(m.textarea.Value()),
 		Options:              m.options,
 		Cancelled:            m.cancelled,
+		Quit:                 m.quit,
 		JumpTarget:           m.jumpTarget,
 		RequestTaskNamePopup: m.requestTaskNamePopup,
 	}
@@ -83,12 +88,24 @@ func (m *TaskInput) SetContent(content string) {
 // checkHistorySelection checks for a history selection file from Ctrl+R picker.
 // If found, it replaces the current content with the selected history item and deletes the file.
 func (m *TaskInput) checkHistorySelection() {
+	m.checkContentSelection(constants.HistorySelectionFile)
+}
+
+// checkPasteSelection checks for a task pasted from the tmux buffer (Alt+V).
+// If found, it replaces the current content with it and deletes the file.
+func (m *TaskInput) checkPasteSelection() {
+	m.checkContentSelection(constants.PasteSelectionFile)
+}
+
+// checkContentSelection replaces the current content with the selection file
+// in the PAW directory, if there is one, and deletes the file.
+func (m *TaskInput) checkContentSelection(name string) {
 	pawDir := m.pawDirPath()
 	if pawDir == "" {
 		return
 	}
 
-	selectionPath := filepath.Join(pawDir, constants.HistorySelectionFile)
+	selectionPath := filepath.Join(pawDir, name)
 	data, err := os.ReadFile(selectionPath) //nolint:gosec // G304: selectionPath is constructed from pawDir
 	if err != nil {
 		// File doesn't exist or can't be read - this is normal (no pending selection)
@@ -108,6 +125,63 @@ func (m *TaskInput) checkHistorySelection() {
 	_ = os.Remove(selectionPath)
 }
 
+// applyClone pre-fills the input with a cloned task's content and options so
+// it can be edited before it is submitted as a new task.
+func (m *TaskInput) applyClone(clone *service.TaskClone) {
+	if clone.Options != nil {
+		opts := clone.Options.Clone()
+		if opts.Parent != "" && !slices.Contains(m.activeTasks, opts.Parent) {
+			// The task it was stacked on has finished; branch off the default base
+			opts.Parent = ""
+		}
+		m.options = opts
+		m.modelIdx = 0
+		for i, model := range config.ValidModels() {
+			if model == opts.Model {
+				m.modelIdx = i
+				break
+			}
+		}
+		m.branchName = ""
+		m.contextIn = strings.Join(opts.ContextFiles, ", ")
+		m.labelsIn = strings.Join(opts.Labels, ", ")
+	}
+	m.textarea.SetValue(clone.Content)
+	m.textarea.CursorEnd()
+	m.updateTextareaHeight()
+	m.persistTemplateDraft()
+	m.switchFocusTo(FocusPanelLeft)
+}
+
+// cloneNotice describes a clone applied to the input.
+func cloneNotice(clone *service.TaskClone) string {
+	if clone.FollowUp {
+		return fmt.Sprintf("↪ Follow-up to %s: describe it and press Alt+Enter", clone.Source)
+	}
+	if clone.Source == "" {
+		return "📋 Cloned task: edit and press Alt+Enter to start it"
+	}
+	return fmt.Sprintf("📋 Cloned %s: edit and press Alt+Enter to start it", clone.Source)
+}
+
+// checkCloneSelection checks for a task cloned with 'paw history clone'.
+// It returns the notice to show if a clone was applied.
+func (m *TaskInput) checkCloneSelection() string {
+	pawDir := m.pawDirPath()
+	if pawDir == "" {
+		return ""
+	}
+	clone, err := service.ReadCloneSelection(pawDir)
+	if err != nil {
+		return "⚠️  " + err.Error()
+	}
+	if clone == nil {
+		return ""
+	}
+	m.applyClone(clone)
+	return cloneNotice(clone)
+}
+
 // checkYaziSelection checks for a yazi file selection file.
 // If found, it appends the selected file path to the current content and deletes the file.
 func (m *TaskInput) checkYaziSelection() {
@@ -279,6 +279,32 @@ func (m *TaskInput) updateKanbanPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "pgdown", "ctrl+d":
 		m.kanban.ScrollDown(5)
 		return m, nil
+	// F: filter the board by the next label (cycles back to all)
+	case "f":
+		m.kanban.CycleLabelFilter()
+		return m, nil
+	// B/W/U/Y: copy the task's branch, worktree path, PR URL, or status line
+	case "b", "w", "u", "y":
+		if task := m.kanban.GetSelectedTask(); task != nil {
+			return m, copyTaskMetadata(task, keyStr)
+		}
+		return m, nil
+	// C: clone the task into the input to edit and start it again
+	case "c":
+		if task := m.kanban.GetSelectedTask(); task != nil {
+			return m, m.cloneTask(task)
+		}
+		return m, nil
+	// N: start a follow-up task that references this one
+	case "n":
+		if task := m.kanban.GetSelectedTask(); task != nil {
+			return m, m.followUpTask(task)
+		}
+		return m, nil
+	// Q: quit the new-task loop
+	case "q":
+		m.quit = true
+		return m, tea.Quit
 	// Enter/Space: jump to selected task
 	case "enter", " ":
 		if task := m.kanban.GetSelectedTask(); task != nil {
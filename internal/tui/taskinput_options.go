@@ -13,7 +13,11 @@ import (
 // Pre-computed padded labels for options panel (avoids fmt.Sprintf per render)
 const (
 	optionLabelModel  = "Model:      " // 12 chars, left-aligned
+	optionLabelType   = "Type:       " // 12 chars, left-aligned
 	optionLabelBranch = "Branch:     " // 12 chars, left-aligned
+	optionLabelCtx    = "Context:    " // 12 chars, left-aligned
+	optionLabelLabels = "Labels:     " // 12 chars, left-aligned
+	optionLabelStack  = "Stack on:   " // 12 chars, left-aligned
 )
 
 // updateOptionsPanel handles key events when the options panel is focused.
@@ -22,44 +26,31 @@ func (m *TaskInput) updateOptionsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	fieldCount := optFieldCount(m.isGitRepo)
 
 	// Handle text input for branch name field (only in git mode)
+	// Only allow valid branch name characters: a-z, 0-9, -, _ (lowercased)
 	if m.isGitRepo && m.optField == OptFieldBranchName {
-		switch keyStr {
-		case "tab", "down":
-			m.applyOptionInputValues()
-			m.optField = OptField((int(m.optField) + 1) % fieldCount)
-			return m, nil
-		case "shift+tab", "up":
-			m.applyOptionInputValues()
-			m.optField = OptField((int(m.optField) - 1 + fieldCount) % fieldCount)
-			return m, nil
-		case "backspace":
-			if len(m.branchName) > 0 {
-				m.branchName = m.branchName[:len(m.branchName)-1]
+		return m.updateOptionText(msg, &m.branchName, 32, func(r rune) (rune, bool) {
+			if r >= 'A' && r <= 'Z' {
+				r += 32
 			}
-			return m, nil
-		case "delete", "ctrl+u":
-			m.branchName = ""
-			return m, nil
-		default:
-			// Accept printable characters for branch name
-			// Only allow valid branch name characters: a-z, 0-9, -, _
-			key := msg.Key()
-			if len(keyStr) == 1 && key.Mod == 0 {
-				r := rune(keyStr[0])
-				if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
-					// Convert to lowercase for branch names
-					if r >= 'A' && r <= 'Z' {
-						r += 32
-					}
-					if len(m.branchName) >= 32 {
-						return m, nil
-					}
-					m.branchName += string(r)
-					return m, nil
-				}
+			return r, (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+		})
+	}
+
+	// Handle text input for extra context files (comma-separated paths)
+	if m.optField == OptFieldContext {
+		return m.updateOptionText(msg, &m.contextIn, 256, func(r rune) (rune, bool) {
+			return r, r >= ' ' && r <= '~'
+		})
+	}
+
+	// Handle text input for labels (comma-separated, lowercased)
+	if m.optField == OptFieldLabels {
+		return m.updateOptionText(msg, &m.labelsIn, 64, func(r rune) (rune, bool) {
+			if r >= 'A' && r <= 'Z' {
+				r += 32
 			}
-		}
-		return m, nil
+			return r, (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == ',' || r == ' '
+		})
 	}
 
 	switch keyStr {
@@ -85,25 +76,108 @@ func (m *TaskInput) updateOptionsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateOptionText edits a text option field. Tab/arrows move between fields;
+// accept filters typed characters (and may transform them).
+func (m *TaskInput) updateOptionText(msg tea.KeyMsg, value *string, maxLen int, accept func(rune) (rune, bool)) (tea.Model, tea.Cmd) {
+	keyStr := msg.String()
+	fieldCount := optFieldCount(m.isGitRepo)
+
+	switch keyStr {
+	case "tab", "down":
+		m.applyOptionInputValues()
+		m.optField = OptField((int(m.optField) + 1) % fieldCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.applyOptionInputValues()
+		m.optField = OptField((int(m.optField) - 1 + fieldCount) % fieldCount)
+		return m, nil
+	case "backspace":
+		if len(*value) > 0 {
+			*value = (*value)[:len(*value)-1]
+		}
+		return m, nil
+	case "delete", "ctrl+u":
+		*value = ""
+		return m, nil
+	case "space":
+		keyStr = " "
+	}
+
+	// Accept printable characters
+	key := msg.Key()
+	if len(keyStr) == 1 && key.Mod == 0 && len(*value) < maxLen {
+		if r, ok := accept(rune(keyStr[0])); ok {
+			*value += string(r)
+		}
+	}
+	return m, nil
+}
+
 // handleOptionLeft handles left arrow key in options panel.
 func (m *TaskInput) handleOptionLeft() {
-	if m.optField == OptFieldModel {
+	switch m.optField {
+	case OptFieldModel:
 		if m.modelIdx > 0 {
 			m.modelIdx--
 			m.options.Model = config.ValidModels()[m.modelIdx]
 		}
+	case OptFieldType:
+		m.setTaskType(m.taskType() - 1)
+	case OptFieldParent:
+		m.cycleParent(-1)
 	}
 }
 
 // handleOptionRight handles right arrow key in options panel.
 func (m *TaskInput) handleOptionRight() {
-	if m.optField == OptFieldModel {
+	switch m.optField {
+	case OptFieldModel:
 		models := config.ValidModels()
 		if m.modelIdx < len(models)-1 {
 			m.modelIdx++
 			m.options.Model = models[m.modelIdx]
 		}
+	case OptFieldType:
+		m.setTaskType(m.taskType() + 1)
+	case OptFieldParent:
+		m.cycleParent(1)
+	}
+}
+
+// taskTypes are the task types in the options panel: code (default), pair
+// (the agent proposes patches), and read-only research.
+var taskTypes = [3]string{"code", "pair", "research"}
+
+// taskType returns the index of the task's type in taskTypes.
+func (m *TaskInput) taskType() int {
+	switch {
+	case m.options.Research:
+		return 2
+	case m.options.Pair:
+		return 1
+	}
+	return 0
+}
+
+// setTaskType sets the task's type by its index in taskTypes, clamped to
+// the first and last types.
+func (m *TaskInput) setTaskType(i int) {
+	i = min(max(i, 0), len(taskTypes)-1)
+	m.options.Pair = i == 1
+	m.options.Research = i == 2
+}
+
+// cycleParent moves the task's parent through none and the active tasks.
+func (m *TaskInput) cycleParent(step int) {
+	choices := append([]string{""}, m.activeTasks...)
+	current := 0
+	for i, name := range choices {
+		if name == m.options.Parent {
+			current = i
+			break
+		}
 	}
+	m.options.Parent = choices[(current+step+len(choices))%len(choices)]
 }
 
 // applyOptionInputValues applies current input values to options.
@@ -112,6 +186,13 @@ func (m *TaskInput) applyOptionInputValues() {
 		return
 	}
 	m.options.BranchName = strings.TrimSpace(m.branchName)
+	m.options.ContextFiles = nil
+	for _, path := range strings.Split(m.contextIn, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			m.options.ContextFiles = append(m.options.ContextFiles, path)
+		}
+	}
+	m.options.Labels = config.ParseLabels(m.labelsIn)
 }
 
 // renderOptionsPanel renders the options panel for the right side.
@@ -151,19 +232,25 @@ func (m *TaskInput) renderOptionsPanel() string {
 	if innerWidth < 20 {
 		innerWidth = 20 // Minimum to display labels
 	}
-	// Pre-allocate lines slice: title + empty + model + branch(if git) + fill lines
+	// Pre-allocate lines slice: title + empty + fields + fill lines
 	lines := make([]string, 0, m.textareaHeight)
+	fields := make([]string, 0, optFieldCount(m.isGitRepo))
 
-	// Title line (use cached styles)
-	if isFocused {
-		lines = append(lines, padToWidth(m.optStyleTitle.Render("Options"), innerWidth))
-	} else {
-		lines = append(lines, padToWidth(m.optStyleTitleDim.Render("Options"), innerWidth))
+	// Title line (use cached styles), dropped when the fields alone fill the textarea height
+	if m.textareaHeight > optFieldCount(m.isGitRepo) {
+		if isFocused {
+			lines = append(lines, padToWidth(m.optStyleTitle.Render("Options"), innerWidth))
+		} else {
+			lines = append(lines, padToWidth(m.optStyleTitleDim.Render("Options"), innerWidth))
+		}
 	}
 
-	// Empty line (from MarginBottom effect)
+	// Empty line (from MarginBottom effect), dropped when the panel
+	// would otherwise be taller than the textarea
 	emptyLine := getPadding(innerWidth)
-	lines = append(lines, emptyLine)
+	if m.textareaHeight > 1+optFieldCount(m.isGitRepo) {
+		lines = append(lines, emptyLine)
+	}
 
 	// Model field (use cached styles)
 	{
@@ -187,39 +274,56 @@ func (m *TaskInput) renderOptionsPanel() string {
 			}
 		}
 		modelLine := label + strings.Join(parts, "")
-		lines = append(lines, padToWidth(modelLine, innerWidth))
+		fields = append(fields, padToWidth(modelLine, innerWidth))
 	}
 
-	// Branch name field (only in git mode, use cached styles)
-	if m.isGitRepo {
-		isSelected := isFocused && m.optField == OptFieldBranchName
-		label := m.optStyleLabel.Render(optionLabelBranch)
+	// Type field: code (default), pair, or read-only research (use cached styles)
+	{
+		isSelected := isFocused && m.optField == OptFieldType
+		label := m.optStyleLabel.Render(optionLabelType)
 		if isSelected {
-			label = m.optStyleSelectedLabel.Render(optionLabelBranch)
+			label = m.optStyleSelectedLabel.Render(optionLabelType)
 		}
 
-		branchValue := m.branchName
-		branchStyle := m.optStyleValue
-		if branchValue == "" {
-			branchValue = "auto"
-			branchStyle = m.optStyleDim
-		}
-		if isSelected {
-			branchStyle = m.optStyleSelectedValue
+		current := m.taskType()
+		parts := make([]string, 0, len(taskTypes))
+		for i, typ := range taskTypes {
+			if i == current {
+				if isSelected {
+					parts = append(parts, m.optStyleSelectedValue.Render("["+typ+"]"))
+				} else {
+					parts = append(parts, m.optStyleValue.Render("["+typ+"]"))
+				}
+			} else {
+				parts = append(parts, m.optStyleDim.Render(" "+typ+" "))
+			}
 		}
+		fields = append(fields, padToWidth(label+strings.Join(parts, ""), innerWidth))
+	}
 
-		availableWidth := innerWidth - len(optionLabelBranch)
-		if availableWidth < 0 {
-			availableWidth = 0
-		}
-		if availableWidth > 0 && lipgloss.Width(branchValue) > availableWidth {
-			branchValue = ansi.Truncate(branchValue, availableWidth, "")
-		}
+	// Extra context files field (use cached styles)
+	fields = append(fields, m.renderOptionText(optionLabelCtx, m.contextIn, "none", isFocused && m.optField == OptFieldContext, innerWidth))
+
+	// Labels field (use cached styles)
+	fields = append(fields, m.renderOptionText(optionLabelLabels, m.labelsIn, "none", isFocused && m.optField == OptFieldLabels, innerWidth))
 
-		branchLine := label + branchStyle.Render(branchValue)
-		lines = append(lines, padToWidth(branchLine, innerWidth))
+	// Branch name field (only in git mode, use cached styles)
+	if m.isGitRepo {
+		fields = append(fields, m.renderOptionText(optionLabelBranch, m.branchName, "auto", isFocused && m.optField == OptFieldBranchName, innerWidth))
+		fields = append(fields, m.renderOptionText(optionLabelStack, m.options.Parent, "none", isFocused && m.optField == OptFieldParent, innerWidth))
 	}
 
+	// When the textarea is shorter than the field list, scroll the fields so
+	// the selected one stays visible
+	if hidden := len(fields) - m.textareaHeight; hidden > 0 {
+		start := 0
+		if isFocused {
+			start = min(hidden, max(0, int(m.optField)-m.textareaHeight+1))
+		}
+		fields = fields[start : start+m.textareaHeight]
+	}
+	lines = append(lines, fields...)
+
 	// Fill remaining height with empty lines (reuse cached padding)
 	for len(lines) < m.textareaHeight {
 		lines = append(lines, emptyLine)
@@ -233,3 +337,31 @@ func (m *TaskInput) renderOptionsPanel() string {
 
 	return panelStyle.Render(strings.Join(lines, "\n"))
 }
+
+// renderOptionText renders a text option line, showing placeholder (dimmed) when empty.
+// Long values keep their end visible so the cursor position stays in view.
+func (m *TaskInput) renderOptionText(labelText, value, placeholder string, isSelected bool, innerWidth int) string {
+	label := m.optStyleLabel.Render(labelText)
+	if isSelected {
+		label = m.optStyleSelectedLabel.Render(labelText)
+	}
+
+	style := m.optStyleValue
+	if value == "" {
+		value = placeholder
+		style = m.optStyleDim
+	}
+	if isSelected {
+		style = m.optStyleSelectedValue
+	}
+
+	availableWidth := innerWidth - len(labelText)
+	if availableWidth < 0 {
+		availableWidth = 0
+	}
+	if availableWidth > 0 && lipgloss.Width(value) > availableWidth {
+		value = ansi.TruncateLeft(value, lipgloss.Width(value)-availableWidth, "")
+	}
+
+	return padToWidth(label+style.Render(value), innerWidth)
+}
@@ -9,6 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 // Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
@@ -22,6 +24,7 @@ const (
 // TaskViewer provides an interactive task content viewer with vim-like navigation.
 type TaskViewer struct {
 	lines         []string
+	contentLines  []string // Task content (lines shows either this or the timeline)
 	scrollPos     int
 	horizontalPos int
 	width         int
@@ -37,6 +40,10 @@ type TaskViewer struct {
 	selectEndY   int // End row (screen-relative)
 	selectEndX   int // End column (screen-relative)
 
+	// Optional tool-call timeline (toggled with 't')
+	tl           *timelineState
+	showTimeline bool
+
 	// Style cache (reused across renders)
 	styleHighlight lipgloss.Style
 	styleStatus    lipgloss.Style
@@ -55,9 +62,10 @@ func NewTaskViewer(content string) *TaskViewer {
 	isDark := DetectDarkMode()
 
 	return &TaskViewer{
-		lines:  lines,
-		isDark: isDark,
-		colors: NewThemeColors(isDark),
+		lines:        lines,
+		contentLines: lines,
+		isDark:       isDark,
+		colors:       NewThemeColors(isDark),
 	}
 }
 
@@ -127,6 +135,10 @@ func (m *TaskViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey handles keyboard input.
 func (m *TaskViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.showTimeline && m.handleTimelineKey(msg) {
+		return m, nil
+	}
+
 	switch msg.String() {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
@@ -139,6 +151,9 @@ func (m *TaskViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "q", "esc":
 		return m, tea.Quit
 
+	case "t":
+		m.toggleTimeline()
+
 	case "down", "j":
 		m.scrollDown(1)
 
@@ -257,6 +272,11 @@ func (m *TaskViewer) View() tea.View {
 			line += getPadding(m.width - lineWidth)
 		}
 
+		// Highlight the selected timeline event
+		if m.isTimelineCursorLine(i) {
+			line = m.styleHighlight.Render(ansi.Strip(line))
+		}
+
 		// Apply selection highlighting if this line is in selection
 		if m.hasSelection {
 			line = m.applySelectionToLine(line, screenY, m.styleHighlight)
@@ -274,16 +294,26 @@ func (m *TaskViewer) View() tea.View {
 	}
 
 	// Status bar
+	label := " Task "
+	if m.showTimeline {
+		label = " Timeline "
+	}
 	var status string
 	if len(m.lines) > 0 {
-		status = " Task " + strconv.Itoa(m.scrollPos+1) + "-" + strconv.Itoa(endPos) + " of " + strconv.Itoa(len(m.lines)) + " lines "
+		status = label + strconv.Itoa(m.scrollPos+1) + "-" + strconv.Itoa(endPos) + " of " + strconv.Itoa(len(m.lines)) + " lines "
 	} else {
 		status = " (empty) "
 	}
 
 	// Keybindings hint (use pre-computed widths to avoid ansi.StringWidth on each render)
-	hint := taskViewerHintFull
-	padding := m.width - len(status) - taskViewerHintFullWidth
+	hint, hintWidth := taskViewerHintFull, taskViewerHintFullWidth
+	switch {
+	case m.showTimeline:
+		hint, hintWidth = taskViewerHintTimeline, taskViewerHintTimelineWidth
+	case m.tl != nil:
+		hint, hintWidth = taskViewerHintWithTimeline, taskViewerHintWithTLWidth
+	}
+	padding := m.width - len(status) - hintWidth
 	if padding < 0 {
 		hint = taskViewerHintShort
 		padding = m.width - len(status) - taskViewerHintShortWidth
@@ -458,8 +488,10 @@ func (m *TaskViewer) copySelection() {
 }
 
 // RunTaskViewer runs the task viewer with the given content.
-func RunTaskViewer(content string) error {
+// If timeline is non-nil, it can be toggled with 't'.
+func RunTaskViewer(content string, timeline *service.Timeline) error {
 	m := NewTaskViewer(content)
+	m.SetTimeline(timeline)
 	p := tea.NewProgram(m)
 	_, err := p.Run()
 	return err
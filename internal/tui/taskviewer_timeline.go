@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// Pre-computed status bar hints for timeline mode
+const (
+	taskViewerHintTimeline      = "↑↓j/k:move enter:toggle e/c:expand/collapse all t:task q:close"
+	taskViewerHintTimelineWidth = 62 // display width of taskViewerHintTimeline
+	taskViewerHintWithTimeline  = "↑↓j/k:scroll g/G:top/end t:timeline q/Esc:close"
+	taskViewerHintWithTLWidth   = 47 // display width of taskViewerHintWithTimeline
+)
+
+// timelineState holds the collapsible timeline view of a task viewer.
+type timelineState struct {
+	timeline *service.Timeline
+	expanded []bool
+	cursor   int   // Selected event index
+	lineIdx  []int // First rendered line index for each event
+}
+
+// SetTimeline attaches a tool-call timeline to the viewer.
+// When set, 't' toggles between the task content and the timeline.
+func (m *TaskViewer) SetTimeline(tl *service.Timeline) {
+	if tl == nil {
+		return
+	}
+	m.tl = &timelineState{
+		timeline: tl,
+		expanded: make([]bool, len(tl.Events)),
+	}
+}
+
+// toggleTimeline switches between task content and timeline view.
+func (m *TaskViewer) toggleTimeline() {
+	if m.tl == nil {
+		return
+	}
+	m.showTimeline = !m.showTimeline
+	m.scrollPos = 0
+	m.horizontalPos = 0
+	m.hasSelection = false
+	if m.showTimeline {
+		m.rebuildTimelineLines()
+	} else {
+		m.lines = m.contentLines
+	}
+}
+
+// rebuildTimelineLines renders the timeline into m.lines based on expand state.
+func (m *TaskViewer) rebuildTimelineLines() {
+	tl := m.tl.timeline
+	lines := make([]string, 0, len(tl.Events)+2)
+	lines = append(lines, timelineHeader(tl), "")
+
+	m.tl.lineIdx = m.tl.lineIdx[:0]
+	for i, ev := range tl.Events {
+		m.tl.lineIdx = append(m.tl.lineIdx, len(lines))
+		lines = append(lines, timelineEventLine(ev, m.tl.expanded[i]))
+		if m.tl.expanded[i] {
+			for _, out := range ev.Output {
+				lines = append(lines, "      "+out)
+			}
+		}
+	}
+	if len(tl.Events) == 0 {
+		lines = append(lines, "  (no tool calls recorded)")
+	}
+	m.lines = lines
+}
+
+// timelineHeader builds the one-line timeline summary.
+func timelineHeader(tl *service.Timeline) string {
+	return " Timeline: " + strconv.Itoa(len(tl.Events)) + " events · " +
+		strconv.Itoa(len(tl.FilesEdited())) + " files edited · " +
+		strconv.Itoa(len(tl.Commands())) + " commands · " +
+		strconv.Itoa(len(tl.Errors())) + " errors"
+}
+
+// timelineEventLine renders the header line of a single event.
+func timelineEventLine(ev service.TimelineEvent, expanded bool) string {
+	marker := "  "
+	if len(ev.Output) > 0 {
+		marker = "▸ "
+		if expanded {
+			marker = "▾ "
+		}
+	}
+
+	var icon string
+	switch ev.Kind {
+	case service.TimelineEdit:
+		icon = "✎ "
+	case service.TimelineCommand:
+		icon = "$ "
+	case service.TimelineMessage:
+		icon = "⏺ "
+	case service.TimelineInterrupt:
+		icon = "⏸ "
+	default:
+		icon = "• "
+	}
+
+	line := " " + marker + icon
+	if ev.Tool != "" {
+		line += ev.Tool + " "
+	}
+	line += ev.Detail
+	if ev.Error {
+		line += "  ✗"
+	}
+	return line
+}
+
+// handleTimelineKey handles keys specific to timeline mode.
+// Returns true if the key was consumed.
+func (m *TaskViewer) handleTimelineKey(msg tea.KeyMsg) bool {
+	events := m.tl.timeline.Events
+	if len(events) == 0 {
+		return false
+	}
+
+	switch msg.String() {
+	case "down", "j":
+		if m.tl.cursor < len(events)-1 {
+			m.tl.cursor++
+		}
+	case "up", "k":
+		if m.tl.cursor > 0 {
+			m.tl.cursor--
+		}
+	case "g", "home":
+		m.tl.cursor = 0
+	case "G", "end":
+		m.tl.cursor = len(events) - 1
+	case "enter", "tab":
+		m.tl.expanded[m.tl.cursor] = !m.tl.expanded[m.tl.cursor]
+		m.rebuildTimelineLines()
+	case "e":
+		m.setAllExpanded(true)
+	case "c":
+		m.setAllExpanded(false)
+	default:
+		return false
+	}
+
+	m.ensureTimelineCursorVisible()
+	return true
+}
+
+// setAllExpanded expands or collapses every event.
+func (m *TaskViewer) setAllExpanded(expanded bool) {
+	for i := range m.tl.expanded {
+		m.tl.expanded[i] = expanded
+	}
+	m.rebuildTimelineLines()
+}
+
+// ensureTimelineCursorVisible scrolls so the selected event is on screen.
+func (m *TaskViewer) ensureTimelineCursorVisible() {
+	if m.tl.cursor >= len(m.tl.lineIdx) {
+		return
+	}
+	line := m.tl.lineIdx[m.tl.cursor]
+	if line < m.scrollPos {
+		m.scrollPos = line
+	} else if line >= m.scrollPos+m.contentHeight() {
+		m.scrollPos = line - m.contentHeight() + 1
+	}
+	if m.tl.cursor == 0 {
+		m.scrollPos = 0
+	}
+}
+
+// isTimelineCursorLine reports whether line index i is the selected event header.
+func (m *TaskViewer) isTimelineCursorLine(i int) bool {
+	if !m.showTimeline || m.tl == nil || m.tl.cursor >= len(m.tl.lineIdx) {
+		return false
+	}
+	return m.tl.lineIdx[m.tl.cursor] == i
+}
@@ -43,6 +43,8 @@ var tips = []string{
 	// Mouse interactions
 	"Use mouse to select and copy text",
 	"Click on a task in kanban to jump to it",
+	"In a kanban column, press b/w/u/y to copy a task's branch/worktree/PR URL/status",
+	"Press c on a kanban task to clone it into the input and re-run it with tweaks",
 	"Scroll with mouse wheel in kanban",
 	"Drag pane borders to resize",
 
@@ -58,6 +60,7 @@ var tips = []string{
 	"Run 'paw check' to verify dependencies",
 	"Run 'paw attach' to reconnect sessions",
 	"Run 'paw history' to review past work",
+	"Set cleanup_policy: delayed to keep finished worktrees in .paw/parked/",
 }
 
 // versionHashRegex matches the git hash suffix in version strings.